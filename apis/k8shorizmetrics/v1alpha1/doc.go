@@ -0,0 +1,21 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains the v1alpha1 custom resource types of the k8shorizmetrics.com API group, allowing operators
+// built on this library to persist gathered metrics and scaling recommendations as cluster objects for auditing.
+// +kubebuilder:object:generate=true
+// +groupName=k8shorizmetrics.com
+package v1alpha1
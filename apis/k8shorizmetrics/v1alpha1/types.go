@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=msnap
+// +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.scaleTargetRef.name`
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.spec.currentReplicas`
+// +kubebuilder:printcolumn:name="Gathered",type=date,JSONPath=`.spec.gatheredTime`
+
+// MetricSnapshot is a record of the metrics gathered for a scale target at a point in time.
+type MetricSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MetricSnapshotSpec `json:"spec"`
+}
+
+// MetricSnapshotSpec is the content of a MetricSnapshot.
+type MetricSnapshotSpec struct {
+	// ScaleTargetRef is the resource the metrics were gathered for.
+	ScaleTargetRef autoscalingv2.CrossVersionObjectReference `json:"scaleTargetRef"`
+	// GatheredTime is when the metrics were gathered.
+	GatheredTime metav1.Time `json:"gatheredTime"`
+	// CurrentReplicas is the replica count of the scale target when the metrics were gathered.
+	CurrentReplicas int32 `json:"currentReplicas"`
+	// Metrics are the metrics gathered, in the same order as the metric specs they were gathered for.
+	// +optional
+	Metrics []GatheredMetric `json:"metrics,omitempty"`
+}
+
+// GatheredMetric is a single gathered metric.
+type GatheredMetric struct {
+	// Spec is the metric spec the metric was gathered for.
+	Spec autoscalingv2.MetricSpec `json:"spec"`
+	// Current is the current value of the metric, as the HPA would report it in its status. Not set if the metric
+	// failed to be gathered.
+	// +optional
+	Current *autoscalingv2.MetricStatus `json:"current,omitempty"`
+	// ReadyPodCount is the number of ready pods the metric was gathered from.
+	// +optional
+	ReadyPodCount *int64 `json:"readyPodCount,omitempty"`
+	// Stale is set if the metric was older than the gatherer's maximum age.
+	// +optional
+	Stale bool `json:"stale,omitempty"`
+	// Error is the reason the metric failed to be gathered.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MetricSnapshotList is a list of MetricSnapshots.
+type MetricSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []MetricSnapshot `json:"items"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=srec
+// +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.scaleTargetRef.name`
+// +kubebuilder:printcolumn:name="Current",type=integer,JSONPath=`.spec.currentReplicas`
+// +kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=`.spec.desiredReplicas`
+// +kubebuilder:printcolumn:name="Evaluated",type=date,JSONPath=`.spec.evaluatedTime`
+
+// ScalingRecommendation is a record of the replica count recommended for a scale target by evaluating its metrics.
+type ScalingRecommendation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScalingRecommendationSpec `json:"spec"`
+}
+
+// ScalingRecommendationSpec is the content of a ScalingRecommendation.
+type ScalingRecommendationSpec struct {
+	// ScaleTargetRef is the resource the recommendation is for.
+	ScaleTargetRef autoscalingv2.CrossVersionObjectReference `json:"scaleTargetRef"`
+	// EvaluatedTime is when the metrics were evaluated.
+	EvaluatedTime metav1.Time `json:"evaluatedTime"`
+	// CurrentReplicas is the replica count of the scale target when the metrics were evaluated.
+	CurrentReplicas int32 `json:"currentReplicas"`
+	// DesiredReplicas is the recommended replica count.
+	DesiredReplicas int32 `json:"desiredReplicas"`
+	// Metrics are the evaluations of each metric, in the same order as the metrics evaluated.
+	// +optional
+	Metrics []MetricRecommendation `json:"metrics,omitempty"`
+}
+
+// MetricRecommendation is the evaluation of a single metric as part of a ScalingRecommendation.
+type MetricRecommendation struct {
+	// Spec is the metric spec of the metric evaluated.
+	Spec autoscalingv2.MetricSpec `json:"spec"`
+	// Replicas is the replica count proposed by the metric.
+	Replicas int32 `json:"replicas"`
+	// UsageRatio is the ratio of the metric's current usage to its target, a ratio above 1 means usage is above the
+	// target.
+	// +optional
+	UsageRatio *resource.Quantity `json:"usageRatio,omitempty"`
+	// Winning is set for the metric which proposed the recommended replica count.
+	// +optional
+	Winning bool `json:"winning,omitempty"`
+	// Error is the reason the metric failed to be evaluated.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScalingRecommendationList is a list of ScalingRecommendations.
+type ScalingRecommendationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ScalingRecommendation `json:"items"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=shist
+// +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.scaleTargetRef.name`
+// +kubebuilder:printcolumn:name="Last Scale Up",type=date,JSONPath=`.spec.lastScaleUp`
+// +kubebuilder:printcolumn:name="Last Scale Down",type=date,JSONPath=`.spec.lastScaleDown`
+
+// ScalingHistory is the scaling history of a scale target, persisted so downscale stabilization and cooldowns survive
+// restarts of the autoscaler.
+type ScalingHistory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScalingHistorySpec `json:"spec"`
+}
+
+// ScalingHistorySpec is the content of a ScalingHistory.
+type ScalingHistorySpec struct {
+	// ScaleTargetRef is the resource the history is for.
+	ScaleTargetRef autoscalingv2.CrossVersionObjectReference `json:"scaleTargetRef"`
+	// Recommendations are the replica counts recently recommended for the scale target, oldest first.
+	// +optional
+	Recommendations []HistoricRecommendation `json:"recommendations,omitempty"`
+	// LastScaleUp is when the scale target was last scaled up.
+	// +optional
+	LastScaleUp *metav1.Time `json:"lastScaleUp,omitempty"`
+	// LastScaleDown is when the scale target was last scaled down.
+	// +optional
+	LastScaleDown *metav1.Time `json:"lastScaleDown,omitempty"`
+}
+
+// HistoricRecommendation is a replica count recommended at a point in time.
+type HistoricRecommendation struct {
+	// Time is when the replica count was recommended.
+	Time metav1.Time `json:"time"`
+	// Replicas is the recommended replica count.
+	Replicas int32 `json:"replicas"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScalingHistoryList is a list of ScalingHistories.
+type ScalingHistoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ScalingHistory `json:"items"`
+}
@@ -0,0 +1,510 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Modifications Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+
+Modified to constrain k8shorizmetrics evaluations using HPA scaling behavior outside of the HPA controller.
+Original source:
+https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscaler/horizontal.go
+https://github.com/kubernetes/kubernetes/blob/master/pkg/apis/autoscaling/v2/defaults.go
+*/
+
+// Package behavior constrains replica count recommendations using the autoscaling/v2 HorizontalPodAutoscaler
+// behavior field in the same way the HPA controller does. Recommendations are stabilized against the recommendations
+// made within the scale up and scale down stabilization windows, and the rate of scaling is limited by the scale up
+// and scale down policies, taking into account the scale events recorded within each policy's period.
+//
+// The Normalizer holds the recommendation and scale event history for each target, so a single Normalizer should be
+// shared between evaluations of the same target.
+package behavior
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/utils/clock"
+)
+
+// DefaultDownscaleStabilizationWindow is the default scale down stabilization window used when the behavior does not
+// set one, matches the HPA's --horizontal-pod-autoscaler-downscale-stabilization default
+const DefaultDownscaleStabilizationWindow = 5 * time.Minute
+
+type timestampedRecommendation struct {
+	recommendation int32
+	timestamp      time.Time
+}
+
+type timestampedScaleEvent struct {
+	replicaChange int32
+	timestamp     time.Time
+	outdated      bool
+}
+
+// Normalizer constrains replica count recommendations for targets using their scaling behavior, keeping a history of
+// recommendations and scale events for each target, keyed by a string identifying the target.
+// DownscaleStabilizationWindow is used for any scale down rules without a stabilization window set. Clock is used to
+// timestamp recommendations and scale events, falling back to the real clock if nil.
+type Normalizer struct {
+	DownscaleStabilizationWindow time.Duration
+	Clock                        clock.PassiveClock
+
+	mu              sync.Mutex
+	recommendations map[string][]timestampedRecommendation
+	scaleUpEvents   map[string][]timestampedScaleEvent
+	scaleDownEvents map[string][]timestampedScaleEvent
+}
+
+// NewNormalizer sets up a Normalizer using the HPA's default downscale stabilization window
+func NewNormalizer() *Normalizer {
+	return &Normalizer{
+		DownscaleStabilizationWindow: DefaultDownscaleStabilizationWindow,
+		Clock:                        clock.RealClock{},
+	}
+}
+
+// Normalization is a desired replica count constrained by scaling behavior. StabilizedReplicas is the desired replica
+// count after stabilization and Replicas is the replica count after the scaling policies and the minimum and maximum
+// replica counts are applied. StabilizationReason and LimitReason are the reasons the HPA reports on its AbleToScale
+// and ScalingLimited conditions for each step.
+type Normalization struct {
+	Replicas            int32                  `json:"replicas"`
+	StabilizedReplicas  int32                  `json:"stabilizedReplicas"`
+	StabilizationReason k8shorizmetrics.Reason `json:"stabilizationReason"`
+	LimitReason         k8shorizmetrics.Reason `json:"limitReason"`
+}
+
+// Normalize constrains the desired replica count for the target using the behavior provided, recording the desired
+// replica count in the target's recommendation history. The desired replica count is first stabilized using the
+// scale up and scale down stabilization windows, then limited by the scaling policies and the minimum and maximum
+// replica counts. If behavior is nil the HPA's default behavior is used.
+func (n *Normalizer) Normalize(key string, behavior *autoscalingv2.HorizontalPodAutoscalerBehavior, minReplicas int32,
+	maxReplicas int32, currentReplicas int32, desiredReplicas int32) int32 {
+	return n.NormalizeWithReasons(key, behavior, minReplicas, maxReplicas, currentReplicas, desiredReplicas).Replicas
+}
+
+// NormalizeWithReasons constrains the desired replica count for the target in the same way as Normalize, returning
+// the reasons the HPA would report for the stabilization and limiting of the desired replica count
+func (n *Normalizer) NormalizeWithReasons(key string, behavior *autoscalingv2.HorizontalPodAutoscalerBehavior,
+	minReplicas int32, maxReplicas int32, currentReplicas int32, desiredReplicas int32) *Normalization {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	behavior = n.withDefaults(behavior)
+	now := n.clock().Now()
+
+	stabilizedRecommendation := n.stabilizeRecommendation(now, key, behavior, currentReplicas, desiredReplicas)
+	stabilizationReason := k8shorizmetrics.ReasonReadyForNewScale
+	if stabilizedRecommendation < desiredReplicas {
+		stabilizationReason = k8shorizmetrics.ReasonScaleUpStabilized
+	} else if stabilizedRecommendation > desiredReplicas {
+		stabilizationReason = k8shorizmetrics.ReasonScaleDownStabilized
+	}
+
+	replicas, limitReason := n.convertDesiredReplicasWithRate(now, key, behavior, minReplicas, maxReplicas,
+		currentReplicas, stabilizedRecommendation)
+
+	return &Normalization{
+		Replicas:            replicas,
+		StabilizedReplicas:  stabilizedRecommendation,
+		StabilizationReason: stabilizationReason,
+		LimitReason:         limitReason,
+	}
+}
+
+// RecordScale records a scale event for the target, changing from the previous replica count to the new replica count.
+// Scale events are used to limit the rate of scaling by the scaling policies, so this should be called whenever the
+// target is scaled. Scale events older than the longest policy period in the behavior are replaced.
+func (n *Normalizer) RecordScale(key string, behavior *autoscalingv2.HorizontalPodAutoscalerBehavior,
+	previousReplicas int32, newReplicas int32) {
+	if previousReplicas == newReplicas {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	behavior = n.withDefaults(behavior)
+	now := n.clock().Now()
+
+	if n.scaleUpEvents == nil {
+		n.scaleUpEvents = map[string][]timestampedScaleEvent{}
+		n.scaleDownEvents = map[string][]timestampedScaleEvent{}
+	}
+
+	if newReplicas > previousReplicas {
+		n.scaleUpEvents[key] = storeScaleEvent(now, n.scaleUpEvents[key], getLongestPolicyPeriod(behavior.ScaleUp),
+			newReplicas-previousReplicas)
+		return
+	}
+
+	n.scaleDownEvents[key] = storeScaleEvent(now, n.scaleDownEvents[key], getLongestPolicyPeriod(behavior.ScaleDown),
+		previousReplicas-newReplicas)
+}
+
+// Forget discards the recommendation and scale event history recorded for the target, for example when the target is
+// deleted
+func (n *Normalizer) Forget(key string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.recommendations, key)
+	delete(n.scaleUpEvents, key)
+	delete(n.scaleDownEvents, key)
+}
+
+func (n *Normalizer) clock() clock.PassiveClock {
+	if n.Clock == nil {
+		return clock.RealClock{}
+	}
+	return n.Clock
+}
+
+// withDefaults returns a copy of the behavior with any fields not set defaulted in the same way the K8s API server
+// defaults them, with the scale down stabilization window defaulted to the DownscaleStabilizationWindow
+func (n *Normalizer) withDefaults(behavior *autoscalingv2.HorizontalPodAutoscalerBehavior) *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	defaulted := &autoscalingv2.HorizontalPodAutoscalerBehavior{}
+	if behavior != nil {
+		defaulted = behavior.DeepCopy()
+	}
+
+	defaulted.ScaleUp = generateScaleUpRules(defaulted.ScaleUp)
+	defaulted.ScaleDown = generateScaleDownRules(defaulted.ScaleDown)
+	if defaulted.ScaleDown.StabilizationWindowSeconds == nil {
+		downscaleStabilizationWindowSeconds := int32(n.DownscaleStabilizationWindow.Seconds())
+		defaulted.ScaleDown.StabilizationWindowSeconds = &downscaleStabilizationWindowSeconds
+	}
+
+	return defaulted
+}
+
+// stabilizeRecommendation brings the current replica count within the lowest recommendation in the scale up
+// stabilization window and the highest recommendation in the scale down stabilization window, recording the desired
+// replica count as a new recommendation
+func (n *Normalizer) stabilizeRecommendation(now time.Time, key string,
+	behavior *autoscalingv2.HorizontalPodAutoscalerBehavior, currentReplicas int32, desiredReplicas int32) int32 {
+	foundOldSample := false
+	oldSampleIndex := 0
+
+	upRecommendation := desiredReplicas
+	upCutoff := now.Add(-time.Second * time.Duration(*behavior.ScaleUp.StabilizationWindowSeconds))
+
+	downRecommendation := desiredReplicas
+	downCutoff := now.Add(-time.Second * time.Duration(*behavior.ScaleDown.StabilizationWindowSeconds))
+
+	if n.recommendations == nil {
+		n.recommendations = map[string][]timestampedRecommendation{}
+	}
+
+	// Calculate the upper and lower stabilization limits
+	for i, rec := range n.recommendations[key] {
+		if rec.timestamp.After(upCutoff) {
+			upRecommendation = min(rec.recommendation, upRecommendation)
+		}
+		if rec.timestamp.After(downCutoff) {
+			downRecommendation = max(rec.recommendation, downRecommendation)
+		}
+		if rec.timestamp.Before(upCutoff) && rec.timestamp.Before(downCutoff) {
+			foundOldSample = true
+			oldSampleIndex = i
+		}
+	}
+
+	// Bring the recommendation to within the upper and lower limits (stabilize)
+	recommendation := currentReplicas
+	if recommendation < upRecommendation {
+		recommendation = upRecommendation
+	}
+	if recommendation > downRecommendation {
+		recommendation = downRecommendation
+	}
+
+	// Record the unstabilized recommendation
+	newRecommendation := timestampedRecommendation{
+		recommendation: desiredReplicas,
+		timestamp:      now,
+	}
+	if foundOldSample {
+		n.recommendations[key][oldSampleIndex] = newRecommendation
+	} else {
+		n.recommendations[key] = append(n.recommendations[key], newRecommendation)
+	}
+
+	return recommendation
+}
+
+// convertDesiredReplicasWithRate limits the desired replica count using the scaling policies and the minimum and
+// maximum replica counts, returning the limited replica count and the reason it was limited
+func (n *Normalizer) convertDesiredReplicasWithRate(now time.Time, key string,
+	behavior *autoscalingv2.HorizontalPodAutoscalerBehavior, minReplicas int32, maxReplicas int32,
+	currentReplicas int32, desiredReplicas int32) (int32, k8shorizmetrics.Reason) {
+	if desiredReplicas > currentReplicas {
+		scaleUpLimit := calculateScaleUpLimit(now, currentReplicas, n.scaleUpEvents[key], n.scaleDownEvents[key],
+			behavior.ScaleUp)
+		if scaleUpLimit < currentReplicas {
+			// We shouldn't scale up further until the scale up events will be cleaned up
+			scaleUpLimit = currentReplicas
+		}
+		maximumAllowedReplicas := maxReplicas
+		possibleLimitingReason := k8shorizmetrics.ReasonTooManyReplicas
+		if maximumAllowedReplicas > scaleUpLimit {
+			maximumAllowedReplicas = scaleUpLimit
+			possibleLimitingReason = k8shorizmetrics.ReasonScaleUpLimit
+		}
+		if desiredReplicas > maximumAllowedReplicas {
+			return maximumAllowedReplicas, possibleLimitingReason
+		}
+	} else if desiredReplicas < currentReplicas {
+		scaleDownLimit := calculateScaleDownLimit(now, currentReplicas, n.scaleUpEvents[key], n.scaleDownEvents[key],
+			behavior.ScaleDown)
+		if scaleDownLimit > currentReplicas {
+			// We shouldn't scale down further until the scale down events will be cleaned up
+			scaleDownLimit = currentReplicas
+		}
+		minimumAllowedReplicas := minReplicas
+		possibleLimitingReason := k8shorizmetrics.ReasonTooFewReplicas
+		if minimumAllowedReplicas < scaleDownLimit {
+			minimumAllowedReplicas = scaleDownLimit
+			possibleLimitingReason = k8shorizmetrics.ReasonScaleDownLimit
+		}
+		if desiredReplicas < minimumAllowedReplicas {
+			return minimumAllowedReplicas, possibleLimitingReason
+		}
+	}
+
+	return desiredReplicas, k8shorizmetrics.ReasonDesiredWithinRange
+}
+
+// getReplicasChangePerPeriod returns the total replica change of the scale events within the period
+func getReplicasChangePerPeriod(now time.Time, periodSeconds int32, scaleEvents []timestampedScaleEvent) int32 {
+	period := time.Second * time.Duration(periodSeconds)
+	cutoff := now.Add(-period)
+	var replicas int32
+	for _, rec := range scaleEvents {
+		if rec.timestamp.After(cutoff) {
+			replicas += rec.replicaChange
+		}
+	}
+	return replicas
+}
+
+// calculateScaleUpLimit returns the maximum number of replicas allowed by the scale up rules
+func calculateScaleUpLimit(now time.Time, currentReplicas int32, scaleUpEvents []timestampedScaleEvent,
+	scaleDownEvents []timestampedScaleEvent, scalingRules *autoscalingv2.HPAScalingRules) int32 {
+	var result int32
+	var proposed int32
+	var selectPolicyFn func(int32, int32) int32
+	if *scalingRules.SelectPolicy == autoscalingv2.DisabledPolicySelect {
+		// Scaling is disabled
+		return currentReplicas
+	} else if *scalingRules.SelectPolicy == autoscalingv2.MinChangePolicySelect {
+		result = math.MaxInt32
+		// For scaling up, the lowest change ('min' policy) produces a minimum value
+		selectPolicyFn = minInt32
+	} else {
+		result = math.MinInt32
+		// Use the default policy otherwise to produce a highest possible change
+		selectPolicyFn = maxInt32
+	}
+	for _, policy := range scalingRules.Policies {
+		replicasAddedInCurrentPeriod := getReplicasChangePerPeriod(now, policy.PeriodSeconds, scaleUpEvents)
+		replicasDeletedInCurrentPeriod := getReplicasChangePerPeriod(now, policy.PeriodSeconds, scaleDownEvents)
+		periodStartReplicas := currentReplicas - replicasAddedInCurrentPeriod + replicasDeletedInCurrentPeriod
+		if policy.Type == autoscalingv2.PodsScalingPolicy {
+			proposed = periodStartReplicas + policy.Value
+		} else if policy.Type == autoscalingv2.PercentScalingPolicy {
+			// The proposal has to be rounded up because the proposed change might not increase the replica count
+			// causing the target to never scale up
+			proposed = int32(math.Ceil(float64(periodStartReplicas) * (1 + float64(policy.Value)/100)))
+		}
+		result = selectPolicyFn(result, proposed)
+	}
+	return result
+}
+
+// calculateScaleDownLimit returns the minimum number of replicas allowed by the scale down rules
+func calculateScaleDownLimit(now time.Time, currentReplicas int32, scaleUpEvents []timestampedScaleEvent,
+	scaleDownEvents []timestampedScaleEvent, scalingRules *autoscalingv2.HPAScalingRules) int32 {
+	var result int32
+	var proposed int32
+	var selectPolicyFn func(int32, int32) int32
+	if *scalingRules.SelectPolicy == autoscalingv2.DisabledPolicySelect {
+		// Scaling is disabled
+		return currentReplicas
+	} else if *scalingRules.SelectPolicy == autoscalingv2.MinChangePolicySelect {
+		result = math.MinInt32
+		// For scaling down, the lowest change ('min' policy) produces a maximum value
+		selectPolicyFn = maxInt32
+	} else {
+		result = math.MaxInt32
+		// Use the default policy otherwise to produce a highest possible change
+		selectPolicyFn = minInt32
+	}
+	for _, policy := range scalingRules.Policies {
+		replicasAddedInCurrentPeriod := getReplicasChangePerPeriod(now, policy.PeriodSeconds, scaleUpEvents)
+		replicasDeletedInCurrentPeriod := getReplicasChangePerPeriod(now, policy.PeriodSeconds, scaleDownEvents)
+		periodStartReplicas := currentReplicas - replicasAddedInCurrentPeriod + replicasDeletedInCurrentPeriod
+		if policy.Type == autoscalingv2.PodsScalingPolicy {
+			proposed = periodStartReplicas - policy.Value
+		} else if policy.Type == autoscalingv2.PercentScalingPolicy {
+			proposed = int32(float64(periodStartReplicas) * (1 - float64(policy.Value)/100))
+		}
+		result = selectPolicyFn(result, proposed)
+	}
+	return result
+}
+
+// storeScaleEvent adds a scale event, replacing an outdated scale event if there is one
+func storeScaleEvent(now time.Time, scaleEvents []timestampedScaleEvent, longestPolicyPeriod int32,
+	replicaChange int32) []timestampedScaleEvent {
+	markScaleEventsOutdated(now, scaleEvents, longestPolicyPeriod)
+
+	foundOldSample := false
+	oldSampleIndex := 0
+	for i, event := range scaleEvents {
+		if event.outdated {
+			foundOldSample = true
+			oldSampleIndex = i
+		}
+	}
+
+	newEvent := timestampedScaleEvent{
+		replicaChange: replicaChange,
+		timestamp:     now,
+		outdated:      false,
+	}
+	if foundOldSample {
+		scaleEvents[oldSampleIndex] = newEvent
+		return scaleEvents
+	}
+	return append(scaleEvents, newEvent)
+}
+
+// markScaleEventsOutdated marks the scale events older than the longest policy period as outdated
+func markScaleEventsOutdated(now time.Time, scaleEvents []timestampedScaleEvent, longestPolicyPeriod int32) {
+	period := time.Second * time.Duration(longestPolicyPeriod)
+	cutoff := now.Add(-period)
+	for i, event := range scaleEvents {
+		if event.timestamp.Before(cutoff) {
+			// Outdated scale events are marked for later reuse
+			scaleEvents[i].outdated = true
+		}
+	}
+}
+
+// getLongestPolicyPeriod returns the longest period of the scaling policies
+func getLongestPolicyPeriod(scalingRules *autoscalingv2.HPAScalingRules) int32 {
+	var longestPolicyPeriod int32
+	for _, policy := range scalingRules.Policies {
+		if policy.PeriodSeconds > longestPolicyPeriod {
+			longestPolicyPeriod = policy.PeriodSeconds
+		}
+	}
+	return longestPolicyPeriod
+}
+
+func minInt32(a, b int32) int32 {
+	return min(a, b)
+}
+
+func maxInt32(a, b int32) int32 {
+	return max(a, b)
+}
+
+// Evaluator wraps an Evaluator, constraining its evaluations for a target using the target's scaling Behavior and its
+// MinReplicas and MaxReplicas in the same way the HPA controller does. The Normalizer holds the history for each
+// target, after the target is scaled RecordScale should be called so the scaling policies can limit future scaling.
+type Evaluator struct {
+	Evaluator   *k8shorizmetrics.Evaluator
+	Normalizer  *Normalizer
+	Behavior    *autoscalingv2.HorizontalPodAutoscalerBehavior
+	MinReplicas int32
+	MaxReplicas int32
+}
+
+// Evaluate returns the target replica count for the target identified by the key provided based on the metrics
+// provided, constrained by the scaling behavior
+func (e *Evaluator) Evaluate(key string, gatheredMetrics []*metrics.Metric, currentReplicas int32) (int32, error) {
+	return e.EvaluateWithContext(context.Background(), key, gatheredMetrics, currentReplicas)
+}
+
+// EvaluateWithContext returns the target replica count for the target identified by the key provided based on the
+// metrics provided, constrained by the scaling behavior, passing the context provided to the Evaluator. As with the
+// HPA, if the current replica count is outside of the minimum and maximum replica counts the metrics are not
+// evaluated and the nearest limit is returned.
+func (e *Evaluator) EvaluateWithContext(ctx context.Context, key string, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32) (int32, error) {
+	normalization, err := e.EvaluateWithContextAndReasons(ctx, key, gatheredMetrics, currentReplicas)
+	if err != nil {
+		return 0, err
+	}
+	return normalization.Replicas, nil
+}
+
+// EvaluateWithReasons returns the target replica count for the target identified by the key provided based on the
+// metrics provided, constrained by the scaling behavior, along with the reasons the HPA would report for the replica
+// count
+func (e *Evaluator) EvaluateWithReasons(key string, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32) (*Normalization, error) {
+	return e.EvaluateWithContextAndReasons(context.Background(), key, gatheredMetrics, currentReplicas)
+}
+
+// EvaluateWithContextAndReasons returns the target replica count for the target identified by the key provided based
+// on the metrics provided, constrained by the scaling behavior, along with the reasons the HPA would report for the
+// replica count, passing the context provided to the Evaluator. If the current replica count is outside of the
+// minimum and maximum replica counts the nearest limit is returned with the TooManyReplicas or TooFewReplicas reason.
+// As with the HPA, if the target has been scaled to zero and the minimum replica count is not zero scaling is treated
+// as disabled and zero replicas are returned.
+func (e *Evaluator) EvaluateWithContextAndReasons(ctx context.Context, key string, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32) (*Normalization, error) {
+	if currentReplicas == 0 && e.MinReplicas != 0 {
+		return limitedNormalization(0, k8shorizmetrics.ReasonDesiredWithinRange), nil
+	}
+	if currentReplicas > e.MaxReplicas {
+		return limitedNormalization(e.MaxReplicas, k8shorizmetrics.ReasonTooManyReplicas), nil
+	}
+	if currentReplicas < e.MinReplicas {
+		return limitedNormalization(e.MinReplicas, k8shorizmetrics.ReasonTooFewReplicas), nil
+	}
+
+	desiredReplicas, err := e.Evaluator.EvaluateWithContext(ctx, gatheredMetrics, currentReplicas)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Normalizer.NormalizeWithReasons(key, e.Behavior, e.MinReplicas, e.MaxReplicas, currentReplicas,
+		desiredReplicas), nil
+}
+
+func limitedNormalization(replicas int32, reason k8shorizmetrics.Reason) *Normalization {
+	return &Normalization{
+		Replicas:            replicas,
+		StabilizedReplicas:  replicas,
+		StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+		LimitReason:         reason,
+	}
+}
+
+// RecordScale records that the target identified by the key provided was scaled from the previous replica count to
+// the new replica count, see Normalizer.RecordScale
+func (e *Evaluator) RecordScale(key string, previousReplicas int32, newReplicas int32) {
+	e.Normalizer.RecordScale(key, e.Behavior, previousReplicas, newReplicas)
+}
@@ -0,0 +1,614 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package behavior_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/behavior"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+type scaleEvent struct {
+	previousReplicas int32
+	newReplicas      int32
+}
+
+type step struct {
+	advance         time.Duration
+	scale           *scaleEvent
+	currentReplicas int32
+	desiredReplicas int32
+	expected        int32
+}
+
+func selectPolicy(policy autoscalingv2.ScalingPolicySelect) *autoscalingv2.ScalingPolicySelect {
+	return &policy
+}
+
+func TestNormalizer_Normalize(t *testing.T) {
+	var tests = []struct {
+		description string
+		behavior    *autoscalingv2.HorizontalPodAutoscalerBehavior
+		minReplicas int32
+		maxReplicas int32
+		steps       []step
+	}{
+		{
+			description: "Default behavior, scale up limited to the greater of 4 pods or 100%",
+			behavior:    nil,
+			minReplicas: 1,
+			maxReplicas: 100,
+			steps: []step{
+				{currentReplicas: 1, desiredReplicas: 10, expected: 5},
+				{currentReplicas: 10, desiredReplicas: 30, expected: 20},
+			},
+		},
+		{
+			description: "Default behavior, scale down to min replicas with no history",
+			behavior:    nil,
+			minReplicas: 1,
+			maxReplicas: 100,
+			steps: []step{
+				{currentReplicas: 10, desiredReplicas: 0, expected: 1},
+			},
+		},
+		{
+			description: "Default behavior, scale down stabilized for 5 minutes",
+			behavior:    nil,
+			minReplicas: 1,
+			maxReplicas: 100,
+			steps: []step{
+				{currentReplicas: 10, desiredReplicas: 10, expected: 10},
+				{advance: time.Minute, currentReplicas: 10, desiredReplicas: 2, expected: 10},
+				{advance: 3 * time.Minute, currentReplicas: 10, desiredReplicas: 2, expected: 10},
+				{advance: 2 * time.Minute, currentReplicas: 10, desiredReplicas: 2, expected: 2},
+			},
+		},
+		{
+			description: "Scale up stabilization window, use lowest recommendation in window",
+			behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: testutil.Int32Ptr(60),
+				},
+			},
+			minReplicas: 1,
+			maxReplicas: 100,
+			steps: []step{
+				{currentReplicas: 5, desiredReplicas: 5, expected: 5},
+				{advance: 30 * time.Second, currentReplicas: 5, desiredReplicas: 10, expected: 5},
+				{advance: 31 * time.Second, currentReplicas: 5, desiredReplicas: 10, expected: 10},
+			},
+		},
+		{
+			description: "Scale up pods policy, limited by scale events within period",
+			behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &autoscalingv2.HPAScalingRules{
+					Policies: []autoscalingv2.HPAScalingPolicy{
+						{
+							Type:          autoscalingv2.PodsScalingPolicy,
+							Value:         2,
+							PeriodSeconds: 60,
+						},
+					},
+				},
+			},
+			minReplicas: 1,
+			maxReplicas: 100,
+			steps: []step{
+				{currentReplicas: 5, desiredReplicas: 10, expected: 7},
+				{
+					advance:         30 * time.Second,
+					scale:           &scaleEvent{previousReplicas: 5, newReplicas: 7},
+					currentReplicas: 7,
+					desiredReplicas: 10,
+					expected:        7,
+				},
+				{advance: 61 * time.Second, currentReplicas: 7, desiredReplicas: 10, expected: 9},
+			},
+		},
+		{
+			description: "Scale up select min policy",
+			behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &autoscalingv2.HPAScalingRules{
+					SelectPolicy: selectPolicy(autoscalingv2.MinChangePolicySelect),
+					Policies: []autoscalingv2.HPAScalingPolicy{
+						{
+							Type:          autoscalingv2.PodsScalingPolicy,
+							Value:         4,
+							PeriodSeconds: 15,
+						},
+						{
+							Type:          autoscalingv2.PercentScalingPolicy,
+							Value:         100,
+							PeriodSeconds: 15,
+						},
+					},
+				},
+			},
+			minReplicas: 1,
+			maxReplicas: 100,
+			steps: []step{
+				{currentReplicas: 2, desiredReplicas: 10, expected: 4},
+			},
+		},
+		{
+			description: "Scale up percent policy rounded up",
+			behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &autoscalingv2.HPAScalingRules{
+					Policies: []autoscalingv2.HPAScalingPolicy{
+						{
+							Type:          autoscalingv2.PercentScalingPolicy,
+							Value:         10,
+							PeriodSeconds: 15,
+						},
+					},
+				},
+			},
+			minReplicas: 1,
+			maxReplicas: 100,
+			steps: []step{
+				{currentReplicas: 3, desiredReplicas: 10, expected: 4},
+			},
+		},
+		{
+			description: "Scale up limited by max replicas",
+			behavior:    nil,
+			minReplicas: 1,
+			maxReplicas: 12,
+			steps: []step{
+				{currentReplicas: 10, desiredReplicas: 100, expected: 12},
+			},
+		},
+		{
+			description: "Scale down disabled",
+			behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleDown: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: testutil.Int32Ptr(0),
+					SelectPolicy:               selectPolicy(autoscalingv2.DisabledPolicySelect),
+				},
+			},
+			minReplicas: 1,
+			maxReplicas: 100,
+			steps: []step{
+				{currentReplicas: 5, desiredReplicas: 1, expected: 5},
+			},
+		},
+		{
+			description: "Scale down percent policy, limited by scale events within period",
+			behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleDown: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: testutil.Int32Ptr(0),
+					Policies: []autoscalingv2.HPAScalingPolicy{
+						{
+							Type:          autoscalingv2.PercentScalingPolicy,
+							Value:         50,
+							PeriodSeconds: 60,
+						},
+					},
+				},
+			},
+			minReplicas: 1,
+			maxReplicas: 100,
+			steps: []step{
+				{currentReplicas: 10, desiredReplicas: 1, expected: 5},
+				{
+					advance:         10 * time.Second,
+					scale:           &scaleEvent{previousReplicas: 10, newReplicas: 5},
+					currentReplicas: 5,
+					desiredReplicas: 1,
+					expected:        5,
+				},
+				{advance: time.Minute, currentReplicas: 5, desiredReplicas: 1, expected: 2},
+			},
+		},
+		{
+			description: "Scale down limited by min replicas",
+			behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleDown: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: testutil.Int32Ptr(0),
+				},
+			},
+			minReplicas: 3,
+			maxReplicas: 100,
+			steps: []step{
+				{currentReplicas: 5, desiredReplicas: 1, expected: 3},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakePassiveClock(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+			normalizer := &behavior.Normalizer{
+				DownscaleStabilizationWindow: behavior.DefaultDownscaleStabilizationWindow,
+				Clock:                        fakeClock,
+			}
+			for i, step := range test.steps {
+				fakeClock.SetTime(fakeClock.Now().Add(step.advance))
+				if step.scale != nil {
+					normalizer.RecordScale("test", test.behavior, step.scale.previousReplicas, step.scale.newReplicas)
+				}
+				result := normalizer.Normalize("test", test.behavior, test.minReplicas, test.maxReplicas,
+					step.currentReplicas, step.desiredReplicas)
+				if result != step.expected {
+					t.Errorf("step %d normalized replicas mismatch, want %d, got %d", i, step.expected, result)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizer_NormalizeWithReasons(t *testing.T) {
+	var tests = []struct {
+		description     string
+		expected        *behavior.Normalization
+		behavior        *autoscalingv2.HorizontalPodAutoscalerBehavior
+		history         []int32
+		currentReplicas int32
+		desiredReplicas int32
+	}{
+		{
+			description: "Within range",
+			expected: &behavior.Normalization{
+				Replicas:            6,
+				StabilizedReplicas:  6,
+				StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+				LimitReason:         k8shorizmetrics.ReasonDesiredWithinRange,
+			},
+			currentReplicas: 5,
+			desiredReplicas: 6,
+		},
+		{
+			description: "Scale down stabilized",
+			expected: &behavior.Normalization{
+				Replicas:            8,
+				StabilizedReplicas:  8,
+				StabilizationReason: k8shorizmetrics.ReasonScaleDownStabilized,
+				LimitReason:         k8shorizmetrics.ReasonDesiredWithinRange,
+			},
+			history:         []int32{8},
+			currentReplicas: 10,
+			desiredReplicas: 2,
+		},
+		{
+			description: "Scale up stabilized",
+			expected: &behavior.Normalization{
+				Replicas:            5,
+				StabilizedReplicas:  5,
+				StabilizationReason: k8shorizmetrics.ReasonScaleUpStabilized,
+				LimitReason:         k8shorizmetrics.ReasonDesiredWithinRange,
+			},
+			behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: testutil.Int32Ptr(60),
+				},
+			},
+			history:         []int32{5},
+			currentReplicas: 5,
+			desiredReplicas: 10,
+		},
+		{
+			description: "Scale up limited by policy",
+			expected: &behavior.Normalization{
+				Replicas:            5,
+				StabilizedReplicas:  10,
+				StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+				LimitReason:         k8shorizmetrics.ReasonScaleUpLimit,
+			},
+			currentReplicas: 1,
+			desiredReplicas: 10,
+		},
+		{
+			description: "Scale up limited by max replicas",
+			expected: &behavior.Normalization{
+				Replicas:            20,
+				StabilizedReplicas:  30,
+				StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+				LimitReason:         k8shorizmetrics.ReasonTooManyReplicas,
+			},
+			currentReplicas: 15,
+			desiredReplicas: 30,
+		},
+		{
+			description: "Scale down limited by policy",
+			expected: &behavior.Normalization{
+				Replicas:            8,
+				StabilizedReplicas:  2,
+				StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+				LimitReason:         k8shorizmetrics.ReasonScaleDownLimit,
+			},
+			behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleDown: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: testutil.Int32Ptr(0),
+					Policies: []autoscalingv2.HPAScalingPolicy{
+						{
+							Type:          autoscalingv2.PodsScalingPolicy,
+							Value:         2,
+							PeriodSeconds: 60,
+						},
+					},
+				},
+			},
+			currentReplicas: 10,
+			desiredReplicas: 2,
+		},
+		{
+			description: "Scale down limited by min replicas",
+			expected: &behavior.Normalization{
+				Replicas:            3,
+				StabilizedReplicas:  1,
+				StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+				LimitReason:         k8shorizmetrics.ReasonTooFewReplicas,
+			},
+			behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleDown: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: testutil.Int32Ptr(0),
+				},
+			},
+			currentReplicas: 5,
+			desiredReplicas: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakePassiveClock(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+			normalizer := &behavior.Normalizer{
+				DownscaleStabilizationWindow: behavior.DefaultDownscaleStabilizationWindow,
+				Clock:                        fakeClock,
+			}
+			for _, recommendation := range test.history {
+				normalizer.Normalize("test", test.behavior, 3, 20, test.currentReplicas, recommendation)
+				fakeClock.SetTime(fakeClock.Now().Add(time.Second))
+			}
+
+			result := normalizer.NormalizeWithReasons("test", test.behavior, 3, 20, test.currentReplicas,
+				test.desiredReplicas)
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("normalization mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestNormalizer_Forget(t *testing.T) {
+	normalizer := &behavior.Normalizer{
+		DownscaleStabilizationWindow: behavior.DefaultDownscaleStabilizationWindow,
+		Clock:                        clocktesting.NewFakePassiveClock(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	normalizer.Normalize("test", nil, 1, 100, 10, 10)
+	if result := normalizer.Normalize("test", nil, 1, 100, 10, 2); result != 10 {
+		t.Errorf("expected scale down to be stabilized, got %d", result)
+	}
+
+	normalizer.Forget("test")
+	if result := normalizer.Normalize("test", nil, 1, 100, 10, 2); result != 2 {
+		t.Errorf("expected scale down after history forgotten, got %d", result)
+	}
+}
+
+func TestNormalizer_ZeroValue(t *testing.T) {
+	normalizer := &behavior.Normalizer{}
+
+	if result := normalizer.Normalize("test", nil, 1, 100, 1, 4); result != 4 {
+		t.Errorf("expected 4, got %d", result)
+	}
+	normalizer.RecordScale("test", nil, 1, 4)
+	// The recorded scale event limits the scale up by the default policies
+	if result := normalizer.Normalize("test", nil, 1, 100, 4, 6); result != 5 {
+		t.Errorf("expected 5, got %d", result)
+	}
+}
+
+func TestNormalizer_DoesNotModifyBehavior(t *testing.T) {
+	scaleUp := &autoscalingv2.HPAScalingRules{}
+	hpaBehavior := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleUp: scaleUp,
+	}
+	normalizer := behavior.NewNormalizer()
+	normalizer.Normalize("test", hpaBehavior, 1, 100, 1, 10)
+	normalizer.RecordScale("test", hpaBehavior, 1, 5)
+
+	expected := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleUp: &autoscalingv2.HPAScalingRules{},
+	}
+	if !cmp.Equal(expected, hpaBehavior) {
+		t.Errorf("behavior modified (-want +got):\n%s", cmp.Diff(expected, hpaBehavior))
+	}
+}
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	gatheredMetrics := []*metrics.Metric{
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+			},
+		},
+	}
+
+	var tests = []struct {
+		description     string
+		expected        int32
+		expectedErr     error
+		evaluator       *k8shorizmetrics.Evaluator
+		minReplicas     int32
+		maxReplicas     int32
+		currentReplicas int32
+	}{
+		{
+			description: "Current replicas above max replicas, use max replicas",
+			expected:    10,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 0, errors.New("should not evaluate")
+					},
+				},
+			},
+			minReplicas:     1,
+			maxReplicas:     10,
+			currentReplicas: 15,
+		},
+		{
+			description: "Current replicas below min replicas, use min replicas",
+			expected:    2,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 0, errors.New("should not evaluate")
+					},
+				},
+			},
+			minReplicas:     2,
+			maxReplicas:     10,
+			currentReplicas: 1,
+		},
+		{
+			description: "Fail to evaluate",
+			expected:    0,
+			expectedErr: errors.New("evaluator multi metric error: 1 errors, first error is fail to evaluate"),
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 0, errors.New("fail to evaluate")
+					},
+				},
+			},
+			minReplicas:     1,
+			maxReplicas:     10,
+			currentReplicas: 5,
+		},
+		{
+			description: "Scale up limited by default behavior",
+			expected:    6,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 20, nil
+					},
+				},
+			},
+			minReplicas:     1,
+			maxReplicas:     100,
+			currentReplicas: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluator := &behavior.Evaluator{
+				Evaluator: test.evaluator,
+				Normalizer: &behavior.Normalizer{
+					DownscaleStabilizationWindow: behavior.DefaultDownscaleStabilizationWindow,
+					Clock:                        clocktesting.NewFakePassiveClock(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)),
+				},
+				MinReplicas: test.minReplicas,
+				MaxReplicas: test.maxReplicas,
+			}
+			result, err := evaluator.Evaluate("test", gatheredMetrics, test.currentReplicas)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if result != test.expected {
+				t.Errorf("replicas mismatch, want %d, got %d", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestEvaluator_EvaluateWithReasons(t *testing.T) {
+	evaluator := &behavior.Evaluator{
+		Evaluator: &k8shorizmetrics.Evaluator{
+			Resource: &fake.ResourceEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 50, nil
+				},
+			},
+		},
+		Normalizer:  behavior.NewNormalizer(),
+		MinReplicas: 1,
+		MaxReplicas: 10,
+	}
+	gatheredMetrics := []*metrics.Metric{
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+			},
+		},
+	}
+
+	expected := &behavior.Normalization{
+		Replicas:            10,
+		StabilizedReplicas:  10,
+		StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+		LimitReason:         k8shorizmetrics.ReasonTooManyReplicas,
+	}
+	result, err := evaluator.EvaluateWithReasons("test", gatheredMetrics, 15)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(expected, result) {
+		t.Errorf("normalization mismatch (-want +got):\n%s", cmp.Diff(expected, result))
+	}
+
+	expected = &behavior.Normalization{
+		Replicas:            10,
+		StabilizedReplicas:  50,
+		StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+		LimitReason:         k8shorizmetrics.ReasonTooManyReplicas,
+	}
+	result, err = evaluator.EvaluateWithReasons("test", gatheredMetrics, 8)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(expected, result) {
+		t.Errorf("normalization mismatch (-want +got):\n%s", cmp.Diff(expected, result))
+	}
+
+	// Scaled to zero with a minimum replica count, scaling is disabled
+	expected = &behavior.Normalization{
+		Replicas:            0,
+		StabilizedReplicas:  0,
+		StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+		LimitReason:         k8shorizmetrics.ReasonDesiredWithinRange,
+	}
+	result, err = evaluator.EvaluateWithReasons("test", gatheredMetrics, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(expected, result) {
+		t.Errorf("normalization mismatch (-want +got):\n%s", cmp.Diff(expected, result))
+	}
+}
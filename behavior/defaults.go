@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Modifications Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+
+Modified to default HPA scaling behavior outside of the K8s API server.
+Original source:
+https://github.com/kubernetes/kubernetes/blob/master/pkg/apis/autoscaling/v2/defaults.go
+*/
+
+package behavior
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+var (
+	// These constants repeats previous HPA behavior
+	scaleUpLimitPercent         int32 = 100
+	scaleUpLimitMinimumPods     int32 = 4
+	scaleUpPeriod               int32 = 15
+	scaleUpStabilizationSeconds int32
+	maxPolicy                   = autoscalingv2.MaxChangePolicySelect
+	defaultHPAScaleUpRules      = autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: &scaleUpStabilizationSeconds,
+		SelectPolicy:               &maxPolicy,
+		Policies: []autoscalingv2.HPAScalingPolicy{
+			{
+				Type:          autoscalingv2.PodsScalingPolicy,
+				Value:         scaleUpLimitMinimumPods,
+				PeriodSeconds: scaleUpPeriod,
+			},
+			{
+				Type:          autoscalingv2.PercentScalingPolicy,
+				Value:         scaleUpLimitPercent,
+				PeriodSeconds: scaleUpPeriod,
+			},
+		},
+	}
+	scaleDownPeriod int32 = 15
+	// Currently we can set the downscaleStabilizationWindow from the command line
+	// So we can not rewrite the command line option from here
+	scaleDownLimitPercent    int32 = 100
+	defaultHPAScaleDownRules       = autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: nil,
+		SelectPolicy:               &maxPolicy,
+		Policies: []autoscalingv2.HPAScalingPolicy{
+			{
+				Type:          autoscalingv2.PercentScalingPolicy,
+				Value:         scaleDownLimitPercent,
+				PeriodSeconds: scaleDownPeriod,
+			},
+		},
+	}
+)
+
+// DefaultScaleUpRules returns the HPA's default scale up rules
+func DefaultScaleUpRules() *autoscalingv2.HPAScalingRules {
+	return defaultHPAScaleUpRules.DeepCopy()
+}
+
+// DefaultScaleDownRules returns the HPA's default scale down rules, the stabilization window is left unset so the
+// Normalizer's DownscaleStabilizationWindow is used
+func DefaultScaleDownRules() *autoscalingv2.HPAScalingRules {
+	return defaultHPAScaleDownRules.DeepCopy()
+}
+
+// generateScaleDownRules returns the scale down rules with any fields not set defaulted
+func generateScaleDownRules(scalingRules *autoscalingv2.HPAScalingRules) *autoscalingv2.HPAScalingRules {
+	defaultScalingRules := DefaultScaleDownRules()
+	if scalingRules == nil {
+		return defaultScalingRules
+	}
+	if scalingRules.SelectPolicy == nil {
+		scalingRules.SelectPolicy = defaultScalingRules.SelectPolicy
+	}
+	if scalingRules.Policies == nil {
+		scalingRules.Policies = defaultScalingRules.Policies
+	}
+	return scalingRules
+}
+
+// generateScaleUpRules returns the scale up rules with any fields not set defaulted
+func generateScaleUpRules(scalingRules *autoscalingv2.HPAScalingRules) *autoscalingv2.HPAScalingRules {
+	defaultScalingRules := DefaultScaleUpRules()
+	if scalingRules == nil {
+		return defaultScalingRules
+	}
+	if scalingRules.StabilizationWindowSeconds == nil {
+		scalingRules.StabilizationWindowSeconds = defaultScalingRules.StabilizationWindowSeconds
+	}
+	if scalingRules.SelectPolicy == nil {
+		scalingRules.SelectPolicy = defaultScalingRules.SelectPolicy
+	}
+	if scalingRules.Policies == nil {
+		scalingRules.Policies = defaultScalingRules.Policies
+	}
+	return scalingRules
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cache provides a caching decorator around a Gatherer, serving gathered metrics from a TTL cache keyed on the
+// metric spec, namespace and pod selector. This cuts load on the metrics APIs when metrics are gathered more often
+// than they are refreshed, for example metrics-server only refreshes metrics every 15 seconds by default.
+//
+// Cached metrics older than the refresh interval are still served, but trigger a refresh in the background so later
+// gathers are served fresh metrics without waiting on the metrics APIs. Metrics served from the cache have their
+// provenance marked as cached, failed gathers are never cached.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/clock"
+)
+
+type entry struct {
+	metric     *metrics.Metric
+	gatheredAt time.Time
+	refreshing bool
+}
+
+// call is a gather in progress, concurrent gathers of the same metric wait for it rather than gathering again
+type call struct {
+	done   chan struct{}
+	metric *metrics.Metric
+	err    error
+}
+
+// Gatherer wraps a Gatherer, caching the metrics it gathers. Cached metrics are served until they are older than the
+// TTL, after which they are gathered again. If RefreshAfter is greater than 0 cached metrics older than RefreshAfter
+// are refreshed in the background while continuing to serve the cached metric. Concurrent gathers of a metric that
+// is not cached wait for a single gather of the metric rather than each gathering it. If Clock is nil the real clock
+// is used.
+type Gatherer struct {
+	Gatherer     *k8shorizmetrics.Gatherer
+	TTL          time.Duration
+	RefreshAfter time.Duration
+	Clock        clock.PassiveClock
+
+	mu         sync.Mutex
+	entries    map[string]*entry
+	inflight   map[string]*call
+	refreshing sync.WaitGroup
+}
+
+// NewGatherer sets up a caching Gatherer with the TTL provided, refreshing cached metrics in the background once they
+// are half of the TTL old
+func NewGatherer(gatherer *k8shorizmetrics.Gatherer, ttl time.Duration) *Gatherer {
+	return &Gatherer{
+		Gatherer:     gatherer,
+		TTL:          ttl,
+		RefreshAfter: ttl / 2,
+		Clock:        clock.RealClock{},
+	}
+}
+
+// Gather returns all of the metrics gathered based on the metric specs provided, serving them from the cache if
+// possible.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError. If a partial error occurs,
+// meaning some metrics were gathered successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (g *Gatherer) Gather(specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) ([]*metrics.Metric, error) {
+	return g.GatherWithContext(context.Background(), specs, namespace, podSelector)
+}
+
+// GatherWithContext returns all of the metrics gathered based on the metric specs provided, serving them from the
+// cache if possible and passing the context provided to the wrapped Gatherer for any metrics that are not cached.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError. If a partial error occurs,
+// meaning some metrics were gathered successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (g *Gatherer) GatherWithContext(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) ([]*metrics.Metric, error) {
+	combinedMetrics := []*metrics.Metric{}
+	gatherErrors := []error{}
+	for i, spec := range specs {
+		gathered, err := g.GatherSingleMetricWithContext(ctx, spec, namespace, podSelector)
+		if err != nil {
+			gatherErrors = append(gatherErrors, &k8shorizmetrics.MetricSpecError{
+				Index: i,
+				Spec:  spec,
+				Err:   err,
+			})
+			continue
+		}
+		combinedMetrics = append(combinedMetrics, gathered)
+	}
+
+	if len(gatherErrors) > 0 {
+		partial := len(gatherErrors) < len(specs)
+		if partial {
+			return combinedMetrics, &k8shorizmetrics.GathererMultiMetricError{
+				Partial: partial,
+				Errors:  gatherErrors,
+			}
+		}
+
+		return nil, &k8shorizmetrics.GathererMultiMetricError{
+			Partial: partial,
+			Errors:  gatherErrors,
+		}
+	}
+
+	return combinedMetrics, nil
+}
+
+// GatherSingleMetric returns the metric gathered based on a single metric spec, serving it from the cache if possible
+func (g *Gatherer) GatherSingleMetric(spec autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) (*metrics.Metric, error) {
+	return g.GatherSingleMetricWithContext(context.Background(), spec, namespace, podSelector)
+}
+
+// GatherSingleMetricWithContext returns the metric gathered based on a single metric spec, serving it from the cache
+// if possible and passing the context provided to the wrapped Gatherer if it is not cached
+func (g *Gatherer) GatherSingleMetricWithContext(ctx context.Context, spec autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) (*metrics.Metric, error) {
+	key := cacheKey(spec, namespace, podSelector)
+
+	g.mu.Lock()
+	now := g.clock().Now()
+	cached, exists := g.entries[key]
+	if exists && now.Sub(cached.gatheredAt) < g.TTL {
+		if g.RefreshAfter > 0 && now.Sub(cached.gatheredAt) >= g.RefreshAfter && !cached.refreshing {
+			cached.refreshing = true
+			g.refreshing.Add(1)
+			go g.refresh(key, spec, namespace, podSelector)
+		}
+		metric := cached.metric.DeepCopy()
+		g.mu.Unlock()
+		markCached(metric)
+		return metric, nil
+	}
+	if exists {
+		delete(g.entries, key)
+	}
+	if pending, ok := g.inflight[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-pending.done:
+		}
+		if pending.err != nil {
+			return nil, pending.err
+		}
+		return pending.metric.DeepCopy(), nil
+	}
+	pending := &call{
+		done: make(chan struct{}),
+	}
+	if g.inflight == nil {
+		g.inflight = map[string]*call{}
+	}
+	g.inflight[key] = pending
+	g.mu.Unlock()
+
+	gathered, err := g.Gatherer.GatherSingleMetricWithContext(ctx, spec, namespace, podSelector)
+
+	g.mu.Lock()
+	delete(g.inflight, key)
+	g.mu.Unlock()
+	if err != nil {
+		pending.err = err
+	} else {
+		pending.metric = gathered.DeepCopy()
+	}
+	close(pending.done)
+
+	if err != nil {
+		return nil, err
+	}
+
+	g.store(key, gathered)
+
+	return gathered, nil
+}
+
+// Purge removes all cached metrics
+func (g *Gatherer) Purge() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.entries = nil
+}
+
+// Wait blocks until any background refreshes in progress have finished
+func (g *Gatherer) Wait() {
+	g.refreshing.Wait()
+}
+
+func (g *Gatherer) refresh(key string, spec autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) {
+	defer g.refreshing.Done()
+
+	gathered, err := g.Gatherer.GatherSingleMetricWithContext(context.Background(), spec, namespace, podSelector)
+	if err != nil {
+		// Keep serving the cached metric until it expires, a later gather will retry the refresh
+		g.mu.Lock()
+		if cached, exists := g.entries[key]; exists {
+			cached.refreshing = false
+		}
+		g.mu.Unlock()
+		return
+	}
+
+	g.store(key, gathered)
+}
+
+func (g *Gatherer) store(key string, gathered *metrics.Metric) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.entries == nil {
+		g.entries = map[string]*entry{}
+	}
+	g.entries[key] = &entry{
+		metric:     gathered.DeepCopy(),
+		gatheredAt: g.clock().Now(),
+	}
+}
+
+func (g *Gatherer) clock() clock.PassiveClock {
+	if g.Clock == nil {
+		return clock.RealClock{}
+	}
+	return g.Clock
+}
+
+func cacheKey(spec autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) string {
+	specKey, _ := json.Marshal(spec)
+	selectorKey := ""
+	if podSelector != nil {
+		selectorKey = podSelector.String()
+	}
+	return namespace + "/" + selectorKey + "/" + string(specKey)
+}
+
+func markCached(metric *metrics.Metric) {
+	var metricProvenance **provenance.Provenance
+	switch {
+	case metric.Resource != nil:
+		metricProvenance = &metric.Resource.Provenance
+	case metric.Pods != nil:
+		metricProvenance = &metric.Pods.Provenance
+	case metric.Object != nil:
+		metricProvenance = &metric.Object.Provenance
+	case metric.External != nil:
+		metricProvenance = &metric.External.Provenance
+	default:
+		return
+	}
+
+	if *metricProvenance == nil {
+		*metricProvenance = &provenance.Provenance{}
+	}
+	(*metricProvenance).Cached = true
+}
@@ -0,0 +1,385 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/cache"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+type step struct {
+	advance       time.Duration
+	podSelector   labels.Selector
+	gatherErr     error
+	expected      *metrics.Metric
+	expectedErr   error
+	expectedCalls int
+}
+
+func TestGatherSingleMetric(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	gatheredMetric := func(value int64, cached bool) *metrics.Metric {
+		metric := &metrics.Metric{
+			Spec: spec,
+			Pods: &podsmetrics.Metric{
+				PodMetricsInfo: podmetrics.MetricsInfo{
+					"pod-1": {Value: value},
+				},
+			},
+		}
+		if cached {
+			metric.Pods.Provenance = &provenance.Provenance{Cached: true}
+		}
+		return metric
+	}
+
+	steps := []step{
+		{
+			expected:      gatheredMetric(1, false),
+			expectedCalls: 1,
+		},
+		{
+			advance:       time.Second,
+			expected:      gatheredMetric(1, true),
+			expectedCalls: 1,
+		},
+		{
+			podSelector:   labels.SelectorFromSet(labels.Set{"app": "other"}),
+			expected:      gatheredMetric(2, false),
+			expectedCalls: 2,
+		},
+		{
+			// Past the refresh interval, serve the cached metric and refresh in the background
+			advance:       5 * time.Second,
+			expected:      gatheredMetric(1, true),
+			expectedCalls: 3,
+		},
+		{
+			expected:      gatheredMetric(3, true),
+			expectedCalls: 3,
+		},
+		{
+			// Past the TTL, gather again
+			advance:       10 * time.Second,
+			gatherErr:     errors.New("fail to gather"),
+			expectedErr:   errors.New("failed to get pods metric: fail to gather"),
+			expectedCalls: 4,
+		},
+		{
+			expected:      gatheredMetric(5, false),
+			expectedCalls: 5,
+		},
+	}
+
+	var mu sync.Mutex
+	calls := 0
+	var gatherErr error
+
+	fakeClock := clocktesting.NewFakePassiveClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	gatherer := cache.NewGatherer(&k8shorizmetrics.Gatherer{
+		Pods: &fake.PodsGatherer{
+			GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				calls++
+				if gatherErr != nil {
+					return nil, gatherErr
+				}
+				return &podsmetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": {Value: int64(calls)},
+					},
+				}, nil
+			},
+		},
+	}, 10*time.Second)
+	gatherer.Clock = fakeClock
+
+	for i, step := range steps {
+		fakeClock.SetTime(fakeClock.Now().Add(step.advance))
+		mu.Lock()
+		gatherErr = step.gatherErr
+		mu.Unlock()
+
+		podSelector := step.podSelector
+		if podSelector == nil {
+			podSelector = labels.Everything()
+		}
+
+		result, err := gatherer.GatherSingleMetric(spec, "test", podSelector)
+		gatherer.Wait()
+
+		if !cmp.Equal(&err, &step.expectedErr, equateErrorMessage) {
+			t.Errorf("step %d error mismatch (-want +got):\n%s", i, cmp.Diff(step.expectedErr, err, equateErrorMessage))
+		}
+		if !cmp.Equal(step.expected, result) {
+			t.Errorf("step %d metric mismatch (-want +got):\n%s", i, cmp.Diff(step.expected, result))
+		}
+		if calls != step.expectedCalls {
+			t.Errorf("step %d calls mismatch, want %d, got %d", i, step.expectedCalls, calls)
+		}
+
+		if result != nil {
+			// Modifying a returned metric must not modify the cached metric
+			result.Pods.PodMetricsInfo["modified"] = podmetrics.Metric{}
+		}
+	}
+}
+
+func TestGather(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	podsSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expected    []*metrics.Metric
+		expectedErr error
+		specs       []autoscalingv2.MetricSpec
+	}{
+		{
+			description: "All fail",
+			expected:    nil,
+			expectedErr: errors.New(`gatherer multi metric error: 1 errors, first error is unknown metric source type "unknown"`),
+			specs: []autoscalingv2.MetricSpec{
+				{
+					Type: "unknown",
+				},
+			},
+		},
+		{
+			description: "Partial failure",
+			expected: []*metrics.Metric{
+				{
+					Spec: podsSpec,
+					Pods: &podsmetrics.Metric{},
+				},
+			},
+			expectedErr: errors.New(`gatherer multi metric error: 1 errors, first error is unknown metric source type "unknown"`),
+			specs: []autoscalingv2.MetricSpec{
+				podsSpec,
+				{
+					Type: "unknown",
+				},
+			},
+		},
+		{
+			description: "Success",
+			expected: []*metrics.Metric{
+				{
+					Spec: podsSpec,
+					Pods: &podsmetrics.Metric{},
+				},
+			},
+			expectedErr: nil,
+			specs:       []autoscalingv2.MetricSpec{podsSpec},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			gatherer := cache.NewGatherer(&k8shorizmetrics.Gatherer{
+				Pods: &fake.PodsGatherer{
+					GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+						return &podsmetrics.Metric{}, nil
+					},
+				},
+			}, time.Minute)
+
+			result, err := gatherer.Gather(test.specs, "test", labels.Everything())
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestPurge(t *testing.T) {
+	calls := 0
+	gatherer := cache.NewGatherer(&k8shorizmetrics.Gatherer{
+		Pods: &fake.PodsGatherer{
+			GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+				calls++
+				return &podsmetrics.Metric{}, nil
+			},
+		},
+	}, time.Minute)
+
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := gatherer.GatherSingleMetric(spec, "test", labels.Everything())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		gatherer.Purge()
+	}
+
+	if calls != 2 {
+		t.Errorf("calls mismatch, want 2, got %d", calls)
+	}
+}
+
+func TestGatherSingleMetricWithoutClock(t *testing.T) {
+	calls := 0
+	gatherer := &cache.Gatherer{
+		Gatherer: &k8shorizmetrics.Gatherer{
+			Pods: &fake.PodsGatherer{
+				GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+					calls++
+					return &podsmetrics.Metric{}, nil
+				},
+			},
+		},
+		TTL: time.Hour,
+	}
+
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := gatherer.GatherSingleMetric(spec, "test", labels.Everything())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("calls mismatch, want 1, got %d", calls)
+	}
+}
+
+func TestGatherSingleMetricConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	started := make(chan struct{})
+	release := make(chan struct{})
+	gatherer := cache.NewGatherer(&k8shorizmetrics.Gatherer{
+		Pods: &fake.PodsGatherer{
+			GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+				mu.Lock()
+				calls++
+				mu.Unlock()
+				close(started)
+				<-release
+				return &podsmetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": {Value: 1},
+					},
+				}, nil
+			},
+		},
+	}, time.Minute)
+
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	results := make([]*metrics.Metric, 5)
+	var wg sync.WaitGroup
+	gather := func(i int) {
+		defer wg.Done()
+		result, err := gatherer.GatherSingleMetric(spec, "test", labels.Everything())
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		results[i] = result
+	}
+
+	wg.Add(len(results))
+	go gather(0)
+	<-started
+	for i := 1; i < len(results); i++ {
+		go gather(i)
+	}
+	// Give the other gathers time to start waiting on the gather in progress
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("calls mismatch, want 1, got %d", calls)
+	}
+
+	for i, result := range results {
+		if result == nil || result.Pods.PodMetricsInfo["pod-1"].Value != 1 {
+			t.Errorf("result %d mismatch, got %v", i, result)
+		}
+	}
+}
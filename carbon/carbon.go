@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package carbon provides sustainability driven autoscaling, using a signal such as grid carbon intensity or energy
+// price to scale the targets of gathered metrics up or down before evaluation. When the signal is above its baseline
+// targets are raised, running fewer replicas at a higher utilization, when the signal is below its baseline targets
+// are lowered, allowing more replicas while energy is clean or cheap.
+package carbon
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// DefaultMinFactor is the lowest factor targets are scaled by if MinFactor is not set, a factor must be greater than 0
+// as a target of 0 cannot be evaluated
+const DefaultMinFactor = 0.1
+
+// SignalProvider provides the current value of a signal, for example grid carbon intensity in gCO2eq/kWh or energy
+// price per kWh
+type SignalProvider interface {
+	GetSignal(ctx context.Context) (float64, error)
+}
+
+// SignalProviderFunc allows a function to be used as a SignalProvider
+type SignalProviderFunc func(ctx context.Context) (float64, error)
+
+// GetSignal calls the function
+func (f SignalProviderFunc) GetSignal(ctx context.Context) (float64, error) {
+	return f(ctx)
+}
+
+// Evaluator wraps an Evaluator, scaling the targets of the metrics evaluated by a factor derived from the signal
+// provided. The factor is the signal divided by the Baseline, bounded by MinFactor and MaxFactor, so a signal at the
+// baseline leaves targets unchanged. If MinFactor is not greater than 0 DefaultMinFactor is used, so a signal of 0
+// never results in a target of 0. A MaxFactor of 0 leaves the factor unbounded above.
+type Evaluator struct {
+	Evaluator *k8shorizmetrics.Evaluator
+	Provider  SignalProvider
+	Baseline  float64
+	MinFactor float64
+	MaxFactor float64
+}
+
+// Factor returns the factor targets will be scaled by for the current signal
+func (e *Evaluator) Factor(ctx context.Context) (float64, error) {
+	if e.Baseline <= 0 {
+		return 0, fmt.Errorf("invalid baseline %f: must be greater than 0", e.Baseline)
+	}
+
+	signal, err := e.Provider.GetSignal(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get signal: %w", err)
+	}
+
+	minFactor := e.MinFactor
+	if minFactor <= 0 {
+		minFactor = DefaultMinFactor
+	}
+
+	factor := signal / e.Baseline
+	factor = math.Max(factor, minFactor)
+	if e.MaxFactor > 0 {
+		factor = math.Min(factor, e.MaxFactor)
+	}
+
+	return factor, nil
+}
+
+// Evaluate returns the target replica count for the metrics provided, with the metric targets scaled by the current
+// signal's factor
+func (e *Evaluator) Evaluate(ctx context.Context, gatheredMetrics []*metrics.Metric, currentReplicas int32) (int32, error) {
+	factor, err := e.Factor(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return e.Evaluator.EvaluateWithContext(ctx, ScaleTargets(gatheredMetrics, factor), currentReplicas)
+}
+
+// ScaleTargets returns a copy of the metrics provided with the target of each metric spec multiplied by the factor
+// provided, the original metrics are not modified
+func ScaleTargets(gatheredMetrics []*metrics.Metric, factor float64) []*metrics.Metric {
+	scaled := make([]*metrics.Metric, len(gatheredMetrics))
+	for i, gatheredMetric := range gatheredMetrics {
+		scaledMetric := *gatheredMetric
+		scaledMetric.Spec = *gatheredMetric.Spec.DeepCopy()
+
+		switch scaledMetric.Spec.Type {
+		case autoscalingv2.ResourceMetricSourceType:
+			if scaledMetric.Spec.Resource != nil {
+				scaleTarget(&scaledMetric.Spec.Resource.Target, factor)
+			}
+		case autoscalingv2.ContainerResourceMetricSourceType:
+			if scaledMetric.Spec.ContainerResource != nil {
+				scaleTarget(&scaledMetric.Spec.ContainerResource.Target, factor)
+			}
+		case autoscalingv2.PodsMetricSourceType:
+			if scaledMetric.Spec.Pods != nil {
+				scaleTarget(&scaledMetric.Spec.Pods.Target, factor)
+			}
+		case autoscalingv2.ObjectMetricSourceType:
+			if scaledMetric.Spec.Object != nil {
+				scaleTarget(&scaledMetric.Spec.Object.Target, factor)
+			}
+		case autoscalingv2.ExternalMetricSourceType:
+			if scaledMetric.Spec.External != nil {
+				scaleTarget(&scaledMetric.Spec.External.Target, factor)
+			}
+		}
+
+		scaled[i] = &scaledMetric
+	}
+	return scaled
+}
+
+// scaleTarget multiplies the target by the factor, a target is never scaled below 1 (or 1m for quantities) so rounding
+// cannot produce a target of 0
+func scaleTarget(target *autoscalingv2.MetricTarget, factor float64) {
+	if target.AverageUtilization != nil {
+		utilization := int32(math.Max(math.Round(float64(*target.AverageUtilization)*factor), 1))
+		target.AverageUtilization = &utilization
+	}
+
+	if target.AverageValue != nil {
+		target.AverageValue = scaleQuantity(target.AverageValue, factor)
+	}
+
+	if target.Value != nil {
+		target.Value = scaleQuantity(target.Value, factor)
+	}
+}
+
+func scaleQuantity(quantity *resource.Quantity, factor float64) *resource.Quantity {
+	milliValue := math.Max(math.Round(float64(quantity.MilliValue())*factor), 1)
+	return resource.NewMilliQuantity(int64(milliValue), quantity.Format)
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carbon_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/carbon"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestFactor(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description string
+		expected    float64
+		expectedErr error
+		signal      float64
+		signalErr   error
+		baseline    float64
+		minFactor   float64
+		maxFactor   float64
+	}{
+		{
+			description: "Invalid baseline",
+			expectedErr: errors.New("invalid baseline 0.000000: must be greater than 0"),
+		},
+		{
+			description: "Fail to get signal",
+			expectedErr: errors.New("failed to get signal: fail to get signal"),
+			signalErr:   errors.New("fail to get signal"),
+			baseline:    200,
+		},
+		{
+			description: "Signal at baseline",
+			expected:    1,
+			signal:      200,
+			baseline:    200,
+			minFactor:   0.5,
+			maxFactor:   1.5,
+		},
+		{
+			description: "Signal within bounds",
+			expected:    1.25,
+			signal:      250,
+			baseline:    200,
+			minFactor:   0.5,
+			maxFactor:   1.5,
+		},
+		{
+			description: "Signal above max factor",
+			expected:    1.5,
+			signal:      800,
+			baseline:    200,
+			minFactor:   0.5,
+			maxFactor:   1.5,
+		},
+		{
+			description: "Signal below min factor",
+			expected:    0.5,
+			signal:      20,
+			baseline:    200,
+			minFactor:   0.5,
+			maxFactor:   1.5,
+		},
+		{
+			description: "Zero signal, default min factor",
+			expected:    carbon.DefaultMinFactor,
+			signal:      0,
+			baseline:    200,
+			maxFactor:   1.5,
+		},
+		{
+			description: "Negative min factor, default min factor",
+			expected:    carbon.DefaultMinFactor,
+			signal:      0,
+			baseline:    200,
+			minFactor:   -1,
+		},
+		{
+			description: "No max factor",
+			expected:    4,
+			signal:      800,
+			baseline:    200,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluator := &carbon.Evaluator{
+				Provider: carbon.SignalProviderFunc(func(ctx context.Context) (float64, error) {
+					return test.signal, test.signalErr
+				}),
+				Baseline:  test.baseline,
+				MinFactor: test.minFactor,
+				MaxFactor: test.maxFactor,
+			}
+			result, err := evaluator.Factor(context.Background())
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("factor mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestScaleTargets(t *testing.T) {
+	gatheredMetrics := []*metrics.Metric{
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						AverageUtilization: int32Ptr(50),
+					},
+				},
+			},
+		},
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						AverageValue: resource.NewMilliQuantity(10000, resource.DecimalSI),
+					},
+				},
+			},
+		},
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						Value: resource.NewMilliQuantity(3000, resource.DecimalSI),
+					},
+				},
+			},
+		},
+	}
+
+	expected := []*metrics.Metric{
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						AverageUtilization: int32Ptr(75),
+					},
+				},
+			},
+		},
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						AverageValue: resource.NewMilliQuantity(15000, resource.DecimalSI),
+					},
+				},
+			},
+		},
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						Value: resource.NewMilliQuantity(4500, resource.DecimalSI),
+					},
+				},
+			},
+		},
+	}
+
+	equateQuantity := cmp.Comparer(func(x, y resource.Quantity) bool { return x.Cmp(y) == 0 })
+
+	result := carbon.ScaleTargets(gatheredMetrics, 1.5)
+	if !cmp.Equal(expected, result, equateQuantity) {
+		t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(expected, result, equateQuantity))
+	}
+
+	if *gatheredMetrics[0].Spec.Resource.Target.AverageUtilization != 50 {
+		t.Errorf("original metrics modified")
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	var targetUtilization int32
+	evaluator := &carbon.Evaluator{
+		Evaluator: &k8shorizmetrics.Evaluator{
+			Resource: &fake.ResourceEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					targetUtilization = *gatheredMetric.Spec.Resource.Target.AverageUtilization
+					return 4, nil
+				},
+			},
+		},
+		Provider: carbon.SignalProviderFunc(func(ctx context.Context) (float64, error) {
+			return 100, nil
+		}),
+		Baseline:  200,
+		MinFactor: 0.5,
+		MaxFactor: 1.5,
+	}
+
+	result, err := evaluator.Evaluate(context.Background(), []*metrics.Metric{
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						AverageUtilization: int32Ptr(60),
+					},
+				},
+			},
+		},
+	}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result != 4 {
+		t.Errorf("evaluation mismatch, want 4, got %d", result)
+	}
+
+	if targetUtilization != 30 {
+		t.Errorf("target utilization mismatch, want 30, got %d", targetUtilization)
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestScaleTargetsFloor(t *testing.T) {
+	gatheredMetrics := []*metrics.Metric{
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						AverageUtilization: int32Ptr(2),
+						AverageValue:       resource.NewMilliQuantity(2, resource.DecimalSI),
+					},
+				},
+			},
+		},
+	}
+
+	result := carbon.ScaleTargets(gatheredMetrics, carbon.DefaultMinFactor)
+
+	target := result[0].Spec.Resource.Target
+	if *target.AverageUtilization != 1 {
+		t.Errorf("utilization mismatch, want 1 got %d", *target.AverageUtilization)
+	}
+	if target.AverageValue.MilliValue() != 1 {
+		t.Errorf("average value mismatch, want 1m got %dm", target.AverageValue.MilliValue())
+	}
+}
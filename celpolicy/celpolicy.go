@@ -0,0 +1,268 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package celpolicy provides a post-processing policy stage which evaluates CEL expressions against the evaluation
+// context to adjust or veto the final replica count recommendation, allowing organisation specific rules to be
+// defined declaratively.
+//
+// Each rule is a CEL expression which evaluates to either an int, which replaces the recommended replica count, or a
+// bool, where false vetoes the recommendation and keeps the current replica count. An int which is negative or too
+// large for a replica count fails the policy. Rules are applied in order, with each rule seeing the recommendation
+// produced by the rules before it. The following variables are available:
+//
+//	currentReplicas  int                     the current replica count
+//	replicas         int                     the recommended replica count
+//	proposals        list(map(string, dyn))  per metric proposals, each with a "type" and "replicas" key
+//	now              timestamp               the time of the evaluation
+//	history          map(string, dyn)        "count", "min", "max" and "mean" of recent recommendations
+//
+// For example, to keep at least 2 replicas during working hours:
+//
+//	now.getHours("Europe/London") >= 9 && replicas < 2 ? 2 : replicas
+//
+// Or to veto scale downs until at least 3 recommendations have been made:
+//
+//	replicas >= currentReplicas || history.count >= 3
+package celpolicy
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"k8s.io/utils/clock"
+)
+
+// Rule is a named CEL expression
+type Rule struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+}
+
+// Proposal is the replica count proposed by a single metric
+type Proposal struct {
+	Type     string `json:"type"`
+	Replicas int32  `json:"replicas"`
+}
+
+// Input is the evaluation context the policy rules are evaluated against
+type Input struct {
+	CurrentReplicas int32
+	Replicas        int32
+	Proposals       []Proposal
+	Time            time.Time
+	History         []int32
+}
+
+// Decision is the result of applying a policy. If a rule vetoed the recommendation Vetoed is true, VetoedBy is the
+// name of the rule and Replicas is the current replica count. AppliedRules are the names of rules that changed the
+// recommendation.
+type Decision struct {
+	Replicas     int32    `json:"replicas"`
+	Vetoed       bool     `json:"vetoed"`
+	VetoedBy     string   `json:"vetoedBy,omitempty"`
+	AppliedRules []string `json:"appliedRules,omitempty"`
+}
+
+type compiledRule struct {
+	name    string
+	program cel.Program
+}
+
+// Policy is a compiled list of rules
+type Policy struct {
+	rules []compiledRule
+}
+
+// New compiles the rules provided into a Policy
+func New(rules []Rule) (*Policy, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("currentReplicas", cel.IntType),
+		cel.Variable("replicas", cel.IntType),
+		cel.Variable("proposals", cel.ListType(cel.MapType(cel.StringType, cel.DynType))),
+		cel.Variable("now", cel.TimestampType),
+		cel.Variable("history", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up CEL environment: %w", err)
+	}
+
+	policy := &Policy{}
+	for _, rule := range rules {
+		ast, issues := env.Compile(rule.Expression)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("invalid rule %s: %w", rule.Name, issues.Err())
+		}
+
+		if ast.OutputType() != cel.IntType && ast.OutputType() != cel.BoolType {
+			return nil, fmt.Errorf("invalid rule %s: expression must evaluate to an int or a bool, got %s", rule.Name,
+				ast.OutputType())
+		}
+
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %s: %w", rule.Name, err)
+		}
+
+		policy.rules = append(policy.rules, compiledRule{
+			name:    rule.Name,
+			program: program,
+		})
+	}
+
+	return policy, nil
+}
+
+// Apply evaluates the policy's rules in order against the input provided
+func (p *Policy) Apply(input Input) (*Decision, error) {
+	proposals := make([]map[string]any, len(input.Proposals))
+	for i, proposal := range input.Proposals {
+		proposals[i] = map[string]any{
+			"type":     proposal.Type,
+			"replicas": int64(proposal.Replicas),
+		}
+	}
+
+	decision := &Decision{
+		Replicas: input.Replicas,
+	}
+
+	for _, rule := range p.rules {
+		out, _, err := rule.program.Eval(map[string]any{
+			"currentReplicas": int64(input.CurrentReplicas),
+			"replicas":        int64(decision.Replicas),
+			"proposals":       proposals,
+			"now":             input.Time,
+			"history":         historyStats(input.History),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate rule %s: %w", rule.name, err)
+		}
+
+		switch value := out.Value().(type) {
+		case bool:
+			if !value {
+				return &Decision{
+					Replicas:     input.CurrentReplicas,
+					Vetoed:       true,
+					VetoedBy:     rule.name,
+					AppliedRules: decision.AppliedRules,
+				}, nil
+			}
+		case int64:
+			if value < 0 || value > math.MaxInt32 {
+				return nil, fmt.Errorf("rule %s evaluated to %d replicas, must be between 0 and %d", rule.name, value,
+					math.MaxInt32)
+			}
+			if int32(value) != decision.Replicas {
+				decision.Replicas = int32(value)
+				decision.AppliedRules = append(decision.AppliedRules, rule.name)
+			}
+		default:
+			return nil, fmt.Errorf("rule %s evaluated to unsupported type %s", rule.name, out.Type().TypeName())
+		}
+	}
+
+	return decision, nil
+}
+
+func historyStats(history []int32) map[string]any {
+	stats := map[string]any{
+		"count": int64(len(history)),
+		"min":   int64(0),
+		"max":   int64(0),
+		"mean":  float64(0),
+	}
+
+	if len(history) == 0 {
+		return stats
+	}
+
+	minReplicas := history[0]
+	maxReplicas := history[0]
+	var total int64
+	for _, replicas := range history {
+		if replicas < minReplicas {
+			minReplicas = replicas
+		}
+		if replicas > maxReplicas {
+			maxReplicas = replicas
+		}
+		total += int64(replicas)
+	}
+
+	stats["min"] = int64(minReplicas)
+	stats["max"] = int64(maxReplicas)
+	stats["mean"] = float64(total) / float64(len(history))
+
+	return stats
+}
+
+// Evaluator wraps an Evaluator, evaluating each metric individually to produce per metric proposals, taking the
+// highest proposal as the recommendation and then applying the Policy to it
+type Evaluator struct {
+	Evaluator *k8shorizmetrics.Evaluator
+	Policy    *Policy
+	Clock     clock.PassiveClock
+}
+
+// Evaluate returns the policy decision for the metrics provided, history is a list of recent recommendations made
+// available to the policy rules.
+// If an error occurs evaluating any metric this will return a EvaluatorMultiMetricError and the policy is not applied.
+func (e *Evaluator) Evaluate(gatheredMetrics []*metrics.Metric, currentReplicas int32, history []int32) (*Decision, error) {
+	var evaluationErrors []error
+	var replicas int32
+	var proposals []Proposal
+	for i, gatheredMetric := range gatheredMetrics {
+		proposedReplicas, err := e.Evaluator.EvaluateSingleMetric(gatheredMetric, currentReplicas)
+		if err != nil {
+			evaluationErrors = append(evaluationErrors, err)
+			continue
+		}
+
+		if i == 0 || proposedReplicas > replicas {
+			replicas = proposedReplicas
+		}
+
+		proposals = append(proposals, Proposal{
+			Type:     string(gatheredMetric.Spec.Type),
+			Replicas: proposedReplicas,
+		})
+	}
+
+	if len(evaluationErrors) > 0 {
+		return nil, &k8shorizmetrics.EvaluatorMultiMetricError{
+			Partial: len(evaluationErrors) < len(gatheredMetrics),
+			Errors:  evaluationErrors,
+		}
+	}
+
+	now := time.Now()
+	if e.Clock != nil {
+		now = e.Clock.Now()
+	}
+
+	return e.Policy.Apply(Input{
+		CurrentReplicas: currentReplicas,
+		Replicas:        replicas,
+		Proposals:       proposals,
+		Time:            now,
+		History:         history,
+	})
+}
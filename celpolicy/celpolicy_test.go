@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celpolicy_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/celpolicy"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestNew(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description string
+		expectedErr error
+		rules       []celpolicy.Rule
+	}{
+		{
+			description: "Undeclared variable",
+			expectedErr: errors.New("invalid rule test: ERROR: <input>:1:1: undeclared reference to 'unknown' (in container '')\n | unknown > 1\n | ^"),
+			rules: []celpolicy.Rule{
+				{Name: "test", Expression: "unknown > 1"},
+			},
+		},
+		{
+			description: "Unsupported output type",
+			expectedErr: errors.New("invalid rule test: expression must evaluate to an int or a bool, got string"),
+			rules: []celpolicy.Rule{
+				{Name: "test", Expression: `"string"`},
+			},
+		},
+		{
+			description: "Valid rules",
+			rules: []celpolicy.Rule{
+				{Name: "int", Expression: "replicas + 1"},
+				{Name: "bool", Expression: "replicas > currentReplicas"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			_, err := celpolicy.New(test.rules)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	workingHours := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	night := time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		description string
+		expected    *celpolicy.Decision
+		expectedErr error
+		rules       []celpolicy.Rule
+		input       celpolicy.Input
+	}{
+		{
+			description: "Runtime error",
+			expectedErr: errors.New("failed to evaluate rule test: no such key: missing"),
+			rules: []celpolicy.Rule{
+				{Name: "test", Expression: "int(history.missing)"},
+			},
+			input: celpolicy.Input{Time: workingHours},
+		},
+		{
+			description: "Negative replica count",
+			expectedErr: errors.New("rule negative evaluated to -1 replicas, must be between 0 and 2147483647"),
+			rules: []celpolicy.Rule{
+				{Name: "negative", Expression: "replicas - 6"},
+			},
+			input: celpolicy.Input{CurrentReplicas: 3, Replicas: 5, Time: workingHours},
+		},
+		{
+			description: "Replica count too large",
+			expectedErr: errors.New("rule too-large evaluated to 4294967296 replicas, must be between 0 and 2147483647"),
+			rules: []celpolicy.Rule{
+				{Name: "too-large", Expression: "4294967296"},
+			},
+			input: celpolicy.Input{CurrentReplicas: 3, Replicas: 5, Time: workingHours},
+		},
+		{
+			description: "No rules, recommendation unchanged",
+			expected: &celpolicy.Decision{
+				Replicas: 5,
+			},
+			input: celpolicy.Input{CurrentReplicas: 3, Replicas: 5, Time: workingHours},
+		},
+		{
+			description: "Minimum during working hours applied",
+			expected: &celpolicy.Decision{
+				Replicas:     2,
+				AppliedRules: []string{"working-hours-minimum"},
+			},
+			rules: []celpolicy.Rule{
+				{Name: "working-hours-minimum", Expression: `now.getHours("UTC") >= 9 && replicas < 2 ? 2 : replicas`},
+			},
+			input: celpolicy.Input{CurrentReplicas: 3, Replicas: 1, Time: workingHours},
+		},
+		{
+			description: "Minimum during working hours not applied at night",
+			expected: &celpolicy.Decision{
+				Replicas: 1,
+			},
+			rules: []celpolicy.Rule{
+				{Name: "working-hours-minimum", Expression: `now.getHours("UTC") >= 9 && replicas < 2 ? 2 : replicas`},
+			},
+			input: celpolicy.Input{CurrentReplicas: 3, Replicas: 1, Time: night},
+		},
+		{
+			description: "Rules chained, veto keeps current replicas",
+			expected: &celpolicy.Decision{
+				Replicas:     3,
+				Vetoed:       true,
+				VetoedBy:     "no-early-scale-down",
+				AppliedRules: []string{"halve"},
+			},
+			rules: []celpolicy.Rule{
+				{Name: "halve", Expression: "replicas / 2"},
+				{Name: "no-early-scale-down", Expression: "replicas >= currentReplicas || history.count >= 3"},
+			},
+			input: celpolicy.Input{CurrentReplicas: 3, Replicas: 4, Time: workingHours, History: []int32{4, 4}},
+		},
+		{
+			description: "Proposals and history stats available",
+			expected: &celpolicy.Decision{
+				Replicas:     6,
+				AppliedRules: []string{"history-max"},
+			},
+			rules: []celpolicy.Rule{
+				{Name: "has-resource", Expression: `proposals.exists(p, p.type == "Resource" && p.replicas == 4)`},
+				{Name: "history-max", Expression: `history.mean > 4.0 ? int(history.max) : replicas`},
+			},
+			input: celpolicy.Input{
+				CurrentReplicas: 3,
+				Replicas:        4,
+				Proposals: []celpolicy.Proposal{
+					{Type: "Resource", Replicas: 4},
+				},
+				Time:    workingHours,
+				History: []int32{3, 6, 5},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			policy, err := celpolicy.New(test.rules)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			result, err := policy.Apply(test.input)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("decision mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	policy, err := celpolicy.New([]celpolicy.Rule{
+		{Name: "cap", Expression: "replicas > 5 ? 5 : replicas"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	evaluator := &celpolicy.Evaluator{
+		Evaluator: &k8shorizmetrics.Evaluator{
+			Resource: &fake.ResourceEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 4, nil
+				},
+			},
+			Pods: &fake.PodsEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+					return 8
+				},
+			},
+		},
+		Policy: policy,
+		Clock:  clocktesting.NewFakePassiveClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	result, err := evaluator.Evaluate([]*metrics.Metric{
+		{Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ResourceMetricSourceType}},
+		{Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.PodsMetricSourceType}},
+	}, 3, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &celpolicy.Decision{
+		Replicas:     5,
+		AppliedRules: []string{"cap"},
+	}
+	if !cmp.Equal(expected, result) {
+		t.Errorf("decision mismatch (-want +got):\n%s", cmp.Diff(expected, result))
+	}
+}
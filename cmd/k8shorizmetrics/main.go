@@ -0,0 +1,305 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command k8shorizmetrics gathers metrics for a set of pods using a kubeconfig and prints the metrics gathered along
+// with the replica count the Horizontal Pod Autoscaler would recommend.
+//
+// The pods can be selected using a label selector or the name of a deployment, and the metric specs can be provided
+// as a YAML or JSON file in the same format as the metrics of a HorizontalPodAutoscaler, or with flags for common
+// resource metrics, for example:
+//
+//	k8shorizmetrics -namespace default -deployment php-apache -cpu-utilization 50
+//	k8shorizmetrics -namespace default -selector run=php-apache -replicas 3 -metrics metrics.yaml -output json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/events"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podsclient"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/homedir"
+)
+
+// Output formats supported by the -output flag
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+type config struct {
+	kubeconfig              string
+	namespace               string
+	selector                string
+	deployment              string
+	metricsFile             string
+	cpuUtilization          int
+	memoryUtilization       int
+	replicas                int
+	tolerance               float64
+	cpuInitializationPeriod time.Duration
+	initialReadinessDelay   time.Duration
+	output                  string
+}
+
+func main() {
+	cfg, err := parseFlags(os.Args[1:], os.Stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if err := run(context.Background(), cfg, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// parseFlags parses and validates the command line flags provided
+func parseFlags(args []string, output io.Writer) (*config, error) {
+	cfg := &config{}
+
+	defaultKubeconfig := os.Getenv("KUBECONFIG")
+	if defaultKubeconfig == "" && homedir.HomeDir() != "" {
+		defaultKubeconfig = filepath.Join(homedir.HomeDir(), ".kube", "config")
+	}
+
+	flags := flag.NewFlagSet("k8shorizmetrics", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.StringVar(&cfg.kubeconfig, "kubeconfig", defaultKubeconfig, "path to the kubeconfig file")
+	flags.StringVar(&cfg.namespace, "namespace", "default", "namespace of the pods to gather metrics for")
+	flags.StringVar(&cfg.selector, "selector", "", "label selector of the pods to gather metrics for")
+	flags.StringVar(&cfg.deployment, "deployment", "",
+		"name of the deployment to gather metrics for, used to find the pod selector and current replica count")
+	flags.StringVar(&cfg.metricsFile, "metrics", "", "path to a YAML or JSON file of metric specs")
+	flags.IntVar(&cfg.cpuUtilization, "cpu-utilization", 0, "target average CPU utilization percentage")
+	flags.IntVar(&cfg.memoryUtilization, "memory-utilization", 0, "target average memory utilization percentage")
+	flags.IntVar(&cfg.replicas, "replicas", 0, "current replica count, defaults to the deployment's replica count")
+	flags.Float64Var(&cfg.tolerance, "tolerance", 0.1, "tolerance of the usage ratio before the replica count changes")
+	flags.DurationVar(&cfg.cpuInitializationPeriod, "cpu-initialization-period", 5*time.Minute,
+		"period after a pod starts during which its CPU samples may be ignored")
+	flags.DurationVar(&cfg.initialReadinessDelay, "initial-readiness-delay", 30*time.Second,
+		"period after a pod starts during which its readiness changes are treated as initial readiness")
+	flags.StringVar(&cfg.output, "output", outputText, "output format, either text or json")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if flags.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	if (cfg.selector == "") == (cfg.deployment == "") {
+		return nil, errors.New("exactly one of -selector or -deployment must be set")
+	}
+	if cfg.deployment == "" && cfg.replicas <= 0 {
+		return nil, errors.New("-replicas must be set to a positive replica count when using -selector")
+	}
+	if cfg.metricsFile == "" && cfg.cpuUtilization == 0 && cfg.memoryUtilization == 0 {
+		return nil, errors.New("at least one of -metrics, -cpu-utilization or -memory-utilization must be set")
+	}
+	if cfg.output != outputText && cfg.output != outputJSON {
+		return nil, fmt.Errorf("unsupported output format '%s', must be text or json", cfg.output)
+	}
+
+	return cfg, nil
+}
+
+// metricSpecs returns the metric specs configured by the metrics file and resource utilization flags
+func metricSpecs(cfg *config) ([]autoscalingv2.MetricSpec, error) {
+	var specs []autoscalingv2.MetricSpec
+	if cfg.metricsFile != "" {
+		data, err := os.ReadFile(cfg.metricsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read metrics file: %w", err)
+		}
+
+		specs, err = k8shorizmetrics.ParseMetricSpecs(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.cpuUtilization > 0 {
+		specs = append(specs, k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceCPU, int32(cfg.cpuUtilization)))
+	}
+	if cfg.memoryUtilization > 0 {
+		specs = append(specs, k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceMemory,
+			int32(cfg.memoryUtilization)))
+	}
+
+	for i, spec := range specs {
+		if err := k8shorizmetrics.ValidateMetricSpec(spec); err != nil {
+			return nil, fmt.Errorf("invalid metric spec at index %d: %w", i, err)
+		}
+	}
+
+	if len(specs) == 0 {
+		return nil, errors.New("no metric specs provided")
+	}
+
+	return specs, nil
+}
+
+func run(ctx context.Context, cfg *config, stdout io.Writer, stderr io.Writer) error {
+	specs, err := metricSpecs(cfg)
+	if err != nil {
+		return err
+	}
+
+	clusterConfig, err := clientcmd.BuildConfigFromFlags("", cfg.kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(clusterConfig)
+	if err != nil {
+		return fmt.Errorf("failed to set up Kubernetes clientset: %w", err)
+	}
+
+	currentReplicas := int32(cfg.replicas)
+	var podSelector labels.Selector
+	if cfg.deployment != "" {
+		scale, err := clientset.AppsV1().Deployments(cfg.namespace).GetScale(ctx, cfg.deployment, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get scale subresource for deployment '%s': %w", cfg.deployment, err)
+		}
+
+		podSelector, err = labels.Parse(scale.Status.Selector)
+		if err != nil {
+			return fmt.Errorf("failed to parse selector of deployment '%s': %w", cfg.deployment, err)
+		}
+
+		if currentReplicas == 0 {
+			currentReplicas = scale.Spec.Replicas
+		}
+	} else {
+		podSelector, err = labels.Parse(cfg.selector)
+		if err != nil {
+			return fmt.Errorf("failed to parse selector: %w", err)
+		}
+	}
+
+	gatherer := k8shorizmetrics.NewGatherer(metricsclient.NewClient(clusterConfig, clientset.Discovery()),
+		&podsclient.OnDemandPodLister{Clientset: clientset}, cfg.cpuInitializationPeriod, cfg.initialReadinessDelay)
+	evaluator := k8shorizmetrics.NewEvaluator(cfg.tolerance)
+
+	gathered, err := gatherer.GatherWithContext(ctx, specs, cfg.namespace, podSelector)
+	if err != nil {
+		var multiErr *k8shorizmetrics.GathererMultiMetricError
+		if !errors.As(err, &multiErr) || !multiErr.Partial {
+			return fmt.Errorf("failed to gather metrics: %w", err)
+		}
+		for _, specErr := range multiErr.MetricSpecErrors() {
+			fmt.Fprintf(stderr, "failed to gather %s: %v\n", events.MetricName(specErr.Spec), specErr.Err)
+		}
+	}
+
+	details, err := evaluator.EvaluateWithContextAndDetails(ctx, gathered, currentReplicas)
+	if err != nil {
+		var multiErr *k8shorizmetrics.EvaluatorMultiMetricError
+		if !errors.As(err, &multiErr) || !multiErr.Partial {
+			return fmt.Errorf("failed to evaluate metrics: %w", err)
+		}
+	}
+
+	return writeOutput(stdout, cfg.output, currentReplicas, details)
+}
+
+// output is the result of gathering and evaluating metrics, printed by the json output format
+type output struct {
+	CurrentReplicas int32          `json:"currentReplicas"`
+	Replicas        int32          `json:"replicas"`
+	Metrics         []outputMetric `json:"metrics"`
+}
+
+type outputMetric struct {
+	Name       string          `json:"name"`
+	Replicas   int32           `json:"replicas"`
+	UsageRatio *float64        `json:"usageRatio,omitempty"`
+	Winning    bool            `json:"winning"`
+	Error      string          `json:"error,omitempty"`
+	Metric     *metrics.Metric `json:"metric"`
+}
+
+// writeOutput writes the evaluation provided in the output format provided
+func writeOutput(w io.Writer, format string, currentReplicas int32, details *k8shorizmetrics.DetailedEvaluation) error {
+	result := output{
+		CurrentReplicas: currentReplicas,
+		Replicas:        details.Replicas,
+		Metrics:         make([]outputMetric, len(details.Metrics)),
+	}
+	for i, evaluation := range details.Metrics {
+		result.Metrics[i] = outputMetric{
+			Name:       events.MetricName(evaluation.Metric.Spec),
+			Replicas:   evaluation.Replicas,
+			UsageRatio: evaluation.UsageRatio,
+			Winning:    evaluation.Winning,
+			Metric:     evaluation.Metric,
+		}
+		if evaluation.Err != nil {
+			result.Metrics[i].Error = evaluation.Err.Error()
+		}
+	}
+
+	if format == outputJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "METRIC\tUSAGE RATIO\tREPLICAS")
+	for _, metric := range result.Metrics {
+		usageRatio := "-"
+		if metric.UsageRatio != nil {
+			usageRatio = fmt.Sprintf("%.2f", *metric.UsageRatio)
+		}
+		replicas := fmt.Sprintf("%d", metric.Replicas)
+		if metric.Error != "" {
+			replicas = "error: " + metric.Error
+		} else if metric.Winning {
+			replicas += " (winning)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", metric.Name, usageRatio, replicas)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\nCurrent replicas: %d\nRecommended replicas: %d\n", result.CurrentReplicas, result.Replicas)
+	return err
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func TestParseFlags(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description string
+		expected    *config
+		expectedErr error
+		args        []string
+	}{
+		{
+			description: "Neither selector nor deployment",
+			expectedErr: errors.New("exactly one of -selector or -deployment must be set"),
+			args:        []string{"-cpu-utilization", "50"},
+		},
+		{
+			description: "Both selector and deployment",
+			expectedErr: errors.New("exactly one of -selector or -deployment must be set"),
+			args:        []string{"-selector", "app=test", "-deployment", "test", "-cpu-utilization", "50"},
+		},
+		{
+			description: "Selector without replicas",
+			expectedErr: errors.New("-replicas must be set to a positive replica count when using -selector"),
+			args:        []string{"-selector", "app=test", "-cpu-utilization", "50"},
+		},
+		{
+			description: "No metrics",
+			expectedErr: errors.New("at least one of -metrics, -cpu-utilization or -memory-utilization must be set"),
+			args:        []string{"-deployment", "test"},
+		},
+		{
+			description: "Unsupported output",
+			expectedErr: errors.New("unsupported output format 'yaml', must be text or json"),
+			args:        []string{"-deployment", "test", "-cpu-utilization", "50", "-output", "yaml"},
+		},
+		{
+			description: "Unexpected arguments",
+			expectedErr: errors.New("unexpected arguments: [extra]"),
+			args:        []string{"-deployment", "test", "-cpu-utilization", "50", "extra"},
+		},
+		{
+			description: "Deployment with defaults",
+			expected: &config{
+				kubeconfig:              "/tmp/kubeconfig",
+				namespace:               "default",
+				deployment:              "test",
+				cpuUtilization:          50,
+				tolerance:               0.1,
+				cpuInitializationPeriod: 5 * time.Minute,
+				initialReadinessDelay:   30 * time.Second,
+				output:                  outputText,
+			},
+			args: []string{"-kubeconfig", "/tmp/kubeconfig", "-deployment", "test", "-cpu-utilization", "50"},
+		},
+		{
+			description: "Selector with all options",
+			expected: &config{
+				kubeconfig:              "/tmp/kubeconfig",
+				namespace:               "test",
+				selector:                "app=test",
+				metricsFile:             "metrics.yaml",
+				memoryUtilization:       70,
+				replicas:                3,
+				tolerance:               0.2,
+				cpuInitializationPeriod: time.Minute,
+				initialReadinessDelay:   time.Second,
+				output:                  outputJSON,
+			},
+			args: []string{
+				"-kubeconfig", "/tmp/kubeconfig",
+				"-namespace", "test",
+				"-selector", "app=test",
+				"-metrics", "metrics.yaml",
+				"-memory-utilization", "70",
+				"-replicas", "3",
+				"-tolerance", "0.2",
+				"-cpu-initialization-period", "1m",
+				"-initial-readiness-delay", "1s",
+				"-output", "json",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := parseFlags(test.args, io.Discard)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result, cmp.AllowUnexported(config{})) {
+				t.Errorf("config mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, cmp.AllowUnexported(config{})))
+			}
+		})
+	}
+}
+
+func TestMetricSpecs(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	dir := t.TempDir()
+	validFile := filepath.Join(dir, "valid.yaml")
+	err := os.WriteFile(validFile, []byte(`metrics:
+- type: Resource
+  resource:
+    name: cpu
+    target:
+      type: Utilization
+      averageUtilization: 60
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalidFile := filepath.Join(dir, "invalid.yaml")
+	err = os.WriteFile(invalidFile, []byte(`- type: Pods
+  pods:
+    metric:
+      name: requests
+    target:
+      type: AverageValue
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		description string
+		expected    []autoscalingv2.MetricSpec
+		expectedErr error
+		cfg         *config
+	}{
+		{
+			description: "Missing file",
+			expectedErr: errors.New("failed to read metrics file: open " + filepath.Join(dir, "missing.yaml") +
+				": no such file or directory"),
+			cfg: &config{metricsFile: filepath.Join(dir, "missing.yaml")},
+		},
+		{
+			description: "Invalid spec in file",
+			expectedErr: errors.New("invalid metric spec at index 0: invalid pods metric source: average value target " +
+				"must set averageValue"),
+			cfg: &config{metricsFile: invalidFile},
+		},
+		{
+			description: "No specs",
+			expectedErr: errors.New("no metric specs provided"),
+			cfg:         &config{},
+		},
+		{
+			description: "File and flags",
+			expected: []autoscalingv2.MetricSpec{
+				k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceCPU, 60),
+				k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceCPU, 50),
+				k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceMemory, 70),
+			},
+			cfg: &config{
+				metricsFile:       validFile,
+				cpuUtilization:    50,
+				memoryUtilization: 70,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := metricSpecs(test.cfg)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("specs mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	cpuMetric := &metrics.Metric{
+		Spec: k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceCPU, 50),
+	}
+	memoryMetric := &metrics.Metric{
+		Spec: k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceMemory, 50),
+	}
+
+	details := &k8shorizmetrics.DetailedEvaluation{
+		Replicas: 4,
+		Metrics: []*k8shorizmetrics.MetricEvaluation{
+			{
+				Metric:     cpuMetric,
+				Replicas:   4,
+				UsageRatio: float64Ptr(2),
+				Winning:    true,
+			},
+			{
+				Metric: memoryMetric,
+				Err:    errors.New("fail"),
+			},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expected    string
+		format      string
+	}{
+		{
+			description: "Text",
+			expected: `METRIC                                               USAGE RATIO  REPLICAS
+cpu resource utilization (percentage of request)     2.00         4 (winning)
+memory resource utilization (percentage of request)  -            error: fail
+
+Current replicas: 2
+Recommended replicas: 4
+`,
+			format: outputText,
+		},
+		{
+			description: "JSON",
+			expected: `{
+  "currentReplicas": 2,
+  "replicas": 4,
+  "metrics": [
+    {
+      "name": "cpu resource utilization (percentage of request)",
+      "replicas": 4,
+      "usageRatio": 2,
+      "winning": true,
+      "metric": {
+        "spec": {
+          "type": "Resource",
+          "resource": {
+            "name": "cpu",
+            "target": {
+              "type": "Utilization",
+              "averageUtilization": 50
+            }
+          }
+        }
+      }
+    },
+    {
+      "name": "memory resource utilization (percentage of request)",
+      "replicas": 0,
+      "winning": false,
+      "error": "fail",
+      "metric": {
+        "spec": {
+          "type": "Resource",
+          "resource": {
+            "name": "memory",
+            "target": {
+              "type": "Utilization",
+              "averageUtilization": 50
+            }
+          }
+        }
+      }
+    }
+  ]
+}
+`,
+			format: outputJSON,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := writeOutput(&buf, test.format, 2, details)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(test.expected, buf.String()) {
+				t.Errorf("output mismatch (-want +got):\n%s", cmp.Diff(test.expected, buf.String()))
+			}
+		})
+	}
+}
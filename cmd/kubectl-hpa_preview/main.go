@@ -0,0 +1,328 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command kubectl-hpa_preview is a kubectl plugin which reads an existing HorizontalPodAutoscaler and prints what
+// k8shorizmetrics would compute for it right now, including the value and proposed replica count of each metric, the
+// desired replica count and any limiting factors, to help troubleshoot differences with the HPA controller.
+//
+// Once installed on the PATH it can be run as a kubectl plugin, for example:
+//
+//	kubectl hpa-preview -n default php-apache
+//
+// The preview has no recommendation history, so unlike the HPA controller scale down stabilization only considers
+// the current recommendation.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/events"
+	"github.com/jthomperoo/k8shorizmetrics/v4/hpa"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podsclient"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cacheddiscovery "k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+	k8sscale "k8s.io/client-go/scale"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/homedir"
+)
+
+// Output formats supported by the -output flag
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+type config struct {
+	kubeconfig              string
+	namespace               string
+	name                    string
+	tolerance               float64
+	cpuInitializationPeriod time.Duration
+	initialReadinessDelay   time.Duration
+	output                  string
+}
+
+func main() {
+	cfg, err := parseFlags(os.Args[1:], os.Stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if err := run(context.Background(), cfg, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// parseFlags parses and validates the command line flags provided, the only argument is the name of the HPA
+func parseFlags(args []string, output io.Writer) (*config, error) {
+	cfg := &config{}
+
+	defaultKubeconfig := os.Getenv("KUBECONFIG")
+	if defaultKubeconfig == "" && homedir.HomeDir() != "" {
+		defaultKubeconfig = filepath.Join(homedir.HomeDir(), ".kube", "config")
+	}
+
+	flags := flag.NewFlagSet("kubectl-hpa_preview", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: kubectl hpa-preview [flags] NAME")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&cfg.kubeconfig, "kubeconfig", defaultKubeconfig, "path to the kubeconfig file")
+	flags.StringVar(&cfg.namespace, "namespace", "default", "namespace of the HorizontalPodAutoscaler")
+	flags.StringVar(&cfg.namespace, "n", "default", "namespace of the HorizontalPodAutoscaler (shorthand)")
+	flags.Float64Var(&cfg.tolerance, "tolerance", 0.1, "tolerance of the usage ratio before the replica count changes")
+	flags.DurationVar(&cfg.cpuInitializationPeriod, "cpu-initialization-period", 5*time.Minute,
+		"period after a pod starts during which its CPU samples may be ignored")
+	flags.DurationVar(&cfg.initialReadinessDelay, "initial-readiness-delay", 30*time.Second,
+		"period after a pod starts during which its readiness changes are treated as initial readiness")
+	flags.StringVar(&cfg.output, "output", outputText, "output format, either text or json")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if flags.NArg() != 1 {
+		return nil, errors.New("exactly one HorizontalPodAutoscaler name must be provided")
+	}
+	cfg.name = flags.Arg(0)
+
+	if cfg.output != outputText && cfg.output != outputJSON {
+		return nil, fmt.Errorf("unsupported output format '%s', must be text or json", cfg.output)
+	}
+
+	return cfg, nil
+}
+
+func run(ctx context.Context, cfg *config, stdout io.Writer, stderr io.Writer) error {
+	clusterConfig, err := clientcmd.BuildConfigFromFlags("", cfg.kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(clusterConfig)
+	if err != nil {
+		return fmt.Errorf("failed to set up Kubernetes clientset: %w", err)
+	}
+
+	restMapper := restmapper.NewDeferredDiscoveryRESTMapper(cacheddiscovery.NewMemCacheClient(clientset.Discovery()))
+	scaleClient, err := k8sscale.NewForConfig(clusterConfig, restMapper, dynamic.LegacyAPIPathResolverFunc,
+		k8sscale.NewDiscoveryScaleKindResolver(clientset.Discovery()))
+	if err != nil {
+		return fmt.Errorf("failed to set up scale client: %w", err)
+	}
+
+	autoscaler, err := clientset.AutoscalingV2().HorizontalPodAutoscalers(cfg.namespace).Get(ctx, cfg.name,
+		metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get HorizontalPodAutoscaler '%s': %w", cfg.name, err)
+	}
+
+	// Evaluating metrics can adjust them for missing pods, so take a copy of each metric as it is gathered to
+	// evaluate each metric separately for the preview
+	snapshot := &gatheredSnapshot{}
+	gatherer := k8shorizmetrics.NewGathererWithOptions(metricsclient.NewClient(clusterConfig, clientset.Discovery()),
+		&podsclient.OnDemandPodLister{Clientset: clientset},
+		k8shorizmetrics.WithCPUInitializationPeriod(cfg.cpuInitializationPeriod),
+		k8shorizmetrics.WithDelayOfInitialReadinessStatus(cfg.initialReadinessDelay),
+		k8shorizmetrics.WithScaleClient(scaleClient),
+		k8shorizmetrics.WithRESTMapper(restMapper),
+		k8shorizmetrics.WithPostGatherHook(snapshot.record))
+	evaluator := k8shorizmetrics.NewEvaluator(cfg.tolerance)
+
+	result, err := hpa.NewAutoscaler(gatherer, evaluator).EvaluateWithContext(ctx, autoscaler)
+	if result == nil {
+		return fmt.Errorf("failed to preview HorizontalPodAutoscaler '%s': %w", cfg.name, err)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "some metrics failed, the replica count will not be scaled down: %v\n", err)
+	}
+
+	var details *k8shorizmetrics.DetailedEvaluation
+	if gathered := snapshot.ordered(autoscaler.Spec.Metrics); len(gathered) > 0 {
+		details, _ = evaluator.EvaluateWithContextAndDetails(ctx, gathered, result.CurrentReplicas)
+	}
+
+	return writeOutput(stdout, cfg.output, newPreview(autoscaler, result, details))
+}
+
+// gatheredSnapshot records a copy of each metric successfully gathered
+type gatheredSnapshot struct {
+	mu       sync.Mutex
+	gathered []*metrics.Metric
+}
+
+func (s *gatheredSnapshot) record(ctx context.Context, spec autoscalingv2.MetricSpec, gathered *metrics.Metric,
+	err error) (*metrics.Metric, error) {
+	if err == nil && gathered != nil {
+		s.mu.Lock()
+		s.gathered = append(s.gathered, gathered.DeepCopy())
+		s.mu.Unlock()
+	}
+	return gathered, err
+}
+
+// ordered returns the metrics recorded in the order of the specs provided, as metrics may be gathered concurrently
+func (s *gatheredSnapshot) ordered(specs []autoscalingv2.MetricSpec) []*metrics.Metric {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	used := make([]bool, len(s.gathered))
+	var ordered []*metrics.Metric
+	for _, spec := range specs {
+		for i, gathered := range s.gathered {
+			if !used[i] && equality.Semantic.DeepEqual(spec, gathered.Spec) {
+				used[i] = true
+				ordered = append(ordered, gathered)
+				break
+			}
+		}
+	}
+	return ordered
+}
+
+// preview is what k8shorizmetrics computes for an HPA, alongside the HPA controller's last desired replica count
+type preview struct {
+	Namespace                 string                 `json:"namespace"`
+	Name                      string                 `json:"name"`
+	CurrentReplicas           int32                  `json:"currentReplicas"`
+	MetricReplicas            *int32                 `json:"metricReplicas,omitempty"`
+	DesiredReplicas           int32                  `json:"desiredReplicas"`
+	ControllerDesiredReplicas int32                  `json:"controllerDesiredReplicas"`
+	ScalingDisabled           bool                   `json:"scalingDisabled"`
+	StabilizedReplicas        int32                  `json:"stabilizedReplicas"`
+	StabilizationReason       k8shorizmetrics.Reason `json:"stabilizationReason,omitempty"`
+	LimitReason               k8shorizmetrics.Reason `json:"limitReason,omitempty"`
+	Metrics                   []previewMetric        `json:"metrics"`
+}
+
+type previewMetric struct {
+	Name       string          `json:"name"`
+	Replicas   int32           `json:"replicas"`
+	UsageRatio *float64        `json:"usageRatio,omitempty"`
+	Winning    bool            `json:"winning"`
+	Error      string          `json:"error,omitempty"`
+	Metric     *metrics.Metric `json:"metric"`
+}
+
+func newPreview(autoscaler *autoscalingv2.HorizontalPodAutoscaler, result *hpa.Result,
+	details *k8shorizmetrics.DetailedEvaluation) *preview {
+	p := &preview{
+		Namespace:                 autoscaler.Namespace,
+		Name:                      autoscaler.Name,
+		CurrentReplicas:           result.CurrentReplicas,
+		DesiredReplicas:           result.DesiredReplicas,
+		ControllerDesiredReplicas: autoscaler.Status.DesiredReplicas,
+		ScalingDisabled:           result.Normalization == nil,
+		Metrics:                   []previewMetric{},
+	}
+
+	if result.Normalization != nil {
+		p.StabilizedReplicas = result.Normalization.StabilizedReplicas
+		p.StabilizationReason = result.Normalization.StabilizationReason
+		p.LimitReason = result.Normalization.LimitReason
+	}
+
+	if details != nil {
+		p.MetricReplicas = &details.Replicas
+		for _, evaluation := range details.Metrics {
+			metric := previewMetric{
+				Name:       events.MetricName(evaluation.Metric.Spec),
+				Replicas:   evaluation.Replicas,
+				UsageRatio: evaluation.UsageRatio,
+				Winning:    evaluation.Winning,
+				Metric:     evaluation.Metric,
+			}
+			if evaluation.Err != nil {
+				metric.Error = evaluation.Err.Error()
+			}
+			p.Metrics = append(p.Metrics, metric)
+		}
+	}
+
+	return p
+}
+
+// writeOutput writes the preview provided in the output format provided
+func writeOutput(w io.Writer, format string, p *preview) error {
+	if format == outputJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(p)
+	}
+
+	fmt.Fprintf(w, "HorizontalPodAutoscaler: %s/%s\n\n", p.Namespace, p.Name)
+
+	if p.ScalingDisabled {
+		_, err := fmt.Fprintf(w, "Scaling is disabled as the scale target has been scaled to zero\n")
+		return err
+	}
+
+	if len(p.Metrics) > 0 {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "METRIC\tUSAGE RATIO\tREPLICAS")
+		for _, metric := range p.Metrics {
+			usageRatio := "-"
+			if metric.UsageRatio != nil {
+				usageRatio = fmt.Sprintf("%.2f", *metric.UsageRatio)
+			}
+			replicas := fmt.Sprintf("%d", metric.Replicas)
+			if metric.Error != "" {
+				replicas = "error: " + metric.Error
+			} else if metric.Winning {
+				replicas += " (winning)"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", metric.Name, usageRatio, replicas)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "Current replicas: %d\n", p.CurrentReplicas)
+	if p.MetricReplicas != nil {
+		fmt.Fprintf(w, "Metric replicas: %d\n", *p.MetricReplicas)
+	}
+	fmt.Fprintf(w, "Stabilized replicas: %d (%s: %s)\n", p.StabilizedReplicas, p.StabilizationReason,
+		p.StabilizationReason.Message())
+	fmt.Fprintf(w, "Desired replicas: %d (%s: %s)\n", p.DesiredReplicas, p.LimitReason, p.LimitReason.Message())
+	_, err := fmt.Fprintf(w, "Controller desired replicas: %d\n", p.ControllerDesiredReplicas)
+	return err
+}
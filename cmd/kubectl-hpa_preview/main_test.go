@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/behavior"
+	"github.com/jthomperoo/k8shorizmetrics/v4/hpa"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func TestParseFlags(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description string
+		expected    *config
+		expectedErr error
+		args        []string
+	}{
+		{
+			description: "No name",
+			expectedErr: errors.New("exactly one HorizontalPodAutoscaler name must be provided"),
+			args:        []string{},
+		},
+		{
+			description: "Multiple names",
+			expectedErr: errors.New("exactly one HorizontalPodAutoscaler name must be provided"),
+			args:        []string{"first", "second"},
+		},
+		{
+			description: "Unsupported output",
+			expectedErr: errors.New("unsupported output format 'yaml', must be text or json"),
+			args:        []string{"-output", "yaml", "test"},
+		},
+		{
+			description: "Defaults",
+			expected: &config{
+				kubeconfig:              "/tmp/kubeconfig",
+				namespace:               "default",
+				name:                    "test",
+				tolerance:               0.1,
+				cpuInitializationPeriod: 5 * time.Minute,
+				initialReadinessDelay:   30 * time.Second,
+				output:                  outputText,
+			},
+			args: []string{"-kubeconfig", "/tmp/kubeconfig", "test"},
+		},
+		{
+			description: "Namespace shorthand and options",
+			expected: &config{
+				kubeconfig:              "/tmp/kubeconfig",
+				namespace:               "test-namespace",
+				name:                    "test",
+				tolerance:               0.2,
+				cpuInitializationPeriod: time.Minute,
+				initialReadinessDelay:   time.Second,
+				output:                  outputJSON,
+			},
+			args: []string{
+				"-kubeconfig", "/tmp/kubeconfig",
+				"-n", "test-namespace",
+				"-tolerance", "0.2",
+				"-cpu-initialization-period", "1m",
+				"-initial-readiness-delay", "1s",
+				"-output", "json",
+				"test",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := parseFlags(test.args, io.Discard)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result, cmp.AllowUnexported(config{})) {
+				t.Errorf("config mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, cmp.AllowUnexported(config{})))
+			}
+		})
+	}
+}
+
+func TestGatheredSnapshot(t *testing.T) {
+	cpuSpec := k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceCPU, 50)
+	memorySpec := k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceMemory, 50)
+
+	snapshot := &gatheredSnapshot{}
+	gathered := &metrics.Metric{Spec: memorySpec}
+	result, err := snapshot.record(context.Background(), memorySpec, gathered, nil)
+	if err != nil || result != gathered {
+		t.Fatalf("expected hook to pass through gathered metric, got %v, %v", result, err)
+	}
+	_, err = snapshot.record(context.Background(), cpuSpec, nil, errors.New("fail"))
+	if err == nil {
+		t.Fatalf("expected hook to pass through error")
+	}
+	_, _ = snapshot.record(context.Background(), cpuSpec, &metrics.Metric{Spec: cpuSpec}, nil)
+
+	// Mutating the gathered metric must not change the snapshot
+	gathered.Spec.Type = autoscalingv2.PodsMetricSourceType
+
+	expected := []*metrics.Metric{
+		{Spec: cpuSpec},
+		{Spec: memorySpec},
+	}
+	ordered := snapshot.ordered([]autoscalingv2.MetricSpec{cpuSpec, memorySpec, cpuSpec})
+	if !cmp.Equal(expected, ordered) {
+		t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(expected, ordered))
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	autoscaler := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Status:     autoscalingv2.HorizontalPodAutoscalerStatus{DesiredReplicas: 3},
+	}
+	cpuMetric := &metrics.Metric{
+		Spec: k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceCPU, 50),
+	}
+
+	var tests = []struct {
+		description string
+		expected    string
+		format      string
+		result      *hpa.Result
+		details     *k8shorizmetrics.DetailedEvaluation
+	}{
+		{
+			description: "Scaling disabled",
+			expected: `HorizontalPodAutoscaler: default/test
+
+Scaling is disabled as the scale target has been scaled to zero
+`,
+			format: outputText,
+			result: &hpa.Result{},
+		},
+		{
+			description: "Limited by maximum replicas",
+			expected: `HorizontalPodAutoscaler: default/test
+
+METRIC                                            USAGE RATIO  REPLICAS
+cpu resource utilization (percentage of request)  3.00         6 (winning)
+
+Current replicas: 2
+Metric replicas: 6
+Stabilized replicas: 6 (ReadyForNewScale: recommended size matches current size)
+Desired replicas: 5 (TooManyReplicas: the desired replica count is more than the maximum replica count)
+Controller desired replicas: 3
+`,
+			format: outputText,
+			result: &hpa.Result{
+				CurrentReplicas: 2,
+				DesiredReplicas: 5,
+				Normalization: &behavior.Normalization{
+					Replicas:            5,
+					StabilizedReplicas:  6,
+					StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+					LimitReason:         k8shorizmetrics.ReasonTooManyReplicas,
+				},
+			},
+			details: &k8shorizmetrics.DetailedEvaluation{
+				Replicas: 6,
+				Metrics: []*k8shorizmetrics.MetricEvaluation{
+					{
+						Metric:     cpuMetric,
+						Replicas:   6,
+						UsageRatio: float64Ptr(3),
+						Winning:    true,
+					},
+				},
+			},
+		},
+		{
+			description: "JSON",
+			expected: `{
+  "namespace": "default",
+  "name": "test",
+  "currentReplicas": 2,
+  "desiredReplicas": 2,
+  "controllerDesiredReplicas": 3,
+  "scalingDisabled": false,
+  "stabilizedReplicas": 2,
+  "stabilizationReason": "ReadyForNewScale",
+  "limitReason": "DesiredWithinRange",
+  "metrics": []
+}
+`,
+			format: outputJSON,
+			result: &hpa.Result{
+				CurrentReplicas: 2,
+				DesiredReplicas: 2,
+				Normalization: &behavior.Normalization{
+					Replicas:            2,
+					StabilizedReplicas:  2,
+					StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+					LimitReason:         k8shorizmetrics.ReasonDesiredWithinRange,
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := writeOutput(&buf, test.format, newPreview(autoscaler, test.result, test.details))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(test.expected, buf.String()) {
+				t.Errorf("output mismatch (-want +got):\n%s", cmp.Diff(test.expected, buf.String()))
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package concurrency provides an evaluation mode based on in-flight concurrent requests per pod, modelled on the
+// Knative Pod Autoscaler (KPA). Observed concurrency is averaged over a stable window to decide the replica count,
+// while a shorter panic window allows rapid scale ups when concurrency spikes. While panicking the replica count is
+// never reduced, panic mode ends once concurrency has stayed below the panic threshold for a full stable window.
+//
+// The target concurrency per pod is taken from the metric spec's average value target, so the same pods or external
+// metric specs used with the Evaluator can be used in concurrency mode.
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// DefaultStableWindow is the default window concurrency is averaged over when not panicking, matches the KPA
+	DefaultStableWindow = 60 * time.Second
+	// DefaultPanicWindowPercentage is the default size of the panic window as a percentage of the stable window,
+	// matches the KPA
+	DefaultPanicWindowPercentage = 10.0
+	// DefaultPanicThreshold is the default ratio of panic window concurrency to the current capacity which will
+	// trigger panic mode, matches the KPA
+	DefaultPanicThreshold = 2.0
+)
+
+type observation struct {
+	timestamp   time.Time
+	concurrency float64
+}
+
+// Autoscaler records observed concurrency over time and recommends replica counts using stable and panic windows.
+// An Autoscaler holds state so a separate Autoscaler should be used for each scaled resource. Any of StableWindow,
+// PanicWindowPercentage and PanicThreshold left as zero use the KPA defaults, and if Clock is nil the real clock is
+// used.
+type Autoscaler struct {
+	StableWindow          time.Duration
+	PanicWindowPercentage float64
+	PanicThreshold        float64
+	Clock                 clock.PassiveClock
+
+	mu           sync.Mutex
+	observations []observation
+	panicTime    time.Time
+	maxPanicPods int32
+}
+
+// NewAutoscaler sets up an Autoscaler using the KPA defaults
+func NewAutoscaler() *Autoscaler {
+	return &Autoscaler{
+		StableWindow:          DefaultStableWindow,
+		PanicWindowPercentage: DefaultPanicWindowPercentage,
+		PanicThreshold:        DefaultPanicThreshold,
+		Clock:                 clock.RealClock{},
+	}
+}
+
+// Recommend records the total concurrency observed across all pods and returns the recommended replica count based
+// on the target concurrency per pod. Concurrency and target concurrency must be in the same units. The target
+// concurrency must be greater than 0, otherwise nothing is recorded and the current replica count is returned.
+func (a *Autoscaler) Recommend(currentReplicas int32, totalConcurrency float64, targetConcurrency float64) int32 {
+	if targetConcurrency <= 0 {
+		return currentReplicas
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.clock().Now()
+	stableWindow := a.stableWindow()
+	panicWindow := time.Duration(float64(stableWindow) * a.panicWindowPercentage() / 100)
+
+	a.observations = append(a.observations, observation{
+		timestamp:   now,
+		concurrency: totalConcurrency,
+	})
+
+	// Drop any observations older than the stable window
+	for len(a.observations) > 0 && now.Sub(a.observations[0].timestamp) > stableWindow {
+		a.observations = a.observations[1:]
+	}
+
+	stableConcurrency := a.averageSince(now.Add(-stableWindow))
+	panicConcurrency := a.averageSince(now.Add(-panicWindow))
+
+	desiredStablePods := int32(math.Ceil(stableConcurrency / targetConcurrency))
+	desiredPanicPods := int32(math.Ceil(panicConcurrency / targetConcurrency))
+
+	readyPods := currentReplicas
+	if readyPods < 1 {
+		readyPods = 1
+	}
+
+	overPanicThreshold := panicConcurrency/(float64(readyPods)*targetConcurrency) >= a.panicThreshold()
+
+	if overPanicThreshold {
+		// Start or extend panic mode
+		a.panicTime = now
+	} else if !a.panicTime.IsZero() && now.Sub(a.panicTime) >= stableWindow {
+		// Concurrency has been below the panic threshold for a full stable window, stop panicking
+		a.panicTime = time.Time{}
+		a.maxPanicPods = 0
+	}
+
+	if a.panicTime.IsZero() {
+		return desiredStablePods
+	}
+
+	// While panicking never scale down
+	desiredPods := desiredPanicPods
+	if desiredPods < a.maxPanicPods {
+		desiredPods = a.maxPanicPods
+	}
+	a.maxPanicPods = desiredPods
+
+	return desiredPods
+}
+
+// Panicking returns if the Autoscaler is currently in panic mode
+func (a *Autoscaler) Panicking() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return !a.panicTime.IsZero()
+}
+
+func (a *Autoscaler) clock() clock.PassiveClock {
+	if a.Clock == nil {
+		return clock.RealClock{}
+	}
+	return a.Clock
+}
+
+func (a *Autoscaler) stableWindow() time.Duration {
+	if a.StableWindow == 0 {
+		return DefaultStableWindow
+	}
+	return a.StableWindow
+}
+
+func (a *Autoscaler) panicWindowPercentage() float64 {
+	if a.PanicWindowPercentage == 0 {
+		return DefaultPanicWindowPercentage
+	}
+	return a.PanicWindowPercentage
+}
+
+func (a *Autoscaler) panicThreshold() float64 {
+	if a.PanicThreshold == 0 {
+		return DefaultPanicThreshold
+	}
+	return a.PanicThreshold
+}
+
+func (a *Autoscaler) averageSince(since time.Time) float64 {
+	var total float64
+	var count int
+	for _, observation := range a.observations {
+		if observation.timestamp.Before(since) {
+			continue
+		}
+		total += observation.concurrency
+		count++
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	return total / float64(count)
+}
+
+// PodsEvaluate (concurrency) calculates a replica count evaluation from a pods metric reporting in-flight concurrent
+// requests per pod, the target concurrency is the metric spec's average value target
+type PodsEvaluate struct {
+	Autoscaler *Autoscaler
+}
+
+// EvaluateWithError calculates an evaluation based on the total concurrency across all pods in the metric provided,
+// failing if the metric spec does not have an average value target greater than 0. The Evaluator prefers this method
+// over Evaluate.
+func (e *PodsEvaluate) EvaluateWithError(ctx context.Context, currentReplicas int32,
+	gatheredMetric *metrics.Metric) (int32, error) {
+	targetConcurrency, err := averageValueTarget(gatheredMetric.Spec.Pods.Target, autoscalingv2.PodsMetricSourceType)
+	if err != nil {
+		return 0, err
+	}
+
+	var totalConcurrency int64
+	for _, podMetric := range gatheredMetric.Pods.PodMetricsInfo {
+		totalConcurrency += podMetric.Value
+	}
+
+	return e.Autoscaler.Recommend(currentReplicas, float64(totalConcurrency), float64(targetConcurrency)), nil
+}
+
+// Evaluate calculates an evaluation based on the total concurrency across all pods in the metric provided. If the
+// metric spec does not have an average value target greater than 0 the current replica count is returned, use
+// EvaluateWithError to have this reported as an error.
+func (e *PodsEvaluate) Evaluate(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+	replicas, err := e.EvaluateWithError(context.Background(), currentReplicas, gatheredMetric)
+	if err != nil {
+		return currentReplicas
+	}
+	return replicas
+}
+
+// ExternalEvaluate (concurrency) calculates a replica count evaluation from an external metric reporting the total
+// in-flight concurrent requests, the target concurrency is the metric spec's average value target
+type ExternalEvaluate struct {
+	Autoscaler *Autoscaler
+}
+
+// Evaluate calculates an evaluation based on the total concurrency in the metric provided, the tolerance is ignored
+// as the stable window smooths out small fluctuations
+func (e *ExternalEvaluate) Evaluate(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+	targetConcurrency, err := averageValueTarget(gatheredMetric.Spec.External.Target,
+		autoscalingv2.ExternalMetricSourceType)
+	if err != nil {
+		return 0, err
+	}
+
+	return e.Autoscaler.Recommend(currentReplicas, float64(*gatheredMetric.External.Current.AverageValue),
+		float64(targetConcurrency)), nil
+}
+
+// averageValueTarget returns the average value target of the metric target provided as a milli-value, failing if it
+// is not set or is not greater than 0
+func averageValueTarget(target autoscalingv2.MetricTarget, sourceType autoscalingv2.MetricSourceType) (int64, error) {
+	if target.AverageValue == nil {
+		return 0, fmt.Errorf("invalid %s metric source: concurrency evaluation requires an average value target",
+			strings.ToLower(string(sourceType)))
+	}
+	targetConcurrency := target.AverageValue.MilliValue()
+	if targetConcurrency <= 0 {
+		return 0, fmt.Errorf("invalid %s metric source: concurrency evaluation requires an average value target "+
+			"greater than 0", strings.ToLower(string(sourceType)))
+	}
+	return targetConcurrency, nil
+}
@@ -0,0 +1,388 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package concurrency_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/concurrency"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	externalmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+type recommendStep struct {
+	advance           time.Duration
+	currentReplicas   int32
+	totalConcurrency  float64
+	expected          int32
+	expectedPanicking bool
+}
+
+func TestRecommend(t *testing.T) {
+	var tests = []struct {
+		description string
+		steps       []recommendStep
+	}{
+		{
+			description: "Single observation below panic threshold",
+			steps: []recommendStep{
+				{currentReplicas: 2, totalConcurrency: 25, expected: 3},
+			},
+		},
+		{
+			description: "No concurrency, scale to zero",
+			steps: []recommendStep{
+				{currentReplicas: 2, totalConcurrency: 0, expected: 0},
+			},
+		},
+		{
+			description: "Stable window averages out a short drop in concurrency",
+			steps: []recommendStep{
+				{currentReplicas: 4, totalConcurrency: 40, expected: 4},
+				{advance: 10 * time.Second, currentReplicas: 4, totalConcurrency: 40, expected: 4},
+				{advance: 10 * time.Second, currentReplicas: 4, totalConcurrency: 10, expected: 3},
+			},
+		},
+		{
+			description: "Observations older than the stable window are dropped",
+			steps: []recommendStep{
+				{currentReplicas: 4, totalConcurrency: 40, expected: 4},
+				{advance: 61 * time.Second, currentReplicas: 4, totalConcurrency: 10, expected: 1},
+			},
+		},
+		{
+			description: "Spike over panic threshold, panic and scale up on panic window",
+			steps: []recommendStep{
+				{currentReplicas: 2, totalConcurrency: 20, expected: 2},
+				{advance: 10 * time.Second, currentReplicas: 2, totalConcurrency: 80, expected: 8, expectedPanicking: true},
+			},
+		},
+		{
+			description: "Do not scale down while panicking, stop panicking after stable window",
+			steps: []recommendStep{
+				{currentReplicas: 2, totalConcurrency: 80, expected: 8, expectedPanicking: true},
+				{advance: 10 * time.Second, currentReplicas: 8, totalConcurrency: 20, expected: 8, expectedPanicking: true},
+				{advance: 30 * time.Second, currentReplicas: 8, totalConcurrency: 20, expected: 8, expectedPanicking: true},
+				{advance: 30 * time.Second, currentReplicas: 8, totalConcurrency: 20, expected: 2},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakePassiveClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+			autoscaler := concurrency.NewAutoscaler()
+			autoscaler.Clock = fakeClock
+
+			for i, step := range test.steps {
+				fakeClock.SetTime(fakeClock.Now().Add(step.advance))
+				result := autoscaler.Recommend(step.currentReplicas, step.totalConcurrency, 10)
+				if !cmp.Equal(step.expected, result) {
+					t.Errorf("step %d recommendation mismatch (-want +got):\n%s", i, cmp.Diff(step.expected, result))
+				}
+				if !cmp.Equal(step.expectedPanicking, autoscaler.Panicking()) {
+					t.Errorf("step %d panicking mismatch (-want +got):\n%s", i, cmp.Diff(step.expectedPanicking, autoscaler.Panicking()))
+				}
+			}
+		})
+
+		// An Autoscaler struct literal with no windows or thresholds set uses the same defaults as NewAutoscaler
+		t.Run(test.description+", zero value defaults", func(t *testing.T) {
+			fakeClock := clocktesting.NewFakePassiveClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+			autoscaler := &concurrency.Autoscaler{
+				Clock: fakeClock,
+			}
+
+			for i, step := range test.steps {
+				fakeClock.SetTime(fakeClock.Now().Add(step.advance))
+				result := autoscaler.Recommend(step.currentReplicas, step.totalConcurrency, 10)
+				if !cmp.Equal(step.expected, result) {
+					t.Errorf("step %d recommendation mismatch (-want +got):\n%s", i, cmp.Diff(step.expected, result))
+				}
+				if !cmp.Equal(step.expectedPanicking, autoscaler.Panicking()) {
+					t.Errorf("step %d panicking mismatch (-want +got):\n%s", i, cmp.Diff(step.expectedPanicking, autoscaler.Panicking()))
+				}
+			}
+		})
+	}
+}
+
+func TestRecommendWithoutClock(t *testing.T) {
+	autoscaler := &concurrency.Autoscaler{}
+
+	result := autoscaler.Recommend(2, 25, 10)
+	if result != 3 {
+		t.Errorf("recommendation mismatch, want 3 got %d", result)
+	}
+}
+
+func TestRecommendInvalidTarget(t *testing.T) {
+	autoscaler := concurrency.NewAutoscaler()
+
+	for _, targetConcurrency := range []float64{0, -10} {
+		result := autoscaler.Recommend(4, 50, targetConcurrency)
+		if result != 4 {
+			t.Errorf("target %f recommendation mismatch, want 4 got %d", targetConcurrency, result)
+		}
+	}
+
+	// No observations should be recorded for the invalid targets
+	result := autoscaler.Recommend(4, 0, 10)
+	if result != 0 {
+		t.Errorf("recommendation mismatch, want 0 got %d", result)
+	}
+}
+
+func TestPodsEvaluate(t *testing.T) {
+	var tests = []struct {
+		description     string
+		expected        int32
+		currentReplicas int32
+		gatheredMetric  *metrics.Metric
+	}{
+		{
+			description:     "Value target, replicas unchanged",
+			expected:        2,
+			currentReplicas: 2,
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Pods: &autoscalingv2.PodsMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							Type:  autoscalingv2.ValueMetricType,
+							Value: k8sresource.NewMilliQuantity(10000, k8sresource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+		{
+			description:     "Total concurrency across pods",
+			expected:        3,
+			currentReplicas: 2,
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Pods: &autoscalingv2.PodsMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							AverageValue: k8sresource.NewMilliQuantity(10000, k8sresource.DecimalSI),
+						},
+					},
+				},
+				Pods: &podsmetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 12000},
+						"pod-2": podmetrics.Metric{Value: 13000},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluate := &concurrency.PodsEvaluate{
+				Autoscaler: concurrency.NewAutoscaler(),
+			}
+			result := evaluate.Evaluate(test.currentReplicas, test.gatheredMetric)
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("evaluation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestPodsEvaluateWithError(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	podMetrics := &podsmetrics.Metric{
+		PodMetricsInfo: podmetrics.MetricsInfo{
+			"pod-1": podmetrics.Metric{Value: 12000},
+			"pod-2": podmetrics.Metric{Value: 13000},
+		},
+	}
+
+	var tests = []struct {
+		description     string
+		expected        int32
+		expectedErr     error
+		currentReplicas int32
+		gatheredMetric  *metrics.Metric
+	}{
+		{
+			description:     "Value target",
+			expectedErr:     errors.New("evaluator multi metric error: 1 errors, first error is invalid pods metric source: concurrency evaluation requires an average value target"),
+			currentReplicas: 2,
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							Type:  autoscalingv2.ValueMetricType,
+							Value: k8sresource.NewMilliQuantity(10000, k8sresource.DecimalSI),
+						},
+					},
+				},
+				Pods: podMetrics,
+			},
+		},
+		{
+			description:     "Zero average value target",
+			expectedErr:     errors.New("evaluator multi metric error: 1 errors, first error is invalid pods metric source: concurrency evaluation requires an average value target greater than 0"),
+			currentReplicas: 2,
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: k8sresource.NewMilliQuantity(0, k8sresource.DecimalSI),
+						},
+					},
+				},
+				Pods: podMetrics,
+			},
+		},
+		{
+			description:     "Total concurrency across pods",
+			expected:        3,
+			currentReplicas: 2,
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: k8sresource.NewMilliQuantity(10000, k8sresource.DecimalSI),
+						},
+					},
+				},
+				Pods: podMetrics,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluator := &k8shorizmetrics.Evaluator{
+				Pods: &concurrency.PodsEvaluate{
+					Autoscaler: concurrency.NewAutoscaler(),
+				},
+			}
+			result, err := evaluator.Evaluate([]*metrics.Metric{test.gatheredMetric}, test.currentReplicas)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("evaluation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestExternalEvaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description     string
+		expected        int32
+		expectedErr     error
+		currentReplicas int32
+		gatheredMetric  *metrics.Metric
+	}{
+		{
+			description: "No average value target",
+			expectedErr: errors.New("invalid external metric source: concurrency evaluation requires an average value target"),
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					External: &autoscalingv2.ExternalMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							Value: k8sresource.NewMilliQuantity(10000, k8sresource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "Zero average value target",
+			expectedErr: errors.New("invalid external metric source: concurrency evaluation requires an average value target greater than 0"),
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					External: &autoscalingv2.ExternalMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							AverageValue: k8sresource.NewMilliQuantity(0, k8sresource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+		{
+			description:     "Total concurrency",
+			expected:        5,
+			currentReplicas: 4,
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					External: &autoscalingv2.ExternalMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							AverageValue: k8sresource.NewMilliQuantity(10000, k8sresource.DecimalSI),
+						},
+					},
+				},
+				External: &externalmetrics.Metric{
+					Current: value.MetricValue{
+						AverageValue: int64Ptr(45000),
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluate := &concurrency.ExternalEvaluate{
+				Autoscaler: concurrency.NewAutoscaler(),
+			}
+			result, err := evaluate.Evaluate(test.currentReplicas, test.gatheredMetric, 0.1)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("evaluation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
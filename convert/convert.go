@@ -0,0 +1,240 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package convert provides helpers for converting metric specs from older autoscaling API versions into the
+// autoscaling/v2 specs used throughout k8shorizmetrics, allowing configurations to be migrated gradually.
+package convert
+
+import (
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// DefaultCPUUtilization is the target CPU utilization percentage used by the HPA when an autoscaling/v1 HPA does not
+// specify one
+const DefaultCPUUtilization = 80
+
+// FromV1HPA converts an autoscaling/v1 HorizontalPodAutoscaler, which only supports targeting average CPU utilization,
+// into the equivalent autoscaling/v2 resource metric spec. If no target CPU utilization is set the HPA default of 80%
+// is used.
+func FromV1HPA(hpa *autoscalingv1.HorizontalPodAutoscaler) []autoscalingv2.MetricSpec {
+	targetUtilization := int32(DefaultCPUUtilization)
+	if hpa.Spec.TargetCPUUtilizationPercentage != nil {
+		targetUtilization = *hpa.Spec.TargetCPUUtilizationPercentage
+	}
+	return []autoscalingv2.MetricSpec{
+		{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: &targetUtilization,
+				},
+			},
+		},
+	}
+}
+
+// FromV2Beta2MetricSpecs converts a slice of autoscaling/v2beta2 metric specs to autoscaling/v2 metric specs
+func FromV2Beta2MetricSpecs(specs []autoscalingv2beta2.MetricSpec) []autoscalingv2.MetricSpec {
+	if specs == nil {
+		return nil
+	}
+	converted := make([]autoscalingv2.MetricSpec, len(specs))
+	for i, spec := range specs {
+		converted[i] = FromV2Beta2MetricSpec(spec)
+	}
+	return converted
+}
+
+// FromV2Beta2MetricSpec converts a single autoscaling/v2beta2 metric spec to an autoscaling/v2 metric spec
+func FromV2Beta2MetricSpec(spec autoscalingv2beta2.MetricSpec) autoscalingv2.MetricSpec {
+	converted := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.MetricSourceType(spec.Type),
+	}
+
+	if spec.Object != nil {
+		converted.Object = &autoscalingv2.ObjectMetricSource{
+			DescribedObject: autoscalingv2.CrossVersionObjectReference{
+				Kind:       spec.Object.DescribedObject.Kind,
+				Name:       spec.Object.DescribedObject.Name,
+				APIVersion: spec.Object.DescribedObject.APIVersion,
+			},
+			Target: fromV2Beta2MetricTarget(spec.Object.Target),
+			Metric: fromV2Beta2MetricIdentifier(spec.Object.Metric),
+		}
+	}
+
+	if spec.Pods != nil {
+		converted.Pods = &autoscalingv2.PodsMetricSource{
+			Metric: fromV2Beta2MetricIdentifier(spec.Pods.Metric),
+			Target: fromV2Beta2MetricTarget(spec.Pods.Target),
+		}
+	}
+
+	if spec.Resource != nil {
+		converted.Resource = &autoscalingv2.ResourceMetricSource{
+			Name:   spec.Resource.Name,
+			Target: fromV2Beta2MetricTarget(spec.Resource.Target),
+		}
+	}
+
+	if spec.ContainerResource != nil {
+		converted.ContainerResource = &autoscalingv2.ContainerResourceMetricSource{
+			Name:      spec.ContainerResource.Name,
+			Target:    fromV2Beta2MetricTarget(spec.ContainerResource.Target),
+			Container: spec.ContainerResource.Container,
+		}
+	}
+
+	if spec.External != nil {
+		converted.External = &autoscalingv2.ExternalMetricSource{
+			Metric: fromV2Beta2MetricIdentifier(spec.External.Metric),
+			Target: fromV2Beta2MetricTarget(spec.External.Target),
+		}
+	}
+
+	return converted
+}
+
+func fromV2Beta2MetricIdentifier(identifier autoscalingv2beta2.MetricIdentifier) autoscalingv2.MetricIdentifier {
+	return autoscalingv2.MetricIdentifier{
+		Name:     identifier.Name,
+		Selector: identifier.Selector,
+	}
+}
+
+func fromV2Beta2MetricTarget(target autoscalingv2beta2.MetricTarget) autoscalingv2.MetricTarget {
+	return autoscalingv2.MetricTarget{
+		Type:               autoscalingv2.MetricTargetType(target.Type),
+		Value:              target.Value,
+		AverageValue:       target.AverageValue,
+		AverageUtilization: target.AverageUtilization,
+	}
+}
+
+// FromV2Beta1MetricSpecs converts a slice of autoscaling/v2beta1 metric specs to autoscaling/v2 metric specs
+func FromV2Beta1MetricSpecs(specs []autoscalingv2beta1.MetricSpec) []autoscalingv2.MetricSpec {
+	if specs == nil {
+		return nil
+	}
+	converted := make([]autoscalingv2.MetricSpec, len(specs))
+	for i, spec := range specs {
+		converted[i] = FromV2Beta1MetricSpec(spec)
+	}
+	return converted
+}
+
+// FromV2Beta1MetricSpec converts a single autoscaling/v2beta1 metric spec to an autoscaling/v2 metric spec. The
+// autoscaling/v2beta1 targets are converted to the autoscaling/v2 target types in the same way as the Kubernetes API
+// server; for example a resource metric with a target average utilization becomes a Utilization target, otherwise it
+// becomes an AverageValue target.
+func FromV2Beta1MetricSpec(spec autoscalingv2beta1.MetricSpec) autoscalingv2.MetricSpec {
+	converted := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.MetricSourceType(spec.Type),
+	}
+
+	if spec.Object != nil {
+		targetType := autoscalingv2.ValueMetricType
+		if spec.Object.AverageValue != nil {
+			targetType = autoscalingv2.AverageValueMetricType
+		}
+		targetValue := spec.Object.TargetValue
+		converted.Object = &autoscalingv2.ObjectMetricSource{
+			DescribedObject: autoscalingv2.CrossVersionObjectReference{
+				Kind:       spec.Object.Target.Kind,
+				Name:       spec.Object.Target.Name,
+				APIVersion: spec.Object.Target.APIVersion,
+			},
+			Metric: autoscalingv2.MetricIdentifier{
+				Name:     spec.Object.MetricName,
+				Selector: spec.Object.Selector,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:         targetType,
+				Value:        &targetValue,
+				AverageValue: spec.Object.AverageValue,
+			},
+		}
+	}
+
+	if spec.Pods != nil {
+		targetAverageValue := spec.Pods.TargetAverageValue
+		converted.Pods = &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name:     spec.Pods.MetricName,
+				Selector: spec.Pods.Selector,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: &targetAverageValue,
+			},
+		}
+	}
+
+	if spec.Resource != nil {
+		converted.Resource = &autoscalingv2.ResourceMetricSource{
+			Name:   spec.Resource.Name,
+			Target: fromV2Beta1ResourceTarget(spec.Resource.TargetAverageUtilization, spec.Resource.TargetAverageValue),
+		}
+	}
+
+	if spec.ContainerResource != nil {
+		converted.ContainerResource = &autoscalingv2.ContainerResourceMetricSource{
+			Name: spec.ContainerResource.Name,
+			Target: fromV2Beta1ResourceTarget(spec.ContainerResource.TargetAverageUtilization,
+				spec.ContainerResource.TargetAverageValue),
+			Container: spec.ContainerResource.Container,
+		}
+	}
+
+	if spec.External != nil {
+		targetType := autoscalingv2.ValueMetricType
+		if spec.External.TargetAverageValue != nil {
+			targetType = autoscalingv2.AverageValueMetricType
+		}
+		converted.External = &autoscalingv2.ExternalMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name:     spec.External.MetricName,
+				Selector: spec.External.MetricSelector,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:         targetType,
+				Value:        spec.External.TargetValue,
+				AverageValue: spec.External.TargetAverageValue,
+			},
+		}
+	}
+
+	return converted
+}
+
+func fromV2Beta1ResourceTarget(averageUtilization *int32, averageValue *resource.Quantity) autoscalingv2.MetricTarget {
+	targetType := autoscalingv2.AverageValueMetricType
+	if averageUtilization != nil {
+		targetType = autoscalingv2.UtilizationMetricType
+	}
+	return autoscalingv2.MetricTarget{
+		Type:               targetType,
+		AverageValue:       averageValue,
+		AverageUtilization: averageUtilization,
+	}
+}
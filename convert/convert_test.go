@@ -0,0 +1,469 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/convert"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFromV1HPA(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    []autoscalingv2.MetricSpec
+		hpa         *autoscalingv1.HorizontalPodAutoscaler
+	}{
+		{
+			description: "No target CPU utilization, use default",
+			expected: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: testutil.Int32Ptr(80),
+						},
+					},
+				},
+			},
+			hpa: &autoscalingv1.HorizontalPodAutoscaler{},
+		},
+		{
+			description: "Target CPU utilization set",
+			expected: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: testutil.Int32Ptr(50),
+						},
+					},
+				},
+			},
+			hpa: &autoscalingv1.HorizontalPodAutoscaler{
+				Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+					TargetCPUUtilizationPercentage: testutil.Int32Ptr(50),
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			converted := convert.FromV1HPA(test.hpa)
+			if !cmp.Equal(test.expected, converted) {
+				t.Errorf("specs mismatch (-want +got):\n%s", cmp.Diff(test.expected, converted))
+			}
+		})
+	}
+}
+
+func TestFromV2Beta2MetricSpecs(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    []autoscalingv2.MetricSpec
+		specs       []autoscalingv2beta2.MetricSpec
+	}{
+		{
+			description: "Nil specs",
+			expected:    nil,
+			specs:       nil,
+		},
+		{
+			description: "Convert object metric",
+			expected: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.ObjectMetricSourceType,
+					Object: &autoscalingv2.ObjectMetricSource{
+						DescribedObject: autoscalingv2.CrossVersionObjectReference{
+							Kind:       "Service",
+							Name:       "test-service",
+							APIVersion: "v1",
+						},
+						Metric: autoscalingv2.MetricIdentifier{
+							Name: "requests-per-second",
+							Selector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"app": "test"},
+							},
+						},
+						Target: autoscalingv2.MetricTarget{
+							Type:  autoscalingv2.ValueMetricType,
+							Value: resource.NewMilliQuantity(5000, resource.DecimalSI),
+						},
+					},
+				},
+			},
+			specs: []autoscalingv2beta2.MetricSpec{
+				{
+					Type: autoscalingv2beta2.ObjectMetricSourceType,
+					Object: &autoscalingv2beta2.ObjectMetricSource{
+						DescribedObject: autoscalingv2beta2.CrossVersionObjectReference{
+							Kind:       "Service",
+							Name:       "test-service",
+							APIVersion: "v1",
+						},
+						Metric: autoscalingv2beta2.MetricIdentifier{
+							Name: "requests-per-second",
+							Selector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"app": "test"},
+							},
+						},
+						Target: autoscalingv2beta2.MetricTarget{
+							Type:  autoscalingv2beta2.ValueMetricType,
+							Value: resource.NewMilliQuantity(5000, resource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "Convert pods, resource, container resource and external metrics",
+			expected: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{
+							Name: "packets-per-second",
+						},
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: resource.NewMilliQuantity(1000, resource.DecimalSI),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: testutil.Int32Ptr(50),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2.ContainerResourceMetricSourceType,
+					ContainerResource: &autoscalingv2.ContainerResourceMetricSource{
+						Name:      corev1.ResourceMemory,
+						Container: "application",
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: testutil.Int32Ptr(60),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{
+							Name: "queue-length",
+						},
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: resource.NewMilliQuantity(30000, resource.DecimalSI),
+						},
+					},
+				},
+			},
+			specs: []autoscalingv2beta2.MetricSpec{
+				{
+					Type: autoscalingv2beta2.PodsMetricSourceType,
+					Pods: &autoscalingv2beta2.PodsMetricSource{
+						Metric: autoscalingv2beta2.MetricIdentifier{
+							Name: "packets-per-second",
+						},
+						Target: autoscalingv2beta2.MetricTarget{
+							Type:         autoscalingv2beta2.AverageValueMetricType,
+							AverageValue: resource.NewMilliQuantity(1000, resource.DecimalSI),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2beta2.ResourceMetricSourceType,
+					Resource: &autoscalingv2beta2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2beta2.MetricTarget{
+							Type:               autoscalingv2beta2.UtilizationMetricType,
+							AverageUtilization: testutil.Int32Ptr(50),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2beta2.ContainerResourceMetricSourceType,
+					ContainerResource: &autoscalingv2beta2.ContainerResourceMetricSource{
+						Name:      corev1.ResourceMemory,
+						Container: "application",
+						Target: autoscalingv2beta2.MetricTarget{
+							Type:               autoscalingv2beta2.UtilizationMetricType,
+							AverageUtilization: testutil.Int32Ptr(60),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2beta2.ExternalMetricSourceType,
+					External: &autoscalingv2beta2.ExternalMetricSource{
+						Metric: autoscalingv2beta2.MetricIdentifier{
+							Name: "queue-length",
+						},
+						Target: autoscalingv2beta2.MetricTarget{
+							Type:         autoscalingv2beta2.AverageValueMetricType,
+							AverageValue: resource.NewMilliQuantity(30000, resource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			converted := convert.FromV2Beta2MetricSpecs(test.specs)
+			if !cmp.Equal(test.expected, converted) {
+				t.Errorf("specs mismatch (-want +got):\n%s", cmp.Diff(test.expected, converted))
+			}
+		})
+	}
+}
+
+func TestFromV2Beta1MetricSpecs(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    []autoscalingv2.MetricSpec
+		specs       []autoscalingv2beta1.MetricSpec
+	}{
+		{
+			description: "Nil specs",
+			expected:    nil,
+			specs:       nil,
+		},
+		{
+			description: "Convert object metrics with value and average value targets",
+			expected: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.ObjectMetricSourceType,
+					Object: &autoscalingv2.ObjectMetricSource{
+						DescribedObject: autoscalingv2.CrossVersionObjectReference{
+							Kind:       "Service",
+							Name:       "test-service",
+							APIVersion: "v1",
+						},
+						Metric: autoscalingv2.MetricIdentifier{
+							Name: "requests-per-second",
+							Selector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"app": "test"},
+							},
+						},
+						Target: autoscalingv2.MetricTarget{
+							Type:  autoscalingv2.ValueMetricType,
+							Value: resource.NewMilliQuantity(5000, resource.DecimalSI),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2.ObjectMetricSourceType,
+					Object: &autoscalingv2.ObjectMetricSource{
+						DescribedObject: autoscalingv2.CrossVersionObjectReference{
+							Kind:       "Service",
+							Name:       "test-service",
+							APIVersion: "v1",
+						},
+						Metric: autoscalingv2.MetricIdentifier{
+							Name: "requests-per-second",
+						},
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							Value:        resource.NewMilliQuantity(0, resource.DecimalSI),
+							AverageValue: resource.NewMilliQuantity(2000, resource.DecimalSI),
+						},
+					},
+				},
+			},
+			specs: []autoscalingv2beta1.MetricSpec{
+				{
+					Type: autoscalingv2beta1.ObjectMetricSourceType,
+					Object: &autoscalingv2beta1.ObjectMetricSource{
+						Target: autoscalingv2beta1.CrossVersionObjectReference{
+							Kind:       "Service",
+							Name:       "test-service",
+							APIVersion: "v1",
+						},
+						MetricName:  "requests-per-second",
+						TargetValue: *resource.NewMilliQuantity(5000, resource.DecimalSI),
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "test"},
+						},
+					},
+				},
+				{
+					Type: autoscalingv2beta1.ObjectMetricSourceType,
+					Object: &autoscalingv2beta1.ObjectMetricSource{
+						Target: autoscalingv2beta1.CrossVersionObjectReference{
+							Kind:       "Service",
+							Name:       "test-service",
+							APIVersion: "v1",
+						},
+						MetricName:   "requests-per-second",
+						TargetValue:  *resource.NewMilliQuantity(0, resource.DecimalSI),
+						AverageValue: resource.NewMilliQuantity(2000, resource.DecimalSI),
+					},
+				},
+			},
+		},
+		{
+			description: "Convert pods, resource, container resource and external metrics",
+			expected: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{
+							Name: "packets-per-second",
+						},
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: resource.NewMilliQuantity(1000, resource.DecimalSI),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: testutil.Int32Ptr(50),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceMemory,
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: resource.NewQuantity(1024, resource.BinarySI),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2.ContainerResourceMetricSourceType,
+					ContainerResource: &autoscalingv2.ContainerResourceMetricSource{
+						Name:      corev1.ResourceMemory,
+						Container: "application",
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: testutil.Int32Ptr(60),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{
+							Name: "queue-length",
+						},
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: resource.NewMilliQuantity(30000, resource.DecimalSI),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{
+							Name: "queue-length",
+							Selector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"queue": "test"},
+							},
+						},
+						Target: autoscalingv2.MetricTarget{
+							Type:  autoscalingv2.ValueMetricType,
+							Value: resource.NewMilliQuantity(100000, resource.DecimalSI),
+						},
+					},
+				},
+			},
+			specs: []autoscalingv2beta1.MetricSpec{
+				{
+					Type: autoscalingv2beta1.PodsMetricSourceType,
+					Pods: &autoscalingv2beta1.PodsMetricSource{
+						MetricName:         "packets-per-second",
+						TargetAverageValue: *resource.NewMilliQuantity(1000, resource.DecimalSI),
+					},
+				},
+				{
+					Type: autoscalingv2beta1.ResourceMetricSourceType,
+					Resource: &autoscalingv2beta1.ResourceMetricSource{
+						Name:                     corev1.ResourceCPU,
+						TargetAverageUtilization: testutil.Int32Ptr(50),
+					},
+				},
+				{
+					Type: autoscalingv2beta1.ResourceMetricSourceType,
+					Resource: &autoscalingv2beta1.ResourceMetricSource{
+						Name:               corev1.ResourceMemory,
+						TargetAverageValue: resource.NewQuantity(1024, resource.BinarySI),
+					},
+				},
+				{
+					Type: autoscalingv2beta1.ContainerResourceMetricSourceType,
+					ContainerResource: &autoscalingv2beta1.ContainerResourceMetricSource{
+						Name:                     corev1.ResourceMemory,
+						Container:                "application",
+						TargetAverageUtilization: testutil.Int32Ptr(60),
+					},
+				},
+				{
+					Type: autoscalingv2beta1.ExternalMetricSourceType,
+					External: &autoscalingv2beta1.ExternalMetricSource{
+						MetricName:         "queue-length",
+						TargetAverageValue: resource.NewMilliQuantity(30000, resource.DecimalSI),
+					},
+				},
+				{
+					Type: autoscalingv2beta1.ExternalMetricSourceType,
+					External: &autoscalingv2beta1.ExternalMetricSource{
+						MetricName: "queue-length",
+						MetricSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"queue": "test"},
+						},
+						TargetValue: resource.NewMilliQuantity(100000, resource.DecimalSI),
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			converted := convert.FromV2Beta1MetricSpecs(test.specs)
+			if !cmp.Equal(test.expected, converted) {
+				t.Errorf("specs mismatch (-want +got):\n%s", cmp.Diff(test.expected, converted))
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cooldown provides hysteresis around evaluations, enforcing a cooldown period after scaling in one direction
+// before scaling in the other direction is allowed, for example no scale down within 5 minutes of a scale up. This
+// stops targets flapping between replica counts when metrics hover around the target.
+//
+// The times of the last scale up and scale down for each target are kept in a Store, which by default is held in
+// memory but can be replaced to persist state across restarts or share it between replicas of an autoscaler.
+package cooldown
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"k8s.io/utils/clock"
+)
+
+// State is the scaling state recorded for a target, a zero time means no scale in that direction has been recorded
+type State struct {
+	LastScaleUp   time.Time `json:"lastScaleUp,omitempty"`
+	LastScaleDown time.Time `json:"lastScaleDown,omitempty"`
+}
+
+// Store stores the State for each target, keyed by a string identifying the target
+type Store interface {
+	// Get returns the State for the target, returning a zero State if none has been stored
+	Get(ctx context.Context, key string) (State, error)
+	// Set stores the State for the target
+	Set(ctx context.Context, key string, state State) error
+	// Delete discards the State for the target
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryStore is a Store that holds State in memory, safe for concurrent use
+type MemoryStore struct {
+	mu     sync.Mutex
+	states map[string]State
+}
+
+// Get returns the State for the target, returning a zero State if none has been stored
+func (s *MemoryStore) Get(ctx context.Context, key string) (State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.states[key], nil
+}
+
+// Set stores the State for the target
+func (s *MemoryStore) Set(ctx context.Context, key string, state State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.states == nil {
+		s.states = map[string]State{}
+	}
+	s.states[key] = state
+	return nil
+}
+
+// Delete discards the State for the target
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, key)
+	return nil
+}
+
+// Evaluator wraps an Evaluator, holding the replica count at the current replica count if the evaluation would scale
+// within a cooldown period of a scale in the opposite direction. ScaleDownCooldown is how long after a scale up scale
+// downs are held for, ScaleUpCooldown is how long after a scale down scale ups are held for; a zero cooldown disables
+// holding in that direction. Each evaluation that changes the replica count is recorded as a scale in the Store, if no
+// Store is provided an in memory Store is used.
+type Evaluator struct {
+	Evaluator         *k8shorizmetrics.Evaluator
+	ScaleUpCooldown   time.Duration
+	ScaleDownCooldown time.Duration
+	Store             Store
+	Clock             clock.PassiveClock
+
+	mu sync.Mutex
+}
+
+// NewEvaluator sets up an Evaluator with the cooldowns provided, using an in memory Store
+func NewEvaluator(evaluator *k8shorizmetrics.Evaluator, scaleUpCooldown time.Duration,
+	scaleDownCooldown time.Duration) *Evaluator {
+	return &Evaluator{
+		Evaluator:         evaluator,
+		ScaleUpCooldown:   scaleUpCooldown,
+		ScaleDownCooldown: scaleDownCooldown,
+		Store:             &MemoryStore{},
+		Clock:             clock.RealClock{},
+	}
+}
+
+// Evaluate returns the target replica count for the target identified by the key provided based on the metrics
+// provided, holding the current replica count during a cooldown
+func (e *Evaluator) Evaluate(key string, gatheredMetrics []*metrics.Metric, currentReplicas int32) (int32, error) {
+	return e.EvaluateWithContext(context.Background(), key, gatheredMetrics, currentReplicas)
+}
+
+// EvaluateWithContext returns the target replica count for the target identified by the key provided based on the
+// metrics provided, holding the current replica count during a cooldown and passing the context provided to the
+// Evaluator and Store
+func (e *Evaluator) EvaluateWithContext(ctx context.Context, key string, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32) (int32, error) {
+	recommendation, err := e.Evaluator.EvaluateWithContext(ctx, gatheredMetrics, currentReplicas)
+	if err != nil {
+		return 0, err
+	}
+
+	if recommendation == currentReplicas {
+		return recommendation, nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	store := e.store()
+	state, err := store.Get(ctx, key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get cooldown state for %s: %w", key, err)
+	}
+
+	now := e.clock().Now()
+	if recommendation > currentReplicas {
+		if inCooldown(now, state.LastScaleDown, e.ScaleUpCooldown) {
+			return currentReplicas, nil
+		}
+		state.LastScaleUp = now
+	} else {
+		if inCooldown(now, state.LastScaleUp, e.ScaleDownCooldown) {
+			return currentReplicas, nil
+		}
+		state.LastScaleDown = now
+	}
+
+	err = store.Set(ctx, key, state)
+	if err != nil {
+		return 0, fmt.Errorf("failed to set cooldown state for %s: %w", key, err)
+	}
+
+	return recommendation, nil
+}
+
+// Forget discards the state recorded for the target, for example when the target is deleted
+func (e *Evaluator) Forget(ctx context.Context, key string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.store().Delete(ctx, key)
+}
+
+func (e *Evaluator) store() Store {
+	if e.Store == nil {
+		e.Store = &MemoryStore{}
+	}
+	return e.Store
+}
+
+func (e *Evaluator) clock() clock.PassiveClock {
+	if e.Clock == nil {
+		return clock.RealClock{}
+	}
+	return e.Clock
+}
+
+func inCooldown(now time.Time, lastScale time.Time, cooldown time.Duration) bool {
+	if cooldown <= 0 || lastScale.IsZero() {
+		return false
+	}
+	return now.Before(lastScale.Add(cooldown))
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cooldown_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/cooldown"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+type failStore struct {
+	cooldown.MemoryStore
+	getErr error
+	setErr error
+}
+
+func (s *failStore) Get(ctx context.Context, key string) (cooldown.State, error) {
+	if s.getErr != nil {
+		return cooldown.State{}, s.getErr
+	}
+	return s.MemoryStore.Get(ctx, key)
+}
+
+func (s *failStore) Set(ctx context.Context, key string, state cooldown.State) error {
+	if s.setErr != nil {
+		return s.setErr
+	}
+	return s.MemoryStore.Set(ctx, key, state)
+}
+
+type step struct {
+	advance         time.Duration
+	currentReplicas int32
+	evaluation      int32
+	expected        int32
+}
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	gatheredMetrics := []*metrics.Metric{
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+			},
+		},
+	}
+
+	var tests = []struct {
+		description       string
+		expectedErr       error
+		evaluateErr       error
+		store             cooldown.Store
+		scaleUpCooldown   time.Duration
+		scaleDownCooldown time.Duration
+		steps             []step
+	}{
+		{
+			description:       "Fail to evaluate",
+			expectedErr:       errors.New("evaluator multi metric error: 1 errors, first error is fail to evaluate"),
+			evaluateErr:       errors.New("fail to evaluate"),
+			scaleDownCooldown: 5 * time.Minute,
+			steps: []step{
+				{currentReplicas: 3, evaluation: 5, expected: 0},
+			},
+		},
+		{
+			description:       "Fail to get state",
+			expectedErr:       errors.New("failed to get cooldown state for test: fail to get"),
+			store:             &failStore{getErr: errors.New("fail to get")},
+			scaleDownCooldown: 5 * time.Minute,
+			steps: []step{
+				{currentReplicas: 3, evaluation: 5, expected: 0},
+			},
+		},
+		{
+			description:       "Fail to set state",
+			expectedErr:       errors.New("failed to set cooldown state for test: fail to set"),
+			store:             &failStore{setErr: errors.New("fail to set")},
+			scaleDownCooldown: 5 * time.Minute,
+			steps: []step{
+				{currentReplicas: 3, evaluation: 5, expected: 0},
+			},
+		},
+		{
+			description:       "No change, state not needed",
+			expectedErr:       nil,
+			store:             &failStore{getErr: errors.New("fail to get")},
+			scaleDownCooldown: 5 * time.Minute,
+			steps: []step{
+				{currentReplicas: 3, evaluation: 3, expected: 3},
+			},
+		},
+		{
+			description:       "Scale down held after scale up, allowed after cooldown",
+			expectedErr:       nil,
+			scaleDownCooldown: 5 * time.Minute,
+			steps: []step{
+				{currentReplicas: 3, evaluation: 5, expected: 5},
+				{advance: time.Minute, currentReplicas: 5, evaluation: 2, expected: 5},
+				{advance: 3 * time.Minute, currentReplicas: 5, evaluation: 2, expected: 5},
+				{advance: time.Minute, currentReplicas: 5, evaluation: 2, expected: 2},
+			},
+		},
+		{
+			description:       "Scale ups not held by scale down cooldown",
+			expectedErr:       nil,
+			scaleDownCooldown: 5 * time.Minute,
+			steps: []step{
+				{currentReplicas: 5, evaluation: 2, expected: 2},
+				{advance: time.Minute, currentReplicas: 2, evaluation: 4, expected: 4},
+				{advance: time.Minute, currentReplicas: 4, evaluation: 6, expected: 6},
+			},
+		},
+		{
+			description:     "Scale up held after scale down",
+			expectedErr:     nil,
+			scaleUpCooldown: 2 * time.Minute,
+			steps: []step{
+				{currentReplicas: 5, evaluation: 2, expected: 2},
+				{advance: time.Minute, currentReplicas: 2, evaluation: 4, expected: 2},
+				{advance: time.Minute, currentReplicas: 2, evaluation: 4, expected: 4},
+			},
+		},
+		{
+			description: "No cooldowns",
+			expectedErr: nil,
+			steps: []step{
+				{currentReplicas: 3, evaluation: 5, expected: 5},
+				{currentReplicas: 5, evaluation: 2, expected: 2},
+				{currentReplicas: 2, evaluation: 4, expected: 4},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakePassiveClock(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+			var evaluation int32
+			evaluator := &cooldown.Evaluator{
+				Evaluator: &k8shorizmetrics.Evaluator{
+					Resource: &fake.ResourceEvaluater{
+						EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+							return evaluation, test.evaluateErr
+						},
+					},
+				},
+				ScaleUpCooldown:   test.scaleUpCooldown,
+				ScaleDownCooldown: test.scaleDownCooldown,
+				Store:             test.store,
+				Clock:             fakeClock,
+			}
+
+			var err error
+			for i, step := range test.steps {
+				fakeClock.SetTime(fakeClock.Now().Add(step.advance))
+				evaluation = step.evaluation
+				var result int32
+				result, err = evaluator.Evaluate("test", gatheredMetrics, step.currentReplicas)
+				if result != step.expected {
+					t.Errorf("step %d mismatch, want %d, got %d", i, step.expected, result)
+				}
+			}
+
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+			}
+		})
+	}
+}
+
+func TestEvaluator_Forget(t *testing.T) {
+	store := &cooldown.MemoryStore{}
+	evaluator := cooldown.NewEvaluator(&k8shorizmetrics.Evaluator{}, time.Minute, time.Minute)
+	evaluator.Store = store
+
+	err := store.Set(context.Background(), "test", cooldown.State{LastScaleUp: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = evaluator.Forget(context.Background(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, _ := store.Get(context.Background(), "test")
+	if !cmp.Equal(cooldown.State{}, state) {
+		t.Errorf("state mismatch (-want +got):\n%s", cmp.Diff(cooldown.State{}, state))
+	}
+}
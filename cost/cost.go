@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cost provides a cost model for replicas, derived from the resource requests of a replica and a price table,
+// along with an Evaluator that caps replica count evaluations at the number of replicas affordable within a budget.
+package cost
+
+import (
+	"math"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Price is the cost of a quantity of a resource, for example a Cost of 0.04 Per 1 CPU
+type Price struct {
+	Per  resource.Quantity `json:"per"`
+	Cost float64           `json:"cost"`
+}
+
+// PriceTable is the price of each resource, any resources without a price are treated as free
+type PriceTable map[corev1.ResourceName]Price
+
+// Model calculates the cost of replicas using a price table
+type Model struct {
+	Prices PriceTable
+}
+
+// ReplicaCost returns the cost of a single replica with the resource requests provided
+func (m *Model) ReplicaCost(requests corev1.ResourceList) float64 {
+	var cost float64
+	for resourceName, request := range requests {
+		price, ok := m.Prices[resourceName]
+		if !ok || price.Per.IsZero() {
+			continue
+		}
+		cost += request.AsApproximateFloat64() / price.Per.AsApproximateFloat64() * price.Cost
+	}
+	return cost
+}
+
+// PodRequests returns the total resource requests of the app containers and restartable init containers (sidecars) in
+// the pod spec provided, see podutil.RunningContainers
+func PodRequests(podSpec *corev1.PodSpec) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range podutil.RunningContainers(podSpec) {
+		for resourceName, request := range container.Resources.Requests {
+			total := requests[resourceName]
+			total.Add(request)
+			requests[resourceName] = total
+		}
+	}
+	return requests
+}
+
+// Recommendation is a replica count evaluation capped by a budget. MetricReplicas is the raw replica count driven by
+// the metrics, BudgetReplicas is the maximum number of replicas affordable within the budget (nil if replicas are
+// free) and Replicas is the lower of the two.
+type Recommendation struct {
+	Replicas       int32   `json:"replicas"`
+	MetricReplicas int32   `json:"metricReplicas"`
+	BudgetReplicas *int32  `json:"budgetReplicas,omitempty"`
+	Capped         bool    `json:"capped"`
+	ReplicaCost    float64 `json:"replicaCost"`
+	Cost           float64 `json:"cost"`
+}
+
+// Evaluator wraps an Evaluator, capping its evaluations at the number of replicas affordable within the Budget given
+// the cost of a replica with the Requests provided. If the Budget is not greater than 0 there is no budget and
+// evaluations are not capped. The budget never caps evaluations below MinReplicas, or a single replica if MinReplicas
+// is not set, so a budget smaller than the cost of a replica cannot scale a target to zero, see the scaletozero
+// package for scaling to zero.
+type Evaluator struct {
+	Evaluator   *k8shorizmetrics.Evaluator
+	Model       *Model
+	Requests    corev1.ResourceList
+	Budget      float64
+	MinReplicas int32
+}
+
+// Evaluate returns the target replica count for the metrics provided capped by the budget, along with the raw metric
+// driven replica count and the economic cap
+func (e *Evaluator) Evaluate(gatheredMetrics []*metrics.Metric, currentReplicas int32) (*Recommendation, error) {
+	metricReplicas, err := e.Evaluator.Evaluate(gatheredMetrics, currentReplicas)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Cap(metricReplicas), nil
+}
+
+// Cap applies the budget to the replica count provided, never capping it below MinReplicas, or a single replica if
+// MinReplicas is not set. Replica counts already below the minimum are left unchanged.
+func (e *Evaluator) Cap(metricReplicas int32) *Recommendation {
+	replicaCost := e.Model.ReplicaCost(e.Requests)
+
+	recommendation := &Recommendation{
+		Replicas:       metricReplicas,
+		MetricReplicas: metricReplicas,
+		ReplicaCost:    replicaCost,
+	}
+
+	if replicaCost > 0 && e.Budget > 0 {
+		minReplicas := e.MinReplicas
+		if minReplicas <= 0 {
+			minReplicas = 1
+		}
+		budgetReplicas := int32(max(min(math.Floor(e.Budget/replicaCost), math.MaxInt32), float64(minReplicas)))
+		recommendation.BudgetReplicas = &budgetReplicas
+		if metricReplicas > budgetReplicas {
+			recommendation.Replicas = budgetReplicas
+			recommendation.Capped = true
+		}
+	}
+
+	recommendation.Cost = float64(recommendation.Replicas) * replicaCost
+
+	return recommendation
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/cost"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var prices = cost.PriceTable{
+	corev1.ResourceCPU: {
+		Per:  resource.MustParse("1"),
+		Cost: 0.04,
+	},
+	corev1.ResourceMemory: {
+		Per:  resource.MustParse("1Gi"),
+		Cost: 0.005,
+	},
+}
+
+func TestReplicaCost(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    float64
+		prices      cost.PriceTable
+		requests    corev1.ResourceList
+	}{
+		{
+			description: "No prices, free",
+			expected:    0,
+			prices:      cost.PriceTable{},
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("500m"),
+			},
+		},
+		{
+			description: "CPU and memory, ignore unpriced resources",
+			expected:    0.04*0.5 + 0.005*2,
+			prices:      prices,
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("500m"),
+				corev1.ResourceMemory:           resource.MustParse("2Gi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("10Gi"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			model := &cost.Model{
+				Prices: test.prices,
+			}
+			result := model.ReplicaCost(test.requests)
+			if !cmp.Equal(test.expected, result, cmpopts.EquateApprox(0, 1e-9)) {
+				t.Errorf("cost mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestPodRequests(t *testing.T) {
+	restartAlways := corev1.ContainerRestartPolicyAlways
+	podSpec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			{
+				// Init containers which run to completion are not included
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("4"),
+					},
+				},
+			},
+			{
+				// Sidecar containers run alongside the app containers so are included
+				RestartPolicy: &restartAlways,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+						corev1.ResourceMemory: resource.MustParse("64Mi"),
+					},
+				},
+			},
+		},
+		Containers: []corev1.Container{
+			{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("250m"),
+						corev1.ResourceMemory: resource.MustParse("512Mi"),
+					},
+				},
+			},
+			{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("750m"),
+					},
+				},
+			},
+		},
+	}
+
+	expected := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1100m"),
+		corev1.ResourceMemory: resource.MustParse("576Mi"),
+	}
+
+	result := cost.PodRequests(podSpec)
+	if !cmp.Equal(expected, result, cmp.Comparer(func(x, y resource.Quantity) bool { return x.Cmp(y) == 0 })) {
+		t.Errorf("requests mismatch (-want +got):\n%s", cmp.Diff(expected, result))
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	// 1 CPU and 2Gi costs 0.05 per replica
+	requests := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("2Gi"),
+	}
+
+	gatheredMetrics := []*metrics.Metric{
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+			},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expected    *cost.Recommendation
+		expectedErr error
+		evaluator   *k8shorizmetrics.Evaluator
+		prices      cost.PriceTable
+		budget      float64
+		minReplicas int32
+	}{
+		{
+			description: "Fail to evaluate",
+			expectedErr: errors.New("evaluator multi metric error: 1 errors, first error is fail to evaluate"),
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 0, errors.New("fail to evaluate")
+					},
+				},
+			},
+			prices: prices,
+		},
+		{
+			description: "Within budget",
+			expected: &cost.Recommendation{
+				Replicas:       4,
+				MetricReplicas: 4,
+				BudgetReplicas: int32Ptr(10),
+				ReplicaCost:    0.05,
+				Cost:           0.2,
+			},
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 4, nil
+					},
+				},
+			},
+			prices: prices,
+			budget: 0.5,
+		},
+		{
+			description: "Over budget, capped",
+			expected: &cost.Recommendation{
+				Replicas:       10,
+				MetricReplicas: 15,
+				BudgetReplicas: int32Ptr(10),
+				Capped:         true,
+				ReplicaCost:    0.05,
+				Cost:           0.5,
+			},
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 15, nil
+					},
+				},
+			},
+			prices: prices,
+			budget: 0.5,
+		},
+		{
+			description: "No budget, no cap",
+			expected: &cost.Recommendation{
+				Replicas:       15,
+				MetricReplicas: 15,
+				ReplicaCost:    0.05,
+				Cost:           0.75,
+			},
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 15, nil
+					},
+				},
+			},
+			prices: prices,
+		},
+		{
+			description: "Budget below the cost of a replica, capped at one replica",
+			expected: &cost.Recommendation{
+				Replicas:       1,
+				MetricReplicas: 4,
+				BudgetReplicas: int32Ptr(1),
+				Capped:         true,
+				ReplicaCost:    0.05,
+				Cost:           0.05,
+			},
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 4, nil
+					},
+				},
+			},
+			prices: prices,
+			budget: 0.01,
+		},
+		{
+			description: "Budget below the cost of a replica, capped at min replicas",
+			expected: &cost.Recommendation{
+				Replicas:       2,
+				MetricReplicas: 4,
+				BudgetReplicas: int32Ptr(2),
+				Capped:         true,
+				ReplicaCost:    0.05,
+				Cost:           0.1,
+			},
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 4, nil
+					},
+				},
+			},
+			prices:      prices,
+			budget:      0.01,
+			minReplicas: 2,
+		},
+		{
+			description: "Evaluation below min replicas, not raised",
+			expected: &cost.Recommendation{
+				Replicas:       0,
+				MetricReplicas: 0,
+				BudgetReplicas: int32Ptr(1),
+				ReplicaCost:    0.05,
+			},
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 0, nil
+					},
+				},
+			},
+			prices: prices,
+			budget: 0.01,
+		},
+		{
+			description: "Free replicas, no cap",
+			expected: &cost.Recommendation{
+				Replicas:       15,
+				MetricReplicas: 15,
+			},
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 15, nil
+					},
+				},
+			},
+			prices: cost.PriceTable{},
+			budget: 0.5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluator := &cost.Evaluator{
+				Evaluator: test.evaluator,
+				Model: &cost.Model{
+					Prices: test.prices,
+				},
+				Requests:    requests,
+				Budget:      test.budget,
+				MinReplicas: test.minReplicas,
+			}
+			result, err := evaluator.Evaluate(gatheredMetrics, 3)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result, cmpopts.EquateApprox(0, 1e-9)) {
+				t.Errorf("recommendation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cpaconfig translates Custom Pod Autoscaler configuration into configured Gatherers and Evaluators. The
+// Custom Pod Autoscaler provides configuration to autoscalers as environment variables, this package reads the same
+// keys used by the Horizontal Pod Autoscaler Custom Pod Autoscaler (tolerance, cpuInitializationPeriod,
+// initialReadinessDelay and metrics).
+package cpaconfig
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Configuration keys read from the Custom Pod Autoscaler configuration
+const (
+	ToleranceKey               = "tolerance"
+	CPUInitializationPeriodKey = "cpuInitializationPeriod"
+	InitialReadinessDelayKey   = "initialReadinessDelay"
+	MetricsKey                 = "metrics"
+)
+
+// Defaults used if a configuration key is not provided, these match the HPA defaults
+const (
+	DefaultTolerance               = 0.1
+	DefaultCPUInitializationPeriod = 300
+	DefaultInitialReadinessDelay   = 30
+)
+
+// Config is the Custom Pod Autoscaler configuration relevant to gathering and evaluating metrics, the CPU
+// initialization period and initial readiness delay are in seconds
+type Config struct {
+	Tolerance               float64                    `json:"tolerance"`
+	CPUInitializationPeriod int64                      `json:"cpuInitializationPeriod"`
+	InitialReadinessDelay   int64                      `json:"initialReadinessDelay"`
+	Metrics                 []autoscalingv2.MetricSpec `json:"metrics"`
+}
+
+// LoadFromEnv loads the configuration from the environment variables set by the Custom Pod Autoscaler
+func LoadFromEnv() (*Config, error) {
+	return Load(os.LookupEnv)
+}
+
+// Load loads the configuration using the lookup function provided to retrieve each configuration key, any keys that
+// are not found are set to their defaults. The metrics key can be provided as either JSON or YAML.
+func Load(lookup func(key string) (string, bool)) (*Config, error) {
+	config := &Config{
+		Tolerance:               DefaultTolerance,
+		CPUInitializationPeriod: DefaultCPUInitializationPeriod,
+		InitialReadinessDelay:   DefaultInitialReadinessDelay,
+	}
+
+	if value, ok := lookup(ToleranceKey); ok {
+		tolerance, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s configuration: %w", ToleranceKey, err)
+		}
+		config.Tolerance = tolerance
+	}
+
+	if value, ok := lookup(CPUInitializationPeriodKey); ok {
+		cpuInitializationPeriod, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s configuration: %w", CPUInitializationPeriodKey, err)
+		}
+		config.CPUInitializationPeriod = cpuInitializationPeriod
+	}
+
+	if value, ok := lookup(InitialReadinessDelayKey); ok {
+		initialReadinessDelay, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s configuration: %w", InitialReadinessDelayKey, err)
+		}
+		config.InitialReadinessDelay = initialReadinessDelay
+	}
+
+	if value, ok := lookup(MetricsKey); ok {
+		var specs []autoscalingv2.MetricSpec
+		err := yaml.Unmarshal([]byte(value), &specs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s configuration: %w", MetricsKey, err)
+		}
+		config.Metrics = specs
+	}
+
+	return config, nil
+}
+
+// NewGatherer sets up a new Gatherer using the CPU initialization period and initial readiness delay from the
+// configuration
+func (c *Config) NewGatherer(metricsclient metricsclient.Client, podlister corelisters.PodLister) *k8shorizmetrics.Gatherer {
+	return k8shorizmetrics.NewGatherer(metricsclient, podlister,
+		time.Duration(c.CPUInitializationPeriod)*time.Second,
+		time.Duration(c.InitialReadinessDelay)*time.Second)
+}
+
+// NewEvaluator sets up a new Evaluator using the tolerance from the configuration
+func (c *Config) NewEvaluator() *k8shorizmetrics.Evaluator {
+	return k8shorizmetrics.NewEvaluator(c.Tolerance)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpaconfig_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/cpaconfig"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestLoad(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description string
+		expected    *cpaconfig.Config
+		expectedErr error
+		values      map[string]string
+	}{
+		{
+			description: "No configuration, use defaults",
+			expected: &cpaconfig.Config{
+				Tolerance:               0.1,
+				CPUInitializationPeriod: 300,
+				InitialReadinessDelay:   30,
+			},
+			values: map[string]string{},
+		},
+		{
+			description: "Invalid tolerance",
+			expectedErr: errors.New(`invalid tolerance configuration: strconv.ParseFloat: parsing "invalid": invalid syntax`),
+			values: map[string]string{
+				"tolerance": "invalid",
+			},
+		},
+		{
+			description: "Invalid CPU initialization period",
+			expectedErr: errors.New(`invalid cpuInitializationPeriod configuration: strconv.ParseInt: parsing "invalid": invalid syntax`),
+			values: map[string]string{
+				"cpuInitializationPeriod": "invalid",
+			},
+		},
+		{
+			description: "Invalid initial readiness delay",
+			expectedErr: errors.New(`invalid initialReadinessDelay configuration: strconv.ParseInt: parsing "invalid": invalid syntax`),
+			values: map[string]string{
+				"initialReadinessDelay": "invalid",
+			},
+		},
+		{
+			description: "Invalid metrics",
+			expectedErr: errors.New(`invalid metrics configuration: error unmarshaling JSON: while decoding JSON: json: cannot unmarshal string into Go value of type []v2.MetricSpec`),
+			values: map[string]string{
+				"metrics": "invalid",
+			},
+		},
+		{
+			description: "Full configuration, YAML metrics",
+			expected: &cpaconfig.Config{
+				Tolerance:               0.2,
+				CPUInitializationPeriod: 60,
+				InitialReadinessDelay:   10,
+				Metrics: []autoscalingv2.MetricSpec{
+					{
+						Type: autoscalingv2.ResourceMetricSourceType,
+						Resource: &autoscalingv2.ResourceMetricSource{
+							Name: corev1.ResourceCPU,
+							Target: autoscalingv2.MetricTarget{
+								Type:               autoscalingv2.UtilizationMetricType,
+								AverageUtilization: testutil.Int32Ptr(50),
+							},
+						},
+					},
+				},
+			},
+			values: map[string]string{
+				"tolerance":               "0.2",
+				"cpuInitializationPeriod": "60",
+				"initialReadinessDelay":   "10",
+				"metrics": `
+- type: Resource
+  resource:
+    name: cpu
+    target:
+      type: Utilization
+      averageUtilization: 50
+`,
+			},
+		},
+		{
+			description: "JSON metrics",
+			expected: &cpaconfig.Config{
+				Tolerance:               0.1,
+				CPUInitializationPeriod: 300,
+				InitialReadinessDelay:   30,
+				Metrics: []autoscalingv2.MetricSpec{
+					{
+						Type: autoscalingv2.ResourceMetricSourceType,
+						Resource: &autoscalingv2.ResourceMetricSource{
+							Name: corev1.ResourceMemory,
+							Target: autoscalingv2.MetricTarget{
+								Type:               autoscalingv2.UtilizationMetricType,
+								AverageUtilization: testutil.Int32Ptr(70),
+							},
+						},
+					},
+				},
+			},
+			values: map[string]string{
+				"metrics": `[{"type":"Resource","resource":{"name":"memory","target":{"type":"Utilization","averageUtilization":70}}}]`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			config, err := cpaconfig.Load(func(key string) (string, bool) {
+				value, ok := test.values[key]
+				return value, ok
+			})
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, config) {
+				t.Errorf("config mismatch (-want +got):\n%s", cmp.Diff(test.expected, config))
+			}
+		})
+	}
+}
+
+func TestConfig_NewGathererAndEvaluator(t *testing.T) {
+	config := &cpaconfig.Config{
+		Tolerance:               0.25,
+		CPUInitializationPeriod: 120,
+		InitialReadinessDelay:   15,
+	}
+
+	gatherer := config.NewGatherer(nil, nil)
+	if gatherer.CPUInitializationPeriod != 120*time.Second {
+		t.Errorf("cpu initialization period mismatch, want %s got %s", 120*time.Second, gatherer.CPUInitializationPeriod)
+	}
+	if gatherer.DelayOfInitialReadinessStatus != 15*time.Second {
+		t.Errorf("initial readiness delay mismatch, want %s got %s", 15*time.Second, gatherer.DelayOfInitialReadinessStatus)
+	}
+
+	evaluator := config.NewEvaluator()
+	if evaluator.Tolerance != 0.25 {
+		t.Errorf("tolerance mismatch, want %f got %f", 0.25, evaluator.Tolerance)
+	}
+}
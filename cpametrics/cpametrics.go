@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cpametrics converts gathered metrics to and from the JSON format used by the Custom Pod Autoscaler to pass
+// metrics between its metric and evaluate hooks. A metric hook outputs the value produced by Marshal, which the Custom
+// Pod Autoscaler wraps in a ResourceMetric for the resource it gathered metrics for, and then provides to the evaluate
+// hook as part of an EvaluateSpec. ParseEvaluateSpec reads the evaluate hook input and returns the gathered metrics
+// from each ResourceMetric.
+package cpametrics
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+)
+
+// Run types provided by the Custom Pod Autoscaler to hooks
+const (
+	RunTypeScaler       = "scaler"
+	RunTypeAPI          = "api"
+	RunTypeAPIDryRun    = "api_dry_run"
+	RunTypeScalerDryRun = "scaler_dry_run"
+)
+
+// ResourceMetric is the Custom Pod Autoscaler representation of the output of a metric hook for a resource, the value
+// is the raw hook output
+type ResourceMetric struct {
+	Resource string `json:"resource"`
+	Value    string `json:"value"`
+}
+
+// EvaluateSpec is the input the Custom Pod Autoscaler provides to an evaluate hook, the resource is the scale target
+// the metrics were gathered for, left as raw JSON as it can be any scalable resource
+type EvaluateSpec struct {
+	Resource json.RawMessage   `json:"resource,omitempty"`
+	Metrics  []*ResourceMetric `json:"metrics"`
+	RunType  string            `json:"runType"`
+}
+
+// Marshal encodes the gathered metrics as the output of a metric hook
+func Marshal(gatheredMetrics []*metrics.Metric) ([]byte, error) {
+	data, err := json.Marshal(gatheredMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metrics: %w", err)
+	}
+	return data, nil
+}
+
+// Unmarshal decodes gathered metrics from the output of a metric hook
+func Unmarshal(data []byte) ([]*metrics.Metric, error) {
+	var gatheredMetrics []*metrics.Metric
+	err := json.Unmarshal(data, &gatheredMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metrics: %w", err)
+	}
+	return gatheredMetrics, nil
+}
+
+// ToResourceMetric encodes the gathered metrics as the Custom Pod Autoscaler resource metric for the named resource
+func ToResourceMetric(resource string, gatheredMetrics []*metrics.Metric) (*ResourceMetric, error) {
+	data, err := Marshal(gatheredMetrics)
+	if err != nil {
+		return nil, err
+	}
+	return &ResourceMetric{
+		Resource: resource,
+		Value:    string(data),
+	}, nil
+}
+
+// FromResourceMetrics decodes the gathered metrics from each Custom Pod Autoscaler resource metric, combining them in
+// order into a single slice
+func FromResourceMetrics(resourceMetrics []*ResourceMetric) ([]*metrics.Metric, error) {
+	var gatheredMetrics []*metrics.Metric
+	for _, resourceMetric := range resourceMetrics {
+		if resourceMetric == nil {
+			continue
+		}
+		decoded, err := Unmarshal([]byte(resourceMetric.Value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid metrics for resource '%s': %w", resourceMetric.Resource, err)
+		}
+		gatheredMetrics = append(gatheredMetrics, decoded...)
+	}
+	return gatheredMetrics, nil
+}
+
+// ParseEvaluateSpec decodes the input provided to an evaluate hook, returning the spec along with the gathered metrics
+// from each of its resource metrics
+func ParseEvaluateSpec(data []byte) (*EvaluateSpec, []*metrics.Metric, error) {
+	spec := &EvaluateSpec{}
+	err := json.Unmarshal(data, spec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal evaluate spec: %w", err)
+	}
+	gatheredMetrics, err := FromResourceMetrics(spec.Metrics)
+	if err != nil {
+		return nil, nil, err
+	}
+	return spec, gatheredMetrics, nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpametrics_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/cpametrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func cpuMetric(pod string, value int64) *metrics.Metric {
+	timestamp := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: testutil.Int32Ptr(50),
+				},
+			},
+		},
+		Resource: &resource.Metric{
+			PodMetricsInfo: podmetrics.MetricsInfo{
+				pod: podmetrics.Metric{
+					Timestamp: timestamp,
+					Window:    time.Minute,
+					Value:     value,
+				},
+			},
+			Requests:      map[string]int64{pod: 1000},
+			ReadyPodCount: 1,
+			IgnoredPods:   sets.String{},
+			MissingPods:   sets.String{},
+			TotalPods:     1,
+			Timestamp:     timestamp,
+		},
+	}
+}
+
+func TestResourceMetricRoundTrip(t *testing.T) {
+	var tests = []struct {
+		description string
+		metrics     []*metrics.Metric
+	}{
+		{
+			description: "No metrics",
+			metrics:     []*metrics.Metric{},
+		},
+		{
+			description: "Single resource metric",
+			metrics:     []*metrics.Metric{cpuMetric("pod-1", 500)},
+		},
+		{
+			description: "Multiple resource metrics",
+			metrics:     []*metrics.Metric{cpuMetric("pod-1", 500), cpuMetric("pod-2", 250)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			resourceMetric, err := cpametrics.ToResourceMetric("test", test.metrics)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resourceMetric.Resource != "test" {
+				t.Errorf("resource mismatch, want 'test' got '%s'", resourceMetric.Resource)
+			}
+
+			result, err := cpametrics.FromResourceMetrics([]*cpametrics.ResourceMetric{resourceMetric})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var expected []*metrics.Metric
+			if len(test.metrics) > 0 {
+				expected = test.metrics
+			}
+			if !cmp.Equal(expected, result) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(expected, result))
+			}
+		})
+	}
+}
+
+func TestParseEvaluateSpec(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	first, err := cpametrics.Marshal([]*metrics.Metric{cpuMetric("pod-1", 500)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := cpametrics.Marshal([]*metrics.Metric{cpuMetric("pod-2", 250)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	evaluateInput := func(resourceMetrics ...*cpametrics.ResourceMetric) []byte {
+		data, err := json.Marshal(&cpametrics.EvaluateSpec{
+			Resource: json.RawMessage(`{"kind":"Deployment","metadata":{"name":"test"}}`),
+			Metrics:  resourceMetrics,
+			RunType:  cpametrics.RunTypeScaler,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return data
+	}
+
+	var tests = []struct {
+		description     string
+		expected        []*metrics.Metric
+		expectedRunType string
+		expectedErr     error
+		input           []byte
+	}{
+		{
+			description: "Invalid JSON",
+			expectedErr: errors.New("failed to unmarshal evaluate spec: unexpected end of JSON input"),
+			input:       []byte(`{`),
+		},
+		{
+			description: "Invalid metric value",
+			expectedErr: errors.New("invalid metrics for resource 'test': failed to unmarshal metrics: " +
+				"invalid character 'o' in literal null (expecting 'u')"),
+			input: evaluateInput(&cpametrics.ResourceMetric{Resource: "test", Value: "not metrics"}),
+		},
+		{
+			description:     "No metrics",
+			expectedRunType: cpametrics.RunTypeScaler,
+			input:           evaluateInput(),
+		},
+		{
+			description:     "Metrics from multiple resource metrics combined in order",
+			expected:        []*metrics.Metric{cpuMetric("pod-1", 500), cpuMetric("pod-2", 250)},
+			expectedRunType: cpametrics.RunTypeScaler,
+			input: evaluateInput(
+				&cpametrics.ResourceMetric{Resource: "test", Value: string(first)},
+				nil,
+				&cpametrics.ResourceMetric{Resource: "test", Value: string(second)},
+			),
+		},
+		{
+			description:     "Metric hook output parsed from raw CPA input",
+			expected:        []*metrics.Metric{cpuMetric("pod-1", 500)},
+			expectedRunType: cpametrics.RunTypeAPIDryRun,
+			input: []byte(`{"resource":{"kind":"Deployment"},"runType":"api_dry_run","metrics":[` +
+				`{"resource":"test","value":` + string(mustMarshalString(t, string(first))) + `}]}`),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			spec, result, err := cpametrics.ParseEvaluateSpec(test.input)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if err != nil {
+				return
+			}
+			if spec.RunType != test.expectedRunType {
+				t.Errorf("run type mismatch, want '%s' got '%s'", test.expectedRunType, spec.RunType)
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func mustMarshalString(t *testing.T, value string) []byte {
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return data
+}
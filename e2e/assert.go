@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podsclient"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// NewGatherer returns a Gatherer which gathers metrics from the cluster
+func (c *Cluster) NewGatherer(cpuInitializationPeriod time.Duration,
+	delayOfInitialReadinessStatus time.Duration) *k8shorizmetrics.Gatherer {
+	return k8shorizmetrics.NewGatherer(
+		metricsclient.NewClient(c.Config, c.Clientset.Discovery()),
+		&podsclient.OnDemandPodLister{
+			Clientset: c.Clientset,
+		},
+		cpuInitializationPeriod,
+		delayOfInitialReadinessStatus,
+	)
+}
+
+// EventuallyGather gathers the metric specs provided until all of them gather successfully and the check provided
+// returns nil, failing the test if this does not happen before the context is done. Metrics take time to become
+// available after a workload is deployed, so a gather failure is retried rather than failing the test.
+func EventuallyGather(ctx context.Context, t testing.TB, gatherer *k8shorizmetrics.Gatherer,
+	specs []autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector,
+	check func(gatheredMetrics []*metrics.Metric) error) []*metrics.Metric {
+	t.Helper()
+
+	var gatheredMetrics []*metrics.Metric
+	var lastErr error
+	err := wait.PollUntilContextCancel(ctx, DefaultPollInterval, true, func(ctx context.Context) (bool, error) {
+		gatheredMetrics, lastErr = gatherer.GatherWithContext(ctx, specs, namespace, podSelector)
+		if lastErr != nil {
+			return false, nil
+		}
+		if check != nil {
+			lastErr = check(gatheredMetrics)
+		}
+		return lastErr == nil, nil
+	})
+	if err != nil {
+		t.Fatalf("metrics were not gathered as expected: %v, last error: %v", err, lastErr)
+	}
+	return gatheredMetrics
+}
+
+// EventuallyEvaluate gathers and evaluates the metric specs provided until the evaluation succeeds and the check
+// provided returns nil, failing the test if this does not happen before the context is done
+func EventuallyEvaluate(ctx context.Context, t testing.TB, gatherer *k8shorizmetrics.Gatherer,
+	evaluator *k8shorizmetrics.Evaluator, specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector, currentReplicas int32, check func(replicas int32) error) int32 {
+	t.Helper()
+
+	var replicas int32
+	var lastErr error
+	err := wait.PollUntilContextCancel(ctx, DefaultPollInterval, true, func(ctx context.Context) (bool, error) {
+		var gatheredMetrics []*metrics.Metric
+		gatheredMetrics, lastErr = gatherer.GatherWithContext(ctx, specs, namespace, podSelector)
+		if lastErr != nil {
+			return false, nil
+		}
+		replicas, lastErr = evaluator.EvaluateWithContext(ctx, gatheredMetrics, currentReplicas)
+		if lastErr != nil {
+			return false, nil
+		}
+		if check != nil {
+			lastErr = check(replicas)
+		}
+		return lastErr == nil, nil
+	})
+	if err != nil {
+		t.Fatalf("metrics were not evaluated as expected: %v, last error: %v", err, lastErr)
+	}
+	return replicas
+}
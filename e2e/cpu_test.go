@@ -0,0 +1,108 @@
+//go:build e2e
+
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/e2e"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TestCPUUtilization runs against the cluster pointed to by the E2E_KUBECONFIG environment variable, or provisions a
+// kind cluster if it is not set
+func TestCPUUtilization(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	cluster, err := e2e.Setup(ctx, e2e.Options{
+		Kubeconfig: os.Getenv("E2E_KUBECONFIG"),
+	})
+	if err != nil {
+		t.Fatalf("failed to set up cluster: %v", err)
+	}
+	defer func() {
+		err := cluster.Teardown(context.Background())
+		if err != nil {
+			t.Errorf("failed to tear down cluster: %v", err)
+		}
+	}()
+
+	err = cluster.DeployMetricsServer(ctx, "")
+	if err != nil {
+		t.Fatalf("failed to deploy metrics-server: %v", err)
+	}
+
+	workload := &e2e.Workload{
+		Namespace: "k8shorizmetrics-e2e",
+		Name:      "php-apache",
+		Replicas:  2,
+	}
+	err = cluster.DeployWorkload(ctx, workload)
+	if err != nil {
+		t.Fatalf("failed to deploy workload: %v", err)
+	}
+	defer func() {
+		err := cluster.DeleteWorkload(context.Background(), workload)
+		if err != nil {
+			t.Errorf("failed to delete workload: %v", err)
+		}
+	}()
+
+	specs := []autoscalingv2.MetricSpec{
+		{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: testutil.Int32Ptr(50),
+				},
+			},
+		},
+	}
+
+	gatherer := cluster.NewGatherer(0, 0)
+
+	e2e.EventuallyGather(ctx, t, gatherer, specs, workload.Namespace, workload.Selector(),
+		func(gatheredMetrics []*metrics.Metric) error {
+			podMetrics := len(gatheredMetrics[0].Resource.PodMetricsInfo)
+			if podMetrics != int(workload.Replicas) {
+				return fmt.Errorf("expected metrics for %d pods, got %d", workload.Replicas, podMetrics)
+			}
+			return nil
+		})
+
+	// An idle workload is well below the target utilization so should scale down to a single replica
+	e2e.EventuallyEvaluate(ctx, t, gatherer, k8shorizmetrics.NewEvaluator(0.1), specs, workload.Namespace,
+		workload.Selector(), workload.Replicas, func(replicas int32) error {
+			if replicas != 1 {
+				return fmt.Errorf("expected 1 replica, got %d", replicas)
+			}
+			return nil
+		})
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package e2e provides a harness for end to end testing the library, and autoscalers built with it, against live
+// metrics. The harness provisions a kind cluster or attaches to an existing cluster, deploys metrics-server and sample
+// workloads, and provides helpers for asserting gather and evaluate results.
+//
+// Provisioning shells out to the kind and kubectl binaries, which must be available. The tests in this package which
+// run against a live cluster are behind the e2e build tag:
+//
+//	go test -tags e2e ./e2e/...
+package e2e
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// DefaultClusterName is the name of the kind cluster used if no name is provided
+	DefaultClusterName = "k8shorizmetrics-e2e"
+	// DefaultMetricsServerManifest is the metrics-server manifest deployed if no manifest is provided
+	DefaultMetricsServerManifest = "https://github.com/kubernetes-sigs/metrics-server/releases/latest/download/components.yaml"
+	// DefaultPollInterval is how often the harness checks whether a condition has been met
+	DefaultPollInterval = 5 * time.Second
+)
+
+const (
+	metricsServerNamespace = "kube-system"
+	metricsServerName      = "metrics-server"
+	kubeletInsecureTLSArg  = "--kubelet-insecure-tls"
+)
+
+// CommandRunner runs an external command, returning its combined output
+type CommandRunner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+// ExecCommandRunner runs the command provided using os/exec
+func ExecCommandRunner(ctx context.Context, name string, args ...string) ([]byte, error) {
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return output, fmt.Errorf("failed to run '%s %s': %w: %s", name, strings.Join(args, " "), err, output)
+	}
+	return output, nil
+}
+
+// Options configures how the cluster is set up. If Kubeconfig is provided the harness attaches to the existing
+// cluster it points to, otherwise the kind cluster named ClusterName is used, being created if it does not exist.
+type Options struct {
+	ClusterName   string
+	Kubeconfig    string
+	NodeImage     string
+	KindBinary    string
+	KubectlBinary string
+	Runner        CommandRunner
+}
+
+// Cluster is a live cluster used for end to end tests
+type Cluster struct {
+	Name       string
+	Kubeconfig string
+	Config     *rest.Config
+	Clientset  kubernetes.Interface
+
+	kind                string
+	kubectl             string
+	runner              CommandRunner
+	created             bool
+	ownedKubeconfigFile bool
+}
+
+// Setup provisions or attaches to a cluster using the options provided. Teardown should be called once the cluster
+// is no longer needed, which only deletes the cluster if it was created by Setup.
+func Setup(ctx context.Context, options Options) (*Cluster, error) {
+	cluster := &Cluster{
+		Name:    options.ClusterName,
+		kind:    options.KindBinary,
+		kubectl: options.KubectlBinary,
+		runner:  options.Runner,
+	}
+	if cluster.Name == "" {
+		cluster.Name = DefaultClusterName
+	}
+	if cluster.kind == "" {
+		cluster.kind = "kind"
+	}
+	if cluster.kubectl == "" {
+		cluster.kubectl = "kubectl"
+	}
+	if cluster.runner == nil {
+		cluster.runner = ExecCommandRunner
+	}
+
+	if options.Kubeconfig != "" {
+		cluster.Kubeconfig = options.Kubeconfig
+		kubeconfig, err := os.ReadFile(options.Kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read kubeconfig: %w", err)
+		}
+		err = cluster.connect(kubeconfig)
+		if err != nil {
+			return nil, err
+		}
+		return cluster, nil
+	}
+
+	exists, err := cluster.kindClusterExists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		args := []string{"create", "cluster", "--name", cluster.Name, "--wait", "5m"}
+		if options.NodeImage != "" {
+			args = append(args, "--image", options.NodeImage)
+		}
+		_, err = cluster.runner(ctx, cluster.kind, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create kind cluster: %w", err)
+		}
+		cluster.created = true
+	}
+
+	kubeconfig, err := cluster.runner(ctx, cluster.kind, "get", "kubeconfig", "--name", cluster.Name)
+	if err != nil {
+		return nil, cluster.teardownAfter(ctx, fmt.Errorf("failed to get kind cluster kubeconfig: %w", err))
+	}
+
+	kubeconfigFile, err := os.CreateTemp("", cluster.Name+"-kubeconfig-")
+	if err != nil {
+		return nil, cluster.teardownAfter(ctx, fmt.Errorf("failed to create kubeconfig file: %w", err))
+	}
+	defer kubeconfigFile.Close()
+
+	cluster.Kubeconfig = kubeconfigFile.Name()
+	cluster.ownedKubeconfigFile = true
+
+	_, err = kubeconfigFile.Write(kubeconfig)
+	if err != nil {
+		return nil, cluster.teardownAfter(ctx, fmt.Errorf("failed to write kubeconfig file: %w", err))
+	}
+
+	err = cluster.connect(kubeconfig)
+	if err != nil {
+		return nil, cluster.teardownAfter(ctx, err)
+	}
+
+	return cluster, nil
+}
+
+// Teardown deletes the kind cluster if it was created by Setup, along with any kubeconfig file written by Setup
+func (c *Cluster) Teardown(ctx context.Context) error {
+	if c.ownedKubeconfigFile {
+		err := os.Remove(c.Kubeconfig)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove kubeconfig file: %w", err)
+		}
+		c.ownedKubeconfigFile = false
+	}
+
+	if !c.created {
+		return nil
+	}
+
+	_, err := c.runner(ctx, c.kind, "delete", "cluster", "--name", c.Name)
+	if err != nil {
+		return fmt.Errorf("failed to delete kind cluster: %w", err)
+	}
+	c.created = false
+	return nil
+}
+
+// DeployMetricsServer applies the metrics-server manifest provided, or DefaultMetricsServerManifest if empty, and
+// waits for it to become available. The kubelet certificates of kind nodes are self signed, so the metrics-server
+// deployment is patched to skip verifying them.
+func (c *Cluster) DeployMetricsServer(ctx context.Context, manifest string) error {
+	if manifest == "" {
+		manifest = DefaultMetricsServerManifest
+	}
+
+	_, err := c.runner(ctx, c.kubectl, "--kubeconfig", c.Kubeconfig, "apply", "-f", manifest)
+	if err != nil {
+		return fmt.Errorf("failed to apply metrics-server manifest: %w", err)
+	}
+
+	deployment, err := c.Clientset.AppsV1().Deployments(metricsServerNamespace).Get(ctx, metricsServerName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get metrics-server deployment: %w", err)
+	}
+
+	if !hasArg(deployment.Spec.Template.Spec.Containers, kubeletInsecureTLSArg) {
+		patch := fmt.Sprintf(`[{"op":"add","path":"/spec/template/spec/containers/0/args/-","value":%q}]`,
+			kubeletInsecureTLSArg)
+		_, err = c.Clientset.AppsV1().Deployments(metricsServerNamespace).Patch(ctx, metricsServerName,
+			types.JSONPatchType, []byte(patch), metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to patch metrics-server deployment: %w", err)
+		}
+	}
+
+	return c.WaitForDeployment(ctx, metricsServerNamespace, metricsServerName)
+}
+
+// WaitForDeployment waits until all of the replicas of the deployment provided are updated and available
+func (c *Cluster) WaitForDeployment(ctx context.Context, namespace string, name string) error {
+	err := wait.PollUntilContextCancel(ctx, DefaultPollInterval, true, func(ctx context.Context) (bool, error) {
+		deployment, err := c.Clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		if deployment.Status.ObservedGeneration < deployment.Generation {
+			return false, nil
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		return deployment.Status.UpdatedReplicas == replicas && deployment.Status.AvailableReplicas == replicas, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed waiting for deployment %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+func (c *Cluster) kindClusterExists(ctx context.Context) (bool, error) {
+	output, err := c.runner(ctx, c.kind, "get", "clusters")
+	if err != nil {
+		return false, fmt.Errorf("failed to list kind clusters: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == c.Name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *Cluster) connect(kubeconfig []byte) error {
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to set up Kubernetes clientset: %w", err)
+	}
+
+	c.Config = config
+	c.Clientset = clientset
+	return nil
+}
+
+// teardownAfter cleans up after a failed setup, returning the setup error
+func (c *Cluster) teardownAfter(ctx context.Context, err error) error {
+	teardownErr := c.Teardown(ctx)
+	if teardownErr != nil {
+		return fmt.Errorf("%w, failed to clean up: %s", err, teardownErr)
+	}
+	return err
+}
+
+func hasArg(containers []corev1.Container, arg string) bool {
+	for _, container := range containers {
+		for _, containerArg := range container.Args {
+			if containerArg == arg {
+				return true
+			}
+		}
+	}
+	return false
+}
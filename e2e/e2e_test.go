@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/e2e"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: kind-test
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: kind-test
+  context:
+    cluster: kind-test
+    user: kind-test
+current-context: kind-test
+users:
+- name: kind-test
+  user:
+    token: test
+`
+
+type command struct {
+	output string
+	err    error
+}
+
+func newRunner(commands map[string]command, ran *[]string) e2e.CommandRunner {
+	return func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		line := strings.Join(append([]string{name}, args...), " ")
+		*ran = append(*ran, line)
+		result, ok := commands[line]
+		if !ok {
+			return nil, errors.New("unexpected command")
+		}
+		return []byte(result.output), result.err
+	}
+}
+
+func TestSetupAndTeardown(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description      string
+		expectedErr      error
+		expectedSetup    []string
+		expectedTeardown []string
+		options          e2e.Options
+		commands         map[string]command
+	}{
+		{
+			"Fail to list kind clusters",
+			errors.New("failed to list kind clusters: kind error"),
+			[]string{"kind get clusters"},
+			nil,
+			e2e.Options{},
+			map[string]command{
+				"kind get clusters": {err: errors.New("kind error")},
+			},
+		},
+		{
+			"Fail to create kind cluster",
+			errors.New("failed to create kind cluster: kind error"),
+			[]string{
+				"kind get clusters",
+				"kind create cluster --name k8shorizmetrics-e2e --wait 5m",
+			},
+			nil,
+			e2e.Options{},
+			map[string]command{
+				"kind get clusters": {output: "other\n"},
+				"kind create cluster --name k8shorizmetrics-e2e --wait 5m": {err: errors.New("kind error")},
+			},
+		},
+		{
+			"Fail to get kubeconfig, created cluster deleted",
+			errors.New("failed to get kind cluster kubeconfig: kind error"),
+			[]string{
+				"kind get clusters",
+				"kind create cluster --name test --wait 5m --image kindest/node:v1.30.0",
+				"kind get kubeconfig --name test",
+				"kind delete cluster --name test",
+			},
+			nil,
+			e2e.Options{
+				ClusterName: "test",
+				NodeImage:   "kindest/node:v1.30.0",
+			},
+			map[string]command{
+				"kind get clusters": {},
+				"kind create cluster --name test --wait 5m --image kindest/node:v1.30.0": {},
+				"kind get kubeconfig --name test":                                        {err: errors.New("kind error")},
+				"kind delete cluster --name test":                                        {},
+			},
+		},
+		{
+			"Success, create cluster and delete on teardown",
+			nil,
+			[]string{
+				"kind get clusters",
+				"kind create cluster --name test --wait 5m",
+				"kind get kubeconfig --name test",
+			},
+			[]string{
+				"kind delete cluster --name test",
+			},
+			e2e.Options{
+				ClusterName: "test",
+			},
+			map[string]command{
+				"kind get clusters":                         {},
+				"kind create cluster --name test --wait 5m": {},
+				"kind get kubeconfig --name test":           {output: kubeconfig},
+				"kind delete cluster --name test":           {},
+			},
+		},
+		{
+			"Success, attach to existing kind cluster and keep on teardown",
+			nil,
+			[]string{
+				"/bin/kind get clusters",
+				"/bin/kind get kubeconfig --name test",
+			},
+			nil,
+			e2e.Options{
+				ClusterName: "test",
+				KindBinary:  "/bin/kind",
+			},
+			map[string]command{
+				"/bin/kind get clusters":               {output: "other\ntest\n"},
+				"/bin/kind get kubeconfig --name test": {output: kubeconfig},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var ran []string
+			test.options.Runner = newRunner(test.commands, &ran)
+
+			cluster, err := e2e.Setup(context.Background(), test.options)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expectedSetup, ran) {
+				t.Errorf("setup commands mismatch (-want +got):\n%s", cmp.Diff(test.expectedSetup, ran))
+			}
+			if err != nil {
+				return
+			}
+
+			if cluster.Config.Host != "https://127.0.0.1:6443" {
+				t.Errorf("unexpected cluster host %s", cluster.Config.Host)
+			}
+			kubeconfigFile := cluster.Kubeconfig
+
+			ran = nil
+			err = cluster.Teardown(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected teardown error: %v", err)
+			}
+			if !cmp.Equal(test.expectedTeardown, ran) {
+				t.Errorf("teardown commands mismatch (-want +got):\n%s", cmp.Diff(test.expectedTeardown, ran))
+			}
+			if _, err := os.Stat(kubeconfigFile); !os.IsNotExist(err) {
+				t.Errorf("expected kubeconfig file %s to be removed", kubeconfigFile)
+			}
+		})
+	}
+}
+
+func TestSetupExistingKubeconfig(t *testing.T) {
+	kubeconfigFile := t.TempDir() + "/kubeconfig"
+	err := os.WriteFile(kubeconfigFile, []byte(kubeconfig), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ran []string
+	cluster, err := e2e.Setup(context.Background(), e2e.Options{
+		Kubeconfig: kubeconfigFile,
+		Runner:     newRunner(nil, &ran),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = cluster.Teardown(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected teardown error: %v", err)
+	}
+
+	if len(ran) != 0 {
+		t.Errorf("expected no commands to be run, got %v", ran)
+	}
+	if _, err := os.Stat(kubeconfigFile); err != nil {
+		t.Errorf("expected provided kubeconfig file to be kept: %v", err)
+	}
+}
+
+func TestWorkload(t *testing.T) {
+	workload := &e2e.Workload{
+		Namespace: "test",
+		Name:      "php-apache",
+		Replicas:  2,
+	}
+
+	expectedSelector := labels.SelectorFromSet(labels.Set{e2e.WorkloadLabel: "php-apache"})
+	if workload.Selector().String() != expectedSelector.String() {
+		t.Errorf("selector mismatch, want %s, got %s", expectedSelector, workload.Selector())
+	}
+
+	deployment := workload.Deployment()
+	if !workload.Selector().Matches(labels.Set(deployment.Spec.Template.Labels)) {
+		t.Errorf("workload selector does not match pod template labels %v", deployment.Spec.Template.Labels)
+	}
+	if *deployment.Spec.Replicas != 2 {
+		t.Errorf("replicas mismatch, want 2, got %d", *deployment.Spec.Replicas)
+	}
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	if container.Image != e2e.DefaultWorkloadImage {
+		t.Errorf("image mismatch, want %s, got %s", e2e.DefaultWorkloadImage, container.Image)
+	}
+	if container.Resources.Requests.Cpu().MilliValue() != 200 {
+		t.Errorf("cpu request mismatch, want 200m, got %s", container.Resources.Requests.Cpu())
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// DefaultWorkloadImage is the image used for workloads if no image is provided, this serves a CPU intensive
+	// page making it useful for generating load
+	DefaultWorkloadImage = "registry.k8s.io/hpa-example"
+	// WorkloadLabel is the label applied to workload pods, set to the workload name, used to select them
+	WorkloadLabel = "k8shorizmetrics.e2e/workload"
+)
+
+// Workload is a sample deployment to gather metrics for. If no Image is provided DefaultWorkloadImage is used, if
+// no Requests are provided the pods request 200m of CPU.
+type Workload struct {
+	Namespace string
+	Name      string
+	Image     string
+	Replicas  int32
+	Requests  corev1.ResourceList
+	Args      []string
+}
+
+// Selector returns the label selector matching the workload's pods
+func (w *Workload) Selector() labels.Selector {
+	return labels.SelectorFromSet(w.labels())
+}
+
+// Deployment returns the deployment used to run the workload
+func (w *Workload) Deployment() *appsv1.Deployment {
+	image := w.Image
+	if image == "" {
+		image = DefaultWorkloadImage
+	}
+
+	requests := w.Requests
+	if requests == nil {
+		requests = corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("200m"),
+		}
+	}
+
+	replicas := w.Replicas
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: w.Namespace,
+			Name:      w.Name,
+			Labels:    w.labels(),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: w.labels(),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: w.labels(),
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  w.Name,
+							Image: image,
+							Args:  w.Args,
+							Resources: corev1.ResourceRequirements{
+								Requests: requests,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// DeployWorkload creates the workload's namespace if it does not exist, deploys the workload and waits for all of its
+// replicas to become available
+func (c *Cluster) DeployWorkload(ctx context.Context, workload *Workload) error {
+	_, err := c.Clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: workload.Namespace,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create workload namespace: %w", err)
+	}
+
+	_, err = c.Clientset.AppsV1().Deployments(workload.Namespace).Create(ctx, workload.Deployment(), metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create workload deployment: %w", err)
+	}
+
+	return c.WaitForDeployment(ctx, workload.Namespace, workload.Name)
+}
+
+// DeleteWorkload deletes the workload's deployment, ignoring it if it has already been deleted
+func (c *Cluster) DeleteWorkload(ctx context.Context, workload *Workload) error {
+	err := c.Clientset.AppsV1().Deployments(workload.Namespace).Delete(ctx, workload.Name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete workload deployment: %w", err)
+	}
+	return nil
+}
+
+func (w *Workload) labels() labels.Set {
+	return labels.Set{
+		WorkloadLabel: w.Name,
+	}
+}
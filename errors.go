@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"errors"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
+)
+
+// Sentinel errors which gathering and evaluating errors wrap, allowing callers to check for them using errors.Is
+// rather than comparing error messages.
+var (
+	// ErrUnknownMetricType occurs when a metric spec has a metric source type that is not built in or registered
+	ErrUnknownMetricType = errors.New("unknown metric source type")
+	// ErrInvalidMetricSource occurs when a metric spec's source is not valid, for example if its target type is not
+	// supported for the metric source type
+	ErrInvalidMetricSource = metrics.ErrInvalidMetricSource
+	// ErrNoMetrics occurs when a metrics API returns no metrics, or none of the metrics returned match known pods
+	ErrNoMetrics = metricsclient.ErrNoMetrics
+	// ErrNoPods occurs when no pods match the pod selector provided
+	ErrNoPods = podutil.ErrNoPods
+	// ErrMissingRequests occurs when a pod's containers are missing a request for the resource being gathered
+	ErrMissingRequests = podutil.ErrMissingRequests
+	// ErrMissingLimits occurs when a pod's containers are missing a limit for the resource being gathered with a limits
+	// utilization basis
+	ErrMissingLimits = podutil.ErrMissingLimits
+	// ErrGatherMetrics occurs when GatherAndEvaluate fails to gather metrics
+	ErrGatherMetrics = errors.New("failed to gather metrics")
+	// ErrEvaluateMetrics occurs when GatherAndEvaluate fails to evaluate gathered metrics
+	ErrEvaluateMetrics = errors.New("failed to evaluate metrics")
+)
+
+// IsPartial returns true if the error provided is a GathererMultiMetricError or EvaluatorMultiMetricError where only
+// some metrics failed, meaning the results returned alongside the error can still be used
+func IsPartial(err error) bool {
+	var gatherErr *GathererMultiMetricError
+	if errors.As(err, &gatherErr) {
+		return gatherErr.Partial
+	}
+	var evaluateErr *EvaluatorMultiMetricError
+	if errors.As(err, &evaluateErr) {
+		return evaluateErr.Partial
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	resourcemetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestGatherSentinelErrors(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    error
+		gatherer    *k8shorizmetrics.Gatherer
+		spec        autoscalingv2.MetricSpec
+	}{
+		{
+			description: "Unknown metric source type",
+			expected:    k8shorizmetrics.ErrUnknownMetricType,
+			gatherer:    &k8shorizmetrics.Gatherer{},
+			spec: autoscalingv2.MetricSpec{
+				Type: "invalid",
+			},
+		},
+		{
+			description: "Invalid resource metric source",
+			expected:    k8shorizmetrics.ErrInvalidMetricSource,
+			gatherer:    &k8shorizmetrics.Gatherer{},
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						Type: autoscalingv2.MetricTargetType("invalid"),
+					},
+				},
+			},
+		},
+		{
+			description: "No metrics returned by resource gatherer",
+			expected:    k8shorizmetrics.ErrNoMetrics,
+			gatherer: &k8shorizmetrics.Gatherer{
+				Resource: &fake.ResourceGatherer{
+					GatherReactor: func(resourceName corev1.ResourceName, namespace string, podSelector labels.Selector, cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error) {
+						return nil, metricsclient.ErrNoMetrics
+					},
+				},
+			},
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						Type: autoscalingv2.UtilizationMetricType,
+					},
+				},
+			},
+		},
+		{
+			description: "No pods matched by resource gatherer",
+			expected:    k8shorizmetrics.ErrNoPods,
+			gatherer: &k8shorizmetrics.Gatherer{
+				Resource: &fake.ResourceGatherer{
+					GatherReactor: func(resourceName corev1.ResourceName, namespace string, podSelector labels.Selector, cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error) {
+						return nil, podutil.ErrNoPods
+					},
+				},
+			},
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						Type: autoscalingv2.UtilizationMetricType,
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			_, err := test.gatherer.Gather([]autoscalingv2.MetricSpec{test.spec}, "test", labels.Everything())
+			if !errors.Is(err, test.expected) {
+				t.Errorf("expected gather error %v to match %v", err, test.expected)
+			}
+			_, err = test.gatherer.GatherSingleMetric(test.spec, "test", labels.Everything())
+			if !errors.Is(err, test.expected) {
+				t.Errorf("expected gather single metric error %v to match %v", err, test.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluateSentinelErrors(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    error
+		metric      *metrics.Metric
+	}{
+		{
+			description: "Unknown metric source type",
+			expected:    k8shorizmetrics.ErrUnknownMetricType,
+			metric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: "invalid",
+				},
+			},
+		},
+		{
+			description: "Invalid external metric source",
+			expected:    k8shorizmetrics.ErrInvalidMetricSource,
+			metric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							Type: autoscalingv2.UtilizationMetricType,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluator := k8shorizmetrics.NewEvaluator(0.1)
+			_, err := evaluator.EvaluateSingleMetric(test.metric, 1)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("expected evaluate error %v to match %v", err, test.expected)
+			}
+		})
+	}
+}
+
+func TestIsPartial(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    bool
+		err         error
+	}{
+		{
+			description: "Nil error",
+			expected:    false,
+			err:         nil,
+		},
+		{
+			description: "Other error",
+			expected:    false,
+			err:         errors.New("test error"),
+		},
+		{
+			description: "Full gatherer error",
+			expected:    false,
+			err:         &k8shorizmetrics.GathererMultiMetricError{Errors: []error{errors.New("test error")}},
+		},
+		{
+			description: "Partial gatherer error",
+			expected:    true,
+			err:         &k8shorizmetrics.GathererMultiMetricError{Partial: true, Errors: []error{errors.New("test error")}},
+		},
+		{
+			description: "Partial evaluator error, joined",
+			expected:    true,
+			err: errors.Join(nil, &k8shorizmetrics.EvaluatorMultiMetricError{
+				Partial: true,
+				Errors:  []error{errors.New("test error")},
+			}),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := k8shorizmetrics.IsPartial(test.err)
+			if result != test.expected {
+				t.Errorf("partial mismatch, want %t got %t", test.expected, result)
+			}
+		})
+	}
+}
@@ -28,14 +28,18 @@ https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscale
 package k8shorizmetrics
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/external"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/object"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/pods"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/replicas"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/resource"
+	"github.com/go-logr/logr"
+	"github.com/jthomperoo/k8shorizmetrics/v4/external"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	"github.com/jthomperoo/k8shorizmetrics/v4/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/replicas"
+	"github.com/jthomperoo/k8shorizmetrics/v4/resource"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 )
 
@@ -71,33 +75,119 @@ type ResourceEvaluater interface {
 	Evaluate(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error)
 }
 
+// ExternalEvaluaterWithContext produces a replica count based on an external metric provided, accepting a context. If
+// an ExternalEvaluater also implements this interface the Evaluator will prefer this method.
+type ExternalEvaluaterWithContext interface {
+	EvaluateWithContext(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error)
+}
+
+// ObjectEvaluaterWithContext produces a replica count based on an object metric provided, accepting a context. If an
+// ObjectEvaluater also implements this interface the Evaluator will prefer this method.
+type ObjectEvaluaterWithContext interface {
+	EvaluateWithContext(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error)
+}
+
+// PodsEvaluaterWithContext produces a replica count based on a pods metric provided, accepting a context. If a
+// PodsEvaluater also implements this interface the Evaluator will prefer this method.
+type PodsEvaluaterWithContext interface {
+	EvaluateWithContext(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric) int32
+}
+
+// PodsEvaluaterWithError produces a replica count based on a pods metric provided, accepting a context and returning
+// an error if the metric cannot be evaluated. If a PodsEvaluater also implements this interface the Evaluator will
+// prefer this method over both Evaluate and EvaluateWithContext.
+type PodsEvaluaterWithError interface {
+	EvaluateWithError(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric) (int32, error)
+}
+
+// ResourceEvaluaterWithContext produces an evaluation based on a resource metric provided, accepting a context. If a
+// ResourceEvaluater also implements this interface the Evaluator will prefer this method.
+type ResourceEvaluaterWithContext interface {
+	EvaluateWithContext(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error)
+}
+
 // Evaluator provides functionality for deciding how many replicas a resource should have based on provided metrics.
+// Sources are evaluaters for additional metric source types, keyed by the metric source type, see RegisterSource.
+// Aggregator combines the replica counts proposed by each metric when evaluating multiple metrics, if nil the highest
+// replica count is taken in the same way as the HPA, see MaxAggregator.
+// ScaleUpLimitPercent and ScaleDownLimitPercent limit how much the replica count can change in a single evaluation as
+// a percentage of the current replica count, for example a scale up limit of 100 allows at most doubling the replica
+// count and a scale down limit of 50 allows at most halving it. MaxStepSize limits how many replicas the replica count
+// can change by in a single evaluation, for example for workloads with a slow warm up. If zero the change is not
+// limited.
+// Logger is used to log debug and trace information about each evaluation, such as tolerance checks, see
+// LogLevelDebug and LogLevelTrace. If not set the logger from the context is used, and if there is none nothing is
+// logged.
 type Evaluator struct {
-	External  ExternalEvaluater
-	Object    ObjectEvaluater
-	Pods      PodsEvaluater
-	Resource  ResourceEvaluater
-	Tolerance float64
+	External              ExternalEvaluater
+	Object                ObjectEvaluater
+	Pods                  PodsEvaluater
+	Resource              ResourceEvaluater
+	Tolerance             float64
+	Sources               map[autoscalingv2.MetricSourceType]SourceEvaluater
+	Aggregator            Aggregator
+	ScaleUpLimitPercent   int32
+	ScaleDownLimitPercent int32
+	MaxStepSize           int32
+	Logger                logr.Logger
 }
 
 // NewEvaluator sets up an evaluate that can process external, object, pod and resource metrics
 func NewEvaluator(tolerance float64) *Evaluator {
+	return NewEvaluatorWithRounding(tolerance, replicas.RoundingCeil)
+}
+
+// NewEvaluatorWithRounding sets up an evaluate that can process external, object, pod and resource metrics, rounding
+// fractional replica counts using the rounding strategy provided rather than always rounding up like the HPA
+func NewEvaluatorWithRounding(tolerance float64, rounding replicas.Rounding) *Evaluator {
 	calculate := &replicas.ReplicaCalculator{
 		Tolerance: tolerance,
+		Rounding:  rounding,
 	}
 	return &Evaluator{
 		External: &external.Evaluate{
 			Calculater: calculate,
+			Rounding:   rounding,
 		},
 		Object: &object.Evaluate{
 			Calculater: calculate,
+			Rounding:   rounding,
 		},
 		Pods: &pods.Evaluate{
 			Calculater: calculate,
 		},
 		Resource: &resource.Evaluate{
 			Calculater: calculate,
+			Rounding:   rounding,
 		},
+		Tolerance: tolerance,
+	}
+}
+
+// EvaluatorOption configures an Evaluator created by NewEvaluatorWithOptions
+type EvaluatorOption func(evaluator *Evaluator)
+
+// NewEvaluatorWithOptions sets up an evaluate that can process external, object, pod and resource metrics in the same
+// way as NewEvaluator, configured with the options provided
+func NewEvaluatorWithOptions(tolerance float64, options ...EvaluatorOption) *Evaluator {
+	evaluator := NewEvaluator(tolerance)
+	for _, option := range options {
+		option(evaluator)
+	}
+	return evaluator
+}
+
+// WithTargetUnits sets the units the targets of external and object metric specs are expressed in for the built in
+// external and object evaluaters, see value.Units. The conversion of each target is included in detailed evaluations,
+// see MetricEvaluation.
+func WithTargetUnits(units value.Units) EvaluatorOption {
+	return func(evaluator *Evaluator) {
+		if externalEvaluate, ok := evaluator.External.(*external.Evaluate); ok {
+			externalEvaluate.TargetUnits = units
+		}
+		if objectEvaluate, ok := evaluator.Object.(*object.Evaluate); ok {
+			objectEvaluate.TargetUnits = units
+		}
 	}
 }
 
@@ -115,42 +205,48 @@ func (e *Evaluator) Evaluate(gatheredMetrics []*metrics.Metric, currentReplicas
 // set to true.
 func (e *Evaluator) EvaluateWithOptions(gatheredMetrics []*metrics.Metric, currentReplicas int32,
 	tolerance float64) (int32, error) {
-	var evaluation int32
-	var evaluationErrors []error
-
-	for i, gatheredMetric := range gatheredMetrics {
-		proposedEvaluation, err := e.EvaluateSingleMetricWithOptions(gatheredMetric, currentReplicas, tolerance)
-		if err != nil {
-			evaluationErrors = append(evaluationErrors, err)
-			continue
-		}
+	return e.evaluate(context.Background(), gatheredMetrics, currentReplicas, tolerance)
+}
 
-		if i == 0 {
-			evaluation = proposedEvaluation
-		}
+// EvaluateWithContext returns the target replica count for an array of multiple metrics, passing the context provided
+// to any evaluaters that implement the context aware evaluater interfaces.
+// If an error occurs evaluating any metric this will return a EvaluatorMultiMetricError. If a partial error occurs,
+// meaning some metrics were evaluated successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (e *Evaluator) EvaluateWithContext(ctx context.Context, gatheredMetrics []*metrics.Metric, currentReplicas int32) (int32, error) {
+	return e.evaluate(ctx, gatheredMetrics, currentReplicas, e.Tolerance)
+}
 
-		// Multiple evaluations, take the highest replica count
-		if proposedEvaluation > evaluation {
-			evaluation = proposedEvaluation
-		}
-	}
+// EvaluateWithContextAndOptions returns the target replica count for an array of multiple metrics with provided
+// options, passing the context provided to any evaluaters that implement the context aware evaluater interfaces.
+// If an error occurs evaluating any metric this will return a EvaluatorMultiMetricError. If a partial error occurs,
+// meaning some metrics were evaluated successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (e *Evaluator) EvaluateWithContextAndOptions(ctx context.Context, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32, tolerance float64) (int32, error) {
+	return e.evaluate(ctx, gatheredMetrics, currentReplicas, tolerance)
+}
 
-	if len(evaluationErrors) > 0 {
-		partial := len(evaluationErrors) < len(gatheredMetrics)
-		if partial {
-			return evaluation, &EvaluatorMultiMetricError{
-				Partial: partial,
-				Errors:  evaluationErrors,
-			}
-		}
+func (e *Evaluator) evaluate(ctx context.Context, gatheredMetrics []*metrics.Metric, currentReplicas int32,
+	tolerance float64) (int32, error) {
+	options := e.defaultOptions(EvaluateOptions{})
+	options.Tolerance = tolerance
+	return e.evaluateWithOptions(ctx, gatheredMetrics, currentReplicas, options)
+}
 
-		return 0, &EvaluatorMultiMetricError{
-			Partial: partial,
-			Errors:  evaluationErrors,
-		}
-	}
+// EvaluateForHPAV1 returns the target replica count for metrics gathered for an autoscaling/v1
+// HorizontalPodAutoscaler, using the current replica count from the HPA's status.
+// If an error occurs evaluating any metric this will return a EvaluatorMultiMetricError.
+func (e *Evaluator) EvaluateForHPAV1(gatheredMetrics []*metrics.Metric, hpa *autoscalingv1.HorizontalPodAutoscaler) (int32, error) {
+	return e.EvaluateForHPAV1WithContext(context.Background(), gatheredMetrics, hpa)
+}
 
-	return evaluation, nil
+// EvaluateForHPAV1WithContext returns the target replica count for metrics gathered for an autoscaling/v1
+// HorizontalPodAutoscaler, using the current replica count from the HPA's status and passing the context provided to
+// any evaluaters that implement the context aware evaluater interfaces.
+// If an error occurs evaluating any metric this will return a EvaluatorMultiMetricError.
+func (e *Evaluator) EvaluateForHPAV1WithContext(ctx context.Context, gatheredMetrics []*metrics.Metric, hpa *autoscalingv1.HorizontalPodAutoscaler) (int32, error) {
+	return e.EvaluateWithContext(ctx, gatheredMetrics, hpa.Status.CurrentReplicas)
 }
 
 // EvaluateSingleMetric returns the target replica count for a single metrics
@@ -161,16 +257,53 @@ func (e *Evaluator) EvaluateSingleMetric(gatheredMetric *metrics.Metric, current
 // EvaluateSingleMetricWithOptions returns the target replica count for a single metrics with provided options
 func (e *Evaluator) EvaluateSingleMetricWithOptions(gatheredMetric *metrics.Metric, currentReplicas int32,
 	tolerance float64) (int32, error) {
+	return e.evaluateSingleMetric(context.Background(), gatheredMetric, currentReplicas, tolerance)
+}
+
+// EvaluateSingleMetricWithContext returns the target replica count for a single metric, passing the context provided
+// to the evaluater if it implements the context aware evaluater interfaces.
+func (e *Evaluator) EvaluateSingleMetricWithContext(ctx context.Context, gatheredMetric *metrics.Metric, currentReplicas int32) (int32, error) {
+	return e.evaluateSingleMetric(ctx, gatheredMetric, currentReplicas, e.Tolerance)
+}
+
+// EvaluateSingleMetricWithContextAndOptions returns the target replica count for a single metric with provided
+// options, passing the context provided to the evaluater if it implements the context aware evaluater interfaces.
+func (e *Evaluator) EvaluateSingleMetricWithContextAndOptions(ctx context.Context, gatheredMetric *metrics.Metric,
+	currentReplicas int32, tolerance float64) (int32, error) {
+	return e.evaluateSingleMetric(ctx, gatheredMetric, currentReplicas, tolerance)
+}
+
+func (e *Evaluator) evaluateSingleMetric(ctx context.Context, gatheredMetric *metrics.Metric, currentReplicas int32,
+	tolerance float64) (int32, error) {
+	if evaluater, ok := e.Sources[gatheredMetric.Spec.Type]; ok {
+		return evaluater.Evaluate(ctx, currentReplicas, gatheredMetric, tolerance)
+	}
+
 	switch gatheredMetric.Spec.Type {
 	case autoscalingv2.ObjectMetricSourceType:
+		if evaluater, ok := e.Object.(ObjectEvaluaterWithContext); ok {
+			return evaluater.EvaluateWithContext(ctx, currentReplicas, gatheredMetric, tolerance)
+		}
 		return e.Object.Evaluate(currentReplicas, gatheredMetric, tolerance)
 	case autoscalingv2.PodsMetricSourceType:
+		if evaluater, ok := e.Pods.(PodsEvaluaterWithError); ok {
+			return evaluater.EvaluateWithError(ctx, currentReplicas, gatheredMetric)
+		}
+		if evaluater, ok := e.Pods.(PodsEvaluaterWithContext); ok {
+			return evaluater.EvaluateWithContext(ctx, currentReplicas, gatheredMetric), nil
+		}
 		return e.Pods.Evaluate(currentReplicas, gatheredMetric), nil
 	case autoscalingv2.ResourceMetricSourceType:
+		if evaluater, ok := e.Resource.(ResourceEvaluaterWithContext); ok {
+			return evaluater.EvaluateWithContext(ctx, currentReplicas, gatheredMetric, tolerance)
+		}
 		return e.Resource.Evaluate(currentReplicas, gatheredMetric, tolerance)
 	case autoscalingv2.ExternalMetricSourceType:
+		if evaluater, ok := e.External.(ExternalEvaluaterWithContext); ok {
+			return evaluater.EvaluateWithContext(ctx, currentReplicas, gatheredMetric, tolerance)
+		}
 		return e.External.Evaluate(currentReplicas, gatheredMetric, tolerance)
 	default:
-		return 0, fmt.Errorf("unknown metric source type %q", string(gatheredMetric.Spec.Type))
+		return 0, fmt.Errorf("%w %q", ErrUnknownMetricType, string(gatheredMetric.Spec.Type))
 	}
 }
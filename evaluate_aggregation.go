@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"math"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+)
+
+// Aggregator combines the replica counts proposed by each successfully evaluated metric into a single target replica
+// count. The evaluations are provided in the same order as the metrics, there is always at least one.
+type Aggregator interface {
+	Aggregate(evaluations []*MetricEvaluation) int32
+}
+
+// AggregatorFunc is an adapter allowing a function to be used as an Aggregator
+type AggregatorFunc func(evaluations []*MetricEvaluation) int32
+
+// Aggregate calls the function with the evaluations provided
+func (f AggregatorFunc) Aggregate(evaluations []*MetricEvaluation) int32 {
+	return f(evaluations)
+}
+
+var (
+	// MaxAggregator takes the highest proposed replica count, this is the HPA's behavior and the default
+	MaxAggregator Aggregator = AggregatorFunc(func(evaluations []*MetricEvaluation) int32 {
+		replicas := evaluations[0].Replicas
+		for _, evaluation := range evaluations[1:] {
+			replicas = max(replicas, evaluation.Replicas)
+		}
+		return replicas
+	})
+	// MinAggregator takes the lowest proposed replica count, scaling aggressively down and conservatively up
+	MinAggregator Aggregator = AggregatorFunc(func(evaluations []*MetricEvaluation) int32 {
+		replicas := evaluations[0].Replicas
+		for _, evaluation := range evaluations[1:] {
+			replicas = min(replicas, evaluation.Replicas)
+		}
+		return replicas
+	})
+	// AverageAggregator takes the mean of the proposed replica counts, rounded up
+	AverageAggregator Aggregator = &WeightedAggregator{}
+)
+
+// WeightedAggregator takes the weighted mean of the proposed replica counts, rounded up. Weight returns the weight of
+// each metric, if nil every metric has a weight of 1. If the total weight is not positive the unweighted mean is used.
+type WeightedAggregator struct {
+	Weight func(gatheredMetric *metrics.Metric) float64
+}
+
+// Aggregate returns the weighted mean of the proposed replica counts, rounded up
+func (a *WeightedAggregator) Aggregate(evaluations []*MetricEvaluation) int32 {
+	var total, totalWeight float64
+	for _, evaluation := range evaluations {
+		weight := 1.0
+		if a.Weight != nil {
+			weight = a.Weight(evaluation.Metric)
+		}
+		total += weight * float64(evaluation.Replicas)
+		totalWeight += weight
+	}
+
+	if totalWeight <= 0 {
+		return AverageAggregator.Aggregate(evaluations)
+	}
+
+	return int32(math.Ceil(total / totalWeight))
+}
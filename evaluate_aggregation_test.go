@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+func TestAggregators(t *testing.T) {
+	resourceMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+		},
+	}
+	podsMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+		},
+	}
+	evaluations := []*k8shorizmetrics.MetricEvaluation{
+		{Metric: resourceMetric, Replicas: 2},
+		{Metric: podsMetric, Replicas: 9},
+		{Metric: resourceMetric, Replicas: 4},
+	}
+
+	var tests = []struct {
+		description string
+		expected    int32
+		aggregator  k8shorizmetrics.Aggregator
+		evaluations []*k8shorizmetrics.MetricEvaluation
+	}{
+		{
+			description: "Max",
+			expected:    9,
+			aggregator:  k8shorizmetrics.MaxAggregator,
+			evaluations: evaluations,
+		},
+		{
+			description: "Min",
+			expected:    2,
+			aggregator:  k8shorizmetrics.MinAggregator,
+			evaluations: evaluations,
+		},
+		{
+			description: "Average, rounded up",
+			expected:    5,
+			aggregator:  k8shorizmetrics.AverageAggregator,
+			evaluations: evaluations,
+		},
+		{
+			description: "Single evaluation",
+			expected:    3,
+			aggregator:  k8shorizmetrics.AverageAggregator,
+			evaluations: []*k8shorizmetrics.MetricEvaluation{
+				{Metric: resourceMetric, Replicas: 3},
+			},
+		},
+		{
+			description: "Weighted, rounded up",
+			expected:    7,
+			aggregator: &k8shorizmetrics.WeightedAggregator{
+				Weight: func(gatheredMetric *metrics.Metric) float64 {
+					if gatheredMetric.Spec.Type == autoscalingv2.PodsMetricSourceType {
+						return 3
+					}
+					return 1
+				},
+			},
+			evaluations: evaluations,
+		},
+		{
+			description: "Weighted, no total weight, use unweighted average",
+			expected:    5,
+			aggregator: &k8shorizmetrics.WeightedAggregator{
+				Weight: func(gatheredMetric *metrics.Metric) float64 {
+					return 0
+				},
+			},
+			evaluations: evaluations,
+		},
+		{
+			description: "Aggregator function",
+			expected:    15,
+			aggregator: k8shorizmetrics.AggregatorFunc(func(evaluations []*k8shorizmetrics.MetricEvaluation) int32 {
+				var total int32
+				for _, evaluation := range evaluations {
+					total += evaluation.Replicas
+				}
+				return total
+			}),
+			evaluations: evaluations,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := test.aggregator.Aggregate(test.evaluations)
+			if result != test.expected {
+				t.Errorf("aggregated replicas mismatch, want %d, got %d", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestEvaluateWithAggregator(t *testing.T) {
+	resourceMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+		},
+	}
+	podsMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+		},
+	}
+
+	var tests = []struct {
+		description     string
+		expected        *k8shorizmetrics.DetailedEvaluation
+		aggregator      k8shorizmetrics.Aggregator
+		gatheredMetrics []*metrics.Metric
+	}{
+		{
+			description: "Default, use max",
+			expected: &k8shorizmetrics.DetailedEvaluation{
+				Replicas: 6,
+				Metrics: []*k8shorizmetrics.MetricEvaluation{
+					{Metric: resourceMetric, Replicas: 2},
+					{Metric: podsMetric, Replicas: 6, Winning: true},
+				},
+			},
+			aggregator:      nil,
+			gatheredMetrics: []*metrics.Metric{resourceMetric, podsMetric},
+		},
+		{
+			description: "Min",
+			expected: &k8shorizmetrics.DetailedEvaluation{
+				Replicas: 2,
+				Metrics: []*k8shorizmetrics.MetricEvaluation{
+					{Metric: resourceMetric, Replicas: 2, Winning: true},
+					{Metric: podsMetric, Replicas: 6},
+				},
+			},
+			aggregator:      k8shorizmetrics.MinAggregator,
+			gatheredMetrics: []*metrics.Metric{resourceMetric, podsMetric},
+		},
+		{
+			description: "Average, no winning metric",
+			expected: &k8shorizmetrics.DetailedEvaluation{
+				Replicas: 4,
+				Metrics: []*k8shorizmetrics.MetricEvaluation{
+					{Metric: resourceMetric, Replicas: 2},
+					{Metric: podsMetric, Replicas: 6},
+				},
+			},
+			aggregator:      k8shorizmetrics.AverageAggregator,
+			gatheredMetrics: []*metrics.Metric{resourceMetric, podsMetric},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluator := &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 2, nil
+					},
+				},
+				Pods: &fake.PodsEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+						return 6
+					},
+				},
+				Aggregator: test.aggregator,
+			}
+
+			result, err := evaluator.EvaluateWithDetails(test.gatheredMetrics, 3)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("detailed evaluation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+
+			replicas, err := evaluator.Evaluate(test.gatheredMetrics, 3)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if replicas != test.expected.Replicas {
+				t.Errorf("replicas mismatch, want %d, got %d", test.expected.Replicas, replicas)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"context"
+	"math"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+// UsageRatioEvaluater reports the ratio of the current usage of a metric to its target, a ratio above 1 means usage
+// is above the target. If an evaluater also implements this interface the Evaluator will include the usage ratio in
+// detailed evaluations.
+type UsageRatioEvaluater interface {
+	UsageRatio(currentReplicas int32, gatheredMetric *metrics.Metric) (float64, error)
+}
+
+// TargetConversionEvaluater reports how the target of a metric is converted for evaluation, for example whether it is
+// treated as milli-units or raw units. If an evaluater also implements this interface the Evaluator will include the
+// conversion in detailed evaluations.
+type TargetConversionEvaluater interface {
+	TargetConversion(gatheredMetric *metrics.Metric) (*value.Conversion, error)
+}
+
+// MetricEvaluation is the evaluation of a single metric as part of a detailed evaluation. Replicas is the replica
+// count proposed by the metric and UsageRatio is the ratio of the metric's current usage to its target, nil if the
+// evaluater does not implement UsageRatioEvaluater. Winning is set for the metric which proposed the replica count
+// chosen by the Evaluator's Aggregator, if multiple metrics propose the chosen replica count only the first is marked
+// as winning, and if the aggregated replica count was not proposed by any metric (for example an average) no metric is
+// marked as winning. If the metric failed to be evaluated Err is set.
+// For resource metrics CurrentUtilization is the current utilization as a percentage of the pod requests (or limits)
+// and CurrentAverageValue is the raw average value of the pods, nil if utilization could not be calculated.
+// TargetConversion is how the metric's target was converted for evaluation, nil if the evaluater does not implement
+// TargetConversionEvaluater.
+type MetricEvaluation struct {
+	Metric              *metrics.Metric
+	Replicas            int32
+	UsageRatio          *float64
+	CurrentUtilization  *int32
+	CurrentAverageValue *int64
+	TargetConversion    *value.Conversion
+	Winning             bool
+	Err                 error
+}
+
+// DetailedEvaluation is an evaluation of multiple metrics, including the evaluation of each metric in the same order
+// as the metrics provided. DeletionCosts is the deletion cost of each pod gathered by the resource and pods metrics
+// which has the pod deletion cost annotation set, keyed by pod name, so callers scaling down can choose which pods to
+// remove consistently.
+type DetailedEvaluation struct {
+	Replicas      int32
+	Metrics       []*MetricEvaluation
+	DeletionCosts map[string]int32
+}
+
+// EvaluateWithDetails returns the target replica count for an array of multiple metrics, along with the evaluation of
+// each metric showing which metric decided the replica count.
+// If an error occurs evaluating any metric this will return a EvaluatorMultiMetricError alongside the detailed
+// evaluation, with the error of each failed metric recorded in its metric evaluation. If a partial error occurs,
+// meaning some metrics were evaluated successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (e *Evaluator) EvaluateWithDetails(gatheredMetrics []*metrics.Metric, currentReplicas int32) (*DetailedEvaluation, error) {
+	return e.EvaluateWithContextAndDetails(context.Background(), gatheredMetrics, currentReplicas)
+}
+
+// EvaluateWithContextAndDetails returns the target replica count for an array of multiple metrics, along with the
+// evaluation of each metric showing which metric decided the replica count, passing the context provided to any
+// evaluaters that implement the context aware evaluater interfaces. See EvaluateWithDetails.
+func (e *Evaluator) EvaluateWithContextAndDetails(ctx context.Context, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32) (*DetailedEvaluation, error) {
+	return e.evaluateDetails(ctx, gatheredMetrics, currentReplicas, e.Tolerance, true)
+}
+
+func (e *Evaluator) evaluateDetails(ctx context.Context, gatheredMetrics []*metrics.Metric, currentReplicas int32,
+	tolerance float64, includeDetails bool) (*DetailedEvaluation, error) {
+	details := &DetailedEvaluation{
+		Metrics: make([]*MetricEvaluation, len(gatheredMetrics)),
+	}
+	var evaluationErrors []error
+	var evaluated []*MetricEvaluation
+	log := resolveLogger(ctx, e.Logger)
+
+	for i, gatheredMetric := range gatheredMetrics {
+		metricEvaluation := &MetricEvaluation{
+			Metric: gatheredMetric,
+		}
+		details.Metrics[i] = metricEvaluation
+
+		// Stop evaluating if the context is done, recording an error for each remaining metric
+		if err := ctx.Err(); err != nil {
+			metricEvaluation.Err = err
+			evaluationErrors = append(evaluationErrors, err)
+			continue
+		}
+
+		// Calculate the usage ratio before evaluating, as evaluating may adjust the metric for missing pods
+		var usageRatio *float64
+		if includeDetails || log.V(LogLevelDebug).Enabled() {
+			usageRatio = e.usageRatio(gatheredMetric, currentReplicas)
+		}
+		if includeDetails {
+			metricEvaluation.CurrentUtilization, metricEvaluation.CurrentAverageValue = resourceUtilization(gatheredMetric)
+			metricEvaluation.TargetConversion = e.targetConversion(gatheredMetric)
+		}
+
+		metricLog := log.WithValues("index", i)
+		if gatheredMetric != nil && gatheredMetric.Spec.Type != "" {
+			metricLog = metricLog.WithValues("metricType", string(gatheredMetric.Spec.Type))
+		}
+		if usageRatio != nil {
+			metricLog.V(LogLevelDebug).Info("Checked tolerance", "usageRatio", *usageRatio, "tolerance", tolerance,
+				"withinTolerance", math.Abs(1-*usageRatio) <= tolerance)
+		}
+
+		proposedEvaluation, err := e.evaluateSingleMetric(ctx, gatheredMetric, currentReplicas, tolerance)
+		if err != nil {
+			metricLog.V(LogLevelDebug).Info("Failed to evaluate metric", "error", err.Error())
+			metricEvaluation.Err = err
+			evaluationErrors = append(evaluationErrors, err)
+			continue
+		}
+
+		metricLog.V(LogLevelDebug).Info("Evaluated metric", "currentReplicas", currentReplicas,
+			"replicas", proposedEvaluation)
+		metricEvaluation.Replicas = proposedEvaluation
+		if includeDetails {
+			metricEvaluation.UsageRatio = usageRatio
+		}
+		evaluated = append(evaluated, metricEvaluation)
+	}
+
+	if len(evaluated) > 0 {
+		aggregator := e.Aggregator
+		if aggregator == nil {
+			aggregator = MaxAggregator
+		}
+
+		// Multiple evaluations, combine them using the aggregator
+		details.Replicas = aggregator.Aggregate(evaluated)
+		for _, metricEvaluation := range evaluated {
+			if metricEvaluation.Replicas == details.Replicas {
+				metricEvaluation.Winning = true
+				break
+			}
+		}
+		log.V(LogLevelDebug).Info("Aggregated metric evaluations", "evaluated", len(evaluated),
+			"failed", len(evaluationErrors), "replicas", details.Replicas)
+	}
+
+	if includeDetails {
+		details.DeletionCosts = deletionCosts(gatheredMetrics)
+	}
+
+	if len(evaluationErrors) > 0 {
+		return details, &EvaluatorMultiMetricError{
+			Partial: len(evaluationErrors) < len(gatheredMetrics),
+			Errors:  evaluationErrors,
+		}
+	}
+
+	return details, nil
+}
+
+// deletionCosts combines the pod deletion costs gathered by the resource and pods metrics provided, returning nil if
+// no pods have a deletion cost
+func deletionCosts(gatheredMetrics []*metrics.Metric) map[string]int32 {
+	var combined map[string]int32
+	for _, gatheredMetric := range gatheredMetrics {
+		if gatheredMetric == nil {
+			continue
+		}
+
+		var metricDeletionCosts map[string]int32
+		switch {
+		case gatheredMetric.Resource != nil:
+			metricDeletionCosts = gatheredMetric.Resource.DeletionCosts
+		case gatheredMetric.Pods != nil:
+			metricDeletionCosts = gatheredMetric.Pods.DeletionCosts
+		}
+
+		for pod, deletionCost := range metricDeletionCosts {
+			if combined == nil {
+				combined = map[string]int32{}
+			}
+			combined[pod] = deletionCost
+		}
+	}
+	return combined
+}
+
+// resourceUtilization returns the current utilization and raw average value of a resource metric, using the values
+// computed when gathering if set and otherwise calculating them from the pod metrics. Returns nil if the metric is not
+// a resource metric or its utilization cannot be calculated.
+func resourceUtilization(gatheredMetric *metrics.Metric) (*int32, *int64) {
+	if gatheredMetric == nil || gatheredMetric.Resource == nil {
+		return nil, nil
+	}
+
+	if gatheredMetric.Resource.CurrentUtilization != nil && gatheredMetric.Resource.CurrentAverageValue != nil {
+		return gatheredMetric.Resource.CurrentUtilization, gatheredMetric.Resource.CurrentAverageValue
+	}
+
+	utilization, averageValue, err := metricsclient.GetResourceUtilization(gatheredMetric.Resource.PodMetricsInfo,
+		gatheredMetric.Resource.UtilizationBase())
+	if err != nil {
+		return nil, nil
+	}
+	return &utilization, &averageValue
+}
+
+// evaluaterFor returns the evaluater used for the metric provided, or nil if there is none
+func (e *Evaluator) evaluaterFor(gatheredMetric *metrics.Metric) any {
+	if sourceEvaluater, ok := e.Sources[gatheredMetric.Spec.Type]; ok {
+		return sourceEvaluater
+	}
+	switch gatheredMetric.Spec.Type {
+	case autoscalingv2.ObjectMetricSourceType:
+		return e.Object
+	case autoscalingv2.PodsMetricSourceType:
+		return e.Pods
+	case autoscalingv2.ResourceMetricSourceType:
+		return e.Resource
+	case autoscalingv2.ExternalMetricSourceType:
+		return e.External
+	}
+	return nil
+}
+
+// targetConversion returns how the target of the metric provided is converted if its evaluater implements
+// TargetConversionEvaluater, otherwise nil
+func (e *Evaluator) targetConversion(gatheredMetric *metrics.Metric) *value.Conversion {
+	if gatheredMetric == nil {
+		return nil
+	}
+
+	targetConversionEvaluater, ok := e.evaluaterFor(gatheredMetric).(TargetConversionEvaluater)
+	if !ok {
+		return nil
+	}
+
+	conversion, err := targetConversionEvaluater.TargetConversion(gatheredMetric)
+	if err != nil {
+		return nil
+	}
+	return conversion
+}
+
+// usageRatio returns the usage ratio of the metric provided if its evaluater implements UsageRatioEvaluater, otherwise
+// nil
+func (e *Evaluator) usageRatio(gatheredMetric *metrics.Metric, currentReplicas int32) *float64 {
+	usageRatioEvaluater, ok := e.evaluaterFor(gatheredMetric).(UsageRatioEvaluater)
+	if !ok {
+		return nil
+	}
+
+	usageRatio, err := usageRatioEvaluater.UsageRatio(currentReplicas, gatheredMetric)
+	if err != nil {
+		return nil
+	}
+	return &usageRatio
+}
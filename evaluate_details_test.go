@@ -0,0 +1,400 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	resourcemetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func TestEvaluateWithDetails(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	averageValue := resource.MustParse("100")
+	targetValue := resource.MustParse("10")
+
+	podsMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: &averageValue,
+				},
+			},
+		},
+		Pods: &pods.Metric{
+			PodMetricsInfo: podmetrics.MetricsInfo{
+				"pod-1": podmetrics.Metric{Value: 150000},
+				"pod-2": podmetrics.Metric{Value: 150000},
+			},
+			ReadyPodCount: 2,
+		},
+	}
+	externalMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ExternalMetricSourceType,
+			External: &autoscalingv2.ExternalMetricSource{
+				Target: autoscalingv2.MetricTarget{
+					Type:  autoscalingv2.ValueMetricType,
+					Value: &targetValue,
+				},
+			},
+		},
+		External: &external.Metric{
+			Current: value.MetricValue{
+				Value: testutil.Int64Ptr(5000),
+			},
+			ReadyPodCount: testutil.Int64Ptr(2),
+		},
+	}
+	milliConversion := &value.Conversion{
+		Units:  value.UnitsMilli,
+		Target: targetValue,
+		Value:  10000,
+	}
+	invalidMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: "invalid",
+		},
+	}
+
+	var tests = []struct {
+		description     string
+		expected        *k8shorizmetrics.DetailedEvaluation
+		expectedErr     error
+		evaluator       *k8shorizmetrics.Evaluator
+		gatheredMetrics []*metrics.Metric
+	}{
+		{
+			description: "No metrics",
+			expected: &k8shorizmetrics.DetailedEvaluation{
+				Replicas: 0,
+				Metrics:  []*k8shorizmetrics.MetricEvaluation{},
+			},
+			expectedErr:     nil,
+			evaluator:       k8shorizmetrics.NewEvaluator(0.1),
+			gatheredMetrics: []*metrics.Metric{},
+		},
+		{
+			description: "Multiple metrics, highest replica count wins",
+			expected: &k8shorizmetrics.DetailedEvaluation{
+				Replicas: 3,
+				Metrics: []*k8shorizmetrics.MetricEvaluation{
+					{
+						Metric:           externalMetric,
+						Replicas:         1,
+						UsageRatio:       float64Ptr(0.5),
+						TargetConversion: milliConversion,
+					},
+					{
+						Metric:     podsMetric,
+						Replicas:   3,
+						UsageRatio: float64Ptr(1.5),
+						Winning:    true,
+					},
+				},
+			},
+			expectedErr:     nil,
+			evaluator:       k8shorizmetrics.NewEvaluator(0.1),
+			gatheredMetrics: []*metrics.Metric{externalMetric, podsMetric},
+		},
+		{
+			description: "Raw target units, conversion included",
+			expected: &k8shorizmetrics.DetailedEvaluation{
+				Replicas: 1000,
+				Metrics: []*k8shorizmetrics.MetricEvaluation{
+					{
+						Metric:     externalMetric,
+						Replicas:   1000,
+						UsageRatio: float64Ptr(500),
+						TargetConversion: &value.Conversion{
+							Units:  value.UnitsRaw,
+							Target: targetValue,
+							Value:  10,
+						},
+						Winning: true,
+					},
+				},
+			},
+			expectedErr:     nil,
+			evaluator:       k8shorizmetrics.NewEvaluatorWithOptions(0.1, k8shorizmetrics.WithTargetUnits(value.UnitsRaw)),
+			gatheredMetrics: []*metrics.Metric{externalMetric},
+		},
+		{
+			description: "Evaluater without usage ratio, first highest replica count wins",
+			expected: &k8shorizmetrics.DetailedEvaluation{
+				Replicas: 4,
+				Metrics: []*k8shorizmetrics.MetricEvaluation{
+					{
+						Metric:   podsMetric,
+						Replicas: 4,
+						Winning:  true,
+					},
+					{
+						Metric:   podsMetric,
+						Replicas: 4,
+					},
+				},
+			},
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Pods: &fake.PodsEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+						return 4
+					},
+				},
+			},
+			gatheredMetrics: []*metrics.Metric{podsMetric, podsMetric},
+		},
+		{
+			description: "Partial failure, error recorded against failing metric",
+			expected: &k8shorizmetrics.DetailedEvaluation{
+				Replicas: 1,
+				Metrics: []*k8shorizmetrics.MetricEvaluation{
+					{
+						Metric: invalidMetric,
+						Err:    errors.New(`unknown metric source type "invalid"`),
+					},
+					{
+						Metric:           externalMetric,
+						Replicas:         1,
+						UsageRatio:       float64Ptr(0.5),
+						TargetConversion: milliConversion,
+						Winning:          true,
+					},
+				},
+			},
+			expectedErr:     errors.New(`evaluator multi metric error: 1 errors, first error is unknown metric source type "invalid"`),
+			evaluator:       k8shorizmetrics.NewEvaluator(0.1),
+			gatheredMetrics: []*metrics.Metric{invalidMetric, externalMetric},
+		},
+		{
+			description: "Complete failure",
+			expected: &k8shorizmetrics.DetailedEvaluation{
+				Replicas: 0,
+				Metrics: []*k8shorizmetrics.MetricEvaluation{
+					{
+						Metric: invalidMetric,
+						Err:    errors.New(`unknown metric source type "invalid"`),
+					},
+				},
+			},
+			expectedErr:     errors.New(`evaluator multi metric error: 1 errors, first error is unknown metric source type "invalid"`),
+			evaluator:       k8shorizmetrics.NewEvaluator(0.1),
+			gatheredMetrics: []*metrics.Metric{invalidMetric},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := test.evaluator.EvaluateWithDetails(test.gatheredMetrics, 2)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+			}
+			if !cmp.Equal(test.expected, result, equateErrorMessage) {
+				t.Errorf("detailed evaluation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, equateErrorMessage))
+			}
+
+			replicas, _ := test.evaluator.Evaluate(test.gatheredMetrics, 2)
+			if test.expectedErr == nil && replicas != test.expected.Replicas {
+				t.Errorf("expected detailed evaluation to match evaluation, want %d, got %d", replicas,
+					test.expected.Replicas)
+			}
+		})
+	}
+}
+
+func TestEvaluateWithDetailsDeletionCosts(t *testing.T) {
+	evaluator := &k8shorizmetrics.Evaluator{
+		Pods: &fake.PodsEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+				return 3
+			},
+		},
+		Resource: &fake.ResourceEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+				return 2, nil
+			},
+		},
+	}
+
+	var tests = []struct {
+		description     string
+		expected        map[string]int32
+		gatheredMetrics []*metrics.Metric
+	}{
+		{
+			description: "No deletion costs",
+			expected:    nil,
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.PodsMetricSourceType},
+					Pods: &pods.Metric{},
+				},
+			},
+		},
+		{
+			description: "Deletion costs combined from resource and pods metrics",
+			expected: map[string]int32{
+				"pod-1": 100,
+				"pod-2": -50,
+				"pod-3": 10,
+			},
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.PodsMetricSourceType},
+					Pods: &pods.Metric{
+						DeletionCosts: map[string]int32{
+							"pod-1": 100,
+							"pod-2": -50,
+						},
+					},
+				},
+				{
+					Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ResourceMetricSourceType},
+					Resource: &resourcemetrics.Metric{
+						DeletionCosts: map[string]int32{
+							"pod-1": 100,
+							"pod-3": 10,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := evaluator.EvaluateWithDetails(test.gatheredMetrics, 2)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !cmp.Equal(test.expected, result.DeletionCosts) {
+				t.Errorf("deletion costs mismatch (-want +got):\n%s", cmp.Diff(test.expected, result.DeletionCosts))
+			}
+		})
+	}
+}
+
+func TestEvaluateWithDetailsCurrentUtilization(t *testing.T) {
+	evaluator := &k8shorizmetrics.Evaluator{
+		Pods: &fake.PodsEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+				return 3
+			},
+		},
+		Resource: &fake.ResourceEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+				return 2, nil
+			},
+		},
+	}
+
+	var tests = []struct {
+		description                 string
+		expectedCurrentUtilization  *int32
+		expectedCurrentAverageValue *int64
+		gatheredMetric              *metrics.Metric
+	}{
+		{
+			description: "Not a resource metric",
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.PodsMetricSourceType},
+				Pods: &pods.Metric{},
+			},
+		},
+		{
+			description: "Resource metric, no requests",
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ResourceMetricSourceType},
+				Resource: &resourcemetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 50},
+					},
+				},
+			},
+		},
+		{
+			description:                 "Resource metric, use values computed when gathered",
+			expectedCurrentUtilization:  testutil.Int32Ptr(80),
+			expectedCurrentAverageValue: testutil.Int64Ptr(40),
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ResourceMetricSourceType},
+				Resource: &resourcemetrics.Metric{
+					CurrentUtilization:  testutil.Int32Ptr(80),
+					CurrentAverageValue: testutil.Int64Ptr(40),
+				},
+			},
+		},
+		{
+			description:                 "Resource metric, calculated from pod metrics",
+			expectedCurrentUtilization:  testutil.Int32Ptr(50),
+			expectedCurrentAverageValue: testutil.Int64Ptr(100),
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ResourceMetricSourceType},
+				Resource: &resourcemetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 50},
+						"pod-2": podmetrics.Metric{Value: 150},
+					},
+					Requests: map[string]int64{
+						"pod-1": 200,
+						"pod-2": 200,
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := evaluator.EvaluateWithDetails([]*metrics.Metric{test.gatheredMetric}, 2)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			metricEvaluation := result.Metrics[0]
+			if !cmp.Equal(test.expectedCurrentUtilization, metricEvaluation.CurrentUtilization) {
+				t.Errorf("current utilization mismatch (-want +got):\n%s",
+					cmp.Diff(test.expectedCurrentUtilization, metricEvaluation.CurrentUtilization))
+			}
+			if !cmp.Equal(test.expectedCurrentAverageValue, metricEvaluation.CurrentAverageValue) {
+				t.Errorf("current average value mismatch (-want +got):\n%s",
+					cmp.Diff(test.expectedCurrentAverageValue, metricEvaluation.CurrentAverageValue))
+			}
+		})
+	}
+}
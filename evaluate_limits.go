@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+)
+
+// LimitDirection is the direction a replica count was limited in to bring it within the minimum and maximum replica
+// counts
+type LimitDirection string
+
+const (
+	// LimitDirectionNone means the replica count was within the minimum and maximum replica counts and was not limited
+	LimitDirectionNone LimitDirection = ""
+	// LimitDirectionRaised means the replica count was below the minimum replica count and was raised to it
+	LimitDirectionRaised LimitDirection = "Raised"
+	// LimitDirectionLowered means the replica count was above the maximum replica count and was lowered to it
+	LimitDirectionLowered LimitDirection = "Lowered"
+)
+
+// LimitedEvaluation is a replica count evaluation limited to the minimum and maximum replica counts. UnlimitedReplicas
+// is the replica count evaluated from the metrics, Replicas is the replica count after limiting and Direction is the
+// direction the replica count was limited in, if at all.
+type LimitedEvaluation struct {
+	Replicas          int32          `json:"replicas"`
+	UnlimitedReplicas int32          `json:"unlimitedReplicas"`
+	Direction         LimitDirection `json:"direction,omitempty"`
+}
+
+// Limited returns if the replica count was limited by the minimum or maximum replica count
+func (e *LimitedEvaluation) Limited() bool {
+	return e.Direction != LimitDirectionNone
+}
+
+// Reason returns the HPA reason for the limited evaluation, TooFewReplicas if the replica count was raised,
+// TooManyReplicas if it was lowered and DesiredWithinRange otherwise
+func (e *LimitedEvaluation) Reason() Reason {
+	switch e.Direction {
+	case LimitDirectionRaised:
+		return ReasonTooFewReplicas
+	case LimitDirectionLowered:
+		return ReasonTooManyReplicas
+	}
+	return ReasonDesiredWithinRange
+}
+
+// LimitReplicas limits the replica count provided to the minimum and maximum replica counts in the same way the HPA
+// does, if the replica count is below the minimum it is raised to the minimum, otherwise if it is above the maximum it
+// is lowered to the maximum
+func LimitReplicas(replicas int32, minReplicas int32, maxReplicas int32) *LimitedEvaluation {
+	limited := &LimitedEvaluation{
+		Replicas:          replicas,
+		UnlimitedReplicas: replicas,
+		Direction:         LimitDirectionNone,
+	}
+
+	if replicas < minReplicas {
+		limited.Replicas = minReplicas
+		limited.Direction = LimitDirectionRaised
+	} else if replicas > maxReplicas {
+		limited.Replicas = maxReplicas
+		limited.Direction = LimitDirectionLowered
+	}
+
+	return limited
+}
+
+// EvaluateWithLimits returns the target replica count for an array of multiple metrics limited to the minimum and
+// maximum replica counts provided, reporting whether the replica count was limited and in which direction.
+// If an error occurs evaluating any metric this will return a EvaluatorMultiMetricError. If a partial error occurs,
+// meaning some metrics were evaluated successfully and others failed, the 'Partial' property of this error will be
+// set to true and the limited evaluation of the successful metrics is returned.
+func (e *Evaluator) EvaluateWithLimits(gatheredMetrics []*metrics.Metric, currentReplicas int32, minReplicas int32,
+	maxReplicas int32) (*LimitedEvaluation, error) {
+	return e.EvaluateWithContextAndLimits(context.Background(), gatheredMetrics, currentReplicas, minReplicas,
+		maxReplicas)
+}
+
+// EvaluateWithContextAndLimits returns the target replica count for an array of multiple metrics limited to the
+// minimum and maximum replica counts provided, reporting whether the replica count was limited and in which
+// direction, passing the context provided to any evaluaters that implement the context aware evaluater interfaces.
+// If an error occurs evaluating any metric this will return a EvaluatorMultiMetricError. If a partial error occurs,
+// meaning some metrics were evaluated successfully and others failed, the 'Partial' property of this error will be
+// set to true and the limited evaluation of the successful metrics is returned.
+func (e *Evaluator) EvaluateWithContextAndLimits(ctx context.Context, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32, minReplicas int32, maxReplicas int32) (*LimitedEvaluation, error) {
+	evaluation, err := e.evaluate(ctx, gatheredMetrics, currentReplicas, e.Tolerance)
+	if err != nil {
+		var multiErr *EvaluatorMultiMetricError
+		if errors.As(err, &multiErr) && multiErr.Partial {
+			return LimitReplicas(evaluation, minReplicas, maxReplicas), err
+		}
+		return nil, err
+	}
+
+	return LimitReplicas(evaluation, minReplicas, maxReplicas), nil
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+func TestLimitReplicas(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    *k8shorizmetrics.LimitedEvaluation
+		replicas    int32
+		minReplicas int32
+		maxReplicas int32
+	}{
+		{
+			description: "Within limits",
+			expected: &k8shorizmetrics.LimitedEvaluation{
+				Replicas:          5,
+				UnlimitedReplicas: 5,
+				Direction:         k8shorizmetrics.LimitDirectionNone,
+			},
+			replicas:    5,
+			minReplicas: 1,
+			maxReplicas: 10,
+		},
+		{
+			description: "At limits",
+			expected: &k8shorizmetrics.LimitedEvaluation{
+				Replicas:          10,
+				UnlimitedReplicas: 10,
+				Direction:         k8shorizmetrics.LimitDirectionNone,
+			},
+			replicas:    10,
+			minReplicas: 10,
+			maxReplicas: 10,
+		},
+		{
+			description: "Below min, raised",
+			expected: &k8shorizmetrics.LimitedEvaluation{
+				Replicas:          2,
+				UnlimitedReplicas: 0,
+				Direction:         k8shorizmetrics.LimitDirectionRaised,
+			},
+			replicas:    0,
+			minReplicas: 2,
+			maxReplicas: 10,
+		},
+		{
+			description: "Above max, lowered",
+			expected: &k8shorizmetrics.LimitedEvaluation{
+				Replicas:          10,
+				UnlimitedReplicas: 25,
+				Direction:         k8shorizmetrics.LimitDirectionLowered,
+			},
+			replicas:    25,
+			minReplicas: 2,
+			maxReplicas: 10,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := k8shorizmetrics.LimitReplicas(test.replicas, test.minReplicas, test.maxReplicas)
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("limited evaluation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+			if result.Limited() != (test.expected.Direction != k8shorizmetrics.LimitDirectionNone) {
+				t.Errorf("limited mismatch, got %t for direction %q", result.Limited(), result.Direction)
+			}
+		})
+	}
+}
+
+func TestEvaluateWithLimits(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	resourceMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+		},
+	}
+	invalidMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: "invalid",
+		},
+	}
+
+	var tests = []struct {
+		description     string
+		expected        *k8shorizmetrics.LimitedEvaluation
+		expectedErr     error
+		evaluator       *k8shorizmetrics.Evaluator
+		gatheredMetrics []*metrics.Metric
+		minReplicas     int32
+		maxReplicas     int32
+	}{
+		{
+			description: "Fail to evaluate",
+			expected:    nil,
+			expectedErr: errors.New(`evaluator multi metric error: 1 errors, first error is unknown metric source type "invalid"`),
+			evaluator:   &k8shorizmetrics.Evaluator{},
+			gatheredMetrics: []*metrics.Metric{
+				invalidMetric,
+			},
+			minReplicas: 1,
+			maxReplicas: 10,
+		},
+		{
+			description: "Partial failure, limit successful evaluation",
+			expected: &k8shorizmetrics.LimitedEvaluation{
+				Replicas:          10,
+				UnlimitedReplicas: 15,
+				Direction:         k8shorizmetrics.LimitDirectionLowered,
+			},
+			expectedErr: errors.New(`evaluator multi metric error: 1 errors, first error is unknown metric source type "invalid"`),
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 15, nil
+					},
+				},
+			},
+			gatheredMetrics: []*metrics.Metric{
+				resourceMetric,
+				invalidMetric,
+			},
+			minReplicas: 1,
+			maxReplicas: 10,
+		},
+		{
+			description: "Success, raised to min replicas",
+			expected: &k8shorizmetrics.LimitedEvaluation{
+				Replicas:          3,
+				UnlimitedReplicas: 1,
+				Direction:         k8shorizmetrics.LimitDirectionRaised,
+			},
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 1, nil
+					},
+				},
+			},
+			gatheredMetrics: []*metrics.Metric{
+				resourceMetric,
+			},
+			minReplicas: 3,
+			maxReplicas: 10,
+		},
+		{
+			description: "Success, within limits",
+			expected: &k8shorizmetrics.LimitedEvaluation{
+				Replicas:          4,
+				UnlimitedReplicas: 4,
+				Direction:         k8shorizmetrics.LimitDirectionNone,
+			},
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+						return 4, nil
+					},
+				},
+			},
+			gatheredMetrics: []*metrics.Metric{
+				resourceMetric,
+			},
+			minReplicas: 1,
+			maxReplicas: 10,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := test.evaluator.EvaluateWithLimits(test.gatheredMetrics, 5, test.minReplicas, test.maxReplicas)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("limited evaluation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+
+			result, err = test.evaluator.EvaluateWithContextAndLimits(context.Background(), test.gatheredMetrics, 5,
+				test.minReplicas, test.maxReplicas)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch with context (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("limited evaluation mismatch with context (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
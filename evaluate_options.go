@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+)
+
+// EvaluateOptions configures a single evaluation. Any option left as the zero value uses the Evaluator's
+// configuration, so new options can be added without changing the behaviour of existing callers.
+type EvaluateOptions struct {
+	// Tolerance is how far the usage ratio of a metric can be from 1 before the replica count is changed
+	Tolerance float64
+	// ScaleUpLimitPercent is the most the replica count can increase by in a single evaluation, as a percentage of the
+	// current replica count
+	ScaleUpLimitPercent int32
+	// ScaleDownLimitPercent is the most the replica count can decrease by in a single evaluation, as a percentage of
+	// the current replica count
+	ScaleDownLimitPercent int32
+	// MaxStepSize is the most the replica count can change by in either direction in a single evaluation
+	MaxStepSize int32
+}
+
+func (e *Evaluator) defaultOptions(options EvaluateOptions) EvaluateOptions {
+	if options.Tolerance == 0 {
+		options.Tolerance = e.Tolerance
+	}
+	if options.ScaleUpLimitPercent == 0 {
+		options.ScaleUpLimitPercent = e.ScaleUpLimitPercent
+	}
+	if options.ScaleDownLimitPercent == 0 {
+		options.ScaleDownLimitPercent = e.ScaleDownLimitPercent
+	}
+	if options.MaxStepSize == 0 {
+		options.MaxStepSize = e.MaxStepSize
+	}
+	return options
+}
+
+// EvaluateWithEvaluateOptions returns the target replica count for an array of multiple metrics with the options
+// provided, passing the context provided to any evaluaters that implement the context aware evaluater interfaces. Any
+// options left as the zero value use the Evaluator's configuration.
+// If an error occurs evaluating any metric this will return a EvaluatorMultiMetricError. If a partial error occurs,
+// meaning some metrics were evaluated successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (e *Evaluator) EvaluateWithEvaluateOptions(ctx context.Context, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32, options EvaluateOptions) (int32, error) {
+	return e.evaluateWithOptions(ctx, gatheredMetrics, currentReplicas, e.defaultOptions(options))
+}
+
+func (e *Evaluator) evaluateWithOptions(ctx context.Context, gatheredMetrics []*metrics.Metric, currentReplicas int32,
+	options EvaluateOptions) (int32, error) {
+	details, err := e.evaluateDetails(ctx, gatheredMetrics, currentReplicas, options.Tolerance, false)
+	if err != nil {
+		var multiErr *EvaluatorMultiMetricError
+		if !errors.As(err, &multiErr) || !multiErr.Partial {
+			return 0, err
+		}
+	}
+
+	limited := limitChange(details.Replicas, currentReplicas, options)
+	if limited != details.Replicas {
+		resolveLogger(ctx, e.Logger).V(LogLevelDebug).Info("Limited replica change", "currentReplicas", currentReplicas,
+			"proposedReplicas", details.Replicas, "replicas", limited)
+	}
+	return limited, err
+}
+
+// limitChange limits how much the replica count can change from the current replica count in a single evaluation.
+// Percentage limits are not applied when there are no current replicas, since any percentage of zero is zero. As
+// with the HPA's percent scaling policies, the scale up limit is rounded up so the replica count can always increase.
+// The step size limit is applied before the percentage limits so it also applies when there are no current
+// replicas; since each limit only narrows the range, the most restrictive limit wins.
+func limitChange(replicas int32, currentReplicas int32, options EvaluateOptions) int32 {
+	if options.MaxStepSize > 0 {
+		replicas = min(max(replicas, currentReplicas-options.MaxStepSize), currentReplicas+options.MaxStepSize)
+	}
+
+	if currentReplicas <= 0 {
+		return replicas
+	}
+
+	if options.ScaleUpLimitPercent > 0 && replicas > currentReplicas {
+		scaleUpLimit := int32(math.Ceil(float64(currentReplicas) * (1 + float64(options.ScaleUpLimitPercent)/100)))
+		replicas = min(replicas, scaleUpLimit)
+	}
+
+	if options.ScaleDownLimitPercent > 0 && replicas < currentReplicas {
+		scaleDownLimit := int32(float64(currentReplicas) * (1 - float64(options.ScaleDownLimitPercent)/100))
+		replicas = max(replicas, scaleDownLimit)
+	}
+
+	return replicas
+}
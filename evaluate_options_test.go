@@ -0,0 +1,316 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+func TestEvaluateWithEvaluateOptions(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	resourceMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+		},
+	}
+	invalidMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: "invalid",
+		},
+	}
+
+	resourceEvaluater := func(replicas int32) *fake.ResourceEvaluater {
+		return &fake.ResourceEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+				return replicas, nil
+			},
+		}
+	}
+
+	var tests = []struct {
+		description     string
+		expected        int32
+		expectedErr     error
+		evaluator       *k8shorizmetrics.Evaluator
+		gatheredMetrics []*metrics.Metric
+		currentReplicas int32
+		options         k8shorizmetrics.EvaluateOptions
+	}{
+		{
+			description:     "Fail to evaluate",
+			expected:        0,
+			expectedErr:     errors.New(`evaluator multi metric error: 1 errors, first error is unknown metric source type "invalid"`),
+			evaluator:       &k8shorizmetrics.Evaluator{},
+			gatheredMetrics: []*metrics.Metric{invalidMetric},
+			currentReplicas: 4,
+			options: k8shorizmetrics.EvaluateOptions{
+				ScaleUpLimitPercent: 100,
+			},
+		},
+		{
+			description: "Partial failure, limit successful evaluation",
+			expected:    8,
+			expectedErr: errors.New(`evaluator multi metric error: 1 errors, first error is unknown metric source type "invalid"`),
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: resourceEvaluater(40),
+			},
+			gatheredMetrics: []*metrics.Metric{resourceMetric, invalidMetric},
+			currentReplicas: 4,
+			options: k8shorizmetrics.EvaluateOptions{
+				ScaleUpLimitPercent: 100,
+			},
+		},
+		{
+			description: "No limits",
+			expected:    40,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: resourceEvaluater(40),
+			},
+			gatheredMetrics: []*metrics.Metric{resourceMetric},
+			currentReplicas: 4,
+			options:         k8shorizmetrics.EvaluateOptions{},
+		},
+		{
+			description: "Scale up limited by options",
+			expected:    8,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: resourceEvaluater(40),
+			},
+			gatheredMetrics: []*metrics.Metric{resourceMetric},
+			currentReplicas: 4,
+			options: k8shorizmetrics.EvaluateOptions{
+				ScaleUpLimitPercent: 100,
+			},
+		},
+		{
+			description: "Scale up limited by evaluator, rounded up",
+			expected:    4,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource:            resourceEvaluater(40),
+				ScaleUpLimitPercent: 10,
+			},
+			gatheredMetrics: []*metrics.Metric{resourceMetric},
+			currentReplicas: 3,
+			options:         k8shorizmetrics.EvaluateOptions{},
+		},
+		{
+			description: "Scale up within limit",
+			expected:    6,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource:            resourceEvaluater(6),
+				ScaleUpLimitPercent: 100,
+			},
+			gatheredMetrics: []*metrics.Metric{resourceMetric},
+			currentReplicas: 4,
+			options:         k8shorizmetrics.EvaluateOptions{},
+		},
+		{
+			description: "Options override evaluator",
+			expected:    12,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource:            resourceEvaluater(40),
+				ScaleUpLimitPercent: 100,
+			},
+			gatheredMetrics: []*metrics.Metric{resourceMetric},
+			currentReplicas: 4,
+			options: k8shorizmetrics.EvaluateOptions{
+				ScaleUpLimitPercent: 200,
+			},
+		},
+		{
+			description: "Scale down limited",
+			expected:    5,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource:              resourceEvaluater(1),
+				ScaleDownLimitPercent: 50,
+			},
+			gatheredMetrics: []*metrics.Metric{resourceMetric},
+			currentReplicas: 10,
+			options:         k8shorizmetrics.EvaluateOptions{},
+		},
+		{
+			description: "Scale down limit does not limit scale up",
+			expected:    40,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource:              resourceEvaluater(40),
+				ScaleDownLimitPercent: 50,
+			},
+			gatheredMetrics: []*metrics.Metric{resourceMetric},
+			currentReplicas: 10,
+			options:         k8shorizmetrics.EvaluateOptions{},
+		},
+		{
+			description: "Scale up limited by step size",
+			expected:    9,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource:    resourceEvaluater(40),
+				MaxStepSize: 5,
+			},
+			gatheredMetrics: []*metrics.Metric{resourceMetric},
+			currentReplicas: 4,
+			options:         k8shorizmetrics.EvaluateOptions{},
+		},
+		{
+			description: "Scale down limited by step size from options",
+			expected:    7,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource: resourceEvaluater(1),
+			},
+			gatheredMetrics: []*metrics.Metric{resourceMetric},
+			currentReplicas: 10,
+			options: k8shorizmetrics.EvaluateOptions{
+				MaxStepSize: 3,
+			},
+		},
+		{
+			description: "Step size more restrictive than percentage",
+			expected:    12,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource:            resourceEvaluater(40),
+				ScaleUpLimitPercent: 100,
+				MaxStepSize:         2,
+			},
+			gatheredMetrics: []*metrics.Metric{resourceMetric},
+			currentReplicas: 10,
+			options:         k8shorizmetrics.EvaluateOptions{},
+		},
+		{
+			description: "Percentage more restrictive than step size",
+			expected:    11,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource:            resourceEvaluater(40),
+				ScaleUpLimitPercent: 10,
+				MaxStepSize:         5,
+			},
+			gatheredMetrics: []*metrics.Metric{resourceMetric},
+			currentReplicas: 10,
+			options:         k8shorizmetrics.EvaluateOptions{},
+		},
+		{
+			description: "No current replicas, limited by step size",
+			expected:    5,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource:    resourceEvaluater(40),
+				MaxStepSize: 5,
+			},
+			gatheredMetrics: []*metrics.Metric{resourceMetric},
+			currentReplicas: 0,
+			options:         k8shorizmetrics.EvaluateOptions{},
+		},
+		{
+			description: "No current replicas, not limited",
+			expected:    40,
+			expectedErr: nil,
+			evaluator: &k8shorizmetrics.Evaluator{
+				Resource:            resourceEvaluater(40),
+				ScaleUpLimitPercent: 100,
+			},
+			gatheredMetrics: []*metrics.Metric{resourceMetric},
+			currentReplicas: 0,
+			options:         k8shorizmetrics.EvaluateOptions{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := test.evaluator.EvaluateWithEvaluateOptions(context.Background(), test.gatheredMetrics,
+				test.currentReplicas, test.options)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if result != test.expected {
+				t.Errorf("evaluation mismatch, want %d, got %d", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestEvaluateAppliesChangeLimits(t *testing.T) {
+	evaluator := &k8shorizmetrics.Evaluator{
+		Resource: &fake.ResourceEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+				return 40, nil
+			},
+		},
+		ScaleUpLimitPercent: 100,
+	}
+
+	result, err := evaluator.Evaluate([]*metrics.Metric{
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+			},
+		},
+	}, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != 8 {
+		t.Errorf("evaluation mismatch, want 8, got %d", result)
+	}
+}
+
+func TestEvaluateWithOptionsAppliesStepSize(t *testing.T) {
+	evaluator := &k8shorizmetrics.Evaluator{
+		Resource: &fake.ResourceEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+				return 40, nil
+			},
+		},
+		MaxStepSize: 5,
+	}
+
+	result, err := evaluator.EvaluateWithOptions([]*metrics.Metric{
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+			},
+		},
+	}, 4, 0.1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != 9 {
+		t.Errorf("evaluation mismatch, want 9, got %d", result)
+	}
+}
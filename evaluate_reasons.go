@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+// Reason is a machine readable reason for the replica count chosen by an evaluation, matching the reasons the HPA
+// reports on its AbleToScale and ScalingLimited conditions
+type Reason string
+
+const (
+	// ReasonDesiredWithinRange means the replica count was not limited
+	ReasonDesiredWithinRange Reason = "DesiredWithinRange"
+	// ReasonTooFewReplicas means the replica count was raised to the minimum replica count
+	ReasonTooFewReplicas Reason = "TooFewReplicas"
+	// ReasonTooManyReplicas means the replica count was lowered to the maximum replica count
+	ReasonTooManyReplicas Reason = "TooManyReplicas"
+	// ReasonScaleUpLimit means the replica count was lowered by the scale up policies
+	ReasonScaleUpLimit Reason = "ScaleUpLimit"
+	// ReasonScaleDownLimit means the replica count was raised by the scale down policies
+	ReasonScaleDownLimit Reason = "ScaleDownLimit"
+	// ReasonReadyForNewScale means the replica count was not changed by stabilization
+	ReasonReadyForNewScale Reason = "ReadyForNewScale"
+	// ReasonScaleUpStabilized means the replica count was lowered to the lowest recommendation in the scale up
+	// stabilization window
+	ReasonScaleUpStabilized Reason = "ScaleUpStabilized"
+	// ReasonScaleDownStabilized means the replica count was raised to the highest recommendation in the scale down
+	// stabilization window
+	ReasonScaleDownStabilized Reason = "ScaleDownStabilized"
+)
+
+var reasonMessages = map[Reason]string{
+	ReasonDesiredWithinRange:  "the desired count is within the acceptable range",
+	ReasonTooFewReplicas:      "the desired replica count is less than the minimum replica count",
+	ReasonTooManyReplicas:     "the desired replica count is more than the maximum replica count",
+	ReasonScaleUpLimit:        "the desired replica count is increasing faster than the maximum scale rate",
+	ReasonScaleDownLimit:      "the desired replica count is decreasing faster than the maximum scale rate",
+	ReasonReadyForNewScale:    "recommended size matches current size",
+	ReasonScaleUpStabilized:   "recent recommendations were lower than current one, applying the lowest recent recommendation",
+	ReasonScaleDownStabilized: "recent recommendations were higher than current one, applying the highest recent recommendation",
+}
+
+// Message returns the human readable message the HPA reports alongside the reason, or an empty string if the reason
+// is not known
+func (r Reason) Message() string {
+	return reasonMessages[r]
+}
+
+// Limiting returns true if the reason means the replica count was limited, in the same way the HPA reports the
+// ScalingLimited condition as true
+func (r Reason) Limiting() bool {
+	switch r {
+	case ReasonTooFewReplicas, ReasonTooManyReplicas, ReasonScaleUpLimit, ReasonScaleDownLimit:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"testing"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+)
+
+func TestReason(t *testing.T) {
+	var tests = []struct {
+		description      string
+		expectedMessage  string
+		expectedLimiting bool
+		reason           k8shorizmetrics.Reason
+	}{
+		{
+			description:      "Desired within range",
+			expectedMessage:  "the desired count is within the acceptable range",
+			expectedLimiting: false,
+			reason:           k8shorizmetrics.ReasonDesiredWithinRange,
+		},
+		{
+			description:      "Too few replicas",
+			expectedMessage:  "the desired replica count is less than the minimum replica count",
+			expectedLimiting: true,
+			reason:           k8shorizmetrics.ReasonTooFewReplicas,
+		},
+		{
+			description:      "Scale up limit",
+			expectedMessage:  "the desired replica count is increasing faster than the maximum scale rate",
+			expectedLimiting: true,
+			reason:           k8shorizmetrics.ReasonScaleUpLimit,
+		},
+		{
+			description:      "Scale down stabilized",
+			expectedMessage:  "recent recommendations were higher than current one, applying the highest recent recommendation",
+			expectedLimiting: false,
+			reason:           k8shorizmetrics.ReasonScaleDownStabilized,
+		},
+		{
+			description:      "Unknown reason",
+			expectedMessage:  "",
+			expectedLimiting: false,
+			reason:           "unknown",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			if message := test.reason.Message(); message != test.expectedMessage {
+				t.Errorf("message mismatch, want %q, got %q", test.expectedMessage, message)
+			}
+			if limiting := test.reason.Limiting(); limiting != test.expectedLimiting {
+				t.Errorf("limiting mismatch, want %t, got %t", test.expectedLimiting, limiting)
+			}
+		})
+	}
+}
+
+func TestLimitedEvaluation_Reason(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    k8shorizmetrics.Reason
+		replicas    int32
+	}{
+		{
+			description: "Within range",
+			expected:    k8shorizmetrics.ReasonDesiredWithinRange,
+			replicas:    5,
+		},
+		{
+			description: "Raised",
+			expected:    k8shorizmetrics.ReasonTooFewReplicas,
+			replicas:    1,
+		},
+		{
+			description: "Lowered",
+			expected:    k8shorizmetrics.ReasonTooManyReplicas,
+			replicas:    20,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := k8shorizmetrics.LimitReplicas(test.replicas, 2, 10).Reason()
+			if result != test.expected {
+				t.Errorf("reason mismatch, want %s, got %s", test.expected, result)
+			}
+		})
+	}
+}
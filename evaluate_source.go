@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"context"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+// SourceEvaluater produces a replica count for a metric with a metric source type registered with the Evaluator,
+// allowing metric source types other than the built in autoscalingv2 source types to be evaluated.
+type SourceEvaluater interface {
+	Evaluate(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error)
+}
+
+// SourceEvaluaterFunc is an adapter allowing a function to be used as a SourceEvaluater
+type SourceEvaluaterFunc func(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric,
+	tolerance float64) (int32, error)
+
+// Evaluate calls the function with the arguments provided
+func (f SourceEvaluaterFunc) Evaluate(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric,
+	tolerance float64) (int32, error) {
+	return f(ctx, currentReplicas, gatheredMetric, tolerance)
+}
+
+// RegisterSource registers an evaluater for the metric source type provided, any gathered metrics with this type will
+// be evaluated using the registered evaluater. Registering a built in source type overrides the built in evaluation for
+// that type, and registering a nil evaluater removes any evaluater previously registered for the type.
+// Sources should be registered before the Evaluator is used, registering is not safe to call concurrently with
+// evaluating.
+func (e *Evaluator) RegisterSource(sourceType autoscalingv2.MetricSourceType, evaluater SourceEvaluater) {
+	if evaluater == nil {
+		delete(e.Sources, sourceType)
+		return
+	}
+	if e.Sources == nil {
+		e.Sources = map[autoscalingv2.MetricSourceType]SourceEvaluater{}
+	}
+	e.Sources[sourceType] = evaluater
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	v2 "k8s.io/api/autoscaling/v2"
+)
+
+func TestEvaluatorRegisterSource(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description     string
+		expected        int32
+		expectedErr     error
+		evaluator       *k8shorizmetrics.Evaluator
+		gatheredMetrics []*metrics.Metric
+		currentReplicas int32
+	}{
+		{
+			description: "Unregistered custom source type",
+			expected:    0,
+			expectedErr: errors.New(`evaluator multi metric error: 1 errors, first error is unknown metric source type "queue"`),
+			evaluator:   &k8shorizmetrics.Evaluator{},
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: v2.MetricSpec{
+						Type: "queue",
+					},
+				},
+			},
+			currentReplicas: 1,
+		},
+		{
+			description: "Registered custom source type fails",
+			expected:    0,
+			expectedErr: errors.New(`evaluator multi metric error: 1 errors, first error is fail to evaluate`),
+			evaluator: func() *k8shorizmetrics.Evaluator {
+				evaluator := &k8shorizmetrics.Evaluator{}
+				evaluator.RegisterSource("queue", k8shorizmetrics.SourceEvaluaterFunc(func(ctx context.Context,
+					currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 0, errors.New("fail to evaluate")
+				}))
+				return evaluator
+			}(),
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: v2.MetricSpec{
+						Type: "queue",
+					},
+				},
+			},
+			currentReplicas: 1,
+		},
+		{
+			description: "Registered custom source type alongside built in source type, take highest",
+			expected:    6,
+			expectedErr: nil,
+			evaluator: func() *k8shorizmetrics.Evaluator {
+				evaluator := &k8shorizmetrics.Evaluator{
+					Pods: &fake.PodsEvaluater{
+						EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+							return 3
+						},
+					},
+					Tolerance: 0.2,
+				}
+				evaluator.RegisterSource("queue", k8shorizmetrics.SourceEvaluaterFunc(func(ctx context.Context,
+					currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					if tolerance != 0.2 {
+						return 0, errors.New("unexpected tolerance")
+					}
+					return currentReplicas * int32(gatheredMetric.Custom.(int)), nil
+				}))
+				return evaluator
+			}(),
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.PodsMetricSourceType,
+					},
+				},
+				{
+					Spec: v2.MetricSpec{
+						Type: "queue",
+					},
+					Custom: 3,
+				},
+			},
+			currentReplicas: 2,
+		},
+		{
+			description: "Registered source overrides built in source type",
+			expected:    10,
+			expectedErr: nil,
+			evaluator: func() *k8shorizmetrics.Evaluator {
+				evaluator := &k8shorizmetrics.Evaluator{
+					Pods: &fake.PodsEvaluater{
+						EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+							return 3
+						},
+					},
+				}
+				evaluator.RegisterSource(v2.PodsMetricSourceType, k8shorizmetrics.SourceEvaluaterFunc(func(ctx context.Context,
+					currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 10, nil
+				}))
+				return evaluator
+			}(),
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.PodsMetricSourceType,
+					},
+				},
+			},
+			currentReplicas: 2,
+		},
+		{
+			description: "Registering nil removes source",
+			expected:    3,
+			expectedErr: nil,
+			evaluator: func() *k8shorizmetrics.Evaluator {
+				evaluator := &k8shorizmetrics.Evaluator{
+					Pods: &fake.PodsEvaluater{
+						EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+							return 3
+						},
+					},
+				}
+				evaluator.RegisterSource(v2.PodsMetricSourceType, k8shorizmetrics.SourceEvaluaterFunc(func(ctx context.Context,
+					currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 10, nil
+				}))
+				evaluator.RegisterSource(v2.PodsMetricSourceType, nil)
+				return evaluator
+			}(),
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.PodsMetricSourceType,
+					},
+				},
+			},
+			currentReplicas: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := test.evaluator.Evaluate(test.gatheredMetrics, test.currentReplicas)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("replicas mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
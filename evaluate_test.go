@@ -17,14 +17,21 @@ limitations under the License.
 package k8shorizmetrics_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/jthomperoo/k8shorizmetrics/v4"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/fake"
+	externalevaluate "github.com/jthomperoo/k8shorizmetrics/v4/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	"github.com/jthomperoo/k8shorizmetrics/v4/object"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	v2 "k8s.io/api/autoscaling/v2"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestEvaluateSingleMetricWithOptions(t *testing.T) {
@@ -627,3 +634,436 @@ func TestEvaluate(t *testing.T) {
 		})
 	}
 }
+
+func TestEvaluateForHPAV1(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description     string
+		expected        int32
+		expectedErr     error
+		resource        k8shorizmetrics.ResourceEvaluater
+		gatheredMetrics []*metrics.Metric
+		hpa             *autoscalingv1.HorizontalPodAutoscaler
+	}{
+		{
+			description: "Failure",
+			expected:    0,
+			expectedErr: errors.New("evaluator multi metric error: 1 errors, first error is test error"),
+			resource: &fake.ResourceEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 0, errors.New("test error")
+				},
+			},
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.ResourceMetricSourceType,
+					},
+				},
+			},
+			hpa: &autoscalingv1.HorizontalPodAutoscaler{},
+		},
+		{
+			description: "Success, use current replicas from HPA status",
+			expected:    6,
+			resource: &fake.ResourceEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return currentReplicas * 2, nil
+				},
+			},
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.ResourceMetricSourceType,
+					},
+				},
+			},
+			hpa: &autoscalingv1.HorizontalPodAutoscaler{
+				Status: autoscalingv1.HorizontalPodAutoscalerStatus{
+					CurrentReplicas: 3,
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluater := &k8shorizmetrics.Evaluator{
+				Resource: test.resource,
+			}
+
+			evaluation, err := evaluater.EvaluateForHPAV1(test.gatheredMetrics, test.hpa)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+
+			if !cmp.Equal(test.expected, evaluation) {
+				t.Errorf("evaluation mismatch (-want +got):\n%s", cmp.Diff(test.expected, evaluation))
+			}
+		})
+	}
+}
+
+func TestEvaluateWithContext(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description     string
+		expected        int32
+		expectedErr     error
+		external        k8shorizmetrics.ExternalEvaluater
+		object          k8shorizmetrics.ObjectEvaluater
+		pods            k8shorizmetrics.PodsEvaluater
+		resource        k8shorizmetrics.ResourceEvaluater
+		ctx             context.Context
+		gatheredMetrics []*metrics.Metric
+		currentReplicas int32
+	}{
+		{
+			description: "Evaluaters without context support, fall back to non context methods",
+			expected:    4,
+			pods: &fake.PodsEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+					return 4
+				},
+			},
+			resource: &fake.ResourceEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 2, nil
+				},
+			},
+			ctx: context.Background(),
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.PodsMetricSourceType,
+					},
+				},
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.ResourceMetricSourceType,
+					},
+				},
+			},
+			currentReplicas: 1,
+		},
+		{
+			description: "Evaluaters with context support, prefer context methods",
+			expected:    6,
+			external: &fake.ExternalEvaluaterWithContext{
+				EvaluateWithContextReactor: func(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 5, nil
+				},
+			},
+			object: &fake.ObjectEvaluaterWithContext{
+				EvaluateWithContextReactor: func(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 6, nil
+				},
+			},
+			pods: &fake.PodsEvaluaterWithContext{
+				EvaluateWithContextReactor: func(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+					return 3
+				},
+			},
+			resource: &fake.ResourceEvaluaterWithContext{
+				EvaluateWithContextReactor: func(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 2, nil
+				},
+			},
+			ctx: context.Background(),
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.ExternalMetricSourceType,
+					},
+				},
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.ObjectMetricSourceType,
+					},
+				},
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.PodsMetricSourceType,
+					},
+				},
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.ResourceMetricSourceType,
+					},
+				},
+			},
+			currentReplicas: 1,
+		},
+		{
+			description: "Pods evaluater returning errors, prefer error method",
+			expected:    0,
+			expectedErr: errors.New("evaluator multi metric error: 1 errors, first error is invalid pods metric"),
+			pods: &fake.PodsEvaluaterWithError{
+				PodsEvaluater: fake.PodsEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+						t.Errorf("pods evaluater without error should not be called")
+						return 0
+					},
+				},
+				EvaluateWithErrorReactor: func(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric) (int32, error) {
+					return 0, errors.New("invalid pods metric")
+				},
+			},
+			ctx: context.Background(),
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.PodsMetricSourceType,
+					},
+				},
+			},
+			currentReplicas: 1,
+		},
+		{
+			description: "Evaluater with context support, context error",
+			expected:    0,
+			expectedErr: errors.New("evaluator multi metric error: 1 errors, first error is context canceled"),
+			resource: &fake.ResourceEvaluaterWithContext{
+				EvaluateWithContextReactor: func(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 0, ctx.Err()
+				},
+			},
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			}(),
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.ResourceMetricSourceType,
+					},
+				},
+			},
+			currentReplicas: 1,
+		},
+		{
+			description: "Context done, evaluaters not called",
+			expected:    0,
+			expectedErr: errors.New("evaluator multi metric error: 2 errors, first error is context canceled"),
+			pods: &fake.PodsEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+					t.Errorf("pods evaluater should not be called")
+					return 0
+				},
+			},
+			resource: &fake.ResourceEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					t.Errorf("resource evaluater should not be called")
+					return 0, nil
+				},
+			},
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			}(),
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.PodsMetricSourceType,
+					},
+				},
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.ResourceMetricSourceType,
+					},
+				},
+			},
+			currentReplicas: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluater := &k8shorizmetrics.Evaluator{
+				External: test.external,
+				Object:   test.object,
+				Pods:     test.pods,
+				Resource: test.resource,
+			}
+
+			evaluation, err := evaluater.EvaluateWithContext(test.ctx, test.gatheredMetrics, test.currentReplicas)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+
+			if !cmp.Equal(test.expected, evaluation) {
+				t.Errorf("evaluation mismatch (-want +got):\n%s", cmp.Diff(test.expected, evaluation))
+			}
+		})
+	}
+}
+
+func TestNewEvaluator(t *testing.T) {
+	currentValue := int64(420000)
+
+	var tests = []struct {
+		description     string
+		expected        int32
+		tolerance       float64
+		gatheredMetrics []*metrics.Metric
+		currentReplicas int32
+	}{
+		{
+			description:     "Change within tolerance, replicas unchanged",
+			expected:        4,
+			tolerance:       0.1,
+			currentReplicas: 4,
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.ExternalMetricSourceType,
+						External: &v2.ExternalMetricSource{
+							Target: v2.MetricTarget{
+								Type:         v2.AverageValueMetricType,
+								AverageValue: k8sresource.NewMilliQuantity(100000, k8sresource.DecimalSI),
+							},
+						},
+					},
+					External: &external.Metric{
+						Current: value.MetricValue{
+							AverageValue: &currentValue,
+						},
+					},
+				},
+			},
+		},
+		{
+			description:     "Change outside tolerance, scale up",
+			expected:        5,
+			tolerance:       0.01,
+			currentReplicas: 4,
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: v2.MetricSpec{
+						Type: v2.ExternalMetricSourceType,
+						External: &v2.ExternalMetricSource{
+							Target: v2.MetricTarget{
+								Type:         v2.AverageValueMetricType,
+								AverageValue: k8sresource.NewMilliQuantity(100000, k8sresource.DecimalSI),
+							},
+						},
+					},
+					External: &external.Metric{
+						Current: value.MetricValue{
+							AverageValue: &currentValue,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluator := k8shorizmetrics.NewEvaluator(test.tolerance)
+			if evaluator.Tolerance != test.tolerance {
+				t.Errorf("tolerance mismatch (-want +got):\n%s", cmp.Diff(test.tolerance, evaluator.Tolerance))
+			}
+
+			evaluation, err := evaluator.Evaluate(test.gatheredMetrics, test.currentReplicas)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(test.expected, evaluation) {
+				t.Errorf("evaluation mismatch (-want +got):\n%s", cmp.Diff(test.expected, evaluation))
+			}
+		})
+	}
+}
+
+func TestEvaluateWithContextAndOptions(t *testing.T) {
+	evaluator := &k8shorizmetrics.Evaluator{
+		Resource: &fake.ResourceEvaluaterWithContext{
+			EvaluateWithContextReactor: func(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+				if ctx.Value(testContextKey{}) != "test" {
+					return 0, errors.New("context not passed through")
+				}
+				if tolerance != 0.5 {
+					return 0, errors.New("tolerance not passed through")
+				}
+				return 3, nil
+			},
+		},
+		Tolerance: 0.1,
+	}
+
+	gatheredMetrics := []*metrics.Metric{
+		{
+			Spec: v2.MetricSpec{
+				Type: v2.ResourceMetricSourceType,
+			},
+		},
+	}
+
+	ctx := context.WithValue(context.Background(), testContextKey{}, "test")
+
+	evaluation, err := evaluator.EvaluateWithContextAndOptions(ctx, gatheredMetrics, 1, 0.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if evaluation != 3 {
+		t.Errorf("evaluation mismatch, want 3, got %d", evaluation)
+	}
+
+	evaluation, err = evaluator.EvaluateSingleMetricWithContextAndOptions(ctx, gatheredMetrics[0], 1, 0.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if evaluation != 3 {
+		t.Errorf("single metric evaluation mismatch, want 3, got %d", evaluation)
+	}
+}
+
+func TestNewEvaluatorWithOptions(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    value.Units
+		options     []k8shorizmetrics.EvaluatorOption
+	}{
+		{
+			description: "No options, default units",
+			expected:    "",
+		},
+		{
+			description: "Raw target units",
+			expected:    value.UnitsRaw,
+			options:     []k8shorizmetrics.EvaluatorOption{k8shorizmetrics.WithTargetUnits(value.UnitsRaw)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluator := k8shorizmetrics.NewEvaluatorWithOptions(0.1, test.options...)
+			if evaluator.Tolerance != 0.1 {
+				t.Errorf("tolerance mismatch (-want +got):\n%s", cmp.Diff(0.1, evaluator.Tolerance))
+			}
+
+			externalUnits := evaluator.External.(*externalevaluate.Evaluate).TargetUnits
+			if !cmp.Equal(test.expected, externalUnits) {
+				t.Errorf("external target units mismatch (-want +got):\n%s", cmp.Diff(test.expected, externalUnits))
+			}
+
+			objectUnits := evaluator.Object.(*object.Evaluate).TargetUnits
+			if !cmp.Equal(test.expected, objectUnits) {
+				t.Errorf("object target units mismatch (-want +got):\n%s", cmp.Diff(test.expected, objectUnits))
+			}
+		})
+	}
+}
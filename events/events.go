@@ -0,0 +1,179 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Modifications Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+
+Modified to produce the HPA's event messages from k8shorizmetrics evaluations.
+Original source:
+https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscaler/horizontal.go
+*/
+
+// Package events produces the same human readable event reasons and messages the Horizontal Pod Autoscaler controller
+// emits, for example "New size: 8; reason: cpu resource utilization (percentage of request) above target", allowing
+// custom autoscalers to emit familiar events.
+package events
+
+import (
+	"fmt"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+// Event reasons used by the HPA
+const (
+	ReasonSuccessfulRescale = "SuccessfulRescale"
+	ReasonFailedRescale     = "FailedRescale"
+)
+
+// Rescale reasons used by the HPA when the current replica count is outside of the minimum and maximum replicas, or
+// when all metrics are below their targets
+const (
+	RescaleReasonAboveMaxReplicas = "Current number of replicas above Spec.MaxReplicas"
+	RescaleReasonBelowMinReplicas = "Current number of replicas below Spec.MinReplicas"
+	RescaleReasonAllMetricsBelow  = "All metrics below target"
+)
+
+// Result is an evaluation along with the name of the metric which produced it and the HPA rescale reason
+type Result struct {
+	Replicas   int32  `json:"replicas"`
+	MetricName string `json:"metricName"`
+	Reason     string `json:"reason"`
+}
+
+// MetricName returns the name the HPA uses to describe a metric spec in events
+func MetricName(spec autoscalingv2.MetricSpec) string {
+	switch spec.Type {
+	case autoscalingv2.ObjectMetricSourceType:
+		if spec.Object == nil {
+			break
+		}
+		return fmt.Sprintf("%s metric %s", spec.Object.DescribedObject.Kind, spec.Object.Metric.Name)
+	case autoscalingv2.PodsMetricSourceType:
+		if spec.Pods == nil {
+			break
+		}
+		return fmt.Sprintf("pods metric %s", spec.Pods.Metric.Name)
+	case autoscalingv2.ResourceMetricSourceType:
+		if spec.Resource == nil {
+			break
+		}
+		if spec.Resource.Target.Value != nil {
+			return fmt.Sprintf("%s resource total", spec.Resource.Name.String())
+		}
+		if spec.Resource.Target.AverageValue != nil {
+			return fmt.Sprintf("%s resource", spec.Resource.Name.String())
+		}
+		return fmt.Sprintf("%s resource utilization (percentage of request)", spec.Resource.Name.String())
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if spec.ContainerResource == nil {
+			break
+		}
+		if spec.ContainerResource.Target.AverageValue != nil {
+			return fmt.Sprintf("%s container resource", spec.ContainerResource.Name.String())
+		}
+		return fmt.Sprintf("%s container resource utilization (percentage of request)", spec.ContainerResource.Name.String())
+	case autoscalingv2.ExternalMetricSourceType:
+		if spec.External == nil {
+			break
+		}
+		return fmt.Sprintf("external metric %s(%+v)", spec.External.Metric.Name, spec.External.Metric.Selector)
+	}
+	return string(spec.Type)
+}
+
+// RescaleReason returns the HPA's rescale reason for a change from the current to the desired replica count driven by
+// the metric provided, if the replica count does not change an empty string is returned
+func RescaleReason(metricName string, currentReplicas int32, desiredReplicas int32) string {
+	if desiredReplicas > currentReplicas {
+		return fmt.Sprintf("%s above target", metricName)
+	}
+	if desiredReplicas < currentReplicas {
+		return RescaleReasonAllMetricsBelow
+	}
+	return ""
+}
+
+// SuccessfulRescaleMessage returns the HPA's SuccessfulRescale event message
+func SuccessfulRescaleMessage(newSize int32, reason string) string {
+	return fmt.Sprintf("New size: %d; reason: %s", newSize, reason)
+}
+
+// FailedRescaleMessage returns the HPA's FailedRescale event message
+func FailedRescaleMessage(newSize int32, reason string, err error) string {
+	return fmt.Sprintf("New size: %d; reason: %s; error: %v", newSize, reason, err.Error())
+}
+
+// Evaluate evaluates each metric individually in the same way as the Evaluator, taking the highest replica count, and
+// returns it along with the name of the metric which produced it and the HPA rescale reason.
+// If an error occurs evaluating any metric this will return a EvaluatorMultiMetricError. If a partial error occurs,
+// meaning some metrics were evaluated successfully and others failed, the result is returned along with the error
+// with the 'Partial' property set to true.
+func Evaluate(evaluator *k8shorizmetrics.Evaluator, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32) (*Result, error) {
+	return evaluate(evaluator, gatheredMetrics, currentReplicas, nil)
+}
+
+// evaluate evaluates the metrics provided, see Evaluate, calling onError if it is not nil for each metric that fails
+// to be evaluated
+func evaluate(evaluator *k8shorizmetrics.Evaluator, gatheredMetrics []*metrics.Metric, currentReplicas int32,
+	onError func(gatheredMetric *metrics.Metric, err error)) (*Result, error) {
+	if len(gatheredMetrics) == 0 {
+		return nil, fmt.Errorf("no metrics provided to evaluate")
+	}
+
+	var evaluationErrors []error
+	var result *Result
+
+	for _, gatheredMetric := range gatheredMetrics {
+		proposedReplicas, err := evaluator.EvaluateSingleMetric(gatheredMetric, currentReplicas)
+		if err != nil {
+			if onError != nil {
+				onError(gatheredMetric, err)
+			}
+			evaluationErrors = append(evaluationErrors, err)
+			continue
+		}
+
+		if result == nil || proposedReplicas > result.Replicas {
+			result = &Result{
+				Replicas:   proposedReplicas,
+				MetricName: MetricName(gatheredMetric.Spec),
+			}
+		}
+	}
+
+	if result == nil {
+		return nil, &k8shorizmetrics.EvaluatorMultiMetricError{
+			Partial: false,
+			Errors:  evaluationErrors,
+		}
+	}
+
+	result.Reason = RescaleReason(result.MetricName, currentReplicas, result.Replicas)
+
+	if len(evaluationErrors) > 0 {
+		return result, &k8shorizmetrics.EvaluatorMultiMetricError{
+			Partial: true,
+			Errors:  evaluationErrors,
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/events"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMetricName(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    string
+		spec        autoscalingv2.MetricSpec
+	}{
+		{
+			description: "Object metric",
+			expected:    "Service metric requests",
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ObjectMetricSourceType,
+				Object: &autoscalingv2.ObjectMetricSource{
+					DescribedObject: autoscalingv2.CrossVersionObjectReference{Kind: "Service"},
+					Metric:          autoscalingv2.MetricIdentifier{Name: "requests"},
+				},
+			},
+		},
+		{
+			description: "Pods metric",
+			expected:    "pods metric requests",
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{Name: "requests"},
+				},
+			},
+		},
+		{
+			description: "Resource utilization metric",
+			expected:    "cpu resource utilization (percentage of request)",
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+				},
+			},
+		},
+		{
+			description: "Resource average value metric",
+			expected:    "memory resource",
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceMemory,
+					Target: autoscalingv2.MetricTarget{
+						AverageValue: resource.NewQuantity(100, resource.DecimalSI),
+					},
+				},
+			},
+		},
+		{
+			description: "Resource value metric",
+			expected:    "memory resource total",
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceMemory,
+					Target: autoscalingv2.MetricTarget{
+						Value: resource.NewQuantity(100, resource.DecimalSI),
+					},
+				},
+			},
+		},
+		{
+			description: "Container resource utilization metric",
+			expected:    "cpu container resource utilization (percentage of request)",
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ContainerResourceMetricSourceType,
+				ContainerResource: &autoscalingv2.ContainerResourceMetricSource{
+					Name: corev1.ResourceCPU,
+				},
+			},
+		},
+		{
+			description: "External metric without selector",
+			expected:    "external metric queue_length(nil)",
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{Name: "queue_length"},
+				},
+			},
+		},
+		{
+			description: "External metric with selector",
+			expected:    "external metric queue_length(&LabelSelector{MatchLabels:map[string]string{queue: jobs,},MatchExpressions:[]LabelSelectorRequirement{},})",
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{
+						Name: "queue_length",
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"queue": "jobs"},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := events.MetricName(test.spec)
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("metric name mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestMessages(t *testing.T) {
+	successful := events.SuccessfulRescaleMessage(8, events.RescaleReason("cpu resource utilization (percentage of request)", 4, 8))
+	expectedSuccessful := "New size: 8; reason: cpu resource utilization (percentage of request) above target"
+	if !cmp.Equal(expectedSuccessful, successful) {
+		t.Errorf("successful message mismatch (-want +got):\n%s", cmp.Diff(expectedSuccessful, successful))
+	}
+
+	failed := events.FailedRescaleMessage(2, events.RescaleReason("pods metric requests", 4, 2), errors.New("conflict"))
+	expectedFailed := "New size: 2; reason: All metrics below target; error: conflict"
+	if !cmp.Equal(expectedFailed, failed) {
+		t.Errorf("failed message mismatch (-want +got):\n%s", cmp.Diff(expectedFailed, failed))
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	cpuMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+			},
+		},
+	}
+
+	podsMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "requests"},
+			},
+		},
+	}
+
+	evaluator := func(cpuReplicas int32, cpuErr error, podsReplicas int32) *k8shorizmetrics.Evaluator {
+		return &k8shorizmetrics.Evaluator{
+			Resource: &fake.ResourceEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return cpuReplicas, cpuErr
+				},
+			},
+			Pods: &fake.PodsEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+					return podsReplicas
+				},
+			},
+		}
+	}
+
+	var tests = []struct {
+		description     string
+		expected        *events.Result
+		expectedErr     error
+		evaluator       *k8shorizmetrics.Evaluator
+		gatheredMetrics []*metrics.Metric
+	}{
+		{
+			description: "No metrics",
+			expectedErr: errors.New("no metrics provided to evaluate"),
+			evaluator:   evaluator(0, nil, 0),
+		},
+		{
+			description:     "All metrics fail",
+			expectedErr:     errors.New("evaluator multi metric error: 1 errors, first error is fail"),
+			evaluator:       evaluator(0, errors.New("fail"), 0),
+			gatheredMetrics: []*metrics.Metric{cpuMetric},
+		},
+		{
+			description: "Partial failure",
+			expected: &events.Result{
+				Replicas:   6,
+				MetricName: "pods metric requests",
+				Reason:     "pods metric requests above target",
+			},
+			expectedErr:     errors.New("evaluator multi metric error: 1 errors, first error is fail"),
+			evaluator:       evaluator(0, errors.New("fail"), 6),
+			gatheredMetrics: []*metrics.Metric{cpuMetric, podsMetric},
+		},
+		{
+			description: "Highest metric drives scale up",
+			expected: &events.Result{
+				Replicas:   8,
+				MetricName: "cpu resource utilization (percentage of request)",
+				Reason:     "cpu resource utilization (percentage of request) above target",
+			},
+			evaluator:       evaluator(8, nil, 6),
+			gatheredMetrics: []*metrics.Metric{cpuMetric, podsMetric},
+		},
+		{
+			description: "Scale down",
+			expected: &events.Result{
+				Replicas:   3,
+				MetricName: "pods metric requests",
+				Reason:     events.RescaleReasonAllMetricsBelow,
+			},
+			evaluator:       evaluator(2, nil, 3),
+			gatheredMetrics: []*metrics.Metric{cpuMetric, podsMetric},
+		},
+		{
+			description: "No change",
+			expected: &events.Result{
+				Replicas:   4,
+				MetricName: "cpu resource utilization (percentage of request)",
+			},
+			evaluator:       evaluator(4, nil, 4),
+			gatheredMetrics: []*metrics.Metric{cpuMetric, podsMetric},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := events.Evaluate(test.evaluator, test.gatheredMetrics, 4)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("result mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// ReasonFailedComputeMetricsReplicas is the event reason used by the HPA when every metric fails to be gathered or
+// evaluated
+const ReasonFailedComputeMetricsReplicas = "FailedComputeMetricsReplicas"
+
+// FailedGetMetricReason returns the event reason used by the HPA when a metric fails, for example
+// "FailedGetResourceMetric" for a resource metric
+func FailedGetMetricReason(spec autoscalingv2.MetricSpec) string {
+	return fmt.Sprintf("FailedGet%sMetric", spec.Type)
+}
+
+// InvalidMetricsMessage returns the HPA's FailedComputeMetricsReplicas event message
+func InvalidMetricsMessage(invalidCount int, totalCount int, firstErr error) string {
+	return fmt.Sprintf("invalid metrics (%d invalid out of %d), first error is: %v", invalidCount, totalCount, firstErr)
+}
+
+// Recorder emits the same events as the HPA on the scale target Object when metrics are gathered and evaluated, so
+// that describing the scale target with kubectl shows why the replica count was chosen.
+type Recorder struct {
+	EventRecorder record.EventRecorder
+	Object        runtime.Object
+}
+
+// NewRecorder sets up a recorder that emits events on the object provided, usually the scale target
+func NewRecorder(eventRecorder record.EventRecorder, object runtime.Object) *Recorder {
+	return &Recorder{
+		EventRecorder: eventRecorder,
+		Object:        object,
+	}
+}
+
+// RecordGatherError emits a warning event for each metric that failed to be gathered in the error provided, which
+// should be returned by the Gatherer. If every metric failed a FailedComputeMetricsReplicas event is also emitted.
+func (r *Recorder) RecordGatherError(err error) {
+	if err == nil {
+		return
+	}
+
+	var multiErr *k8shorizmetrics.GathererMultiMetricError
+	if !errors.As(err, &multiErr) {
+		r.EventRecorder.Event(r.Object, corev1.EventTypeWarning, ReasonFailedComputeMetricsReplicas,
+			InvalidMetricsMessage(1, 1, err))
+		return
+	}
+
+	for _, gatherErr := range multiErr.Errors {
+		var specErr *k8shorizmetrics.MetricSpecError
+		if !errors.As(gatherErr, &specErr) {
+			continue
+		}
+		r.EventRecorder.Event(r.Object, corev1.EventTypeWarning, FailedGetMetricReason(specErr.Spec), specErr.Err.Error())
+	}
+
+	if !multiErr.Partial && len(multiErr.Errors) > 0 {
+		r.EventRecorder.Event(r.Object, corev1.EventTypeWarning, ReasonFailedComputeMetricsReplicas,
+			InvalidMetricsMessage(len(multiErr.Errors), len(multiErr.Errors), multiErr.Errors[0]))
+	}
+}
+
+// Evaluate evaluates the metrics provided in the same way as Evaluate, emitting events describing the evaluation.
+// A warning event is emitted for each metric that fails to be evaluated, and if every metric fails a
+// FailedComputeMetricsReplicas event is emitted. If the evaluation changes the replica count a SuccessfulRescale event
+// is emitted with the new size and reason.
+func (r *Recorder) Evaluate(evaluator *k8shorizmetrics.Evaluator, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32) (*Result, error) {
+	result, err := evaluate(evaluator, gatheredMetrics, currentReplicas, func(gatheredMetric *metrics.Metric, err error) {
+		r.EventRecorder.Event(r.Object, corev1.EventTypeWarning, FailedGetMetricReason(gatheredMetric.Spec), err.Error())
+	})
+	if result == nil {
+		var multiErr *k8shorizmetrics.EvaluatorMultiMetricError
+		if errors.As(err, &multiErr) && len(multiErr.Errors) > 0 {
+			r.EventRecorder.Event(r.Object, corev1.EventTypeWarning, ReasonFailedComputeMetricsReplicas,
+				InvalidMetricsMessage(len(multiErr.Errors), len(gatheredMetrics), multiErr.Errors[0]))
+		}
+		return nil, err
+	}
+
+	if result.Replicas != currentReplicas {
+		r.RecordRescale(result)
+	}
+	return result, err
+}
+
+// RecordRescale emits a SuccessfulRescale event for the result provided
+func (r *Recorder) RecordRescale(result *Result) {
+	r.EventRecorder.Event(r.Object, corev1.EventTypeNormal, ReasonSuccessfulRescale,
+		SuccessfulRescaleMessage(result.Replicas, result.Reason))
+}
+
+// RecordFailedRescale emits a FailedRescale event for the result provided, for use when applying the replica count
+// to the scale target fails
+func (r *Recorder) RecordFailedRescale(result *Result, err error) {
+	r.EventRecorder.Event(r.Object, corev1.EventTypeWarning, ReasonFailedRescale,
+		FailedRescaleMessage(result.Replicas, result.Reason, err))
+}
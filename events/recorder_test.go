@@ -0,0 +1,277 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/events"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// recordedEvents drains the events recorded by the fake recorder provided
+func recordedEvents(recorder *record.FakeRecorder) []string {
+	var recorded []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			recorded = append(recorded, event)
+		default:
+			return recorded
+		}
+	}
+}
+
+func TestRecorderRecordGatherError(t *testing.T) {
+	cpuSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: corev1.ResourceCPU,
+		},
+	}
+	podsSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "requests"},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expected    []string
+		err         error
+	}{
+		{
+			description: "No error, no events",
+		},
+		{
+			description: "Non gatherer error",
+			expected: []string{
+				"Warning FailedComputeMetricsReplicas invalid metrics (1 invalid out of 1), first error is: fail",
+			},
+			err: errors.New("fail"),
+		},
+		{
+			description: "Partial failure, event for failed metric",
+			expected: []string{
+				"Warning FailedGetResourceMetric unable to get metrics for resource cpu",
+			},
+			err: &k8shorizmetrics.GathererMultiMetricError{
+				Partial: true,
+				Errors: []error{
+					&k8shorizmetrics.MetricSpecError{
+						Index: 0,
+						Spec:  cpuSpec,
+						Err:   errors.New("unable to get metrics for resource cpu"),
+					},
+				},
+			},
+		},
+		{
+			description: "All metrics fail, event for each failed metric and failed compute",
+			expected: []string{
+				"Warning FailedGetResourceMetric unable to get metrics for resource cpu",
+				"Warning FailedGetPodsMetric unable to get metric requests",
+				"Warning FailedComputeMetricsReplicas invalid metrics (2 invalid out of 2), first error is: " +
+					"unable to get metrics for resource cpu",
+			},
+			err: &k8shorizmetrics.GathererMultiMetricError{
+				Partial: false,
+				Errors: []error{
+					&k8shorizmetrics.MetricSpecError{
+						Index: 0,
+						Spec:  cpuSpec,
+						Err:   errors.New("unable to get metrics for resource cpu"),
+					},
+					&k8shorizmetrics.MetricSpecError{
+						Index: 1,
+						Spec:  podsSpec,
+						Err:   errors.New("unable to get metric requests"),
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeRecorder := record.NewFakeRecorder(10)
+			recorder := events.NewRecorder(fakeRecorder, &appsv1.Deployment{})
+
+			recorder.RecordGatherError(test.err)
+
+			result := recordedEvents(fakeRecorder)
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("events mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestRecorderEvaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	cpuMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+			},
+		},
+	}
+
+	podsMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "requests"},
+			},
+		},
+	}
+
+	evaluator := func(cpuReplicas int32, cpuErr error, podsReplicas int32) *k8shorizmetrics.Evaluator {
+		return &k8shorizmetrics.Evaluator{
+			Resource: &fake.ResourceEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return cpuReplicas, cpuErr
+				},
+			},
+			Pods: &fake.PodsEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+					return podsReplicas
+				},
+			},
+		}
+	}
+
+	var tests = []struct {
+		description     string
+		expected        *events.Result
+		expectedErr     error
+		expectedEvents  []string
+		evaluator       *k8shorizmetrics.Evaluator
+		gatheredMetrics []*metrics.Metric
+		currentReplicas int32
+	}{
+		{
+			description: "No metrics, no events",
+			expectedErr: errors.New("no metrics provided to evaluate"),
+			evaluator:   evaluator(0, nil, 0),
+		},
+		{
+			description: "All metrics fail",
+			expectedErr: errors.New("evaluator multi metric error: 1 errors, first error is fail"),
+			expectedEvents: []string{
+				"Warning FailedGetResourceMetric fail",
+				"Warning FailedComputeMetricsReplicas invalid metrics (1 invalid out of 1), first error is: fail",
+			},
+			evaluator:       evaluator(0, errors.New("fail"), 0),
+			gatheredMetrics: []*metrics.Metric{cpuMetric},
+			currentReplicas: 2,
+		},
+		{
+			description: "Partial failure, rescale",
+			expected: &events.Result{
+				Replicas:   6,
+				MetricName: "pods metric requests",
+				Reason:     "pods metric requests above target",
+			},
+			expectedErr: errors.New("evaluator multi metric error: 1 errors, first error is fail"),
+			expectedEvents: []string{
+				"Warning FailedGetResourceMetric fail",
+				"Normal SuccessfulRescale New size: 6; reason: pods metric requests above target",
+			},
+			evaluator:       evaluator(0, errors.New("fail"), 6),
+			gatheredMetrics: []*metrics.Metric{cpuMetric, podsMetric},
+			currentReplicas: 2,
+		},
+		{
+			description: "Scale down",
+			expected: &events.Result{
+				Replicas:   1,
+				MetricName: "cpu resource utilization (percentage of request)",
+				Reason:     "All metrics below target",
+			},
+			expectedEvents: []string{
+				"Normal SuccessfulRescale New size: 1; reason: All metrics below target",
+			},
+			evaluator:       evaluator(1, nil, 1),
+			gatheredMetrics: []*metrics.Metric{cpuMetric, podsMetric},
+			currentReplicas: 4,
+		},
+		{
+			description: "No change, no events",
+			expected: &events.Result{
+				Replicas:   4,
+				MetricName: "cpu resource utilization (percentage of request)",
+			},
+			evaluator:       evaluator(4, nil, 3),
+			gatheredMetrics: []*metrics.Metric{cpuMetric, podsMetric},
+			currentReplicas: 4,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeRecorder := record.NewFakeRecorder(10)
+			recorder := events.NewRecorder(fakeRecorder, &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			})
+
+			result, err := recorder.Evaluate(test.evaluator, test.gatheredMetrics, test.currentReplicas)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("result mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+			recorded := recordedEvents(fakeRecorder)
+			if !cmp.Equal(test.expectedEvents, recorded) {
+				t.Errorf("events mismatch (-want +got):\n%s", cmp.Diff(test.expectedEvents, recorded))
+			}
+		})
+	}
+}
+
+func TestRecorderRecordFailedRescale(t *testing.T) {
+	fakeRecorder := record.NewFakeRecorder(10)
+	recorder := events.NewRecorder(fakeRecorder, &appsv1.Deployment{})
+
+	recorder.RecordFailedRescale(&events.Result{
+		Replicas: 3,
+		Reason:   "All metrics below target",
+	}, errors.New("fail"))
+
+	expected := []string{"Warning FailedRescale New size: 3; reason: All metrics below target; error: fail"}
+	recorded := recordedEvents(fakeRecorder)
+	if !cmp.Equal(expected, recorded) {
+		t.Errorf("events mismatch (-want +got):\n%s", cmp.Diff(expected, recorded))
+	}
+}
@@ -6,9 +6,9 @@ toolchain go1.22.2
 
 require (
 	github.com/jthomperoo/k8shorizmetrics/v4 v4.0.0
-	k8s.io/api v0.30.0
-	k8s.io/apimachinery v0.30.0
-	k8s.io/client-go v0.30.0
+	k8s.io/api v0.30.1
+	k8s.io/apimachinery v0.30.1
+	k8s.io/client-go v0.30.1
 )
 
 require (
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"log"
 	"path/filepath"
 	"time"
@@ -82,17 +83,17 @@ func main() {
 		},
 	}
 
-	// Loop infinitely, wait 5 seconds between each loop
-	for {
-		time.Sleep(5 * time.Second)
-
-		// Gather the metrics using the specs, targeting the namespace and pod selector defined above
-		metric, err := gather.GatherSingleMetric(spec, namespace, podMatchSelector)
-		if err != nil {
-			log.Println(err)
+	// Gather the metrics every 5 seconds using the spec, targeting the namespace and pod selector defined above, until
+	// the program is stopped
+	snapshots := gather.Watch(context.Background(), []v2.MetricSpec{spec}, namespace, podMatchSelector, 5*time.Second)
+	for snapshot := range snapshots {
+		if snapshot.Err != nil {
+			log.Println(snapshot.Err)
 			continue
 		}
 
+		metric := snapshot.Metrics[0]
+
 		log.Println("CPU metrics:")
 
 		for pod, podmetric := range metric.Resource.PodMetricsInfo {
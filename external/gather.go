@@ -28,11 +28,14 @@ https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscale
 package external
 
 import (
+	"context"
 	"fmt"
+	"time"
 
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/podutil"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	metricsclient "github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
@@ -47,6 +50,11 @@ type Gather struct {
 
 // Gather retrieves an external metric
 func (c *Gather) Gather(metricName, namespace string, metricSelector *metav1.LabelSelector, podSelector labels.Selector) (*external.Metric, error) {
+	return c.GatherWithContext(context.Background(), metricName, namespace, metricSelector, podSelector)
+}
+
+// GatherWithContext retrieves an external metric, passing the context provided to the metrics client
+func (c *Gather) GatherWithContext(ctx context.Context, metricName, namespace string, metricSelector *metav1.LabelSelector, podSelector labels.Selector) (*external.Metric, error) {
 	// Convert selector to expected type
 	metricLabelSelector, err := metav1.LabelSelectorAsSelector(metricSelector)
 	if err != nil {
@@ -54,17 +62,23 @@ func (c *Gather) Gather(metricName, namespace string, metricSelector *metav1.Lab
 	}
 
 	// Get metrics
-	gathered, timestamp, err := c.MetricsClient.GetExternalMetric(metricName, namespace, metricLabelSelector)
+	start := time.Now()
+	gathered, timestamp, err := metricsclient.WithContext(c.MetricsClient).GetExternalMetricWithContext(ctx, metricName, namespace, metricLabelSelector)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get external metric %s/%s/%+v: %w", namespace, metricName, metricSelector, err)
 	}
+	metricProvenance := &provenance.Provenance{
+		Source:    provenance.SourceExternal,
+		Duration:  time.Since(start),
+		ItemCount: len(gathered),
+	}
 	utilization := int64(0)
 	for _, val := range gathered {
 		utilization = utilization + val
 	}
 
 	// Calculate number of ready pods
-	readyPodCount, err := c.PodReadyCounter.GetReadyPodsCount(namespace, podSelector)
+	readyPodCount, err := podutil.GetReadyPodsCountWithContext(ctx, c.PodReadyCounter, namespace, podSelector)
 	if err != nil {
 		return nil, fmt.Errorf("unable to calculate ready pods: %w", err)
 	}
@@ -75,11 +89,17 @@ func (c *Gather) Gather(metricName, namespace string, metricSelector *metav1.Lab
 		},
 		ReadyPodCount: &readyPodCount,
 		Timestamp:     timestamp,
+		Provenance:    metricProvenance,
 	}, nil
 }
 
 // GatherPerPod retrieves an external per pod metric
 func (c *Gather) GatherPerPod(metricName, namespace string, metricSelector *metav1.LabelSelector) (*external.Metric, error) {
+	return c.GatherPerPodWithContext(context.Background(), metricName, namespace, metricSelector)
+}
+
+// GatherPerPodWithContext retrieves an external per pod metric, passing the context provided to the metrics client
+func (c *Gather) GatherPerPodWithContext(ctx context.Context, metricName, namespace string, metricSelector *metav1.LabelSelector) (*external.Metric, error) {
 	// Convert selector to expected type
 	metricLabelSelector, err := metav1.LabelSelectorAsSelector(metricSelector)
 	if err != nil {
@@ -87,10 +107,16 @@ func (c *Gather) GatherPerPod(metricName, namespace string, metricSelector *meta
 	}
 
 	// Get metrics
-	gathered, timestamp, err := c.MetricsClient.GetExternalMetric(metricName, namespace, metricLabelSelector)
+	start := time.Now()
+	gathered, timestamp, err := metricsclient.WithContext(c.MetricsClient).GetExternalMetricWithContext(ctx, metricName, namespace, metricLabelSelector)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get external metric %s/%s/%+v: %w", namespace, metricName, metricSelector, err)
 	}
+	metricProvenance := &provenance.Provenance{
+		Source:    provenance.SourceExternal,
+		Duration:  time.Since(start),
+		ItemCount: len(gathered),
+	}
 
 	// Calculate utilization total for pods
 	utilization := int64(0)
@@ -102,6 +128,7 @@ func (c *Gather) GatherPerPod(metricName, namespace string, metricSelector *meta
 		Current: value.MetricValue{
 			AverageValue: &utilization,
 		},
-		Timestamp: timestamp,
+		Timestamp:  timestamp,
+		Provenance: metricProvenance,
 	}, nil
 }
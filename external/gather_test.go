@@ -22,13 +22,15 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/external"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/fake"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/podutil"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jthomperoo/k8shorizmetrics/v4/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
 	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
 	externalmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
 	metricsclient "github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
@@ -40,6 +42,7 @@ func TestGather(t *testing.T) {
 		}
 		return x.Error() == y.Error()
 	})
+	ignoreDuration := cmpopts.IgnoreFields(provenance.Provenance{}, "Duration")
 
 	var tests = []struct {
 		description     string
@@ -110,6 +113,10 @@ func TestGather(t *testing.T) {
 				Current: value.MetricValue{
 					Value: testutil.Int64Ptr(15),
 				},
+				Provenance: &provenance.Provenance{
+					Source:    provenance.SourceExternal,
+					ItemCount: 5,
+				},
 			},
 			nil,
 			&fake.MetricsClient{
@@ -139,8 +146,8 @@ func TestGather(t *testing.T) {
 				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
 				return
 			}
-			if !cmp.Equal(test.expected, metric) {
-				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric))
+			if !cmp.Equal(test.expected, metric, ignoreDuration) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric, ignoreDuration))
 			}
 		})
 	}
@@ -153,6 +160,7 @@ func TestGatherPerPod(t *testing.T) {
 		}
 		return x.Error() == y.Error()
 	})
+	ignoreDuration := cmpopts.IgnoreFields(provenance.Provenance{}, "Duration")
 
 	var tests = []struct {
 		description     string
@@ -200,6 +208,10 @@ func TestGatherPerPod(t *testing.T) {
 				Current: value.MetricValue{
 					AverageValue: testutil.Int64Ptr(15),
 				},
+				Provenance: &provenance.Provenance{
+					Source:    provenance.SourceExternal,
+					ItemCount: 5,
+				},
 			},
 			nil,
 			&fake.MetricsClient{
@@ -224,8 +236,8 @@ func TestGatherPerPod(t *testing.T) {
 				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
 				return
 			}
-			if !cmp.Equal(test.expected, metric) {
-				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric))
+			if !cmp.Equal(test.expected, metric, ignoreDuration) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric, ignoreDuration))
 			}
 		})
 	}
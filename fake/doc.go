@@ -0,0 +1,20 @@
+/*
+Copyright 2022 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides reactor based fakes of the k8shorizmetrics Gatherer and Evaluator, the metric source
+// gatherers and evaluaters they are built from, and the Kubernetes clients and listers they use, for unit testing
+// code built on k8shorizmetrics. Each fake calls the reactor function set for the method being called.
+package fake
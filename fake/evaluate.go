@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+)
+
+// ExternalEvaluater (fake) provides a way to insert functionality into a ExternalEvaluater
+type ExternalEvaluater struct {
+	EvaluateReactor func(currentReplicas int32, gatheredMetric *metrics.Metric,
+		tolerance float64) (int32, error)
+}
+
+// Evaluate calls the fake ExternalEvaluater function
+func (f *ExternalEvaluater) Evaluate(currentReplicas int32, gatheredMetric *metrics.Metric,
+	tolerance float64) (int32, error) {
+	return f.EvaluateReactor(currentReplicas, gatheredMetric, tolerance)
+}
+
+// ObjectEvaluater (fake) provides a way to insert functionality into a ObjectEvaluater
+type ObjectEvaluater struct {
+	EvaluateReactor func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error)
+}
+
+// Evaluate calls the fake ObjectEvaluater function
+func (f *ObjectEvaluater) Evaluate(currentReplicas int32, gatheredMetric *metrics.Metric,
+	tolerance float64) (int32, error) {
+	return f.EvaluateReactor(currentReplicas, gatheredMetric, tolerance)
+}
+
+// PodsEvaluater (fake) provides a way to insert functionality into a PodsEvaluater
+type PodsEvaluater struct {
+	EvaluateReactor func(currentReplicas int32, gatheredMetric *metrics.Metric) int32
+}
+
+// Evaluate calls the fake PodsEvaluater function
+func (f *PodsEvaluater) Evaluate(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+	return f.EvaluateReactor(currentReplicas, gatheredMetric)
+}
+
+// ResourceEvaluater (fake) provides a way to insert functionality into a ResourceEvaluater
+type ResourceEvaluater struct {
+	EvaluateReactor func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error)
+}
+
+// Evaluate calls the fake ResourceEvaluater function
+func (f *ResourceEvaluater) Evaluate(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+	return f.EvaluateReactor(currentReplicas, gatheredMetric, tolerance)
+}
+
+// ExternalEvaluaterWithContext (fake) provides a way to insert functionality into a ExternalEvaluaterWithContext
+type ExternalEvaluaterWithContext struct {
+	ExternalEvaluater
+	EvaluateWithContextReactor func(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric,
+		tolerance float64) (int32, error)
+}
+
+// EvaluateWithContext calls the fake ExternalEvaluaterWithContext function
+func (f *ExternalEvaluaterWithContext) EvaluateWithContext(ctx context.Context, currentReplicas int32,
+	gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+	return f.EvaluateWithContextReactor(ctx, currentReplicas, gatheredMetric, tolerance)
+}
+
+// ObjectEvaluaterWithContext (fake) provides a way to insert functionality into a ObjectEvaluaterWithContext
+type ObjectEvaluaterWithContext struct {
+	ObjectEvaluater
+	EvaluateWithContextReactor func(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric,
+		tolerance float64) (int32, error)
+}
+
+// EvaluateWithContext calls the fake ObjectEvaluaterWithContext function
+func (f *ObjectEvaluaterWithContext) EvaluateWithContext(ctx context.Context, currentReplicas int32,
+	gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+	return f.EvaluateWithContextReactor(ctx, currentReplicas, gatheredMetric, tolerance)
+}
+
+// PodsEvaluaterWithContext (fake) provides a way to insert functionality into a PodsEvaluaterWithContext
+type PodsEvaluaterWithContext struct {
+	PodsEvaluater
+	EvaluateWithContextReactor func(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric) int32
+}
+
+// EvaluateWithContext calls the fake PodsEvaluaterWithContext function
+func (f *PodsEvaluaterWithContext) EvaluateWithContext(ctx context.Context, currentReplicas int32,
+	gatheredMetric *metrics.Metric) int32 {
+	return f.EvaluateWithContextReactor(ctx, currentReplicas, gatheredMetric)
+}
+
+// PodsEvaluaterWithError (fake) provides a way to insert functionality into a PodsEvaluaterWithError
+type PodsEvaluaterWithError struct {
+	PodsEvaluater
+	EvaluateWithErrorReactor func(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric) (int32, error)
+}
+
+// EvaluateWithError calls the fake PodsEvaluaterWithError function
+func (f *PodsEvaluaterWithError) EvaluateWithError(ctx context.Context, currentReplicas int32,
+	gatheredMetric *metrics.Metric) (int32, error) {
+	return f.EvaluateWithErrorReactor(ctx, currentReplicas, gatheredMetric)
+}
+
+// ResourceEvaluaterWithContext (fake) provides a way to insert functionality into a ResourceEvaluaterWithContext
+type ResourceEvaluaterWithContext struct {
+	ResourceEvaluater
+	EvaluateWithContextReactor func(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric,
+		tolerance float64) (int32, error)
+}
+
+// EvaluateWithContext calls the fake ResourceEvaluaterWithContext function
+func (f *ResourceEvaluaterWithContext) EvaluateWithContext(ctx context.Context, currentReplicas int32,
+	gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+	return f.EvaluateWithContextReactor(ctx, currentReplicas, gatheredMetric, tolerance)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package fake
 
 import (
+	"context"
 	"time"
 
 	externalmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
@@ -104,3 +105,86 @@ func (f *ResourceGatherer) GatherRaw(resourceName corev1.ResourceName, namespace
 	delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error) {
 	return f.GatherRawReactor(resourceName, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
 }
+
+// ExternalGathererWithContext (fake) provides a way to insert functionality into a ExternalGathererWithContext
+type ExternalGathererWithContext struct {
+	ExternalGatherer
+	GatherWithContextReactor func(ctx context.Context, metricName, namespace string, metricSelector *metav1.LabelSelector,
+		podSelector labels.Selector) (*externalmetrics.Metric, error)
+	GatherPerPodWithContextReactor func(ctx context.Context, metricName, namespace string,
+		metricSelector *metav1.LabelSelector) (*externalmetrics.Metric, error)
+}
+
+// GatherWithContext calls the fake ExternalGathererWithContext function
+func (f *ExternalGathererWithContext) GatherWithContext(ctx context.Context, metricName, namespace string,
+	metricSelector *metav1.LabelSelector, podSelector labels.Selector) (*externalmetrics.Metric, error) {
+	return f.GatherWithContextReactor(ctx, metricName, namespace, metricSelector, podSelector)
+}
+
+// GatherPerPodWithContext calls the fake ExternalGathererWithContext function
+func (f *ExternalGathererWithContext) GatherPerPodWithContext(ctx context.Context, metricName, namespace string,
+	metricSelector *metav1.LabelSelector) (*externalmetrics.Metric, error) {
+	return f.GatherPerPodWithContextReactor(ctx, metricName, namespace, metricSelector)
+}
+
+// ObjectGathererWithContext (fake) provides a way to insert functionality into a ObjectGathererWithContext
+type ObjectGathererWithContext struct {
+	ObjectGatherer
+	GatherWithContextReactor func(ctx context.Context, metricName string, namespace string,
+		objectRef *autoscalingv2.CrossVersionObjectReference, podSelector labels.Selector,
+		metricSelector labels.Selector) (*objectmetrics.Metric, error)
+	GatherPerPodWithContextReactor func(ctx context.Context, metricName string, namespace string,
+		objectRef *autoscalingv2.CrossVersionObjectReference, metricSelector labels.Selector) (*objectmetrics.Metric, error)
+}
+
+// GatherWithContext calls the fake ObjectGathererWithContext function
+func (f *ObjectGathererWithContext) GatherWithContext(ctx context.Context, metricName string, namespace string,
+	objectRef *autoscalingv2.CrossVersionObjectReference, podSelector labels.Selector,
+	metricSelector labels.Selector) (*objectmetrics.Metric, error) {
+	return f.GatherWithContextReactor(ctx, metricName, namespace, objectRef, podSelector, metricSelector)
+}
+
+// GatherPerPodWithContext calls the fake ObjectGathererWithContext function
+func (f *ObjectGathererWithContext) GatherPerPodWithContext(ctx context.Context, metricName string, namespace string,
+	objectRef *autoscalingv2.CrossVersionObjectReference,
+	metricSelector labels.Selector) (*objectmetrics.Metric, error) {
+	return f.GatherPerPodWithContextReactor(ctx, metricName, namespace, objectRef, metricSelector)
+}
+
+// PodsGathererWithContext (fake) provides a way to insert functionality into a PodsGathererWithContext
+type PodsGathererWithContext struct {
+	PodsGatherer
+	GatherWithContextReactor func(ctx context.Context, metricName string, namespace string, podSelector labels.Selector,
+		metricSelector labels.Selector) (*podsmetrics.Metric, error)
+}
+
+// GatherWithContext calls the fake PodsGathererWithContext function
+func (f *PodsGathererWithContext) GatherWithContext(ctx context.Context, metricName string, namespace string,
+	podSelector labels.Selector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+	return f.GatherWithContextReactor(ctx, metricName, namespace, podSelector, metricSelector)
+}
+
+// ResourceGathererWithContext (fake) provides a way to insert functionality into a ResourceGathererWithContext
+type ResourceGathererWithContext struct {
+	ResourceGatherer
+	GatherWithContextReactor func(ctx context.Context, resourceName corev1.ResourceName, namespace string,
+		podSelector labels.Selector, cpuInitializationPeriod time.Duration,
+		delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error)
+	GatherRawWithContextReactor func(ctx context.Context, resourceName corev1.ResourceName, namespace string,
+		podSelector labels.Selector, cpuInitializationPeriod time.Duration,
+		delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error)
+}
+
+// GatherWithContext calls the fake ResourceGathererWithContext function
+func (f *ResourceGathererWithContext) GatherWithContext(ctx context.Context, resourceName corev1.ResourceName,
+	namespace string, podSelector labels.Selector, cpuInitializationPeriod time.Duration,
+	delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error) {
+	return f.GatherWithContextReactor(ctx, resourceName, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+}
+
+// GatherRawWithContext calls the fake ResourceGathererWithContext function
+func (f *ResourceGathererWithContext) GatherRawWithContext(ctx context.Context, resourceName corev1.ResourceName,
+	namespace string, podSelector labels.Selector, cpuInitializationPeriod time.Duration,
+	delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error) {
+	return f.GatherRawWithContextReactor(ctx, resourceName, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+}
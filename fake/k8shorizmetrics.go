@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Gatherer (fake) provides a way to insert functionality into code that gathers metrics using a
+// k8shorizmetrics.Gatherer. If a context aware reactor is not set the context aware method falls back to the reactor
+// without a context.
+type Gatherer struct {
+	GatherReactor                        func(specs []autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) ([]*metrics.Metric, error)
+	GatherWithContextReactor             func(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) ([]*metrics.Metric, error)
+	GatherSingleMetricReactor            func(spec autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) (*metrics.Metric, error)
+	GatherSingleMetricWithContextReactor func(ctx context.Context, spec autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) (*metrics.Metric, error)
+}
+
+// Gather calls the fake Gatherer function
+func (f *Gatherer) Gather(specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) ([]*metrics.Metric, error) {
+	return f.GatherReactor(specs, namespace, podSelector)
+}
+
+// GatherWithContext calls the fake Gatherer function
+func (f *Gatherer) GatherWithContext(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) ([]*metrics.Metric, error) {
+	if f.GatherWithContextReactor == nil {
+		return f.GatherReactor(specs, namespace, podSelector)
+	}
+	return f.GatherWithContextReactor(ctx, specs, namespace, podSelector)
+}
+
+// GatherSingleMetric calls the fake Gatherer function
+func (f *Gatherer) GatherSingleMetric(spec autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) (*metrics.Metric, error) {
+	return f.GatherSingleMetricReactor(spec, namespace, podSelector)
+}
+
+// GatherSingleMetricWithContext calls the fake Gatherer function
+func (f *Gatherer) GatherSingleMetricWithContext(ctx context.Context, spec autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) (*metrics.Metric, error) {
+	if f.GatherSingleMetricWithContextReactor == nil {
+		return f.GatherSingleMetricReactor(spec, namespace, podSelector)
+	}
+	return f.GatherSingleMetricWithContextReactor(ctx, spec, namespace, podSelector)
+}
+
+// Evaluator (fake) provides a way to insert functionality into code that evaluates metrics using a
+// k8shorizmetrics.Evaluator. If a context aware reactor is not set the context aware method falls back to the reactor
+// without a context.
+type Evaluator struct {
+	EvaluateReactor                        func(gatheredMetrics []*metrics.Metric, currentReplicas int32) (int32, error)
+	EvaluateWithContextReactor             func(ctx context.Context, gatheredMetrics []*metrics.Metric, currentReplicas int32) (int32, error)
+	EvaluateSingleMetricReactor            func(gatheredMetric *metrics.Metric, currentReplicas int32) (int32, error)
+	EvaluateSingleMetricWithContextReactor func(ctx context.Context, gatheredMetric *metrics.Metric, currentReplicas int32) (int32, error)
+}
+
+// Evaluate calls the fake Evaluator function
+func (f *Evaluator) Evaluate(gatheredMetrics []*metrics.Metric, currentReplicas int32) (int32, error) {
+	return f.EvaluateReactor(gatheredMetrics, currentReplicas)
+}
+
+// EvaluateWithContext calls the fake Evaluator function
+func (f *Evaluator) EvaluateWithContext(ctx context.Context, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32) (int32, error) {
+	if f.EvaluateWithContextReactor == nil {
+		return f.EvaluateReactor(gatheredMetrics, currentReplicas)
+	}
+	return f.EvaluateWithContextReactor(ctx, gatheredMetrics, currentReplicas)
+}
+
+// EvaluateSingleMetric calls the fake Evaluator function
+func (f *Evaluator) EvaluateSingleMetric(gatheredMetric *metrics.Metric, currentReplicas int32) (int32, error) {
+	return f.EvaluateSingleMetricReactor(gatheredMetric, currentReplicas)
+}
+
+// EvaluateSingleMetricWithContext calls the fake Evaluator function
+func (f *Evaluator) EvaluateSingleMetricWithContext(ctx context.Context, gatheredMetric *metrics.Metric,
+	currentReplicas int32) (int32, error) {
+	if f.EvaluateSingleMetricWithContextReactor == nil {
+		return f.EvaluateSingleMetricReactor(gatheredMetric, currentReplicas)
+	}
+	return f.EvaluateSingleMetricWithContextReactor(ctx, gatheredMetric, currentReplicas)
+}
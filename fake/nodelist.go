@@ -0,0 +1,38 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// NodeLister (fake) provides a way to insert functionality into a NodeLister
+type NodeLister struct {
+	ListReactor func(selector labels.Selector) (ret []*corev1.Node, err error)
+	GetReactor  func(name string) (*corev1.Node, error)
+}
+
+// List calls the fake NodeLister function
+func (f *NodeLister) List(selector labels.Selector) (ret []*corev1.Node, err error) {
+	return f.ListReactor(selector)
+}
+
+// Get calls the fake NodeLister function
+func (f *NodeLister) Get(name string) (*corev1.Node, error) {
+	return f.GetReactor(name)
+}
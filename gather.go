@@ -28,31 +28,41 @@ https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscale
 package k8shorizmetrics
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/external"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/object"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/pods"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/podutil"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/resource"
+	"github.com/go-logr/logr"
+	"github.com/jthomperoo/k8shorizmetrics/v4/convert"
+	"github.com/jthomperoo/k8shorizmetrics/v4/external"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
 	externalmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
 	objectmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/object"
 	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
 	resourcemetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
 	metricsclient "github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/resource"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	k8sscale "k8s.io/client-go/scale"
+	"k8s.io/utils/clock"
 )
 
 // GathererMultiMetricError occurs when gathering multiple metrics, if any metric fails to be gathered this error will
 // be returned which contains all of the individual errors in the 'Errors' slice, if some metrics were gathered
-// successfully the error will have the 'Partial' property set to true.
+// successfully the error will have the 'Partial' property set to true. Each individual error is a MetricSpecError
+// identifying the metric spec which failed.
 type GathererMultiMetricError struct {
 	Partial bool
 	Errors  []error
@@ -62,6 +72,40 @@ func (e *GathererMultiMetricError) Error() string {
 	return fmt.Sprintf("gatherer multi metric error: %d errors, first error is %s", len(e.Errors), e.Errors[0])
 }
 
+// Unwrap returns the individual errors, allowing errors.Is and errors.As to match against any of them
+func (e *GathererMultiMetricError) Unwrap() []error {
+	return e.Errors
+}
+
+// MetricSpecErrors returns the errors which are associated with the metric spec that failed to be gathered
+func (e *GathererMultiMetricError) MetricSpecErrors() []*MetricSpecError {
+	specErrors := []*MetricSpecError{}
+	for _, err := range e.Errors {
+		var specErr *MetricSpecError
+		if errors.As(err, &specErr) {
+			specErrors = append(specErrors, specErr)
+		}
+	}
+	return specErrors
+}
+
+// MetricSpecError occurs when a metric spec fails to be gathered as part of gathering multiple metrics, it associates
+// the error with the metric spec that failed and its index in the metric specs provided. The error message is the
+// message of the underlying error.
+type MetricSpecError struct {
+	Index int
+	Spec  autoscalingv2.MetricSpec
+	Err   error
+}
+
+func (e *MetricSpecError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *MetricSpecError) Unwrap() error {
+	return e.Err
+}
+
 // ExternalGatherer allows retrieval of external metrics.
 type ExternalGatherer interface {
 	Gather(metricName, namespace string, metricSelector *metav1.LabelSelector, podSelector labels.Selector) (*externalmetrics.Metric, error)
@@ -87,15 +131,66 @@ type ResourceGatherer interface {
 		cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error)
 }
 
-// Gatherer provides functionality for retrieving metrics on supplied metric specs.
+// ExternalGathererWithContext allows retrieval of external metrics, accepting a context which can be used to cancel
+// requests. If an ExternalGatherer also implements this interface the Gatherer will prefer these methods.
+type ExternalGathererWithContext interface {
+	GatherWithContext(ctx context.Context, metricName, namespace string, metricSelector *metav1.LabelSelector, podSelector labels.Selector) (*externalmetrics.Metric, error)
+	GatherPerPodWithContext(ctx context.Context, metricName, namespace string, metricSelector *metav1.LabelSelector) (*externalmetrics.Metric, error)
+}
+
+// ObjectGathererWithContext allows retrieval of object metrics, accepting a context which can be used to cancel
+// requests. If an ObjectGatherer also implements this interface the Gatherer will prefer these methods.
+type ObjectGathererWithContext interface {
+	GatherWithContext(ctx context.Context, metricName string, namespace string, objectRef *autoscalingv2.CrossVersionObjectReference, podSelector labels.Selector, metricSelector labels.Selector) (*objectmetrics.Metric, error)
+	GatherPerPodWithContext(ctx context.Context, metricName string, namespace string, objectRef *autoscalingv2.CrossVersionObjectReference, metricSelector labels.Selector) (*objectmetrics.Metric, error)
+}
+
+// PodsGathererWithContext allows retrieval of pods metrics, accepting a context which can be used to cancel requests.
+// If a PodsGatherer also implements this interface the Gatherer will prefer this method.
+type PodsGathererWithContext interface {
+	GatherWithContext(ctx context.Context, metricName string, namespace string, podSelector labels.Selector, metricSelector labels.Selector) (*podsmetrics.Metric, error)
+}
+
+// ResourceGathererWithContext allows retrieval of resource metrics, accepting a context which can be used to cancel
+// requests. If a ResourceGatherer also implements this interface the Gatherer will prefer these methods.
+type ResourceGathererWithContext interface {
+	GatherWithContext(ctx context.Context, resourceName corev1.ResourceName, namespace string, podSelector labels.Selector,
+		cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error)
+	GatherRawWithContext(ctx context.Context, resourceName corev1.ResourceName, namespace string, podSelector labels.Selector,
+		cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error)
+}
+
+// Gatherer provides functionality for retrieving metrics on supplied metric specs. Concurrency is the maximum number of
+// metric specs gathered at once when gathering multiple metrics, if 0 or 1 metric specs are gathered sequentially.
+// Sources are gatherers for additional metric source types, keyed by the metric source type, see RegisterSource.
+// ScaleClient and RESTMapper are used to look up the scale subresource of scale targets, see GatherForTarget.
+// If Retry is set metrics which fail to be gathered with a retriable error are retried, see RetryPolicy.
+// The age of the oldest sample of each gathered metric is recorded, and if MaxAge is greater than 0 any gathered metric
+// with a sample older than MaxAge is marked as stale, or if RejectStale is set fails to be gathered with a
+// StaleMetricError. Clock is used to calculate the age of metrics and
+// the timing of GatherResults, if nil the real clock is used.
+// PreGatherHooks and PostGatherHooks are called before and after each metric spec is gathered, see PreGatherHook and
+// PostGatherHook.
+// Logger is used to log debug and trace information about each metric gathered, see LogLevelDebug and LogLevelTrace.
+// If not set the logger from the context is used, and if there is none nothing is logged.
 type Gatherer struct {
 	Resource                      ResourceGatherer
 	Pods                          PodsGatherer
 	Object                        ObjectGatherer
 	External                      ExternalGatherer
 	ScaleClient                   k8sscale.ScalesGetter
+	RESTMapper                    meta.RESTMapper
 	CPUInitializationPeriod       time.Duration
 	DelayOfInitialReadinessStatus time.Duration
+	Concurrency                   int
+	Sources                       map[autoscalingv2.MetricSourceType]SourceGatherer
+	Retry                         *RetryPolicy
+	MaxAge                        time.Duration
+	RejectStale                   bool
+	Clock                         clock.PassiveClock
+	PreGatherHooks                []PreGatherHook
+	PostGatherHooks               []PostGatherHook
+	Logger                        logr.Logger
 }
 
 // NewGatherer sets up a new Metric Gatherer
@@ -104,13 +199,21 @@ func NewGatherer(
 	podlister corelisters.PodLister,
 	cpuInitializationPeriod time.Duration,
 	delayOfInitialReadinessStatus time.Duration) *Gatherer {
+	return NewGathererWithOptions(metricsclient, podlister,
+		WithCPUInitializationPeriod(cpuInitializationPeriod),
+		WithDelayOfInitialReadinessStatus(delayOfInitialReadinessStatus))
+}
 
+// NewGathererWithOptions sets up a new Metric Gatherer configured with the options provided, any configuration not
+// provided is left as the zero value
+func NewGathererWithOptions(metricsclient metricsclient.Client, podlister corelisters.PodLister,
+	options ...GathererOption) *Gatherer {
 	// Set up pod ready counter
 	podReadyCounter := &podutil.PodReadyCount{
 		PodLister: podlister,
 	}
 
-	return &Gatherer{
+	gatherer := &Gatherer{
 		Resource: &resource.Gather{
 			MetricsClient: metricsclient,
 			PodLister:     podlister,
@@ -127,9 +230,13 @@ func NewGatherer(
 			MetricsClient:   metricsclient,
 			PodReadyCounter: podReadyCounter,
 		},
-		CPUInitializationPeriod:       cpuInitializationPeriod,
-		DelayOfInitialReadinessStatus: delayOfInitialReadinessStatus,
 	}
+
+	for _, option := range options {
+		option(gatherer)
+	}
+
+	return gatherer
 }
 
 // Gather returns all of the metrics gathered based on the metric specs provided.
@@ -146,19 +253,99 @@ func (c *Gatherer) Gather(specs []autoscalingv2.MetricSpec, namespace string, po
 // set to true.
 func (c *Gatherer) GatherWithOptions(specs []autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector,
 	cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration) ([]*metrics.Metric, error) {
+	return c.gather(context.Background(), specs, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus, c.Concurrency)
+}
+
+// GatherWithContext returns all of the metrics gathered based on the metric specs provided, passing the context
+// provided to any gatherers that implement the context aware gatherer interfaces.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError. If a partial error occurs,
+// meaning some metrics were gathered successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (c *Gatherer) GatherWithContext(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) ([]*metrics.Metric, error) {
+	return c.gather(ctx, specs, namespace, podSelector, c.CPUInitializationPeriod, c.DelayOfInitialReadinessStatus, c.Concurrency)
+}
+
+// GatherWithContextAndOptions returns all of the metrics gathered based on the metric specs provided with options,
+// passing the context provided to any gatherers that implement the context aware gatherer interfaces.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError. If a partial error occurs,
+// meaning some metrics were gathered successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (c *Gatherer) GatherWithContextAndOptions(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector, cpuInitializationPeriod time.Duration,
+	delayOfInitialReadinessStatus time.Duration) ([]*metrics.Metric, error) {
+	return c.gather(ctx, specs, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus, c.Concurrency)
+}
+
+// GatherWithGatherOptions returns all of the metrics gathered based on the metric specs provided with the options
+// provided, passing the context provided to any gatherers that implement the context aware gatherer interfaces. Any
+// options left as the zero value use the Gatherer's configuration.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError. If a partial error occurs,
+// meaning some metrics were gathered successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (c *Gatherer) GatherWithGatherOptions(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector, options GatherOptions) ([]*metrics.Metric, error) {
+	options = c.defaultOptions(options)
+	return c.gather(ctx, specs, namespace, podSelector, options.CPUInitializationPeriod,
+		options.DelayOfInitialReadinessStatus, options.Concurrency)
+}
+
+func (c *Gatherer) gather(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector,
+	cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration, concurrency int) ([]*metrics.Metric, error) {
+	results := c.gatherResults(ctx, specs, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus,
+		concurrency)
+
+	gathered := make([]*metrics.Metric, len(results))
+	errs := make([]error, len(results))
+	for i, result := range results {
+		gathered[i], errs[i] = result.Metric, result.Err
+	}
+
+	return combineGathered(specs, gathered, errs)
+}
+
+// gatherConcurrently calls gather for each index up to n, if concurrency is greater than 1 the calls are made
+// concurrently with at most concurrency calls in flight at once, otherwise they are made sequentially
+func gatherConcurrently(n int, concurrency int, gather func(i int)) {
+	if concurrency <= 1 || n <= 1 {
+		for i := 0; i < n; i++ {
+			gather(i)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, concurrency)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-limit }()
+			gather(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// combineGathered combines the results of gathering multiple metric specs in the order of the specs, regardless of the
+// order they were gathered in, returning a GathererMultiMetricError if any failed
+func combineGathered(specs []autoscalingv2.MetricSpec, gathered []*metrics.Metric, errs []error) ([]*metrics.Metric, error) {
 	combinedMetrics := []*metrics.Metric{}
 	gatherErrors := []error{}
-	for _, spec := range specs {
-		metric, err := c.GatherSingleMetricWithOptions(spec, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
-		if err != nil {
-			gatherErrors = append(gatherErrors, err)
+	for i := range gathered {
+		if errs[i] != nil {
+			gatherErrors = append(gatherErrors, &MetricSpecError{
+				Index: i,
+				Spec:  specs[i],
+				Err:   errs[i],
+			})
 			continue
 		}
-		combinedMetrics = append(combinedMetrics, metric)
+		combinedMetrics = append(combinedMetrics, gathered[i])
 	}
 
 	if len(gatherErrors) > 0 {
-		partial := len(gatherErrors) < len(specs)
+		partial := len(gatherErrors) < len(gathered)
 		if partial {
 			return combinedMetrics, &GathererMultiMetricError{
 				Partial: partial,
@@ -175,14 +362,126 @@ func (c *Gatherer) GatherWithOptions(specs []autoscalingv2.MetricSpec, namespace
 	return combinedMetrics, nil
 }
 
+// GatherV2Beta2 returns all of the metrics gathered based on the autoscaling/v2beta2 metric specs provided, converting
+// them to autoscaling/v2 metric specs before gathering. The gathered metrics will contain the converted
+// autoscaling/v2 specs, so they can be evaluated as normal.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError.
+func (c *Gatherer) GatherV2Beta2(specs []autoscalingv2beta2.MetricSpec, namespace string, podSelector labels.Selector) ([]*metrics.Metric, error) {
+	return c.GatherV2Beta2WithContext(context.Background(), specs, namespace, podSelector)
+}
+
+// GatherV2Beta2WithContext returns all of the metrics gathered based on the autoscaling/v2beta2 metric specs
+// provided, converting them to autoscaling/v2 metric specs before gathering and passing the context provided to any
+// gatherers that implement the context aware gatherer interfaces.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError.
+func (c *Gatherer) GatherV2Beta2WithContext(ctx context.Context, specs []autoscalingv2beta2.MetricSpec, namespace string, podSelector labels.Selector) ([]*metrics.Metric, error) {
+	return c.GatherWithContext(ctx, convert.FromV2Beta2MetricSpecs(specs), namespace, podSelector)
+}
+
+// GatherForHPAV1 returns the metrics gathered for an autoscaling/v1 HorizontalPodAutoscaler, converting its target
+// CPU utilization to the equivalent autoscaling/v2 resource metric spec. Metrics are gathered in the HPA's namespace
+// for the pods matching the pod selector provided.
+// If an error occurs gathering the metric this will return a GatherMultiMetricError.
+func (c *Gatherer) GatherForHPAV1(hpa *autoscalingv1.HorizontalPodAutoscaler, podSelector labels.Selector) ([]*metrics.Metric, error) {
+	return c.GatherForHPAV1WithContext(context.Background(), hpa, podSelector)
+}
+
+// GatherForHPAV1WithContext returns the metrics gathered for an autoscaling/v1 HorizontalPodAutoscaler, passing the
+// context provided to any gatherers that implement the context aware gatherer interfaces.
+// If an error occurs gathering the metric this will return a GatherMultiMetricError.
+func (c *Gatherer) GatherForHPAV1WithContext(ctx context.Context, hpa *autoscalingv1.HorizontalPodAutoscaler, podSelector labels.Selector) ([]*metrics.Metric, error) {
+	return c.GatherWithContext(ctx, convert.FromV1HPA(hpa), hpa.Namespace, podSelector)
+}
+
 // GatherSingleMetric returns the metric gathered based on a single metric spec.
 func (c *Gatherer) GatherSingleMetric(spec autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) (*metrics.Metric, error) {
 	return c.GatherSingleMetricWithOptions(spec, namespace, podSelector, c.CPUInitializationPeriod, c.DelayOfInitialReadinessStatus)
 }
 
+// GatherSingleMetricV2Beta2 returns the metric gathered based on a single autoscaling/v2beta2 metric spec, converting
+// it to an autoscaling/v2 metric spec before gathering.
+func (c *Gatherer) GatherSingleMetricV2Beta2(spec autoscalingv2beta2.MetricSpec, namespace string, podSelector labels.Selector) (*metrics.Metric, error) {
+	return c.GatherSingleMetricV2Beta2WithContext(context.Background(), spec, namespace, podSelector)
+}
+
+// GatherSingleMetricV2Beta2WithContext returns the metric gathered based on a single autoscaling/v2beta2 metric spec,
+// converting it to an autoscaling/v2 metric spec before gathering and passing the context provided to the gatherer if
+// it implements the context aware gatherer interfaces.
+func (c *Gatherer) GatherSingleMetricV2Beta2WithContext(ctx context.Context, spec autoscalingv2beta2.MetricSpec, namespace string, podSelector labels.Selector) (*metrics.Metric, error) {
+	return c.GatherSingleMetricWithContext(ctx, convert.FromV2Beta2MetricSpec(spec), namespace, podSelector)
+}
+
 // GatherSingleMetricWithOptions returns the metric gathered based on a single metric spec with options.
 func (c *Gatherer) GatherSingleMetricWithOptions(spec autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector,
 	cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration) (*metrics.Metric, error) {
+	return c.gatherSingleMetric(context.Background(), spec, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+}
+
+// GatherSingleMetricWithContext returns the metric gathered based on a single metric spec, passing the context
+// provided to the gatherer if it implements the context aware gatherer interfaces.
+func (c *Gatherer) GatherSingleMetricWithContext(ctx context.Context, spec autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) (*metrics.Metric, error) {
+	return c.gatherSingleMetric(ctx, spec, namespace, podSelector, c.CPUInitializationPeriod, c.DelayOfInitialReadinessStatus)
+}
+
+// GatherSingleMetricWithContextAndOptions returns the metric gathered based on a single metric spec with options,
+// passing the context provided to the gatherer if it implements the context aware gatherer interfaces.
+func (c *Gatherer) GatherSingleMetricWithContextAndOptions(ctx context.Context, spec autoscalingv2.MetricSpec,
+	namespace string, podSelector labels.Selector, cpuInitializationPeriod time.Duration,
+	delayOfInitialReadinessStatus time.Duration) (*metrics.Metric, error) {
+	return c.gatherSingleMetric(ctx, spec, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+}
+
+// GatherSingleMetricWithGatherOptions returns the metric gathered based on a single metric spec with the options
+// provided, passing the context provided to the gatherer if it implements the context aware gatherer interfaces. Any
+// options left as the zero value use the Gatherer's configuration.
+func (c *Gatherer) GatherSingleMetricWithGatherOptions(ctx context.Context, spec autoscalingv2.MetricSpec,
+	namespace string, podSelector labels.Selector, options GatherOptions) (*metrics.Metric, error) {
+	options = c.defaultOptions(options)
+	return c.gatherSingleMetric(ctx, spec, namespace, podSelector, options.CPUInitializationPeriod,
+		options.DelayOfInitialReadinessStatus)
+}
+
+func (c *Gatherer) gatherSingleMetric(ctx context.Context, spec autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector,
+	cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration) (*metrics.Metric, error) {
+	log := resolveLogger(ctx, c.Logger).WithValues("metricType", string(spec.Type), "namespace", namespace)
+	log.V(LogLevelTrace).Info("Gathering metric", "podSelector", podSelector)
+
+	gathered, err := c.runPreGatherHooks(ctx, &spec, namespace, podSelector)
+	if gathered != nil || err != nil {
+		log.V(LogLevelTrace).Info("Metric provided by pre gather hook")
+	} else {
+		gathered, err = c.gatherSingleMetricWithRetry(ctx, spec, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+		if err == nil {
+			gathered, err = c.checkStaleness(log, gathered)
+		}
+	}
+
+	gathered, err = c.runPostGatherHooks(ctx, spec, gathered, err)
+	if err != nil {
+		log.V(LogLevelDebug).Info("Failed to gather metric", "error", err.Error())
+		return gathered, err
+	}
+
+	log.V(LogLevelDebug).Info("Gathered metric", "age", gathered.Age, "stale", gathered.Stale)
+	logPodGrouping(log, gathered)
+	return gathered, nil
+}
+
+func (c *Gatherer) gatherSingleMetricOnce(ctx context.Context, spec autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector,
+	cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration) (*metrics.Metric, error) {
+	if source, ok := c.Sources[spec.Type]; ok {
+		resolveLogger(ctx, c.Logger).V(LogLevelTrace).Info("Gathering metric using registered source",
+			"metricType", string(spec.Type))
+		gathered, err := source.Gather(ctx, spec, namespace, podSelector, GatherOptions{
+			CPUInitializationPeriod:       cpuInitializationPeriod,
+			DelayOfInitialReadinessStatus: delayOfInitialReadinessStatus,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s metric: %w", string(spec.Type), err)
+		}
+		return gathered, nil
+	}
+
 	switch spec.Type {
 	case autoscalingv2.ObjectMetricSourceType:
 		metricSelector, err := metav1.LabelSelectorAsSelector(spec.Object.Metric.Selector)
@@ -192,7 +491,7 @@ func (c *Gatherer) GatherSingleMetricWithOptions(spec autoscalingv2.MetricSpec,
 
 		switch spec.Object.Target.Type {
 		case autoscalingv2.ValueMetricType:
-			objectMetric, err := c.Object.Gather(spec.Object.Metric.Name, namespace, &spec.Object.DescribedObject, podSelector, metricSelector)
+			objectMetric, err := c.gatherObject(ctx, spec.Object.Metric.Name, namespace, &spec.Object.DescribedObject, podSelector, metricSelector)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get object metric: %w", err)
 			}
@@ -201,7 +500,7 @@ func (c *Gatherer) GatherSingleMetricWithOptions(spec autoscalingv2.MetricSpec,
 				Object: objectMetric,
 			}, nil
 		case autoscalingv2.AverageValueMetricType:
-			objectMetric, err := c.Object.GatherPerPod(spec.Object.Metric.Name, namespace, &spec.Object.DescribedObject, metricSelector)
+			objectMetric, err := c.gatherObjectPerPod(ctx, spec.Object.Metric.Name, namespace, &spec.Object.DescribedObject, metricSelector)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get object metric: %w", err)
 			}
@@ -210,7 +509,10 @@ func (c *Gatherer) GatherSingleMetricWithOptions(spec autoscalingv2.MetricSpec,
 				Object: objectMetric,
 			}, nil
 		default:
-			return nil, fmt.Errorf("invalid object metric source: must be either value or average value")
+			return nil, &metrics.InvalidMetricSourceError{
+				SourceType: autoscalingv2.ObjectMetricSourceType,
+				Reason:     "must be either value or average value",
+			}
 		}
 	case autoscalingv2.PodsMetricSourceType:
 		metricSelector, err := metav1.LabelSelectorAsSelector(spec.Pods.Metric.Selector)
@@ -218,11 +520,14 @@ func (c *Gatherer) GatherSingleMetricWithOptions(spec autoscalingv2.MetricSpec,
 			return nil, fmt.Errorf("failed to get pods metric: %w", err)
 		}
 
-		if spec.Pods.Target.Type != autoscalingv2.AverageValueMetricType {
-			return nil, fmt.Errorf("invalid pods metric source: must be average value")
+		if spec.Pods.Target.Type != autoscalingv2.AverageValueMetricType && spec.Pods.Target.Type != autoscalingv2.ValueMetricType {
+			return nil, &metrics.InvalidMetricSourceError{
+				SourceType: autoscalingv2.PodsMetricSourceType,
+				Reason:     "must be either value or average value",
+			}
 		}
 
-		podsMetric, err := c.Pods.Gather(spec.Pods.Metric.Name, namespace, podSelector, metricSelector)
+		podsMetric, err := c.gatherPods(ctx, spec.Pods.Metric.Name, namespace, podSelector, metricSelector)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get pods metric: %w", err)
 		}
@@ -232,8 +537,8 @@ func (c *Gatherer) GatherSingleMetricWithOptions(spec autoscalingv2.MetricSpec,
 		}, nil
 	case autoscalingv2.ResourceMetricSourceType:
 		switch spec.Resource.Target.Type {
-		case autoscalingv2.AverageValueMetricType:
-			resourceMetric, err := c.Resource.GatherRaw(spec.Resource.Name, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+		case autoscalingv2.ValueMetricType, autoscalingv2.AverageValueMetricType:
+			resourceMetric, err := c.gatherResourceRaw(ctx, spec.Resource.Name, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get resource metric: %w", err)
 			}
@@ -242,7 +547,7 @@ func (c *Gatherer) GatherSingleMetricWithOptions(spec autoscalingv2.MetricSpec,
 				Resource: resourceMetric,
 			}, nil
 		case autoscalingv2.UtilizationMetricType:
-			resourceMetric, err := c.Resource.Gather(spec.Resource.Name, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+			resourceMetric, err := c.gatherResource(ctx, spec.Resource.Name, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get resource metric: %w", err)
 			}
@@ -251,13 +556,16 @@ func (c *Gatherer) GatherSingleMetricWithOptions(spec autoscalingv2.MetricSpec,
 				Resource: resourceMetric,
 			}, nil
 		default:
-			return nil, fmt.Errorf("invalid resource metric source: must be either average value or average utilization")
+			return nil, &metrics.InvalidMetricSourceError{
+				SourceType: autoscalingv2.ResourceMetricSourceType,
+				Reason:     "must be either value, average value or average utilization",
+			}
 		}
 
 	case autoscalingv2.ExternalMetricSourceType:
 		switch spec.External.Target.Type {
 		case autoscalingv2.ValueMetricType:
-			externalMetric, err := c.External.Gather(spec.External.Metric.Name, namespace, spec.External.Metric.Selector, podSelector)
+			externalMetric, err := c.gatherExternal(ctx, spec.External.Metric.Name, namespace, spec.External.Metric.Selector, podSelector)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get external metric: %w", err)
 			}
@@ -266,7 +574,7 @@ func (c *Gatherer) GatherSingleMetricWithOptions(spec autoscalingv2.MetricSpec,
 				External: externalMetric,
 			}, nil
 		case autoscalingv2.AverageValueMetricType:
-			externalMetric, err := c.External.GatherPerPod(spec.External.Metric.Name, namespace, spec.External.Metric.Selector)
+			externalMetric, err := c.gatherExternalPerPod(ctx, spec.External.Metric.Name, namespace, spec.External.Metric.Selector)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get external metric: %w", err)
 			}
@@ -275,10 +583,64 @@ func (c *Gatherer) GatherSingleMetricWithOptions(spec autoscalingv2.MetricSpec,
 				External: externalMetric,
 			}, nil
 		default:
-			return nil, fmt.Errorf("invalid external metric source: must be either value or average value")
+			return nil, &metrics.InvalidMetricSourceError{
+				SourceType: autoscalingv2.ExternalMetricSourceType,
+				Reason:     "must be either value or average value",
+			}
 		}
 
 	default:
-		return nil, fmt.Errorf("unknown metric source type %q", string(spec.Type))
+		return nil, fmt.Errorf("%w %q", ErrUnknownMetricType, string(spec.Type))
+	}
+}
+
+func (c *Gatherer) gatherObject(ctx context.Context, metricName string, namespace string, objectRef *autoscalingv2.CrossVersionObjectReference, podSelector labels.Selector, metricSelector labels.Selector) (*objectmetrics.Metric, error) {
+	if gatherer, ok := c.Object.(ObjectGathererWithContext); ok {
+		return gatherer.GatherWithContext(ctx, metricName, namespace, objectRef, podSelector, metricSelector)
+	}
+	return c.Object.Gather(metricName, namespace, objectRef, podSelector, metricSelector)
+}
+
+func (c *Gatherer) gatherObjectPerPod(ctx context.Context, metricName string, namespace string, objectRef *autoscalingv2.CrossVersionObjectReference, metricSelector labels.Selector) (*objectmetrics.Metric, error) {
+	if gatherer, ok := c.Object.(ObjectGathererWithContext); ok {
+		return gatherer.GatherPerPodWithContext(ctx, metricName, namespace, objectRef, metricSelector)
+	}
+	return c.Object.GatherPerPod(metricName, namespace, objectRef, metricSelector)
+}
+
+func (c *Gatherer) gatherPods(ctx context.Context, metricName string, namespace string, podSelector labels.Selector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+	if gatherer, ok := c.Pods.(PodsGathererWithContext); ok {
+		return gatherer.GatherWithContext(ctx, metricName, namespace, podSelector, metricSelector)
+	}
+	return c.Pods.Gather(metricName, namespace, podSelector, metricSelector)
+}
+
+func (c *Gatherer) gatherResource(ctx context.Context, resourceName corev1.ResourceName, namespace string, podSelector labels.Selector,
+	cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error) {
+	if gatherer, ok := c.Resource.(ResourceGathererWithContext); ok {
+		return gatherer.GatherWithContext(ctx, resourceName, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+	}
+	return c.Resource.Gather(resourceName, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+}
+
+func (c *Gatherer) gatherResourceRaw(ctx context.Context, resourceName corev1.ResourceName, namespace string, podSelector labels.Selector,
+	cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error) {
+	if gatherer, ok := c.Resource.(ResourceGathererWithContext); ok {
+		return gatherer.GatherRawWithContext(ctx, resourceName, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+	}
+	return c.Resource.GatherRaw(resourceName, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+}
+
+func (c *Gatherer) gatherExternal(ctx context.Context, metricName, namespace string, metricSelector *metav1.LabelSelector, podSelector labels.Selector) (*externalmetrics.Metric, error) {
+	if gatherer, ok := c.External.(ExternalGathererWithContext); ok {
+		return gatherer.GatherWithContext(ctx, metricName, namespace, metricSelector, podSelector)
+	}
+	return c.External.Gather(metricName, namespace, metricSelector, podSelector)
+}
+
+func (c *Gatherer) gatherExternalPerPod(ctx context.Context, metricName, namespace string, metricSelector *metav1.LabelSelector) (*externalmetrics.Metric, error) {
+	if gatherer, ok := c.External.(ExternalGathererWithContext); ok {
+		return gatherer.GatherPerPodWithContext(ctx, metricName, namespace, metricSelector)
 	}
+	return c.External.GatherPerPod(metricName, namespace, metricSelector)
 }
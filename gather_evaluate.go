@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// MetricsGatherer gathers metrics for a set of metric specs, it is implemented by the Gatherer and by wrappers around
+// it such as cache.Gatherer
+type MetricsGatherer interface {
+	GatherWithContext(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string,
+		podSelector labels.Selector) ([]*metrics.Metric, error)
+}
+
+var _ MetricsGatherer = &Gatherer{}
+
+// GatherAndEvaluate gathers the metrics for the specs provided and evaluates them using the options provided, in the
+// same way as the HPA. If only some metrics fail to be gathered or evaluated (see IsPartial) the replica count
+// evaluated from the remaining metrics is returned along with the partial error, but it is never lower than the
+// current replica count as the failed metrics could require more replicas. Any other failure returns an error
+// wrapping ErrGatherMetrics or ErrEvaluateMetrics.
+func GatherAndEvaluate(ctx context.Context, gatherer MetricsGatherer, evaluator *Evaluator,
+	specs []autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector, currentReplicas int32,
+	options EvaluateOptions) ([]*metrics.Metric, int32, error) {
+	gatheredMetrics, gatherErr := gatherer.GatherWithContext(ctx, specs, namespace, podSelector)
+	if gatherErr != nil && !IsPartial(gatherErr) {
+		return nil, 0, fmt.Errorf("%w: %w", ErrGatherMetrics, gatherErr)
+	}
+
+	targetReplicas, evaluateErr := evaluator.EvaluateWithEvaluateOptions(ctx, gatheredMetrics, currentReplicas,
+		options)
+	if evaluateErr != nil && !IsPartial(evaluateErr) {
+		return nil, 0, fmt.Errorf("%w: %w", ErrEvaluateMetrics, evaluateErr)
+	}
+
+	partialErr := errors.Join(gatherErr, evaluateErr)
+	if partialErr != nil && targetReplicas < currentReplicas {
+		// Do not scale down if any metrics are invalid, the missing metrics could require more replicas
+		targetReplicas = currentReplicas
+	}
+
+	return gatheredMetrics, targetReplicas, partialErr
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestGatherAndEvaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	objectMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ObjectMetricSourceType,
+		},
+	}
+
+	var tests = []struct {
+		description      string
+		expected         int32
+		expectedMetrics  []*metrics.Metric
+		expectedErr      error
+		expectedSentinel error
+		expectedPartial  bool
+		gatherer         *fake.Gatherer
+		evaluater        *fake.ObjectEvaluater
+		currentReplicas  int32
+	}{
+		{
+			description:      "Gather failure",
+			expectedErr:      errors.New("failed to gather metrics: gather error"),
+			expectedSentinel: k8shorizmetrics.ErrGatherMetrics,
+			gatherer: &fake.Gatherer{
+				GatherReactor: func(specs []autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) ([]*metrics.Metric, error) {
+					return nil, errors.New("gather error")
+				},
+			},
+			currentReplicas: 5,
+		},
+		{
+			description:      "Evaluate failure",
+			expectedErr:      errors.New("failed to evaluate metrics: evaluator multi metric error: 1 errors, first error is evaluate error"),
+			expectedSentinel: k8shorizmetrics.ErrEvaluateMetrics,
+			gatherer: &fake.Gatherer{
+				GatherReactor: func(specs []autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) ([]*metrics.Metric, error) {
+					return []*metrics.Metric{objectMetric}, nil
+				},
+			},
+			evaluater: &fake.ObjectEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 0, errors.New("evaluate error")
+				},
+			},
+			currentReplicas: 5,
+		},
+		{
+			description:     "Partial gather failure, do not scale down",
+			expected:        5,
+			expectedMetrics: []*metrics.Metric{objectMetric},
+			expectedErr:     errors.New("gatherer multi metric error: 1 errors, first error is gather error"),
+			expectedPartial: true,
+			gatherer: &fake.Gatherer{
+				GatherReactor: func(specs []autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) ([]*metrics.Metric, error) {
+					return []*metrics.Metric{objectMetric}, &k8shorizmetrics.GathererMultiMetricError{
+						Partial: true,
+						Errors:  []error{errors.New("gather error")},
+					}
+				},
+			},
+			evaluater: &fake.ObjectEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 2, nil
+				},
+			},
+			currentReplicas: 5,
+		},
+		{
+			description:     "Partial gather failure, scale up",
+			expected:        8,
+			expectedMetrics: []*metrics.Metric{objectMetric},
+			expectedErr:     errors.New("gatherer multi metric error: 1 errors, first error is gather error"),
+			expectedPartial: true,
+			gatherer: &fake.Gatherer{
+				GatherReactor: func(specs []autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) ([]*metrics.Metric, error) {
+					return []*metrics.Metric{objectMetric}, &k8shorizmetrics.GathererMultiMetricError{
+						Partial: true,
+						Errors:  []error{errors.New("gather error")},
+					}
+				},
+			},
+			evaluater: &fake.ObjectEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 8, nil
+				},
+			},
+			currentReplicas: 5,
+		},
+		{
+			description:     "Success, scale down",
+			expected:        2,
+			expectedMetrics: []*metrics.Metric{objectMetric},
+			gatherer: &fake.Gatherer{
+				GatherReactor: func(specs []autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) ([]*metrics.Metric, error) {
+					return []*metrics.Metric{objectMetric}, nil
+				},
+			},
+			evaluater: &fake.ObjectEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 2, nil
+				},
+			},
+			currentReplicas: 5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluator := &k8shorizmetrics.Evaluator{
+				Object: test.evaluater,
+			}
+
+			gathered, replicas, err := k8shorizmetrics.GatherAndEvaluate(context.Background(), test.gatherer, evaluator,
+				nil, "test-namespace", labels.Everything(), test.currentReplicas, k8shorizmetrics.EvaluateOptions{})
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if test.expectedSentinel != nil && !errors.Is(err, test.expectedSentinel) {
+				t.Errorf("expected error to wrap %v", test.expectedSentinel)
+			}
+			if k8shorizmetrics.IsPartial(err) != test.expectedPartial {
+				t.Errorf("partial mismatch (-want +got):\n%s", cmp.Diff(test.expectedPartial, k8shorizmetrics.IsPartial(err)))
+			}
+			if !cmp.Equal(test.expected, replicas) {
+				t.Errorf("replicas mismatch (-want +got):\n%s", cmp.Diff(test.expected, replicas))
+			}
+			if !cmp.Equal(test.expectedMetrics, gathered) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expectedMetrics, gathered))
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"context"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// PreGatherHook is called before each metric spec is gathered. A copy of the spec is provided which can be modified
+// before it is gathered, and returning a metric or an error short circuits gathering, skipping any remaining pre
+// gather hooks and using the metric or error returned as the result of the gather.
+type PreGatherHook func(ctx context.Context, spec *autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) (*metrics.Metric, error)
+
+// PostGatherHook is called after each metric spec is gathered, including if gathering failed or was short circuited
+// by a PreGatherHook, with the result of the gather. The metric and error returned replace the result of the gather,
+// so a hook which does not modify the result should return the metric and error it was provided.
+type PostGatherHook func(ctx context.Context, spec autoscalingv2.MetricSpec, gathered *metrics.Metric,
+	err error) (*metrics.Metric, error)
+
+// AddPreGatherHook adds a hook called before each metric spec is gathered, hooks are called in the order they are
+// added. Hooks should be added before the Gatherer is used, adding hooks is not safe to call concurrently with
+// gathering.
+func (c *Gatherer) AddPreGatherHook(hook PreGatherHook) {
+	c.PreGatherHooks = append(c.PreGatherHooks, hook)
+}
+
+// AddPostGatherHook adds a hook called after each metric spec is gathered, hooks are called in the order they are
+// added. Hooks should be added before the Gatherer is used, adding hooks is not safe to call concurrently with
+// gathering.
+func (c *Gatherer) AddPostGatherHook(hook PostGatherHook) {
+	c.PostGatherHooks = append(c.PostGatherHooks, hook)
+}
+
+func (c *Gatherer) runPreGatherHooks(ctx context.Context, spec *autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) (*metrics.Metric, error) {
+	if len(c.PreGatherHooks) > 0 {
+		// Copy the spec so hooks modifying it do not modify the caller's spec
+		*spec = *spec.DeepCopy()
+	}
+	for _, hook := range c.PreGatherHooks {
+		gathered, err := hook(ctx, spec, namespace, podSelector)
+		if gathered != nil || err != nil {
+			return gathered, err
+		}
+	}
+	return nil, nil
+}
+
+func (c *Gatherer) runPostGatherHooks(ctx context.Context, spec autoscalingv2.MetricSpec, gathered *metrics.Metric,
+	err error) (*metrics.Metric, error) {
+	for _, hook := range c.PostGatherHooks {
+		gathered, err = hook(ctx, spec, gathered, err)
+	}
+	return gathered, err
+}
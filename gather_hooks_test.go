@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestGatherHooks(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: "requests",
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	renamedSpec := *spec.DeepCopy()
+	renamedSpec.Pods.Metric.Name = "renamed"
+
+	var tests = []struct {
+		description     string
+		expected        *metrics.Metric
+		expectedErr     error
+		expectedCalls   []string
+		expectedGathers []string
+		preHooks        []k8shorizmetrics.PreGatherHook
+		postHooks       []k8shorizmetrics.PostGatherHook
+	}{
+		{
+			description: "No hooks",
+			expected: &metrics.Metric{
+				Spec: spec,
+				Pods: &podsmetrics.Metric{},
+			},
+			expectedGathers: []string{"requests"},
+		},
+		{
+			description: "Pre hook modifies spec",
+			expected: &metrics.Metric{
+				Spec: renamedSpec,
+				Pods: &podsmetrics.Metric{},
+			},
+			expectedGathers: []string{"renamed"},
+			preHooks: []k8shorizmetrics.PreGatherHook{
+				func(ctx context.Context, spec *autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) (*metrics.Metric, error) {
+					spec.Pods.Metric.Name = "renamed"
+					return nil, nil
+				},
+			},
+		},
+		{
+			description: "Pre hook short circuits with metric, skipping remaining pre hooks and gathering",
+			expected: &metrics.Metric{
+				Spec:   spec,
+				Custom: "short circuit",
+			},
+			expectedCalls:   []string{"pre-1", "post-1"},
+			expectedGathers: nil,
+			preHooks: []k8shorizmetrics.PreGatherHook{
+				func(ctx context.Context, spec *autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) (*metrics.Metric, error) {
+					return &metrics.Metric{Spec: *spec, Custom: "short circuit"}, nil
+				},
+			},
+			postHooks: []k8shorizmetrics.PostGatherHook{
+				func(ctx context.Context, spec autoscalingv2.MetricSpec, gathered *metrics.Metric, err error) (*metrics.Metric, error) {
+					return gathered, err
+				},
+			},
+		},
+		{
+			description:     "Pre hook short circuits with error",
+			expectedErr:     errors.New("blocked"),
+			expectedGathers: nil,
+			preHooks: []k8shorizmetrics.PreGatherHook{
+				func(ctx context.Context, spec *autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) (*metrics.Metric, error) {
+					return nil, errors.New("blocked")
+				},
+			},
+		},
+		{
+			description: "Post hooks run in order and replace result",
+			expected: &metrics.Metric{
+				Spec:   spec,
+				Custom: "recovered",
+			},
+			expectedCalls:   []string{"pre-1", "pre-2", "post-1", "post-2"},
+			expectedGathers: []string{"requests"},
+			preHooks: []k8shorizmetrics.PreGatherHook{
+				func(ctx context.Context, spec *autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) (*metrics.Metric, error) {
+					return nil, nil
+				},
+				func(ctx context.Context, spec *autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) (*metrics.Metric, error) {
+					return nil, nil
+				},
+			},
+			postHooks: []k8shorizmetrics.PostGatherHook{
+				func(ctx context.Context, spec autoscalingv2.MetricSpec, gathered *metrics.Metric, err error) (*metrics.Metric, error) {
+					return nil, errors.New("replaced")
+				},
+				func(ctx context.Context, spec autoscalingv2.MetricSpec, gathered *metrics.Metric, err error) (*metrics.Metric, error) {
+					if err == nil || err.Error() != "replaced" {
+						return nil, errors.New("expected error from previous hook")
+					}
+					return &metrics.Metric{Spec: spec, Custom: "recovered"}, nil
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var calls []string
+			var gathers []string
+			gatherer := &k8shorizmetrics.Gatherer{
+				Pods: &fake.PodsGatherer{
+					GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+						gathers = append(gathers, metricName)
+						return &podsmetrics.Metric{}, nil
+					},
+				},
+			}
+			for i, hook := range test.preHooks {
+				name := "pre-" + string(rune('1'+i))
+				gatherer.AddPreGatherHook(func(ctx context.Context, spec *autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector) (*metrics.Metric, error) {
+					calls = append(calls, name)
+					return hook(ctx, spec, namespace, podSelector)
+				})
+			}
+			for i, hook := range test.postHooks {
+				name := "post-" + string(rune('1'+i))
+				gatherer.AddPostGatherHook(func(ctx context.Context, spec autoscalingv2.MetricSpec, gathered *metrics.Metric, err error) (*metrics.Metric, error) {
+					calls = append(calls, name)
+					return hook(ctx, spec, gathered, err)
+				})
+			}
+
+			result, err := gatherer.GatherSingleMetric(spec, "test", labels.Everything())
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("metric mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+			if test.expectedCalls != nil && !cmp.Equal(test.expectedCalls, calls) {
+				t.Errorf("hook calls mismatch (-want +got):\n%s", cmp.Diff(test.expectedCalls, calls))
+			}
+			if !cmp.Equal(test.expectedGathers, gathers) {
+				t.Errorf("gathers mismatch (-want +got):\n%s", cmp.Diff(test.expectedGathers, gathers))
+			}
+			if spec.Pods.Metric.Name != "requests" {
+				t.Errorf("pre gather hook modified caller's spec")
+			}
+		})
+	}
+}
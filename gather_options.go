@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	resourcemetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/resource"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	k8sscale "k8s.io/client-go/scale"
+)
+
+// GatherOptions configures a single gather. Any option left as the zero value uses the Gatherer's configuration, so
+// new options can be added without changing the behaviour of existing callers.
+type GatherOptions struct {
+	// CPUInitializationPeriod is the period after a pod starts during which CPU metrics from unready pods are ignored
+	CPUInitializationPeriod time.Duration
+	// DelayOfInitialReadinessStatus is the period after a pod starts during which its readiness status is treated as
+	// not yet initialized
+	DelayOfInitialReadinessStatus time.Duration
+	// Concurrency is the maximum number of metric specs gathered at once when gathering multiple metrics
+	Concurrency int
+}
+
+func (c *Gatherer) defaultOptions(options GatherOptions) GatherOptions {
+	if options.CPUInitializationPeriod == 0 {
+		options.CPUInitializationPeriod = c.CPUInitializationPeriod
+	}
+	if options.DelayOfInitialReadinessStatus == 0 {
+		options.DelayOfInitialReadinessStatus = c.DelayOfInitialReadinessStatus
+	}
+	if options.Concurrency == 0 {
+		options.Concurrency = c.Concurrency
+	}
+	return options
+}
+
+// GathererOption configures a Gatherer created by NewGathererWithOptions
+type GathererOption func(gatherer *Gatherer)
+
+// WithCPUInitializationPeriod sets the period after a pod starts during which CPU metrics from unready pods are
+// ignored
+func WithCPUInitializationPeriod(cpuInitializationPeriod time.Duration) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.CPUInitializationPeriod = cpuInitializationPeriod
+	}
+}
+
+// WithDelayOfInitialReadinessStatus sets the period after a pod starts during which its readiness status is treated
+// as not yet initialized
+func WithDelayOfInitialReadinessStatus(delayOfInitialReadinessStatus time.Duration) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.DelayOfInitialReadinessStatus = delayOfInitialReadinessStatus
+	}
+}
+
+// WithScaleClient sets the scale client used to look up the scale subresource of targets
+func WithScaleClient(scaleClient k8sscale.ScalesGetter) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.ScaleClient = scaleClient
+	}
+}
+
+// WithRESTMapper sets the REST mapper used to map scale targets to resources when looking up their scale subresource
+func WithRESTMapper(restMapper meta.RESTMapper) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.RESTMapper = restMapper
+	}
+}
+
+// WithConcurrency sets the maximum number of metric specs gathered at once when gathering multiple metrics
+func WithConcurrency(concurrency int) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.Concurrency = concurrency
+	}
+}
+
+// WithResourceGatherer replaces the default resource metric gatherer
+func WithResourceGatherer(resourceGatherer ResourceGatherer) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.Resource = resourceGatherer
+	}
+}
+
+// WithResourceUtilizationBasis sets what the default resource metric gatherer calculates resource utilization against,
+// for example pod limits rather than requests. This has no effect if the resource metric gatherer has been replaced.
+func WithResourceUtilizationBasis(utilizationBasis resourcemetrics.UtilizationBasis) GathererOption {
+	return func(gatherer *Gatherer) {
+		if resourceGatherer, ok := gatherer.Resource.(*resource.Gather); ok {
+			resourceGatherer.UtilizationBasis = utilizationBasis
+		}
+	}
+}
+
+// WithPodsGatherer replaces the default pods metric gatherer
+func WithPodsGatherer(podsGatherer PodsGatherer) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.Pods = podsGatherer
+	}
+}
+
+// WithObjectGatherer replaces the default object metric gatherer
+func WithObjectGatherer(objectGatherer ObjectGatherer) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.Object = objectGatherer
+	}
+}
+
+// WithExternalGatherer replaces the default external metric gatherer
+func WithExternalGatherer(externalGatherer ExternalGatherer) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.External = externalGatherer
+	}
+}
+
+// WithRetry sets the policy used to retry metrics which fail to be gathered
+func WithRetry(retry *RetryPolicy) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.Retry = retry
+	}
+}
+
+// WithMaxAge sets the maximum age of gathered metrics, metrics older than this are marked as stale
+func WithMaxAge(maxAge time.Duration) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.MaxAge = maxAge
+	}
+}
+
+// WithRejectStale sets whether metrics older than the maximum age fail to be gathered rather than being marked as
+// stale
+func WithRejectStale(rejectStale bool) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.RejectStale = rejectStale
+	}
+}
+
+// WithPreGatherHook adds a hook called before each metric spec is gathered
+func WithPreGatherHook(hook PreGatherHook) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.AddPreGatherHook(hook)
+	}
+}
+
+// WithPostGatherHook adds a hook called after each metric spec is gathered
+func WithPostGatherHook(hook PostGatherHook) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.AddPostGatherHook(hook)
+	}
+}
+
+// WithSource registers the gatherer provided for the metric source type provided, see Gatherer.RegisterSource
+func WithSource(sourceType autoscalingv2.MetricSourceType, sourceGatherer SourceGatherer) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.RegisterSource(sourceType, sourceGatherer)
+	}
+}
+
+// WithLogger sets the logger used to log debug and trace information about each metric gathered
+func WithLogger(logger logr.Logger) GathererOption {
+	return func(gatherer *Gatherer) {
+		gatherer.Logger = logger
+	}
+}
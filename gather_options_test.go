@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	resourcemetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/resource"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	fakescale "k8s.io/client-go/scale/fake"
+)
+
+func TestNewGathererWithOptionsDefaults(t *testing.T) {
+	gatherer := k8shorizmetrics.NewGathererWithOptions(&fake.MetricsClient{}, &fake.PodLister{})
+
+	if _, ok := gatherer.Resource.(*resource.Gather); !ok {
+		t.Errorf("expected default resource gatherer, got %T", gatherer.Resource)
+	}
+	if _, ok := gatherer.Pods.(*pods.Gather); !ok {
+		t.Errorf("expected default pods gatherer, got %T", gatherer.Pods)
+	}
+	if _, ok := gatherer.Object.(*object.Gather); !ok {
+		t.Errorf("expected default object gatherer, got %T", gatherer.Object)
+	}
+	if _, ok := gatherer.External.(*external.Gather); !ok {
+		t.Errorf("expected default external gatherer, got %T", gatherer.External)
+	}
+	if gatherer.CPUInitializationPeriod != 0 || gatherer.DelayOfInitialReadinessStatus != 0 ||
+		gatherer.Concurrency != 0 || gatherer.ScaleClient != nil {
+		t.Errorf("expected zero value configuration, got %+v", gatherer)
+	}
+}
+
+func TestNewGathererWithOptions(t *testing.T) {
+	scaleClient := &fakescale.FakeScaleClient{}
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	retry := k8shorizmetrics.DefaultRetryPolicy()
+	resourceGatherer := &fake.ResourceGatherer{}
+	podsGatherer := &fake.PodsGatherer{}
+	objectGatherer := &fake.ObjectGatherer{}
+	externalGatherer := &fake.ExternalGatherer{}
+
+	gatherer := k8shorizmetrics.NewGathererWithOptions(&fake.MetricsClient{}, &fake.PodLister{},
+		k8shorizmetrics.WithCPUInitializationPeriod(time.Minute),
+		k8shorizmetrics.WithDelayOfInitialReadinessStatus(time.Second),
+		k8shorizmetrics.WithScaleClient(scaleClient),
+		k8shorizmetrics.WithRESTMapper(restMapper),
+		k8shorizmetrics.WithRetry(retry),
+		k8shorizmetrics.WithMaxAge(time.Hour),
+		k8shorizmetrics.WithRejectStale(true),
+		k8shorizmetrics.WithPreGatherHook(func(ctx context.Context, spec *autoscalingv2.MetricSpec, namespace string,
+			podSelector labels.Selector) (*metrics.Metric, error) {
+			return nil, nil
+		}),
+		k8shorizmetrics.WithPostGatherHook(func(ctx context.Context, spec autoscalingv2.MetricSpec,
+			gathered *metrics.Metric, err error) (*metrics.Metric, error) {
+			return gathered, err
+		}),
+		k8shorizmetrics.WithConcurrency(4),
+		k8shorizmetrics.WithResourceGatherer(resourceGatherer),
+		k8shorizmetrics.WithPodsGatherer(podsGatherer),
+		k8shorizmetrics.WithObjectGatherer(objectGatherer),
+		k8shorizmetrics.WithExternalGatherer(externalGatherer))
+
+	if gatherer.CPUInitializationPeriod != time.Minute {
+		t.Errorf("cpu initialization period mismatch, want %s, got %s", time.Minute, gatherer.CPUInitializationPeriod)
+	}
+	if gatherer.DelayOfInitialReadinessStatus != time.Second {
+		t.Errorf("delay of initial readiness status mismatch, want %s, got %s", time.Second,
+			gatherer.DelayOfInitialReadinessStatus)
+	}
+	if gatherer.ScaleClient != scaleClient {
+		t.Errorf("scale client not set")
+	}
+	if gatherer.RESTMapper != restMapper {
+		t.Errorf("REST mapper not set")
+	}
+	if gatherer.Retry != retry {
+		t.Errorf("retry policy not set")
+	}
+	if gatherer.MaxAge != time.Hour || !gatherer.RejectStale {
+		t.Errorf("staleness mismatch, want max age %s and reject stale, got max age %s and reject stale %t", time.Hour,
+			gatherer.MaxAge, gatherer.RejectStale)
+	}
+	if len(gatherer.PreGatherHooks) != 1 || len(gatherer.PostGatherHooks) != 1 {
+		t.Errorf("hooks mismatch, want 1 pre and 1 post gather hook, got %d and %d", len(gatherer.PreGatherHooks),
+			len(gatherer.PostGatherHooks))
+	}
+	if gatherer.Concurrency != 4 {
+		t.Errorf("concurrency mismatch, want 4, got %d", gatherer.Concurrency)
+	}
+	if gatherer.Resource != resourceGatherer || gatherer.Pods != podsGatherer || gatherer.Object != objectGatherer ||
+		gatherer.External != externalGatherer {
+		t.Errorf("expected gatherers to be replaced, got %+v", gatherer)
+	}
+}
+
+func TestWithResourceUtilizationBasis(t *testing.T) {
+	gatherer := k8shorizmetrics.NewGathererWithOptions(&fake.MetricsClient{}, &fake.PodLister{},
+		k8shorizmetrics.WithResourceUtilizationBasis(resourcemetrics.UtilizationBasisLimits))
+
+	resourceGatherer, ok := gatherer.Resource.(*resource.Gather)
+	if !ok {
+		t.Fatalf("expected default resource gatherer, got %T", gatherer.Resource)
+	}
+	if resourceGatherer.UtilizationBasis != resourcemetrics.UtilizationBasisLimits {
+		t.Errorf("utilization basis mismatch, want %s, got %s", resourcemetrics.UtilizationBasisLimits,
+			resourceGatherer.UtilizationBasis)
+	}
+}
+
+func TestGatherWithGatherOptions(t *testing.T) {
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.UtilizationMetricType,
+			},
+		},
+	}
+
+	var tests = []struct {
+		description                           string
+		expectedCPUInitializationPeriod       time.Duration
+		expectedDelayOfInitialReadinessStatus time.Duration
+		options                               k8shorizmetrics.GatherOptions
+	}{
+		{
+			description:                           "Zero value options, use gatherer configuration",
+			expectedCPUInitializationPeriod:       time.Hour,
+			expectedDelayOfInitialReadinessStatus: time.Minute,
+			options:                               k8shorizmetrics.GatherOptions{},
+		},
+		{
+			description:                           "Override CPU initialization period only",
+			expectedCPUInitializationPeriod:       time.Second,
+			expectedDelayOfInitialReadinessStatus: time.Minute,
+			options: k8shorizmetrics.GatherOptions{
+				CPUInitializationPeriod: time.Second,
+			},
+		},
+		{
+			description:                           "Override all options",
+			expectedCPUInitializationPeriod:       time.Second,
+			expectedDelayOfInitialReadinessStatus: time.Millisecond,
+			options: k8shorizmetrics.GatherOptions{
+				CPUInitializationPeriod:       time.Second,
+				DelayOfInitialReadinessStatus: time.Millisecond,
+				Concurrency:                   2,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var cpuInitializationPeriods, delaysOfInitialReadinessStatus []time.Duration
+			gatherer := &k8shorizmetrics.Gatherer{
+				Resource: &fake.ResourceGatherer{
+					GatherReactor: func(resourceName corev1.ResourceName, namespace string, podSelector labels.Selector, cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error) {
+						cpuInitializationPeriods = append(cpuInitializationPeriods, cpuInitializationPeriod)
+						delaysOfInitialReadinessStatus = append(delaysOfInitialReadinessStatus, delayOfInitialReadinessStatus)
+						return &resourcemetrics.Metric{}, nil
+					},
+				},
+				CPUInitializationPeriod:       time.Hour,
+				DelayOfInitialReadinessStatus: time.Minute,
+			}
+
+			_, err := gatherer.GatherWithGatherOptions(context.Background(), []autoscalingv2.MetricSpec{spec}, "test", labels.Everything(), test.options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_, err = gatherer.GatherSingleMetricWithGatherOptions(context.Background(), spec, "test", labels.Everything(), test.options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for i := range cpuInitializationPeriods {
+				if cpuInitializationPeriods[i] != test.expectedCPUInitializationPeriod {
+					t.Errorf("cpu initialization period mismatch, want %s, got %s", test.expectedCPUInitializationPeriod,
+						cpuInitializationPeriods[i])
+				}
+				if delaysOfInitialReadinessStatus[i] != test.expectedDelayOfInitialReadinessStatus {
+					t.Errorf("delay of initial readiness status mismatch, want %s, got %s",
+						test.expectedDelayOfInitialReadinessStatus, delaysOfInitialReadinessStatus[i])
+				}
+			}
+			if len(cpuInitializationPeriods) != 2 {
+				t.Errorf("expected 2 gathers, got %d", len(cpuInitializationPeriods))
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/clock"
+)
+
+// GatherResult is the result of gathering a single metric spec as part of gathering multiple metrics. If the metric
+// was gathered successfully Metric is set and Err is nil, otherwise Metric is nil and Err is the error which occurred.
+// Start is when gathering the metric spec started and Duration is how long it took, including any retries and hooks.
+type GatherResult struct {
+	Spec     autoscalingv2.MetricSpec
+	Metric   *metrics.Metric
+	Err      error
+	Start    time.Time
+	Duration time.Duration
+}
+
+// GatherResults returns a result for each of the metric specs provided, in the same order as the metric specs.
+// Unlike Gather, a failure to gather any metric spec does not cause an error to be returned, instead the error is
+// recorded in the result for that metric spec.
+func (c *Gatherer) GatherResults(specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) []*GatherResult {
+	return c.GatherResultsWithContext(context.Background(), specs, namespace, podSelector)
+}
+
+// GatherResultsWithContext returns a result for each of the metric specs provided, in the same order as the metric
+// specs, passing the context provided to any gatherers that implement the context aware gatherer interfaces. See
+// GatherResults.
+func (c *Gatherer) GatherResultsWithContext(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) []*GatherResult {
+	return c.gatherResults(ctx, specs, namespace, podSelector, c.CPUInitializationPeriod,
+		c.DelayOfInitialReadinessStatus, c.Concurrency)
+}
+
+// GatherResultsWithGatherOptions returns a result for each of the metric specs provided, in the same order as the
+// metric specs, with the options provided, passing the context provided to any gatherers that implement the context
+// aware gatherer interfaces. Any options left as the zero value use the Gatherer's configuration. See GatherResults.
+func (c *Gatherer) GatherResultsWithGatherOptions(ctx context.Context, specs []autoscalingv2.MetricSpec,
+	namespace string, podSelector labels.Selector, options GatherOptions) []*GatherResult {
+	options = c.defaultOptions(options)
+	return c.gatherResults(ctx, specs, namespace, podSelector, options.CPUInitializationPeriod,
+		options.DelayOfInitialReadinessStatus, options.Concurrency)
+}
+
+func (c *Gatherer) gatherResults(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector, cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration,
+	concurrency int) []*GatherResult {
+	results := make([]*GatherResult, len(specs))
+
+	// List pods once per namespace and selector for all of the specs, rather than once per spec
+	ctx = podutil.WithPodListCache(ctx)
+
+	gatherClock := c.clock()
+	gatherConcurrently(len(specs), concurrency, func(i int) {
+		start := gatherClock.Now()
+		gathered, err := c.gatherSingleMetric(ctx, specs[i], namespace, podSelector, cpuInitializationPeriod,
+			delayOfInitialReadinessStatus)
+		if err != nil {
+			gathered = nil
+		}
+		results[i] = &GatherResult{
+			Spec:     specs[i],
+			Metric:   gathered,
+			Err:      err,
+			Start:    start,
+			Duration: gatherClock.Since(start),
+		}
+	})
+
+	return results
+}
+
+// clock returns the Clock of the Gatherer, or the real clock if it is not set
+func (c *Gatherer) clock() clock.PassiveClock {
+	if c.Clock == nil {
+		return clock.RealClock{}
+	}
+	return c.Clock
+}
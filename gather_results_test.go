@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestGatherResults(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	resourceSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: corev1.ResourceCPU,
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.UtilizationMetricType,
+			},
+		},
+	}
+	podsSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: "test-metric",
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+	invalidSpec := autoscalingv2.MetricSpec{
+		Type: "invalid",
+	}
+
+	var tests = []struct {
+		description string
+		expected    []*k8shorizmetrics.GatherResult
+		specs       []autoscalingv2.MetricSpec
+	}{
+		{
+			description: "No specs",
+			expected:    []*k8shorizmetrics.GatherResult{},
+			specs:       []autoscalingv2.MetricSpec{},
+		},
+		{
+			description: "All specs succeed",
+			expected: []*k8shorizmetrics.GatherResult{
+				{
+					Spec: resourceSpec,
+					Metric: &metrics.Metric{
+						Spec:     resourceSpec,
+						Resource: &resource.Metric{},
+					},
+					Start:    start,
+					Duration: time.Second,
+				},
+				{
+					Spec: podsSpec,
+					Metric: &metrics.Metric{
+						Spec: podsSpec,
+						Pods: &pods.Metric{},
+					},
+					Start:    start.Add(time.Second),
+					Duration: 2 * time.Second,
+				},
+			},
+			specs: []autoscalingv2.MetricSpec{resourceSpec, podsSpec},
+		},
+		{
+			description: "Partial failure, error associated with failing spec",
+			expected: []*k8shorizmetrics.GatherResult{
+				{
+					Spec:     invalidSpec,
+					Err:      errors.New(`unknown metric source type "invalid"`),
+					Start:    start,
+					Duration: 0,
+				},
+				{
+					Spec: podsSpec,
+					Metric: &metrics.Metric{
+						Spec: podsSpec,
+						Pods: &pods.Metric{},
+					},
+					Start:    start,
+					Duration: 2 * time.Second,
+				},
+			},
+			specs: []autoscalingv2.MetricSpec{invalidSpec, podsSpec},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakeClock(start)
+			gatherer := &k8shorizmetrics.Gatherer{
+				Resource: &fake.ResourceGatherer{
+					GatherReactor: func(resourceName corev1.ResourceName, namespace string, podSelector labels.Selector, cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (*resource.Metric, error) {
+						fakeClock.Step(time.Second)
+						return &resource.Metric{}, nil
+					},
+				},
+				Pods: &fake.PodsGatherer{
+					GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*pods.Metric, error) {
+						fakeClock.Step(2 * time.Second)
+						return &pods.Metric{}, nil
+					},
+				},
+				Clock: fakeClock,
+			}
+
+			result := gatherer.GatherResults(test.specs, "test-namespace", labels.Everything())
+			if !cmp.Equal(test.expected, result, equateErrorMessage) {
+				t.Errorf("results mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, equateErrorMessage))
+			}
+		})
+	}
+}
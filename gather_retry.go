@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// RetryPolicy configures retrying failed metric gathers. Each metric spec is retried separately, waiting between
+// attempts using the exponential Backoff provided, with Backoff.Steps as the maximum number of attempts. Only errors
+// for which Retriable returns true are retried, if Retriable is nil IsRetriable is used.
+// Retrying gathers here and retrying requests with metricsclient.RESTClient.Retry stack, with each gather attempt
+// making up to the metrics client's Backoff.Steps requests, so usually only one of the two should be enabled.
+type RetryPolicy struct {
+	Backoff   wait.Backoff
+	Retriable func(err error) bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy making up to 4 attempts, starting with a 100ms wait and doubling the wait
+// each attempt, with 10% jitter
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		Backoff: wait.Backoff{
+			Duration: 100 * time.Millisecond,
+			Factor:   2,
+			Jitter:   0.1,
+			Steps:    4,
+		},
+	}
+}
+
+// IsRetriable returns true if the error provided is likely to be transient, such as the metrics API rate limiting
+// requests, being temporarily unavailable, timing out or the connection to it being reset. A metricsclient.TimeoutError
+// is retriable even though it wraps context.DeadlineExceeded, since only the request timed out rather than the context
+// of the gather.
+func IsRetriable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var timeoutErr *metricsclient.TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return true
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if k8serrors.IsTooManyRequests(err) || k8serrors.IsServiceUnavailable(err) || k8serrors.IsServerTimeout(err) ||
+		k8serrors.IsTimeout(err) || k8serrors.IsInternalError(err) {
+		return true
+	}
+
+	if utilnet.IsConnectionReset(err) || utilnet.IsConnectionRefused(err) || utilnet.IsProbableEOF(err) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func (c *Gatherer) gatherSingleMetricWithRetry(ctx context.Context, spec autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector, cpuInitializationPeriod time.Duration,
+	delayOfInitialReadinessStatus time.Duration) (*metrics.Metric, error) {
+	if c.Retry == nil {
+		return c.gatherSingleMetricOnce(ctx, spec, namespace, podSelector, cpuInitializationPeriod,
+			delayOfInitialReadinessStatus)
+	}
+
+	retriable := c.Retry.Retriable
+	if retriable == nil {
+		retriable = IsRetriable
+	}
+
+	backoff := c.Retry.Backoff
+	for {
+		gathered, err := c.gatherSingleMetricOnce(ctx, spec, namespace, podSelector, cpuInitializationPeriod,
+			delayOfInitialReadinessStatus)
+		if err == nil || !retriable(err) || backoff.Steps <= 1 {
+			return gathered, err
+		}
+
+		wait := backoff.Step()
+		resolveLogger(ctx, c.Logger).V(LogLevelDebug).Info("Retrying metric gather", "metricType", string(spec.Type),
+			"namespace", namespace, "wait", wait, "error", err.Error())
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestIsRetriable(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    bool
+		err         error
+	}{
+		{
+			description: "Nil error",
+			expected:    false,
+			err:         nil,
+		},
+		{
+			description: "Generic error",
+			expected:    false,
+			err:         errors.New("fail"),
+		},
+		{
+			description: "Not found",
+			expected:    false,
+			err:         k8serrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "test"),
+		},
+		{
+			description: "Context canceled",
+			expected:    false,
+			err:         fmt.Errorf("failed to get pods metric: %w", context.Canceled),
+		},
+		{
+			description: "Context deadline exceeded",
+			expected:    false,
+			err:         fmt.Errorf("failed to get pods metric: %w", context.DeadlineExceeded),
+		},
+		{
+			description: "Request timeout",
+			expected:    true,
+			err: fmt.Errorf("failed to get pods metric: %w", &metricsclient.TimeoutError{
+				Timeout: time.Second,
+				Err:     context.DeadlineExceeded,
+			}),
+		},
+		{
+			description: "Too many requests",
+			expected:    true,
+			err:         fmt.Errorf("failed to get pods metric: %w", k8serrors.NewTooManyRequests("slow down", 1)),
+		},
+		{
+			description: "Service unavailable",
+			expected:    true,
+			err:         k8serrors.NewServiceUnavailable("unavailable"),
+		},
+		{
+			description: "Connection reset",
+			expected:    true,
+			err:         fmt.Errorf("failed to get resource metric: %w", syscall.ECONNRESET),
+		},
+		{
+			description: "Network timeout",
+			expected:    true,
+			err:         &net.DNSError{IsTimeout: true},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := k8shorizmetrics.IsRetriable(test.err)
+			if result != test.expected {
+				t.Errorf("retriable mismatch, want %t, got %t", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGatherRetry(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	tooManyRequests := k8serrors.NewTooManyRequests("slow down", 1)
+
+	var tests = []struct {
+		description   string
+		expectedErr   error
+		expectedCalls int
+		retry         *k8shorizmetrics.RetryPolicy
+		errs          []error
+	}{
+		{
+			description:   "No retry policy, fail",
+			expectedErr:   errors.New("failed to get pods metric: slow down"),
+			expectedCalls: 1,
+			retry:         nil,
+			errs:          []error{tooManyRequests, nil},
+		},
+		{
+			description:   "Retry, succeed after retriable errors",
+			expectedErr:   nil,
+			expectedCalls: 3,
+			retry: &k8shorizmetrics.RetryPolicy{
+				Backoff: wait.Backoff{Duration: time.Millisecond, Factor: 2, Jitter: 0.1, Steps: 4},
+			},
+			errs: []error{tooManyRequests, syscall.ECONNRESET, nil},
+		},
+		{
+			description:   "Retry, attempts exhausted",
+			expectedErr:   errors.New("failed to get pods metric: slow down"),
+			expectedCalls: 2,
+			retry: &k8shorizmetrics.RetryPolicy{
+				Backoff: wait.Backoff{Duration: time.Millisecond, Steps: 2},
+			},
+			errs: []error{tooManyRequests, tooManyRequests, nil},
+		},
+		{
+			description:   "Retry, non retriable error",
+			expectedErr:   errors.New("failed to get pods metric: fail"),
+			expectedCalls: 1,
+			retry: &k8shorizmetrics.RetryPolicy{
+				Backoff: wait.Backoff{Duration: time.Millisecond, Steps: 4},
+			},
+			errs: []error{errors.New("fail"), nil},
+		},
+		{
+			description:   "Retry, custom retriable",
+			expectedErr:   nil,
+			expectedCalls: 2,
+			retry: &k8shorizmetrics.RetryPolicy{
+				Backoff: wait.Backoff{Duration: time.Millisecond, Steps: 4},
+				Retriable: func(err error) bool {
+					return true
+				},
+			},
+			errs: []error{errors.New("fail"), nil},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			calls := 0
+			gatherer := &k8shorizmetrics.Gatherer{
+				Pods: &fake.PodsGatherer{
+					GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+						err := test.errs[calls]
+						calls++
+						if err != nil {
+							return nil, err
+						}
+						return &podsmetrics.Metric{}, nil
+					},
+				},
+				Retry: test.retry,
+			}
+
+			_, err := gatherer.GatherSingleMetric(spec, "test", labels.Everything())
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+			}
+			if calls != test.expectedCalls {
+				t.Errorf("calls mismatch, want %d, got %d", test.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestGatherRetryContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	gatherer := &k8shorizmetrics.Gatherer{
+		Pods: &fake.PodsGatherer{
+			GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+				calls++
+				cancel()
+				return nil, k8serrors.NewServiceUnavailable("unavailable")
+			},
+		},
+		Retry: &k8shorizmetrics.RetryPolicy{
+			Backoff: wait.Backoff{Duration: time.Hour, Steps: 4},
+		},
+	}
+
+	_, err := gatherer.GatherSingleMetricWithContext(ctx, autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}, "test", labels.Everything())
+	if err == nil || err.Error() != "failed to get pods metric: unavailable" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls mismatch, want 1, got %d", calls)
+	}
+}
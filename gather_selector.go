@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// PodSelectorFromLabelSelector converts and validates a label selector, for example from a custom resource's spec,
+// into a pod selector that can be used for gathering. A nil label selector is invalid, an empty label selector
+// selects all pods.
+func PodSelectorFromLabelSelector(labelSelector *metav1.LabelSelector) (labels.Selector, error) {
+	if labelSelector == nil {
+		return nil, errors.New("invalid pod selector: selector is required")
+	}
+
+	podSelector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pod selector: %w", err)
+	}
+
+	return podSelector, nil
+}
+
+// GatherWithLabelSelector returns all of the metrics gathered based on the metric specs provided, for the pods
+// matching the label selector provided, see PodSelectorFromLabelSelector.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError. If a partial error occurs,
+// meaning some metrics were gathered successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (c *Gatherer) GatherWithLabelSelector(specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector *metav1.LabelSelector) ([]*metrics.Metric, error) {
+	return c.GatherWithContextAndLabelSelector(context.Background(), specs, namespace, podSelector)
+}
+
+// GatherWithContextAndLabelSelector returns all of the metrics gathered based on the metric specs provided, for the
+// pods matching the label selector provided, passing the context provided to any gatherers that implement the
+// context aware gatherer interfaces, see PodSelectorFromLabelSelector.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError. If a partial error occurs,
+// meaning some metrics were gathered successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (c *Gatherer) GatherWithContextAndLabelSelector(ctx context.Context, specs []autoscalingv2.MetricSpec,
+	namespace string, podSelector *metav1.LabelSelector) ([]*metrics.Metric, error) {
+	selector, err := PodSelectorFromLabelSelector(podSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GatherWithContext(ctx, specs, namespace, selector)
+}
+
+// GatherSingleMetricWithLabelSelector returns the metric gathered based on a single metric spec, for the pods
+// matching the label selector provided, see PodSelectorFromLabelSelector.
+func (c *Gatherer) GatherSingleMetricWithLabelSelector(spec autoscalingv2.MetricSpec, namespace string,
+	podSelector *metav1.LabelSelector) (*metrics.Metric, error) {
+	return c.GatherSingleMetricWithContextAndLabelSelector(context.Background(), spec, namespace, podSelector)
+}
+
+// GatherSingleMetricWithContextAndLabelSelector returns the metric gathered based on a single metric spec, for the
+// pods matching the label selector provided, passing the context provided to the gatherer if it implements the
+// context aware gatherer interfaces, see PodSelectorFromLabelSelector.
+func (c *Gatherer) GatherSingleMetricWithContextAndLabelSelector(ctx context.Context, spec autoscalingv2.MetricSpec,
+	namespace string, podSelector *metav1.LabelSelector) (*metrics.Metric, error) {
+	selector, err := PodSelectorFromLabelSelector(podSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GatherSingleMetricWithContext(ctx, spec, namespace, selector)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestPodSelectorFromLabelSelector(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description   string
+		expected      string
+		expectedErr   error
+		labelSelector *metav1.LabelSelector
+	}{
+		{
+			description:   "Nil selector",
+			expectedErr:   errors.New("invalid pod selector: selector is required"),
+			labelSelector: nil,
+		},
+		{
+			description: "Invalid operator",
+			expectedErr: errors.New(`invalid pod selector: "invalid" is not a valid label selector operator`),
+			labelSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "app",
+						Operator: "invalid",
+					},
+				},
+			},
+		},
+		{
+			description:   "Empty selector, select everything",
+			expected:      "",
+			labelSelector: &metav1.LabelSelector{},
+		},
+		{
+			description: "Match labels and expressions",
+			expected:    "app=test,tier in (api,web)",
+			labelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": "test",
+				},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "tier",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"web", "api"},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := k8shorizmetrics.PodSelectorFromLabelSelector(test.labelSelector)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if result != nil && result.String() != test.expected {
+				t.Errorf("selector mismatch, want %q, got %q", test.expected, result.String())
+			}
+		})
+	}
+}
+
+func TestGatherWithLabelSelector(t *testing.T) {
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	var podSelectors []string
+	gatherer := &k8shorizmetrics.Gatherer{
+		Pods: &fake.PodsGatherer{
+			GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+				podSelectors = append(podSelectors, podSelector.String())
+				return &podsmetrics.Metric{}, nil
+			},
+		},
+	}
+
+	labelSelector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			"app": "test",
+		},
+	}
+
+	_, err := gatherer.GatherWithLabelSelector([]autoscalingv2.MetricSpec{spec}, "test", labelSelector)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = gatherer.GatherSingleMetricWithLabelSelector(spec, "test", labelSelector)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = gatherer.GatherWithContextAndLabelSelector(context.Background(), []autoscalingv2.MetricSpec{spec}, "test", nil)
+	if err == nil || err.Error() != "invalid pod selector: selector is required" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = gatherer.GatherSingleMetricWithContextAndLabelSelector(context.Background(), spec, "test", nil)
+	if err == nil || err.Error() != "invalid pod selector: selector is required" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if !cmp.Equal([]string{"app=test", "app=test"}, podSelectors) {
+		t.Errorf("pod selectors mismatch (-want +got):\n%s", cmp.Diff([]string{"app=test", "app=test"}, podSelectors))
+	}
+}
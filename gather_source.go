@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"context"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SourceGatherer allows retrieval of metrics for a metric source type registered with the Gatherer, allowing metric
+// source types other than the built in autoscalingv2 source types to be gathered.
+type SourceGatherer interface {
+	Gather(ctx context.Context, spec autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector,
+		options GatherOptions) (*metrics.Metric, error)
+}
+
+// SourceGathererFunc is an adapter allowing a function to be used as a SourceGatherer
+type SourceGathererFunc func(ctx context.Context, spec autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector, options GatherOptions) (*metrics.Metric, error)
+
+// Gather calls the function with the arguments provided
+func (f SourceGathererFunc) Gather(ctx context.Context, spec autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector, options GatherOptions) (*metrics.Metric, error) {
+	return f(ctx, spec, namespace, podSelector, options)
+}
+
+// RegisterSource registers a gatherer for the metric source type provided, any metric specs with this type will be
+// gathered using the registered gatherer. Registering a built in source type overrides the built in gathering for
+// that type, and registering a nil gatherer removes any gatherer previously registered for the type.
+// Sources should be registered before the Gatherer is used, registering is not safe to call concurrently with
+// gathering.
+func (c *Gatherer) RegisterSource(sourceType autoscalingv2.MetricSourceType, gatherer SourceGatherer) {
+	if gatherer == nil {
+		delete(c.Sources, sourceType)
+		return
+	}
+	if c.Sources == nil {
+		c.Sources = map[autoscalingv2.MetricSourceType]SourceGatherer{}
+	}
+	c.Sources[sourceType] = gatherer
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestRegisterSource(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description string
+		expected    *metrics.Metric
+		expectedErr error
+		gatherer    *k8shorizmetrics.Gatherer
+		spec        autoscalingv2.MetricSpec
+	}{
+		{
+			description: "Unregistered custom source type",
+			expected:    nil,
+			expectedErr: errors.New(`unknown metric source type "queue"`),
+			gatherer:    &k8shorizmetrics.Gatherer{},
+			spec: autoscalingv2.MetricSpec{
+				Type: "queue",
+			},
+		},
+		{
+			description: "Registered custom source type fails",
+			expected:    nil,
+			expectedErr: errors.New(`failed to get queue metric: fail to gather`),
+			gatherer: func() *k8shorizmetrics.Gatherer {
+				gatherer := &k8shorizmetrics.Gatherer{}
+				gatherer.RegisterSource("queue", k8shorizmetrics.SourceGathererFunc(func(ctx context.Context,
+					spec autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector,
+					options k8shorizmetrics.GatherOptions) (*metrics.Metric, error) {
+					return nil, errors.New("fail to gather")
+				}))
+				return gatherer
+			}(),
+			spec: autoscalingv2.MetricSpec{
+				Type: "queue",
+			},
+		},
+		{
+			description: "Registered custom source type succeeds",
+			expected: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: "queue",
+				},
+				Custom: "test/5s/10s",
+			},
+			expectedErr: nil,
+			gatherer: func() *k8shorizmetrics.Gatherer {
+				gatherer := &k8shorizmetrics.Gatherer{
+					CPUInitializationPeriod:       5 * time.Second,
+					DelayOfInitialReadinessStatus: 10 * time.Second,
+				}
+				gatherer.RegisterSource("queue", k8shorizmetrics.SourceGathererFunc(func(ctx context.Context,
+					spec autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector,
+					options k8shorizmetrics.GatherOptions) (*metrics.Metric, error) {
+					return &metrics.Metric{
+						Spec:   spec,
+						Custom: namespace + "/" + options.CPUInitializationPeriod.String() + "/" + options.DelayOfInitialReadinessStatus.String(),
+					}, nil
+				}))
+				return gatherer
+			}(),
+			spec: autoscalingv2.MetricSpec{
+				Type: "queue",
+			},
+		},
+		{
+			description: "Registered source overrides built in source type",
+			expected: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.PodsMetricSourceType,
+				},
+				Custom: "overridden",
+			},
+			expectedErr: nil,
+			gatherer: k8shorizmetrics.NewGathererWithOptions(&fake.MetricsClient{}, &fake.PodLister{},
+				k8shorizmetrics.WithSource(autoscalingv2.PodsMetricSourceType, k8shorizmetrics.SourceGathererFunc(
+					func(ctx context.Context, spec autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector,
+						options k8shorizmetrics.GatherOptions) (*metrics.Metric, error) {
+						return &metrics.Metric{
+							Spec:   spec,
+							Custom: "overridden",
+						}, nil
+					}))),
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+			},
+		},
+		{
+			description: "Registering nil removes source",
+			expected: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							Type: autoscalingv2.AverageValueMetricType,
+						},
+					},
+				},
+				Pods: &podsmetrics.Metric{},
+			},
+			expectedErr: nil,
+			gatherer: func() *k8shorizmetrics.Gatherer {
+				gatherer := &k8shorizmetrics.Gatherer{
+					Pods: &fake.PodsGatherer{
+						GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+							return &podsmetrics.Metric{}, nil
+						},
+					},
+				}
+				gatherer.RegisterSource(autoscalingv2.PodsMetricSourceType, k8shorizmetrics.SourceGathererFunc(
+					func(ctx context.Context, spec autoscalingv2.MetricSpec, namespace string, podSelector labels.Selector,
+						options k8shorizmetrics.GatherOptions) (*metrics.Metric, error) {
+						return nil, errors.New("should not be called")
+					}))
+				gatherer.RegisterSource(autoscalingv2.PodsMetricSourceType, nil)
+				return gatherer
+			}(),
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						Type: autoscalingv2.AverageValueMetricType,
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := test.gatherer.GatherSingleMetric(test.spec, "test", labels.Everything())
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
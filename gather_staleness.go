@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+// StaleMetricError occurs when the oldest sample of a gathered metric is older than the Gatherer's MaxAge and the
+// Gatherer is configured to reject stale metrics. Timestamp is the timestamp of the oldest sample.
+type StaleMetricError struct {
+	Spec      autoscalingv2.MetricSpec
+	Timestamp time.Time
+	Age       time.Duration
+	MaxAge    time.Duration
+}
+
+func (e *StaleMetricError) Error() string {
+	return fmt.Sprintf("stale %s metric: metric is %s old, exceeding maximum age of %s", string(e.Spec.Type), e.Age,
+		e.MaxAge)
+}
+
+// checkStaleness records the age of the oldest sample in the gathered metric, marking the metric as stale if it is
+// older than the MaxAge, returning a StaleMetricError instead if RejectStale is set. Metrics without a timestamp have
+// no age and are never stale.
+func (c *Gatherer) checkStaleness(log logr.Logger, gathered *metrics.Metric) (*metrics.Metric, error) {
+	timestamp := gathered.OldestTimestamp()
+	if timestamp.IsZero() {
+		return gathered, nil
+	}
+
+	age := c.clock().Since(timestamp)
+	gathered.Age = age
+	if c.MaxAge <= 0 || age <= c.MaxAge {
+		return gathered, nil
+	}
+
+	log.V(LogLevelDebug).Info("Metric is stale", "age", age, "maxAge", c.MaxAge, "rejected", c.RejectStale)
+	if c.RejectStale {
+		return nil, &StaleMetricError{
+			Spec:      gathered.Spec,
+			Timestamp: timestamp,
+			Age:       age,
+			MaxAge:    c.MaxAge,
+		}
+	}
+
+	gathered.Stale = true
+	return gathered, nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestGatherStaleness(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expected    *metrics.Metric
+		expectedErr error
+		gathered    podsmetrics.Metric
+		maxAge      time.Duration
+		rejectStale bool
+	}{
+		{
+			description: "No max age, old metric not stale",
+			expected: &metrics.Metric{
+				Spec: spec,
+				Pods: &podsmetrics.Metric{Timestamp: now.Add(-time.Hour)},
+				Age:  time.Hour,
+			},
+			gathered: podsmetrics.Metric{Timestamp: now.Add(-time.Hour)},
+		},
+		{
+			description: "No timestamp, not stale",
+			expected: &metrics.Metric{
+				Spec: spec,
+				Pods: &podsmetrics.Metric{},
+			},
+			maxAge: time.Minute,
+		},
+		{
+			description: "Within max age, not stale",
+			expected: &metrics.Metric{
+				Spec: spec,
+				Pods: &podsmetrics.Metric{Timestamp: now.Add(-time.Minute)},
+				Age:  time.Minute,
+			},
+			gathered: podsmetrics.Metric{Timestamp: now.Add(-time.Minute)},
+			maxAge:   time.Minute,
+		},
+		{
+			description: "Older than max age, marked stale",
+			expected: &metrics.Metric{
+				Spec:  spec,
+				Pods:  &podsmetrics.Metric{Timestamp: now.Add(-2 * time.Minute)},
+				Age:   2 * time.Minute,
+				Stale: true,
+			},
+			gathered: podsmetrics.Metric{Timestamp: now.Add(-2 * time.Minute)},
+			maxAge:   time.Minute,
+		},
+		{
+			description: "Oldest pod sample older than max age, marked stale",
+			expected: &metrics.Metric{
+				Spec: spec,
+				Pods: &podsmetrics.Metric{
+					Timestamp:       now.Add(-time.Minute),
+					OldestTimestamp: now.Add(-2 * time.Minute),
+					NewestTimestamp: now.Add(-30 * time.Second),
+				},
+				Age:   2 * time.Minute,
+				Stale: true,
+			},
+			gathered: podsmetrics.Metric{
+				Timestamp:       now.Add(-time.Minute),
+				OldestTimestamp: now.Add(-2 * time.Minute),
+				NewestTimestamp: now.Add(-30 * time.Second),
+			},
+			maxAge: time.Minute,
+		},
+		{
+			description: "Older than max age, rejected",
+			expectedErr: errors.New("stale Pods metric: metric is 2m0s old, exceeding maximum age of 1m0s"),
+			gathered:    podsmetrics.Metric{Timestamp: now.Add(-2 * time.Minute)},
+			maxAge:      time.Minute,
+			rejectStale: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			gatherer := &k8shorizmetrics.Gatherer{
+				Pods: &fake.PodsGatherer{
+					GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+						gathered := test.gathered
+						return &gathered, nil
+					},
+				},
+				MaxAge:      test.maxAge,
+				RejectStale: test.rejectStale,
+				Clock:       clocktesting.NewFakePassiveClock(now),
+			}
+
+			result, err := gatherer.GatherSingleMetric(spec, "test", labels.Everything())
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("metric mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+			if err != nil {
+				var staleErr *k8shorizmetrics.StaleMetricError
+				if !errors.As(err, &staleErr) || staleErr.Age != 2*time.Minute {
+					t.Errorf("expected StaleMetricError with age 2m, got %#v", err)
+				}
+			}
+		})
+	}
+}
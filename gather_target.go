@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/scaler"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// GatherForTarget returns all of the metrics gathered based on the metric specs provided for the scale target
+// provided, resolving the pods to gather metrics for using the selector in the status of the target's scale
+// subresource in the same way as the HPA controller. This requires the Gatherer's ScaleClient and RESTMapper to be
+// set.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError. If a partial error occurs,
+// meaning some metrics were gathered successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (c *Gatherer) GatherForTarget(specs []autoscalingv2.MetricSpec, namespace string,
+	scaleTargetRef autoscalingv2.CrossVersionObjectReference) ([]*metrics.Metric, error) {
+	return c.GatherForTargetWithContext(context.Background(), specs, namespace, scaleTargetRef)
+}
+
+// GatherForTargetWithContext returns all of the metrics gathered based on the metric specs provided for the scale
+// target provided, resolving the pods to gather metrics for using the selector in the status of the target's scale
+// subresource and passing the context provided to any gatherers that implement the context aware gatherer interfaces.
+// This requires the Gatherer's ScaleClient and RESTMapper to be set.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError. If a partial error occurs,
+// meaning some metrics were gathered successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (c *Gatherer) GatherForTargetWithContext(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string,
+	scaleTargetRef autoscalingv2.CrossVersionObjectReference) ([]*metrics.Metric, error) {
+	podSelector, err := c.TargetSelector(ctx, namespace, scaleTargetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GatherWithContext(ctx, specs, namespace, podSelector)
+}
+
+// TargetSelector returns the pod selector of the scale target provided, parsed from the selector in the status of the
+// target's scale subresource. This requires the Gatherer's ScaleClient and RESTMapper to be set.
+func (c *Gatherer) TargetSelector(ctx context.Context, namespace string,
+	scaleTargetRef autoscalingv2.CrossVersionObjectReference) (labels.Selector, error) {
+	if c.ScaleClient == nil {
+		return nil, errors.New("failed to get scale target selector: no scale client configured")
+	}
+
+	if c.RESTMapper == nil {
+		return nil, errors.New("failed to get scale target selector: no REST mapper configured")
+	}
+
+	scale, err := (&scaler.Scaler{
+		ScaleClient: c.ScaleClient,
+		RESTMapper:  c.RESTMapper,
+	}).GetScale(ctx, namespace, scaleTargetRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scale target selector: %w", err)
+	}
+
+	if scale.Status.Selector == "" {
+		return nil, fmt.Errorf("failed to get scale target selector: selector is required for %s %q", scaleTargetRef.Kind,
+			scaleTargetRef.Name)
+	}
+
+	podSelector, err := labels.Parse(scale.Status.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scale target selector: %w", err)
+	}
+
+	return podSelector, nil
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakescale "k8s.io/client-go/scale/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGatherForTarget(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	deploymentRef := autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "test-deployment",
+	}
+
+	podsSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: "test-metric",
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	scaleGetReactor := func(selector string) k8stesting.ReactionFunc {
+		return func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &autoscalingv1.Scale{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-deployment",
+					Namespace: "test-namespace",
+				},
+				Status: autoscalingv1.ScaleStatus{
+					Selector: selector,
+				},
+			}, nil
+		}
+	}
+
+	var tests = []struct {
+		description         string
+		expected            []*metrics.Metric
+		expectedErr         error
+		expectedPodSelector string
+		noScaleClient       bool
+		noRESTMapper        bool
+		scaleGetReactor     k8stesting.ReactionFunc
+		scaleTargetRef      autoscalingv2.CrossVersionObjectReference
+	}{
+		{
+			description:    "No scale client",
+			expectedErr:    errors.New("failed to get scale target selector: no scale client configured"),
+			noScaleClient:  true,
+			scaleTargetRef: deploymentRef,
+		},
+		{
+			description:    "No REST mapper",
+			expectedErr:    errors.New("failed to get scale target selector: no REST mapper configured"),
+			noRESTMapper:   true,
+			scaleTargetRef: deploymentRef,
+		},
+		{
+			description: "Unknown kind",
+			expectedErr: errors.New(`failed to get scale target selector: failed to map scale target to resource: no matches for kind "Unknown" in version "apps/v1"`),
+			scaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Unknown",
+				Name:       "test",
+			},
+		},
+		{
+			description: "Fail to get scale",
+			expectedErr: errors.New("failed to get scale target selector: failed to get scale subresource: fail to get scale"),
+			scaleGetReactor: func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("fail to get scale")
+			},
+			scaleTargetRef: deploymentRef,
+		},
+		{
+			description:     "Empty selector",
+			expectedErr:     errors.New(`failed to get scale target selector: selector is required for Deployment "test-deployment"`),
+			scaleGetReactor: scaleGetReactor(""),
+			scaleTargetRef:  deploymentRef,
+		},
+		{
+			description:     "Invalid selector",
+			expectedErr:     errors.New("failed to get scale target selector: unable to parse requirement: found '', expected: ',', ')' or identifier"),
+			scaleGetReactor: scaleGetReactor("app in ("),
+			scaleTargetRef:  deploymentRef,
+		},
+		{
+			description: "Success",
+			expected: []*metrics.Metric{
+				{
+					Spec: podsSpec,
+					Pods: &podsmetrics.Metric{},
+				},
+			},
+			expectedPodSelector: "app=test",
+			scaleGetReactor:     scaleGetReactor("app=test"),
+			scaleTargetRef:      deploymentRef,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+			restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+			scaleClient := &fakescale.FakeScaleClient{}
+			if test.scaleGetReactor != nil {
+				scaleClient.AddReactor("get", "deployments", test.scaleGetReactor)
+			}
+
+			var podSelector labels.Selector
+			gatherer := &k8shorizmetrics.Gatherer{
+				Pods: &fake.PodsGatherer{
+					GatherReactor: func(metricName, namespace string, selector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+						podSelector = selector
+						return &podsmetrics.Metric{}, nil
+					},
+				},
+				ScaleClient: scaleClient,
+				RESTMapper:  restMapper,
+			}
+			if test.noScaleClient {
+				gatherer.ScaleClient = nil
+			}
+			if test.noRESTMapper {
+				gatherer.RESTMapper = nil
+			}
+
+			result, err := gatherer.GatherForTarget([]autoscalingv2.MetricSpec{podsSpec}, "test-namespace", test.scaleTargetRef)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+
+			if test.expectedPodSelector != "" && podSelector.String() != test.expectedPodSelector {
+				t.Errorf("pod selector mismatch, want %s, got %s", test.expectedPodSelector, podSelector)
+			}
+		})
+	}
+}
+
+func TestGatherForTargetWithContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), testContextKey{}, "test")
+
+	var gotCtx context.Context
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	scaleClient := &fakescale.FakeScaleClient{}
+	scaleClient.AddReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &autoscalingv1.Scale{
+			Status: autoscalingv1.ScaleStatus{
+				Selector: "app=test",
+			},
+		}, nil
+	})
+
+	gatherer := &k8shorizmetrics.Gatherer{
+		Pods: &fake.PodsGathererWithContext{
+			GatherWithContextReactor: func(ctx context.Context, metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+				gotCtx = ctx
+				return &podsmetrics.Metric{}, nil
+			},
+		},
+		ScaleClient: scaleClient,
+		RESTMapper:  restMapper,
+	}
+
+	_, err := gatherer.GatherForTargetWithContext(ctx, []autoscalingv2.MetricSpec{
+		{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Target: autoscalingv2.MetricTarget{
+					Type: autoscalingv2.AverageValueMetricType,
+				},
+			},
+		},
+	}, "test-namespace", autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "test-deployment",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotCtx == nil || gotCtx.Value(testContextKey{}) != "test" {
+		t.Errorf("expected context to be passed to gatherer")
+	}
+}
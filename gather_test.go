@@ -17,13 +17,16 @@ limitations under the License.
 package k8shorizmetrics_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/jthomperoo/k8shorizmetrics/v4"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
 	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
@@ -32,11 +35,15 @@ import (
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	k8sscale "k8s.io/client-go/scale"
 )
 
@@ -330,8 +337,8 @@ func TestGatherSingleMetricWithOptions(t *testing.T) {
 			namespace: "test",
 		},
 		{
-			description:                   "Pods Metric: Target not average value",
-			expectedErr:                   errors.New(`invalid pods metric source: must be average value`),
+			description:                   "Pods Metric: Target not value or average value",
+			expectedErr:                   errors.New(`invalid pods metric source: must be either value or average value`),
 			cpuInitializationPeriod:       0,
 			delayOfInitialReadinessStatus: 0,
 			spec: autoscalingv2.MetricSpec{
@@ -341,7 +348,7 @@ func TestGatherSingleMetricWithOptions(t *testing.T) {
 						Selector: metav1.SetAsLabelSelector(labels.Set{}),
 					},
 					Target: autoscalingv2.MetricTarget{
-						Type: autoscalingv2.ValueMetricType,
+						Type: autoscalingv2.UtilizationMetricType,
 					},
 				},
 			},
@@ -432,7 +439,7 @@ func TestGatherSingleMetricWithOptions(t *testing.T) {
 		},
 		{
 			description:                   "Resource Metric: No target",
-			expectedErr:                   errors.New(`invalid resource metric source: must be either average value or average utilization`),
+			expectedErr:                   errors.New(`invalid resource metric source: must be either value, average value or average utilization`),
 			cpuInitializationPeriod:       0,
 			delayOfInitialReadinessStatus: 0,
 			spec: autoscalingv2.MetricSpec{
@@ -442,15 +449,15 @@ func TestGatherSingleMetricWithOptions(t *testing.T) {
 			namespace: "test",
 		},
 		{
-			description:                   "Resource Metric: Target not average value or average utilization",
-			expectedErr:                   errors.New(`invalid resource metric source: must be either average value or average utilization`),
+			description:                   "Resource Metric: Target not value, average value or average utilization",
+			expectedErr:                   errors.New(`invalid resource metric source: must be either value, average value or average utilization`),
 			cpuInitializationPeriod:       0,
 			delayOfInitialReadinessStatus: 0,
 			spec: autoscalingv2.MetricSpec{
 				Type: autoscalingv2.ResourceMetricSourceType,
 				Resource: &autoscalingv2.ResourceMetricSource{
 					Target: autoscalingv2.MetricTarget{
-						Type: autoscalingv2.ValueMetricType,
+						Type: autoscalingv2.MetricTargetType("invalid"),
 					},
 				},
 			},
@@ -496,6 +503,60 @@ func TestGatherSingleMetricWithOptions(t *testing.T) {
 			},
 			namespace: "test",
 		},
+		{
+			description: "Resource Metric: Value success",
+			expected: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							Type: autoscalingv2.ValueMetricType,
+						},
+					},
+				},
+				Resource: &resource.Metric{
+					ReadyPodCount: 2,
+					IgnoredPods:   sets.String{},
+					MissingPods:   sets.String{},
+					TotalPods:     2,
+					Timestamp:     time.Time{},
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"test": podmetrics.Metric{
+							Value:     10,
+							Timestamp: time.Time{},
+						},
+					},
+				},
+			},
+			resource: &fake.ResourceGatherer{
+				GatherRawReactor: func(resourceName corev1.ResourceName, namespace string, podSelector labels.Selector, cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (*resource.Metric, error) {
+					return &resource.Metric{
+						ReadyPodCount: 2,
+						IgnoredPods:   sets.String{},
+						MissingPods:   sets.String{},
+						TotalPods:     2,
+						Timestamp:     time.Time{},
+						PodMetricsInfo: podmetrics.MetricsInfo{
+							"test": podmetrics.Metric{
+								Value:     10,
+								Timestamp: time.Time{},
+							},
+						},
+					}, nil
+				},
+			},
+			cpuInitializationPeriod:       0,
+			delayOfInitialReadinessStatus: 0,
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						Type: autoscalingv2.ValueMetricType,
+					},
+				},
+			},
+			namespace: "test",
+		},
 		{
 			description: "Resource Metric: Average value success",
 			expected: &metrics.Metric{
@@ -1454,3 +1515,780 @@ func TestGather(t *testing.T) {
 		})
 	}
 }
+
+func TestGatherV2Beta2(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    []*metrics.Metric
+		expectedErr *k8shorizmetrics.GathererMultiMetricError
+		pods        k8shorizmetrics.PodsGatherer
+		specs       []autoscalingv2beta2.MetricSpec
+		namespace   string
+		podSelector labels.Selector
+	}{
+		{
+			description: "Full failure",
+			expectedErr: &k8shorizmetrics.GathererMultiMetricError{
+				Partial: false,
+				Errors: []error{
+					errors.New(`failed to get pods metric: test error`),
+				},
+			},
+			pods: &fake.PodsGatherer{
+				GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*pods.Metric, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			specs: []autoscalingv2beta2.MetricSpec{
+				{
+					Type: autoscalingv2beta2.PodsMetricSourceType,
+					Pods: &autoscalingv2beta2.PodsMetricSource{
+						Target: autoscalingv2beta2.MetricTarget{
+							Type: autoscalingv2beta2.AverageValueMetricType,
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "Success, spec converted to autoscaling/v2",
+			expected: []*metrics.Metric{
+				{
+					Spec: autoscalingv2.MetricSpec{
+						Type: autoscalingv2.PodsMetricSourceType,
+						Pods: &autoscalingv2.PodsMetricSource{
+							Metric: autoscalingv2.MetricIdentifier{
+								Name: "test-metric",
+							},
+							Target: autoscalingv2.MetricTarget{
+								Type: autoscalingv2.AverageValueMetricType,
+							},
+						},
+					},
+					Pods: &pods.Metric{
+						ReadyPodCount: 1,
+						TotalPods:     1,
+					},
+				},
+			},
+			pods: &fake.PodsGatherer{
+				GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*pods.Metric, error) {
+					return &pods.Metric{
+						ReadyPodCount: 1,
+						TotalPods:     1,
+					}, nil
+				},
+			},
+			specs: []autoscalingv2beta2.MetricSpec{
+				{
+					Type: autoscalingv2beta2.PodsMetricSourceType,
+					Pods: &autoscalingv2beta2.PodsMetricSource{
+						Metric: autoscalingv2beta2.MetricIdentifier{
+							Name: "test-metric",
+						},
+						Target: autoscalingv2beta2.MetricTarget{
+							Type: autoscalingv2beta2.AverageValueMetricType,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			gatherer := &k8shorizmetrics.Gatherer{
+				Pods: test.pods,
+			}
+			metric, err := gatherer.GatherV2Beta2(test.specs, test.namespace, test.podSelector)
+			gatherErr := &k8shorizmetrics.GathererMultiMetricError{}
+
+			if err == nil && test.expectedErr != nil {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr.Error(), gatherErr.Error()))
+				return
+			}
+
+			if err != nil {
+				if errors.As(err, &gatherErr) {
+					if !cmp.Equal(gatherErr.Partial, test.expectedErr.Partial) {
+						t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(&test.expectedErr.Partial, gatherErr.Partial))
+						return
+					}
+
+					if !cmp.Equal(gatherErr.Error(), test.expectedErr.Error()) {
+						t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr.Error(), gatherErr.Error()))
+						return
+					}
+				} else {
+					t.Error("unexpected error type returned, expected GathererMutliMetricError")
+					return
+				}
+			}
+
+			if !cmp.Equal(test.expected, metric) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric))
+			}
+		})
+	}
+}
+
+func TestGatherForHPAV1(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description string
+		expected    []*metrics.Metric
+		expectedErr error
+		resource    k8shorizmetrics.ResourceGatherer
+		hpa         *autoscalingv1.HorizontalPodAutoscaler
+		podSelector labels.Selector
+	}{
+		{
+			description: "Failure",
+			expectedErr: errors.New("gatherer multi metric error: 1 errors, first error is failed to get resource metric: test error"),
+			resource: &fake.ResourceGatherer{
+				GatherReactor: func(resourceName corev1.ResourceName, namespace string, podSelector labels.Selector, cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (*resource.Metric, error) {
+					return nil, errors.New("test error")
+				},
+			},
+			hpa: &autoscalingv1.HorizontalPodAutoscaler{},
+		},
+		{
+			description: "Success, gather CPU utilization in HPA namespace",
+			expected: []*metrics.Metric{
+				{
+					Spec: autoscalingv2.MetricSpec{
+						Type: autoscalingv2.ResourceMetricSourceType,
+						Resource: &autoscalingv2.ResourceMetricSource{
+							Name: corev1.ResourceCPU,
+							Target: autoscalingv2.MetricTarget{
+								Type:               autoscalingv2.UtilizationMetricType,
+								AverageUtilization: testutil.Int32Ptr(50),
+							},
+						},
+					},
+					Resource: &resource.Metric{
+						TotalPods: 1,
+					},
+				},
+			},
+			resource: &fake.ResourceGatherer{
+				GatherReactor: func(resourceName corev1.ResourceName, namespace string, podSelector labels.Selector, cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (*resource.Metric, error) {
+					if namespace != "test-namespace" {
+						return nil, errors.New("unexpected namespace")
+					}
+					return &resource.Metric{
+						TotalPods: 1,
+					}, nil
+				},
+			},
+			hpa: &autoscalingv1.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-namespace",
+				},
+				Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+					TargetCPUUtilizationPercentage: testutil.Int32Ptr(50),
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			gatherer := &k8shorizmetrics.Gatherer{
+				Resource: test.resource,
+			}
+			metric, err := gatherer.GatherForHPAV1(test.hpa, test.podSelector)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, metric) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric))
+			}
+		})
+	}
+}
+
+type testContextKey struct{}
+
+func TestGatherSingleMetricWithContext(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description string
+		expected    *metrics.Metric
+		expectedErr error
+		resource    k8shorizmetrics.ResourceGatherer
+		pods        k8shorizmetrics.PodsGatherer
+		object      k8shorizmetrics.ObjectGatherer
+		external    k8shorizmetrics.ExternalGatherer
+		ctx         context.Context
+		spec        autoscalingv2.MetricSpec
+	}{
+		{
+			description: "Resource gatherer without context support, fall back to non context method",
+			expected: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							Type: autoscalingv2.UtilizationMetricType,
+						},
+					},
+				},
+				Resource: &resource.Metric{
+					TotalPods: 1,
+				},
+			},
+			resource: &fake.ResourceGatherer{
+				GatherReactor: func(resourceName corev1.ResourceName, namespace string, podSelector labels.Selector, cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (*resource.Metric, error) {
+					return &resource.Metric{
+						TotalPods: 1,
+					}, nil
+				},
+			},
+			ctx: context.Background(),
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						Type: autoscalingv2.UtilizationMetricType,
+					},
+				},
+			},
+		},
+		{
+			description: "Resource gatherer with context support, prefer context method",
+			expected: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							Type: autoscalingv2.AverageValueMetricType,
+						},
+					},
+				},
+				Resource: &resource.Metric{
+					TotalPods: 2,
+				},
+			},
+			resource: &fake.ResourceGathererWithContext{
+				ResourceGatherer: fake.ResourceGatherer{
+					GatherRawReactor: func(resourceName corev1.ResourceName, namespace string, podSelector labels.Selector, cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (*resource.Metric, error) {
+						return nil, errors.New("should not be called")
+					},
+				},
+				GatherRawWithContextReactor: func(ctx context.Context, resourceName corev1.ResourceName, namespace string, podSelector labels.Selector, cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (*resource.Metric, error) {
+					if ctx.Value(testContextKey{}) != "test" {
+						return nil, errors.New("context not passed through")
+					}
+					return &resource.Metric{
+						TotalPods: 2,
+					}, nil
+				},
+			},
+			ctx: context.WithValue(context.Background(), testContextKey{}, "test"),
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						Type: autoscalingv2.AverageValueMetricType,
+					},
+				},
+			},
+		},
+		{
+			description: "Pods gatherer with context support, error",
+			expectedErr: errors.New("failed to get pods metric: context canceled"),
+			pods: &fake.PodsGathererWithContext{
+				GatherWithContextReactor: func(ctx context.Context, metricName, namespace string, podSelector, metricSelector labels.Selector) (*pods.Metric, error) {
+					return nil, ctx.Err()
+				},
+			},
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			}(),
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						Type: autoscalingv2.AverageValueMetricType,
+					},
+				},
+			},
+		},
+		{
+			description: "Object gatherer with context support, success",
+			expected: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ObjectMetricSourceType,
+					Object: &autoscalingv2.ObjectMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							Type: autoscalingv2.ValueMetricType,
+						},
+					},
+				},
+				Object: &object.Metric{
+					Current: value.MetricValue{
+						Value: testutil.Int64Ptr(5),
+					},
+				},
+			},
+			object: &fake.ObjectGathererWithContext{
+				GatherWithContextReactor: func(ctx context.Context, metricName, namespace string, objectRef *autoscalingv2.CrossVersionObjectReference, podSelector, metricSelector labels.Selector) (*object.Metric, error) {
+					return &object.Metric{
+						Current: value.MetricValue{
+							Value: testutil.Int64Ptr(5),
+						},
+					}, nil
+				},
+			},
+			ctx: context.Background(),
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ObjectMetricSourceType,
+				Object: &autoscalingv2.ObjectMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						Type: autoscalingv2.ValueMetricType,
+					},
+				},
+			},
+		},
+		{
+			description: "External gatherer with context support, success",
+			expected: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							Type: autoscalingv2.AverageValueMetricType,
+						},
+					},
+				},
+				External: &external.Metric{
+					Current: value.MetricValue{
+						AverageValue: testutil.Int64Ptr(3),
+					},
+				},
+			},
+			external: &fake.ExternalGathererWithContext{
+				GatherPerPodWithContextReactor: func(ctx context.Context, metricName, namespace string, metricSelector *metav1.LabelSelector) (*external.Metric, error) {
+					return &external.Metric{
+						Current: value.MetricValue{
+							AverageValue: testutil.Int64Ptr(3),
+						},
+					}, nil
+				},
+			},
+			ctx: context.Background(),
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{
+					Target: autoscalingv2.MetricTarget{
+						Type: autoscalingv2.AverageValueMetricType,
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			gatherer := &k8shorizmetrics.Gatherer{
+				External: test.external,
+				Object:   test.object,
+				Pods:     test.pods,
+				Resource: test.resource,
+			}
+			metric, err := gatherer.GatherSingleMetricWithContext(test.ctx, test.spec, "test-namespace", nil)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, metric) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric))
+			}
+		})
+	}
+}
+
+func TestGatherWithContextAndOptions(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.UtilizationMetricType,
+			},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expected    []*metrics.Metric
+		expectedErr error
+		ctx         context.Context
+	}{
+		{
+			description: "Context and options passed through",
+			expected: []*metrics.Metric{
+				{
+					Spec: spec,
+					Resource: &resource.Metric{
+						TotalPods: 3,
+					},
+				},
+			},
+			ctx: context.WithValue(context.Background(), testContextKey{}, "test"),
+		},
+		{
+			description: "Context cancelled",
+			expectedErr: &k8shorizmetrics.GathererMultiMetricError{
+				Partial: false,
+				Errors: []error{
+					errors.New("failed to get resource metric: context canceled"),
+				},
+			},
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.WithValue(context.Background(), testContextKey{}, "test"))
+				cancel()
+				return ctx
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			gatherer := &k8shorizmetrics.Gatherer{
+				Resource: &fake.ResourceGathererWithContext{
+					GatherWithContextReactor: func(ctx context.Context, resourceName corev1.ResourceName, namespace string, podSelector labels.Selector, cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (*resource.Metric, error) {
+						if ctx.Value(testContextKey{}) != "test" {
+							return nil, errors.New("context not passed through")
+						}
+						if cpuInitializationPeriod != time.Minute || delayOfInitialReadinessStatus != time.Second {
+							return nil, errors.New("options not passed through")
+						}
+						if err := ctx.Err(); err != nil {
+							return nil, err
+						}
+						return &resource.Metric{
+							TotalPods: 3,
+						}, nil
+					},
+				},
+				CPUInitializationPeriod:       time.Hour,
+				DelayOfInitialReadinessStatus: time.Hour,
+			}
+			gathered, err := gatherer.GatherWithContextAndOptions(test.ctx, []autoscalingv2.MetricSpec{spec}, "test-namespace", nil, time.Minute, time.Second)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, gathered) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, gathered))
+			}
+		})
+	}
+}
+
+func TestGatherConcurrency(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	podsSpec := func(name string) autoscalingv2.MetricSpec {
+		return autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{
+					Name: name,
+				},
+				Target: autoscalingv2.MetricTarget{
+					Type: autoscalingv2.AverageValueMetricType,
+				},
+			},
+		}
+	}
+
+	var tests = []struct {
+		description         string
+		expected            []*metrics.Metric
+		expectedErr         error
+		expectedMaxInFlight int
+		concurrency         int
+		specs               []autoscalingv2.MetricSpec
+	}{
+		{
+			description: "Sequential, no concurrency",
+			expected: []*metrics.Metric{
+				{Spec: podsSpec("40"), Pods: &pods.Metric{TotalPods: 40}},
+				{Spec: podsSpec("30"), Pods: &pods.Metric{TotalPods: 30}},
+				{Spec: podsSpec("20"), Pods: &pods.Metric{TotalPods: 20}},
+			},
+			expectedMaxInFlight: 1,
+			concurrency:         0,
+			specs:               []autoscalingv2.MetricSpec{podsSpec("40"), podsSpec("30"), podsSpec("20")},
+		},
+		{
+			description: "Concurrency limited to 2, results in spec order",
+			expected: []*metrics.Metric{
+				{Spec: podsSpec("40"), Pods: &pods.Metric{TotalPods: 40}},
+				{Spec: podsSpec("30"), Pods: &pods.Metric{TotalPods: 30}},
+				{Spec: podsSpec("20"), Pods: &pods.Metric{TotalPods: 20}},
+				{Spec: podsSpec("10"), Pods: &pods.Metric{TotalPods: 10}},
+			},
+			expectedMaxInFlight: 2,
+			concurrency:         2,
+			specs:               []autoscalingv2.MetricSpec{podsSpec("40"), podsSpec("30"), podsSpec("20"), podsSpec("10")},
+		},
+		{
+			description: "Concurrency greater than specs, partial errors in spec order",
+			expected: []*metrics.Metric{
+				{Spec: podsSpec("20"), Pods: &pods.Metric{TotalPods: 20}},
+			},
+			expectedErr: &k8shorizmetrics.GathererMultiMetricError{
+				Partial: true,
+				Errors: []error{
+					errors.New("failed to get pods metric: fail 30"),
+					errors.New("failed to get pods metric: fail 10"),
+				},
+			},
+			expectedMaxInFlight: 3,
+			concurrency:         10,
+			specs:               []autoscalingv2.MetricSpec{podsSpec("fail 30"), podsSpec("20"), podsSpec("fail 10")},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var mu sync.Mutex
+			inFlight := 0
+			maxInFlight := 0
+
+			gatherer := &k8shorizmetrics.Gatherer{
+				Pods: &fake.PodsGatherer{
+					GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*pods.Metric, error) {
+						mu.Lock()
+						inFlight++
+						if inFlight > maxInFlight {
+							maxInFlight = inFlight
+						}
+						mu.Unlock()
+
+						defer func() {
+							mu.Lock()
+							inFlight--
+							mu.Unlock()
+						}()
+
+						// Earlier specs take longer to gather, so finish out of order when gathered concurrently
+						var duration int
+						failed := false
+						if _, err := fmt.Sscanf(metricName, "fail %d", &duration); err == nil {
+							failed = true
+						} else if _, err := fmt.Sscanf(metricName, "%d", &duration); err != nil {
+							return nil, err
+						}
+						time.Sleep(time.Duration(duration) * time.Millisecond)
+
+						if failed {
+							return nil, errors.New(metricName)
+						}
+						return &pods.Metric{TotalPods: duration}, nil
+					},
+				},
+				Concurrency: test.concurrency,
+			}
+
+			gathered, err := gatherer.Gather(test.specs, "test-namespace", labels.Everything())
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, gathered) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, gathered))
+			}
+			if maxInFlight != test.expectedMaxInFlight {
+				t.Errorf("max in flight mismatch, want %d, got %d", test.expectedMaxInFlight, maxInFlight)
+			}
+		})
+	}
+}
+
+func TestGatherMetricSpecErrors(t *testing.T) {
+	failingSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: "fail",
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+	succeedingSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: "succeed",
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+	unknownSpec := autoscalingv2.MetricSpec{
+		Type: "unknown",
+	}
+
+	gatherErr := errors.New("fail to gather")
+	gatherer := &k8shorizmetrics.Gatherer{
+		Pods: &fake.PodsGatherer{
+			GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*pods.Metric, error) {
+				if metricName == "fail" {
+					return nil, gatherErr
+				}
+				return &pods.Metric{}, nil
+			},
+		},
+	}
+
+	_, err := gatherer.Gather([]autoscalingv2.MetricSpec{succeedingSpec, failingSpec, unknownSpec}, "test", labels.Everything())
+
+	var multiErr *k8shorizmetrics.GathererMultiMetricError
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("expected GathererMultiMetricError, got %v", err)
+	}
+
+	specErrors := multiErr.MetricSpecErrors()
+	if len(specErrors) != 2 {
+		t.Fatalf("expected 2 metric spec errors, got %d", len(specErrors))
+	}
+
+	if specErrors[0].Index != 1 || !cmp.Equal(failingSpec, specErrors[0].Spec) {
+		t.Errorf("first error mismatch, want index 1 and spec %v, got index %d and spec %v", failingSpec,
+			specErrors[0].Index, specErrors[0].Spec)
+	}
+	if !errors.Is(specErrors[0], gatherErr) {
+		t.Errorf("expected first error to wrap gather error, got %v", specErrors[0])
+	}
+	if specErrors[0].Error() != "failed to get pods metric: fail to gather" {
+		t.Errorf("first error message mismatch, got %q", specErrors[0].Error())
+	}
+
+	if specErrors[1].Index != 2 || !cmp.Equal(unknownSpec, specErrors[1].Spec) {
+		t.Errorf("second error mismatch, want index 2 and spec %v, got index %d and spec %v", unknownSpec,
+			specErrors[1].Index, specErrors[1].Spec)
+	}
+}
+
+func TestGatherReusesPodList(t *testing.T) {
+	lists := 0
+	podLister := &fake.PodLister{
+		PodsReactor: func(namespace string) corelisters.PodNamespaceLister {
+			return &fake.PodNamespaceLister{
+				ListReactor: func(selector labels.Selector) (ret []*corev1.Pod, err error) {
+					lists++
+					return []*corev1.Pod{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-pod",
+								Namespace: namespace,
+							},
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Resources: corev1.ResourceRequirements{
+											Requests: corev1.ResourceList{
+												corev1.ResourceCPU:    k8sresource.MustParse("100m"),
+												corev1.ResourceMemory: k8sresource.MustParse("100Mi"),
+											},
+										},
+									},
+								},
+							},
+							Status: corev1.PodStatus{
+								Phase: corev1.PodRunning,
+							},
+						},
+					}, nil
+				},
+			}
+		},
+	}
+	metricsClient := &fake.MetricsClient{
+		GetResourceMetricReactor: func(resource corev1.ResourceName, namespace string, selector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+			return podmetrics.MetricsInfo{"test-pod": podmetrics.Metric{Value: 50}}, time.Time{}, nil
+		},
+		GetRawMetricReactor: func(metricName string, namespace string, selector labels.Selector, metricSelector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+			return podmetrics.MetricsInfo{"test-pod": podmetrics.Metric{Value: 50}}, time.Time{}, nil
+		},
+	}
+
+	gatherer := k8shorizmetrics.NewGatherer(metricsClient, podLister, 0, 0)
+
+	specs := []autoscalingv2.MetricSpec{
+		{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type: autoscalingv2.UtilizationMetricType,
+				},
+			},
+		},
+		{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceMemory,
+				Target: autoscalingv2.MetricTarget{
+					Type: autoscalingv2.UtilizationMetricType,
+				},
+			},
+		},
+		{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{
+					Name: "test-metric",
+				},
+				Target: autoscalingv2.MetricTarget{
+					Type: autoscalingv2.AverageValueMetricType,
+				},
+			},
+		},
+	}
+
+	_, err := gatherer.Gather(specs, "test-namespace", labels.SelectorFromSet(labels.Set{"app": "test"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lists != 1 {
+		t.Errorf("expected pods to be listed once for all specs, got %d lists", lists)
+	}
+
+	_, err = gatherer.Gather(specs, "test-namespace", labels.SelectorFromSet(labels.Set{"app": "test"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lists != 2 {
+		t.Errorf("expected pods to be listed again for a separate gather, got %d lists", lists)
+	}
+}
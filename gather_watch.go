@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// DefaultWatchInterval is the interval between gathers used by Watch if no interval is provided, matching the
+	// default sync period of the HPA controller
+	DefaultWatchInterval = 15 * time.Second
+	// MaxWatchBackoff is the longest Watch will wait between gathers after repeated failures, unless the interval
+	// provided is longer
+	MaxWatchBackoff = 5 * time.Minute
+)
+
+// Snapshot is the result of a single gather made by Watch. If some metrics failed to be gathered Err is set alongside
+// the metrics that were gathered, if every metric failed Metrics is empty.
+type Snapshot struct {
+	Time    time.Time
+	Metrics []*metrics.Metric
+	Err     error
+}
+
+// Watch gathers metrics based on the metric specs provided immediately and then every interval, sending a snapshot of
+// each gather on the returned channel until the context is cancelled, after which the channel is closed. If the
+// interval is not positive DefaultWatchInterval is used.
+// A snapshot is only sent if its metrics or error differ from the last snapshot sent, so consumers are not sent
+// duplicate results when the metrics API has not updated between gathers. If every metric fails to be gathered on
+// consecutive gathers the wait between them is doubled for each further failure, up to MaxWatchBackoff.
+// If the Gatherer's Clock implements clock.Clock it is used to time gathers, otherwise the real clock is used.
+func (c *Gatherer) Watch(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector, interval time.Duration) <-chan Snapshot {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	watchClock, ok := c.Clock.(clock.Clock)
+	if !ok {
+		watchClock = clock.RealClock{}
+	}
+
+	snapshots := make(chan Snapshot)
+	go func() {
+		defer close(snapshots)
+
+		var last *Snapshot
+		failures := 0
+		for {
+			now := watchClock.Now()
+			gathered, err := c.GatherWithContext(ctx, specs, namespace, podSelector)
+			if ctx.Err() != nil {
+				return
+			}
+
+			snapshot := Snapshot{
+				Time:    now,
+				Metrics: gathered,
+				Err:     err,
+			}
+			if last == nil || !snapshot.equal(last) {
+				select {
+				case snapshots <- snapshot:
+				case <-ctx.Done():
+					return
+				}
+				last = &snapshot
+			}
+
+			wait := interval
+			if err != nil && !IsPartial(err) {
+				wait = watchBackoff(interval, failures)
+				failures++
+				resolveLogger(ctx, c.Logger).V(LogLevelDebug).Info("Backing off metric watch", "namespace", namespace,
+					"wait", wait, "error", err.Error())
+			} else {
+				failures = 0
+			}
+
+			timer := watchClock.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C():
+			}
+		}
+	}()
+
+	return snapshots
+}
+
+// snapshotEquality compares snapshot metrics, comparing times by the instant they represent
+var snapshotEquality = func() conversion.Equalities {
+	equalities := equality.Semantic.Copy()
+	if err := equalities.AddFunc(func(a, b time.Time) bool {
+		return a.Equal(b)
+	}); err != nil {
+		panic(err)
+	}
+	return equalities
+}()
+
+// equal returns true if the snapshots have the same metrics and error, ignoring the time they were taken and the age
+// and provenance of the metrics, which change each gather even if the metrics have not been updated
+func (s *Snapshot) equal(other *Snapshot) bool {
+	if (s.Err == nil) != (other.Err == nil) {
+		return false
+	}
+	if s.Err != nil && s.Err.Error() != other.Err.Error() {
+		return false
+	}
+	if len(s.Metrics) != len(other.Metrics) {
+		return false
+	}
+	for i := range s.Metrics {
+		if (s.Metrics[i] == nil) != (other.Metrics[i] == nil) {
+			return false
+		}
+		if s.Metrics[i] == nil {
+			continue
+		}
+		if !snapshotEquality.DeepEqual(withoutVolatile(*s.Metrics[i]), withoutVolatile(*other.Metrics[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// withoutVolatile returns a shallow copy of the metric with the fields that change each gather cleared, the age and the
+// provenance, which includes the duration of the API call
+func withoutVolatile(metric metrics.Metric) metrics.Metric {
+	metric.Age = 0
+	if metric.Resource != nil {
+		resourceMetric := *metric.Resource
+		resourceMetric.Provenance = nil
+		metric.Resource = &resourceMetric
+	}
+	if metric.Pods != nil {
+		podsMetric := *metric.Pods
+		podsMetric.Provenance = nil
+		metric.Pods = &podsMetric
+	}
+	if metric.Object != nil {
+		objectMetric := *metric.Object
+		objectMetric.Provenance = nil
+		metric.Object = &objectMetric
+	}
+	if metric.External != nil {
+		externalMetric := *metric.External
+		externalMetric.Provenance = nil
+		metric.External = &externalMetric
+	}
+	return metric
+}
+
+// watchBackoff returns the wait before the next gather after the number of consecutive failures provided, doubling
+// the interval for each failure up to MaxWatchBackoff
+func watchBackoff(interval time.Duration, failures int) time.Duration {
+	maxWait := MaxWatchBackoff
+	if interval > maxWait {
+		maxWait = interval
+	}
+
+	wait := interval
+	for i := 0; i < failures && wait < maxWait; i++ {
+		wait *= 2
+	}
+	if wait > maxWait {
+		wait = maxWait
+	}
+	return wait
+}
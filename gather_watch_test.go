@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestGathererWatch(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	interval := 10 * time.Second
+
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: "requests",
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	gatherErr := &k8shorizmetrics.GathererMultiMetricError{
+		Errors: []error{&k8shorizmetrics.MetricSpecError{
+			Err: errors.New("failed to get pods metric: fail"),
+		}},
+	}
+
+	snapshotMetrics := func(readyPodCount int64, age time.Duration) []*metrics.Metric {
+		return []*metrics.Metric{
+			{
+				Spec: spec,
+				Pods: &podsmetrics.Metric{ReadyPodCount: readyPodCount, Timestamp: start},
+				Age:  age,
+			},
+		}
+	}
+
+	provenanceMetrics := func(readyPodCount int64, age time.Duration, duration time.Duration) []*metrics.Metric {
+		gathered := snapshotMetrics(readyPodCount, age)
+		gathered[0].Pods.Provenance = &provenance.Provenance{Source: provenance.SourceCustom, Duration: duration}
+		return gathered
+	}
+
+	var tests = []struct {
+		description     string
+		expected        []k8shorizmetrics.Snapshot
+		expectedGathers []time.Time
+		results         []error
+		readyPodCounts  []int64
+		durations       []time.Duration
+	}{
+		{
+			description: "Skip unchanged snapshots, ignoring the age of metrics",
+			expected: []k8shorizmetrics.Snapshot{
+				{Time: start, Metrics: snapshotMetrics(1, 0)},
+				{Time: start.Add(2 * interval), Metrics: snapshotMetrics(2, 2*interval)},
+				{Time: start.Add(3 * interval), Metrics: snapshotMetrics(1, 3*interval)},
+			},
+			expectedGathers: []time.Time{
+				start,
+				start.Add(interval),
+				start.Add(2 * interval),
+				start.Add(3 * interval),
+				start.Add(4 * interval),
+			},
+			results:        []error{nil, nil, nil, nil, nil},
+			readyPodCounts: []int64{1, 1, 2, 1, 1},
+		},
+		{
+			description: "Skip unchanged snapshots, ignoring the provenance of metrics",
+			expected: []k8shorizmetrics.Snapshot{
+				{Time: start, Metrics: provenanceMetrics(1, 0, time.Millisecond)},
+				{Time: start.Add(2 * interval), Metrics: provenanceMetrics(2, 2*interval, 3*time.Millisecond)},
+			},
+			expectedGathers: []time.Time{
+				start,
+				start.Add(interval),
+				start.Add(2 * interval),
+				start.Add(3 * interval),
+			},
+			results:        []error{nil, nil, nil, nil},
+			readyPodCounts: []int64{1, 1, 2, 2},
+			durations:      []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond, 4 * time.Millisecond},
+		},
+		{
+			description: "Back off after consecutive failures",
+			expected: []k8shorizmetrics.Snapshot{
+				{Time: start, Err: gatherErr},
+				{Time: start.Add(7 * interval), Metrics: snapshotMetrics(1, 7*interval)},
+			},
+			expectedGathers: []time.Time{
+				start,
+				start.Add(interval),
+				start.Add(3 * interval),
+				start.Add(7 * interval),
+				start.Add(8 * interval),
+			},
+			results:        []error{errors.New("fail"), errors.New("fail"), errors.New("fail"), nil, nil},
+			readyPodCounts: []int64{0, 0, 0, 1, 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakeClock(start)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			gathers := []time.Time{}
+			gatherer := &k8shorizmetrics.Gatherer{
+				Pods: &fake.PodsGatherer{
+					GatherReactor: func(metricName, namespace string, podSelector,
+						metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+						call := len(gathers)
+						gathers = append(gathers, fakeClock.Now())
+						if call == len(test.results)-1 {
+							// Stop watching once the last result has been gathered
+							cancel()
+						}
+						if test.results[call] != nil {
+							return nil, test.results[call]
+						}
+						gathered := &podsmetrics.Metric{ReadyPodCount: test.readyPodCounts[call], Timestamp: start}
+						if test.durations != nil {
+							gathered.Provenance = &provenance.Provenance{
+								Source:   provenance.SourceCustom,
+								Duration: test.durations[call],
+							}
+						}
+						return gathered, nil
+					},
+				},
+				Clock: fakeClock,
+			}
+
+			snapshots := gatherer.Watch(ctx, []autoscalingv2.MetricSpec{spec}, "default", labels.Everything(), interval)
+
+			// Move the clock forward each time the watch is waiting for the next gather
+			go func() {
+				for ctx.Err() == nil {
+					if fakeClock.HasWaiters() {
+						fakeClock.Step(interval)
+					}
+					time.Sleep(time.Millisecond)
+				}
+			}()
+
+			result := []k8shorizmetrics.Snapshot{}
+			for snapshot := range snapshots {
+				result = append(result, snapshot)
+			}
+
+			if !cmp.Equal(test.expected, result, equateErrorMessage) {
+				t.Errorf("snapshots mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, equateErrorMessage))
+			}
+
+			if !cmp.Equal(test.expectedGathers, gathers) {
+				t.Errorf("gathers mismatch (-want +got):\n%s", cmp.Diff(test.expectedGathers, gathers))
+			}
+		})
+	}
+}
+
+func TestGathererWatchCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	gatherer := &k8shorizmetrics.Gatherer{
+		Pods: &fake.PodsGatherer{
+			GatherReactor: func(metricName, namespace string, podSelector,
+				metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+				return &podsmetrics.Metric{}, nil
+			},
+		},
+		Clock: clocktesting.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	snapshots := gatherer.Watch(ctx, []autoscalingv2.MetricSpec{
+		k8shorizmetrics.NewPodsAverageValueSpec("requests", resource.MustParse("1"), nil),
+	}, "default", labels.Everything(), 0)
+
+	if _, ok := <-snapshots; !ok {
+		t.Fatalf("expected first snapshot before the watch is cancelled")
+	}
+
+	cancel()
+
+	if _, ok := <-snapshots; ok {
+		t.Errorf("expected snapshots channel to be closed after the watch is cancelled")
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Workload is a set of metric specs to gather for the pods matched by the pod selector, used to gather metrics for
+// multiple workloads at once with GatherWorkloads.
+type Workload struct {
+	Specs       []autoscalingv2.MetricSpec
+	PodSelector labels.Selector
+}
+
+// WorkloadResult is the result of gathering the metrics for a single workload. If an error occurs gathering any
+// metric Err will be a GathererMultiMetricError, with any successfully gathered metrics still included in Metrics if
+// the error is partial.
+type WorkloadResult struct {
+	Metrics []*metrics.Metric
+	Err     error
+}
+
+type workloadRequest struct {
+	spec        autoscalingv2.MetricSpec
+	podSelector labels.Selector
+}
+
+// GatherWorkloads returns the metrics gathered for multiple workloads in a namespace, keyed by the same keys as the
+// workloads provided. Each distinct metric request is only gathered once and shared between the workloads that
+// need it, so workloads with the same pod selector share pod lists and metric API calls, and per pod object and
+// external metrics, which do not depend on the pod selector, are shared between all workloads.
+// Distinct metric requests are gathered concurrently if the Gatherer's Concurrency is greater than 1.
+func (c *Gatherer) GatherWorkloads(namespace string, workloads map[string]Workload) map[string]*WorkloadResult {
+	return c.GatherWorkloadsWithContext(context.Background(), namespace, workloads)
+}
+
+// GatherWorkloadsWithContext returns the metrics gathered for multiple workloads in a namespace, keyed by the same
+// keys as the workloads provided, passing the context provided to any gatherers that implement the context aware
+// gatherer interfaces. Each distinct metric request is only gathered once and shared between the workloads that need
+// it, see GatherWorkloads.
+func (c *Gatherer) GatherWorkloadsWithContext(ctx context.Context, namespace string,
+	workloads map[string]Workload) map[string]*WorkloadResult {
+	// Deduplicate the metric requests across all workloads
+	requestKeys := make(map[string][]string, len(workloads))
+	requestIndexes := map[string]int{}
+	requests := []workloadRequest{}
+	for name, workload := range workloads {
+		keys := make([]string, len(workload.Specs))
+		for i, spec := range workload.Specs {
+			key := c.workloadRequestKey(spec, workload.PodSelector)
+			if _, exists := requestIndexes[key]; !exists {
+				requestIndexes[key] = len(requests)
+				requests = append(requests, workloadRequest{
+					spec:        spec,
+					podSelector: workload.PodSelector,
+				})
+			}
+			keys[i] = key
+		}
+		requestKeys[name] = keys
+	}
+
+	gathered := make([]*metrics.Metric, len(requests))
+	errs := make([]error, len(requests))
+
+	// List pods once per selector across all of the workloads, rather than once per metric request
+	ctx = podutil.WithPodListCache(ctx)
+
+	gatherConcurrently(len(requests), c.Concurrency, func(i int) {
+		gathered[i], errs[i] = c.gatherSingleMetric(ctx, requests[i].spec, namespace, requests[i].podSelector,
+			c.CPUInitializationPeriod, c.DelayOfInitialReadinessStatus)
+	})
+
+	results := make(map[string]*WorkloadResult, len(workloads))
+	for name, workload := range workloads {
+		workloadGathered := make([]*metrics.Metric, len(workload.Specs))
+		workloadErrs := make([]error, len(workload.Specs))
+		for i, key := range requestKeys[name] {
+			index := requestIndexes[key]
+			if errs[index] != nil {
+				workloadErrs[i] = errs[index]
+				continue
+			}
+			// Copy shared metrics so evaluating one workload's metrics cannot modify another workload's metrics
+			workloadGathered[i] = gathered[index].DeepCopy()
+		}
+
+		combined, err := combineGathered(workload.Specs, workloadGathered, workloadErrs)
+		results[name] = &WorkloadResult{
+			Metrics: combined,
+			Err:     err,
+		}
+	}
+
+	return results
+}
+
+// workloadRequestKey returns a key identifying the metric request for the spec and pod selector provided, per pod
+// object and external metrics are gathered without the pod selector so the pod selector is left out of their key
+func (c *Gatherer) workloadRequestKey(spec autoscalingv2.MetricSpec, podSelector labels.Selector) string {
+	specKey, _ := json.Marshal(spec)
+
+	if _, registered := c.Sources[spec.Type]; !registered {
+		switch {
+		case spec.Type == autoscalingv2.ObjectMetricSourceType && spec.Object != nil &&
+			spec.Object.Target.Type == autoscalingv2.AverageValueMetricType:
+			return string(specKey)
+		case spec.Type == autoscalingv2.ExternalMetricSourceType && spec.External != nil &&
+			spec.External.Target.Type == autoscalingv2.AverageValueMetricType:
+			return string(specKey)
+		}
+	}
+
+	selectorKey := ""
+	if podSelector != nil {
+		selectorKey = podSelector.String()
+	}
+
+	return selectorKey + "/" + string(specKey)
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	externalmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestGatherWorkloads(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	podsSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: "requests",
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	failingPodsSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: "fail",
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	externalPerPodSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ExternalMetricSourceType,
+		External: &autoscalingv2.ExternalMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: "queue",
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	externalValueSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ExternalMetricSourceType,
+		External: &autoscalingv2.ExternalMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: "queue",
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.ValueMetricType,
+			},
+		},
+	}
+
+	var tests = []struct {
+		description           string
+		expected              map[string]*k8shorizmetrics.WorkloadResult
+		expectedPodsCalls     map[string]int
+		expectedExternalCalls int
+		workloads             map[string]k8shorizmetrics.Workload
+		concurrency           int
+	}{
+		{
+			description:       "No workloads",
+			expected:          map[string]*k8shorizmetrics.WorkloadResult{},
+			expectedPodsCalls: map[string]int{},
+			workloads:         map[string]k8shorizmetrics.Workload{},
+		},
+		{
+			description: "Workloads with the same selector share pods metric",
+			expected: map[string]*k8shorizmetrics.WorkloadResult{
+				"a": {
+					Metrics: []*metrics.Metric{
+						{
+							Spec: podsSpec,
+							Pods: &podsmetrics.Metric{PodMetricsInfo: podmetrics.MetricsInfo{"app=shared": {}}},
+						},
+					},
+				},
+				"b": {
+					Metrics: []*metrics.Metric{
+						{
+							Spec: podsSpec,
+							Pods: &podsmetrics.Metric{PodMetricsInfo: podmetrics.MetricsInfo{"app=shared": {}}},
+						},
+					},
+				},
+			},
+			expectedPodsCalls: map[string]int{
+				"app=shared": 1,
+			},
+			workloads: map[string]k8shorizmetrics.Workload{
+				"a": {
+					Specs:       []autoscalingv2.MetricSpec{podsSpec},
+					PodSelector: labels.SelectorFromSet(labels.Set{"app": "shared"}),
+				},
+				"b": {
+					Specs:       []autoscalingv2.MetricSpec{podsSpec},
+					PodSelector: labels.SelectorFromSet(labels.Set{"app": "shared"}),
+				},
+			},
+		},
+		{
+			description: "Workloads with different selectors share per pod external metric, but not pods metric",
+			expected: map[string]*k8shorizmetrics.WorkloadResult{
+				"a": {
+					Metrics: []*metrics.Metric{
+						{
+							Spec: podsSpec,
+							Pods: &podsmetrics.Metric{PodMetricsInfo: podmetrics.MetricsInfo{"app=a": {}}},
+						},
+						{
+							Spec:     externalPerPodSpec,
+							External: &externalmetrics.Metric{ReadyPodCount: testutil.Int64Ptr(1)},
+						},
+					},
+				},
+				"b": {
+					Metrics: []*metrics.Metric{
+						{
+							Spec:     externalPerPodSpec,
+							External: &externalmetrics.Metric{ReadyPodCount: testutil.Int64Ptr(1)},
+						},
+						{
+							Spec: podsSpec,
+							Pods: &podsmetrics.Metric{PodMetricsInfo: podmetrics.MetricsInfo{"app=b": {}}},
+						},
+					},
+				},
+			},
+			expectedPodsCalls: map[string]int{
+				"app=a": 1,
+				"app=b": 1,
+			},
+			expectedExternalCalls: 1,
+			workloads: map[string]k8shorizmetrics.Workload{
+				"a": {
+					Specs:       []autoscalingv2.MetricSpec{podsSpec, externalPerPodSpec},
+					PodSelector: labels.SelectorFromSet(labels.Set{"app": "a"}),
+				},
+				"b": {
+					Specs:       []autoscalingv2.MetricSpec{externalPerPodSpec, podsSpec},
+					PodSelector: labels.SelectorFromSet(labels.Set{"app": "b"}),
+				},
+			},
+			concurrency: 2,
+		},
+		{
+			description: "Workloads with different selectors do not share external value metric",
+			expected: map[string]*k8shorizmetrics.WorkloadResult{
+				"a": {
+					Metrics: []*metrics.Metric{
+						{
+							Spec:     externalValueSpec,
+							External: &externalmetrics.Metric{ReadyPodCount: testutil.Int64Ptr(1)},
+						},
+					},
+				},
+				"b": {
+					Metrics: []*metrics.Metric{
+						{
+							Spec:     externalValueSpec,
+							External: &externalmetrics.Metric{ReadyPodCount: testutil.Int64Ptr(1)},
+						},
+					},
+				},
+			},
+			expectedPodsCalls:     map[string]int{},
+			expectedExternalCalls: 2,
+			workloads: map[string]k8shorizmetrics.Workload{
+				"a": {
+					Specs:       []autoscalingv2.MetricSpec{externalValueSpec},
+					PodSelector: labels.SelectorFromSet(labels.Set{"app": "a"}),
+				},
+				"b": {
+					Specs:       []autoscalingv2.MetricSpec{externalValueSpec},
+					PodSelector: labels.SelectorFromSet(labels.Set{"app": "b"}),
+				},
+			},
+		},
+		{
+			description: "Errors are reported per workload",
+			expected: map[string]*k8shorizmetrics.WorkloadResult{
+				"a": {
+					Metrics: []*metrics.Metric{
+						{
+							Spec: podsSpec,
+							Pods: &podsmetrics.Metric{PodMetricsInfo: podmetrics.MetricsInfo{"app=a": {}}},
+						},
+					},
+					Err: errors.New("gatherer multi metric error: 1 errors, first error is failed to get pods metric: fail to gather"),
+				},
+				"b": {
+					Metrics: []*metrics.Metric{
+						{
+							Spec: podsSpec,
+							Pods: &podsmetrics.Metric{PodMetricsInfo: podmetrics.MetricsInfo{"app=b": {}}},
+						},
+					},
+				},
+			},
+			expectedPodsCalls: map[string]int{
+				"app=a": 2,
+				"app=b": 1,
+			},
+			workloads: map[string]k8shorizmetrics.Workload{
+				"a": {
+					Specs:       []autoscalingv2.MetricSpec{podsSpec, failingPodsSpec},
+					PodSelector: labels.SelectorFromSet(labels.Set{"app": "a"}),
+				},
+				"b": {
+					Specs:       []autoscalingv2.MetricSpec{podsSpec},
+					PodSelector: labels.SelectorFromSet(labels.Set{"app": "b"}),
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var mu sync.Mutex
+			podsCalls := map[string]int{}
+			externalCalls := 0
+
+			gatherer := &k8shorizmetrics.Gatherer{
+				Pods: &fake.PodsGatherer{
+					GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+						mu.Lock()
+						defer mu.Unlock()
+						podsCalls[podSelector.String()]++
+						if metricName == "fail" {
+							return nil, errors.New("fail to gather")
+						}
+						return &podsmetrics.Metric{PodMetricsInfo: podmetrics.MetricsInfo{podSelector.String(): {}}}, nil
+					},
+				},
+				External: &fake.ExternalGatherer{
+					GatherReactor: func(metricName, namespace string, metricSelector *metav1.LabelSelector, podSelector labels.Selector) (*externalmetrics.Metric, error) {
+						mu.Lock()
+						defer mu.Unlock()
+						externalCalls++
+						return &externalmetrics.Metric{ReadyPodCount: testutil.Int64Ptr(1)}, nil
+					},
+					GatherPerPodReactor: func(metricName, namespace string, metricSelector *metav1.LabelSelector) (*externalmetrics.Metric, error) {
+						mu.Lock()
+						defer mu.Unlock()
+						externalCalls++
+						return &externalmetrics.Metric{ReadyPodCount: testutil.Int64Ptr(1)}, nil
+					},
+				},
+				Concurrency: test.concurrency,
+			}
+
+			results := gatherer.GatherWorkloads("test", test.workloads)
+			if !cmp.Equal(test.expected, results, equateErrorMessage) {
+				t.Errorf("results mismatch (-want +got):\n%s", cmp.Diff(test.expected, results, equateErrorMessage))
+			}
+			if !cmp.Equal(test.expectedPodsCalls, podsCalls) {
+				t.Errorf("pods calls mismatch (-want +got):\n%s", cmp.Diff(test.expectedPodsCalls, podsCalls))
+			}
+			if externalCalls != test.expectedExternalCalls {
+				t.Errorf("external calls mismatch, want %d, got %d", test.expectedExternalCalls, externalCalls)
+			}
+		})
+	}
+}
+
+func TestGatherWorkloadsCopiesSharedMetrics(t *testing.T) {
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	gatherer := &k8shorizmetrics.Gatherer{
+		Pods: &fake.PodsGatherer{
+			GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+				return &podsmetrics.Metric{PodMetricsInfo: podmetrics.MetricsInfo{"pod-1": {Value: 1}}}, nil
+			},
+		},
+	}
+
+	results := gatherer.GatherWorkloads("test", map[string]k8shorizmetrics.Workload{
+		"a": {Specs: []autoscalingv2.MetricSpec{spec}, PodSelector: labels.Everything()},
+		"b": {Specs: []autoscalingv2.MetricSpec{spec}, PodSelector: labels.Everything()},
+	})
+
+	results["a"].Metrics[0].Pods.PodMetricsInfo["pod-2"] = podmetrics.Metric{}
+	if len(results["b"].Metrics[0].Pods.PodMetricsInfo) != 1 {
+		t.Errorf("modifying one workload's metrics modified another workload's metrics")
+	}
+}
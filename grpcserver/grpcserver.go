@@ -0,0 +1,258 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package grpcserver exposes gathering and evaluating metrics as a gRPC service, so platforms written in other
+// languages can use the same calculations as the Horizontal Pod Autoscaler by running k8shorizmetrics as a sidecar.
+//
+// The service is defined in servicepb/service.proto, gathered metrics use the messages defined in metrics.proto, see
+// the metricspb package, and metric specs use the Kubernetes protobuf encoding of autoscaling/v2 MetricSpec.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/grpcserver/servicepb"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/metricspb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// DefaultWatchInterval is the interval between each gather and evaluation of a watch if the request does not set
+	// one, matching the default sync period of the HPA controller
+	DefaultWatchInterval = 15 * time.Second
+	// MinWatchInterval is the shortest interval a watch request can set
+	MinWatchInterval = time.Second
+)
+
+// Server implements the MetricsService using the Gatherer and Evaluator. WatchInterval is the interval used by watches
+// that do not set an interval, if zero DefaultWatchInterval is used. Clock is used to time watches, if nil the real
+// clock is used.
+type Server struct {
+	servicepb.UnimplementedMetricsServiceServer
+	Gatherer      *k8shorizmetrics.Gatherer
+	Evaluator     *k8shorizmetrics.Evaluator
+	WatchInterval time.Duration
+	Clock         clock.WithTicker
+}
+
+// NewServer sets up a server using the gatherer and evaluator provided
+func NewServer(gatherer *k8shorizmetrics.Gatherer, evaluator *k8shorizmetrics.Evaluator) *Server {
+	return &Server{
+		Gatherer:  gatherer,
+		Evaluator: evaluator,
+	}
+}
+
+// Register registers the server as the MetricsService implementation of the gRPC server provided
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	servicepb.RegisterMetricsServiceServer(registrar, s)
+}
+
+// Gather gathers metrics for the metric specs provided. If some metrics fail to be gathered the metrics that were
+// gathered are returned with the error set in the response, if every metric fails an Unavailable error is returned.
+func (s *Server) Gather(ctx context.Context, request *servicepb.GatherRequest) (*servicepb.GatherResponse, error) {
+	specs, podSelector, err := decodeGatherRequest(request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	gathered, err := s.Gatherer.GatherWithContext(ctx, specs, request.GetNamespace(), podSelector)
+	if err != nil && !k8shorizmetrics.IsPartial(err) {
+		return nil, status.Errorf(codes.Unavailable, "failed to gather metrics: %v", err)
+	}
+
+	messages, encodeErr := encodeMetrics(gathered)
+	if encodeErr != nil {
+		return nil, status.Error(codes.Internal, encodeErr.Error())
+	}
+
+	return &servicepb.GatherResponse{
+		Metrics: messages,
+		Error:   errorMessage(err),
+	}, nil
+}
+
+// Evaluate evaluates the gathered metrics provided. If some metrics fail to be evaluated the replica count from the
+// remaining metrics is returned with the error set in the response, if every metric fails a FailedPrecondition error
+// is returned.
+func (s *Server) Evaluate(ctx context.Context, request *servicepb.EvaluateRequest) (*servicepb.EvaluateResponse, error) {
+	gatheredMetrics, err := s.decodeMetrics(request.GetMetrics())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	replicas, err := s.Evaluator.EvaluateWithEvaluateOptions(ctx, gatheredMetrics, request.GetCurrentReplicas(),
+		k8shorizmetrics.EvaluateOptions{Tolerance: request.GetTolerance()})
+	if err != nil && !k8shorizmetrics.IsPartial(err) {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to evaluate metrics: %v", err)
+	}
+
+	return &servicepb.EvaluateResponse{
+		Replicas: replicas,
+		Error:    errorMessage(err),
+	}, nil
+}
+
+// Watch gathers and evaluates metrics immediately and then on each interval, sending a response for each until the
+// call is cancelled. Failures to gather or evaluate metrics are reported in the response rather than ending the watch.
+func (s *Server) Watch(request *servicepb.WatchRequest, stream servicepb.MetricsService_WatchServer) error {
+	specs, podSelector, err := decodeGatherRequest(request.GetGather())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	interval := s.WatchInterval
+	if interval == 0 {
+		interval = DefaultWatchInterval
+	}
+	if request.GetInterval() != nil {
+		interval = request.GetInterval().AsDuration()
+		if interval < MinWatchInterval {
+			return status.Errorf(codes.InvalidArgument, "watch interval must be at least %s", MinWatchInterval)
+		}
+	}
+
+	watchClock := s.Clock
+	if watchClock == nil {
+		watchClock = clock.RealClock{}
+	}
+
+	ticker := watchClock.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx := stream.Context()
+	for {
+		response := s.watchOnce(ctx, request, specs, podSelector, watchClock.Now())
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C():
+		}
+	}
+}
+
+func (s *Server) watchOnce(ctx context.Context, request *servicepb.WatchRequest, specs []autoscalingv2.MetricSpec,
+	podSelector labels.Selector, now time.Time) *servicepb.WatchResponse {
+	response := &servicepb.WatchResponse{
+		Time: timestamppb.New(now),
+	}
+
+	gathered, gatherErr := s.Gatherer.GatherWithContext(ctx, specs, request.GetGather().GetNamespace(), podSelector)
+	if gatherErr != nil && !k8shorizmetrics.IsPartial(gatherErr) {
+		response.Error = fmt.Sprintf("failed to gather metrics: %v", gatherErr)
+		return response
+	}
+
+	messages, err := encodeMetrics(gathered)
+	if err != nil {
+		response.Error = err.Error()
+		return response
+	}
+	response.Metrics = messages
+
+	replicas, evaluateErr := s.Evaluator.EvaluateWithEvaluateOptions(ctx, gathered, request.GetCurrentReplicas(),
+		k8shorizmetrics.EvaluateOptions{Tolerance: request.GetTolerance()})
+	if evaluateErr != nil && !k8shorizmetrics.IsPartial(evaluateErr) {
+		response.Error = fmt.Sprintf("failed to evaluate metrics: %v", evaluateErr)
+		return response
+	}
+
+	response.Replicas = replicas
+	response.Error = errorMessage(errors.Join(gatherErr, evaluateErr))
+	return response
+}
+
+// decodeMetrics converts the metric messages provided, checking each can be evaluated. Metrics for source types
+// registered with the Evaluator are left for their evaluater to validate.
+func (s *Server) decodeMetrics(messages []*metricspb.Metric) ([]*metrics.Metric, error) {
+	if len(messages) == 0 {
+		return nil, errors.New("no metrics provided")
+	}
+
+	gatheredMetrics := make([]*metrics.Metric, len(messages))
+	for i, message := range messages {
+		gatheredMetric, err := metricspb.ToMetric(message)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric at index %d: %w", i, err)
+		}
+
+		if _, ok := s.Evaluator.Sources[gatheredMetric.Kind()]; !ok {
+			if err := k8shorizmetrics.ValidateMetric(gatheredMetric); err != nil {
+				return nil, fmt.Errorf("invalid metric at index %d: %w", i, err)
+			}
+		}
+
+		gatheredMetrics[i] = gatheredMetric
+	}
+	return gatheredMetrics, nil
+}
+
+func decodeGatherRequest(request *servicepb.GatherRequest) ([]autoscalingv2.MetricSpec, labels.Selector, error) {
+	if len(request.GetSpecs()) == 0 {
+		return nil, nil, errors.New("no metric specs provided")
+	}
+
+	specs := make([]autoscalingv2.MetricSpec, len(request.GetSpecs()))
+	for i, data := range request.GetSpecs() {
+		if err := specs[i].Unmarshal(data); err != nil {
+			return nil, nil, fmt.Errorf("invalid metric spec at index %d: %w", i, err)
+		}
+		if err := k8shorizmetrics.ValidateMetricSpec(specs[i]); err != nil {
+			return nil, nil, fmt.Errorf("invalid metric spec at index %d: %w", i, err)
+		}
+	}
+
+	podSelector, err := labels.Parse(request.GetPodSelector())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid pod selector: %w", err)
+	}
+
+	return specs, podSelector, nil
+}
+
+func encodeMetrics(gatheredMetrics []*metrics.Metric) ([]*metricspb.Metric, error) {
+	messages := make([]*metricspb.Metric, len(gatheredMetrics))
+	for i, gatheredMetric := range gatheredMetrics {
+		message, err := metricspb.FromMetric(gatheredMetric)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode metric at index %d: %w", i, err)
+		}
+		messages[i] = message
+	}
+	return messages, nil
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
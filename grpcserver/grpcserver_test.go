@@ -0,0 +1,400 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/grpcserver"
+	"github.com/jthomperoo/k8shorizmetrics/v4/grpcserver/servicepb"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/metricspb"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	resourcemetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+// newClient serves the server provided over an in memory connection, returning a client connected to it
+func newClient(t *testing.T, server *grpcserver.Server) servicepb.MetricsServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	server.Register(grpcServer)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return servicepb.NewMetricsServiceClient(conn)
+}
+
+// equateStatus reports an error if the gRPC status of the errors provided differ, returning true if they match
+func equateStatus(t *testing.T, expected error, got error) bool {
+	want := status.Convert(expected).Proto()
+	if !cmp.Equal(want, status.Convert(got).Proto(), protocmp.Transform()) {
+		t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(want, status.Convert(got).Proto(), protocmp.Transform()))
+		return false
+	}
+	return true
+}
+
+func marshalSpec(t *testing.T, spec autoscalingv2.MetricSpec) []byte {
+	data, err := spec.Marshal()
+	if err != nil {
+		t.Fatalf("failed to marshal spec: %v", err)
+	}
+	return data
+}
+
+func fromMetric(t *testing.T, gatheredMetric *metrics.Metric) *metricspb.Metric {
+	message, err := metricspb.FromMetric(gatheredMetric)
+	if err != nil {
+		t.Fatalf("failed to convert metric: %v", err)
+	}
+	return message
+}
+
+func newGatherer() *k8shorizmetrics.Gatherer {
+	return &k8shorizmetrics.Gatherer{
+		Pods: &fake.PodsGatherer{
+			GatherReactor: func(metricName string, namespace string, podSelector labels.Selector,
+				metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+				if metricName == "fail" {
+					return nil, errors.New("fail")
+				}
+				return &podsmetrics.Metric{ReadyPodCount: 3}, nil
+			},
+		},
+	}
+}
+
+func newEvaluator() *k8shorizmetrics.Evaluator {
+	return &k8shorizmetrics.Evaluator{
+		Tolerance: 0.1,
+		Resource: &fake.ResourceEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric,
+				tolerance float64) (int32, error) {
+				return 0, errors.New("fail")
+			},
+		},
+		Pods: &fake.PodsEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+				return currentReplicas + 3
+			},
+		},
+	}
+}
+
+var (
+	requestsSpec = k8shorizmetrics.NewPodsAverageValueSpec("requests", resource.MustParse("10"), nil)
+	failSpec     = k8shorizmetrics.NewPodsAverageValueSpec("fail", resource.MustParse("10"), nil)
+	cpuSpec      = k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceCPU, 50)
+
+	resourceMetric = resourcemetrics.Metric{}
+)
+
+func TestServerGather(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    *servicepb.GatherResponse
+		expectedErr error
+		request     func(t *testing.T) *servicepb.GatherRequest
+	}{
+		{
+			description: "No specs",
+			expectedErr: status.Error(codes.InvalidArgument, "no metric specs provided"),
+			request: func(t *testing.T) *servicepb.GatherRequest {
+				return &servicepb.GatherRequest{Namespace: "default"}
+			},
+		},
+		{
+			description: "Invalid spec encoding",
+			expectedErr: status.Error(codes.InvalidArgument, "invalid metric spec at index 0: unexpected EOF"),
+			request: func(t *testing.T) *servicepb.GatherRequest {
+				return &servicepb.GatherRequest{Namespace: "default", Specs: [][]byte{{0x0a, 0x05}}}
+			},
+		},
+		{
+			description: "Invalid spec",
+			expectedErr: status.Error(codes.InvalidArgument,
+				"invalid metric spec at index 0: invalid pods metric source: pods must be set"),
+			request: func(t *testing.T) *servicepb.GatherRequest {
+				return &servicepb.GatherRequest{
+					Namespace: "default",
+					Specs: [][]byte{
+						marshalSpec(t, autoscalingv2.MetricSpec{Type: autoscalingv2.PodsMetricSourceType}),
+					},
+				}
+			},
+		},
+		{
+			description: "Invalid pod selector",
+			expectedErr: status.Error(codes.InvalidArgument,
+				"invalid pod selector: found '=', expected: !, identifier, or 'end of string'"),
+			request: func(t *testing.T) *servicepb.GatherRequest {
+				return &servicepb.GatherRequest{
+					Namespace:   "default",
+					PodSelector: "=",
+					Specs:       [][]byte{marshalSpec(t, requestsSpec)},
+				}
+			},
+		},
+		{
+			description: "All metrics fail",
+			expectedErr: status.Error(codes.Unavailable,
+				"failed to gather metrics: gatherer multi metric error: 1 errors, first error is failed to get pods "+
+					"metric: fail"),
+			request: func(t *testing.T) *servicepb.GatherRequest {
+				return &servicepb.GatherRequest{
+					Namespace: "default",
+					Specs:     [][]byte{marshalSpec(t, failSpec)},
+				}
+			},
+		},
+		{
+			description: "Partial failure",
+			expected: &servicepb.GatherResponse{
+				Metrics: []*metricspb.Metric{
+					fromMetric(t, &metrics.Metric{Spec: requestsSpec, Pods: &podsmetrics.Metric{ReadyPodCount: 3}}),
+				},
+				Error: "gatherer multi metric error: 1 errors, first error is failed to get pods metric: fail",
+			},
+			request: func(t *testing.T) *servicepb.GatherRequest {
+				return &servicepb.GatherRequest{
+					Namespace: "default",
+					Specs:     [][]byte{marshalSpec(t, requestsSpec), marshalSpec(t, failSpec)},
+				}
+			},
+		},
+		{
+			description: "Success",
+			expected: &servicepb.GatherResponse{
+				Metrics: []*metricspb.Metric{
+					fromMetric(t, &metrics.Metric{Spec: requestsSpec, Pods: &podsmetrics.Metric{ReadyPodCount: 3}}),
+				},
+			},
+			request: func(t *testing.T) *servicepb.GatherRequest {
+				return &servicepb.GatherRequest{
+					Namespace:   "default",
+					PodSelector: "app=test",
+					Specs:       [][]byte{marshalSpec(t, requestsSpec)},
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client := newClient(t, grpcserver.NewServer(newGatherer(), newEvaluator()))
+
+			result, err := client.Gather(context.Background(), test.request(t))
+			if !equateStatus(t, test.expectedErr, err) {
+				return
+			}
+			if !cmp.Equal(test.expected, result, protocmp.Transform()) {
+				t.Errorf("response mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, protocmp.Transform()))
+			}
+		})
+	}
+}
+
+func TestServerEvaluate(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    *servicepb.EvaluateResponse
+		expectedErr error
+		request     func(t *testing.T) *servicepb.EvaluateRequest
+	}{
+		{
+			description: "No metrics",
+			expectedErr: status.Error(codes.InvalidArgument, "no metrics provided"),
+			request: func(t *testing.T) *servicepb.EvaluateRequest {
+				return &servicepb.EvaluateRequest{CurrentReplicas: 2}
+			},
+		},
+		{
+			description: "Missing metric values",
+			expectedErr: status.Error(codes.InvalidArgument, "invalid metric at index 0: pods metric values must be set"),
+			request: func(t *testing.T) *servicepb.EvaluateRequest {
+				return &servicepb.EvaluateRequest{
+					CurrentReplicas: 2,
+					Metrics:         []*metricspb.Metric{fromMetric(t, &metrics.Metric{Spec: requestsSpec})},
+				}
+			},
+		},
+		{
+			description: "All metrics fail",
+			expectedErr: status.Error(codes.FailedPrecondition,
+				"failed to evaluate metrics: evaluator multi metric error: 1 errors, first error is fail"),
+			request: func(t *testing.T) *servicepb.EvaluateRequest {
+				return &servicepb.EvaluateRequest{
+					CurrentReplicas: 2,
+					Metrics: []*metricspb.Metric{
+						fromMetric(t, &metrics.Metric{Spec: cpuSpec, Resource: &resourceMetric}),
+					},
+				}
+			},
+		},
+		{
+			description: "Partial failure",
+			expected: &servicepb.EvaluateResponse{
+				Replicas: 5,
+				Error:    "evaluator multi metric error: 1 errors, first error is fail",
+			},
+			request: func(t *testing.T) *servicepb.EvaluateRequest {
+				return &servicepb.EvaluateRequest{
+					CurrentReplicas: 2,
+					Metrics: []*metricspb.Metric{
+						fromMetric(t, &metrics.Metric{Spec: cpuSpec, Resource: &resourceMetric}),
+						fromMetric(t, &metrics.Metric{Spec: requestsSpec, Pods: &podsmetrics.Metric{}}),
+					},
+				}
+			},
+		},
+		{
+			description: "Success",
+			expected: &servicepb.EvaluateResponse{
+				Replicas: 5,
+			},
+			request: func(t *testing.T) *servicepb.EvaluateRequest {
+				return &servicepb.EvaluateRequest{
+					CurrentReplicas: 2,
+					Tolerance:       0.5,
+					Metrics: []*metricspb.Metric{
+						fromMetric(t, &metrics.Metric{Spec: requestsSpec, Pods: &podsmetrics.Metric{}}),
+					},
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client := newClient(t, grpcserver.NewServer(newGatherer(), newEvaluator()))
+
+			result, err := client.Evaluate(context.Background(), test.request(t))
+			if !equateStatus(t, test.expectedErr, err) {
+				return
+			}
+			if !cmp.Equal(test.expected, result, protocmp.Transform()) {
+				t.Errorf("response mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, protocmp.Transform()))
+			}
+		})
+	}
+}
+
+func TestServerWatch(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Invalid interval", func(t *testing.T) {
+		client := newClient(t, grpcserver.NewServer(newGatherer(), newEvaluator()))
+
+		stream, err := client.Watch(context.Background(), &servicepb.WatchRequest{
+			Gather: &servicepb.GatherRequest{
+				Namespace: "default",
+				Specs:     [][]byte{marshalSpec(t, requestsSpec)},
+			},
+			CurrentReplicas: 2,
+			Interval:        durationpb.New(time.Millisecond),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = stream.Recv()
+		expectedErr := status.Error(codes.InvalidArgument, "watch interval must be at least 1s")
+		equateStatus(t, expectedErr, err)
+	})
+
+	t.Run("Streams a response each interval", func(t *testing.T) {
+		fakeClock := clocktesting.NewFakeClock(now)
+		server := grpcserver.NewServer(newGatherer(), newEvaluator())
+		server.Clock = fakeClock
+		client := newClient(t, server)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stream, err := client.Watch(ctx, &servicepb.WatchRequest{
+			Gather: &servicepb.GatherRequest{
+				Namespace: "default",
+				Specs:     [][]byte{marshalSpec(t, requestsSpec), marshalSpec(t, failSpec)},
+			},
+			CurrentReplicas: 2,
+			Interval:        durationpb.New(time.Minute),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		gathered := []*metricspb.Metric{
+			fromMetric(t, &metrics.Metric{Spec: requestsSpec, Pods: &podsmetrics.Metric{ReadyPodCount: 3}}),
+		}
+		expectedErr := "gatherer multi metric error: 1 errors, first error is failed to get pods metric: fail"
+
+		for i := 0; i < 2; i++ {
+			result, err := stream.Recv()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expected := &servicepb.WatchResponse{
+				Time:     timestamppb.New(now.Add(time.Duration(i) * time.Minute)),
+				Metrics:  gathered,
+				Replicas: 5,
+				Error:    expectedErr,
+			}
+			if !cmp.Equal(expected, result, protocmp.Transform()) {
+				t.Errorf("response mismatch (-want +got):\n%s", cmp.Diff(expected, result, protocmp.Transform()))
+			}
+
+			// Wait for the watch to wait on the ticker before moving the clock forward
+			for !fakeClock.HasWaiters() {
+				time.Sleep(time.Millisecond)
+			}
+			fakeClock.Step(time.Minute)
+		}
+	})
+}
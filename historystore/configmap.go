@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package historystore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/cooldown"
+	"github.com/jthomperoo/k8shorizmetrics/v4/stabilization"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// ConfigMapStore persists the scaling history of every target in a single ConfigMap, with each target's Record held
+// as JSON under a data key derived from the target's key. The ConfigMap is created when history is first saved.
+// Writes that conflict with another writer are retried using the Backoff provided. As a ConfigMap is limited to 1MiB
+// this suits a moderate number of targets, use a ScalingHistoryStore for many targets.
+type ConfigMapStore struct {
+	Client    kubernetes.Interface
+	Namespace string
+	Name      string
+	Backoff   wait.Backoff
+}
+
+// NewConfigMapStore sets up a ConfigMapStore persisting history to the ConfigMap with the namespace and name provided,
+// retrying on conflicts using the client-go default retry backoff
+func NewConfigMapStore(client kubernetes.Interface, namespace string, name string) *ConfigMapStore {
+	return &ConfigMapStore{
+		Client:    client,
+		Namespace: namespace,
+		Name:      name,
+		Backoff:   retry.DefaultRetry,
+	}
+}
+
+// Load returns the recommendation history saved for the target, returning no history if none has been saved
+func (s *ConfigMapStore) Load(ctx context.Context, key string) ([]stabilization.Recommendation, error) {
+	record, err := s.load(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return record.History, nil
+}
+
+// Save replaces the recommendation history saved for the target
+func (s *ConfigMapStore) Save(ctx context.Context, key string, history []stabilization.Recommendation) error {
+	return s.update(ctx, key, func(record *Record) {
+		record.History = history
+	})
+}
+
+// Get returns the cooldown state saved for the target, returning a zero State if none has been saved
+func (s *ConfigMapStore) Get(ctx context.Context, key string) (cooldown.State, error) {
+	record, err := s.load(ctx, key)
+	if err != nil {
+		return cooldown.State{}, err
+	}
+	return record.Cooldown, nil
+}
+
+// Set replaces the cooldown state saved for the target
+func (s *ConfigMapStore) Set(ctx context.Context, key string, state cooldown.State) error {
+	return s.update(ctx, key, func(record *Record) {
+		record.Cooldown = state
+	})
+}
+
+// Delete discards the cooldown state saved for the target, removing the target from the ConfigMap if it has no
+// recommendation history saved either
+func (s *ConfigMapStore) Delete(ctx context.Context, key string) error {
+	return s.update(ctx, key, func(record *Record) {
+		record.Cooldown = cooldown.State{}
+	})
+}
+
+func (s *ConfigMapStore) load(ctx context.Context, key string) (*Record, error) {
+	configMap, err := s.Client.CoreV1().ConfigMaps(s.Namespace).Get(ctx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return &Record{}, nil
+		}
+		return nil, fmt.Errorf("failed to get history config map: %w", err)
+	}
+
+	return decodeRecord(configMap, key)
+}
+
+// update applies the change provided to the target's record, retrying if the ConfigMap is modified or created
+// concurrently
+func (s *ConfigMapStore) update(ctx context.Context, key string, change func(record *Record)) error {
+	return retry.OnError(s.Backoff, func(err error) bool {
+		return k8serrors.IsConflict(err) || k8serrors.IsAlreadyExists(err)
+	}, func() error {
+		configMap, err := s.Client.CoreV1().ConfigMaps(s.Namespace).Get(ctx, s.Name, metav1.GetOptions{})
+		exists := true
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get history config map: %w", err)
+			}
+			exists = false
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: s.Namespace,
+					Name:      s.Name,
+				},
+			}
+		}
+
+		record, err := decodeRecord(configMap, key)
+		if err != nil {
+			return err
+		}
+
+		change(record)
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		if record.empty() {
+			if !exists {
+				return nil
+			}
+			delete(configMap.Data, dataKey(key))
+		} else {
+			data, err := json.Marshal(record)
+			if err != nil {
+				return fmt.Errorf("failed to marshal history for %s: %w", key, err)
+			}
+			configMap.Data[dataKey(key)] = string(data)
+		}
+
+		if !exists {
+			_, err = s.Client.CoreV1().ConfigMaps(s.Namespace).Create(ctx, configMap, metav1.CreateOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to create history config map: %w", err)
+			}
+			return nil
+		}
+
+		_, err = s.Client.CoreV1().ConfigMaps(s.Namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to update history config map: %w", err)
+		}
+		return nil
+	})
+}
+
+func decodeRecord(configMap *corev1.ConfigMap, key string) (*Record, error) {
+	data, ok := configMap.Data[dataKey(key)]
+	if !ok {
+		return &Record{}, nil
+	}
+
+	record := &Record{}
+	err := json.Unmarshal([]byte(data), record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal history for %s: %w", key, err)
+	}
+	return record, nil
+}
+
+// dataKey returns the ConfigMap data key for the target key provided. ConfigMap data keys cannot contain '/', so
+// these are replaced with '_', which cannot appear in the API versions, kinds, namespaces or names making up a
+// target key.
+func dataKey(key string) string {
+	return strings.ReplaceAll(key, "/", "_")
+}
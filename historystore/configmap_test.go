@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package historystore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/cooldown"
+	"github.com/jthomperoo/k8shorizmetrics/v4/historystore"
+	"github.com/jthomperoo/k8shorizmetrics/v4/stabilization"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var key = stabilization.TargetKey("test-namespace", autoscalingv2.CrossVersionObjectReference{
+	APIVersion: "apps/v1",
+	Kind:       "Deployment",
+	Name:       "test",
+})
+
+var history = []stabilization.Recommendation{
+	{Timestamp: now, Replicas: 3},
+	{Timestamp: now.Add(time.Minute), Replicas: 2},
+}
+
+var state = cooldown.State{
+	LastScaleUp: now,
+}
+
+func TestConfigMapStore(t *testing.T) {
+	client := k8sfake.NewSimpleClientset()
+	store := historystore.NewConfigMapStore(client, "store-namespace", "history")
+	ctx := context.Background()
+
+	loaded, err := store.Load(ctx, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded != nil {
+		t.Errorf("expected no history before saving, got %v", loaded)
+	}
+
+	// Discarding state that was never saved should not create the config map
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.CoreV1().ConfigMaps("store-namespace").Get(ctx, "history", metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Fatalf("expected config map not to exist, got %v", err)
+	}
+
+	if err := store.Save(ctx, key, history); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Set(ctx, key, state); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err = store.Load(ctx, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(history, loaded) {
+		t.Errorf("history mismatch (-want +got):\n%s", cmp.Diff(history, loaded))
+	}
+
+	gotState, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(state, gotState) {
+		t.Errorf("state mismatch (-want +got):\n%s", cmp.Diff(state, gotState))
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps("store-namespace").Get(ctx, "history", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedData := map[string]string{
+		"apps_v1_Deployment_test-namespace_test": `{"history":[{"timestamp":"2026-01-01T00:00:00Z","replicas":3},` +
+			`{"timestamp":"2026-01-01T00:01:00Z","replicas":2}],"cooldown":{"lastScaleUp":"2026-01-01T00:00:00Z",` +
+			`"lastScaleDown":"0001-01-01T00:00:00Z"}}`,
+	}
+	if !cmp.Equal(expectedData, configMap.Data) {
+		t.Errorf("config map data mismatch (-want +got):\n%s", cmp.Diff(expectedData, configMap.Data))
+	}
+
+	// Discarding the cooldown state keeps the history, discarding both removes the target
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loaded, err = store.Load(ctx, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(history, loaded) {
+		t.Errorf("history mismatch after delete (-want +got):\n%s", cmp.Diff(history, loaded))
+	}
+
+	if err := store.Save(ctx, key, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	configMap, err = client.CoreV1().ConfigMaps("store-namespace").Get(ctx, "history", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(configMap.Data) != 0 {
+		t.Errorf("expected no config map data, got %v", configMap.Data)
+	}
+}
+
+func TestConfigMapStoreErrors(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description   string
+		expectedErr   error
+		expectedState cooldown.State
+		configMap     *corev1.ConfigMap
+		reactors      map[string]k8stesting.ReactionFunc
+	}{
+		{
+			description: "Fail to get config map",
+			expectedErr: errors.New("failed to get history config map: fail to get"),
+			reactors: map[string]k8stesting.ReactionFunc{
+				"get": func(action k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("fail to get")
+				},
+			},
+		},
+		{
+			description: "Invalid history",
+			expectedErr: errors.New("failed to unmarshal history for apps/v1/Deployment/test-namespace/test: " +
+				"invalid character 'i' looking for beginning of value"),
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "store-namespace", Name: "history"},
+				Data: map[string]string{
+					"apps_v1_Deployment_test-namespace_test": "invalid",
+				},
+			},
+		},
+		{
+			description: "Fail to create config map",
+			expectedErr: errors.New("failed to create history config map: fail to create"),
+			reactors: map[string]k8stesting.ReactionFunc{
+				"create": func(action k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("fail to create")
+				},
+			},
+		},
+		{
+			description:   "Conflict on first update, retry and succeed",
+			expectedState: state,
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "store-namespace", Name: "history"},
+			},
+			reactors: map[string]k8stesting.ReactionFunc{
+				"update": func() k8stesting.ReactionFunc {
+					calls := 0
+					return func(action k8stesting.Action) (bool, runtime.Object, error) {
+						calls++
+						if calls == 1 {
+							return true, nil, k8serrors.NewConflict(schema.GroupResource{Resource: "configmaps"},
+								"history", errors.New("object has been modified"))
+						}
+						return false, nil, nil
+					}
+				}(),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client := k8sfake.NewSimpleClientset()
+			if test.configMap != nil {
+				client = k8sfake.NewSimpleClientset(test.configMap)
+			}
+			for verb, reactor := range test.reactors {
+				client.PrependReactor(verb, "configmaps", reactor)
+			}
+
+			store := historystore.NewConfigMapStore(client, "store-namespace", "history")
+
+			err := store.Set(context.Background(), key, state)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			gotState, err := store.Get(context.Background(), key)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(test.expectedState, gotState) {
+				t.Errorf("state mismatch (-want +got):\n%s", cmp.Diff(test.expectedState, gotState))
+			}
+		})
+	}
+}
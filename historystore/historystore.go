@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package historystore persists the scaling history of scale targets to the cluster, so downscale stabilization and
+// cooldowns survive restarts of the autoscaler and are handed over between replicas. History can be kept in a single
+// ConfigMap or in a ScalingHistory custom resource per target.
+//
+// Both stores implement stabilization.Store, persisting the recommendation history of each target, and cooldown.Store,
+// persisting the times each target was last scaled up and down, so the same store can be used with a
+// stabilization.Stabilizer, see poller.Poller's HistoryStore, and a cooldown.Evaluator.
+package historystore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/cooldown"
+	"github.com/jthomperoo/k8shorizmetrics/v4/stabilization"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+// Store persists both the stabilization history and cooldown state of each target
+type Store interface {
+	stabilization.Store
+	cooldown.Store
+}
+
+// Record is the scaling history persisted for a single target
+type Record struct {
+	History  []stabilization.Recommendation `json:"history,omitempty"`
+	Cooldown cooldown.State                 `json:"cooldown"`
+}
+
+// empty returns if the record holds no history
+func (r *Record) empty() bool {
+	return len(r.History) == 0 && r.Cooldown.LastScaleUp.IsZero() && r.Cooldown.LastScaleDown.IsZero()
+}
+
+// parseTargetKey splits a key built by stabilization.TargetKey back into the namespace and scale target reference
+func parseTargetKey(key string) (string, autoscalingv2.CrossVersionObjectReference, error) {
+	parts := strings.Split(key, "/")
+
+	var apiVersion string
+	switch len(parts) {
+	case 4:
+		apiVersion = parts[0]
+	case 5:
+		apiVersion = parts[0] + "/" + parts[1]
+	default:
+		return "", autoscalingv2.CrossVersionObjectReference{}, fmt.Errorf(
+			"invalid target key %q, expected a key built by stabilization.TargetKey", key)
+	}
+
+	kind, namespace, name := parts[len(parts)-3], parts[len(parts)-2], parts[len(parts)-1]
+	if kind == "" || namespace == "" || name == "" {
+		return "", autoscalingv2.CrossVersionObjectReference{}, fmt.Errorf(
+			"invalid target key %q, expected a key built by stabilization.TargetKey", key)
+	}
+
+	return namespace, autoscalingv2.CrossVersionObjectReference{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Name:       name,
+	}, nil
+}
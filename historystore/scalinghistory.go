@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package historystore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/apis/k8shorizmetrics/v1alpha1"
+	"github.com/jthomperoo/k8shorizmetrics/v4/client/clientset/versioned"
+	"github.com/jthomperoo/k8shorizmetrics/v4/cooldown"
+	"github.com/jthomperoo/k8shorizmetrics/v4/stabilization"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// ScalingHistoryStore persists the scaling history of each target in a ScalingHistory custom resource in the target's
+// namespace, named after the target's kind and name, for example deployment-my-app. Keys must be built by
+// stabilization.TargetKey so the target can be determined from the key. The ScalingHistory is created when history is
+// first saved for a target. Writes that conflict with another writer are retried using the Backoff provided.
+type ScalingHistoryStore struct {
+	Client  versioned.Interface
+	Backoff wait.Backoff
+}
+
+// NewScalingHistoryStore sets up a ScalingHistoryStore persisting history using the clientset provided, retrying on
+// conflicts using the client-go default retry backoff
+func NewScalingHistoryStore(client versioned.Interface) *ScalingHistoryStore {
+	return &ScalingHistoryStore{
+		Client:  client,
+		Backoff: retry.DefaultRetry,
+	}
+}
+
+// Load returns the recommendation history saved for the target, returning no history if none has been saved
+func (s *ScalingHistoryStore) Load(ctx context.Context, key string) ([]stabilization.Recommendation, error) {
+	namespace, scaleTargetRef, err := parseTargetKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.get(ctx, namespace, objectName(scaleTargetRef))
+	if err != nil || history == nil {
+		return nil, err
+	}
+	return toRecord(&history.Spec).History, nil
+}
+
+// Save replaces the recommendation history saved for the target
+func (s *ScalingHistoryStore) Save(ctx context.Context, key string, history []stabilization.Recommendation) error {
+	return s.update(ctx, key, func(record *Record) {
+		record.History = history
+	})
+}
+
+// Get returns the cooldown state saved for the target, returning a zero State if none has been saved
+func (s *ScalingHistoryStore) Get(ctx context.Context, key string) (cooldown.State, error) {
+	namespace, scaleTargetRef, err := parseTargetKey(key)
+	if err != nil {
+		return cooldown.State{}, err
+	}
+
+	history, err := s.get(ctx, namespace, objectName(scaleTargetRef))
+	if err != nil || history == nil {
+		return cooldown.State{}, err
+	}
+	return toRecord(&history.Spec).Cooldown, nil
+}
+
+// Set replaces the cooldown state saved for the target
+func (s *ScalingHistoryStore) Set(ctx context.Context, key string, state cooldown.State) error {
+	return s.update(ctx, key, func(record *Record) {
+		record.Cooldown = state
+	})
+}
+
+// Delete discards the cooldown state saved for the target, deleting the target's ScalingHistory if it has no
+// recommendation history saved either
+func (s *ScalingHistoryStore) Delete(ctx context.Context, key string) error {
+	return s.update(ctx, key, func(record *Record) {
+		record.Cooldown = cooldown.State{}
+	})
+}
+
+// get returns the target's ScalingHistory, nil if it does not exist
+func (s *ScalingHistoryStore) get(ctx context.Context, namespace string,
+	name string) (*v1alpha1.ScalingHistory, error) {
+	history, err := s.Client.K8shorizmetricsV1alpha1().ScalingHistories(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get scaling history: %w", err)
+	}
+	return history, nil
+}
+
+// update applies the change provided to the target's record, retrying if the ScalingHistory is modified or created
+// concurrently
+func (s *ScalingHistoryStore) update(ctx context.Context, key string, change func(record *Record)) error {
+	namespace, scaleTargetRef, err := parseTargetKey(key)
+	if err != nil {
+		return err
+	}
+
+	name := objectName(scaleTargetRef)
+	client := s.Client.K8shorizmetricsV1alpha1().ScalingHistories(namespace)
+
+	return retry.OnError(s.Backoff, func(err error) bool {
+		return k8serrors.IsConflict(err) || k8serrors.IsAlreadyExists(err)
+	}, func() error {
+		history, err := s.get(ctx, namespace, name)
+		if err != nil {
+			return err
+		}
+
+		record := &Record{}
+		if history != nil {
+			record = toRecord(&history.Spec)
+		}
+
+		change(record)
+
+		if record.empty() {
+			if history == nil {
+				return nil
+			}
+			err = client.Delete(ctx, name, metav1.DeleteOptions{
+				Preconditions: &metav1.Preconditions{
+					ResourceVersion: &history.ResourceVersion,
+				},
+			})
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete scaling history: %w", err)
+			}
+			return nil
+		}
+
+		if history == nil {
+			_, err = client.Create(ctx, &v1alpha1.ScalingHistory{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      name,
+				},
+				Spec: toSpec(scaleTargetRef, record),
+			}, metav1.CreateOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to create scaling history: %w", err)
+			}
+			return nil
+		}
+
+		history.Spec = toSpec(scaleTargetRef, record)
+		_, err = client.Update(ctx, history, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to update scaling history: %w", err)
+		}
+		return nil
+	})
+}
+
+// objectName returns the name of the ScalingHistory for the scale target provided
+func objectName(scaleTargetRef autoscalingv2.CrossVersionObjectReference) string {
+	return strings.ToLower(scaleTargetRef.Kind) + "-" + scaleTargetRef.Name
+}
+
+func toRecord(spec *v1alpha1.ScalingHistorySpec) *Record {
+	record := &Record{}
+	for _, recommendation := range spec.Recommendations {
+		record.History = append(record.History, stabilization.Recommendation{
+			Timestamp: recommendation.Time.Time,
+			Replicas:  recommendation.Replicas,
+		})
+	}
+	if spec.LastScaleUp != nil {
+		record.Cooldown.LastScaleUp = spec.LastScaleUp.Time
+	}
+	if spec.LastScaleDown != nil {
+		record.Cooldown.LastScaleDown = spec.LastScaleDown.Time
+	}
+	return record
+}
+
+func toSpec(scaleTargetRef autoscalingv2.CrossVersionObjectReference, record *Record) v1alpha1.ScalingHistorySpec {
+	spec := v1alpha1.ScalingHistorySpec{
+		ScaleTargetRef: scaleTargetRef,
+		LastScaleUp:    optionalTime(record.Cooldown.LastScaleUp),
+		LastScaleDown:  optionalTime(record.Cooldown.LastScaleDown),
+	}
+	for _, recommendation := range record.History {
+		spec.Recommendations = append(spec.Recommendations, v1alpha1.HistoricRecommendation{
+			Time:     metav1.NewTime(recommendation.Timestamp),
+			Replicas: recommendation.Replicas,
+		})
+	}
+	return spec
+}
+
+func optionalTime(t time.Time) *metav1.Time {
+	if t.IsZero() {
+		return nil
+	}
+	converted := metav1.NewTime(t)
+	return &converted
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package historystore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/apis/k8shorizmetrics/v1alpha1"
+	"github.com/jthomperoo/k8shorizmetrics/v4/client/clientset/versioned/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/cooldown"
+	"github.com/jthomperoo/k8shorizmetrics/v4/historystore"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestScalingHistoryStore(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	store := historystore.NewScalingHistoryStore(client)
+	ctx := context.Background()
+
+	loaded, err := store.Load(ctx, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded != nil {
+		t.Errorf("expected no history before saving, got %v", loaded)
+	}
+
+	if err := store.Save(ctx, key, history); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Set(ctx, key, state); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err = store.Load(ctx, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(history, loaded) {
+		t.Errorf("history mismatch (-want +got):\n%s", cmp.Diff(history, loaded))
+	}
+
+	gotState, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(state, gotState) {
+		t.Errorf("state mismatch (-want +got):\n%s", cmp.Diff(state, gotState))
+	}
+
+	scalingHistory, err := client.K8shorizmetricsV1alpha1().ScalingHistories("test-namespace").Get(ctx,
+		"deployment-test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lastScaleUp := metav1.NewTime(now)
+	expectedSpec := v1alpha1.ScalingHistorySpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       "test",
+		},
+		Recommendations: []v1alpha1.HistoricRecommendation{
+			{Time: metav1.NewTime(now), Replicas: 3},
+			{Time: metav1.NewTime(now.Add(time.Minute)), Replicas: 2},
+		},
+		LastScaleUp: &lastScaleUp,
+	}
+	if !cmp.Equal(expectedSpec, scalingHistory.Spec) {
+		t.Errorf("scaling history mismatch (-want +got):\n%s", cmp.Diff(expectedSpec, scalingHistory.Spec))
+	}
+
+	// Discarding the cooldown state keeps the history, discarding both deletes the scaling history
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gotState, err = store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(cooldown.State{}, gotState) {
+		t.Errorf("state mismatch after delete (-want +got):\n%s", cmp.Diff(cooldown.State{}, gotState))
+	}
+
+	if err := store.Save(ctx, key, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = client.K8shorizmetricsV1alpha1().ScalingHistories("test-namespace").Get(ctx, "deployment-test",
+		metav1.GetOptions{})
+	if !k8serrors.IsNotFound(err) {
+		t.Errorf("expected scaling history to be deleted, got %v", err)
+	}
+}
+
+func TestScalingHistoryStoreKeys(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description       string
+		expectedErr       error
+		expectedNamespace string
+		expectedName      string
+		key               string
+	}{
+		{
+			description: "Invalid key",
+			expectedErr: errors.New(`invalid target key "not-a-target-key", expected a key built by ` +
+				"stabilization.TargetKey"),
+			key: "not-a-target-key",
+		},
+		{
+			description: "Invalid key, empty name",
+			expectedErr: errors.New(`invalid target key "apps/v1/Deployment/test-namespace/", expected a key built ` +
+				"by stabilization.TargetKey"),
+			key: "apps/v1/Deployment/test-namespace/",
+		},
+		{
+			description:       "Core API group",
+			expectedNamespace: "test-namespace",
+			expectedName:      "replicationcontroller-test",
+			key:               "v1/ReplicationController/test-namespace/test",
+		},
+		{
+			description:       "Named API group",
+			expectedNamespace: "other-namespace",
+			expectedName:      "statefulset-test",
+			key:               "apps/v1/StatefulSet/other-namespace/test",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			store := historystore.NewScalingHistoryStore(client)
+
+			err := store.Set(context.Background(), test.key, state)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			_, err = client.K8shorizmetricsV1alpha1().ScalingHistories(test.expectedNamespace).Get(
+				context.Background(), test.expectedName, metav1.GetOptions{})
+			if err != nil {
+				t.Errorf("expected scaling history %s/%s, got %v", test.expectedNamespace, test.expectedName, err)
+			}
+		})
+	}
+}
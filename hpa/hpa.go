@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hpa provides a single entry point for working out what a HorizontalPodAutoscaler would do, resolving the
+// HPA's scale target, gathering all of its metrics and evaluating them constrained by its scaling behavior and its
+// minimum and maximum replica counts in the same way as the HPA controller.
+package hpa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/behavior"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/scaler"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Result is the outcome of evaluating a HorizontalPodAutoscaler. DesiredReplicas is the replica count the HPA would
+// scale its target to, Metrics are the metrics gathered for the HPA and Normalization describes how the desired
+// replica count was constrained by the HPA's scaling behavior. If scaling is disabled, because the target has been
+// scaled to zero, Normalization and Metrics are nil.
+type Result struct {
+	CurrentReplicas int32                   `json:"currentReplicas"`
+	DesiredReplicas int32                   `json:"desiredReplicas"`
+	Metrics         []*metrics.Metric       `json:"metrics,omitempty"`
+	Normalization   *behavior.Normalization `json:"normalization,omitempty"`
+}
+
+// Autoscaler evaluates HorizontalPodAutoscalers, using the Gatherer to resolve the scale target and gather metrics,
+// the Evaluator to evaluate them and the Normalizer to apply each HPA's scaling behavior. The Gatherer must have its
+// ScaleClient and RESTMapper set. The Normalizer holds the recommendation history for each HPA, keyed by the HPA's
+// namespace and name, after the target is scaled RecordScale should be called so the scaling policies can limit
+// future scaling.
+type Autoscaler struct {
+	Gatherer   *k8shorizmetrics.Gatherer
+	Evaluator  *k8shorizmetrics.Evaluator
+	Normalizer *behavior.Normalizer
+}
+
+// NewAutoscaler sets up an Autoscaler using the gatherer and evaluator provided with a Normalizer using the HPA's
+// default downscale stabilization window
+func NewAutoscaler(gatherer *k8shorizmetrics.Gatherer, evaluator *k8shorizmetrics.Evaluator) *Autoscaler {
+	return &Autoscaler{
+		Gatherer:   gatherer,
+		Evaluator:  evaluator,
+		Normalizer: behavior.NewNormalizer(),
+	}
+}
+
+// Evaluate returns the replica count the HPA provided would scale its target to, see EvaluateWithContext
+func (a *Autoscaler) Evaluate(hpa *autoscalingv2.HorizontalPodAutoscaler) (*Result, error) {
+	return a.EvaluateWithContext(context.Background(), hpa)
+}
+
+// EvaluateWithContext returns the replica count the HPA provided would scale its target to, passing the context
+// provided to the Gatherer and Evaluator. The current replica count and pod selector are read from the scale
+// subresource of the HPA's scale target. If the target has been scaled to zero and the HPA has a non zero minimum
+// replica count scaling is disabled and no metrics are gathered.
+// As with the HPA, if some metrics fail to be gathered or evaluated the remaining metrics are still used, but the
+// target will not be scaled down; in this case the result is returned along with the error.
+func (a *Autoscaler) EvaluateWithContext(ctx context.Context,
+	hpa *autoscalingv2.HorizontalPodAutoscaler) (*Result, error) {
+	if a.Gatherer.ScaleClient == nil {
+		return nil, errors.New("failed to get scale target: no scale client configured")
+	}
+
+	if a.Gatherer.RESTMapper == nil {
+		return nil, errors.New("failed to get scale target: no REST mapper configured")
+	}
+
+	scaleTargetRef := hpa.Spec.ScaleTargetRef
+	scale, err := (&scaler.Scaler{
+		ScaleClient: a.Gatherer.ScaleClient,
+		RESTMapper:  a.Gatherer.RESTMapper,
+	}).GetScale(ctx, hpa.Namespace, scaleTargetRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scale target: %w", err)
+	}
+
+	currentReplicas := scale.Spec.Replicas
+
+	minReplicas := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = *hpa.Spec.MinReplicas
+	}
+
+	if currentReplicas == 0 && minReplicas != 0 {
+		return &Result{}, nil
+	}
+
+	if currentReplicas > hpa.Spec.MaxReplicas {
+		return limitedResult(currentReplicas, hpa.Spec.MaxReplicas, k8shorizmetrics.ReasonTooManyReplicas), nil
+	}
+
+	if currentReplicas < minReplicas {
+		return limitedResult(currentReplicas, minReplicas, k8shorizmetrics.ReasonTooFewReplicas), nil
+	}
+
+	if scale.Status.Selector == "" {
+		return nil, fmt.Errorf("failed to get scale target selector: selector is required for %s %q", scaleTargetRef.Kind,
+			scaleTargetRef.Name)
+	}
+
+	podSelector, err := labels.Parse(scale.Status.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scale target selector: %w", err)
+	}
+
+	gatheredMetrics, desiredReplicas, partialErr := k8shorizmetrics.GatherAndEvaluate(ctx, a.Gatherer, a.Evaluator,
+		hpa.Spec.Metrics, hpa.Namespace, podSelector, currentReplicas, k8shorizmetrics.EvaluateOptions{})
+	if partialErr != nil && !k8shorizmetrics.IsPartial(partialErr) {
+		return nil, partialErr
+	}
+
+	normalization := a.Normalizer.NormalizeWithReasons(Key(hpa), hpa.Spec.Behavior, minReplicas, hpa.Spec.MaxReplicas,
+		currentReplicas, desiredReplicas)
+
+	return &Result{
+		CurrentReplicas: currentReplicas,
+		DesiredReplicas: normalization.Replicas,
+		Metrics:         gatheredMetrics,
+		Normalization:   normalization,
+	}, partialErr
+}
+
+// RecordScale records that the target of the HPA provided was scaled from the previous replica count to the new
+// replica count, see behavior.Normalizer.RecordScale
+func (a *Autoscaler) RecordScale(hpa *autoscalingv2.HorizontalPodAutoscaler, previousReplicas int32,
+	newReplicas int32) {
+	a.Normalizer.RecordScale(Key(hpa), hpa.Spec.Behavior, previousReplicas, newReplicas)
+}
+
+// Forget removes the recommendation and scale event history held for the HPA provided, this should be called when
+// the HPA is deleted
+func (a *Autoscaler) Forget(hpa *autoscalingv2.HorizontalPodAutoscaler) {
+	a.Normalizer.Forget(Key(hpa))
+}
+
+// Key returns the key identifying the HPA provided in the Normalizer's history, made up of the HPA's namespace and
+// name
+func Key(hpa *autoscalingv2.HorizontalPodAutoscaler) string {
+	return fmt.Sprintf("%s/%s", hpa.Namespace, hpa.Name)
+}
+
+func limitedResult(currentReplicas int32, replicas int32, reason k8shorizmetrics.Reason) *Result {
+	return &Result{
+		CurrentReplicas: currentReplicas,
+		DesiredReplicas: replicas,
+		Normalization: &behavior.Normalization{
+			Replicas:            replicas,
+			StabilizedReplicas:  replicas,
+			StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+			LimitReason:         reason,
+		},
+	}
+}
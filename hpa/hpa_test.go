@@ -0,0 +1,362 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hpa_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/behavior"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/hpa"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakescale "k8s.io/client-go/scale/fake"
+	k8stesting "k8s.io/client-go/testing"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func podsSpec(name string) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: name,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+}
+
+func podsMetric(name string) *metrics.Metric {
+	return &metrics.Metric{
+		Spec: podsSpec(name),
+		Pods: &podsmetrics.Metric{},
+	}
+}
+
+func TestAutoscaler_Evaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description     string
+		expected        *hpa.Result
+		expectedErr     error
+		scale           *autoscalingv1.Scale
+		scaleErr        error
+		minReplicas     *int32
+		maxReplicas     int32
+		specs           []autoscalingv2.MetricSpec
+		gatherErrs      map[string]error
+		evaluations     map[string]int32
+		noScaleClient   bool
+		noRESTMapper    bool
+		expectNoGathers bool
+	}{
+		{
+			description:   "Fail, no scale client",
+			expectedErr:   errors.New("failed to get scale target: no scale client configured"),
+			maxReplicas:   10,
+			noScaleClient: true,
+		},
+		{
+			description:  "Fail, no REST mapper",
+			expectedErr:  errors.New("failed to get scale target: no REST mapper configured"),
+			maxReplicas:  10,
+			noRESTMapper: true,
+		},
+		{
+			description: "Fail, scale subresource error",
+			expectedErr: errors.New("failed to get scale target: failed to get scale subresource: scale error"),
+			scaleErr:    errors.New("scale error"),
+			maxReplicas: 10,
+		},
+		{
+			description: "Fail, empty selector",
+			expectedErr: errors.New(`failed to get scale target selector: selector is required for Deployment "test-deployment"`),
+			scale: &autoscalingv1.Scale{
+				Spec: autoscalingv1.ScaleSpec{Replicas: 3},
+			},
+			maxReplicas: 10,
+		},
+		{
+			description: "Fail, all metrics fail to gather",
+			expectedErr: errors.New("failed to gather metrics: gatherer multi metric error: 1 errors, first error is " +
+				"failed to get pods metric: gather error"),
+			scale: &autoscalingv1.Scale{
+				Spec:   autoscalingv1.ScaleSpec{Replicas: 3},
+				Status: autoscalingv1.ScaleStatus{Selector: "app=test"},
+			},
+			maxReplicas: 10,
+			specs:       []autoscalingv2.MetricSpec{podsSpec("a")},
+			gatherErrs:  map[string]error{"a": errors.New("gather error")},
+		},
+		{
+			description: "Success, scaling disabled, target scaled to zero",
+			expected:    &hpa.Result{},
+			scale: &autoscalingv1.Scale{
+				Spec:   autoscalingv1.ScaleSpec{Replicas: 0},
+				Status: autoscalingv1.ScaleStatus{Selector: "app=test"},
+			},
+			maxReplicas:     10,
+			specs:           []autoscalingv2.MetricSpec{podsSpec("a")},
+			expectNoGathers: true,
+		},
+		{
+			description: "Success, current replicas above max replicas",
+			expected: &hpa.Result{
+				CurrentReplicas: 12,
+				DesiredReplicas: 10,
+				Normalization: &behavior.Normalization{
+					Replicas:            10,
+					StabilizedReplicas:  10,
+					StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+					LimitReason:         k8shorizmetrics.ReasonTooManyReplicas,
+				},
+			},
+			scale: &autoscalingv1.Scale{
+				Spec:   autoscalingv1.ScaleSpec{Replicas: 12},
+				Status: autoscalingv1.ScaleStatus{Selector: "app=test"},
+			},
+			maxReplicas:     10,
+			specs:           []autoscalingv2.MetricSpec{podsSpec("a")},
+			expectNoGathers: true,
+		},
+		{
+			description: "Success, current replicas below min replicas",
+			expected: &hpa.Result{
+				CurrentReplicas: 1,
+				DesiredReplicas: 2,
+				Normalization: &behavior.Normalization{
+					Replicas:            2,
+					StabilizedReplicas:  2,
+					StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+					LimitReason:         k8shorizmetrics.ReasonTooFewReplicas,
+				},
+			},
+			scale: &autoscalingv1.Scale{
+				Spec:   autoscalingv1.ScaleSpec{Replicas: 1},
+				Status: autoscalingv1.ScaleStatus{Selector: "app=test"},
+			},
+			minReplicas:     testutil.Int32Ptr(2),
+			maxReplicas:     10,
+			specs:           []autoscalingv2.MetricSpec{podsSpec("a")},
+			expectNoGathers: true,
+		},
+		{
+			description: "Success, scale up",
+			expected: &hpa.Result{
+				CurrentReplicas: 3,
+				DesiredReplicas: 5,
+				Metrics:         []*metrics.Metric{podsMetric("a"), podsMetric("b")},
+				Normalization: &behavior.Normalization{
+					Replicas:            5,
+					StabilizedReplicas:  5,
+					StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+					LimitReason:         k8shorizmetrics.ReasonDesiredWithinRange,
+				},
+			},
+			scale: &autoscalingv1.Scale{
+				Spec:   autoscalingv1.ScaleSpec{Replicas: 3},
+				Status: autoscalingv1.ScaleStatus{Selector: "app=test"},
+			},
+			maxReplicas: 10,
+			specs:       []autoscalingv2.MetricSpec{podsSpec("a"), podsSpec("b")},
+			evaluations: map[string]int32{"a": 5, "b": 2},
+		},
+		{
+			description: "Success, scale up limited by max replicas",
+			expected: &hpa.Result{
+				CurrentReplicas: 3,
+				DesiredReplicas: 4,
+				Metrics:         []*metrics.Metric{podsMetric("a")},
+				Normalization: &behavior.Normalization{
+					Replicas:            4,
+					StabilizedReplicas:  7,
+					StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+					LimitReason:         k8shorizmetrics.ReasonTooManyReplicas,
+				},
+			},
+			scale: &autoscalingv1.Scale{
+				Spec:   autoscalingv1.ScaleSpec{Replicas: 3},
+				Status: autoscalingv1.ScaleStatus{Selector: "app=test"},
+			},
+			maxReplicas: 4,
+			specs:       []autoscalingv2.MetricSpec{podsSpec("a")},
+			evaluations: map[string]int32{"a": 7},
+		},
+		{
+			description: "Partial, scale up with a metric failing to gather",
+			expected: &hpa.Result{
+				CurrentReplicas: 3,
+				DesiredReplicas: 6,
+				Metrics:         []*metrics.Metric{podsMetric("b")},
+				Normalization: &behavior.Normalization{
+					Replicas:            6,
+					StabilizedReplicas:  6,
+					StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+					LimitReason:         k8shorizmetrics.ReasonDesiredWithinRange,
+				},
+			},
+			expectedErr: errors.New("gatherer multi metric error: 1 errors, first error is " +
+				"failed to get pods metric: gather error"),
+			scale: &autoscalingv1.Scale{
+				Spec:   autoscalingv1.ScaleSpec{Replicas: 3},
+				Status: autoscalingv1.ScaleStatus{Selector: "app=test"},
+			},
+			maxReplicas: 10,
+			specs:       []autoscalingv2.MetricSpec{podsSpec("a"), podsSpec("b")},
+			gatherErrs:  map[string]error{"a": errors.New("gather error")},
+			evaluations: map[string]int32{"b": 6},
+		},
+		{
+			description: "Partial, scale down prevented with a metric failing to gather",
+			expected: &hpa.Result{
+				CurrentReplicas: 3,
+				DesiredReplicas: 3,
+				Metrics:         []*metrics.Metric{podsMetric("b")},
+				Normalization: &behavior.Normalization{
+					Replicas:            3,
+					StabilizedReplicas:  3,
+					StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+					LimitReason:         k8shorizmetrics.ReasonDesiredWithinRange,
+				},
+			},
+			expectedErr: errors.New("gatherer multi metric error: 1 errors, first error is " +
+				"failed to get pods metric: gather error"),
+			scale: &autoscalingv1.Scale{
+				Spec:   autoscalingv1.ScaleSpec{Replicas: 3},
+				Status: autoscalingv1.ScaleStatus{Selector: "app=test"},
+			},
+			maxReplicas: 10,
+			specs:       []autoscalingv2.MetricSpec{podsSpec("a"), podsSpec("b")},
+			gatherErrs:  map[string]error{"a": errors.New("gather error")},
+			evaluations: map[string]int32{"b": 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+			restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+			scaleClient := &fakescale.FakeScaleClient{}
+			scaleClient.AddReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if test.scaleErr != nil {
+					return true, nil, test.scaleErr
+				}
+				return true, test.scale, nil
+			})
+
+			gathers := 0
+			gatherer := &k8shorizmetrics.Gatherer{
+				Pods: &fake.PodsGatherer{
+					GatherReactor: func(metricName, namespace string, selector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+						gathers++
+						if namespace != "test-namespace" {
+							t.Errorf("namespace mismatch, want test-namespace, got %s", namespace)
+						}
+						if selector.String() != "app=test" {
+							t.Errorf("pod selector mismatch, want app=test, got %s", selector)
+						}
+						if err, ok := test.gatherErrs[metricName]; ok {
+							return nil, err
+						}
+						return &podsmetrics.Metric{}, nil
+					},
+				},
+				ScaleClient: scaleClient,
+				RESTMapper:  restMapper,
+			}
+			if test.noScaleClient {
+				gatherer.ScaleClient = nil
+			}
+			if test.noRESTMapper {
+				gatherer.RESTMapper = nil
+			}
+
+			evaluator := &k8shorizmetrics.Evaluator{
+				Pods: &fake.PodsEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+						return test.evaluations[gatheredMetric.Spec.Pods.Metric.Name]
+					},
+				},
+			}
+
+			autoscaler := hpa.NewAutoscaler(gatherer, evaluator)
+			autoscaler.Normalizer.Clock = clocktesting.NewFakePassiveClock(time.Unix(1000, 0))
+
+			result, err := autoscaler.Evaluate(&autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-hpa",
+					Namespace: "test-namespace",
+				},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+						Name:       "test-deployment",
+					},
+					MinReplicas: test.minReplicas,
+					MaxReplicas: test.maxReplicas,
+					Metrics:     test.specs,
+				},
+			})
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("result mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+
+			if test.expectNoGathers && gathers != 0 {
+				t.Errorf("expected no metrics to be gathered, got %d gathers", gathers)
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	key := hpa.Key(&autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-hpa",
+			Namespace: "test-namespace",
+		},
+	})
+	if key != "test-namespace/test-hpa" {
+		t.Errorf("key mismatch, want test-namespace/test-hpa, got %s", key)
+	}
+}
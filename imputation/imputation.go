@@ -0,0 +1,389 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package imputation fills in metrics which could not be gathered at all, using previous observations of the same
+// metric spec. This differs from the HPA's handling of missing pods, which only covers individual pods missing from
+// an otherwise successfully gathered metric.
+//
+// Imputed metrics have their provenance marked as cached, and each imputation is recorded so it can be included when
+// explaining an evaluation.
+package imputation
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+// Strategy is how a missing metric is imputed
+type Strategy string
+
+const (
+	// StrategyLastObserved carries the last observed metric forward
+	StrategyLastObserved Strategy = "LastObserved"
+	// StrategyWindowMean uses the mean of the metric's values over the observation window
+	StrategyWindowMean Strategy = "WindowMean"
+	// StrategyZero uses the last observed metric with all values set to zero
+	StrategyZero Strategy = "Zero"
+)
+
+// Imputation records that a metric was imputed, Cycles is the number of consecutive cycles the metric has been
+// missing for
+type Imputation struct {
+	Spec     autoscalingv2.MetricSpec `json:"spec"`
+	Strategy Strategy                 `json:"strategy"`
+	Cycles   int                      `json:"cycles"`
+}
+
+// Explanation is the result of an evaluation along with the imputations made to the metrics it was based on
+type Explanation struct {
+	Replicas    int32        `json:"replicas"`
+	Imputations []Imputation `json:"imputations,omitempty"`
+}
+
+type specHistory struct {
+	observations []*metrics.Metric
+	missing      int
+}
+
+// Imputer tracks observations of each metric spec across gather cycles and imputes any that are missing. Window is
+// the number of observations kept for each metric spec, used by StrategyWindowMean, and is treated as 1 if not
+// positive. MaxCycles is the maximum number of consecutive cycles a metric will be imputed for before it is left
+// missing, if 0 there is no limit. A metric which has never been observed cannot be imputed.
+type Imputer struct {
+	Strategy  Strategy
+	Window    int
+	MaxCycles int
+
+	mu      sync.Mutex
+	history map[string]*specHistory
+}
+
+// Impute records the metrics gathered for the specs provided and imputes any specs without a gathered metric,
+// returning the gathered and imputed metrics in the order of the specs, along with a record of each imputation
+func (i *Imputer) Impute(specs []autoscalingv2.MetricSpec, gatheredMetrics []*metrics.Metric) ([]*metrics.Metric, []Imputation) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.history == nil {
+		i.history = map[string]*specHistory{}
+	}
+
+	window := i.Window
+	if window < 1 {
+		window = 1
+	}
+
+	gatheredByKey := map[string]*metrics.Metric{}
+	for _, gatheredMetric := range gatheredMetrics {
+		gatheredByKey[specKey(gatheredMetric.Spec)] = gatheredMetric
+	}
+
+	var result []*metrics.Metric
+	var imputations []Imputation
+	for _, spec := range specs {
+		key := specKey(spec)
+
+		history, ok := i.history[key]
+		if !ok {
+			history = &specHistory{}
+			i.history[key] = history
+		}
+
+		if gatheredMetric, ok := gatheredByKey[key]; ok {
+			history.missing = 0
+			history.observations = append(history.observations, copyMetric(gatheredMetric))
+			if len(history.observations) > window {
+				history.observations = history.observations[len(history.observations)-window:]
+			}
+			result = append(result, gatheredMetric)
+			continue
+		}
+
+		history.missing++
+
+		if len(history.observations) == 0 || (i.MaxCycles > 0 && history.missing > i.MaxCycles) {
+			continue
+		}
+
+		var imputed *metrics.Metric
+		switch i.Strategy {
+		case StrategyWindowMean:
+			imputed = meanMetric(history.observations)
+		case StrategyZero:
+			imputed = zeroMetric(history.observations[len(history.observations)-1])
+		default:
+			imputed = copyMetric(history.observations[len(history.observations)-1])
+		}
+
+		markCached(imputed)
+		result = append(result, imputed)
+		imputations = append(imputations, Imputation{
+			Spec:     spec,
+			Strategy: i.strategy(),
+			Cycles:   history.missing,
+		})
+	}
+
+	return result, imputations
+}
+
+// Evaluator imputes any metric specs missing from the gathered metrics before evaluating them
+type Evaluator struct {
+	Evaluator *k8shorizmetrics.Evaluator
+	Imputer   *Imputer
+}
+
+// Evaluate imputes the metrics missing for the specs provided, then returns the target replica count along with the
+// imputations made. If no metrics are available, even after imputation, the Evaluator is not called and an error is
+// returned.
+func (e *Evaluator) Evaluate(specs []autoscalingv2.MetricSpec, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32) (*Explanation, error) {
+	imputedMetrics, imputations := e.Imputer.Impute(specs, gatheredMetrics)
+	if len(imputedMetrics) == 0 {
+		return nil, errors.New("no metrics available to evaluate after imputation")
+	}
+
+	replicas, err := e.Evaluator.Evaluate(imputedMetrics, currentReplicas)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Explanation{
+		Replicas:    replicas,
+		Imputations: imputations,
+	}, nil
+}
+
+func (i *Imputer) strategy() Strategy {
+	if i.Strategy == "" {
+		return StrategyLastObserved
+	}
+	return i.Strategy
+}
+
+func specKey(spec autoscalingv2.MetricSpec) string {
+	key, _ := json.Marshal(spec)
+	return string(key)
+}
+
+func markCached(metric *metrics.Metric) {
+	var metricProvenance **provenance.Provenance
+	switch {
+	case metric.Resource != nil:
+		metricProvenance = &metric.Resource.Provenance
+	case metric.Pods != nil:
+		metricProvenance = &metric.Pods.Provenance
+	case metric.Object != nil:
+		metricProvenance = &metric.Object.Provenance
+	case metric.External != nil:
+		metricProvenance = &metric.External.Provenance
+	default:
+		return
+	}
+
+	cached := &provenance.Provenance{}
+	if *metricProvenance != nil {
+		*cached = **metricProvenance
+	}
+	cached.Cached = true
+	*metricProvenance = cached
+}
+
+// copyMetric copies the metric provided deeply enough that evaluating the copy does not modify the original
+func copyMetric(metric *metrics.Metric) *metrics.Metric {
+	copied := *metric
+	if metric.Resource != nil {
+		resourceMetric := *metric.Resource
+		resourceMetric.PodMetricsInfo = copyPodMetrics(metric.Resource.PodMetricsInfo)
+		copied.Resource = &resourceMetric
+	}
+	if metric.Pods != nil {
+		podsMetric := *metric.Pods
+		podsMetric.PodMetricsInfo = copyPodMetrics(metric.Pods.PodMetricsInfo)
+		copied.Pods = &podsMetric
+	}
+	if metric.Object != nil {
+		objectMetric := *metric.Object
+		objectMetric.Current = copyValue(metric.Object.Current)
+		copied.Object = &objectMetric
+	}
+	if metric.External != nil {
+		externalMetric := *metric.External
+		externalMetric.Current = copyValue(metric.External.Current)
+		copied.External = &externalMetric
+	}
+	return &copied
+}
+
+func copyPodMetrics(podMetrics podmetrics.MetricsInfo) podmetrics.MetricsInfo {
+	if podMetrics == nil {
+		return nil
+	}
+	copied := make(podmetrics.MetricsInfo, len(podMetrics))
+	for podName, podMetric := range podMetrics {
+		copied[podName] = podMetric
+	}
+	return copied
+}
+
+func copyValue(metricValue value.MetricValue) value.MetricValue {
+	copied := value.MetricValue{}
+	if metricValue.Value != nil {
+		v := *metricValue.Value
+		copied.Value = &v
+	}
+	if metricValue.AverageValue != nil {
+		v := *metricValue.AverageValue
+		copied.AverageValue = &v
+	}
+	return copied
+}
+
+func zeroMetric(metric *metrics.Metric) *metrics.Metric {
+	zeroed := copyMetric(metric)
+	if zeroed.Resource != nil {
+		zeroPodMetrics(zeroed.Resource.PodMetricsInfo)
+	}
+	if zeroed.Pods != nil {
+		zeroPodMetrics(zeroed.Pods.PodMetricsInfo)
+	}
+	if zeroed.Object != nil {
+		zeroed.Object.Current = zeroValue(zeroed.Object.Current)
+	}
+	if zeroed.External != nil {
+		zeroed.External.Current = zeroValue(zeroed.External.Current)
+	}
+	return zeroed
+}
+
+func zeroPodMetrics(podMetrics podmetrics.MetricsInfo) {
+	for podName, podMetric := range podMetrics {
+		podMetric.Value = 0
+		podMetrics[podName] = podMetric
+	}
+}
+
+func zeroValue(metricValue value.MetricValue) value.MetricValue {
+	zero := int64(0)
+	if metricValue.Value != nil {
+		metricValue.Value = &zero
+	}
+	if metricValue.AverageValue != nil {
+		metricValue.AverageValue = &zero
+	}
+	return metricValue
+}
+
+// meanMetric uses the latest observation with each value replaced by the mean of that value across the observations,
+// for pod metrics the mean is taken per pod for the pods in the latest observation
+func meanMetric(observations []*metrics.Metric) *metrics.Metric {
+	mean := copyMetric(observations[len(observations)-1])
+
+	if mean.Resource != nil {
+		meanPodMetrics(mean.Resource.PodMetricsInfo, observations, func(metric *metrics.Metric) podmetrics.MetricsInfo {
+			if metric.Resource == nil {
+				return nil
+			}
+			return metric.Resource.PodMetricsInfo
+		})
+	}
+
+	if mean.Pods != nil {
+		meanPodMetrics(mean.Pods.PodMetricsInfo, observations, func(metric *metrics.Metric) podmetrics.MetricsInfo {
+			if metric.Pods == nil {
+				return nil
+			}
+			return metric.Pods.PodMetricsInfo
+		})
+	}
+
+	if mean.Object != nil {
+		mean.Object.Current = meanValue(observations, func(metric *metrics.Metric) *value.MetricValue {
+			if metric.Object == nil {
+				return nil
+			}
+			return &metric.Object.Current
+		})
+	}
+
+	if mean.External != nil {
+		mean.External.Current = meanValue(observations, func(metric *metrics.Metric) *value.MetricValue {
+			if metric.External == nil {
+				return nil
+			}
+			return &metric.External.Current
+		})
+	}
+
+	return mean
+}
+
+func meanPodMetrics(podMetrics podmetrics.MetricsInfo, observations []*metrics.Metric,
+	get func(metric *metrics.Metric) podmetrics.MetricsInfo) {
+	for podName, podMetric := range podMetrics {
+		var total int64
+		var count int64
+		for _, observation := range observations {
+			observed, ok := get(observation)[podName]
+			if !ok {
+				continue
+			}
+			total += observed.Value
+			count++
+		}
+		podMetric.Value = int64(math.Round(float64(total) / float64(count)))
+		podMetrics[podName] = podMetric
+	}
+}
+
+func meanValue(observations []*metrics.Metric, get func(metric *metrics.Metric) *value.MetricValue) value.MetricValue {
+	var valueTotal, averageValueTotal int64
+	var valueCount, averageValueCount int64
+	for _, observation := range observations {
+		observed := get(observation)
+		if observed == nil {
+			continue
+		}
+		if observed.Value != nil {
+			valueTotal += *observed.Value
+			valueCount++
+		}
+		if observed.AverageValue != nil {
+			averageValueTotal += *observed.AverageValue
+			averageValueCount++
+		}
+	}
+
+	mean := value.MetricValue{}
+	if valueCount > 0 {
+		v := int64(math.Round(float64(valueTotal) / float64(valueCount)))
+		mean.Value = &v
+	}
+	if averageValueCount > 0 {
+		v := int64(math.Round(float64(averageValueTotal) / float64(averageValueCount)))
+		mean.AverageValue = &v
+	}
+	return mean
+}
@@ -0,0 +1,414 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imputation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/imputation"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	externalmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+var externalSpec = autoscalingv2.MetricSpec{
+	Type: autoscalingv2.ExternalMetricSourceType,
+	External: &autoscalingv2.ExternalMetricSource{
+		Metric: autoscalingv2.MetricIdentifier{
+			Name: "queue-length",
+		},
+	},
+}
+
+var podsSpec = autoscalingv2.MetricSpec{
+	Type: autoscalingv2.PodsMetricSourceType,
+	Pods: &autoscalingv2.PodsMetricSource{
+		Metric: autoscalingv2.MetricIdentifier{
+			Name: "requests-per-second",
+		},
+	},
+}
+
+func externalMetric(v int64, cached bool) *metrics.Metric {
+	metric := &metrics.Metric{
+		Spec: externalSpec,
+		External: &externalmetrics.Metric{
+			Current: value.MetricValue{
+				Value: int64Ptr(v),
+			},
+		},
+	}
+	if cached {
+		metric.External.Provenance = &provenance.Provenance{
+			Cached: true,
+		}
+	}
+	return metric
+}
+
+func podsMetric(values map[string]int64, cached bool) *metrics.Metric {
+	podMetricsInfo := podmetrics.MetricsInfo{}
+	for podName, v := range values {
+		podMetricsInfo[podName] = podmetrics.Metric{Value: v}
+	}
+	metric := &metrics.Metric{
+		Spec: podsSpec,
+		Pods: &podsmetrics.Metric{
+			PodMetricsInfo: podMetricsInfo,
+			ReadyPodCount:  int64(len(values)),
+		},
+	}
+	if cached {
+		metric.Pods.Provenance = &provenance.Provenance{
+			Cached: true,
+		}
+	}
+	return metric
+}
+
+type cycle struct {
+	gathered            []*metrics.Metric
+	expected            []*metrics.Metric
+	expectedImputations []imputation.Imputation
+}
+
+func TestImpute(t *testing.T) {
+	var tests = []struct {
+		description string
+		imputer     *imputation.Imputer
+		specs       []autoscalingv2.MetricSpec
+		cycles      []cycle
+	}{
+		{
+			"Never observed, not imputed",
+			&imputation.Imputer{},
+			[]autoscalingv2.MetricSpec{externalSpec},
+			[]cycle{
+				{
+					gathered: nil,
+					expected: nil,
+				},
+			},
+		},
+		{
+			"Default strategy, carry last observed",
+			&imputation.Imputer{},
+			[]autoscalingv2.MetricSpec{externalSpec},
+			[]cycle{
+				{
+					gathered: []*metrics.Metric{externalMetric(5, false)},
+					expected: []*metrics.Metric{externalMetric(5, false)},
+				},
+				{
+					gathered: []*metrics.Metric{externalMetric(7, false)},
+					expected: []*metrics.Metric{externalMetric(7, false)},
+				},
+				{
+					gathered: nil,
+					expected: []*metrics.Metric{externalMetric(7, true)},
+					expectedImputations: []imputation.Imputation{
+						{Spec: externalSpec, Strategy: imputation.StrategyLastObserved, Cycles: 1},
+					},
+				},
+				{
+					gathered: nil,
+					expected: []*metrics.Metric{externalMetric(7, true)},
+					expectedImputations: []imputation.Imputation{
+						{Spec: externalSpec, Strategy: imputation.StrategyLastObserved, Cycles: 2},
+					},
+				},
+			},
+		},
+		{
+			"Last observed, stop after max cycles and reset on observation",
+			&imputation.Imputer{
+				Strategy:  imputation.StrategyLastObserved,
+				MaxCycles: 1,
+			},
+			[]autoscalingv2.MetricSpec{externalSpec},
+			[]cycle{
+				{
+					gathered: []*metrics.Metric{externalMetric(5, false)},
+					expected: []*metrics.Metric{externalMetric(5, false)},
+				},
+				{
+					gathered: nil,
+					expected: []*metrics.Metric{externalMetric(5, true)},
+					expectedImputations: []imputation.Imputation{
+						{Spec: externalSpec, Strategy: imputation.StrategyLastObserved, Cycles: 1},
+					},
+				},
+				{
+					gathered: nil,
+					expected: nil,
+				},
+				{
+					gathered: []*metrics.Metric{externalMetric(3, false)},
+					expected: []*metrics.Metric{externalMetric(3, false)},
+				},
+				{
+					gathered: nil,
+					expected: []*metrics.Metric{externalMetric(3, true)},
+					expectedImputations: []imputation.Imputation{
+						{Spec: externalSpec, Strategy: imputation.StrategyLastObserved, Cycles: 1},
+					},
+				},
+			},
+		},
+		{
+			"Window mean, external metric",
+			&imputation.Imputer{
+				Strategy: imputation.StrategyWindowMean,
+				Window:   2,
+			},
+			[]autoscalingv2.MetricSpec{externalSpec},
+			[]cycle{
+				{
+					gathered: []*metrics.Metric{externalMetric(100, false)},
+					expected: []*metrics.Metric{externalMetric(100, false)},
+				},
+				{
+					gathered: []*metrics.Metric{externalMetric(4, false)},
+					expected: []*metrics.Metric{externalMetric(4, false)},
+				},
+				{
+					gathered: []*metrics.Metric{externalMetric(7, false)},
+					expected: []*metrics.Metric{externalMetric(7, false)},
+				},
+				{
+					gathered: nil,
+					expected: []*metrics.Metric{externalMetric(6, true)},
+					expectedImputations: []imputation.Imputation{
+						{Spec: externalSpec, Strategy: imputation.StrategyWindowMean, Cycles: 1},
+					},
+				},
+			},
+		},
+		{
+			"Window mean, pods metric averaged per pod",
+			&imputation.Imputer{
+				Strategy: imputation.StrategyWindowMean,
+				Window:   3,
+			},
+			[]autoscalingv2.MetricSpec{podsSpec},
+			[]cycle{
+				{
+					gathered: []*metrics.Metric{podsMetric(map[string]int64{"pod-1": 10, "pod-2": 20}, false)},
+					expected: []*metrics.Metric{podsMetric(map[string]int64{"pod-1": 10, "pod-2": 20}, false)},
+				},
+				{
+					gathered: []*metrics.Metric{podsMetric(map[string]int64{"pod-1": 20, "pod-3": 30}, false)},
+					expected: []*metrics.Metric{podsMetric(map[string]int64{"pod-1": 20, "pod-3": 30}, false)},
+				},
+				{
+					gathered: nil,
+					expected: []*metrics.Metric{podsMetric(map[string]int64{"pod-1": 15, "pod-3": 30}, true)},
+					expectedImputations: []imputation.Imputation{
+						{Spec: podsSpec, Strategy: imputation.StrategyWindowMean, Cycles: 1},
+					},
+				},
+			},
+		},
+		{
+			"Zero, one metric missing of two",
+			&imputation.Imputer{
+				Strategy: imputation.StrategyZero,
+			},
+			[]autoscalingv2.MetricSpec{podsSpec, externalSpec},
+			[]cycle{
+				{
+					gathered: []*metrics.Metric{
+						externalMetric(5, false),
+						podsMetric(map[string]int64{"pod-1": 10}, false),
+					},
+					expected: []*metrics.Metric{
+						podsMetric(map[string]int64{"pod-1": 10}, false),
+						externalMetric(5, false),
+					},
+				},
+				{
+					gathered: []*metrics.Metric{
+						externalMetric(6, false),
+					},
+					expected: []*metrics.Metric{
+						podsMetric(map[string]int64{"pod-1": 0}, true),
+						externalMetric(6, false),
+					},
+					expectedImputations: []imputation.Imputation{
+						{Spec: podsSpec, Strategy: imputation.StrategyZero, Cycles: 1},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			for i, cycle := range test.cycles {
+				result, imputations := test.imputer.Impute(test.specs, cycle.gathered)
+				if !cmp.Equal(result, cycle.expected) {
+					t.Errorf("cycle %d metrics mismatch (-want +got):\n%s", i, cmp.Diff(cycle.expected, result))
+				}
+				if !cmp.Equal(imputations, cycle.expectedImputations) {
+					t.Errorf("cycle %d imputations mismatch (-want +got):\n%s", i,
+						cmp.Diff(cycle.expectedImputations, imputations))
+				}
+			}
+		})
+	}
+}
+
+func TestImputeDoesNotShareState(t *testing.T) {
+	imputer := &imputation.Imputer{}
+	specs := []autoscalingv2.MetricSpec{podsSpec}
+
+	imputer.Impute(specs, []*metrics.Metric{podsMetric(map[string]int64{"pod-1": 10}, false)})
+
+	first, _ := imputer.Impute(specs, nil)
+	first[0].Pods.PodMetricsInfo["missing-pod"] = podmetrics.Metric{Value: 0}
+
+	second, _ := imputer.Impute(specs, nil)
+	expected := []*metrics.Metric{podsMetric(map[string]int64{"pod-1": 10}, true)}
+	if !cmp.Equal(second, expected) {
+		t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(expected, second))
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description     string
+		expected        *imputation.Explanation
+		expectedErr     error
+		evaluator       *imputation.Evaluator
+		specs           []autoscalingv2.MetricSpec
+		gatheredMetrics []*metrics.Metric
+		currentReplicas int32
+	}{
+		{
+			"Fail, no metrics available",
+			nil,
+			errors.New("no metrics available to evaluate after imputation"),
+			&imputation.Evaluator{
+				Evaluator: &k8shorizmetrics.Evaluator{},
+				Imputer:   &imputation.Imputer{},
+			},
+			[]autoscalingv2.MetricSpec{externalSpec},
+			nil,
+			3,
+		},
+		{
+			"Fail, evaluator error",
+			nil,
+			&k8shorizmetrics.EvaluatorMultiMetricError{
+				Partial: false,
+				Errors:  []error{errors.New("fail to evaluate")},
+			},
+			&imputation.Evaluator{
+				Evaluator: &k8shorizmetrics.Evaluator{
+					External: &fake.ExternalEvaluater{
+						EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+							return 0, errors.New("fail to evaluate")
+						},
+					},
+				},
+				Imputer: &imputation.Imputer{},
+			},
+			[]autoscalingv2.MetricSpec{externalSpec},
+			[]*metrics.Metric{externalMetric(5, false)},
+			3,
+		},
+		{
+			"Success, no imputation",
+			&imputation.Explanation{
+				Replicas: 5,
+			},
+			nil,
+			&imputation.Evaluator{
+				Evaluator: &k8shorizmetrics.Evaluator{
+					External: &fake.ExternalEvaluater{
+						EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+							return int32(*gatheredMetric.External.Current.Value), nil
+						},
+					},
+				},
+				Imputer: &imputation.Imputer{},
+			},
+			[]autoscalingv2.MetricSpec{externalSpec},
+			[]*metrics.Metric{externalMetric(5, false)},
+			3,
+		},
+		{
+			"Success, imputed metric recorded",
+			&imputation.Explanation{
+				Replicas: 0,
+				Imputations: []imputation.Imputation{
+					{Spec: externalSpec, Strategy: imputation.StrategyZero, Cycles: 1},
+				},
+			},
+			nil,
+			func() *imputation.Evaluator {
+				imputer := &imputation.Imputer{
+					Strategy: imputation.StrategyZero,
+				}
+				imputer.Impute([]autoscalingv2.MetricSpec{externalSpec}, []*metrics.Metric{externalMetric(5, false)})
+				return &imputation.Evaluator{
+					Evaluator: &k8shorizmetrics.Evaluator{
+						External: &fake.ExternalEvaluater{
+							EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+								return int32(*gatheredMetric.External.Current.Value), nil
+							},
+						},
+					},
+					Imputer: imputer,
+				}
+			}(),
+			[]autoscalingv2.MetricSpec{externalSpec},
+			nil,
+			3,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := test.evaluator.Evaluate(test.specs, test.gatheredMetrics, test.currentReplicas)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("explanation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
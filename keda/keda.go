@@ -0,0 +1,356 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package keda implements the KEDA external scaler gRPC protocol using the Gatherer and Evaluator, allowing
+// autoscaling/v2 metric specs written for the HPA to be served to KEDA ScaledObjects without rewriting them, for
+// example while migrating from the HPA to KEDA.
+//
+// Each ScaledObject trigger provides the metric specs in its metadata, see MetadataMetricSpecs, and the scale target
+// is looked up in the same way as the HPA to find the current replica count and pod selector. The replica count the
+// HPA would scale to is reported to KEDA as the metric value with a target of 1, so the HPA created by KEDA scales to
+// that replica count. The scaler is active unless every metric allows scaling to zero, see scaletozero.Idle.
+//
+// An example trigger:
+//
+//	triggers:
+//	- type: external
+//	  metadata:
+//	    scalerAddress: k8shorizmetrics-keda:9090
+//	    scaleTargetName: php-apache
+//	    metricSpecs: |
+//	      - type: Resource
+//	        resource:
+//	          name: cpu
+//	          target:
+//	            type: Utilization
+//	            averageUtilization: 50
+package keda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/keda/externalscalerpb"
+	"github.com/jthomperoo/k8shorizmetrics/v4/scaler"
+	"github.com/jthomperoo/k8shorizmetrics/v4/scaletozero"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/clock"
+)
+
+// Keys read from the scaler metadata of a ScaledObject trigger
+const (
+	// MetadataMetricSpecs is the key of the autoscaling/v2 metric specs to scale on, provided as a YAML or JSON list
+	// of metric specs or as a HorizontalPodAutoscaler, see k8shorizmetrics.ParseMetricSpecs. This is required.
+	MetadataMetricSpecs = "metricSpecs"
+	// MetadataScaleTargetName is the key of the name of the resource being scaled, if not set the name of the
+	// ScaledObject is used
+	MetadataScaleTargetName = "scaleTargetName"
+	// MetadataScaleTargetKind is the key of the kind of the resource being scaled, if not set Deployment is used
+	MetadataScaleTargetKind = "scaleTargetKind"
+	// MetadataScaleTargetAPIVersion is the key of the API version of the resource being scaled, if not set apps/v1 is
+	// used
+	MetadataScaleTargetAPIVersion = "scaleTargetAPIVersion"
+	// MetadataTolerance is the key of the tolerance to evaluate with, if not set the Evaluator's tolerance is used
+	MetadataTolerance = "tolerance"
+	// MetadataMetricName is the key of the name of the metric reported to KEDA, if not set DefaultMetricName is used
+	MetadataMetricName = "metricName"
+)
+
+const (
+	// DefaultMetricName is the name of the metric reported to KEDA if the trigger does not set one
+	DefaultMetricName = "k8shorizmetrics-replicas"
+	// DefaultPollingInterval is the interval between each check of whether the scaler is active when streaming,
+	// matching the default polling interval of KEDA
+	DefaultPollingInterval = 30 * time.Second
+)
+
+const (
+	defaultScaleTargetKind       = "Deployment"
+	defaultScaleTargetAPIVersion = "apps/v1"
+)
+
+// Server implements the KEDA ExternalScaler service using the Gatherer and Evaluator. The Gatherer's ScaleClient and
+// RESTMapper must be set to look up scale targets. PollingInterval is the interval used when streaming whether the
+// scaler is active, if zero DefaultPollingInterval is used. Clock is used to time streams, if nil the real clock is
+// used.
+type Server struct {
+	externalscalerpb.UnimplementedExternalScalerServer
+	Gatherer        *k8shorizmetrics.Gatherer
+	Evaluator       *k8shorizmetrics.Evaluator
+	PollingInterval time.Duration
+	Clock           clock.WithTicker
+}
+
+// NewServer sets up a server using the gatherer and evaluator provided
+func NewServer(gatherer *k8shorizmetrics.Gatherer, evaluator *k8shorizmetrics.Evaluator) *Server {
+	return &Server{
+		Gatherer:  gatherer,
+		Evaluator: evaluator,
+	}
+}
+
+// Register registers the server as the ExternalScaler implementation of the gRPC server provided
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	externalscalerpb.RegisterExternalScalerServer(registrar, s)
+}
+
+// IsActive returns false if every metric of the ScaledObject allows scaling to zero, and true otherwise. If some
+// metrics fail to be gathered the scaler is reported as active, as the missing metrics could require replicas.
+func (s *Server) IsActive(ctx context.Context,
+	ref *externalscalerpb.ScaledObjectRef) (*externalscalerpb.IsActiveResponse, error) {
+	trigger, err := parseTrigger(ref)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	active, err := s.isActive(ctx, trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalscalerpb.IsActiveResponse{
+		Result: active,
+	}, nil
+}
+
+// StreamIsActive checks whether the scaler is active immediately and then on each polling interval, sending the
+// result of each check until the call is cancelled or a check fails.
+func (s *Server) StreamIsActive(ref *externalscalerpb.ScaledObjectRef,
+	stream externalscalerpb.ExternalScaler_StreamIsActiveServer) error {
+	trigger, err := parseTrigger(ref)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	interval := s.PollingInterval
+	if interval == 0 {
+		interval = DefaultPollingInterval
+	}
+
+	streamClock := s.Clock
+	if streamClock == nil {
+		streamClock = clock.RealClock{}
+	}
+
+	ticker := streamClock.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx := stream.Context()
+	for {
+		active, err := s.isActive(ctx, trigger)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(&externalscalerpb.IsActiveResponse{Result: active}); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C():
+		}
+	}
+}
+
+// GetMetricSpec returns a single metric with a target of 1, which the metric returned by GetMetrics is measured
+// against
+func (s *Server) GetMetricSpec(ctx context.Context,
+	ref *externalscalerpb.ScaledObjectRef) (*externalscalerpb.GetMetricSpecResponse, error) {
+	trigger, err := parseTrigger(ref)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &externalscalerpb.GetMetricSpecResponse{
+		MetricSpecs: []*externalscalerpb.MetricSpec{
+			{
+				MetricName:      trigger.metricName,
+				TargetSize:      1,
+				TargetSizeFloat: 1,
+			},
+		},
+	}, nil
+}
+
+// GetMetrics gathers and evaluates the metrics of the ScaledObject, returning the replica count the HPA would scale
+// to as the metric value. If some metrics fail the replica count is evaluated from the remaining metrics, but will
+// not be lower than the current replica count as the missing metrics could require more replicas.
+func (s *Server) GetMetrics(ctx context.Context,
+	request *externalscalerpb.GetMetricsRequest) (*externalscalerpb.GetMetricsResponse, error) {
+	trigger, err := parseTrigger(request.GetScaledObjectRef())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	scale, err := s.getScale(ctx, trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	podSelector, err := scalePodSelector(trigger, scale)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to gather metrics: %v", err)
+	}
+
+	_, desiredReplicas, err := k8shorizmetrics.GatherAndEvaluate(ctx, s.Gatherer, s.Evaluator, trigger.specs,
+		trigger.namespace, podSelector, scale.Spec.Replicas, trigger.evaluateOptions)
+	if err != nil && !k8shorizmetrics.IsPartial(err) {
+		if errors.Is(err, k8shorizmetrics.ErrGatherMetrics) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	metricName := request.GetMetricName()
+	if metricName == "" {
+		metricName = trigger.metricName
+	}
+
+	return &externalscalerpb.GetMetricsResponse{
+		MetricValues: []*externalscalerpb.MetricValue{
+			{
+				MetricName:       metricName,
+				MetricValue:      int64(desiredReplicas),
+				MetricValueFloat: float64(desiredReplicas),
+			},
+		},
+	}, nil
+}
+
+func (s *Server) isActive(ctx context.Context, trigger *trigger) (bool, error) {
+	scale, err := s.getScale(ctx, trigger)
+	if err != nil {
+		return false, err
+	}
+
+	podSelector, err := scalePodSelector(trigger, scale)
+	if err != nil {
+		return false, status.Errorf(codes.Unavailable, "failed to gather metrics: %v", err)
+	}
+
+	gatheredMetrics, err := s.Gatherer.GatherWithContext(ctx, trigger.specs, trigger.namespace, podSelector)
+	if err != nil {
+		if !k8shorizmetrics.IsPartial(err) {
+			return false, status.Errorf(codes.Unavailable, "failed to gather metrics: %v", err)
+		}
+		return true, nil
+	}
+
+	return !scaletozero.Idle(gatheredMetrics), nil
+}
+
+func (s *Server) getScale(ctx context.Context, trigger *trigger) (*autoscalingv1.Scale, error) {
+	if s.Gatherer.ScaleClient == nil {
+		return nil, status.Error(codes.FailedPrecondition, "failed to get scale target: no scale client configured")
+	}
+
+	if s.Gatherer.RESTMapper == nil {
+		return nil, status.Error(codes.FailedPrecondition, "failed to get scale target: no REST mapper configured")
+	}
+
+	scale, err := (&scaler.Scaler{
+		ScaleClient: s.Gatherer.ScaleClient,
+		RESTMapper:  s.Gatherer.RESTMapper,
+	}).GetScale(ctx, trigger.namespace, trigger.scaleTargetRef)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to get scale target: %v", err)
+	}
+
+	return scale, nil
+}
+
+func scalePodSelector(trigger *trigger, scale *autoscalingv1.Scale) (labels.Selector, error) {
+	if scale.Status.Selector == "" {
+		return nil, fmt.Errorf("selector is required for %s %q", trigger.scaleTargetRef.Kind,
+			trigger.scaleTargetRef.Name)
+	}
+
+	podSelector, err := labels.Parse(scale.Status.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scale target selector: %w", err)
+	}
+
+	return podSelector, nil
+}
+
+// trigger is the configuration of a ScaledObject trigger, parsed from its scaler metadata
+type trigger struct {
+	namespace       string
+	specs           []autoscalingv2.MetricSpec
+	scaleTargetRef  autoscalingv2.CrossVersionObjectReference
+	evaluateOptions k8shorizmetrics.EvaluateOptions
+	metricName      string
+}
+
+func parseTrigger(ref *externalscalerpb.ScaledObjectRef) (*trigger, error) {
+	metadata := ref.GetScalerMetadata()
+
+	rawSpecs, ok := metadata[MetadataMetricSpecs]
+	if !ok || rawSpecs == "" {
+		return nil, fmt.Errorf("%s metadata is required", MetadataMetricSpecs)
+	}
+
+	specs, err := k8shorizmetrics.ParseMetricSpecs([]byte(rawSpecs))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s metadata: %w", MetadataMetricSpecs, err)
+	}
+
+	for i, spec := range specs {
+		if err := k8shorizmetrics.ValidateMetricSpec(spec); err != nil {
+			return nil, fmt.Errorf("invalid %s metadata: invalid metric spec at index %d: %w", MetadataMetricSpecs, i,
+				err)
+		}
+	}
+
+	parsed := &trigger{
+		namespace: ref.GetNamespace(),
+		specs:     specs,
+		scaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			Kind:       metadataOrDefault(metadata, MetadataScaleTargetKind, defaultScaleTargetKind),
+			Name:       metadataOrDefault(metadata, MetadataScaleTargetName, ref.GetName()),
+			APIVersion: metadataOrDefault(metadata, MetadataScaleTargetAPIVersion, defaultScaleTargetAPIVersion),
+		},
+		metricName: metadataOrDefault(metadata, MetadataMetricName, DefaultMetricName),
+	}
+
+	if rawTolerance, ok := metadata[MetadataTolerance]; ok && rawTolerance != "" {
+		tolerance, err := strconv.ParseFloat(rawTolerance, 64)
+		if err != nil || tolerance < 0 {
+			return nil, fmt.Errorf("invalid %s metadata: must be a non-negative number", MetadataTolerance)
+		}
+		parsed.evaluateOptions.Tolerance = tolerance
+	}
+
+	return parsed, nil
+}
+
+func metadataOrDefault(metadata map[string]string, key string, defaultValue string) string {
+	if value, ok := metadata[key]; ok && value != "" {
+		return value
+	}
+	return defaultValue
+}
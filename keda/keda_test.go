@@ -0,0 +1,444 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/keda"
+	"github.com/jthomperoo/k8shorizmetrics/v4/keda/externalscalerpb"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	externalmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakescale "k8s.io/client-go/scale/fake"
+	k8stesting "k8s.io/client-go/testing"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+// newClient serves the server provided over an in memory connection, returning a client connected to it
+func newClient(t *testing.T, server *keda.Server) externalscalerpb.ExternalScalerClient {
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	server.Register(grpcServer)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return externalscalerpb.NewExternalScalerClient(conn)
+}
+
+// newServer sets up a server scaling the php-apache deployment with the scale provided. External metrics are gathered
+// from the values provided, a metric named fail fails to gather and a metric named bad fails to evaluate. Evaluating
+// an external metric returns its value as the replica count.
+func newServer(scale *autoscalingv1.Scale, values map[string]int64) *keda.Server {
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	scaleClient := &fakescale.FakeScaleClient{}
+	scaleClient.AddReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		getAction := action.(k8stesting.GetAction)
+		if scale == nil || getAction.GetName() != scale.Name {
+			return true, nil, errors.New("not found")
+		}
+		return true, scale, nil
+	})
+
+	gatherer := &k8shorizmetrics.Gatherer{
+		External: &fake.ExternalGatherer{
+			GatherReactor: func(metricName, namespace string, metricSelector *metav1.LabelSelector,
+				podSelector labels.Selector) (*externalmetrics.Metric, error) {
+				if metricName == "fail" {
+					return nil, errors.New("fail")
+				}
+				current := values[metricName]
+				return &externalmetrics.Metric{Current: value.MetricValue{Value: &current}}, nil
+			},
+		},
+		ScaleClient: scaleClient,
+		RESTMapper:  restMapper,
+	}
+
+	evaluator := &k8shorizmetrics.Evaluator{
+		Tolerance: 0.1,
+		External: &fake.ExternalEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric,
+				tolerance float64) (int32, error) {
+				if gatheredMetric.Spec.External.Metric.Name == "bad" {
+					return 0, errors.New("bad")
+				}
+				return int32(*gatheredMetric.External.Current.Value), nil
+			},
+		},
+	}
+
+	return keda.NewServer(gatherer, evaluator)
+}
+
+func newScale(replicas int32) *autoscalingv1.Scale {
+	return &autoscalingv1.Scale{
+		ObjectMeta: metav1.ObjectMeta{Name: "php-apache", Namespace: "default"},
+		Spec:       autoscalingv1.ScaleSpec{Replicas: replicas},
+		Status:     autoscalingv1.ScaleStatus{Replicas: replicas, Selector: "app=php-apache"},
+	}
+}
+
+func metricSpecs(t *testing.T, names ...string) string {
+	specs := make([]autoscalingv2.MetricSpec, len(names))
+	for i, name := range names {
+		specs[i] = k8shorizmetrics.NewExternalValueSpec(name, resource.MustParse("1"), nil)
+	}
+	data, err := json.Marshal(specs)
+	if err != nil {
+		t.Fatalf("failed to marshal specs: %v", err)
+	}
+	return string(data)
+}
+
+func scaledObjectRef(metadata map[string]string) *externalscalerpb.ScaledObjectRef {
+	return &externalscalerpb.ScaledObjectRef{
+		Name:           "php-apache",
+		Namespace:      "default",
+		ScalerMetadata: metadata,
+	}
+}
+
+func equateStatus(t *testing.T, expected error, got error) {
+	want := status.Convert(expected).Proto()
+	if !cmp.Equal(want, status.Convert(got).Proto(), protocmp.Transform()) {
+		t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(want, status.Convert(got).Proto(), protocmp.Transform()))
+	}
+}
+
+func TestServerIsActive(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    *externalscalerpb.IsActiveResponse
+		expectedErr error
+		scale       *autoscalingv1.Scale
+		values      map[string]int64
+		metadata    func(t *testing.T) map[string]string
+	}{
+		{
+			description: "Missing metric specs",
+			expectedErr: status.Error(codes.InvalidArgument, "metricSpecs metadata is required"),
+			scale:       newScale(1),
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{}
+			},
+		},
+		{
+			description: "Invalid metric spec",
+			expectedErr: status.Error(codes.InvalidArgument,
+				"invalid metricSpecs metadata: invalid metric spec at index 0: invalid external metric source: "+
+					"external must be set"),
+			scale: newScale(1),
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{keda.MetadataMetricSpecs: `[{"type": "External"}]`}
+			},
+		},
+		{
+			description: "Invalid tolerance",
+			expectedErr: status.Error(codes.InvalidArgument,
+				"invalid tolerance metadata: must be a non-negative number"),
+			scale: newScale(1),
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{
+					keda.MetadataMetricSpecs: metricSpecs(t, "queue"),
+					keda.MetadataTolerance:   "-1",
+				}
+			},
+		},
+		{
+			description: "Scale target not found",
+			expectedErr: status.Error(codes.Unavailable,
+				"failed to get scale target: failed to get scale subresource: not found"),
+			scale: newScale(1),
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{
+					keda.MetadataMetricSpecs:     metricSpecs(t, "queue"),
+					keda.MetadataScaleTargetName: "missing",
+				}
+			},
+		},
+		{
+			description: "All metrics fail to gather",
+			expectedErr: status.Error(codes.Unavailable,
+				"failed to gather metrics: gatherer multi metric error: 1 errors, first error is failed to get "+
+					"external metric: fail"),
+			scale: newScale(1),
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{keda.MetadataMetricSpecs: metricSpecs(t, "fail")}
+			},
+		},
+		{
+			description: "Partial failure is active",
+			expected:    &externalscalerpb.IsActiveResponse{Result: true},
+			scale:       newScale(1),
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{keda.MetadataMetricSpecs: metricSpecs(t, "queue", "fail")}
+			},
+		},
+		{
+			description: "Idle metrics are inactive",
+			expected:    &externalscalerpb.IsActiveResponse{Result: false},
+			scale:       newScale(0),
+			values:      map[string]int64{"queue": 0},
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{keda.MetadataMetricSpecs: metricSpecs(t, "queue")}
+			},
+		},
+		{
+			description: "Non zero metric is active",
+			expected:    &externalscalerpb.IsActiveResponse{Result: true},
+			scale:       newScale(0),
+			values:      map[string]int64{"queue": 0, "requests": 5},
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{keda.MetadataMetricSpecs: metricSpecs(t, "queue", "requests")}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client := newClient(t, newServer(test.scale, test.values))
+
+			result, err := client.IsActive(context.Background(), scaledObjectRef(test.metadata(t)))
+			equateStatus(t, test.expectedErr, err)
+			if !cmp.Equal(test.expected, result, protocmp.Transform()) {
+				t.Errorf("response mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, protocmp.Transform()))
+			}
+		})
+	}
+}
+
+func TestServerGetMetricSpec(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    *externalscalerpb.GetMetricSpecResponse
+		expectedErr error
+		metadata    func(t *testing.T) map[string]string
+	}{
+		{
+			description: "Invalid metric specs",
+			expectedErr: status.Error(codes.InvalidArgument, "metricSpecs metadata is required"),
+			metadata: func(t *testing.T) map[string]string {
+				return nil
+			},
+		},
+		{
+			description: "Default metric name",
+			expected: &externalscalerpb.GetMetricSpecResponse{
+				MetricSpecs: []*externalscalerpb.MetricSpec{
+					{MetricName: keda.DefaultMetricName, TargetSize: 1, TargetSizeFloat: 1},
+				},
+			},
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{keda.MetadataMetricSpecs: metricSpecs(t, "queue")}
+			},
+		},
+		{
+			description: "Custom metric name",
+			expected: &externalscalerpb.GetMetricSpecResponse{
+				MetricSpecs: []*externalscalerpb.MetricSpec{
+					{MetricName: "php-apache-replicas", TargetSize: 1, TargetSizeFloat: 1},
+				},
+			},
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{
+					keda.MetadataMetricSpecs: metricSpecs(t, "queue"),
+					keda.MetadataMetricName:  "php-apache-replicas",
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client := newClient(t, newServer(newScale(1), nil))
+
+			result, err := client.GetMetricSpec(context.Background(), scaledObjectRef(test.metadata(t)))
+			equateStatus(t, test.expectedErr, err)
+			if !cmp.Equal(test.expected, result, protocmp.Transform()) {
+				t.Errorf("response mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, protocmp.Transform()))
+			}
+		})
+	}
+}
+
+func TestServerGetMetrics(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    *externalscalerpb.GetMetricsResponse
+		expectedErr error
+		scale       *autoscalingv1.Scale
+		values      map[string]int64
+		metadata    func(t *testing.T) map[string]string
+	}{
+		{
+			description: "Invalid metric specs",
+			expectedErr: status.Error(codes.InvalidArgument, "invalid metricSpecs metadata: invalid metric specs: "+
+				"yaml: line 1: did not find expected node content"),
+			scale: newScale(2),
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{keda.MetadataMetricSpecs: "["}
+			},
+		},
+		{
+			description: "Scale target has no selector",
+			expectedErr: status.Error(codes.Unavailable,
+				`failed to gather metrics: selector is required for Deployment "php-apache"`),
+			scale: &autoscalingv1.Scale{
+				ObjectMeta: metav1.ObjectMeta{Name: "php-apache", Namespace: "default"},
+				Spec:       autoscalingv1.ScaleSpec{Replicas: 2},
+			},
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{keda.MetadataMetricSpecs: metricSpecs(t, "queue")}
+			},
+		},
+		{
+			description: "All metrics fail to evaluate",
+			expectedErr: status.Error(codes.FailedPrecondition,
+				"failed to evaluate metrics: evaluator multi metric error: 1 errors, first error is bad"),
+			scale: newScale(2),
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{keda.MetadataMetricSpecs: metricSpecs(t, "bad")}
+			},
+		},
+		{
+			description: "Partial failure does not scale down",
+			expected: &externalscalerpb.GetMetricsResponse{
+				MetricValues: []*externalscalerpb.MetricValue{
+					{MetricName: "s0-k8shorizmetrics-replicas", MetricValue: 2, MetricValueFloat: 2},
+				},
+			},
+			scale:  newScale(2),
+			values: map[string]int64{"queue": 1},
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{keda.MetadataMetricSpecs: metricSpecs(t, "queue", "fail")}
+			},
+		},
+		{
+			description: "Partial failure scales up",
+			expected: &externalscalerpb.GetMetricsResponse{
+				MetricValues: []*externalscalerpb.MetricValue{
+					{MetricName: "s0-k8shorizmetrics-replicas", MetricValue: 4, MetricValueFloat: 4},
+				},
+			},
+			scale:  newScale(2),
+			values: map[string]int64{"queue": 4},
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{keda.MetadataMetricSpecs: metricSpecs(t, "queue", "bad")}
+			},
+		},
+		{
+			description: "Success",
+			expected: &externalscalerpb.GetMetricsResponse{
+				MetricValues: []*externalscalerpb.MetricValue{
+					{MetricName: "s0-k8shorizmetrics-replicas", MetricValue: 5, MetricValueFloat: 5},
+				},
+			},
+			scale:  newScale(2),
+			values: map[string]int64{"queue": 1, "requests": 5},
+			metadata: func(t *testing.T) map[string]string {
+				return map[string]string{keda.MetadataMetricSpecs: metricSpecs(t, "queue", "requests")}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client := newClient(t, newServer(test.scale, test.values))
+
+			result, err := client.GetMetrics(context.Background(), &externalscalerpb.GetMetricsRequest{
+				ScaledObjectRef: scaledObjectRef(test.metadata(t)),
+				MetricName:      "s0-k8shorizmetrics-replicas",
+			})
+			equateStatus(t, test.expectedErr, err)
+			if !cmp.Equal(test.expected, result, protocmp.Transform()) {
+				t.Errorf("response mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, protocmp.Transform()))
+			}
+		})
+	}
+}
+
+func TestServerStreamIsActive(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	values := map[string]int64{"queue": 0}
+	server := newServer(newScale(0), values)
+	server.Clock = fakeClock
+	client := newClient(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.StreamIsActive(ctx, scaledObjectRef(map[string]string{
+		keda.MetadataMetricSpecs: metricSpecs(t, "queue"),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expected := range []bool{false, true} {
+		result, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.GetResult() != expected {
+			t.Errorf("active mismatch, want %t, got %t", expected, result.GetResult())
+		}
+
+		// Wait for the stream to wait on the ticker before changing the metric and moving the clock forward
+		for !fakeClock.HasWaiters() {
+			time.Sleep(time.Millisecond)
+		}
+		values["queue"] = 3
+		fakeClock.Step(keda.DefaultPollingInterval)
+	}
+}
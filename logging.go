@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Verbosity levels the Gatherer and Evaluator log at. Debug logs describe each metric gathered and evaluated, trace
+// logs add the detail behind each decision, such as the reason each pod was excluded from a metric.
+const (
+	LogLevelDebug = 1
+	LogLevelTrace = 2
+)
+
+// resolveLogger returns the logger provided if it is set, otherwise the logger from the context provided, discarding
+// logs if neither is set
+func resolveLogger(ctx context.Context, logger logr.Logger) logr.Logger {
+	if logger.GetSink() != nil {
+		return logger
+	}
+	return logr.FromContextOrDiscard(ctx)
+}
+
+// logPodGrouping logs how the pods of a gathered resource or pods metric were grouped into ready, ignored and missing
+// pods, along with the reason any pods were excluded at trace level
+func logPodGrouping(log logr.Logger, gathered *metrics.Metric) {
+	var readyPodCount int64
+	var totalPods int
+	var ignoredPods, missingPods sets.String
+	var podErrors map[string]string
+	switch {
+	case gathered.Resource != nil:
+		readyPodCount, totalPods = gathered.Resource.ReadyPodCount, gathered.Resource.TotalPods
+		ignoredPods, missingPods = gathered.Resource.IgnoredPods, gathered.Resource.MissingPods
+		podErrors = gathered.Resource.Errors
+	case gathered.Pods != nil:
+		readyPodCount, totalPods = gathered.Pods.ReadyPodCount, gathered.Pods.TotalPods
+		ignoredPods, missingPods = gathered.Pods.IgnoredPods, gathered.Pods.MissingPods
+		podErrors = gathered.Pods.Errors
+	default:
+		return
+	}
+
+	log.V(LogLevelDebug).Info("Grouped pods", "totalPods", totalPods, "readyPods", readyPodCount,
+		"ignoredPods", ignoredPods.Len(), "missingPods", missingPods.Len())
+
+	traceLog := log.V(LogLevelTrace)
+	if !traceLog.Enabled() {
+		return
+	}
+	traceLog.Info("Pod groups", "ignoredPods", ignoredPods.List(), "missingPods", missingPods.List())
+	podNames := make([]string, 0, len(podErrors))
+	for podName := range podErrors {
+		podNames = append(podNames, podName)
+	}
+	sort.Strings(podNames)
+	for _, podName := range podNames {
+		traceLog.Info("Pod excluded", "pod", podName, "reason", podErrors[podName])
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// captureLogger returns a logger at the verbosity provided which records the message of each log line
+func captureLogger(t *testing.T, verbosity int, messages *[]string) logr.Logger {
+	return funcr.NewJSON(func(obj string) {
+		var line struct {
+			Msg string `json:"msg"`
+		}
+		if err := json.Unmarshal([]byte(obj), &line); err != nil {
+			t.Fatalf("failed to parse log line: %v", err)
+		}
+		*messages = append(*messages, line.Msg)
+	}, funcr.Options{Verbosity: verbosity})
+}
+
+func TestGatherLogging(t *testing.T) {
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expected    []string
+		verbosity   int
+		fromContext bool
+	}{
+		{
+			description: "Verbosity zero, nothing logged",
+			verbosity:   0,
+		},
+		{
+			description: "Debug verbosity, gathered metric and pod grouping logged",
+			expected:    []string{"Gathered metric", "Grouped pods"},
+			verbosity:   k8shorizmetrics.LogLevelDebug,
+		},
+		{
+			description: "Trace verbosity, pod grouping decisions logged",
+			expected: []string{
+				"Gathering metric",
+				"Gathered metric",
+				"Grouped pods",
+				"Pod groups",
+				"Pod excluded",
+			},
+			verbosity: k8shorizmetrics.LogLevelTrace,
+		},
+		{
+			description: "Logger from context used if no logger set",
+			expected:    []string{"Gathered metric", "Grouped pods"},
+			verbosity:   k8shorizmetrics.LogLevelDebug,
+			fromContext: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var messages []string
+			log := captureLogger(t, test.verbosity, &messages)
+
+			gatherer := &k8shorizmetrics.Gatherer{
+				Pods: &fake.PodsGatherer{
+					GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+						return &podsmetrics.Metric{
+							PodMetricsInfo: podmetrics.MetricsInfo{
+								"pod-1": podmetrics.Metric{Value: 1000},
+							},
+							ReadyPodCount: 1,
+							IgnoredPods:   sets.NewString("pod-2"),
+							MissingPods:   sets.String{},
+							Errors:        map[string]string{"pod-2": "pod is unready"},
+							TotalPods:     2,
+						}, nil
+					},
+				},
+			}
+
+			ctx := context.Background()
+			if test.fromContext {
+				ctx = logr.NewContext(ctx, log)
+			} else {
+				gatherer.Logger = log
+			}
+
+			_, err := gatherer.GatherSingleMetricWithContext(ctx, spec, "test", labels.Everything())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(test.expected, messages) {
+				t.Errorf("log messages mismatch (-want +got):\n%s", cmp.Diff(test.expected, messages))
+			}
+		})
+	}
+}
+
+func TestEvaluateLogging(t *testing.T) {
+	averageValue := resource.MustParse("50")
+	gatheredMetric := &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: &averageValue,
+				},
+			},
+		},
+		Pods: &podsmetrics.Metric{
+			PodMetricsInfo: podmetrics.MetricsInfo{
+				"pod-1": podmetrics.Metric{Value: 150000},
+				"pod-2": podmetrics.Metric{Value: 150000},
+			},
+			ReadyPodCount: 2,
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expected    []string
+		verbosity   int
+		maxStepSize int32
+	}{
+		{
+			description: "Verbosity zero, nothing logged",
+			verbosity:   0,
+		},
+		{
+			description: "Debug verbosity, tolerance check and evaluation logged",
+			expected: []string{
+				"Checked tolerance",
+				"Evaluated metric",
+				"Aggregated metric evaluations",
+			},
+			verbosity: k8shorizmetrics.LogLevelDebug,
+		},
+		{
+			description: "Debug verbosity, limited replica change logged",
+			expected: []string{
+				"Checked tolerance",
+				"Evaluated metric",
+				"Aggregated metric evaluations",
+				"Limited replica change",
+			},
+			verbosity:   k8shorizmetrics.LogLevelDebug,
+			maxStepSize: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var messages []string
+			evaluator := k8shorizmetrics.NewEvaluator(0.1)
+			evaluator.MaxStepSize = test.maxStepSize
+			evaluator.Logger = captureLogger(t, test.verbosity, &messages)
+
+			_, err := evaluator.Evaluate([]*metrics.Metric{gatheredMetric.DeepCopy()}, 2)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(test.expected, messages) {
+				t.Errorf("log messages mismatch (-want +got):\n%s", cmp.Diff(test.expected, messages))
+			}
+		})
+	}
+}
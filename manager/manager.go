@@ -0,0 +1,392 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package manager autoscales many workloads from a single process, gathering and evaluating metrics for every
+// registered target on a schedule and keeping the latest recommendation for each target. Targets are evaluated with
+// bounded parallelism so a large number of targets does not overwhelm the metrics APIs, and targets in the same
+// namespace share a metric cache so identical metrics are only gathered once per round.
+//
+// Pod lists are shared across all targets through the pod lister of the Gatherer provided, pass an informer backed
+// lister, see podsclient.NewInformerPodLister, to serve pod lists from a single watch rather than listing pods for
+// every target.
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/cache"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/scaler"
+	"github.com/jthomperoo/k8shorizmetrics/v4/stabilization"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// DefaultInterval is the interval between evaluation rounds if none is set, matching the default sync period of
+	// the HPA controller
+	DefaultInterval = 15 * time.Second
+	// DefaultParallelism is the maximum number of targets evaluated at the same time if none is set
+	DefaultParallelism = 10
+)
+
+// Target is a workload to autoscale, the scale target to look up the current replica count from, the metric specs it
+// is scaled on and the replica limits to keep it within. PodSelector is optional, if it is nil the pod selector is
+// read from the scale target's scale subresource. Replica counts below MinReplicas are raised to it, and MaxReplicas
+// of zero means the replica count is not limited to a maximum.
+type Target struct {
+	Namespace      string
+	ScaleTargetRef autoscalingv2.CrossVersionObjectReference
+	PodSelector    labels.Selector
+	Specs          []autoscalingv2.MetricSpec
+	MinReplicas    int32
+	MaxReplicas    int32
+}
+
+// Key returns the key identifying the target, see stabilization.TargetKey
+func (t Target) Key() string {
+	return stabilization.TargetKey(t.Namespace, t.ScaleTargetRef)
+}
+
+// Recommendation is the outcome of evaluating a target, the replica count the target should be scaled to based on
+// its metrics at the time of the evaluation. If the evaluation failed Err is set and only the Target and Time are
+// provided, if only some metrics failed the recommendation is provided alongside the error in Err.
+type Recommendation struct {
+	Target          Target
+	Time            time.Time
+	CurrentReplicas int32
+	TargetReplicas  int32
+	Limited         *k8shorizmetrics.LimitedEvaluation
+	Metrics         []*metrics.Metric
+	Err             error
+}
+
+// ScaleRecommendation returns the recommendation as a scaler.Recommendation, which can be applied to the target with
+// Scaler.Apply
+func (r *Recommendation) ScaleRecommendation() *scaler.Recommendation {
+	return &scaler.Recommendation{
+		Namespace:       r.Target.Namespace,
+		ScaleTargetRef:  r.Target.ScaleTargetRef,
+		CurrentReplicas: r.CurrentReplicas,
+		TargetReplicas:  r.TargetReplicas,
+	}
+}
+
+// Manager tracks many targets, evaluating every registered target each Interval with at most Parallelism targets
+// evaluated at the same time and keeping the latest recommendation for each target. The Gatherer's ScaleClient and
+// RESTMapper must be set to look up the current replica count and pod selector of each target.
+// Metrics are gathered through a cache shared by all targets in the same namespace, cached metrics are kept for the
+// CacheTTL, which defaults to the Interval so each metric is gathered at most once per round.
+type Manager struct {
+	Gatherer    *k8shorizmetrics.Gatherer
+	Evaluator   *k8shorizmetrics.Evaluator
+	Interval    time.Duration
+	Parallelism int
+	CacheTTL    time.Duration
+	Clock       clock.Clock
+	Logger      logr.Logger
+
+	mu              sync.Mutex
+	targets         map[string]Target
+	caches          map[string]*cache.Gatherer
+	recommendations map[string]*Recommendation
+}
+
+// NewManager sets up a Manager using the default interval and parallelism
+func NewManager(gatherer *k8shorizmetrics.Gatherer, evaluator *k8shorizmetrics.Evaluator) *Manager {
+	return &Manager{
+		Gatherer:    gatherer,
+		Evaluator:   evaluator,
+		Interval:    DefaultInterval,
+		Parallelism: DefaultParallelism,
+		Clock:       clock.RealClock{},
+	}
+}
+
+// Register adds the target to the manager, replacing any target with the same key, and returns the target's key. The
+// target is evaluated in the next round.
+func (m *Manager) Register(target Target) string {
+	key := target.Key()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.targets == nil {
+		m.targets = map[string]Target{}
+	}
+	m.targets[key] = target
+
+	return key
+}
+
+// Unregister stops evaluating the target with the key provided and forgets its latest recommendation. Once no
+// targets remain in a namespace the namespace's metric cache is dropped.
+func (m *Manager) Unregister(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	target, ok := m.targets[key]
+	if !ok {
+		return
+	}
+	delete(m.targets, key)
+	delete(m.recommendations, key)
+
+	for _, remaining := range m.targets {
+		if remaining.Namespace == target.Namespace {
+			return
+		}
+	}
+	delete(m.caches, target.Namespace)
+}
+
+// Targets returns the keys of the registered targets
+func (m *Manager) Targets() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]string, 0, len(m.targets))
+	for key := range m.targets {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Recommendation returns the latest recommendation for the target with the key provided, returning false if the
+// target is not registered or has not been evaluated yet
+func (m *Manager) Recommendation(key string) (*Recommendation, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	recommendation, ok := m.recommendations[key]
+	return recommendation, ok
+}
+
+// Recommendations returns the latest recommendation for every target that has been evaluated, keyed by target key
+func (m *Manager) Recommendations() map[string]*Recommendation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	recommendations := make(map[string]*Recommendation, len(m.recommendations))
+	for key, recommendation := range m.recommendations {
+		recommendations[key] = recommendation
+	}
+	return recommendations
+}
+
+// Run evaluates all registered targets immediately and then every Interval until the context is cancelled, waiting
+// for any round in progress to finish before returning
+func (m *Manager) Run(ctx context.Context) {
+	runClock := m.clock()
+	for {
+		m.EvaluateAll(ctx)
+
+		timer := runClock.NewTimer(m.interval())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+	}
+}
+
+// EvaluateAll runs a single round, evaluating every registered target with at most Parallelism targets evaluated at
+// the same time and storing the latest recommendation for each target. This blocks until every target has been
+// evaluated.
+func (m *Manager) EvaluateAll(ctx context.Context) {
+	m.mu.Lock()
+	targets := make([]Target, 0, len(m.targets))
+	for _, target := range m.targets {
+		targets = append(targets, target)
+	}
+	m.mu.Unlock()
+
+	parallelism := m.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+
+	semaphore := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for _, target := range targets {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case semaphore <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(target Target) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			recommendation, err := m.Evaluate(ctx, target)
+			if ctx.Err() != nil {
+				return
+			}
+			if recommendation == nil {
+				recommendation = &Recommendation{
+					Target: target,
+					Time:   m.clock().Now(),
+				}
+			}
+			recommendation.Err = err
+			if err != nil {
+				m.Logger.V(k8shorizmetrics.LogLevelDebug).Info("Failed to evaluate target", "target", target.Key(),
+					"error", err.Error())
+			}
+
+			m.store(target.Key(), recommendation)
+		}(target)
+	}
+	wg.Wait()
+}
+
+// Evaluate gathers and evaluates the metrics of the target provided through the target namespace's shared metric
+// cache, returning the recommended replica count limited to the target's replica limits. If some metrics fail the
+// recommendation is evaluated from the remaining metrics, but will not be lower than the current replica count as
+// the missing metrics could require more replicas, and the error is returned alongside the recommendation. Targets
+// with zero replicas are not evaluated and are recommended to stay at zero, in the same way as the HPA.
+func (m *Manager) Evaluate(ctx context.Context, target Target) (*Recommendation, error) {
+	if m.Gatherer.ScaleClient == nil {
+		return nil, errors.New("failed to get scale target: no scale client configured")
+	}
+
+	if m.Gatherer.RESTMapper == nil {
+		return nil, errors.New("failed to get scale target: no REST mapper configured")
+	}
+
+	now := m.clock().Now()
+
+	scale, err := (&scaler.Scaler{
+		ScaleClient: m.Gatherer.ScaleClient,
+		RESTMapper:  m.Gatherer.RESTMapper,
+	}).GetScale(ctx, target.Namespace, target.ScaleTargetRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scale target: %w", err)
+	}
+
+	currentReplicas := scale.Spec.Replicas
+	if currentReplicas == 0 {
+		return &Recommendation{
+			Target: target,
+			Time:   now,
+		}, nil
+	}
+
+	podSelector := target.PodSelector
+	if podSelector == nil {
+		if scale.Status.Selector == "" {
+			return nil, fmt.Errorf("failed to get scale target selector: selector is required for %s %q",
+				target.ScaleTargetRef.Kind, target.ScaleTargetRef.Name)
+		}
+
+		podSelector, err = labels.Parse(scale.Status.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get scale target selector: %w", err)
+		}
+	}
+
+	gatheredMetrics, targetReplicas, partialErr := k8shorizmetrics.GatherAndEvaluate(ctx, m.cache(target.Namespace),
+		m.Evaluator, target.Specs, target.Namespace, podSelector, currentReplicas, k8shorizmetrics.EvaluateOptions{})
+	if partialErr != nil && !k8shorizmetrics.IsPartial(partialErr) {
+		return nil, partialErr
+	}
+
+	var limited *k8shorizmetrics.LimitedEvaluation
+	if target.MinReplicas > 0 || target.MaxReplicas > 0 {
+		maxReplicas := target.MaxReplicas
+		if maxReplicas <= 0 {
+			maxReplicas = math.MaxInt32
+		}
+		limited = k8shorizmetrics.LimitReplicas(targetReplicas, target.MinReplicas, maxReplicas)
+		targetReplicas = limited.Replicas
+	}
+
+	return &Recommendation{
+		Target:          target,
+		Time:            now,
+		CurrentReplicas: currentReplicas,
+		TargetReplicas:  targetReplicas,
+		Limited:         limited,
+		Metrics:         gatheredMetrics,
+	}, partialErr
+}
+
+func (m *Manager) store(key string, recommendation *Recommendation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Targets unregistered during the round are not stored
+	if _, ok := m.targets[key]; !ok {
+		return
+	}
+
+	if m.recommendations == nil {
+		m.recommendations = map[string]*Recommendation{}
+	}
+	m.recommendations[key] = recommendation
+}
+
+// cache returns the metric cache shared by targets in the namespace provided, setting it up if needed
+func (m *Manager) cache(namespace string) *cache.Gatherer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.caches == nil {
+		m.caches = map[string]*cache.Gatherer{}
+	}
+
+	namespaceCache, ok := m.caches[namespace]
+	if !ok {
+		ttl := m.CacheTTL
+		if ttl <= 0 {
+			ttl = m.interval()
+		}
+		namespaceCache = &cache.Gatherer{
+			Gatherer: m.Gatherer,
+			TTL:      ttl,
+			Clock:    m.clock(),
+		}
+		m.caches[namespace] = namespaceCache
+	}
+
+	return namespaceCache
+}
+
+func (m *Manager) interval() time.Duration {
+	if m.Interval <= 0 {
+		return DefaultInterval
+	}
+	return m.Interval
+}
+
+func (m *Manager) clock() clock.Clock {
+	if m.Clock == nil {
+		return clock.RealClock{}
+	}
+	return m.Clock
+}
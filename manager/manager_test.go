@@ -0,0 +1,547 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/manager"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakescale "k8s.io/client-go/scale/fake"
+	k8stesting "k8s.io/client-go/testing"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+var now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func podsSpec(name string) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: name,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+}
+
+func podsMetric(name string) *metrics.Metric {
+	return &metrics.Metric{
+		Spec: podsSpec(name),
+		Pods: &podsmetrics.Metric{},
+	}
+}
+
+func target(namespace string, name string, specs ...autoscalingv2.MetricSpec) manager.Target {
+	return manager.Target{
+		Namespace: namespace,
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       name,
+		},
+		Specs: specs,
+	}
+}
+
+func limitedTarget(minReplicas int32, maxReplicas int32, specs ...autoscalingv2.MetricSpec) manager.Target {
+	limited := target("test-namespace", "test-deployment", specs...)
+	limited.MinReplicas = minReplicas
+	limited.MaxReplicas = maxReplicas
+	return limited
+}
+
+func newScale(replicas int32) *autoscalingv1.Scale {
+	return &autoscalingv1.Scale{
+		Spec:   autoscalingv1.ScaleSpec{Replicas: replicas},
+		Status: autoscalingv1.ScaleStatus{Selector: "app=test"},
+	}
+}
+
+// newManager sets up a manager for deployments with the scales provided, keyed by name. Pods metrics are gathered
+// unless they have an error in the gather errors provided, counting each gather in the gathers provided if it is not
+// nil, and each evaluates to the replica count in the evaluations provided.
+func newManager(scales func(name string) (*autoscalingv1.Scale, error), gatherErrs map[string]error,
+	evaluations map[string]int32, gathers *atomic.Int32) *manager.Manager {
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	scaleClient := &fakescale.FakeScaleClient{}
+	scaleClient.AddReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		scale, err := scales(action.(k8stesting.GetAction).GetName())
+		return true, scale, err
+	})
+
+	gatherer := &k8shorizmetrics.Gatherer{
+		Pods: &fake.PodsGatherer{
+			GatherReactor: func(metricName, namespace string, selector,
+				metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+				if gathers != nil {
+					gathers.Add(1)
+				}
+				if err, ok := gatherErrs[metricName]; ok {
+					return nil, err
+				}
+				return &podsmetrics.Metric{}, nil
+			},
+		},
+		ScaleClient: scaleClient,
+		RESTMapper:  restMapper,
+	}
+
+	evaluator := &k8shorizmetrics.Evaluator{
+		Pods: &fake.PodsEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+				return evaluations[gatheredMetric.Spec.Pods.Metric.Name]
+			},
+		},
+	}
+
+	m := manager.NewManager(gatherer, evaluator)
+	m.Clock = clocktesting.NewFakeClock(now)
+	return m
+}
+
+func TestManagerEvaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description   string
+		expected      *manager.Recommendation
+		expectedErr   error
+		scale         *autoscalingv1.Scale
+		scaleErr      error
+		target        manager.Target
+		gatherErrs    map[string]error
+		evaluations   map[string]int32
+		noScaleClient bool
+	}{
+		{
+			description:   "Fail, no scale client",
+			expectedErr:   errors.New("failed to get scale target: no scale client configured"),
+			target:        target("test-namespace", "test-deployment", podsSpec("a")),
+			noScaleClient: true,
+		},
+		{
+			description: "Fail, scale subresource error",
+			expectedErr: errors.New("failed to get scale target: failed to get scale subresource: scale error"),
+			scaleErr:    errors.New("scale error"),
+			target:      target("test-namespace", "test-deployment", podsSpec("a")),
+		},
+		{
+			description: "Fail, empty selector",
+			expectedErr: errors.New(`failed to get scale target selector: selector is required for Deployment "test-deployment"`),
+			scale: &autoscalingv1.Scale{
+				Spec: autoscalingv1.ScaleSpec{Replicas: 3},
+			},
+			target: target("test-namespace", "test-deployment", podsSpec("a")),
+		},
+		{
+			description: "Fail, all metrics fail to gather",
+			expectedErr: errors.New("failed to gather metrics: gatherer multi metric error: 1 errors, first error is " +
+				"failed to get pods metric: gather error"),
+			scale:      newScale(3),
+			target:     target("test-namespace", "test-deployment", podsSpec("a")),
+			gatherErrs: map[string]error{"a": errors.New("gather error")},
+		},
+		{
+			description: "Success, target scaled to zero is not evaluated",
+			expected: &manager.Recommendation{
+				Target: limitedTarget(1, 10, podsSpec("a")),
+				Time:   now,
+			},
+			scale:       newScale(0),
+			target:      limitedTarget(1, 10, podsSpec("a")),
+			evaluations: map[string]int32{"a": 5},
+		},
+		{
+			description: "Partial, does not scale down while metrics are missing",
+			expected: &manager.Recommendation{
+				Target:          target("test-namespace", "test-deployment", podsSpec("a"), podsSpec("b")),
+				Time:            now,
+				CurrentReplicas: 3,
+				TargetReplicas:  3,
+				Metrics:         []*metrics.Metric{podsMetric("b")},
+			},
+			expectedErr: errors.New("gatherer multi metric error: 1 errors, first error is failed to get pods metric: " +
+				"gather error"),
+			scale:       newScale(3),
+			target:      target("test-namespace", "test-deployment", podsSpec("a"), podsSpec("b")),
+			gatherErrs:  map[string]error{"a": errors.New("gather error")},
+			evaluations: map[string]int32{"b": 1},
+		},
+		{
+			description: "Success, no limits",
+			expected: &manager.Recommendation{
+				Target:          target("test-namespace", "test-deployment", podsSpec("a"), podsSpec("b")),
+				Time:            now,
+				CurrentReplicas: 3,
+				TargetReplicas:  50,
+				Metrics:         []*metrics.Metric{podsMetric("a"), podsMetric("b")},
+			},
+			scale:       newScale(3),
+			target:      target("test-namespace", "test-deployment", podsSpec("a"), podsSpec("b")),
+			evaluations: map[string]int32{"a": 50, "b": 2},
+		},
+		{
+			description: "Success, within limits",
+			expected: &manager.Recommendation{
+				Target:          limitedTarget(1, 10, podsSpec("a")),
+				Time:            now,
+				CurrentReplicas: 3,
+				TargetReplicas:  5,
+				Limited: &k8shorizmetrics.LimitedEvaluation{
+					Replicas:          5,
+					UnlimitedReplicas: 5,
+				},
+				Metrics: []*metrics.Metric{podsMetric("a")},
+			},
+			scale:       newScale(3),
+			target:      limitedTarget(1, 10, podsSpec("a")),
+			evaluations: map[string]int32{"a": 5},
+		},
+		{
+			description: "Success, lowered to maximum replicas",
+			expected: &manager.Recommendation{
+				Target:          limitedTarget(1, 10, podsSpec("a")),
+				Time:            now,
+				CurrentReplicas: 3,
+				TargetReplicas:  10,
+				Limited: &k8shorizmetrics.LimitedEvaluation{
+					Replicas:          10,
+					UnlimitedReplicas: 50,
+					Direction:         k8shorizmetrics.LimitDirectionLowered,
+				},
+				Metrics: []*metrics.Metric{podsMetric("a")},
+			},
+			scale:       newScale(3),
+			target:      limitedTarget(1, 10, podsSpec("a")),
+			evaluations: map[string]int32{"a": 50},
+		},
+		{
+			description: "Success, raised to minimum replicas",
+			expected: &manager.Recommendation{
+				Target:          limitedTarget(2, 10, podsSpec("a")),
+				Time:            now,
+				CurrentReplicas: 3,
+				TargetReplicas:  2,
+				Limited: &k8shorizmetrics.LimitedEvaluation{
+					Replicas:          2,
+					UnlimitedReplicas: 1,
+					Direction:         k8shorizmetrics.LimitDirectionRaised,
+				},
+				Metrics: []*metrics.Metric{podsMetric("a")},
+			},
+			scale:       newScale(3),
+			target:      limitedTarget(2, 10, podsSpec("a")),
+			evaluations: map[string]int32{"a": 1},
+		},
+		{
+			description: "Success, raised to minimum replicas without maximum replicas",
+			expected: &manager.Recommendation{
+				Target:          limitedTarget(2, 0, podsSpec("a")),
+				Time:            now,
+				CurrentReplicas: 3,
+				TargetReplicas:  2,
+				Limited: &k8shorizmetrics.LimitedEvaluation{
+					Replicas:          2,
+					UnlimitedReplicas: 1,
+					Direction:         k8shorizmetrics.LimitDirectionRaised,
+				},
+				Metrics: []*metrics.Metric{podsMetric("a")},
+			},
+			scale:       newScale(3),
+			target:      limitedTarget(2, 0, podsSpec("a")),
+			evaluations: map[string]int32{"a": 1},
+		},
+		{
+			description: "Success, not lowered without maximum replicas",
+			expected: &manager.Recommendation{
+				Target:          limitedTarget(2, 0, podsSpec("a")),
+				Time:            now,
+				CurrentReplicas: 3,
+				TargetReplicas:  50,
+				Limited: &k8shorizmetrics.LimitedEvaluation{
+					Replicas:          50,
+					UnlimitedReplicas: 50,
+				},
+				Metrics: []*metrics.Metric{podsMetric("a")},
+			},
+			scale:       newScale(3),
+			target:      limitedTarget(2, 0, podsSpec("a")),
+			evaluations: map[string]int32{"a": 50},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			m := newManager(func(name string) (*autoscalingv1.Scale, error) {
+				return test.scale, test.scaleErr
+			}, test.gatherErrs, test.evaluations, nil)
+			if test.noScaleClient {
+				m.Gatherer.ScaleClient = nil
+			}
+
+			result, err := m.Evaluate(context.Background(), test.target)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("recommendation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestManagerEvaluatePodSelector(t *testing.T) {
+	var selectors []string
+	m := newManager(func(name string) (*autoscalingv1.Scale, error) {
+		return &autoscalingv1.Scale{
+			Spec: autoscalingv1.ScaleSpec{Replicas: 3},
+		}, nil
+	}, nil, map[string]int32{"a": 4}, nil)
+	m.Gatherer.Pods = &fake.PodsGatherer{
+		GatherReactor: func(metricName, namespace string, selector,
+			metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+			selectors = append(selectors, selector.String())
+			return &podsmetrics.Metric{}, nil
+		},
+	}
+
+	selected := target("test-namespace", "test-deployment", podsSpec("a"))
+	selected.PodSelector = labels.SelectorFromSet(labels.Set{"app": "selected"})
+
+	result, err := m.Evaluate(context.Background(), selected)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.TargetReplicas != 4 {
+		t.Errorf("expected 4 target replicas, got %d", result.TargetReplicas)
+	}
+
+	expectedSelectors := []string{"app=selected"}
+	if !cmp.Equal(expectedSelectors, selectors) {
+		t.Errorf("selectors mismatch (-want +got):\n%s", cmp.Diff(expectedSelectors, selectors))
+	}
+}
+
+type result struct {
+	TargetReplicas int32
+	Err            string
+}
+
+func results(recommendations map[string]*manager.Recommendation) map[string]result {
+	summarised := map[string]result{}
+	for key, recommendation := range recommendations {
+		summary := result{TargetReplicas: recommendation.TargetReplicas}
+		if recommendation.Err != nil {
+			summary.Err = recommendation.Err.Error()
+		}
+		summarised[key] = summary
+	}
+	return summarised
+}
+
+func TestManagerEvaluateAll(t *testing.T) {
+	t.Run("Share metric cache within a namespace", func(t *testing.T) {
+		var gathers atomic.Int32
+		m := newManager(func(name string) (*autoscalingv1.Scale, error) {
+			if name == "failing" {
+				return nil, errors.New("scale error")
+			}
+			return newScale(2), nil
+		}, nil, map[string]int32{"a": 4}, &gathers)
+
+		first := m.Register(target("first-namespace", "first", podsSpec("a")))
+		second := m.Register(target("first-namespace", "second", podsSpec("a")))
+		third := m.Register(target("second-namespace", "third", podsSpec("a")))
+		failing := m.Register(target("second-namespace", "failing", podsSpec("a")))
+
+		m.EvaluateAll(context.Background())
+
+		expected := map[string]result{
+			first:   {TargetReplicas: 4},
+			second:  {TargetReplicas: 4},
+			third:   {TargetReplicas: 4},
+			failing: {Err: "failed to get scale target: failed to get scale subresource: scale error"},
+		}
+		if got := results(m.Recommendations()); !cmp.Equal(expected, got) {
+			t.Errorf("recommendations mismatch (-want +got):\n%s", cmp.Diff(expected, got))
+		}
+
+		// The first and second targets share a cached metric, the third target is in another namespace
+		if gathers.Load() != 2 {
+			t.Errorf("expected 2 gathers, got %d", gathers.Load())
+		}
+
+		recommendation, ok := m.Recommendation(third)
+		if !ok {
+			t.Fatalf("expected recommendation for %s", third)
+		}
+		if recommendation.CurrentReplicas != 2 {
+			t.Errorf("expected 2 current replicas, got %d", recommendation.CurrentReplicas)
+		}
+	})
+
+	t.Run("Bounded parallelism", func(t *testing.T) {
+		var inFlight, maxInFlight atomic.Int32
+		m := newManager(func(name string) (*autoscalingv1.Scale, error) {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				seen := maxInFlight.Load()
+				if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			return newScale(2), nil
+		}, nil, map[string]int32{"a": 4}, nil)
+		m.Parallelism = 2
+
+		for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+			m.Register(target("test-namespace", name, podsSpec("a")))
+		}
+
+		m.EvaluateAll(context.Background())
+
+		if maxInFlight.Load() > 2 {
+			t.Errorf("expected at most 2 targets evaluated at once, got %d", maxInFlight.Load())
+		}
+		if len(m.Recommendations()) != 6 {
+			t.Errorf("expected 6 recommendations, got %d", len(m.Recommendations()))
+		}
+	})
+
+	t.Run("Unregister forgets recommendation", func(t *testing.T) {
+		m := newManager(func(name string) (*autoscalingv1.Scale, error) {
+			return newScale(2), nil
+		}, nil, map[string]int32{"a": 4}, nil)
+
+		first := m.Register(target("test-namespace", "first", podsSpec("a")))
+		second := m.Register(target("test-namespace", "second", podsSpec("a")))
+
+		m.EvaluateAll(context.Background())
+		m.Unregister(first)
+
+		if _, ok := m.Recommendation(first); ok {
+			t.Errorf("expected no recommendation for unregistered target %s", first)
+		}
+
+		expectedTargets := []string{second}
+		if !cmp.Equal(expectedTargets, m.Targets()) {
+			t.Errorf("targets mismatch (-want +got):\n%s", cmp.Diff(expectedTargets, m.Targets()))
+		}
+	})
+}
+
+func TestManagerRun(t *testing.T) {
+	var gathers atomic.Int32
+	var mu sync.Mutex
+	replicas := int32(2)
+	m := newManager(func(name string) (*autoscalingv1.Scale, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return newScale(replicas), nil
+	}, nil, map[string]int32{"a": 4}, &gathers)
+	m.Interval = time.Minute
+
+	first := m.Register(target("test-namespace", "first", podsSpec("a")))
+	second := m.Register(target("test-namespace", "second", podsSpec("a")))
+
+	fakeClock := m.Clock.(*clocktesting.FakeClock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.Run(ctx)
+		close(done)
+	}()
+
+	// Wait for the first round to finish, then change the replica count and run the second round
+	for !fakeClock.HasWaiters() {
+		time.Sleep(time.Millisecond)
+	}
+	mu.Lock()
+	replicas = 3
+	mu.Unlock()
+	fakeClock.Step(time.Minute)
+
+	for {
+		recommendation, ok := m.Recommendation(second)
+		if ok && recommendation.CurrentReplicas == 3 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for !fakeClock.HasWaiters() {
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	<-done
+
+	keys := m.Targets()
+	sort.Strings(keys)
+	expectedKeys := []string{first, second}
+	sort.Strings(expectedKeys)
+	if !cmp.Equal(expectedKeys, keys) {
+		t.Errorf("targets mismatch (-want +got):\n%s", cmp.Diff(expectedKeys, keys))
+	}
+
+	// Each round gathers the shared metric once, the cache expires between rounds
+	if gathers.Load() != 2 {
+		t.Errorf("expected 2 gathers, got %d", gathers.Load())
+	}
+
+	for _, key := range []string{first, second} {
+		recommendation, ok := m.Recommendation(key)
+		if !ok {
+			t.Fatalf("expected recommendation for %s", key)
+		}
+		if recommendation.CurrentReplicas != 3 || !recommendation.Time.Equal(now.Add(time.Minute)) {
+			t.Errorf("expected recommendation from second round for %s, got %d replicas at %s", key,
+				recommendation.CurrentReplicas, recommendation.Time)
+		}
+	}
+}
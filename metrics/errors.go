@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+// ErrInvalidMetricSource occurs when a metric spec's source is not valid, for example if its target type is not
+// supported for the metric source type
+var ErrInvalidMetricSource = errors.New("invalid metric source")
+
+// InvalidMetricSourceError occurs when a metric spec's source is not valid, it matches ErrInvalidMetricSource when
+// using errors.Is.
+type InvalidMetricSourceError struct {
+	SourceType autoscalingv2.MetricSourceType
+	Reason     string
+}
+
+func (e *InvalidMetricSourceError) Error() string {
+	return fmt.Sprintf("invalid %s metric source: %s", strings.ToLower(string(e.SourceType)), e.Reason)
+}
+
+// Is returns true if the target is ErrInvalidMetricSource
+func (e *InvalidMetricSourceError) Is(target error) bool {
+	return target == ErrInvalidMetricSource
+}
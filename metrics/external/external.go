@@ -20,6 +20,7 @@ package external
 import (
 	"time"
 
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
 )
 
@@ -27,7 +28,8 @@ import (
 // on information coming from components running outside of cluster (for example length of queue in cloud messaging
 // service, or QPS from loadbalancer running outside of cluster).
 type Metric struct {
-	Current       value.MetricValue `json:"current,omitempty"`
-	ReadyPodCount *int64            `json:"readyPodCount,omitempty"`
-	Timestamp     time.Time         `json:"timestamp,omitempty"`
+	Current       value.MetricValue      `json:"current,omitempty"`
+	ReadyPodCount *int64                 `json:"readyPodCount,omitempty"`
+	Timestamp     time.Time              `json:"timestamp,omitempty"`
+	Provenance    *provenance.Provenance `json:"provenance,omitempty"`
 }
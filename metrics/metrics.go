@@ -18,18 +18,217 @@ limitations under the License.
 package metrics
 
 import (
+	"time"
+
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
-// Metric is a metric that has been retrieved from the K8s metrics server
+// Metric is a metric that has been retrieved from the K8s metrics server. Custom holds the value gathered for any
+// metric source types registered with the Gatherer that are not one of the built in autoscalingv2 source types.
+// Age is the age of the oldest sample in the metric when it was gathered, and Stale is set if this was older than the
+// maximum age configured on the Gatherer.
 type Metric struct {
 	Spec     autoscalingv2.MetricSpec `json:"spec"`
 	Resource *resource.Metric         `json:"resource,omitempty"`
 	Pods     *pods.Metric             `json:"pods,omitempty"`
 	Object   *object.Metric           `json:"object,omitempty"`
 	External *external.Metric         `json:"external,omitempty"`
+	Custom   any                      `json:"custom,omitempty"`
+	Age      time.Duration            `json:"age,omitempty"`
+	Stale    bool                     `json:"stale,omitempty"`
+}
+
+// Kind returns the type of metric source the metric was gathered for
+func (m *Metric) Kind() autoscalingv2.MetricSourceType {
+	if m == nil {
+		return ""
+	}
+	return m.Spec.Type
+}
+
+// Timestamp returns the timestamp of the gathered metric, or the zero time if the metric has no timestamp
+func (m *Metric) Timestamp() time.Time {
+	if m == nil {
+		return time.Time{}
+	}
+	switch {
+	case m.Resource != nil:
+		return m.Resource.Timestamp
+	case m.Pods != nil:
+		return m.Pods.Timestamp
+	case m.Object != nil:
+		return m.Object.Timestamp
+	case m.External != nil:
+		return m.External.Timestamp
+	}
+	return time.Time{}
+}
+
+// OldestTimestamp returns the timestamp of the oldest sample in the gathered metric, for resource and pods metrics the
+// oldest pod metric timestamp, otherwise the metric's timestamp. Returns the zero time if the metric has no timestamp.
+func (m *Metric) OldestTimestamp() time.Time {
+	oldest, _ := m.timestampRange()
+	return oldest
+}
+
+// NewestTimestamp returns the timestamp of the newest sample in the gathered metric, for resource and pods metrics the
+// newest pod metric timestamp, otherwise the metric's timestamp. Returns the zero time if the metric has no timestamp.
+func (m *Metric) NewestTimestamp() time.Time {
+	_, newest := m.timestampRange()
+	return newest
+}
+
+// timestampRange returns the oldest and newest timestamps of the gathered metric, falling back to the metric's
+// timestamp if the oldest and newest pod metric timestamps were not recorded
+func (m *Metric) timestampRange() (time.Time, time.Time) {
+	if m == nil {
+		return time.Time{}, time.Time{}
+	}
+	oldest, newest := time.Time{}, time.Time{}
+	switch {
+	case m.Resource != nil:
+		oldest, newest = m.Resource.OldestTimestamp, m.Resource.NewestTimestamp
+	case m.Pods != nil:
+		oldest, newest = m.Pods.OldestTimestamp, m.Pods.NewestTimestamp
+	}
+	if oldest.IsZero() || newest.IsZero() {
+		timestamp := m.Timestamp()
+		return timestamp, timestamp
+	}
+	return oldest, newest
+}
+
+// AsResource returns the resource metric if one was gathered, and whether it was present
+func (m *Metric) AsResource() (*resource.Metric, bool) {
+	return Value[resource.Metric](m)
+}
+
+// AsPods returns the pods metric if one was gathered, and whether it was present
+func (m *Metric) AsPods() (*pods.Metric, bool) {
+	return Value[pods.Metric](m)
+}
+
+// AsObject returns the object metric if one was gathered, and whether it was present
+func (m *Metric) AsObject() (*object.Metric, bool) {
+	return Value[object.Metric](m)
+}
+
+// AsExternal returns the external metric if one was gathered, and whether it was present
+func (m *Metric) AsExternal() (*external.Metric, bool) {
+	return Value[external.Metric](m)
+}
+
+// Value returns the gathered metric of the type provided, and whether it was present
+func Value[T resource.Metric | pods.Metric | object.Metric | external.Metric](m *Metric) (*T, bool) {
+	if m == nil {
+		return nil, false
+	}
+	for _, value := range []any{m.Resource, m.Pods, m.Object, m.External} {
+		if typed, ok := value.(*T); ok && typed != nil {
+			return typed, true
+		}
+	}
+	return nil, false
+}
+
+// DeepCopy returns a copy of the metric that can be modified without modifying the original, any Custom value is
+// copied shallowly
+func (m *Metric) DeepCopy() *Metric {
+	if m == nil {
+		return nil
+	}
+
+	copied := *m
+	copied.Spec = *m.Spec.DeepCopy()
+
+	if m.Resource != nil {
+		resourceMetric := *m.Resource
+		resourceMetric.PodMetricsInfo = copyPodMetrics(m.Resource.PodMetricsInfo)
+		resourceMetric.Requests = copyMap(m.Resource.Requests)
+		resourceMetric.Limits = copyMap(m.Resource.Limits)
+		resourceMetric.IgnoredPods = copySet(m.Resource.IgnoredPods)
+		resourceMetric.MissingPods = copySet(m.Resource.MissingPods)
+		resourceMetric.Errors = copyMap(m.Resource.Errors)
+		resourceMetric.DeletionCosts = copyMap(m.Resource.DeletionCosts)
+		resourceMetric.CurrentUtilization = copyPointer(m.Resource.CurrentUtilization)
+		resourceMetric.CurrentAverageValue = copyPointer(m.Resource.CurrentAverageValue)
+		resourceMetric.Provenance = copyPointer(m.Resource.Provenance)
+		copied.Resource = &resourceMetric
+	}
+
+	if m.Pods != nil {
+		podsMetric := *m.Pods
+		podsMetric.PodMetricsInfo = copyPodMetrics(m.Pods.PodMetricsInfo)
+		podsMetric.IgnoredPods = copySet(m.Pods.IgnoredPods)
+		podsMetric.MissingPods = copySet(m.Pods.MissingPods)
+		podsMetric.Errors = copyMap(m.Pods.Errors)
+		podsMetric.DeletionCosts = copyMap(m.Pods.DeletionCosts)
+		podsMetric.Provenance = copyPointer(m.Pods.Provenance)
+		copied.Pods = &podsMetric
+	}
+
+	if m.Object != nil {
+		objectMetric := *m.Object
+		objectMetric.Current = copyValue(m.Object.Current)
+		objectMetric.ReadyPodCount = copyPointer(m.Object.ReadyPodCount)
+		objectMetric.Provenance = copyPointer(m.Object.Provenance)
+		copied.Object = &objectMetric
+	}
+
+	if m.External != nil {
+		externalMetric := *m.External
+		externalMetric.Current = copyValue(m.External.Current)
+		externalMetric.ReadyPodCount = copyPointer(m.External.ReadyPodCount)
+		externalMetric.Provenance = copyPointer(m.External.Provenance)
+		copied.External = &externalMetric
+	}
+
+	return &copied
+}
+
+func copyPodMetrics(podMetrics podmetrics.MetricsInfo) podmetrics.MetricsInfo {
+	if podMetrics == nil {
+		return nil
+	}
+	return podmetrics.MetricsInfo(copyMap(map[string]podmetrics.Metric(podMetrics)))
+}
+
+func copyMap[V any](original map[string]V) map[string]V {
+	if original == nil {
+		return nil
+	}
+	copied := make(map[string]V, len(original))
+	for key, value := range original {
+		copied[key] = value
+	}
+	return copied
+}
+
+func copySet(original sets.String) sets.String {
+	if original == nil {
+		return nil
+	}
+	return sets.NewString(original.UnsortedList()...)
+}
+
+func copyPointer[T any](original *T) *T {
+	if original == nil {
+		return nil
+	}
+	copied := *original
+	return &copied
+}
+
+func copyValue(metricValue value.MetricValue) value.MetricValue {
+	return value.MetricValue{
+		Value:        copyPointer(metricValue.Value),
+		AverageValue: copyPointer(metricValue.AverageValue),
+	}
 }
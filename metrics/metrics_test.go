@@ -0,0 +1,360 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestMetric_Kind(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    autoscalingv2.MetricSourceType
+		metric      *metrics.Metric
+	}{
+		{
+			description: "Nil metric",
+			expected:    "",
+			metric:      nil,
+		},
+		{
+			description: "Pods metric",
+			expected:    autoscalingv2.PodsMetricSourceType,
+			metric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.PodsMetricSourceType,
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			kind := test.metric.Kind()
+			if !cmp.Equal(test.expected, kind) {
+				t.Errorf("kind mismatch (-want +got):\n%s", cmp.Diff(test.expected, kind))
+			}
+		})
+	}
+}
+
+func TestMetric_As(t *testing.T) {
+	resourceMetric := &resource.Metric{TotalPods: 1}
+	podsMetric := &pods.Metric{TotalPods: 2}
+	objectMetric := &object.Metric{}
+	externalMetric := &external.Metric{}
+
+	var tests = []struct {
+		description      string
+		expectedResource *resource.Metric
+		expectedPods     *pods.Metric
+		expectedObject   *object.Metric
+		expectedExternal *external.Metric
+		metric           *metrics.Metric
+	}{
+		{
+			description: "Nil metric",
+			metric:      nil,
+		},
+		{
+			description: "Empty metric",
+			metric:      &metrics.Metric{},
+		},
+		{
+			description:      "Resource metric",
+			expectedResource: resourceMetric,
+			metric: &metrics.Metric{
+				Resource: resourceMetric,
+			},
+		},
+		{
+			description:  "Pods metric",
+			expectedPods: podsMetric,
+			metric: &metrics.Metric{
+				Pods: podsMetric,
+			},
+		},
+		{
+			description:    "Object metric",
+			expectedObject: objectMetric,
+			metric: &metrics.Metric{
+				Object: objectMetric,
+			},
+		},
+		{
+			description:      "External metric",
+			expectedExternal: externalMetric,
+			metric: &metrics.Metric{
+				External: externalMetric,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			resourceValue, ok := test.metric.AsResource()
+			if resourceValue != test.expectedResource || ok != (test.expectedResource != nil) {
+				t.Errorf("resource mismatch, want %v got %v (%t)", test.expectedResource, resourceValue, ok)
+			}
+			podsValue, ok := test.metric.AsPods()
+			if podsValue != test.expectedPods || ok != (test.expectedPods != nil) {
+				t.Errorf("pods mismatch, want %v got %v (%t)", test.expectedPods, podsValue, ok)
+			}
+			objectValue, ok := test.metric.AsObject()
+			if objectValue != test.expectedObject || ok != (test.expectedObject != nil) {
+				t.Errorf("object mismatch, want %v got %v (%t)", test.expectedObject, objectValue, ok)
+			}
+			externalValue, ok := test.metric.AsExternal()
+			if externalValue != test.expectedExternal || ok != (test.expectedExternal != nil) {
+				t.Errorf("external mismatch, want %v got %v (%t)", test.expectedExternal, externalValue, ok)
+			}
+			genericValue, ok := metrics.Value[resource.Metric](test.metric)
+			if genericValue != test.expectedResource || ok != (test.expectedResource != nil) {
+				t.Errorf("generic resource mismatch, want %v got %v (%t)", test.expectedResource, genericValue, ok)
+			}
+		})
+	}
+}
+
+func TestMetric_DeepCopy(t *testing.T) {
+	var tests = []struct {
+		description string
+		metric      *metrics.Metric
+		modify      func(metric *metrics.Metric)
+	}{
+		{
+			description: "Nil metric",
+			metric:      nil,
+			modify:      func(metric *metrics.Metric) {},
+		},
+		{
+			description: "Resource metric",
+			metric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: "cpu",
+					},
+				},
+				Resource: &resource.Metric{
+					PodMetricsInfo:      podmetrics.MetricsInfo{"pod-1": {Value: 1}},
+					Requests:            map[string]int64{"pod-1": 1},
+					Limits:              map[string]int64{"pod-1": 2},
+					IgnoredPods:         sets.NewString("pod-2"),
+					MissingPods:         sets.NewString("pod-3"),
+					Errors:              map[string]string{"pod-4": "failed"},
+					DeletionCosts:       map[string]int32{"pod-1": 10},
+					Provenance:          &provenance.Provenance{Source: provenance.SourceResource},
+					CurrentUtilization:  testutil.Int32Ptr(50),
+					CurrentAverageValue: testutil.Int64Ptr(1),
+				},
+			},
+			modify: func(metric *metrics.Metric) {
+				metric.Spec.Resource.Name = "memory"
+				metric.Resource.PodMetricsInfo["pod-5"] = podmetrics.Metric{}
+				metric.Resource.Requests["pod-5"] = 5
+				metric.Resource.Limits["pod-5"] = 5
+				metric.Resource.IgnoredPods.Insert("pod-5")
+				metric.Resource.MissingPods.Insert("pod-5")
+				metric.Resource.Errors["pod-5"] = "failed"
+				metric.Resource.DeletionCosts["pod-5"] = 5
+				metric.Resource.Provenance.Cached = true
+				*metric.Resource.CurrentUtilization = 100
+				*metric.Resource.CurrentAverageValue = 2
+			},
+		},
+		{
+			description: "Pods metric",
+			metric: &metrics.Metric{
+				Pods: &pods.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{"pod-1": {Value: 1}},
+					IgnoredPods:    sets.NewString("pod-2"),
+					MissingPods:    sets.NewString("pod-3"),
+					DeletionCosts:  map[string]int32{"pod-1": 10},
+					Provenance:     &provenance.Provenance{Source: provenance.SourceCustom},
+				},
+			},
+			modify: func(metric *metrics.Metric) {
+				metric.Pods.PodMetricsInfo["pod-5"] = podmetrics.Metric{}
+				metric.Pods.IgnoredPods.Insert("pod-5")
+				metric.Pods.MissingPods.Insert("pod-5")
+				metric.Pods.DeletionCosts["pod-5"] = 5
+				metric.Pods.Provenance.Cached = true
+			},
+		},
+		{
+			description: "Object and external metrics",
+			metric: &metrics.Metric{
+				Object: &object.Metric{
+					Current:       value.MetricValue{Value: testutil.Int64Ptr(1)},
+					ReadyPodCount: testutil.Int64Ptr(2),
+				},
+				External: &external.Metric{
+					Current:       value.MetricValue{AverageValue: testutil.Int64Ptr(3)},
+					ReadyPodCount: testutil.Int64Ptr(4),
+				},
+			},
+			modify: func(metric *metrics.Metric) {
+				*metric.Object.Current.Value = 5
+				*metric.Object.ReadyPodCount = 5
+				*metric.External.Current.AverageValue = 5
+				*metric.External.ReadyPodCount = 5
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			copied := test.metric.DeepCopy()
+			if !cmp.Equal(test.metric, copied) {
+				t.Fatalf("copy mismatch (-want +got):\n%s", cmp.Diff(test.metric, copied))
+			}
+			if copied == nil {
+				return
+			}
+			original := test.metric.DeepCopy()
+			test.modify(copied)
+			if !cmp.Equal(original, test.metric) {
+				t.Errorf("modifying copy modified original (-want +got):\n%s", cmp.Diff(original, test.metric))
+			}
+		})
+	}
+}
+
+func TestMetric_Timestamp(t *testing.T) {
+	timestamp := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		description string
+		expected    time.Time
+		metric      *metrics.Metric
+	}{
+		{
+			description: "Nil metric",
+			expected:    time.Time{},
+			metric:      nil,
+		},
+		{
+			description: "Custom metric",
+			expected:    time.Time{},
+			metric:      &metrics.Metric{Custom: 1},
+		},
+		{
+			description: "Resource metric",
+			expected:    timestamp,
+			metric:      &metrics.Metric{Resource: &resource.Metric{Timestamp: timestamp}},
+		},
+		{
+			description: "Pods metric",
+			expected:    timestamp,
+			metric:      &metrics.Metric{Pods: &pods.Metric{Timestamp: timestamp}},
+		},
+		{
+			description: "Object metric",
+			expected:    timestamp,
+			metric:      &metrics.Metric{Object: &object.Metric{Timestamp: timestamp}},
+		},
+		{
+			description: "External metric",
+			expected:    timestamp,
+			metric:      &metrics.Metric{External: &external.Metric{Timestamp: timestamp}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := test.metric.Timestamp()
+			if !result.Equal(test.expected) {
+				t.Errorf("timestamp mismatch, want %s, got %s", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestMetric_OldestAndNewestTimestamp(t *testing.T) {
+	timestamp := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	oldest := timestamp.Add(-time.Minute)
+	newest := timestamp.Add(time.Minute)
+
+	var tests = []struct {
+		description    string
+		expectedOldest time.Time
+		expectedNewest time.Time
+		metric         *metrics.Metric
+	}{
+		{
+			description:    "Nil metric",
+			expectedOldest: time.Time{},
+			expectedNewest: time.Time{},
+			metric:         nil,
+		},
+		{
+			description:    "Resource metric, no pod timestamps, fall back to timestamp",
+			expectedOldest: timestamp,
+			expectedNewest: timestamp,
+			metric:         &metrics.Metric{Resource: &resource.Metric{Timestamp: timestamp}},
+		},
+		{
+			description:    "Resource metric, pod timestamps",
+			expectedOldest: oldest,
+			expectedNewest: newest,
+			metric: &metrics.Metric{Resource: &resource.Metric{
+				Timestamp:       timestamp,
+				OldestTimestamp: oldest,
+				NewestTimestamp: newest,
+			}},
+		},
+		{
+			description:    "Pods metric, pod timestamps",
+			expectedOldest: oldest,
+			expectedNewest: newest,
+			metric: &metrics.Metric{Pods: &pods.Metric{
+				Timestamp:       timestamp,
+				OldestTimestamp: oldest,
+				NewestTimestamp: newest,
+			}},
+		},
+		{
+			description:    "External metric",
+			expectedOldest: timestamp,
+			expectedNewest: timestamp,
+			metric:         &metrics.Metric{External: &external.Metric{Timestamp: timestamp}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			resultOldest := test.metric.OldestTimestamp()
+			if !resultOldest.Equal(test.expectedOldest) {
+				t.Errorf("oldest timestamp mismatch, want %s, got %s", test.expectedOldest, resultOldest)
+			}
+			resultNewest := test.metric.NewestTimestamp()
+			if !resultNewest.Equal(test.expectedNewest) {
+				t.Errorf("newest timestamp mismatch, want %s, got %s", test.expectedNewest, resultNewest)
+			}
+		})
+	}
+}
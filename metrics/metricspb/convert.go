@@ -0,0 +1,371 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metricspb provides protobuf messages for the gathered metric models, allowing gathered metrics to be passed
+// between processes in a compact, schema'd format. The messages are generated from metrics.proto, FromMetric and
+// ToMetric convert between them and the metrics.Metric model, and Marshal and Unmarshal convert directly to and from
+// the protobuf wire format.
+//
+// The metric spec is encoded using the Kubernetes protobuf encoding of autoscaling/v2 MetricSpec, and any Custom value
+// is encoded as JSON, decoding to a json.RawMessage. Timestamps are decoded in UTC. The pod metrics, requests, ignored
+// pods and missing pods are always decoded as non-nil, while any other empty maps are decoded as nil.
+package metricspb
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Marshal encodes the gathered metric provided in the protobuf wire format
+func Marshal(gatheredMetric *metrics.Metric) ([]byte, error) {
+	message, err := FromMetric(gatheredMetric)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(message)
+}
+
+// Unmarshal decodes a gathered metric from the protobuf wire format
+func Unmarshal(data []byte) (*metrics.Metric, error) {
+	message := &Metric{}
+	err := proto.Unmarshal(data, message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metric: %w", err)
+	}
+	return ToMetric(message)
+}
+
+// FromMetric converts a gathered metric into its protobuf message, returning nil if the metric is nil. The message
+// shares any maps and pointers with the metric provided.
+func FromMetric(gatheredMetric *metrics.Metric) (*Metric, error) {
+	if gatheredMetric == nil {
+		return nil, nil
+	}
+
+	spec, err := gatheredMetric.Spec.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metric spec: %w", err)
+	}
+
+	var custom []byte
+	if gatheredMetric.Custom != nil {
+		custom, err = json.Marshal(gatheredMetric.Custom)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal custom metric value: %w", err)
+		}
+	}
+
+	return &Metric{
+		Spec:     spec,
+		Resource: fromResource(gatheredMetric.Resource),
+		Pods:     fromPods(gatheredMetric.Pods),
+		Object:   fromObject(gatheredMetric.Object),
+		External: fromExternal(gatheredMetric.External),
+		Custom:   custom,
+		Age:      fromDuration(gatheredMetric.Age),
+		Stale:    gatheredMetric.Stale,
+	}, nil
+}
+
+// ToMetric converts a protobuf message into a gathered metric, returning nil if the message is nil. The metric shares
+// any maps and pointers with the message provided.
+func ToMetric(message *Metric) (*metrics.Metric, error) {
+	if message == nil {
+		return nil, nil
+	}
+
+	spec := autoscalingv2.MetricSpec{}
+	err := spec.Unmarshal(message.GetSpec())
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metric spec: %w", err)
+	}
+
+	var custom any
+	if len(message.GetCustom()) > 0 {
+		custom = json.RawMessage(message.GetCustom())
+	}
+
+	return &metrics.Metric{
+		Spec:     spec,
+		Resource: toResource(message.GetResource()),
+		Pods:     toPods(message.GetPods()),
+		Object:   toObject(message.GetObject()),
+		External: toExternal(message.GetExternal()),
+		Custom:   custom,
+		Age:      message.GetAge().AsDuration(),
+		Stale:    message.GetStale(),
+	}, nil
+}
+
+func fromResource(resourceMetric *resource.Metric) *ResourceMetric {
+	if resourceMetric == nil {
+		return nil
+	}
+	return &ResourceMetric{
+		PodMetricsInfo:      fromPodMetrics(resourceMetric.PodMetricsInfo),
+		Requests:            resourceMetric.Requests,
+		Limits:              resourceMetric.Limits,
+		ReadyPodCount:       resourceMetric.ReadyPodCount,
+		IgnoredPods:         fromSet(resourceMetric.IgnoredPods),
+		MissingPods:         fromSet(resourceMetric.MissingPods),
+		Errors:              resourceMetric.Errors,
+		TotalPods:           int64(resourceMetric.TotalPods),
+		DeletionCosts:       resourceMetric.DeletionCosts,
+		UtilizationBasis:    string(resourceMetric.UtilizationBasis),
+		CurrentUtilization:  resourceMetric.CurrentUtilization,
+		CurrentAverageValue: resourceMetric.CurrentAverageValue,
+		Timestamp:           fromTime(resourceMetric.Timestamp),
+		OldestTimestamp:     fromTime(resourceMetric.OldestTimestamp),
+		NewestTimestamp:     fromTime(resourceMetric.NewestTimestamp),
+		Provenance:          fromProvenance(resourceMetric.Provenance),
+	}
+}
+
+func toResource(message *ResourceMetric) *resource.Metric {
+	if message == nil {
+		return nil
+	}
+	return &resource.Metric{
+		PodMetricsInfo:      toPodMetrics(message.GetPodMetricsInfo()),
+		Requests:            nonNilMap(message.GetRequests()),
+		Limits:              nilIfEmpty(message.GetLimits()),
+		ReadyPodCount:       message.GetReadyPodCount(),
+		IgnoredPods:         sets.NewString(message.GetIgnoredPods()...),
+		MissingPods:         sets.NewString(message.GetMissingPods()...),
+		Errors:              nilIfEmpty(message.GetErrors()),
+		TotalPods:           int(message.GetTotalPods()),
+		DeletionCosts:       nilIfEmpty(message.GetDeletionCosts()),
+		UtilizationBasis:    resource.UtilizationBasis(message.GetUtilizationBasis()),
+		CurrentUtilization:  message.CurrentUtilization,
+		CurrentAverageValue: message.CurrentAverageValue,
+		Timestamp:           toTime(message.GetTimestamp()),
+		OldestTimestamp:     toTime(message.GetOldestTimestamp()),
+		NewestTimestamp:     toTime(message.GetNewestTimestamp()),
+		Provenance:          toProvenance(message.GetProvenance()),
+	}
+}
+
+func fromPods(podsMetric *pods.Metric) *PodsMetric {
+	if podsMetric == nil {
+		return nil
+	}
+	return &PodsMetric{
+		PodMetricsInfo:  fromPodMetrics(podsMetric.PodMetricsInfo),
+		ReadyPodCount:   podsMetric.ReadyPodCount,
+		IgnoredPods:     fromSet(podsMetric.IgnoredPods),
+		MissingPods:     fromSet(podsMetric.MissingPods),
+		Errors:          podsMetric.Errors,
+		TotalPods:       int64(podsMetric.TotalPods),
+		DeletionCosts:   podsMetric.DeletionCosts,
+		Timestamp:       fromTime(podsMetric.Timestamp),
+		OldestTimestamp: fromTime(podsMetric.OldestTimestamp),
+		NewestTimestamp: fromTime(podsMetric.NewestTimestamp),
+		Provenance:      fromProvenance(podsMetric.Provenance),
+	}
+}
+
+func toPods(message *PodsMetric) *pods.Metric {
+	if message == nil {
+		return nil
+	}
+	return &pods.Metric{
+		PodMetricsInfo:  toPodMetrics(message.GetPodMetricsInfo()),
+		ReadyPodCount:   message.GetReadyPodCount(),
+		IgnoredPods:     sets.NewString(message.GetIgnoredPods()...),
+		MissingPods:     sets.NewString(message.GetMissingPods()...),
+		Errors:          nilIfEmpty(message.GetErrors()),
+		TotalPods:       int(message.GetTotalPods()),
+		DeletionCosts:   nilIfEmpty(message.GetDeletionCosts()),
+		Timestamp:       toTime(message.GetTimestamp()),
+		OldestTimestamp: toTime(message.GetOldestTimestamp()),
+		NewestTimestamp: toTime(message.GetNewestTimestamp()),
+		Provenance:      toProvenance(message.GetProvenance()),
+	}
+}
+
+func fromObject(objectMetric *object.Metric) *ObjectMetric {
+	if objectMetric == nil {
+		return nil
+	}
+	return &ObjectMetric{
+		Current:       fromValue(objectMetric.Current),
+		ReadyPodCount: objectMetric.ReadyPodCount,
+		Timestamp:     fromTime(objectMetric.Timestamp),
+		Provenance:    fromProvenance(objectMetric.Provenance),
+	}
+}
+
+func toObject(message *ObjectMetric) *object.Metric {
+	if message == nil {
+		return nil
+	}
+	return &object.Metric{
+		Current:       toValue(message.GetCurrent()),
+		ReadyPodCount: message.ReadyPodCount,
+		Timestamp:     toTime(message.GetTimestamp()),
+		Provenance:    toProvenance(message.GetProvenance()),
+	}
+}
+
+func fromExternal(externalMetric *external.Metric) *ExternalMetric {
+	if externalMetric == nil {
+		return nil
+	}
+	return &ExternalMetric{
+		Current:       fromValue(externalMetric.Current),
+		ReadyPodCount: externalMetric.ReadyPodCount,
+		Timestamp:     fromTime(externalMetric.Timestamp),
+		Provenance:    fromProvenance(externalMetric.Provenance),
+	}
+}
+
+func toExternal(message *ExternalMetric) *external.Metric {
+	if message == nil {
+		return nil
+	}
+	return &external.Metric{
+		Current:       toValue(message.GetCurrent()),
+		ReadyPodCount: message.ReadyPodCount,
+		Timestamp:     toTime(message.GetTimestamp()),
+		Provenance:    toProvenance(message.GetProvenance()),
+	}
+}
+
+func fromPodMetrics(podMetrics podmetrics.MetricsInfo) map[string]*PodMetric {
+	if podMetrics == nil {
+		return nil
+	}
+	messages := make(map[string]*PodMetric, len(podMetrics))
+	for podName, podMetric := range podMetrics {
+		messages[podName] = &PodMetric{
+			Timestamp: fromTime(podMetric.Timestamp),
+			Window:    fromDuration(podMetric.Window),
+			Value:     podMetric.Value,
+		}
+	}
+	return messages
+}
+
+func toPodMetrics(messages map[string]*PodMetric) podmetrics.MetricsInfo {
+	podMetrics := make(podmetrics.MetricsInfo, len(messages))
+	for podName, message := range messages {
+		podMetrics[podName] = podmetrics.Metric{
+			Timestamp: toTime(message.GetTimestamp()),
+			Window:    message.GetWindow().AsDuration(),
+			Value:     message.GetValue(),
+		}
+	}
+	return podMetrics
+}
+
+func fromProvenance(metricProvenance *provenance.Provenance) *Provenance {
+	if metricProvenance == nil {
+		return nil
+	}
+	return &Provenance{
+		Source:    string(metricProvenance.Source),
+		Duration:  fromDuration(metricProvenance.Duration),
+		ItemCount: int64(metricProvenance.ItemCount),
+		Cached:    metricProvenance.Cached,
+	}
+}
+
+func toProvenance(message *Provenance) *provenance.Provenance {
+	if message == nil {
+		return nil
+	}
+	return &provenance.Provenance{
+		Source:    provenance.Source(message.GetSource()),
+		Duration:  message.GetDuration().AsDuration(),
+		ItemCount: int(message.GetItemCount()),
+		Cached:    message.GetCached(),
+	}
+}
+
+func fromValue(metricValue value.MetricValue) *MetricValue {
+	return &MetricValue{
+		Value:        metricValue.Value,
+		AverageValue: metricValue.AverageValue,
+	}
+}
+
+func toValue(message *MetricValue) value.MetricValue {
+	return value.MetricValue{
+		Value:        message.Value,
+		AverageValue: message.AverageValue,
+	}
+}
+
+// fromSet converts a set into a sorted list so the encoding is deterministic
+func fromSet(set sets.String) []string {
+	if set.Len() == 0 {
+		return nil
+	}
+	return set.List()
+}
+
+// fromTime converts a time into a timestamp, leaving the zero time unset
+func fromTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// toTime converts a timestamp into a time, converting an unset timestamp to the zero time
+func toTime(timestamp *timestamppb.Timestamp) time.Time {
+	if timestamp == nil {
+		return time.Time{}
+	}
+	return timestamp.AsTime()
+}
+
+// fromDuration converts a duration into its protobuf message, leaving a zero duration unset
+func fromDuration(duration time.Duration) *durationpb.Duration {
+	if duration == 0 {
+		return nil
+	}
+	return durationpb.New(duration)
+}
+
+func nonNilMap[V any](original map[string]V) map[string]V {
+	if original == nil {
+		return map[string]V{}
+	}
+	return original
+}
+
+func nilIfEmpty[V any](original map[string]V) map[string]V {
+	if len(original) == 0 {
+		return nil
+	}
+	return original
+}
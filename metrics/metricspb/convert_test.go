@@ -0,0 +1,260 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricspb_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/metricspb"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestMarshalUnmarshal(t *testing.T) {
+	timestamp := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	targetValue := k8sresource.MustParse("500m")
+
+	var tests = []struct {
+		description string
+		expected    *metrics.Metric
+		metric      *metrics.Metric
+	}{
+		{
+			description: "Resource metric",
+			expected: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: testutil.Int32Ptr(50),
+						},
+					},
+				},
+				Resource: &resource.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Timestamp: timestamp, Window: time.Minute, Value: 100},
+						"pod-2": podmetrics.Metric{Timestamp: timestamp.Add(time.Second), Window: time.Minute, Value: 200},
+					},
+					Requests:            map[string]int64{"pod-1": 200, "pod-2": 200, "pod-3": 200},
+					Limits:              map[string]int64{"pod-1": 400, "pod-2": 400, "pod-3": 400},
+					ReadyPodCount:       2,
+					IgnoredPods:         sets.NewString(),
+					MissingPods:         sets.NewString("pod-3"),
+					Errors:              map[string]string{"pod-3": "missing metrics"},
+					TotalPods:           3,
+					DeletionCosts:       map[string]int32{"pod-1": -10},
+					UtilizationBasis:    resource.UtilizationBasisLimits,
+					CurrentUtilization:  testutil.Int32Ptr(75),
+					CurrentAverageValue: testutil.Int64Ptr(150),
+					Timestamp:           timestamp,
+					OldestTimestamp:     timestamp,
+					NewestTimestamp:     timestamp.Add(time.Second),
+					Provenance: &provenance.Provenance{
+						Source:    provenance.SourceResource,
+						Duration:  time.Millisecond,
+						ItemCount: 2,
+					},
+				},
+				Age:   time.Minute,
+				Stale: true,
+			},
+			metric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: testutil.Int32Ptr(50),
+						},
+					},
+				},
+				Resource: &resource.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Timestamp: timestamp, Window: time.Minute, Value: 100},
+						"pod-2": podmetrics.Metric{Timestamp: timestamp.Add(time.Second), Window: time.Minute, Value: 200},
+					},
+					Requests:            map[string]int64{"pod-1": 200, "pod-2": 200, "pod-3": 200},
+					Limits:              map[string]int64{"pod-1": 400, "pod-2": 400, "pod-3": 400},
+					ReadyPodCount:       2,
+					IgnoredPods:         sets.NewString(),
+					MissingPods:         sets.NewString("pod-3"),
+					Errors:              map[string]string{"pod-3": "missing metrics"},
+					TotalPods:           3,
+					DeletionCosts:       map[string]int32{"pod-1": -10},
+					UtilizationBasis:    resource.UtilizationBasisLimits,
+					CurrentUtilization:  testutil.Int32Ptr(75),
+					CurrentAverageValue: testutil.Int64Ptr(150),
+					Timestamp:           timestamp,
+					OldestTimestamp:     timestamp,
+					NewestTimestamp:     timestamp.Add(time.Second),
+					Provenance: &provenance.Provenance{
+						Source:    provenance.SourceResource,
+						Duration:  time.Millisecond,
+						ItemCount: 2,
+					},
+				},
+				Age:   time.Minute,
+				Stale: true,
+			},
+		},
+		{
+			description: "Pods metric, nil maps and sets",
+			expected: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{
+							Name:     "requests",
+							Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+						},
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: &targetValue,
+						},
+					},
+				},
+				Pods: &pods.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{},
+					IgnoredPods:    sets.NewString(),
+					MissingPods:    sets.NewString(),
+				},
+			},
+			metric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{
+							Name:     "requests",
+							Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+						},
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: &targetValue,
+						},
+					},
+				},
+				Pods: &pods.Metric{},
+			},
+		},
+		{
+			description: "Object metric",
+			expected: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ObjectMetricSourceType},
+				Object: &object.Metric{
+					Current:       value.MetricValue{Value: testutil.Int64Ptr(0)},
+					ReadyPodCount: testutil.Int64Ptr(3),
+					Timestamp:     timestamp,
+				},
+			},
+			metric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ObjectMetricSourceType},
+				Object: &object.Metric{
+					Current:       value.MetricValue{Value: testutil.Int64Ptr(0)},
+					ReadyPodCount: testutil.Int64Ptr(3),
+					Timestamp:     timestamp,
+				},
+			},
+		},
+		{
+			description: "External metric",
+			expected: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ExternalMetricSourceType},
+				External: &external.Metric{
+					Current: value.MetricValue{AverageValue: testutil.Int64Ptr(5)},
+					Provenance: &provenance.Provenance{
+						Source: provenance.SourceExternal,
+						Cached: true,
+					},
+				},
+			},
+			metric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ExternalMetricSourceType},
+				External: &external.Metric{
+					Current: value.MetricValue{AverageValue: testutil.Int64Ptr(5)},
+					Provenance: &provenance.Provenance{
+						Source: provenance.SourceExternal,
+						Cached: true,
+					},
+				},
+			},
+		},
+		{
+			description: "Custom metric, decoded as raw JSON",
+			expected: &metrics.Metric{
+				Spec:   autoscalingv2.MetricSpec{Type: "Custom"},
+				Custom: json.RawMessage(`{"value":5}`),
+			},
+			metric: &metrics.Metric{
+				Spec:   autoscalingv2.MetricSpec{Type: "Custom"},
+				Custom: map[string]int{"value": 5},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			data, err := metricspb.Marshal(test.metric)
+			if err != nil {
+				t.Fatalf("unexpected error marshalling: %s", err)
+			}
+			result, err := metricspb.Unmarshal(data)
+			if err != nil {
+				t.Fatalf("unexpected error unmarshalling: %s", err)
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("metric mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	// The protobuf library deliberately varies its error messages, so only the wrapping is checked
+	_, err := metricspb.Unmarshal([]byte{0xff})
+	if err == nil || !strings.HasPrefix(err.Error(), "failed to unmarshal metric: ") {
+		t.Errorf("expected unmarshal error, got %v", err)
+	}
+}
+
+func TestNilMetric(t *testing.T) {
+	message, err := metricspb.FromMetric(nil)
+	if err != nil || message != nil {
+		t.Errorf("expected nil message and no error, got %v, %v", message, err)
+	}
+	metric, err := metricspb.ToMetric(nil)
+	if err != nil || metric != nil {
+		t.Errorf("expected nil metric and no error, got %v, %v", metric, err)
+	}
+}
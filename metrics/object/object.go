@@ -20,12 +20,14 @@ package object
 import (
 	"time"
 
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
 )
 
 // Metric (Object) is a metric describing a kubernetes object (for example, hits-per-second on an Ingress object).
 type Metric struct {
-	Current       value.MetricValue `json:"current,omitempty"`
-	ReadyPodCount *int64            `json:"readyPodCount,omitempty"`
-	Timestamp     time.Time         `json:"timestamp,omitempty"`
+	Current       value.MetricValue      `json:"current,omitempty"`
+	ReadyPodCount *int64                 `json:"readyPodCount,omitempty"`
+	Timestamp     time.Time              `json:"timestamp,omitempty"`
+	Provenance    *provenance.Provenance `json:"provenance,omitempty"`
 }
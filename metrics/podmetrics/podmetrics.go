@@ -28,3 +28,20 @@ type Metric struct {
 
 // MetricsInfo contains pod metrics as a map from pod names to MetricsInfo
 type MetricsInfo map[string]Metric
+
+// TimestampRange returns the oldest and newest timestamps of the pod metrics, ignoring any without a timestamp. If no
+// pod metric has a timestamp both are the zero time.
+func (m MetricsInfo) TimestampRange() (oldest time.Time, newest time.Time) {
+	for _, metric := range m {
+		if metric.Timestamp.IsZero() {
+			continue
+		}
+		if oldest.IsZero() || metric.Timestamp.Before(oldest) {
+			oldest = metric.Timestamp
+		}
+		if newest.IsZero() || metric.Timestamp.After(newest) {
+			newest = metric.Timestamp
+		}
+	}
+	return oldest, newest
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podmetrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+)
+
+func TestMetricsInfo_TimestampRange(t *testing.T) {
+	timestamp := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		description    string
+		expectedOldest time.Time
+		expectedNewest time.Time
+		metrics        podmetrics.MetricsInfo
+	}{
+		{
+			description:    "No metrics",
+			expectedOldest: time.Time{},
+			expectedNewest: time.Time{},
+			metrics:        podmetrics.MetricsInfo{},
+		},
+		{
+			description:    "Single metric",
+			expectedOldest: timestamp,
+			expectedNewest: timestamp,
+			metrics: podmetrics.MetricsInfo{
+				"pod-1": podmetrics.Metric{Timestamp: timestamp},
+			},
+		},
+		{
+			description:    "Multiple metrics, metrics without a timestamp ignored",
+			expectedOldest: timestamp.Add(-time.Minute),
+			expectedNewest: timestamp.Add(time.Minute),
+			metrics: podmetrics.MetricsInfo{
+				"pod-1": podmetrics.Metric{Timestamp: timestamp},
+				"pod-2": podmetrics.Metric{Timestamp: timestamp.Add(-time.Minute)},
+				"pod-3": podmetrics.Metric{Timestamp: timestamp.Add(time.Minute)},
+				"pod-4": podmetrics.Metric{},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			oldest, newest := test.metrics.TimestampRange()
+			if !oldest.Equal(test.expectedOldest) {
+				t.Errorf("oldest timestamp mismatch, want %s, got %s", test.expectedOldest, oldest)
+			}
+			if !newest.Equal(test.expectedNewest) {
+				t.Errorf("newest timestamp mismatch, want %s, got %s", test.expectedNewest, newest)
+			}
+		})
+	}
+}
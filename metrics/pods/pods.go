@@ -21,16 +21,27 @@ import (
 	"time"
 
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // Metric (Pods) is a metric describing each pod in the current scale target (for example,
 // transactions-processed-per-second).  The values will be averaged together before being compared to the target value.
+// Errors is the reason each missing pod was grouped as it was, keyed by pod name.
+// DeletionCosts is the deletion cost of each pod which has the controller.kubernetes.io/pod-deletion-cost annotation
+// set, keyed by pod name, which can be used to choose which pods to remove when scaling down.
+// Timestamp is the timestamp reported with the metrics, OldestTimestamp and NewestTimestamp are the oldest and newest
+// timestamps of the pod metrics, which can be used to detect skew between the samples of different pods.
 type Metric struct {
-	PodMetricsInfo podmetrics.MetricsInfo `json:"podMetricsInfo"`
-	ReadyPodCount  int64                  `json:"readyPodCount"`
-	IgnoredPods    sets.String            `json:"ignoredPods"`
-	MissingPods    sets.String            `json:"missingPods"`
-	TotalPods      int                    `json:"totalPods"`
-	Timestamp      time.Time              `json:"timestamp,omitempty"`
+	PodMetricsInfo  podmetrics.MetricsInfo `json:"podMetricsInfo"`
+	ReadyPodCount   int64                  `json:"readyPodCount"`
+	IgnoredPods     sets.String            `json:"ignoredPods"`
+	MissingPods     sets.String            `json:"missingPods"`
+	Errors          map[string]string      `json:"errors,omitempty"`
+	TotalPods       int                    `json:"totalPods"`
+	DeletionCosts   map[string]int32       `json:"deletionCosts,omitempty"`
+	Timestamp       time.Time              `json:"timestamp,omitempty"`
+	OldestTimestamp time.Time              `json:"oldestTimestamp,omitempty"`
+	NewestTimestamp time.Time              `json:"newestTimestamp,omitempty"`
+	Provenance      *provenance.Provenance `json:"provenance,omitempty"`
 }
@@ -0,0 +1,42 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package provenance contains models describing where and how a gathered metric was retrieved, allowing consumers to
+// assess the quality of the data used for a scaling decision.
+package provenance
+
+import "time"
+
+// Source is the K8s metrics API that a metric was retrieved from.
+type Source string
+
+const (
+	// SourceResource is the resource metrics API (metrics.k8s.io).
+	SourceResource Source = "resource"
+	// SourceCustom is the custom metrics API (custom.metrics.k8s.io).
+	SourceCustom Source = "custom"
+	// SourceExternal is the external metrics API (external.metrics.k8s.io).
+	SourceExternal Source = "external"
+)
+
+// Provenance describes how a metric was retrieved: the API it came from, how long the API call took, how many items
+// the API returned and whether the value was served from a cache or fallback rather than a live API call.
+type Provenance struct {
+	Source    Source        `json:"source"`
+	Duration  time.Duration `json:"duration"`
+	ItemCount int           `json:"itemCount"`
+	Cached    bool          `json:"cached"`
+}
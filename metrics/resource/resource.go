@@ -21,18 +21,61 @@ import (
 	"time"
 
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // Metric (Resource) is a resource metric known to Kubernetes, as specified in requests and limits, describing each pod
 // in the current scale target (e.g. CPU or memory).  Such metrics are built in to Kubernetes, and have special scaling
 // options on top of those available to normal per-pod metrics (the "pods" source).
+// Limits is the resource limits of each pod, set if every pod has a limit for the resource or if utilization is
+// calculated against limits. UtilizationBasis is what utilization is calculated against, requests unless set.
+// Errors is the reason each missing or ignored pod was grouped as it was, keyed by pod name. A pod with a container
+// missing a request (or a limit with a limits utilization basis) is not recorded here, instead gathering fails with
+// k8shorizmetrics.ErrMissingRequests or ErrMissingLimits in the same way as the HPA.
+// DeletionCosts is the deletion cost of each pod which has the controller.kubernetes.io/pod-deletion-cost annotation
+// set, keyed by pod name, which can be used to choose which pods to remove when scaling down.
+// CurrentUtilization is the current utilization as a percentage of the utilization basis and CurrentAverageValue is the
+// raw average value of the pods used to calculate it, both set when gathered if utilization could be calculated. These
+// are calculated from the gathered metrics, before any adjustment for missing or unready pods made while evaluating.
+// Timestamp is the timestamp reported with the metrics, OldestTimestamp and NewestTimestamp are the oldest and newest
+// timestamps of the pod metrics, which can be used to detect skew between the samples of different pods.
 type Metric struct {
-	PodMetricsInfo podmetrics.MetricsInfo `json:"podMetricsInfo"`
-	Requests       map[string]int64       `json:"requests"`
-	ReadyPodCount  int64                  `json:"readyPodCount"`
-	IgnoredPods    sets.String            `json:"ignoredPods"`
-	MissingPods    sets.String            `json:"missingPods"`
-	TotalPods      int                    `json:"totalPods"`
-	Timestamp      time.Time              `json:"timestamp,omitempty"`
+	PodMetricsInfo      podmetrics.MetricsInfo `json:"podMetricsInfo"`
+	Requests            map[string]int64       `json:"requests"`
+	Limits              map[string]int64       `json:"limits,omitempty"`
+	ReadyPodCount       int64                  `json:"readyPodCount"`
+	IgnoredPods         sets.String            `json:"ignoredPods"`
+	MissingPods         sets.String            `json:"missingPods"`
+	Errors              map[string]string      `json:"errors,omitempty"`
+	TotalPods           int                    `json:"totalPods"`
+	DeletionCosts       map[string]int32       `json:"deletionCosts,omitempty"`
+	UtilizationBasis    UtilizationBasis       `json:"utilizationBasis,omitempty"`
+	CurrentUtilization  *int32                 `json:"currentUtilization,omitempty"`
+	CurrentAverageValue *int64                 `json:"currentAverageValue,omitempty"`
+	Timestamp           time.Time              `json:"timestamp,omitempty"`
+	OldestTimestamp     time.Time              `json:"oldestTimestamp,omitempty"`
+	NewestTimestamp     time.Time              `json:"newestTimestamp,omitempty"`
+	Provenance          *provenance.Provenance `json:"provenance,omitempty"`
+}
+
+// UtilizationBasis is the pod resource that resource utilization is calculated as a percentage of
+type UtilizationBasis string
+
+const (
+	// UtilizationBasisRequests calculates utilization as a percentage of pod resource requests, in the same way as the
+	// HPA. This is the default.
+	UtilizationBasisRequests UtilizationBasis = "requests"
+	// UtilizationBasisLimits calculates utilization as a percentage of pod resource limits, for workloads sized on
+	// their limits
+	UtilizationBasisLimits UtilizationBasis = "limits"
+)
+
+// UtilizationBase returns the per pod resource values that utilization is calculated as a percentage of, the Limits
+// if the UtilizationBasis is limits, otherwise the Requests
+func (m *Metric) UtilizationBase() map[string]int64 {
+	if m.UtilizationBasis == UtilizationBasisLimits {
+		return m.Limits
+	}
+	return m.Requests
 }
@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package value
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Units declares the units a metric target quantity is expressed in, which determines how it is converted before
+// being compared against gathered metric values
+type Units string
+
+const (
+	// UnitsMilli converts target quantities to milli-units, matching the milli-unit values produced by the gatherers.
+	// This is the default.
+	UnitsMilli Units = "milli"
+	// UnitsRaw uses the plain value of target quantities, for use when gathered metric values are plain counts, for
+	// example when metrics are built from an adapter that returns plain counts rather than using the gatherers
+	UnitsRaw Units = "raw"
+)
+
+// Conversion records how a target quantity was converted to the value used in an evaluation
+type Conversion struct {
+	Units  Units             `json:"units"`
+	Target resource.Quantity `json:"target"`
+	Value  int64             `json:"value"`
+}
+
+// Convert converts the target quantity provided to the value used in an evaluation, if the units are not set they are
+// treated as UnitsMilli
+func (u Units) Convert(target resource.Quantity) *Conversion {
+	if u == UnitsRaw {
+		return &Conversion{
+			Units:  UnitsRaw,
+			Target: target,
+			Value:  target.Value(),
+		}
+	}
+	return &Conversion{
+		Units:  UnitsMilli,
+		Target: target,
+		Value:  target.MilliValue(),
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/clock"
+)
+
+type cachedResponse struct {
+	value      any
+	timestamp  time.Time
+	responseAt time.Time
+}
+
+// CachedClient wraps a Client, caching the responses of resource, raw and external metric requests keyed by the
+// arguments of the request. Cached responses are served until they are older than the TTL, after which the request is
+// made again. Failed requests are never cached, and object metric requests are passed through uncached.
+// This suits consumers which make the same requests from multiple code paths, for example several metric specs
+// gathering the same metric within a single reconcile. Each response served from the cache is a copy, so modifying
+// a response does not modify the cache.
+type CachedClient struct {
+	Client Client
+	TTL    time.Duration
+	Clock  clock.PassiveClock
+
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+}
+
+// NewCachedClient sets up a CachedClient wrapping the client provided, caching responses for the TTL provided
+func NewCachedClient(client Client, ttl time.Duration) *CachedClient {
+	return &CachedClient{
+		Client: client,
+		TTL:    ttl,
+		Clock:  clock.RealClock{},
+	}
+}
+
+// GetResourceMetric gets the given resource metric for all pods matching the specified selector in the given
+// namespace, serving it from the cache if possible
+func (c *CachedClient) GetResourceMetric(resource v1.ResourceName, namespace string, selector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+	return c.GetResourceMetricWithContext(context.Background(), resource, namespace, selector)
+}
+
+// GetResourceMetricWithContext gets the given resource metric for all pods matching the specified selector in the
+// given namespace, serving it from the cache if possible and passing the context to the wrapped client otherwise
+func (c *CachedClient) GetResourceMetricWithContext(ctx context.Context, resource v1.ResourceName, namespace string, selector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+	key := fmt.Sprintf("resource/%s/%s/%s", resource, namespace, selectorKey(selector))
+	value, timestamp, err := c.get(key, func() (any, time.Time, error) {
+		return WithContext(c.Client).GetResourceMetricWithContext(ctx, resource, namespace, selector)
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return copyMetricsInfo(value.(podmetrics.MetricsInfo)), timestamp, nil
+}
+
+// GetRawMetric gets the given metric for all pods matching the specified selector in the given namespace, serving it
+// from the cache if possible
+func (c *CachedClient) GetRawMetric(metricName string, namespace string, selector labels.Selector, metricSelector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+	return c.GetRawMetricWithContext(context.Background(), metricName, namespace, selector, metricSelector)
+}
+
+// GetRawMetricWithContext gets the given metric for all pods matching the specified selector in the given namespace,
+// serving it from the cache if possible and passing the context to the wrapped client otherwise
+func (c *CachedClient) GetRawMetricWithContext(ctx context.Context, metricName string, namespace string, selector labels.Selector, metricSelector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+	key := fmt.Sprintf("raw/%s/%s/%s/%s", metricName, namespace, selectorKey(selector), selectorKey(metricSelector))
+	value, timestamp, err := c.get(key, func() (any, time.Time, error) {
+		return WithContext(c.Client).GetRawMetricWithContext(ctx, metricName, namespace, selector, metricSelector)
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return copyMetricsInfo(value.(podmetrics.MetricsInfo)), timestamp, nil
+}
+
+// GetObjectMetric gets the given metric for the given object in the given namespace, this is never cached
+func (c *CachedClient) GetObjectMetric(metricName string, namespace string, objectRef *autoscalingv2.CrossVersionObjectReference, metricSelector labels.Selector) (int64, time.Time, error) {
+	return c.GetObjectMetricWithContext(context.Background(), metricName, namespace, objectRef, metricSelector)
+}
+
+// GetObjectMetricWithContext gets the given metric for the given object in the given namespace, passing the context
+// to the wrapped client, this is never cached
+func (c *CachedClient) GetObjectMetricWithContext(ctx context.Context, metricName string, namespace string, objectRef *autoscalingv2.CrossVersionObjectReference, metricSelector labels.Selector) (int64, time.Time, error) {
+	return WithContext(c.Client).GetObjectMetricWithContext(ctx, metricName, namespace, objectRef, metricSelector)
+}
+
+// GetExternalMetric gets all the values of a given external metric that match the specified selector, serving them
+// from the cache if possible
+func (c *CachedClient) GetExternalMetric(metricName, namespace string, selector labels.Selector) ([]int64, time.Time, error) {
+	return c.GetExternalMetricWithContext(context.Background(), metricName, namespace, selector)
+}
+
+// GetExternalMetricWithContext gets all the values of a given external metric that match the specified selector,
+// serving them from the cache if possible and passing the context to the wrapped client otherwise
+func (c *CachedClient) GetExternalMetricWithContext(ctx context.Context, metricName, namespace string, selector labels.Selector) ([]int64, time.Time, error) {
+	key := fmt.Sprintf("external/%s/%s/%s", metricName, namespace, selectorKey(selector))
+	value, timestamp, err := c.get(key, func() (any, time.Time, error) {
+		return WithContext(c.Client).GetExternalMetricWithContext(ctx, metricName, namespace, selector)
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	values := value.([]int64)
+	return append(make([]int64, 0, len(values)), values...), timestamp, nil
+}
+
+// Purge removes all cached responses
+func (c *CachedClient) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+}
+
+// get returns the cached response for the key provided, making the request and caching its response if there is no
+// cached response or it has expired
+func (c *CachedClient) get(key string, request func() (any, time.Time, error)) (any, time.Time, error) {
+	c.mu.Lock()
+	now := c.clock().Now()
+	cached, exists := c.entries[key]
+	c.mu.Unlock()
+
+	if exists && now.Sub(cached.responseAt) < c.TTL {
+		return cached.value, cached.timestamp, nil
+	}
+
+	value, timestamp, err := request()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now = c.clock().Now()
+	if c.entries == nil {
+		c.entries = map[string]cachedResponse{}
+	}
+	// Drop expired responses so the cache does not grow with requests that are no longer made
+	for existingKey, existing := range c.entries {
+		if now.Sub(existing.responseAt) >= c.TTL {
+			delete(c.entries, existingKey)
+		}
+	}
+	c.entries[key] = cachedResponse{
+		value:      value,
+		timestamp:  timestamp,
+		responseAt: now,
+	}
+
+	return value, timestamp, nil
+}
+
+func (c *CachedClient) clock() clock.PassiveClock {
+	if c.Clock == nil {
+		return clock.RealClock{}
+	}
+	return c.Clock
+}
+
+func selectorKey(selector labels.Selector) string {
+	if selector == nil {
+		return ""
+	}
+	return selector.String()
+}
+
+func copyMetricsInfo(info podmetrics.MetricsInfo) podmetrics.MetricsInfo {
+	copied := make(podmetrics.MetricsInfo, len(info))
+	for pod, metric := range info {
+		copied[pod] = metric
+	}
+	return copied
+}
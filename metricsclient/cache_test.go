@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsclient_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestCachedClient(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	calls := map[string]int{}
+	var requestErr error
+	client := metricsclient.NewCachedClient(&fake.MetricsClient{
+		GetResourceMetricReactor: func(resource v1.ResourceName, namespace string,
+			selector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+			calls["resource"]++
+			if requestErr != nil {
+				return nil, time.Time{}, requestErr
+			}
+			return podmetrics.MetricsInfo{
+				"pod-1": {Value: int64(calls["resource"])},
+			}, now, nil
+		},
+		GetRawMetricReactor: func(metricName string, namespace string, selector labels.Selector,
+			metricSelector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+			calls["raw"]++
+			return podmetrics.MetricsInfo{
+				"pod-1": {Value: int64(calls["raw"])},
+			}, now, nil
+		},
+		GetObjectMetricReactor: func(metricName string, namespace string,
+			objectRef *autoscalingv2.CrossVersionObjectReference, metricSelector labels.Selector) (int64, time.Time, error) {
+			calls["object"]++
+			return int64(calls["object"]), now, nil
+		},
+		GetExternalMetricReactor: func(metricName string, namespace string,
+			selector labels.Selector) ([]int64, time.Time, error) {
+			calls["external"]++
+			return []int64{int64(calls["external"])}, now, nil
+		},
+	}, 10*time.Second)
+	fakeClock := clocktesting.NewFakePassiveClock(now)
+	client.Clock = fakeClock
+
+	selector := labels.SelectorFromSet(labels.Set{"app": "test"})
+
+	resource := func() int64 {
+		t.Helper()
+		info, timestamp, err := client.GetResourceMetric(v1.ResourceCPU, "test", selector)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !timestamp.Equal(now) {
+			t.Errorf("expected timestamp %s, got %s", now, timestamp)
+		}
+		value := info["pod-1"].Value
+		// Modifying a response must not modify the cache
+		delete(info, "pod-1")
+		return value
+	}
+
+	if value := resource(); value != 1 {
+		t.Errorf("expected first response to be requested, got %d", value)
+	}
+
+	fakeClock.SetTime(now.Add(5 * time.Second))
+	if value := resource(); value != 1 {
+		t.Errorf("expected cached response within the TTL, got %d", value)
+	}
+
+	// Different arguments are cached separately
+	if _, _, err := client.GetResourceMetric(v1.ResourceMemory, "test", selector); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls["resource"] != 2 {
+		t.Errorf("expected 2 resource requests, got %d", calls["resource"])
+	}
+
+	fakeClock.SetTime(now.Add(15 * time.Second))
+	if value := resource(); value != 3 {
+		t.Errorf("expected response to be requested again after the TTL, got %d", value)
+	}
+
+	// Failed requests are not cached
+	client.Purge()
+	requestErr = errors.New("fail to get metric")
+	if _, _, err := client.GetResourceMetric(v1.ResourceCPU, "test", selector); err == nil {
+		t.Errorf("expected error")
+	}
+	requestErr = nil
+	if value := resource(); value != 5 {
+		t.Errorf("expected failed response not to be cached, got %d", value)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.GetRawMetric("test", "test", selector, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		values, _, err := client.GetExternalMetric("test", "test", selector)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		values[0] = 100
+		if _, _, err := client.GetObjectMetric("test", "test", &autoscalingv2.CrossVersionObjectReference{}, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	values, _, err := client.GetExternalMetric("test", "test", selector)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal([]int64{1}, values) {
+		t.Errorf("external values mismatch (-want +got):\n%s", cmp.Diff([]int64{1}, values))
+	}
+
+	expectedCalls := map[string]int{
+		"resource": 5,
+		"raw":      1,
+		"external": 1,
+		"object":   2,
+	}
+	if !cmp.Equal(expectedCalls, calls) {
+		t.Errorf("calls mismatch (-want +got):\n%s", cmp.Diff(expectedCalls, calls))
+	}
+}
@@ -19,6 +19,7 @@ package metricsclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -33,6 +34,8 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	custommetricsv1 "k8s.io/metrics/pkg/apis/custom_metrics/v1beta2"
+	externalmetricsv1beta1 "k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+	metricsapi "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1"
 	"k8s.io/metrics/pkg/client/custom_metrics"
 	"k8s.io/metrics/pkg/client/external_metrics"
@@ -42,6 +45,9 @@ const (
 	metricServerDefaultMetricWindow = time.Minute
 )
 
+// ErrNoMetrics occurs when a metrics API returns no metrics, or none of the metrics returned match known pods
+var ErrNoMetrics = errors.New("no metrics returned")
+
 // Client allows for retrieval of Kubernetes metrics
 type Client interface {
 	GetResourceMetric(resource v1.ResourceName, namespace string, selector labels.Selector) (podmetrics.MetricsInfo, time.Time, error)
@@ -50,6 +56,56 @@ type Client interface {
 	GetExternalMetric(metricName, namespace string, selector labels.Selector) ([]int64, time.Time, error)
 }
 
+// ClientWithContext allows for retrieval of Kubernetes metrics, accepting a context which can be used to cancel
+// requests
+type ClientWithContext interface {
+	GetResourceMetricWithContext(ctx context.Context, resource v1.ResourceName, namespace string, selector labels.Selector) (podmetrics.MetricsInfo, time.Time, error)
+	GetRawMetricWithContext(ctx context.Context, metricName string, namespace string, selector labels.Selector, metricSelector labels.Selector) (podmetrics.MetricsInfo, time.Time, error)
+	GetObjectMetricWithContext(ctx context.Context, metricName string, namespace string, objectRef *autoscalingv2.CrossVersionObjectReference, metricSelector labels.Selector) (int64, time.Time, error)
+	GetExternalMetricWithContext(ctx context.Context, metricName, namespace string, selector labels.Selector) ([]int64, time.Time, error)
+}
+
+// WithContext returns the client provided as a ClientWithContext. If the client does not implement ClientWithContext
+// it is wrapped so that the context is checked before each request is made.
+func WithContext(client Client) ClientWithContext {
+	if contextClient, ok := client.(ClientWithContext); ok {
+		return contextClient
+	}
+	return &contextClient{client: client}
+}
+
+type contextClient struct {
+	client Client
+}
+
+func (c *contextClient) GetResourceMetricWithContext(ctx context.Context, resource v1.ResourceName, namespace string, selector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, time.Time{}, err
+	}
+	return c.client.GetResourceMetric(resource, namespace, selector)
+}
+
+func (c *contextClient) GetRawMetricWithContext(ctx context.Context, metricName string, namespace string, selector labels.Selector, metricSelector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, time.Time{}, err
+	}
+	return c.client.GetRawMetric(metricName, namespace, selector, metricSelector)
+}
+
+func (c *contextClient) GetObjectMetricWithContext(ctx context.Context, metricName string, namespace string, objectRef *autoscalingv2.CrossVersionObjectReference, metricSelector labels.Selector) (int64, time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, time.Time{}, err
+	}
+	return c.client.GetObjectMetric(metricName, namespace, objectRef, metricSelector)
+}
+
+func (c *contextClient) GetExternalMetricWithContext(ctx context.Context, metricName, namespace string, selector labels.Selector) ([]int64, time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, time.Time{}, err
+	}
+	return c.client.GetExternalMetric(metricName, namespace, selector)
+}
+
 func NewClient(clusterConfig *rest.Config, discovery discovery.DiscoveryInterface) *RESTClient {
 	return &RESTClient{
 		Client:                metricsv1beta1.NewForConfigOrDie(clusterConfig),
@@ -62,23 +118,37 @@ func NewClient(clusterConfig *rest.Config, discovery discovery.DiscoveryInterfac
 	}
 }
 
-// RESTClient retrieves Kubernetes metrics through the Kubernetes REST API
+// RESTClient retrieves Kubernetes metrics through the Kubernetes REST API. If Timeout is greater than 0 each request
+// is abandoned with a TimeoutError if it takes longer than the Timeout, so a hung metrics API cannot stall gathering
+// indefinitely. The timeout can be overridden for individual calls using WithRequestTimeout.
+// If a Retry policy is provided failed requests are retried under the policy, with the Timeout applied to each
+// attempt, so transient failures of a metrics API do not immediately fail gathering.
 type RESTClient struct {
 	Client                metricsv1beta1.MetricsV1beta1Interface
 	ExternalMetricsClient external_metrics.ExternalMetricsClient
 	CustomMetricsClient   custom_metrics.CustomMetricsClient
+	Timeout               time.Duration
+	Retry                 *RetryPolicy
 }
 
 // GetResourceMetric gets the given resource metric (and an associated oldest timestamp)
 // for all pods matching the specified selector in the given namespace
 func (c *RESTClient) GetResourceMetric(resource v1.ResourceName, namespace string, selector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
-	metrics, err := c.Client.PodMetricses(namespace).List(context.Background(), metav1.ListOptions{LabelSelector: selector.String()})
+	return c.GetResourceMetricWithContext(context.Background(), resource, namespace, selector)
+}
+
+// GetResourceMetricWithContext gets the given resource metric (and an associated oldest timestamp)
+// for all pods matching the specified selector in the given namespace, cancelling the request if the context is done
+func (c *RESTClient) GetResourceMetricWithContext(ctx context.Context, resource v1.ResourceName, namespace string, selector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+	metrics, err := runRequest(ctx, c, func(ctx context.Context) (*metricsapi.PodMetricsList, error) {
+		return c.Client.PodMetricses(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	})
 	if err != nil {
-		return nil, time.Time{}, fmt.Errorf("unable to fetch metrics from resource metrics API: %v", err)
+		return nil, time.Time{}, fmt.Errorf("unable to fetch metrics from resource metrics API: %w", err)
 	}
 
 	if len(metrics.Items) == 0 {
-		return nil, time.Time{}, fmt.Errorf("no metrics returned from resource metrics API")
+		return nil, time.Time{}, fmt.Errorf("%w from resource metrics API", ErrNoMetrics)
 	}
 
 	res := make(podmetrics.MetricsInfo, len(metrics.Items))
@@ -110,13 +180,23 @@ func (c *RESTClient) GetResourceMetric(resource v1.ResourceName, namespace strin
 // GetRawMetric gets the given metric (and an associated oldest timestamp)
 // for all pods matching the specified selector in the given namespace
 func (c *RESTClient) GetRawMetric(metricName string, namespace string, selector labels.Selector, metricSelector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
-	metrics, err := c.CustomMetricsClient.NamespacedMetrics(namespace).GetForObjects(schema.GroupKind{Kind: "Pod"}, selector, metricName, metricSelector)
+	return c.GetRawMetricWithContext(context.Background(), metricName, namespace, selector, metricSelector)
+}
+
+// GetRawMetricWithContext gets the given metric (and an associated oldest timestamp)
+// for all pods matching the specified selector in the given namespace, returning early if the context is done
+func (c *RESTClient) GetRawMetricWithContext(ctx context.Context, metricName string, namespace string, selector labels.Selector, metricSelector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+	metrics, err := runRequest(ctx, c, func(ctx context.Context) (*custommetricsv1.MetricValueList, error) {
+		return runWithContext(ctx, func() (*custommetricsv1.MetricValueList, error) {
+			return c.CustomMetricsClient.NamespacedMetrics(namespace).GetForObjects(schema.GroupKind{Kind: "Pod"}, selector, metricName, metricSelector)
+		})
+	})
 	if err != nil {
-		return nil, time.Time{}, fmt.Errorf("unable to fetch metrics from custom metrics API: %v", err)
+		return nil, time.Time{}, fmt.Errorf("unable to fetch metrics from custom metrics API: %w", err)
 	}
 
 	if len(metrics.Items) == 0 {
-		return nil, time.Time{}, fmt.Errorf("no metrics returned from custom metrics API")
+		return nil, time.Time{}, fmt.Errorf("%w from custom metrics API", ErrNoMetrics)
 	}
 
 	res := make(podmetrics.MetricsInfo, len(metrics.Items))
@@ -142,20 +222,27 @@ func (c *RESTClient) GetRawMetric(metricName string, namespace string, selector
 // GetObjectMetric gets the given metric (and an associated timestamp) for the given
 // object in the given namespace
 func (c *RESTClient) GetObjectMetric(metricName string, namespace string, objectRef *autoscalingv2.CrossVersionObjectReference, metricSelector labels.Selector) (int64, time.Time, error) {
+	return c.GetObjectMetricWithContext(context.Background(), metricName, namespace, objectRef, metricSelector)
+}
+
+// GetObjectMetricWithContext gets the given metric (and an associated timestamp) for the given
+// object in the given namespace, returning early if the context is done
+func (c *RESTClient) GetObjectMetricWithContext(ctx context.Context, metricName string, namespace string, objectRef *autoscalingv2.CrossVersionObjectReference, metricSelector labels.Selector) (int64, time.Time, error) {
 	gvk := schema.FromAPIVersionAndKind(objectRef.APIVersion, objectRef.Kind)
-	var metricValue *custommetricsv1.MetricValue
-	var err error
-	if gvk.Kind == "Namespace" && gvk.Group == "" {
-		// handle namespace separately
-		// NB: we ignore namespace name here, since CrossVersionObjectReference isn't
-		// supposed to allow you to escape your namespace
-		metricValue, err = c.CustomMetricsClient.RootScopedMetrics().GetForObject(gvk.GroupKind(), namespace, metricName, metricSelector)
-	} else {
-		metricValue, err = c.CustomMetricsClient.NamespacedMetrics(namespace).GetForObject(gvk.GroupKind(), objectRef.Name, metricName, metricSelector)
-	}
+	metricValue, err := runRequest(ctx, c, func(ctx context.Context) (*custommetricsv1.MetricValue, error) {
+		return runWithContext(ctx, func() (*custommetricsv1.MetricValue, error) {
+			if gvk.Kind == "Namespace" && gvk.Group == "" {
+				// handle namespace separately
+				// NB: we ignore namespace name here, since CrossVersionObjectReference isn't
+				// supposed to allow you to escape your namespace
+				return c.CustomMetricsClient.RootScopedMetrics().GetForObject(gvk.GroupKind(), namespace, metricName, metricSelector)
+			}
+			return c.CustomMetricsClient.NamespacedMetrics(namespace).GetForObject(gvk.GroupKind(), objectRef.Name, metricName, metricSelector)
+		})
+	})
 
 	if err != nil {
-		return 0, time.Time{}, fmt.Errorf("unable to fetch metrics from custom metrics API: %v", err)
+		return 0, time.Time{}, fmt.Errorf("unable to fetch metrics from custom metrics API: %w", err)
 	}
 
 	return metricValue.Value.MilliValue(), metricValue.Timestamp.Time, nil
@@ -164,13 +251,23 @@ func (c *RESTClient) GetObjectMetric(metricName string, namespace string, object
 // GetExternalMetric gets all the values of a given external metric
 // that match the specified selector.
 func (c *RESTClient) GetExternalMetric(metricName, namespace string, selector labels.Selector) ([]int64, time.Time, error) {
-	metrics, err := c.ExternalMetricsClient.NamespacedMetrics(namespace).List(metricName, selector)
+	return c.GetExternalMetricWithContext(context.Background(), metricName, namespace, selector)
+}
+
+// GetExternalMetricWithContext gets all the values of a given external metric
+// that match the specified selector, returning early if the context is done
+func (c *RESTClient) GetExternalMetricWithContext(ctx context.Context, metricName, namespace string, selector labels.Selector) ([]int64, time.Time, error) {
+	metrics, err := runRequest(ctx, c, func(ctx context.Context) (*externalmetricsv1beta1.ExternalMetricValueList, error) {
+		return runWithContext(ctx, func() (*externalmetricsv1beta1.ExternalMetricValueList, error) {
+			return c.ExternalMetricsClient.NamespacedMetrics(namespace).List(metricName, selector)
+		})
+	})
 	if err != nil {
-		return []int64{}, time.Time{}, fmt.Errorf("unable to fetch metrics from external metrics API: %v", err)
+		return []int64{}, time.Time{}, fmt.Errorf("unable to fetch metrics from external metrics API: %w", err)
 	}
 
 	if len(metrics.Items) == 0 {
-		return nil, time.Time{}, fmt.Errorf("no metrics returned from external metrics API")
+		return nil, time.Time{}, fmt.Errorf("%w from external metrics API", ErrNoMetrics)
 	}
 
 	res := make([]int64, 0)
@@ -181,10 +278,57 @@ func (c *RESTClient) GetExternalMetric(metricName, namespace string, selector la
 	return res, timestamp, nil
 }
 
+// runRequest runs the request provided under the retry policy of the client, applying the request timeout of the client
+// to each attempt
+func runRequest[T any](ctx context.Context, c *RESTClient, request func(ctx context.Context) (T, error)) (T, error) {
+	return runWithRetry(ctx, c.Retry, func() (T, error) {
+		return runWithTimeout(ctx, c.Timeout, request)
+	})
+}
+
+// runWithContext runs the request provided, returning early if the context is done. The custom and external metrics
+// clients do not accept a context, so an abandoned request continues in the background until it completes.
+func runWithContext[T any](ctx context.Context, request func() (T, error)) (T, error) {
+	if err := ctx.Err(); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		value, err := request()
+		done <- result{value: value, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	case result := <-done:
+		return result.value, result.err
+	}
+}
+
 // GetResourceUtilizationRatio takes in a set of metrics, a set of matching requests,
 // and a target utilization percentage, and calculates the ratio of
 // desired to actual utilization (returning that, the actual utilization, and the raw average value)
 func GetResourceUtilizationRatio(metrics podmetrics.MetricsInfo, requests map[string]int64, targetUtilization int32) (utilizationRatio float64, currentUtilization int32, rawAverageValue int64, err error) {
+	currentUtilization, rawAverageValue, err = GetResourceUtilization(metrics, requests)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	return float64(currentUtilization) / float64(targetUtilization), currentUtilization, rawAverageValue, nil
+}
+
+// GetResourceUtilization takes in a set of metrics and a set of matching requests, and calculates the actual
+// utilization as a percentage of the requests and the raw average value of the pods with requests
+func GetResourceUtilization(metrics podmetrics.MetricsInfo, requests map[string]int64) (currentUtilization int32, rawAverageValue int64, err error) {
 	metricsTotal := int64(0)
 	requestsTotal := int64(0)
 	numEntries := 0
@@ -204,12 +348,12 @@ func GetResourceUtilizationRatio(metrics podmetrics.MetricsInfo, requests map[st
 	// if the set of requests is completely disjoint from the set of metrics,
 	// then we could have an issue where the requests total is zero
 	if requestsTotal == 0 {
-		return 0, 0, 0, fmt.Errorf("no metrics returned matched known pods")
+		return 0, 0, fmt.Errorf("%w matched known pods", ErrNoMetrics)
 	}
 
 	currentUtilization = int32((metricsTotal * 100) / requestsTotal)
 
-	return float64(currentUtilization) / float64(targetUtilization), currentUtilization, metricsTotal / int64(numEntries), nil
+	return currentUtilization, metricsTotal / int64(numEntries), nil
 }
 
 // GetMetricUtilizationRatio takes in a set of metrics and a target utilization value,
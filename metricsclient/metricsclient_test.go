@@ -17,11 +17,13 @@ limitations under the License.
 package metricsclient_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
 	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
@@ -913,3 +915,177 @@ func Test_GetExternalMetric(t *testing.T) {
 		})
 	}
 }
+
+func TestGetExternalMetricWithContext(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	release := make(chan struct{})
+	defer close(release)
+
+	var tests = []struct {
+		description string
+		expectedErr error
+		ctx         func() (context.Context, context.CancelFunc)
+	}{
+		{
+			description: "Fail, context cancelled before request",
+			expectedErr: errors.New("unable to fetch metrics from external metrics API: context canceled"),
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+		},
+		{
+			description: "Fail, context deadline exceeded during request",
+			expectedErr: errors.New("unable to fetch metrics from external metrics API: context deadline exceeded"),
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client := metricsclient.RESTClient{
+				ExternalMetricsClient: &external_metricsfake.FakeExternalMetricsClient{
+					Fake: k8stesting.Fake{
+						ReactionChain: []k8stesting.Reactor{
+							&k8stesting.SimpleReactor{
+								Resource: "*",
+								Verb:     "*",
+								Reaction: func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+									<-release
+									return true, nil, errors.New("request should have been abandoned")
+								},
+							},
+						},
+					},
+				},
+			}
+
+			ctx, cancel := test.ctx()
+			defer cancel()
+
+			_, _, err := client.GetExternalMetricWithContext(ctx, "test", "test", labels.Everything())
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+			}
+		})
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var tests = []struct {
+		description string
+		expected    int64
+		expectedErr error
+		ctx         context.Context
+	}{
+		{
+			description: "Context cancelled, client not called",
+			expectedErr: context.Canceled,
+			ctx:         cancelled,
+		},
+		{
+			description: "Context not cancelled, client called",
+			expected:    5,
+			ctx:         context.Background(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client := metricsclient.WithContext(&fake.MetricsClient{
+				GetObjectMetricReactor: func(metricName, namespace string, objectRef *autoscalingv2.CrossVersionObjectReference, metricSelector labels.Selector) (int64, time.Time, error) {
+					return 5, time.Time{}, nil
+				},
+			})
+
+			result, _, err := client.GetObjectMetricWithContext(test.ctx, "test", "test", &autoscalingv2.CrossVersionObjectReference{}, labels.Everything())
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("metric mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+
+	restClient := &metricsclient.RESTClient{}
+	if metricsclient.WithContext(restClient) != metricsclient.ClientWithContext(restClient) {
+		t.Errorf("expected RESTClient to be used directly as it implements ClientWithContext")
+	}
+}
+
+func TestGetResourceUtilization(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description          string
+		expectedUtilization  int32
+		expectedAverageValue int64
+		expectedErr          error
+		metrics              podmetrics.MetricsInfo
+		requests             map[string]int64
+	}{
+		{
+			description: "Fail, no requests match metrics",
+			expectedErr: errors.New("no metrics returned matched known pods"),
+			metrics: podmetrics.MetricsInfo{
+				"pod-1": podmetrics.Metric{Value: 50},
+			},
+			requests: map[string]int64{
+				"pod-2": 100,
+			},
+		},
+		{
+			description:          "Success, pods without requests excluded",
+			expectedUtilization:  75,
+			expectedAverageValue: 150,
+			metrics: podmetrics.MetricsInfo{
+				"pod-1": podmetrics.Metric{Value: 100},
+				"pod-2": podmetrics.Metric{Value: 200},
+				"pod-3": podmetrics.Metric{Value: 1000},
+			},
+			requests: map[string]int64{
+				"pod-1": 200,
+				"pod-2": 200,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			utilization, averageValue, err := metricsclient.GetResourceUtilization(test.metrics, test.requests)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expectedUtilization, utilization) {
+				t.Errorf("utilization mismatch (-want +got):\n%s", cmp.Diff(test.expectedUtilization, utilization))
+			}
+			if !cmp.Equal(test.expectedAverageValue, averageValue) {
+				t.Errorf("average value mismatch (-want +got):\n%s", cmp.Diff(test.expectedAverageValue, averageValue))
+			}
+		})
+	}
+}
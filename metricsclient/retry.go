@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// RetryPolicy configures retrying failed requests to the metrics APIs, all of which are idempotent reads. Requests are
+// retried waiting between attempts using the exponential Backoff provided, with Backoff.Steps as the maximum number of
+// attempts, in the same way as k8shorizmetrics.RetryPolicy. Requests are retried if the metrics API responds with one
+// of the RetriableStatusCodes, if the request times out (see RESTClient.Timeout) or if the connection to the metrics
+// API fails. Requests are never retried once the context of the call is done.
+// Retrying requests here and retrying gathers with k8shorizmetrics.Gatherer.Retry stack, with each gather attempt
+// making up to Backoff.Steps requests, so usually only one of the two should be enabled.
+type RetryPolicy struct {
+	Backoff              wait.Backoff
+	RetriableStatusCodes []int32
+}
+
+// DefaultRetryPolicy returns a RetryPolicy making up to 3 attempts, starting with a 100ms wait and doubling the wait
+// each attempt, with 10% jitter. Requests are retried on 429 Too Many Requests, 500 Internal Server Error, 502 Bad
+// Gateway, 503 Service Unavailable and 504 Gateway Timeout responses.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		Backoff: wait.Backoff{
+			Duration: 100 * time.Millisecond,
+			Factor:   2,
+			Jitter:   0.1,
+			Steps:    3,
+		},
+		RetriableStatusCodes: []int32{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// Retriable returns true if a request which failed with the error provided should be retried under the policy
+func (p *RetryPolicy) Retriable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return true
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr k8serrors.APIStatus
+	if errors.As(err, &statusErr) {
+		code := statusErr.Status().Code
+		for _, retriable := range p.RetriableStatusCodes {
+			if code == retriable {
+				return true
+			}
+		}
+		return false
+	}
+
+	return utilnet.IsConnectionReset(err) || utilnet.IsConnectionRefused(err) || utilnet.IsProbableEOF(err)
+}
+
+// runWithRetry runs the request provided, retrying it under the retry policy provided. If the policy is nil the
+// request is only attempted once.
+func runWithRetry[T any](ctx context.Context, policy *RetryPolicy, request func() (T, error)) (T, error) {
+	if policy == nil {
+		return request()
+	}
+
+	backoff := policy.Backoff
+	for {
+		value, err := request()
+		if err == nil || backoff.Steps <= 1 || ctx.Err() != nil || !policy.Retriable(err) {
+			return value, err
+		}
+
+		timer := time.NewTimer(backoff.Step())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return value, err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsclient_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8stesting "k8s.io/client-go/testing"
+	external_metricsv1beta1 "k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+	external_metricsfake "k8s.io/metrics/pkg/client/external_metrics/fake"
+)
+
+func TestRetry(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	policy := func(maxAttempts int, codes ...int32) *metricsclient.RetryPolicy {
+		return &metricsclient.RetryPolicy{
+			Backoff: wait.Backoff{
+				Duration: time.Millisecond,
+				Factor:   2,
+				Steps:    maxAttempts,
+			},
+			RetriableStatusCodes: codes,
+		}
+	}
+
+	unavailable := k8serrors.NewServiceUnavailable("adapter unavailable")
+	notFound := k8serrors.NewNotFound(schema.GroupResource{Resource: "test"}, "test")
+	internal := k8serrors.NewInternalError(errors.New("adapter failed"))
+
+	var tests = []struct {
+		description      string
+		expectedErr      error
+		expectedAttempts int32
+		policy           *metricsclient.RetryPolicy
+		timeout          time.Duration
+		// responses are the results of each attempt, a nil error responds with a metric and errSlow responds after
+		// the timeout has passed
+		responses []error
+	}{
+		{
+			description:      "No policy, not retried",
+			expectedErr:      fmt.Errorf("unable to fetch metrics from external metrics API: %w", unavailable),
+			expectedAttempts: 1,
+			responses:        []error{unavailable, nil},
+		},
+		{
+			description:      "Retriable status code, retry and succeed",
+			expectedAttempts: 2,
+			policy:           metricsclient.DefaultRetryPolicy(),
+			responses:        []error{unavailable, nil},
+		},
+		{
+			description:      "Status code not retriable",
+			expectedErr:      fmt.Errorf("unable to fetch metrics from external metrics API: %w", notFound),
+			expectedAttempts: 1,
+			policy:           metricsclient.DefaultRetryPolicy(),
+			responses:        []error{notFound, nil},
+		},
+		{
+			description:      "Custom retriable status codes",
+			expectedErr:      fmt.Errorf("unable to fetch metrics from external metrics API: %w", unavailable),
+			expectedAttempts: 2,
+			policy:           policy(3, 500),
+			responses:        []error{internal, unavailable, nil},
+		},
+		{
+			description:      "Max attempts reached",
+			expectedErr:      fmt.Errorf("unable to fetch metrics from external metrics API: %w", unavailable),
+			expectedAttempts: 3,
+			policy:           policy(3, 503),
+			responses:        []error{unavailable, unavailable, unavailable, nil},
+		},
+		{
+			description:      "Connection refused, retry and succeed",
+			expectedAttempts: 2,
+			policy:           policy(3),
+			responses:        []error{fmt.Errorf("dial tcp: %w", syscall.ECONNREFUSED), nil},
+		},
+		{
+			description:      "Attempt timed out, retry and succeed",
+			expectedAttempts: 2,
+			policy:           policy(3),
+			timeout:          100 * time.Millisecond,
+			responses:        []error{errSlow, nil},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var attempts atomic.Int32
+			client := metricsclient.RESTClient{
+				ExternalMetricsClient: &external_metricsfake.FakeExternalMetricsClient{
+					Fake: k8stesting.Fake{
+						ReactionChain: []k8stesting.Reactor{
+							&k8stesting.SimpleReactor{
+								Resource: "*",
+								Verb:     "*",
+								Reaction: func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+									response := test.responses[attempts.Add(1)-1]
+									if response == errSlow {
+										// The fake holds a lock while reacting, so the next attempt is only
+										// handled once this response is made
+										time.Sleep(110 * time.Millisecond)
+									}
+									if response != nil {
+										return true, nil, response
+									}
+									return true, &external_metricsv1beta1.ExternalMetricValueList{
+										Items: []external_metricsv1beta1.ExternalMetricValue{{}},
+									}, nil
+								},
+							},
+						},
+					},
+				},
+				Timeout: test.timeout,
+				Retry:   test.policy,
+			}
+
+			_, _, err := client.GetExternalMetricWithContext(context.Background(), "test", "test", labels.Everything())
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+			}
+
+			if attempts.Load() != test.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", test.expectedAttempts, attempts.Load())
+			}
+		})
+	}
+}
+
+var errSlow = errors.New("slow")
+
+func TestRetryContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var attempts atomic.Int32
+	client := metricsclient.RESTClient{
+		ExternalMetricsClient: &external_metricsfake.FakeExternalMetricsClient{
+			Fake: k8stesting.Fake{
+				ReactionChain: []k8stesting.Reactor{
+					&k8stesting.SimpleReactor{
+						Resource: "*",
+						Verb:     "*",
+						Reaction: func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+							attempts.Add(1)
+							cancel()
+							return true, nil, k8serrors.NewServiceUnavailable("adapter unavailable")
+						},
+					},
+				},
+			},
+		},
+		Retry: &metricsclient.RetryPolicy{
+			Backoff:              wait.Backoff{Duration: time.Hour, Steps: 3},
+			RetriableStatusCodes: []int32{503},
+		},
+	}
+
+	_, _, err := client.GetExternalMetricWithContext(ctx, "test", "test", labels.Everything())
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if attempts.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts.Load())
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeoutError occurs when a request to a metrics API takes longer than the request timeout
+type TimeoutError struct {
+	Timeout time.Duration
+	Err     error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("request timed out after %s: %s", e.Timeout, e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+type requestTimeoutKey struct{}
+
+// WithRequestTimeout returns a copy of the context provided which overrides the request timeout of a RESTClient for
+// any requests made with it, a timeout of zero or less disables the timeout for those requests
+func WithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, timeout)
+}
+
+// runWithTimeout runs the request provided with the request timeout applied to the context, using the timeout set on
+// the context by WithRequestTimeout if there is one and the client timeout provided otherwise. If the request fails
+// because the timeout expired a TimeoutError is returned.
+func runWithTimeout[T any](ctx context.Context, clientTimeout time.Duration,
+	request func(ctx context.Context) (T, error)) (T, error) {
+	timeout := clientTimeout
+	if override, ok := ctx.Value(requestTimeoutKey{}).(time.Duration); ok {
+		timeout = override
+	}
+
+	if timeout <= 0 {
+		return request(ctx)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	value, err := request(timeoutCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+		return value, &TimeoutError{
+			Timeout: timeout,
+			Err:     err,
+		}
+	}
+	return value, err
+}
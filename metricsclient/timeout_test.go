@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsclient_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	external_metricsv1beta1 "k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+	metricsv1beta1fake "k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1/fake"
+	external_metricsfake "k8s.io/metrics/pkg/client/external_metrics/fake"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	release := make(chan struct{})
+	defer close(release)
+
+	hung := func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		<-release
+		return true, nil, errors.New("request should have been abandoned")
+	}
+
+	responding := func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		return true, &external_metricsv1beta1.ExternalMetricValueList{
+			Items: []external_metricsv1beta1.ExternalMetricValue{{}},
+		}, nil
+	}
+
+	var tests = []struct {
+		description   string
+		expectedErr   error
+		expectTimeout bool
+		timeout       time.Duration
+		reaction      k8stesting.ReactionFunc
+		ctx           func() (context.Context, context.CancelFunc)
+	}{
+		{
+			description: "Fail, client timeout exceeded",
+			expectedErr: errors.New("unable to fetch metrics from external metrics API: request timed out after " +
+				"10ms: context deadline exceeded"),
+			expectTimeout: true,
+			timeout:       10 * time.Millisecond,
+			reaction:      hung,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+		},
+		{
+			description: "Fail, call timeout overrides client timeout",
+			expectedErr: errors.New("unable to fetch metrics from external metrics API: request timed out after " +
+				"10ms: context deadline exceeded"),
+			expectTimeout: true,
+			timeout:       time.Hour,
+			reaction:      hung,
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				return metricsclient.WithRequestTimeout(ctx, 10*time.Millisecond), cancel
+			},
+		},
+		{
+			description: "Fail, caller deadline exceeded is not a timeout error",
+			expectedErr: errors.New("unable to fetch metrics from external metrics API: context deadline exceeded"),
+			timeout:     time.Hour,
+			reaction:    hung,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+		},
+		{
+			description: "Success, call timeout disables client timeout",
+			timeout:     time.Nanosecond,
+			reaction: func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+				time.Sleep(10 * time.Millisecond)
+				return responding(action)
+			},
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				return metricsclient.WithRequestTimeout(ctx, 0), cancel
+			},
+		},
+		{
+			description: "Success, within client timeout",
+			timeout:     time.Hour,
+			reaction:    responding,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client := metricsclient.RESTClient{
+				ExternalMetricsClient: &external_metricsfake.FakeExternalMetricsClient{
+					Fake: k8stesting.Fake{
+						ReactionChain: []k8stesting.Reactor{
+							&k8stesting.SimpleReactor{
+								Resource: "*",
+								Verb:     "*",
+								Reaction: test.reaction,
+							},
+						},
+					},
+				},
+				Timeout: test.timeout,
+			}
+
+			ctx, cancel := test.ctx()
+			defer cancel()
+
+			_, _, err := client.GetExternalMetricWithContext(ctx, "test", "test", labels.Everything())
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+			}
+
+			var timeoutErr *metricsclient.TimeoutError
+			if errors.As(err, &timeoutErr) != test.expectTimeout {
+				t.Errorf("expected timeout error %t, got %v", test.expectTimeout, err)
+			}
+		})
+	}
+}
+
+func TestGetResourceMetricTimeout(t *testing.T) {
+	client := metricsclient.RESTClient{
+		Client: &metricsv1beta1fake.FakeMetricsV1beta1{
+			Fake: &k8stesting.Fake{
+				ReactionChain: []k8stesting.Reactor{
+					&k8stesting.SimpleReactor{
+						Resource: "*",
+						Verb:     "*",
+						Reaction: func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+							// The fake does not observe the context, so respond once the timeout has passed
+							time.Sleep(20 * time.Millisecond)
+							return true, nil, errors.New("request cancelled")
+						},
+					},
+				},
+			},
+		},
+		Timeout: 10 * time.Millisecond,
+	}
+
+	_, _, err := client.GetResourceMetricWithContext(context.Background(), v1.ResourceCPU, "test", labels.Everything())
+
+	expected := "unable to fetch metrics from resource metrics API: request timed out after 10ms: request cancelled"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+}
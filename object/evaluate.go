@@ -0,0 +1,111 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Modifications Copyright 2022 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+
+Modified to split up evaluations and metric gathering to work with the
+Custom Pod Autoscaler framework.
+Original source:
+https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscaler/horizontal.go
+https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscaler/replica_calculator.go
+*/
+
+package object
+
+import (
+	"context"
+	"math"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	"github.com/jthomperoo/k8shorizmetrics/v4/replicas"
+	autoscaling "k8s.io/api/autoscaling/v2"
+)
+
+// Evaluate (object) calculates a replica count evaluation, using the tolerance and calculater provided. TargetUnits
+// declares the units the spec's target quantities are expressed in, if not set they are converted to milli-units to
+// match the values produced by the object gatherer. Rounding is how fractional replica counts are rounded for average
+// value targets, if not set they are rounded up in the same way as the HPA.
+type Evaluate struct {
+	Calculater  replicas.Calculator
+	TargetUnits value.Units
+	Rounding    replicas.Rounding
+}
+
+// EvaluateWithContext calculates an evaluation based on the metric provided and the current number of replicas,
+// returning the context's error without evaluating if the context is done
+func (e *Evaluate) EvaluateWithContext(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return e.Evaluate(currentReplicas, gatheredMetric, tolerance)
+}
+
+// Evaluate calculates an evaluation based on the metric provided and the current number of replicas
+func (e *Evaluate) Evaluate(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+	conversion, err := e.TargetConversion(gatheredMetric)
+	if err != nil {
+		return 0, err
+	}
+
+	if gatheredMetric.Spec.Object.Target.Type == autoscaling.ValueMetricType {
+		utilization := float64(*gatheredMetric.Object.Current.Value)
+		usageRatio := float64(utilization) / float64(conversion.Value)
+		replicaCount := e.Calculater.GetUsageRatioReplicaCount(currentReplicas, usageRatio, *gatheredMetric.Object.ReadyPodCount)
+		return replicaCount, nil
+	}
+
+	utilization := float64(*gatheredMetric.Object.Current.AverageValue)
+	replicaCount := currentReplicas
+	usageRatio := utilization / (float64(conversion.Value) * float64(replicaCount))
+	if math.Abs(1.0-usageRatio) > tolerance {
+		// update number of replicas if change is large enough
+		replicaCount = e.Rounding.Round(utilization / float64(conversion.Value))
+	}
+	return replicaCount, nil
+}
+
+// TargetConversion returns how the target of the metric provided is converted for evaluation, allowing the units used
+// to be included when explaining an evaluation
+func (e *Evaluate) TargetConversion(gatheredMetric *metrics.Metric) (*value.Conversion, error) {
+	if gatheredMetric.Spec.Object.Target.Type == autoscaling.ValueMetricType {
+		return e.TargetUnits.Convert(*gatheredMetric.Spec.Object.Target.Value), nil
+	}
+	if gatheredMetric.Spec.Object.Target.Type == autoscaling.AverageValueMetricType {
+		return e.TargetUnits.Convert(*gatheredMetric.Spec.Object.Target.AverageValue), nil
+	}
+	return nil, &metrics.InvalidMetricSourceError{
+		SourceType: autoscaling.ObjectMetricSourceType,
+		Reason:     "neither a value target nor an average value target was set",
+	}
+}
+
+// UsageRatio returns the ratio of the current value of the metric provided to its target, for average value targets
+// the target is multiplied by the current replica count. A ratio above 1 means usage is above the target.
+func (e *Evaluate) UsageRatio(currentReplicas int32, gatheredMetric *metrics.Metric) (float64, error) {
+	conversion, err := e.TargetConversion(gatheredMetric)
+	if err != nil {
+		return 0, err
+	}
+
+	if gatheredMetric.Spec.Object.Target.Type == autoscaling.ValueMetricType {
+		return float64(*gatheredMetric.Object.Current.Value) / float64(conversion.Value), nil
+	}
+
+	return float64(*gatheredMetric.Object.Current.AverageValue) / (float64(conversion.Value) * float64(currentReplicas)), nil
+}
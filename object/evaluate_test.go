@@ -0,0 +1,326 @@
+/*
+Copyright 2022 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	objectmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	"github.com/jthomperoo/k8shorizmetrics/v4/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/replicas"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestEvaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description     string
+		expected        int32
+		expectedErr     error
+		calculater      replicas.Calculator
+		tolerance       float64
+		currentReplicas int32
+		gatheredMetric  *metrics.Metric
+	}{
+		{
+			"Invalid metric source",
+			0,
+			errors.New("invalid object metric source: neither a value target nor an average value target was set"),
+			nil,
+			0,
+			3,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Object: &v2.ObjectMetricSource{},
+				},
+			},
+		},
+		{
+			"Success, average value, beyond tolerance",
+			10,
+			nil,
+			nil,
+			0,
+			5,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Object: &v2.ObjectMetricSource{
+						Target: v2.MetricTarget{
+							Type:         v2.AverageValueMetricType,
+							AverageValue: resource.NewMilliQuantity(50, resource.DecimalSI),
+						},
+					},
+				},
+				Object: &objectmetrics.Metric{
+					Current: value.MetricValue{
+						AverageValue: testutil.Int64Ptr(500),
+					},
+				},
+			},
+		},
+		{
+			"Success, average value, within tolerance",
+			5,
+			nil,
+			nil,
+			0,
+			5,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Object: &v2.ObjectMetricSource{
+						Target: v2.MetricTarget{
+							Type:         v2.AverageValueMetricType,
+							AverageValue: resource.NewMilliQuantity(50, resource.DecimalSI),
+						},
+					},
+				},
+				Object: &objectmetrics.Metric{
+					Current: value.MetricValue{
+						AverageValue: testutil.Int64Ptr(250),
+					},
+				},
+			},
+		},
+		{
+			"Success, value",
+			3,
+			nil,
+			&fake.Calculate{
+				GetUsageRatioReplicaCountReactor: func(currentReplicas int32, usageRatio float64, readyPodCount int64) int32 {
+					return 3
+				},
+			},
+			0,
+			5,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Object: &v2.ObjectMetricSource{
+						Target: v2.MetricTarget{
+							Type:  v2.ValueMetricType,
+							Value: resource.NewMilliQuantity(50, resource.DecimalSI),
+						},
+					},
+				},
+				Object: &objectmetrics.Metric{
+					ReadyPodCount: testutil.Int64Ptr(2),
+					Current: value.MetricValue{
+						Value: testutil.Int64Ptr(250),
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluater := object.Evaluate{
+				Calculater: test.calculater,
+			}
+			evaluation, err := evaluater.Evaluate(test.currentReplicas, test.gatheredMetric, test.tolerance)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, evaluation) {
+				t.Errorf("evaluation mismatch (-want +got):\n%s", cmp.Diff(test.expected, evaluation))
+			}
+		})
+	}
+}
+
+func TestEvaluateTargetUnits(t *testing.T) {
+	var tests = []struct {
+		description     string
+		expected        int32
+		targetUnits     value.Units
+		currentReplicas int32
+		gatheredMetric  *metrics.Metric
+	}{
+		{
+			"Milli units, plain count values compared against milli target",
+			1,
+			value.UnitsMilli,
+			2,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Object: &v2.ObjectMetricSource{
+						Target: v2.MetricTarget{
+							Type:         v2.AverageValueMetricType,
+							AverageValue: resource.NewQuantity(10, resource.DecimalSI),
+						},
+					},
+				},
+				Object: &objectmetrics.Metric{
+					Current: value.MetricValue{
+						AverageValue: testutil.Int64Ptr(40),
+					},
+				},
+			},
+		},
+		{
+			"Raw units, plain count values compared against raw target",
+			4,
+			value.UnitsRaw,
+			2,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Object: &v2.ObjectMetricSource{
+						Target: v2.MetricTarget{
+							Type:         v2.AverageValueMetricType,
+							AverageValue: resource.NewQuantity(10, resource.DecimalSI),
+						},
+					},
+				},
+				Object: &objectmetrics.Metric{
+					Current: value.MetricValue{
+						AverageValue: testutil.Int64Ptr(40),
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			eval := object.Evaluate{
+				Calculater:  &replicas.ReplicaCalculator{},
+				TargetUnits: test.targetUnits,
+			}
+			result, err := eval.Evaluate(test.currentReplicas, test.gatheredMetric, 0.1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("evaluation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestTargetConversion(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	equateQuantity := cmp.Comparer(func(x, y resource.Quantity) bool {
+		return x.Cmp(y) == 0
+	})
+
+	var tests = []struct {
+		description    string
+		expected       *value.Conversion
+		expectedErr    error
+		targetUnits    value.Units
+		gatheredMetric *metrics.Metric
+	}{
+		{
+			"Invalid metric source",
+			nil,
+			errors.New("invalid object metric source: neither a value target nor an average value target was set"),
+			value.UnitsRaw,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Object: &v2.ObjectMetricSource{},
+				},
+			},
+		},
+		{
+			"Default units, value target",
+			&value.Conversion{
+				Units:  value.UnitsMilli,
+				Target: resource.MustParse("5"),
+				Value:  5000,
+			},
+			nil,
+			"",
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Object: &v2.ObjectMetricSource{
+						Target: v2.MetricTarget{
+							Type:  v2.ValueMetricType,
+							Value: resource.NewQuantity(5, resource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+		{
+			"Raw units, average value target",
+			&value.Conversion{
+				Units:  value.UnitsRaw,
+				Target: resource.MustParse("5"),
+				Value:  5,
+			},
+			nil,
+			value.UnitsRaw,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Object: &v2.ObjectMetricSource{
+						Target: v2.MetricTarget{
+							Type:         v2.AverageValueMetricType,
+							AverageValue: resource.NewQuantity(5, resource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			eval := object.Evaluate{
+				TargetUnits: test.targetUnits,
+			}
+			result, err := eval.TargetConversion(test.gatheredMetric)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result, equateQuantity) {
+				t.Errorf("conversion mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, equateQuantity))
+			}
+		})
+	}
+}
+
+func TestEvaluateWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	eval := object.Evaluate{
+		Calculater: &fake.Calculate{},
+	}
+
+	_, err := eval.EvaluateWithContext(ctx, 3, &metrics.Metric{}, 0.1)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled error, got %v", err)
+	}
+}
@@ -28,12 +28,15 @@ https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscale
 package object
 
 import (
+	"context"
 	"fmt"
+	"time"
 
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/podutil"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
 	metricsclient "github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
 	autoscaling "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/labels"
 )
@@ -46,14 +49,25 @@ type Gather struct {
 
 // Gather retrieves an object metric
 func (c *Gather) Gather(metricName string, namespace string, objectRef *autoscaling.CrossVersionObjectReference, podSelector labels.Selector, metricSelector labels.Selector) (*object.Metric, error) {
+	return c.GatherWithContext(context.Background(), metricName, namespace, objectRef, podSelector, metricSelector)
+}
+
+// GatherWithContext retrieves an object metric, passing the context provided to the metrics client
+func (c *Gather) GatherWithContext(ctx context.Context, metricName string, namespace string, objectRef *autoscaling.CrossVersionObjectReference, podSelector labels.Selector, metricSelector labels.Selector) (*object.Metric, error) {
 	// Get metrics
-	utilization, timestamp, err := c.MetricsClient.GetObjectMetric(metricName, namespace, objectRef, metricSelector)
+	start := time.Now()
+	utilization, timestamp, err := metricsclient.WithContext(c.MetricsClient).GetObjectMetricWithContext(ctx, metricName, namespace, objectRef, metricSelector)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get metric %s: %s on %s %s: %w", metricName, objectRef.Kind, namespace, objectRef.Name, err)
 	}
+	metricProvenance := &provenance.Provenance{
+		Source:    provenance.SourceCustom,
+		Duration:  time.Since(start),
+		ItemCount: 1,
+	}
 
 	// Calculate number of ready pods
-	readyPodCount, err := c.PodReadyCounter.GetReadyPodsCount(namespace, podSelector)
+	readyPodCount, err := podutil.GetReadyPodsCountWithContext(ctx, c.PodReadyCounter, namespace, podSelector)
 	if err != nil {
 		return nil, fmt.Errorf("unable to calculate ready pods: %w", err)
 	}
@@ -64,21 +78,34 @@ func (c *Gather) Gather(metricName string, namespace string, objectRef *autoscal
 		},
 		ReadyPodCount: &readyPodCount,
 		Timestamp:     timestamp,
+		Provenance:    metricProvenance,
 	}, nil
 }
 
 // GatherPerPod retrieves an object per pod metric
 func (c *Gather) GatherPerPod(metricName string, namespace string, objectRef *autoscaling.CrossVersionObjectReference, metricSelector labels.Selector) (*object.Metric, error) {
+	return c.GatherPerPodWithContext(context.Background(), metricName, namespace, objectRef, metricSelector)
+}
+
+// GatherPerPodWithContext retrieves an object per pod metric, passing the context provided to the metrics client
+func (c *Gather) GatherPerPodWithContext(ctx context.Context, metricName string, namespace string, objectRef *autoscaling.CrossVersionObjectReference, metricSelector labels.Selector) (*object.Metric, error) {
 	// Get metrics
-	utilization, timestamp, err := c.MetricsClient.GetObjectMetric(metricName, namespace, objectRef, metricSelector)
+	start := time.Now()
+	utilization, timestamp, err := metricsclient.WithContext(c.MetricsClient).GetObjectMetricWithContext(ctx, metricName, namespace, objectRef, metricSelector)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get metric %s: %s on %s %s/%w", metricName, objectRef.Kind, namespace, objectRef.Name, err)
 	}
+	metricProvenance := &provenance.Provenance{
+		Source:    provenance.SourceCustom,
+		Duration:  time.Since(start),
+		ItemCount: 1,
+	}
 
 	return &object.Metric{
 		Current: value.MetricValue{
 			AverageValue: &utilization,
 		},
-		Timestamp: timestamp,
+		Timestamp:  timestamp,
+		Provenance: metricProvenance,
 	}, nil
 }
@@ -22,13 +22,15 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/fake"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/object"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/podutil"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
 	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
 	objectmetric "github.com/jthomperoo/k8shorizmetrics/v4/metrics/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
 	metricsclient "github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/labels"
 )
@@ -40,6 +42,7 @@ func TestGather(t *testing.T) {
 		}
 		return x.Error() == y.Error()
 	})
+	ignoreDuration := cmpopts.IgnoreFields(provenance.Provenance{}, "Duration")
 
 	var tests = []struct {
 		description     string
@@ -96,6 +99,10 @@ func TestGather(t *testing.T) {
 					Value: testutil.Int64Ptr(5),
 				},
 				ReadyPodCount: testutil.Int64Ptr(2),
+				Provenance: &provenance.Provenance{
+					Source:    provenance.SourceCustom,
+					ItemCount: 1,
+				},
 			},
 			nil,
 			&fake.MetricsClient{
@@ -126,8 +133,8 @@ func TestGather(t *testing.T) {
 				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
 				return
 			}
-			if !cmp.Equal(test.expected, metric) {
-				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric))
+			if !cmp.Equal(test.expected, metric, ignoreDuration) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric, ignoreDuration))
 			}
 		})
 	}
@@ -140,6 +147,7 @@ func TestGatherPerPod(t *testing.T) {
 		}
 		return x.Error() == y.Error()
 	})
+	ignoreDuration := cmpopts.IgnoreFields(provenance.Provenance{}, "Duration")
 
 	var tests = []struct {
 		description     string
@@ -173,6 +181,10 @@ func TestGatherPerPod(t *testing.T) {
 				Current: value.MetricValue{
 					AverageValue: testutil.Int64Ptr(5),
 				},
+				Provenance: &provenance.Provenance{
+					Source:    provenance.SourceCustom,
+					ItemCount: 1,
+				},
 			},
 			nil,
 			&fake.MetricsClient{
@@ -198,8 +210,8 @@ func TestGatherPerPod(t *testing.T) {
 				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
 				return
 			}
-			if !cmp.Equal(test.expected, metric) {
-				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric))
+			if !cmp.Equal(test.expected, metric, ignoreDuration) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric, ignoreDuration))
 			}
 		})
 	}
@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package opa provides an integration point for enforcing Open Policy Agent policies on scaling decisions. Each
+// proposed decision is sent to a Decider, which returns a verdict allowing, denying or mutating the decision.
+//
+// The Client Decider queries an OPA instance using the REST Data API, with the decision provided as the input
+// document, and expects the policy to produce a result with the structure of a Verdict, for example:
+//
+//	package autoscaling
+//
+//	default decision := {"allow": true}
+//
+//	decision := {"allow": true, "replicas": 2, "reason": "minimum of 2 replicas in production"} if {
+//		input.namespace == "production"
+//		input.proposedReplicas < 2
+//	}
+//
+// Embedded Rego evaluation can be used by implementing the Decider interface.
+package opa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+)
+
+// Input is a proposed scaling decision sent to the policy
+type Input struct {
+	Namespace        string            `json:"namespace"`
+	Name             string            `json:"name"`
+	CurrentReplicas  int32             `json:"currentReplicas"`
+	ProposedReplicas int32             `json:"proposedReplicas"`
+	Metrics          []*metrics.Metric `json:"metrics,omitempty"`
+}
+
+// Verdict is the policy's decision, if Allow is false the decision is denied and the current replica count is kept,
+// if Replicas is set the proposed replica count is replaced
+type Verdict struct {
+	Allow    bool   `json:"allow"`
+	Replicas *int32 `json:"replicas,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Decider returns a verdict for a proposed scaling decision
+type Decider interface {
+	Decide(ctx context.Context, input *Input) (*Verdict, error)
+}
+
+// DeciderFunc allows a function to be used as a Decider
+type DeciderFunc func(ctx context.Context, input *Input) (*Verdict, error)
+
+// Decide calls the function
+func (f DeciderFunc) Decide(ctx context.Context, input *Input) (*Verdict, error) {
+	return f(ctx, input)
+}
+
+// Client is a Decider which queries an OPA instance using the REST Data API. URL is the base URL of the OPA instance,
+// for example http://localhost:8181, and Path is the path of the decision document, for example
+// autoscaling/decision. If HTTPClient is nil http.DefaultClient is used.
+type Client struct {
+	URL        string
+	Path       string
+	HTTPClient *http.Client
+}
+
+type dataRequest struct {
+	Input *Input `json:"input"`
+}
+
+type dataResponse struct {
+	Result *Verdict `json:"result"`
+}
+
+// Decide queries the OPA instance for a verdict on the input provided
+func (c *Client) Decide(ctx context.Context, input *Input) (*Verdict, error) {
+	body, err := json.Marshal(&dataRequest{Input: input})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal policy input: %w", err)
+	}
+
+	url := strings.TrimSuffix(c.URL, "/") + "/v1/data/" + strings.TrimPrefix(c.Path, "/")
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query policy: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query policy: unexpected status code %d", response.StatusCode)
+	}
+
+	var result dataResponse
+	err = json.NewDecoder(response.Body).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode policy response: %w", err)
+	}
+
+	if result.Result == nil {
+		return nil, fmt.Errorf("policy %s is undefined", c.Path)
+	}
+
+	return result.Result, nil
+}
+
+// Decision is the outcome of applying a verdict to a proposed scaling decision
+type Decision struct {
+	Replicas         int32  `json:"replicas"`
+	ProposedReplicas int32  `json:"proposedReplicas"`
+	Allowed          bool   `json:"allowed"`
+	Mutated          bool   `json:"mutated"`
+	Reason           string `json:"reason,omitempty"`
+}
+
+// Apply requests a verdict for the input provided and applies it
+func Apply(ctx context.Context, decider Decider, input *Input) (*Decision, error) {
+	verdict, err := decider.Decide(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &Decision{
+		Replicas:         input.ProposedReplicas,
+		ProposedReplicas: input.ProposedReplicas,
+		Allowed:          verdict.Allow,
+		Reason:           verdict.Reason,
+	}
+
+	if !verdict.Allow {
+		decision.Replicas = input.CurrentReplicas
+		return decision, nil
+	}
+
+	if verdict.Replicas != nil && *verdict.Replicas != input.ProposedReplicas {
+		decision.Replicas = *verdict.Replicas
+		decision.Mutated = true
+	}
+
+	return decision, nil
+}
+
+// Evaluator wraps an Evaluator, sending each evaluation to the Decider and applying its verdict. Namespace and Name
+// identify the scaled resource to the policy.
+type Evaluator struct {
+	Evaluator *k8shorizmetrics.Evaluator
+	Decider   Decider
+	Namespace string
+	Name      string
+}
+
+// Evaluate returns the target replica count for the metrics provided after the policy verdict is applied
+func (e *Evaluator) Evaluate(ctx context.Context, gatheredMetrics []*metrics.Metric, currentReplicas int32) (*Decision, error) {
+	proposedReplicas, err := e.Evaluator.EvaluateWithContext(ctx, gatheredMetrics, currentReplicas)
+	if err != nil {
+		return nil, err
+	}
+
+	return Apply(ctx, e.Decider, &Input{
+		Namespace:        e.Namespace,
+		Name:             e.Name,
+		CurrentReplicas:  currentReplicas,
+		ProposedReplicas: proposedReplicas,
+		Metrics:          gatheredMetrics,
+	})
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opa_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/opa"
+)
+
+func TestClientDecide(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description string
+		expected    *opa.Verdict
+		expectedErr error
+		handler     http.HandlerFunc
+	}{
+		{
+			description: "Unexpected status code",
+			expectedErr: errors.New("failed to query policy: unexpected status code 500"),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+		},
+		{
+			description: "Invalid response",
+			expectedErr: errors.New("failed to decode policy response: invalid character 'i' looking for beginning of value"),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("invalid"))
+			},
+		},
+		{
+			description: "Undefined policy",
+			expectedErr: errors.New("policy autoscaling/decision is undefined"),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("{}"))
+			},
+		},
+		{
+			description: "Verdict returned",
+			expected: &opa.Verdict{
+				Allow:    true,
+				Replicas: int32Ptr(2),
+				Reason:   "minimum of 2 replicas in production",
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/data/autoscaling/decision" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				var request struct {
+					Input opa.Input `json:"input"`
+				}
+				err := json.NewDecoder(r.Body).Decode(&request)
+				if err != nil || request.Input.Namespace != "production" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.Write([]byte(`{"result": {"allow": true, "replicas": 2, "reason": "minimum of 2 replicas in production"}}`))
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			server := httptest.NewServer(test.handler)
+			defer server.Close()
+
+			client := &opa.Client{
+				URL:  server.URL,
+				Path: "autoscaling/decision",
+			}
+			result, err := client.Decide(context.Background(), &opa.Input{
+				Namespace:        "production",
+				Name:             "web",
+				CurrentReplicas:  3,
+				ProposedReplicas: 1,
+			})
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("verdict mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description string
+		expected    *opa.Decision
+		expectedErr error
+		verdict     *opa.Verdict
+		verdictErr  error
+	}{
+		{
+			description: "Fail to decide",
+			expectedErr: errors.New("fail to decide"),
+			verdictErr:  errors.New("fail to decide"),
+		},
+		{
+			description: "Allowed",
+			expected: &opa.Decision{
+				Replicas:         5,
+				ProposedReplicas: 5,
+				Allowed:          true,
+			},
+			verdict: &opa.Verdict{Allow: true},
+		},
+		{
+			description: "Denied, keep current replicas",
+			expected: &opa.Decision{
+				Replicas:         3,
+				ProposedReplicas: 5,
+				Reason:           "change freeze",
+			},
+			verdict: &opa.Verdict{Allow: false, Reason: "change freeze"},
+		},
+		{
+			description: "Mutated",
+			expected: &opa.Decision{
+				Replicas:         4,
+				ProposedReplicas: 5,
+				Allowed:          true,
+				Mutated:          true,
+			},
+			verdict: &opa.Verdict{Allow: true, Replicas: int32Ptr(4)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			decider := opa.DeciderFunc(func(ctx context.Context, input *opa.Input) (*opa.Verdict, error) {
+				return test.verdict, test.verdictErr
+			})
+			result, err := opa.Apply(context.Background(), decider, &opa.Input{
+				CurrentReplicas:  3,
+				ProposedReplicas: 5,
+			})
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("decision mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
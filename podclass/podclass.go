@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package podclass classifies pods by the labels of the node they are scheduled on, for example spot or on-demand,
+// and adjusts the missing and unready pod assumptions made during evaluation for each class. Without this, pods lost
+// to spot or preemptible node churn look like missing pods, which are assumed to have no usage when scaling up and
+// can trigger unnecessary scale ups.
+package podclass
+
+import (
+	"fmt"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// Class is a group of pods identified by the labels of the node they are scheduled on.
+// If IgnoreMissing is set, pods in this class without metrics are excluded from evaluation rather than being assumed
+// to be using 0% of their target on scale up and 100% on scale down.
+// If IgnoreUnready is set, unready pods in this class are excluded from evaluation rather than being assumed to be
+// using 0% of their target on scale up.
+type Class struct {
+	Name          string
+	NodeSelector  labels.Selector
+	IgnoreMissing bool
+	IgnoreUnready bool
+}
+
+// Classifier assigns pods to classes, the first class with a node selector matching the pod's node is used. Pods
+// that do not match any class, are not yet scheduled, or that no longer exist keep the default HPA assumptions.
+type Classifier struct {
+	PodLister  corelisters.PodLister
+	NodeLister corelisters.NodeLister
+	Classes    []Class
+}
+
+// Classify returns the class of the pod provided, or nil if it does not belong to a class
+func (c *Classifier) Classify(namespace string, podName string) (*Class, error) {
+	pod, err := c.PodLister.Pods(namespace).Get(podName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get pod %s: %w", podName, err)
+	}
+
+	if pod.Spec.NodeName == "" {
+		return nil, nil
+	}
+
+	node, err := c.NodeLister.Get(pod.Spec.NodeName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get node %s: %w", pod.Spec.NodeName, err)
+	}
+
+	for i, class := range c.Classes {
+		if class.NodeSelector.Matches(labels.Set(node.Labels)) {
+			return &c.Classes[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Apply returns a copy of the gathered metrics provided with missing and unready pods excluded according to their
+// class. Only resource and pods metrics track missing and unready pods, other metrics are returned unchanged.
+func (c *Classifier) Apply(namespace string, gatheredMetrics []*metrics.Metric) ([]*metrics.Metric, error) {
+	classified := make([]*metrics.Metric, len(gatheredMetrics))
+	for i, gatheredMetric := range gatheredMetrics {
+		classifiedMetric := *gatheredMetric
+
+		switch gatheredMetric.Spec.Type {
+		case autoscalingv2.ResourceMetricSourceType:
+			if gatheredMetric.Resource != nil {
+				resourceMetric := *gatheredMetric.Resource
+				missingPods, ignoredPods, err := c.filter(namespace, resourceMetric.MissingPods, resourceMetric.IgnoredPods)
+				if err != nil {
+					return nil, err
+				}
+				resourceMetric.MissingPods = missingPods
+				resourceMetric.IgnoredPods = ignoredPods
+				classifiedMetric.Resource = &resourceMetric
+			}
+		case autoscalingv2.PodsMetricSourceType:
+			if gatheredMetric.Pods != nil {
+				podsMetric := *gatheredMetric.Pods
+				missingPods, ignoredPods, err := c.filter(namespace, podsMetric.MissingPods, podsMetric.IgnoredPods)
+				if err != nil {
+					return nil, err
+				}
+				podsMetric.MissingPods = missingPods
+				podsMetric.IgnoredPods = ignoredPods
+				classifiedMetric.Pods = &podsMetric
+			}
+		}
+
+		classified[i] = &classifiedMetric
+	}
+
+	return classified, nil
+}
+
+func (c *Classifier) filter(namespace string, missingPods sets.String, ignoredPods sets.String) (sets.String, sets.String, error) {
+	filteredMissing := sets.NewString()
+	for podName := range missingPods {
+		class, err := c.Classify(namespace, podName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if class != nil && class.IgnoreMissing {
+			continue
+		}
+		filteredMissing.Insert(podName)
+	}
+
+	filteredIgnored := sets.NewString()
+	for podName := range ignoredPods {
+		class, err := c.Classify(namespace, podName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if class != nil && class.IgnoreUnready {
+			continue
+		}
+		filteredIgnored.Insert(podName)
+	}
+
+	return filteredMissing, filteredIgnored, nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podclass_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	resourcemetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podclass"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+func TestApply(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	pods := map[string]*corev1.Pod{
+		"spot-pod":      {ObjectMeta: metav1.ObjectMeta{Name: "spot-pod"}, Spec: corev1.PodSpec{NodeName: "spot-node"}},
+		"spot-unready":  {ObjectMeta: metav1.ObjectMeta{Name: "spot-unready"}, Spec: corev1.PodSpec{NodeName: "spot-node"}},
+		"on-demand-pod": {ObjectMeta: metav1.ObjectMeta{Name: "on-demand-pod"}, Spec: corev1.PodSpec{NodeName: "on-demand-node"}},
+		"pending-pod":   {ObjectMeta: metav1.ObjectMeta{Name: "pending-pod"}},
+		"lost-node-pod": {ObjectMeta: metav1.ObjectMeta{Name: "lost-node-pod"}, Spec: corev1.PodSpec{NodeName: "lost-node"}},
+	}
+
+	nodes := map[string]*corev1.Node{
+		"spot-node": {ObjectMeta: metav1.ObjectMeta{Name: "spot-node", Labels: map[string]string{
+			"node.kubernetes.io/lifecycle": "spot",
+		}}},
+		"on-demand-node": {ObjectMeta: metav1.ObjectMeta{Name: "on-demand-node", Labels: map[string]string{
+			"node.kubernetes.io/lifecycle": "on-demand",
+		}}},
+	}
+
+	podLister := &fake.PodLister{
+		PodsReactor: func(namespace string) corelisters.PodNamespaceLister {
+			return &fake.PodNamespaceLister{
+				GetReactor: func(name string) (*corev1.Pod, error) {
+					pod, ok := pods[name]
+					if !ok {
+						return nil, k8serrors.NewNotFound(schema.GroupResource{Resource: "pods"}, name)
+					}
+					return pod, nil
+				},
+			}
+		},
+	}
+
+	nodeLister := &fake.NodeLister{
+		GetReactor: func(name string) (*corev1.Node, error) {
+			node, ok := nodes[name]
+			if !ok {
+				return nil, k8serrors.NewNotFound(schema.GroupResource{Resource: "nodes"}, name)
+			}
+			return node, nil
+		},
+	}
+
+	classes := []podclass.Class{
+		{
+			Name:          "spot",
+			NodeSelector:  labels.SelectorFromSet(labels.Set{"node.kubernetes.io/lifecycle": "spot"}),
+			IgnoreMissing: true,
+			IgnoreUnready: true,
+		},
+		{
+			Name:         "on-demand",
+			NodeSelector: labels.SelectorFromSet(labels.Set{"node.kubernetes.io/lifecycle": "on-demand"}),
+		},
+	}
+
+	var tests = []struct {
+		description     string
+		expected        []*metrics.Metric
+		expectedErr     error
+		podLister       corelisters.PodLister
+		gatheredMetrics []*metrics.Metric
+	}{
+		{
+			description: "Fail to get pod",
+			expectedErr: errors.New("failed to get pod spot-pod: fail to get pod"),
+			podLister: &fake.PodLister{
+				PodsReactor: func(namespace string) corelisters.PodNamespaceLister {
+					return &fake.PodNamespaceLister{
+						GetReactor: func(name string) (*corev1.Pod, error) {
+							return nil, errors.New("fail to get pod")
+						},
+					}
+				},
+			},
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ResourceMetricSourceType},
+					Resource: &resourcemetrics.Metric{
+						MissingPods: sets.NewString("spot-pod"),
+					},
+				},
+			},
+		},
+		{
+			description: "Spot pods excluded, other pods keep default assumptions",
+			expected: []*metrics.Metric{
+				{
+					Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ResourceMetricSourceType},
+					Resource: &resourcemetrics.Metric{
+						MissingPods: sets.NewString("on-demand-pod", "pending-pod", "deleted-pod", "lost-node-pod"),
+						IgnoredPods: sets.NewString(),
+					},
+				},
+				{
+					Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.PodsMetricSourceType},
+					Pods: &podsmetrics.Metric{
+						MissingPods: sets.NewString(),
+						IgnoredPods: sets.NewString("on-demand-pod"),
+					},
+				},
+				{
+					Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ExternalMetricSourceType},
+				},
+			},
+			podLister: podLister,
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ResourceMetricSourceType},
+					Resource: &resourcemetrics.Metric{
+						MissingPods: sets.NewString("spot-pod", "on-demand-pod", "pending-pod", "deleted-pod", "lost-node-pod"),
+						IgnoredPods: sets.NewString("spot-unready"),
+					},
+				},
+				{
+					Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.PodsMetricSourceType},
+					Pods: &podsmetrics.Metric{
+						MissingPods: sets.NewString("spot-pod"),
+						IgnoredPods: sets.NewString("spot-unready", "on-demand-pod"),
+					},
+				},
+				{
+					Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ExternalMetricSourceType},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			classifier := &podclass.Classifier{
+				PodLister:  test.podLister,
+				NodeLister: nodeLister,
+				Classes:    classes,
+			}
+			result, err := classifier.Apply("test-namespace", test.gatheredMetrics)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
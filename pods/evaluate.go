@@ -0,0 +1,119 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Modifications Copyright 2022 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+
+Modified to split up evaluations and metric gathering to work with the
+Custom Pod Autoscaler framework.
+Original source:
+https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscaler/horizontal.go
+https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscaler/replica_calculator.go
+*/
+
+package pods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/replicas"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+// Evaluate (pods) calculates a replica count evaluation, using the tolerance and calculater provided
+type Evaluate struct {
+	Calculater replicas.Calculator
+}
+
+// EvaluateWithContext calculates an evaluation based on the metric provided and the current number of replicas. Pods
+// evaluation makes no requests so the context is not used, the Evaluator checks the context before each evaluation.
+func (e *Evaluate) EvaluateWithContext(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+	return e.Evaluate(currentReplicas, gatheredMetric)
+}
+
+// EvaluateWithError calculates an evaluation based on the metric provided and the current number of replicas. If the
+// target is a value target, rather than the average value target supported by the HPA, the sum of the metric across
+// all pods is evaluated against the target value, for example to target a total number of requests per second
+// regardless of the replica count. If a value target is not greater than 0 an InvalidMetricSourceError is returned.
+func (e *Evaluate) EvaluateWithError(ctx context.Context, currentReplicas int32,
+	gatheredMetric *metrics.Metric) (int32, error) {
+	if gatheredMetric.Spec.Pods.Target.Type == autoscalingv2.ValueMetricType {
+		usageRatio, err := sumUsageRatio(gatheredMetric)
+		if err != nil {
+			return 0, err
+		}
+		return e.Calculater.GetUsageRatioReplicaCount(currentReplicas, usageRatio,
+			gatheredMetric.Pods.ReadyPodCount), nil
+	}
+
+	return e.Calculater.GetPlainMetricReplicaCount(
+		gatheredMetric.Pods.PodMetricsInfo,
+		currentReplicas,
+		gatheredMetric.Spec.Pods.Target.AverageValue.MilliValue(),
+		gatheredMetric.Pods.ReadyPodCount,
+		gatheredMetric.Pods.MissingPods,
+		gatheredMetric.Pods.IgnoredPods,
+	), nil
+}
+
+// Evaluate calculates an evaluation based on the metric provided and the current number of replicas, see
+// EvaluateWithError. If the metric spec has a value target that is not greater than 0 the current replica count is
+// returned, use EvaluateWithError to have this reported as an error.
+func (e *Evaluate) Evaluate(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+	replicas, err := e.EvaluateWithError(context.Background(), currentReplicas, gatheredMetric)
+	if err != nil {
+		return currentReplicas
+	}
+	return replicas
+}
+
+// UsageRatio returns the ratio of the current usage of the metric provided to its target, before any adjustment for
+// missing or ignored pods. A ratio above 1 means usage is above the target.
+func (e *Evaluate) UsageRatio(currentReplicas int32, gatheredMetric *metrics.Metric) (float64, error) {
+	if gatheredMetric.Spec.Pods.Target.Type == autoscalingv2.ValueMetricType {
+		return sumUsageRatio(gatheredMetric)
+	}
+
+	if len(gatheredMetric.Pods.PodMetricsInfo) == 0 {
+		return 0, fmt.Errorf("%w for pods metric", metricsclient.ErrNoMetrics)
+	}
+
+	usageRatio, _ := metricsclient.GetMetricUtilizationRatio(gatheredMetric.Pods.PodMetricsInfo,
+		gatheredMetric.Spec.Pods.Target.AverageValue.MilliValue())
+	return usageRatio, nil
+}
+
+// sumUsageRatio returns the ratio of the sum of the metric across all pods to the value target, failing if the value
+// target is not set or is not greater than 0
+func sumUsageRatio(gatheredMetric *metrics.Metric) (float64, error) {
+	target := gatheredMetric.Spec.Pods.Target.Value
+	if target == nil || target.MilliValue() <= 0 {
+		return 0, &metrics.InvalidMetricSourceError{
+			SourceType: autoscalingv2.PodsMetricSourceType,
+			Reason:     "a value target must be greater than 0",
+		}
+	}
+
+	var total int64
+	for _, podMetric := range gatheredMetric.Pods.PodMetricsInfo {
+		total += podMetric.Value
+	}
+	return float64(total) / float64(target.MilliValue()), nil
+}
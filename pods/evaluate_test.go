@@ -0,0 +1,275 @@
+/*
+Copyright 2022 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pods_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	metricspods "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/replicas"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestEvaluate(t *testing.T) {
+	var tests = []struct {
+		description     string
+		expected        int32
+		calculater      replicas.Calculator
+		currentReplicas int32
+		gatheredMetric  *metrics.Metric
+	}{
+		{
+			"Calculate 5 replicas, 2 ready pods, 1 ignored and 1 missing",
+			5,
+			&fake.Calculate{
+				GetPlainMetricReplicaCountReactor: func(metrics podmetrics.MetricsInfo, currentReplicas int32, targetUtilization, readyPodCount int64, missingPods, ignoredPods sets.String) int32 {
+					return 5
+				},
+			},
+			4,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Pods: &v2.PodsMetricSource{
+						Target: v2.MetricTarget{
+							AverageValue: resource.NewMilliQuantity(50, resource.DecimalSI),
+						},
+					},
+				},
+				Pods: &metricspods.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{},
+					ReadyPodCount:  2,
+					IgnoredPods:    sets.String{"ignored": {}},
+					MissingPods:    sets.String{"missing": {}},
+				},
+			},
+		},
+		{
+			"Sum target, calculate 6 replicas, usage ratio 1.5, 4 ready pods",
+			6,
+			&fake.Calculate{
+				GetUsageRatioReplicaCountReactor: func(currentReplicas int32, usageRatio float64, readyPodCount int64) int32 {
+					if usageRatio != 1.5 {
+						t.Errorf("unexpected usage ratio %f", usageRatio)
+					}
+					if readyPodCount != 4 {
+						t.Errorf("unexpected ready pod count %d", readyPodCount)
+					}
+					return 6
+				},
+			},
+			4,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Pods: &v2.PodsMetricSource{
+						Target: v2.MetricTarget{
+							Type:  v2.ValueMetricType,
+							Value: resource.NewMilliQuantity(200, resource.DecimalSI),
+						},
+					},
+				},
+				Pods: &metricspods.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 100},
+						"pod-2": podmetrics.Metric{Value: 50},
+						"pod-3": podmetrics.Metric{Value: 75},
+						"pod-4": podmetrics.Metric{Value: 75},
+					},
+					ReadyPodCount: 4,
+				},
+			},
+		},
+		{
+			"Sum target, real calculater, 300 total against 100 target with 2 ready pods",
+			6,
+			&replicas.ReplicaCalculator{
+				Tolerance: 0.1,
+			},
+			2,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Pods: &v2.PodsMetricSource{
+						Target: v2.MetricTarget{
+							Type:  v2.ValueMetricType,
+							Value: resource.NewMilliQuantity(100, resource.DecimalSI),
+						},
+					},
+				},
+				Pods: &metricspods.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 200},
+						"pod-2": podmetrics.Metric{Value: 100},
+					},
+					ReadyPodCount: 2,
+				},
+			},
+		},
+		{
+			"Sum target without a value, return current replicas",
+			4,
+			&replicas.ReplicaCalculator{
+				Tolerance: 0.1,
+			},
+			4,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Pods: &v2.PodsMetricSource{
+						Target: v2.MetricTarget{
+							Type: v2.ValueMetricType,
+						},
+					},
+				},
+				Pods: &metricspods.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 200},
+					},
+					ReadyPodCount: 1,
+				},
+			},
+		},
+		{
+			"Sum target with a zero value, return current replicas",
+			4,
+			&replicas.ReplicaCalculator{
+				Tolerance: 0.1,
+			},
+			4,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Pods: &v2.PodsMetricSource{
+						Target: v2.MetricTarget{
+							Type:  v2.ValueMetricType,
+							Value: resource.NewMilliQuantity(0, resource.DecimalSI),
+						},
+					},
+				},
+				Pods: &metricspods.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 200},
+					},
+					ReadyPodCount: 1,
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			eval := pods.Evaluate{
+				Calculater: test.calculater,
+			}
+			result := eval.Evaluate(test.currentReplicas, test.gatheredMetric)
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("evaluation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestEvaluateWithError(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	sumMetric := func(target *resource.Quantity) *metrics.Metric {
+		return &metrics.Metric{
+			Spec: v2.MetricSpec{
+				Pods: &v2.PodsMetricSource{
+					Target: v2.MetricTarget{
+						Type:  v2.ValueMetricType,
+						Value: target,
+					},
+				},
+			},
+			Pods: &metricspods.Metric{
+				PodMetricsInfo: podmetrics.MetricsInfo{
+					"pod-1": podmetrics.Metric{Value: 200},
+					"pod-2": podmetrics.Metric{Value: 100},
+				},
+				ReadyPodCount: 2,
+			},
+		}
+	}
+
+	var tests = []struct {
+		description        string
+		expected           int32
+		expectedUsageRatio float64
+		expectedErr        error
+		gatheredMetric     *metrics.Metric
+	}{
+		{
+			description:    "Sum target without a value",
+			expectedErr:    errors.New("invalid pods metric source: a value target must be greater than 0"),
+			gatheredMetric: sumMetric(nil),
+		},
+		{
+			description:    "Sum target with a zero value",
+			expectedErr:    errors.New("invalid pods metric source: a value target must be greater than 0"),
+			gatheredMetric: sumMetric(resource.NewMilliQuantity(0, resource.DecimalSI)),
+		},
+		{
+			description:    "Sum target with a negative value",
+			expectedErr:    errors.New("invalid pods metric source: a value target must be greater than 0"),
+			gatheredMetric: sumMetric(resource.NewMilliQuantity(-100, resource.DecimalSI)),
+		},
+		{
+			description:        "Sum target, 300 total against 100 target with 2 ready pods",
+			expected:           6,
+			expectedUsageRatio: 3,
+			gatheredMetric:     sumMetric(resource.NewMilliQuantity(100, resource.DecimalSI)),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			eval := pods.Evaluate{
+				Calculater: &replicas.ReplicaCalculator{
+					Tolerance: 0.1,
+				},
+			}
+
+			result, err := eval.EvaluateWithError(context.Background(), 2, test.gatheredMetric)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("evaluation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+
+			usageRatio, err := eval.UsageRatio(2, test.gatheredMetric)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("usage ratio error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err,
+					equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expectedUsageRatio, usageRatio) {
+				t.Errorf("usage ratio mismatch (-want +got):\n%s", cmp.Diff(test.expectedUsageRatio, usageRatio))
+			}
+		})
+	}
+}
@@ -28,11 +28,14 @@ https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscale
 package pods
 
 import (
+	"context"
 	"fmt"
+	"time"
 
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/podutil"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
 	metricsclient "github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	corelisters "k8s.io/client-go/listers/core/v1"
@@ -46,36 +49,58 @@ type Gather struct {
 
 // Gather retrieves a pods metric
 func (c *Gather) Gather(metricName string, namespace string, podSelector labels.Selector, metricSelector labels.Selector) (*pods.Metric, error) {
+	return c.GatherWithContext(context.Background(), metricName, namespace, podSelector, metricSelector)
+}
+
+// GatherWithContext retrieves a pods metric, passing the context provided to the metrics client
+func (c *Gather) GatherWithContext(ctx context.Context, metricName string, namespace string, podSelector labels.Selector, metricSelector labels.Selector) (*pods.Metric, error) {
 	// Get metrics
-	metrics, timestamp, err := c.MetricsClient.GetRawMetric(metricName, namespace, podSelector, metricSelector)
+	start := time.Now()
+	metrics, timestamp, err := metricsclient.WithContext(c.MetricsClient).GetRawMetricWithContext(ctx, metricName, namespace, podSelector, metricSelector)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get metric %s: %w", metricName, err)
 	}
+	metricProvenance := &provenance.Provenance{
+		Source:    provenance.SourceCustom,
+		Duration:  time.Since(start),
+		ItemCount: len(metrics),
+	}
 
 	// Get pods
-	podList, err := c.PodLister.Pods(namespace).List(podSelector)
+	podList, err := podutil.ListPods(ctx, c.PodLister, namespace, podSelector)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get pods while calculating replica count: %w", err)
 	}
 
+	// Exclude terminating and failed pods, removing any metrics reported for them
+	podList, excludedPods := podutil.FilterActivePods(podList)
+	podutil.RemoveMetricsForPods(metrics, excludedPods)
+
 	totalPods := len(podList)
 	if totalPods == 0 {
 		return &pods.Metric{
 			ReadyPodCount: 0,
 			TotalPods:     0,
 			Timestamp:     timestamp,
+			Provenance:    metricProvenance,
 		}, nil
 	}
 
 	// Remove missing pod metrics
-	readyPodCount, _, missingPods := podutil.GroupPods(podList, metrics, corev1.ResourceName(""), 0, 0)
+	readyPodCount, _, missingPods, podErrors := podutil.GroupPodsWithReasons(podList, metrics, corev1.ResourceName(""), 0, 0)
+	oldestTimestamp, newestTimestamp := metrics.TimestampRange()
 
 	return &pods.Metric{
-		PodMetricsInfo: metrics,
-		ReadyPodCount:  int64(readyPodCount),
-		IgnoredPods:    nil, // Pods metric cannot be CPU based, so Pods cannot be ignored
-		MissingPods:    missingPods,
-		TotalPods:      totalPods,
-		Timestamp:      timestamp,
+		PodMetricsInfo:  metrics,
+		ReadyPodCount:   int64(readyPodCount),
+		IgnoredPods:     nil, // Pods metric cannot be CPU based, so Pods cannot be ignored
+		MissingPods:     missingPods,
+		Errors:          podErrors,
+		TotalPods:       totalPods,
+		DeletionCosts:   podutil.GetPodDeletionCosts(podList),
+		Timestamp:       timestamp,
+		OldestTimestamp: oldestTimestamp,
+		NewestTimestamp: newestTimestamp,
+		Provenance:      metricProvenance,
 	}, nil
 }
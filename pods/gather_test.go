@@ -22,11 +22,14 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/fake"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/pods"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
 	podsmetric "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
 	metricsclient "github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -41,6 +44,7 @@ func TestGather(t *testing.T) {
 		}
 		return x.Error() == y.Error()
 	})
+	ignoreDuration := cmpopts.IgnoreFields(provenance.Provenance{}, "Duration")
 
 	var tests = []struct {
 		description    string
@@ -97,6 +101,10 @@ func TestGather(t *testing.T) {
 				ReadyPodCount: 0,
 				TotalPods:     0,
 				Timestamp:     time.Time{},
+				Provenance: &provenance.Provenance{
+					Source:    provenance.SourceCustom,
+					ItemCount: 1,
+				},
 			},
 			nil,
 			&fake.MetricsClient{
@@ -134,6 +142,14 @@ func TestGather(t *testing.T) {
 					"ready-pod-2": podmetrics.Metric{},
 					"ready-pod-3": podmetrics.Metric{},
 				},
+				Errors: map[string]string{
+					"missing-pod-1": podutil.ReasonNoMetrics,
+					"missing-pod-2": podutil.ReasonNoMetrics,
+				},
+				Provenance: &provenance.Provenance{
+					Source:    provenance.SourceCustom,
+					ItemCount: 3,
+				},
 			},
 			nil,
 			&fake.MetricsClient{
@@ -197,8 +213,8 @@ func TestGather(t *testing.T) {
 				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
 				return
 			}
-			if !cmp.Equal(test.expected, metric) {
-				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric))
+			if !cmp.Equal(test.expected, metric, ignoreDuration) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric, ignoreDuration))
 			}
 		})
 	}
@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podsclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// InformerOptions configures an informer backed PodLister. Namespace limits the pods watched and cached to a single
+// namespace, if empty pods across the cluster are cached. ResyncPeriod is how often the informer resyncs its cache, if
+// zero the cache is never resynced.
+type InformerOptions struct {
+	Namespace    string
+	ResyncPeriod time.Duration
+}
+
+// NewInformerPodLister sets up a PodLister backed by a shared informer, which watches pods and serves lists from a
+// local cache rather than querying the API server on every gather. The informer is started and runs until the
+// context provided is cancelled; this blocks until the informer's cache has synced, returning an error if the context
+// is cancelled before it syncs. The PodLister returned can be passed to NewGatherer.
+func NewInformerPodLister(ctx context.Context, clientset kubernetes.Interface,
+	options InformerOptions) (corelisters.PodLister, error) {
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, options.ResyncPeriod,
+		informers.WithNamespace(options.Namespace))
+
+	// The lister must be requested before the factory is started so the pod informer is registered
+	podLister := factory.Core().V1().Pods().Lister()
+
+	factory.Start(ctx.Done())
+	for informerType, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return nil, fmt.Errorf("failed to sync informer cache for %s", informerType)
+		}
+	}
+
+	return podLister, nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podsclient_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podsclient"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	fakecorev1 "k8s.io/client-go/kubernetes/typed/core/v1/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestNewInformerPodLister(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	testPods := []runtime.Object{
+		&corev1.Pod{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "test-pod",
+				Namespace: "test-namespace",
+				Labels:    map[string]string{"app": "test"},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "other-pod",
+				Namespace: "test-namespace",
+				Labels:    map[string]string{"app": "other"},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "test-pod",
+				Namespace: "other-namespace",
+				Labels:    map[string]string{"app": "test"},
+			},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expected    []string
+		expectedErr error
+		clientset   kubernetes.Interface
+		options     podsclient.InformerOptions
+		selector    labels.Selector
+	}{
+		{
+			description: "Fail, cache never syncs",
+			expectedErr: errors.New("failed to sync informer cache for *v1.Pod"),
+			clientset: func() *fake.Clientset {
+				clientset := fake.NewSimpleClientset()
+				clientset.CoreV1().(*fakecorev1.FakeCoreV1).Fake.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("fail to list pods")
+				})
+				return clientset
+			}(),
+			selector: labels.Everything(),
+		},
+		{
+			description: "Success, list pods across cluster",
+			expected:    []string{"other-namespace/test-pod", "test-namespace/other-pod", "test-namespace/test-pod"},
+			clientset:   fake.NewSimpleClientset(testPods...),
+			selector:    labels.Everything(),
+		},
+		{
+			description: "Success, list pods matching selector across cluster",
+			expected:    []string{"other-namespace/test-pod", "test-namespace/test-pod"},
+			clientset:   fake.NewSimpleClientset(testPods...),
+			selector:    labels.SelectorFromSet(labels.Set{"app": "test"}),
+		},
+		{
+			description: "Success, list pods scoped to namespace",
+			expected:    []string{"test-namespace/other-pod", "test-namespace/test-pod"},
+			clientset:   fake.NewSimpleClientset(testPods...),
+			options: podsclient.InformerOptions{
+				Namespace:    "test-namespace",
+				ResyncPeriod: time.Minute,
+			},
+			selector: labels.Everything(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			podLister, err := podsclient.NewInformerPodLister(ctx, test.clientset, test.options)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			pods, err := podLister.List(test.selector)
+			if err != nil {
+				t.Fatalf("unexpected error listing pods: %s", err)
+			}
+
+			listed := []string{}
+			for _, pod := range pods {
+				listed = append(listed, pod.Namespace+"/"+pod.Name)
+			}
+
+			if !cmp.Equal(test.expected, listed, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("pods mismatch (-want +got):\n%s", cmp.Diff(test.expected, listed))
+			}
+		})
+	}
+}
@@ -15,7 +15,9 @@ limitations under the License.
 */
 
 // Package podsclient provides an on-demand client for retrieving pods, without
-// using caching, as the HorizontalPodAutoscaler does.
+// using caching, as the HorizontalPodAutoscaler does. For large clusters an
+// informer backed client that serves pods from a local cache is also provided,
+// see NewInformerPodLister.
 package podsclient
 
 import (
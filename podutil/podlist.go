@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podutil
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+type podListCacheKey struct{}
+
+type podListCache struct {
+	mu      sync.Mutex
+	entries map[string]*podListEntry
+}
+
+type podListEntry struct {
+	mu     sync.Mutex
+	listed bool
+	pods   []*corev1.Pod
+}
+
+// WithPodListCache returns a context which caches pod lists made using ListPods with the context, pods are listed
+// once per namespace and selector for the lifetime of the context. Failed lists are not cached. If the context
+// provided already caches pod lists it is returned unchanged.
+func WithPodListCache(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(podListCacheKey{}).(*podListCache); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, podListCacheKey{}, &podListCache{
+		entries: map[string]*podListEntry{},
+	})
+}
+
+// ListPods lists the pods in the namespace matching the selector using the pod lister provided, if the context was
+// set up using WithPodListCache the pod list is shared with any other ListPods calls using the context for the same
+// namespace and selector.
+func ListPods(ctx context.Context, podLister corelisters.PodLister, namespace string,
+	selector labels.Selector) ([]*corev1.Pod, error) {
+	cache, ok := ctx.Value(podListCacheKey{}).(*podListCache)
+	if !ok {
+		return podLister.Pods(namespace).List(selector)
+	}
+
+	key := namespace + "/" + selector.String()
+	cache.mu.Lock()
+	entry, ok := cache.entries[key]
+	if !ok {
+		entry = &podListEntry{}
+		cache.entries[key] = entry
+	}
+	cache.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if !entry.listed {
+		pods, err := podLister.Pods(namespace).List(selector)
+		if err != nil {
+			return nil, err
+		}
+		entry.pods = pods
+		entry.listed = true
+	}
+
+	// Copy the list so callers can't affect each other by modifying it
+	pods := make([]*corev1.Pod, len(entry.pods))
+	copy(pods, entry.pods)
+	return pods, nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podutil_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+func TestListPods(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	testPods := []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-pod",
+			},
+		},
+	}
+
+	type listCall struct {
+		namespace string
+		selector  labels.Selector
+	}
+
+	var tests = []struct {
+		description   string
+		expected      []*corev1.Pod
+		expectedErr   error
+		expectedLists int
+		cache         bool
+		failFirst     bool
+		calls         []listCall
+	}{
+		{
+			description:   "No cache, list each call",
+			expected:      testPods,
+			expectedErr:   nil,
+			expectedLists: 2,
+			cache:         false,
+			calls: []listCall{
+				{namespace: "test", selector: labels.SelectorFromSet(labels.Set{"app": "test"})},
+				{namespace: "test", selector: labels.SelectorFromSet(labels.Set{"app": "test"})},
+			},
+		},
+		{
+			description:   "Cache, list once for the same namespace and selector",
+			expected:      testPods,
+			expectedErr:   nil,
+			expectedLists: 1,
+			cache:         true,
+			calls: []listCall{
+				{namespace: "test", selector: labels.SelectorFromSet(labels.Set{"app": "test"})},
+				{namespace: "test", selector: labels.SelectorFromSet(labels.Set{"app": "test"})},
+				{namespace: "test", selector: labels.SelectorFromSet(labels.Set{"app": "test"})},
+			},
+		},
+		{
+			description:   "Cache, list once per namespace and selector",
+			expected:      testPods,
+			expectedErr:   nil,
+			expectedLists: 3,
+			cache:         true,
+			calls: []listCall{
+				{namespace: "test", selector: labels.SelectorFromSet(labels.Set{"app": "test"})},
+				{namespace: "other", selector: labels.SelectorFromSet(labels.Set{"app": "test"})},
+				{namespace: "test", selector: labels.SelectorFromSet(labels.Set{"app": "other"})},
+				{namespace: "other", selector: labels.SelectorFromSet(labels.Set{"app": "test"})},
+			},
+		},
+		{
+			description:   "Cache, failed list is not cached",
+			expected:      testPods,
+			expectedErr:   nil,
+			expectedLists: 2,
+			cache:         true,
+			failFirst:     true,
+			calls: []listCall{
+				{namespace: "test", selector: labels.SelectorFromSet(labels.Set{"app": "test"})},
+				{namespace: "test", selector: labels.SelectorFromSet(labels.Set{"app": "test"})},
+				{namespace: "test", selector: labels.SelectorFromSet(labels.Set{"app": "test"})},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			lists := 0
+			podLister := &fake.PodLister{
+				PodsReactor: func(namespace string) corelisters.PodNamespaceLister {
+					return &fake.PodNamespaceLister{
+						ListReactor: func(selector labels.Selector) (ret []*corev1.Pod, err error) {
+							lists++
+							if test.failFirst && lists == 1 {
+								return nil, errors.New("fail to list pods")
+							}
+							return testPods, nil
+						},
+					}
+				},
+			}
+
+			ctx := context.Background()
+			if test.cache {
+				ctx = podutil.WithPodListCache(ctx)
+				// Setting up the cache again should reuse the existing cache
+				ctx = podutil.WithPodListCache(ctx)
+			}
+
+			var result []*corev1.Pod
+			var err error
+			for i, call := range test.calls {
+				result, err = podutil.ListPods(ctx, podLister, call.namespace, call.selector)
+				if test.failFirst && i == 0 {
+					expectedErr := errors.New("fail to list pods")
+					if !cmp.Equal(&err, &expectedErr, equateErrorMessage) {
+						t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(expectedErr, err, equateErrorMessage))
+					}
+				}
+			}
+
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("pods mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+
+			if lists != test.expectedLists {
+				t.Errorf("list count mismatch, want %d, got %d", test.expectedLists, lists)
+			}
+		})
+	}
+}
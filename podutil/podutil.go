@@ -0,0 +1,321 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Modifications Copyright 2022 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+
+Modified to split up evaluations and metric gathering to work with the
+Custom Pod Autoscaler framework.
+Original source:
+https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscaler/horizontal.go
+https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscaler/replica_calculator.go
+*/
+
+// Package podutil provides utilities for getting pod information from the K8s APIs, such as grouping pods into ready,
+// ignored and missing, calculating pod resource requests and counting ready pods in the same way the HPA does. These
+// can be used when building custom metric gathering.
+package podutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+var (
+	// ErrNoPods occurs when no pods match the pod selector provided
+	ErrNoPods = errors.New("no pods returned by selector")
+	// ErrMissingRequests occurs when a pod's containers are missing a request for the resource being calculated
+	ErrMissingRequests = errors.New("missing request")
+	// ErrMissingLimits occurs when a pod's containers are missing a limit for the resource being calculated
+	ErrMissingLimits = errors.New("missing limit")
+)
+
+// PodReadyCounter provides a way to count number of ready pods
+type PodReadyCounter interface {
+	GetReadyPodsCount(namespace string, selector labels.Selector) (int64, error)
+}
+
+// PodReadyCounterWithContext provides a way to count number of ready pods, accepting a context which can be used to
+// share pod lists using WithPodListCache
+type PodReadyCounterWithContext interface {
+	GetReadyPodsCountWithContext(ctx context.Context, namespace string, selector labels.Selector) (int64, error)
+}
+
+// GetReadyPodsCountWithContext counts the number of ready pods using the counter provided, passing the context
+// provided if the counter implements PodReadyCounterWithContext
+func GetReadyPodsCountWithContext(ctx context.Context, counter PodReadyCounter, namespace string,
+	selector labels.Selector) (int64, error) {
+	if contextCounter, ok := counter.(PodReadyCounterWithContext); ok {
+		return contextCounter.GetReadyPodsCountWithContext(ctx, namespace, selector)
+	}
+	return counter.GetReadyPodsCount(namespace, selector)
+}
+
+// PodReadyCount provides a way to count the number of ready pods using a pod lister
+type PodReadyCount struct {
+	PodLister corelisters.PodLister
+}
+
+// GetReadyPodsCount returns the number of pods that are deemed 'ready'
+func (c *PodReadyCount) GetReadyPodsCount(namespace string, selector labels.Selector) (int64, error) {
+	return c.GetReadyPodsCountWithContext(context.Background(), namespace, selector)
+}
+
+// GetReadyPodsCountWithContext returns the number of pods that are deemed 'ready', sharing the pod list if the
+// context was set up using WithPodListCache
+func (c *PodReadyCount) GetReadyPodsCountWithContext(ctx context.Context, namespace string,
+	selector labels.Selector) (int64, error) {
+	// Get pods
+	podList, err := ListPods(ctx, c.PodLister, namespace, selector)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get pods while calculating replica count: %w", err)
+	}
+
+	// Count number of ready pods, terminating pods can still be running and ready so are excluded
+	readyPodCount := int64(0)
+	for _, pod := range podList {
+		if IsPodActive(pod) && pod.Status.Phase == corev1.PodRunning && isPodReady(pod) {
+			readyPodCount++
+		}
+	}
+
+	return readyPodCount, nil
+}
+
+// Reasons recorded against pods that are grouped as missing or ignored
+const (
+	ReasonPending          = "pod is pending"
+	ReasonNoMetrics        = "no metrics returned for pod"
+	ReasonNoReadyCondition = "pod has no ready condition or start time"
+	ReasonUnreadyInCPUInit = "pod is unready or has not had a full metric window since becoming ready, within the CPU " +
+		"initialization period"
+	ReasonNeverReady = "pod is unready and has never been ready"
+)
+
+// IsPodActive returns false if the pod provided is terminating, having a deletion timestamp set, or has failed, which
+// includes pods that have been evicted. These pods still show up in pod lists but should not be included in metrics or
+// pod counts, in the same way as the HPA.
+func IsPodActive(pod *corev1.Pod) bool {
+	return pod.DeletionTimestamp == nil && pod.Status.Phase != corev1.PodFailed
+}
+
+// FilterActivePods splits the pods provided into the active pods and the names of the pods which are excluded for
+// being terminating or failed, see IsPodActive
+func FilterActivePods(pods []*corev1.Pod) (activePods []*corev1.Pod, excludedPods sets.String) {
+	activePods = make([]*corev1.Pod, 0, len(pods))
+	excludedPods = sets.NewString()
+	for _, pod := range pods {
+		if !IsPodActive(pod) {
+			excludedPods.Insert(pod.Name)
+			continue
+		}
+		activePods = append(activePods, pod)
+	}
+	return activePods, excludedPods
+}
+
+// GroupPods groups pods into ready, missing and ignored based on PodMetricsInfo and resource provided
+func GroupPods(pods []*corev1.Pod, metrics podmetrics.MetricsInfo, resource corev1.ResourceName, cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (readyPodCount int, ignoredPods sets.String, missingPods sets.String) {
+	readyPodCount, ignoredPods, missingPods, _ = GroupPodsWithReasons(pods, metrics, resource, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+	return
+}
+
+// GroupPodsWithReasons groups pods into ready, missing and ignored based on PodMetricsInfo and resource provided, also
+// returning a map of pod name to the reason each missing or ignored pod was grouped as it was. Pods with containers
+// missing a request are not given a reason, as the HPA fails the whole calculation for them rather than ignoring them,
+// see CalculatePodRequests.
+func GroupPodsWithReasons(pods []*corev1.Pod, metrics podmetrics.MetricsInfo, resource corev1.ResourceName, cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (readyPodCount int, ignoredPods sets.String, missingPods sets.String, reasons map[string]string) {
+	missingPods = sets.NewString()
+	ignoredPods = sets.NewString()
+	reasons = map[string]string{}
+	for _, pod := range pods {
+		if !IsPodActive(pod) {
+			continue
+		}
+		// Pending pods are ignored.
+		if pod.Status.Phase == corev1.PodPending {
+			ignoredPods.Insert(pod.Name)
+			reasons[pod.Name] = ReasonPending
+			continue
+		}
+		// Pods missing metrics.
+		metric, found := metrics[pod.Name]
+		if !found {
+			missingPods.Insert(pod.Name)
+			reasons[pod.Name] = ReasonNoMetrics
+			continue
+		}
+		// Unready pods are ignored.
+		if resource == corev1.ResourceCPU {
+			var ignorePod bool
+			var reason string
+			_, condition := getPodCondition(pod.Status, corev1.PodReady)
+			if condition == nil || pod.Status.StartTime == nil {
+				ignorePod = true
+				reason = ReasonNoReadyCondition
+			} else {
+				// Pod still within possible initialisation period.
+				if pod.Status.StartTime.Add(cpuInitializationPeriod).After(time.Now()) {
+					// Ignore sample if pod is unready or one window of metric wasn't collected since last state transition.
+					ignorePod = condition.Status == corev1.ConditionFalse || metric.Timestamp.Before(condition.LastTransitionTime.Time.Add(metric.Window))
+					reason = ReasonUnreadyInCPUInit
+				} else {
+					// Ignore metric if pod is unready and it has never been ready.
+					ignorePod = condition.Status == corev1.ConditionFalse && pod.Status.StartTime.Add(delayOfInitialReadinessStatus).After(condition.LastTransitionTime.Time)
+					reason = ReasonNeverReady
+				}
+			}
+			if ignorePod {
+				ignoredPods.Insert(pod.Name)
+				reasons[pod.Name] = reason
+				continue
+			}
+		}
+		readyPodCount++
+	}
+	return
+}
+
+// PodDeletionCostAnnotation is the annotation used to set the cost of deleting a pod relative to the other pods
+// managed by the same ReplicaSet, pods with a lower deletion cost are preferred when scaling down
+const PodDeletionCostAnnotation = "controller.kubernetes.io/pod-deletion-cost"
+
+// GetPodDeletionCosts returns the deletion cost of each pod provided that has a valid PodDeletionCostAnnotation,
+// keyed by pod name. Pods without the annotation, or with an invalid value, have the default deletion cost of 0 and
+// are not included, in the same way as the ReplicaSet controller. If no pods have a deletion cost nil is returned.
+func GetPodDeletionCosts(pods []*corev1.Pod) map[string]int32 {
+	var deletionCosts map[string]int32
+	for _, pod := range pods {
+		deletionCost, ok := getPodDeletionCost(pod)
+		if !ok {
+			continue
+		}
+		if deletionCosts == nil {
+			deletionCosts = map[string]int32{}
+		}
+		deletionCosts[pod.Name] = deletionCost
+	}
+	return deletionCosts
+}
+
+// getPodDeletionCost parses the deletion cost annotation of the pod provided, values with a leading plus sign or
+// leading zeros are not valid
+func getPodDeletionCost(pod *corev1.Pod) (int32, bool) {
+	value, exists := pod.Annotations[PodDeletionCostAnnotation]
+	if !exists || value == "" {
+		return 0, false
+	}
+
+	digits := strings.TrimPrefix(value, "-")
+	if digits == "" || digits[0] < '0' || digits[0] > '9' || (digits[0] == '0' && len(digits) > 1) {
+		return 0, false
+	}
+
+	deletionCost, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return int32(deletionCost), true
+}
+
+// CalculatePodRequests calculates pod resource requests for a slice of pods, summing the requests of each pod's app
+// containers and restartable init containers (sidecars) in the same way as the HPA. Init containers which are not
+// restartable run to completion before the app containers start, so are not included.
+func CalculatePodRequests(pods []*corev1.Pod, resource corev1.ResourceName) (map[string]int64, error) {
+	requests := make(map[string]int64, len(pods))
+	for _, pod := range pods {
+		podSum := int64(0)
+		for _, container := range RunningContainers(&pod.Spec) {
+			if containerRequest, ok := container.Resources.Requests[resource]; ok {
+				podSum += containerRequest.MilliValue()
+			} else {
+				return nil, fmt.Errorf("%w for %s", ErrMissingRequests, resource)
+			}
+		}
+		requests[pod.Name] = podSum
+	}
+	return requests, nil
+}
+
+// CalculatePodLimits calculates pod resource limits for a slice of pods, summing the limits of each pod's app
+// containers and restartable init containers (sidecars) in the same way as CalculatePodRequests
+func CalculatePodLimits(pods []*corev1.Pod, resource corev1.ResourceName) (map[string]int64, error) {
+	limits := make(map[string]int64, len(pods))
+	for _, pod := range pods {
+		podSum := int64(0)
+		for _, container := range RunningContainers(&pod.Spec) {
+			if containerLimit, ok := container.Resources.Limits[resource]; ok {
+				podSum += containerLimit.MilliValue()
+			} else {
+				return nil, fmt.Errorf("%w for %s", ErrMissingLimits, resource)
+			}
+		}
+		limits[pod.Name] = podSum
+	}
+	return limits, nil
+}
+
+// RunningContainers returns the app containers and restartable init containers (sidecars) of the pod spec provided,
+// which are the containers that run alongside each other once the pod has started
+func RunningContainers(podSpec *corev1.PodSpec) []corev1.Container {
+	containers := append([]corev1.Container{}, podSpec.Containers...)
+	for _, container := range podSpec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			containers = append(containers, container)
+		}
+	}
+	return containers
+}
+
+// RemoveMetricsForPods removes the pods provided from the PodMetricsInfo provided
+func RemoveMetricsForPods(metrics podmetrics.MetricsInfo, pods sets.String) {
+	for _, pod := range pods.UnsortedList() {
+		delete(metrics, pod)
+	}
+}
+
+// IsPodReady returns true if a pod is ready; false otherwise.
+func isPodReady(pod *corev1.Pod) bool {
+	_, condition := getPodCondition(pod.Status, corev1.PodReady)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// GetPodCondition extracts the provided condition from the given status and returns that.
+// Returns nil and -1 if the condition is not present, and the index of the located condition.
+func getPodCondition(status corev1.PodStatus, conditionType corev1.PodConditionType) (int, *corev1.PodCondition) {
+	conditions := status.Conditions
+	if conditions == nil {
+		return -1, nil
+	}
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return i, &conditions[i]
+		}
+	}
+	return -1, nil
+}
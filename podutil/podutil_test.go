@@ -22,9 +22,9 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/fake"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/podutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -232,6 +232,37 @@ func TestPodReadyCount_GetReadyPodsCount(t *testing.T) {
 			"test-namespace",
 			nil,
 		},
+		{
+			"1 ready terminating pod, success",
+			0,
+			nil,
+			&fake.PodLister{
+				PodsReactor: func(namespace string) corelisters.PodNamespaceLister {
+					return &fake.PodNamespaceLister{
+						ListReactor: func(selector labels.Selector) (ret []*corev1.Pod, err error) {
+							return []*corev1.Pod{
+								{
+									ObjectMeta: metav1.ObjectMeta{
+										DeletionTimestamp: &metav1.Time{},
+									},
+									Status: corev1.PodStatus{
+										Phase: corev1.PodRunning,
+										Conditions: []corev1.PodCondition{
+											{
+												Type:   corev1.PodReady,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+								},
+							}, nil
+						},
+					}
+				},
+			},
+			"test-namespace",
+			nil,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
@@ -617,6 +648,152 @@ func TestGroupPods(t *testing.T) {
 	}
 }
 
+func TestGroupPodsWithReasons(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    map[string]string
+		pods        []*corev1.Pod
+		metrics     podmetrics.MetricsInfo
+		resource    corev1.ResourceName
+	}{
+		{
+			description: "No pods, no reasons",
+			expected:    map[string]string{},
+			pods:        []*corev1.Pod{},
+			metrics:     podmetrics.MetricsInfo{},
+			resource:    corev1.ResourceCPU,
+		},
+		{
+			description: "Ready pod has no reason, pending and missing pods have reasons",
+			expected: map[string]string{
+				"pending": podutil.ReasonPending,
+				"missing": podutil.ReasonNoMetrics,
+			},
+			pods: []*corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "ready",
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "pending",
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodPending,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "missing",
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+					},
+				},
+			},
+			metrics: podmetrics.MetricsInfo{
+				"ready": podmetrics.Metric{Value: 1},
+			},
+			resource: corev1.ResourceMemory,
+		},
+		{
+			description: "CPU pod without ready condition",
+			expected: map[string]string{
+				"lucretius": podutil.ReasonNoReadyCondition,
+			},
+			pods: []*corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "lucretius",
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+						StartTime: &metav1.Time{
+							Time: time.Now(),
+						},
+					},
+				},
+			},
+			metrics: podmetrics.MetricsInfo{
+				"lucretius": podmetrics.Metric{Value: 1},
+			},
+			resource: corev1.ResourceCPU,
+		},
+		{
+			description: "CPU pod without fresh metrics during initialization period",
+			expected: map[string]string{
+				"bentham": podutil.ReasonUnreadyInCPUInit,
+			},
+			pods: []*corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "bentham",
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+						StartTime: &metav1.Time{
+							Time: time.Now().Add(-1 * time.Minute),
+						},
+						Conditions: []corev1.PodCondition{
+							{
+								Type:               corev1.PodReady,
+								LastTransitionTime: metav1.Time{Time: time.Now().Add(-30 * time.Second)},
+								Status:             corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			metrics: podmetrics.MetricsInfo{
+				"bentham": podmetrics.Metric{Value: 1, Timestamp: time.Now(), Window: 60 * time.Second},
+			},
+			resource: corev1.ResourceCPU,
+		},
+		{
+			description: "CPU pod that has never been ready after initialization period",
+			expected: map[string]string{
+				"lucretius": podutil.ReasonNeverReady,
+			},
+			pods: []*corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "lucretius",
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+						StartTime: &metav1.Time{
+							Time: time.Now().Add(-10 * time.Minute),
+						},
+						Conditions: []corev1.PodCondition{
+							{
+								Type:               corev1.PodReady,
+								LastTransitionTime: metav1.Time{Time: time.Now().Add(-9*time.Minute - 54*time.Second)},
+								Status:             corev1.ConditionFalse,
+							},
+						},
+					},
+				},
+			},
+			metrics: podmetrics.MetricsInfo{
+				"lucretius": podmetrics.Metric{Value: 1},
+			},
+			resource: corev1.ResourceCPU,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			_, _, _, reasons := podutil.GroupPodsWithReasons(test.pods, test.metrics, test.resource, 2*time.Minute, 10*time.Second)
+			if !cmp.Equal(test.expected, reasons) {
+				t.Errorf("reasons mismatch (-want +got):\n%s", cmp.Diff(test.expected, reasons))
+			}
+		})
+	}
+}
+
 func TestCalculatePodRequests(t *testing.T) {
 	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
 		if x == nil || y == nil {
@@ -624,6 +801,7 @@ func TestCalculatePodRequests(t *testing.T) {
 		}
 		return x.Error() == y.Error()
 	})
+	restartPolicyAlways := corev1.ContainerRestartPolicyAlways
 	var tests = []struct {
 		description string
 		expected    map[string]int64
@@ -743,6 +921,78 @@ func TestCalculatePodRequests(t *testing.T) {
 			},
 			"test resource",
 		},
+		{
+			"Pod with sidecar and init containers success",
+			map[string]int64{
+				"test-pod": 30,
+			},
+			nil,
+			[]*corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-pod",
+					},
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{
+							{
+								Name:          "sidecar",
+								RestartPolicy: &restartPolicyAlways,
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										"test resource": *resource.NewMilliQuantity(20, resource.DecimalSI),
+									},
+								},
+							},
+							{
+								Name: "init",
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										"test resource": *resource.NewMilliQuantity(100, resource.DecimalSI),
+									},
+								},
+							},
+						},
+						Containers: []corev1.Container{
+							{
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										"test resource": *resource.NewMilliQuantity(10, resource.DecimalSI),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"test resource",
+		},
+		{
+			"Fail sidecar missing requests",
+			nil,
+			errors.New("missing request for test resource"),
+			[]*corev1.Pod{
+				{
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{
+							{
+								Name:          "sidecar",
+								RestartPolicy: &restartPolicyAlways,
+							},
+						},
+						Containers: []corev1.Container{
+							{
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										"test resource": *resource.NewMilliQuantity(10, resource.DecimalSI),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"test resource",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
@@ -804,3 +1054,163 @@ func TestRemoveMetricsForPods(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterActivePods(t *testing.T) {
+	var tests = []struct {
+		description          string
+		expectedActivePods   []*corev1.Pod
+		expectedExcludedPods sets.String
+		pods                 []*corev1.Pod
+	}{
+		{
+			description:          "No pods",
+			expectedActivePods:   []*corev1.Pod{},
+			expectedExcludedPods: sets.NewString(),
+			pods:                 []*corev1.Pod{},
+		},
+		{
+			description: "Exclude terminating, failed and evicted pods",
+			expectedActivePods: []*corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "running-pod",
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "pending-pod",
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodPending,
+					},
+				},
+			},
+			expectedExcludedPods: sets.NewString("terminating-pod", "failed-pod", "evicted-pod"),
+			pods: []*corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "running-pod",
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "terminating-pod",
+						DeletionTimestamp: &metav1.Time{},
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "pending-pod",
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodPending,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "failed-pod",
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodFailed,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "evicted-pod",
+					},
+					Status: corev1.PodStatus{
+						Phase:  corev1.PodFailed,
+						Reason: "Evicted",
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			activePods, excludedPods := podutil.FilterActivePods(test.pods)
+			if !cmp.Equal(test.expectedActivePods, activePods) {
+				t.Errorf("active pods mismatch (-want +got):\n%s", cmp.Diff(test.expectedActivePods, activePods))
+			}
+			if !cmp.Equal(test.expectedExcludedPods, excludedPods) {
+				t.Errorf("excluded pods mismatch (-want +got):\n%s", cmp.Diff(test.expectedExcludedPods, excludedPods))
+			}
+		})
+	}
+}
+
+func TestGetPodDeletionCosts(t *testing.T) {
+	podWithDeletionCost := func(name string, deletionCost string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Annotations: map[string]string{
+					podutil.PodDeletionCostAnnotation: deletionCost,
+				},
+			},
+		}
+	}
+
+	var tests = []struct {
+		description string
+		expected    map[string]int32
+		pods        []*corev1.Pod
+	}{
+		{
+			description: "No pods",
+			expected:    nil,
+			pods:        []*corev1.Pod{},
+		},
+		{
+			description: "No pods with deletion cost",
+			expected:    nil,
+			pods: []*corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "pod-1",
+					},
+				},
+			},
+		},
+		{
+			description: "Valid and invalid deletion costs",
+			expected: map[string]int32{
+				"positive": 100,
+				"negative": -100,
+				"zero":     0,
+			},
+			pods: []*corev1.Pod{
+				podWithDeletionCost("positive", "100"),
+				podWithDeletionCost("negative", "-100"),
+				podWithDeletionCost("zero", "0"),
+				podWithDeletionCost("empty", ""),
+				podWithDeletionCost("plus-sign", "+10"),
+				podWithDeletionCost("leading-zero", "010"),
+				podWithDeletionCost("not-a-number", "abc"),
+				podWithDeletionCost("overflow", "2147483648"),
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "no-annotation",
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			deletionCosts := podutil.GetPodDeletionCosts(test.pods)
+			if !cmp.Equal(test.expected, deletionCosts) {
+				t.Errorf("deletion costs mismatch (-want +got):\n%s", cmp.Diff(test.expected, deletionCosts))
+			}
+		})
+	}
+}
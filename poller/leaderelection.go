@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// Default leader election durations, matching the defaults of the Kubernetes controller manager
+const (
+	DefaultLeaseDuration = 15 * time.Second
+	DefaultRenewDeadline = 10 * time.Second
+	DefaultRetryPeriod   = 2 * time.Second
+)
+
+// LeaderElection configures running a Poller with leader election. Lock is the resource lock used to elect the
+// leader, for example a resourcelock.LeaseLock with a unique identity for each replica. Any duration which is not set
+// uses its default.
+type LeaderElection struct {
+	Lock          resourcelock.Interface
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+// RunWithLeaderElection runs the poller only while this replica is the elected leader, so when running multiple
+// replicas only one polls targets while the others stand by. If leadership is lost polling stops and the replica
+// stands by until it is elected again, until the context is cancelled. The lease is released when the context is
+// cancelled, allowing another replica to take over immediately.
+// To hand over stabilization history between leaders set a HistoryStore, which the new leader restores history from
+// when it starts polling each target.
+func (p *Poller) RunWithLeaderElection(ctx context.Context, election LeaderElection) error {
+	config := leaderelection.LeaderElectionConfig{
+		Lock:            election.Lock,
+		LeaseDuration:   election.LeaseDuration,
+		RenewDeadline:   election.RenewDeadline,
+		RetryPeriod:     election.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            "k8shorizmetrics-poller",
+	}
+	if config.LeaseDuration == 0 {
+		config.LeaseDuration = DefaultLeaseDuration
+	}
+	if config.RenewDeadline == 0 {
+		config.RenewDeadline = DefaultRenewDeadline
+	}
+	if config.RetryPeriod == 0 {
+		config.RetryPeriod = DefaultRetryPeriod
+	}
+
+	// Polling runs in a separate goroutine to the elector, so polling from a previous term must finish before a new
+	// term can start polling
+	var runMu sync.Mutex
+	var runErr error
+	config.Callbacks = leaderelection.LeaderCallbacks{
+		OnStartedLeading: func(leaderCtx context.Context) {
+			runMu.Lock()
+			defer runMu.Unlock()
+
+			if leaderCtx.Err() != nil || runErr != nil {
+				return
+			}
+
+			p.Logger.Info("Started leading, polling targets")
+			runErr = p.Run(leaderCtx)
+		},
+		OnStoppedLeading: func() {
+			p.Logger.V(k8shorizmetrics.LogLevelDebug).Info("Not leading, standing by")
+		},
+	}
+
+	elector, err := leaderelection.NewLeaderElector(config)
+	if err != nil {
+		return fmt.Errorf("invalid leader election configuration: %w", err)
+	}
+
+	for ctx.Err() == nil {
+		elector.Run(ctx)
+
+		runMu.Lock()
+		err := runErr
+		runMu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Wait for polling to stop
+	runMu.Lock()
+	defer runMu.Unlock()
+	return runErr
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poller_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/poller"
+	"github.com/jthomperoo/k8shorizmetrics/v4/stabilization"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/utils/clock"
+)
+
+// memoryStore is a stabilization.Store keeping history in memory
+type memoryStore struct {
+	mu      sync.Mutex
+	history map[string][]stabilization.Recommendation
+}
+
+func (s *memoryStore) Load(ctx context.Context, key string) ([]stabilization.Recommendation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.history[key], nil
+}
+
+func (s *memoryStore) Save(ctx context.Context, key string, history []stabilization.Recommendation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history[key] = history
+	return nil
+}
+
+func TestPollerRunWithLeaderElection(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	store := &memoryStore{history: map[string][]stabilization.Recommendation{}}
+	key := target("test-deployment", podsSpec("a")).Key()
+
+	var mu sync.Mutex
+	polls := map[string]int{}
+
+	newReplica := func(identity string) (*poller.Poller, poller.LeaderElection) {
+		p := newPoller(func(name string) (*autoscalingv1.Scale, error) {
+			return newScale(2), nil
+		}, nil, map[string]int32{"a": 4})
+		p.Clock = clock.RealClock{}
+		p.Interval = 10 * time.Millisecond
+		p.Stabilizer = stabilization.NewStabilizer()
+		p.HistoryStore = store
+		p.Callback = func(ctx context.Context, recommendation *poller.Recommendation, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			polls[identity]++
+		}
+		p.Register(target("test-deployment", podsSpec("a")))
+
+		return p, poller.LeaderElection{
+			Lock:          newLock(clientset, identity),
+			LeaseDuration: time.Second,
+			RenewDeadline: 500 * time.Millisecond,
+			RetryPeriod:   50 * time.Millisecond,
+		}
+	}
+
+	pollCount := func(identity string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return polls[identity]
+	}
+
+	waitFor := func(description string, condition func() bool) {
+		deadline := time.Now().Add(10 * time.Second)
+		for !condition() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", description)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	first, firstElection := newReplica("first")
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstDone := make(chan error)
+	go func() {
+		firstDone <- first.RunWithLeaderElection(firstCtx, firstElection)
+	}()
+
+	waitFor("first replica to poll", func() bool {
+		return pollCount("first") >= 3
+	})
+
+	second, secondElection := newReplica("second")
+	secondCtx, cancelSecond := context.WithCancel(context.Background())
+	defer cancelSecond()
+	secondDone := make(chan error)
+	go func() {
+		secondDone <- second.RunWithLeaderElection(secondCtx, secondElection)
+	}()
+
+	// The second replica stands by while the first is leading
+	time.Sleep(200 * time.Millisecond)
+	if count := pollCount("second"); count != 0 {
+		t.Errorf("expected second replica not to poll while standing by, got %d polls", count)
+	}
+
+	cancelFirst()
+	if err := <-firstDone; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	firstHistory := first.Stabilizer.History(key)
+
+	waitFor("second replica to poll", func() bool {
+		return pollCount("second") >= 1
+	})
+
+	// The second replica continues from the history of the first
+	secondHistory := second.Stabilizer.History(key)
+	if len(secondHistory) <= len(firstHistory) {
+		t.Fatalf("expected second replica to restore %d recommendations, got %d", len(firstHistory),
+			len(secondHistory))
+	}
+	for i, recommendation := range firstHistory {
+		if !recommendation.Timestamp.Equal(secondHistory[i].Timestamp) {
+			t.Errorf("history mismatch at %d, want %v, got %v", i, recommendation, secondHistory[i])
+		}
+	}
+
+	cancelSecond()
+	if err := <-secondDone; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPollerRunWithLeaderElectionInvalidConfig(t *testing.T) {
+	p := newPoller(func(name string) (*autoscalingv1.Scale, error) {
+		return newScale(2), nil
+	}, nil, nil)
+
+	err := p.RunWithLeaderElection(context.Background(), poller.LeaderElection{
+		Lock:          newLock(fake.NewSimpleClientset(), "test"),
+		LeaseDuration: time.Second,
+		RenewDeadline: 2 * time.Second,
+	})
+
+	expected := "invalid leader election configuration: leaseDuration must be greater than renewDeadline"
+	if err == nil || err.Error() != expected {
+		t.Errorf("error mismatch, want %s, got %v", expected, err)
+	}
+}
+
+func newLock(clientset kubernetes.Interface, identity string) resourcelock.Interface {
+	return &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      "test-poller",
+			Namespace: "default",
+		},
+		Client: clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+}
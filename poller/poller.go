@@ -0,0 +1,386 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package poller periodically gathers and evaluates metrics for registered scale targets, calling back with a
+// replica count recommendation for each poll. This provides the polling loop that consumers otherwise write
+// themselves, with a configurable interval, jitter and backoff after failures.
+package poller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/scaler"
+	"github.com/jthomperoo/k8shorizmetrics/v4/stabilization"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/clock"
+)
+
+// DefaultInterval is the interval between polls of each target if none is set, matching the default sync period of
+// the HPA controller
+const DefaultInterval = 15 * time.Second
+
+// DefaultBackoff returns the backoff used after failed polls if none is set, starting at the DefaultInterval and
+// doubling after each consecutive failure up to 5 minutes, with 10% jitter
+func DefaultBackoff() wait.Backoff {
+	return wait.Backoff{
+		Duration: DefaultInterval,
+		Factor:   2,
+		Jitter:   0.1,
+		Steps:    math.MaxInt32,
+		Cap:      5 * time.Minute,
+	}
+}
+
+// Target is a scale target to poll and the metric specs it is scaled on
+type Target struct {
+	Namespace      string
+	ScaleTargetRef autoscalingv2.CrossVersionObjectReference
+	Specs          []autoscalingv2.MetricSpec
+}
+
+// Key returns the key identifying the target, see stabilization.TargetKey
+func (t Target) Key() string {
+	return stabilization.TargetKey(t.Namespace, t.ScaleTargetRef)
+}
+
+// Recommendation is the outcome of polling a target, the replica count the target should be scaled to based on its
+// metrics at the time of the poll
+type Recommendation struct {
+	Target          Target
+	Time            time.Time
+	CurrentReplicas int32
+	TargetReplicas  int32
+	Metrics         []*metrics.Metric
+}
+
+// ScaleRecommendation returns the recommendation as a scaler.Recommendation, which can be applied to the target with
+// Scaler.Apply
+func (r *Recommendation) ScaleRecommendation() *scaler.Recommendation {
+	return &scaler.Recommendation{
+		Namespace:       r.Target.Namespace,
+		ScaleTargetRef:  r.Target.ScaleTargetRef,
+		CurrentReplicas: r.CurrentReplicas,
+		TargetReplicas:  r.TargetReplicas,
+	}
+}
+
+// Callback is called with the result of each poll of a target. If the poll failed the recommendation is nil, if only
+// some metrics failed both the recommendation and the error are provided. Callbacks for different targets can be
+// called concurrently.
+type Callback func(ctx context.Context, recommendation *Recommendation, err error)
+
+// Poller polls registered targets, each in its own goroutine while the poller is running, calling the Callback with
+// the result of each poll. The Gatherer's ScaleClient and RESTMapper must be set to look up the current replica count
+// and pod selector of each target.
+// Successful polls are made every Interval, randomly extended by up to the Jitter factor of the interval to spread
+// polls of different targets. After a failed poll the Backoff is used, increasing the wait after each consecutive
+// failure until a poll succeeds. If a Stabilizer is provided each recommendation is stabilized for its target.
+// If a HistoryStore is provided alongside the Stabilizer, each target's stabilization history is restored from the
+// store when polling of the target starts and saved to the store after each poll, so history is kept across restarts
+// and handed over between replicas, see RunWithLeaderElection.
+type Poller struct {
+	Gatherer     *k8shorizmetrics.Gatherer
+	Evaluator    *k8shorizmetrics.Evaluator
+	Stabilizer   *stabilization.Stabilizer
+	HistoryStore stabilization.Store
+	Callback     Callback
+	Interval     time.Duration
+	Jitter       float64
+	Backoff      wait.Backoff
+	Clock        clock.Clock
+	Logger       logr.Logger
+
+	mu      sync.Mutex
+	targets map[string]*registration
+	ctx     context.Context
+	wg      sync.WaitGroup
+}
+
+type registration struct {
+	target Target
+	cancel context.CancelFunc
+}
+
+// NewPoller sets up a Poller using the default interval and backoff, calling the callback provided with the result of
+// each poll
+func NewPoller(gatherer *k8shorizmetrics.Gatherer, evaluator *k8shorizmetrics.Evaluator, callback Callback) *Poller {
+	return &Poller{
+		Gatherer:  gatherer,
+		Evaluator: evaluator,
+		Callback:  callback,
+		Interval:  DefaultInterval,
+		Backoff:   DefaultBackoff(),
+		Clock:     clock.RealClock{},
+	}
+}
+
+// Register adds the target to the poller, replacing any target with the same key, and returns the target's key. If
+// the poller is running the target is polled immediately.
+func (p *Poller) Register(target Target) string {
+	key := target.Key()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.targets == nil {
+		p.targets = map[string]*registration{}
+	}
+
+	if existing, ok := p.targets[key]; ok && existing.cancel != nil {
+		existing.cancel()
+	}
+
+	registered := &registration{
+		target: target,
+	}
+	p.targets[key] = registered
+
+	if p.ctx != nil {
+		p.start(key, registered)
+	}
+
+	return key
+}
+
+// Unregister stops polling the target with the key provided and forgets its stabilization history
+func (p *Poller) Unregister(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if registered, ok := p.targets[key]; ok && registered.cancel != nil {
+		registered.cancel()
+	}
+	delete(p.targets, key)
+
+	if p.Stabilizer != nil {
+		p.Stabilizer.Forget(key)
+	}
+}
+
+// Targets returns the keys of the registered targets
+func (p *Poller) Targets() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	keys := make([]string, 0, len(p.targets))
+	for key := range p.targets {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Run polls the registered targets until the context is cancelled, waiting for any polls in progress to finish
+// before returning. A poller can only be run once at a time.
+func (p *Poller) Run(ctx context.Context) error {
+	p.mu.Lock()
+	if p.ctx != nil {
+		p.mu.Unlock()
+		return errors.New("poller is already running")
+	}
+	p.ctx = ctx
+	for key, registered := range p.targets {
+		p.start(key, registered)
+	}
+	p.mu.Unlock()
+
+	<-ctx.Done()
+
+	p.mu.Lock()
+	p.ctx = nil
+	for _, registered := range p.targets {
+		if registered.cancel != nil {
+			registered.cancel()
+			registered.cancel = nil
+		}
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	return nil
+}
+
+// Poll gathers and evaluates the metrics of the target provided, returning the recommended replica count. If some
+// metrics fail the recommendation is evaluated from the remaining metrics, but will not be lower than the current
+// replica count as the missing metrics could require more replicas, and the error is returned alongside the
+// recommendation. Targets with zero replicas are not evaluated and are recommended to stay at zero, in the same way
+// as the HPA.
+func (p *Poller) Poll(ctx context.Context, target Target) (*Recommendation, error) {
+	if p.Gatherer.ScaleClient == nil {
+		return nil, errors.New("failed to get scale target: no scale client configured")
+	}
+
+	if p.Gatherer.RESTMapper == nil {
+		return nil, errors.New("failed to get scale target: no REST mapper configured")
+	}
+
+	now := p.clock().Now()
+
+	scale, err := (&scaler.Scaler{
+		ScaleClient: p.Gatherer.ScaleClient,
+		RESTMapper:  p.Gatherer.RESTMapper,
+	}).GetScale(ctx, target.Namespace, target.ScaleTargetRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scale target: %w", err)
+	}
+
+	currentReplicas := scale.Spec.Replicas
+	if currentReplicas == 0 {
+		return &Recommendation{
+			Target: target,
+			Time:   now,
+		}, nil
+	}
+
+	if scale.Status.Selector == "" {
+		return nil, fmt.Errorf("failed to get scale target selector: selector is required for %s %q",
+			target.ScaleTargetRef.Kind, target.ScaleTargetRef.Name)
+	}
+
+	podSelector, err := labels.Parse(scale.Status.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scale target selector: %w", err)
+	}
+
+	gatheredMetrics, targetReplicas, partialErr := k8shorizmetrics.GatherAndEvaluate(ctx, p.Gatherer, p.Evaluator,
+		target.Specs, target.Namespace, podSelector, currentReplicas, k8shorizmetrics.EvaluateOptions{})
+	if partialErr != nil && !k8shorizmetrics.IsPartial(partialErr) {
+		return nil, partialErr
+	}
+
+	if p.Stabilizer != nil {
+		targetReplicas = p.Stabilizer.Stabilize(target.Key(), targetReplicas)
+	}
+
+	return &Recommendation{
+		Target:          target,
+		Time:            now,
+		CurrentReplicas: currentReplicas,
+		TargetReplicas:  targetReplicas,
+		Metrics:         gatheredMetrics,
+	}, partialErr
+}
+
+// start begins polling the registered target, must be called with the lock held while the poller is running
+func (p *Poller) start(key string, registered *registration) {
+	ctx, cancel := context.WithCancel(p.ctx)
+	registered.cancel = cancel
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.pollTarget(ctx, key, registered.target)
+	}()
+}
+
+func (p *Poller) pollTarget(ctx context.Context, key string, target Target) {
+	log := p.Logger.WithValues("target", key)
+	pollClock := p.clock()
+
+	p.restoreHistory(ctx, log, key)
+
+	backoff := p.backoff()
+	for {
+		recommendation, err := p.Poll(ctx, target)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if recommendation != nil {
+			p.saveHistory(ctx, log, key)
+		}
+
+		if p.Callback != nil {
+			p.Callback(ctx, recommendation, err)
+		}
+
+		var wait time.Duration
+		if recommendation == nil {
+			wait = backoff.Step()
+			log.V(k8shorizmetrics.LogLevelDebug).Info("Backing off polling target", "wait", wait,
+				"error", err.Error())
+		} else {
+			backoff = p.backoff()
+			wait = p.interval()
+		}
+
+		timer := pollClock.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+	}
+}
+
+func (p *Poller) restoreHistory(ctx context.Context, log logr.Logger, key string) {
+	if p.Stabilizer == nil || p.HistoryStore == nil {
+		return
+	}
+
+	history, err := p.HistoryStore.Load(ctx, key)
+	if err != nil {
+		log.Error(err, "Failed to restore stabilization history")
+		return
+	}
+
+	p.Stabilizer.SetHistory(key, history)
+}
+
+func (p *Poller) saveHistory(ctx context.Context, log logr.Logger, key string) {
+	if p.Stabilizer == nil || p.HistoryStore == nil {
+		return
+	}
+
+	if err := p.HistoryStore.Save(ctx, key, p.Stabilizer.History(key)); err != nil {
+		log.Error(err, "Failed to save stabilization history")
+	}
+}
+
+func (p *Poller) interval() time.Duration {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if p.Jitter > 0 {
+		interval = wait.Jitter(interval, p.Jitter)
+	}
+	return interval
+}
+
+func (p *Poller) backoff() wait.Backoff {
+	if p.Backoff.Duration <= 0 {
+		return DefaultBackoff()
+	}
+	return p.Backoff
+}
+
+func (p *Poller) clock() clock.Clock {
+	if p.Clock == nil {
+		return clock.RealClock{}
+	}
+	return p.Clock
+}
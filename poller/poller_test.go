@@ -0,0 +1,420 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poller_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/poller"
+	"github.com/jthomperoo/k8shorizmetrics/v4/stabilization"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	fakescale "k8s.io/client-go/scale/fake"
+	k8stesting "k8s.io/client-go/testing"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+var now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func podsSpec(name string) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: name,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+}
+
+func podsMetric(name string) *metrics.Metric {
+	return &metrics.Metric{
+		Spec: podsSpec(name),
+		Pods: &podsmetrics.Metric{},
+	}
+}
+
+func target(name string, specs ...autoscalingv2.MetricSpec) poller.Target {
+	return poller.Target{
+		Namespace: "test-namespace",
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       name,
+		},
+		Specs: specs,
+	}
+}
+
+// newPoller sets up a poller for deployments with the scales provided, keyed by name. Pods metrics are gathered
+// unless they have an error in the gather errors provided and each evaluates to the replica count in the evaluations
+// provided.
+func newPoller(scales func(name string) (*autoscalingv1.Scale, error), gatherErrs map[string]error,
+	evaluations map[string]int32) *poller.Poller {
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	scaleClient := &fakescale.FakeScaleClient{}
+	scaleClient.AddReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		scale, err := scales(action.(k8stesting.GetAction).GetName())
+		return true, scale, err
+	})
+
+	gatherer := &k8shorizmetrics.Gatherer{
+		Pods: &fake.PodsGatherer{
+			GatherReactor: func(metricName, namespace string, selector,
+				metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+				if err, ok := gatherErrs[metricName]; ok {
+					return nil, err
+				}
+				return &podsmetrics.Metric{}, nil
+			},
+		},
+		ScaleClient: scaleClient,
+		RESTMapper:  restMapper,
+	}
+
+	evaluator := &k8shorizmetrics.Evaluator{
+		Pods: &fake.PodsEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+				return evaluations[gatheredMetric.Spec.Pods.Metric.Name]
+			},
+		},
+	}
+
+	p := poller.NewPoller(gatherer, evaluator, nil)
+	p.Clock = clocktesting.NewFakeClock(now)
+	return p
+}
+
+func newScale(replicas int32) *autoscalingv1.Scale {
+	return &autoscalingv1.Scale{
+		Spec:   autoscalingv1.ScaleSpec{Replicas: replicas},
+		Status: autoscalingv1.ScaleStatus{Selector: "app=test"},
+	}
+}
+
+func TestPollerPoll(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description   string
+		expected      *poller.Recommendation
+		expectedErr   error
+		scale         *autoscalingv1.Scale
+		scaleErr      error
+		target        poller.Target
+		gatherErrs    map[string]error
+		evaluations   map[string]int32
+		stabilizer    *stabilization.Stabilizer
+		noScaleClient bool
+	}{
+		{
+			description:   "Fail, no scale client",
+			expectedErr:   errors.New("failed to get scale target: no scale client configured"),
+			target:        target("test-deployment", podsSpec("a")),
+			noScaleClient: true,
+		},
+		{
+			description: "Fail, scale subresource error",
+			expectedErr: errors.New("failed to get scale target: failed to get scale subresource: scale error"),
+			scaleErr:    errors.New("scale error"),
+			target:      target("test-deployment", podsSpec("a")),
+		},
+		{
+			description: "Fail, empty selector",
+			expectedErr: errors.New(`failed to get scale target selector: selector is required for Deployment "test-deployment"`),
+			scale: &autoscalingv1.Scale{
+				Spec: autoscalingv1.ScaleSpec{Replicas: 3},
+			},
+			target: target("test-deployment", podsSpec("a")),
+		},
+		{
+			description: "Fail, all metrics fail to gather",
+			expectedErr: errors.New("failed to gather metrics: gatherer multi metric error: 1 errors, first error is " +
+				"failed to get pods metric: gather error"),
+			scale:      newScale(3),
+			target:     target("test-deployment", podsSpec("a")),
+			gatherErrs: map[string]error{"a": errors.New("gather error")},
+		},
+		{
+			description: "Success, target scaled to zero is not evaluated",
+			expected: &poller.Recommendation{
+				Target: target("test-deployment", podsSpec("a")),
+				Time:   now,
+			},
+			scale:       newScale(0),
+			target:      target("test-deployment", podsSpec("a")),
+			evaluations: map[string]int32{"a": 5},
+		},
+		{
+			description: "Partial, does not scale down while metrics are missing",
+			expected: &poller.Recommendation{
+				Target:          target("test-deployment", podsSpec("a"), podsSpec("b")),
+				Time:            now,
+				CurrentReplicas: 3,
+				TargetReplicas:  3,
+				Metrics:         []*metrics.Metric{podsMetric("b")},
+			},
+			expectedErr: errors.New("gatherer multi metric error: 1 errors, first error is failed to get pods metric: " +
+				"gather error"),
+			scale:       newScale(3),
+			target:      target("test-deployment", podsSpec("a"), podsSpec("b")),
+			gatherErrs:  map[string]error{"a": errors.New("gather error")},
+			evaluations: map[string]int32{"b": 1},
+		},
+		{
+			description: "Success",
+			expected: &poller.Recommendation{
+				Target:          target("test-deployment", podsSpec("a"), podsSpec("b")),
+				Time:            now,
+				CurrentReplicas: 3,
+				TargetReplicas:  5,
+				Metrics:         []*metrics.Metric{podsMetric("a"), podsMetric("b")},
+			},
+			scale:       newScale(3),
+			target:      target("test-deployment", podsSpec("a"), podsSpec("b")),
+			evaluations: map[string]int32{"a": 5, "b": 2},
+		},
+		{
+			description: "Success, stabilized",
+			expected: &poller.Recommendation{
+				Target:          target("test-deployment", podsSpec("a")),
+				Time:            now,
+				CurrentReplicas: 3,
+				TargetReplicas:  4,
+				Metrics:         []*metrics.Metric{podsMetric("a")},
+			},
+			scale:       newScale(3),
+			target:      target("test-deployment", podsSpec("a")),
+			evaluations: map[string]int32{"a": 1},
+			stabilizer: func() *stabilization.Stabilizer {
+				stabilizer := stabilization.NewStabilizer()
+				stabilizer.Clock = clocktesting.NewFakePassiveClock(now)
+				stabilizer.Stabilize(target("test-deployment").Key(), 4)
+				return stabilizer
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			p := newPoller(func(name string) (*autoscalingv1.Scale, error) {
+				return test.scale, test.scaleErr
+			}, test.gatherErrs, test.evaluations)
+			p.Stabilizer = test.stabilizer
+			if test.noScaleClient {
+				p.Gatherer.ScaleClient = nil
+			}
+
+			result, err := p.Poll(context.Background(), test.target)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("recommendation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+type poll struct {
+	Key            string
+	Time           time.Time
+	TargetReplicas int32
+	Err            string
+}
+
+// runPoller runs the poller provided until stop returns true for the polls recorded so far, moving the clock forward
+// by the step provided whenever the poller is waiting
+func runPoller(t *testing.T, p *poller.Poller, step time.Duration, stop func(polls []poll) bool,
+	whileRunning func()) []poll {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fakeClock := p.Clock.(*clocktesting.FakeClock)
+
+	var mu sync.Mutex
+	polls := []poll{}
+	p.Callback = func(ctx context.Context, recommendation *poller.Recommendation, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		result := poll{Time: fakeClock.Now()}
+		if recommendation != nil {
+			result.Key = recommendation.Target.Key()
+			result.TargetReplicas = recommendation.TargetReplicas
+		}
+		if err != nil {
+			result.Err = err.Error()
+		}
+		polls = append(polls, result)
+		if stop(polls) {
+			cancel()
+		}
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- p.Run(ctx)
+	}()
+
+	if whileRunning != nil {
+		whileRunning()
+	}
+
+	for ctx.Err() == nil {
+		if fakeClock.HasWaiters() {
+			fakeClock.Step(step)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	return polls
+}
+
+func TestPollerRun(t *testing.T) {
+	t.Run("Poll on interval and back off after failures", func(t *testing.T) {
+		scaleCalls := 0
+		p := newPoller(func(name string) (*autoscalingv1.Scale, error) {
+			scaleCalls++
+			if scaleCalls <= 3 {
+				return nil, errors.New("scale error")
+			}
+			return newScale(2), nil
+		}, nil, map[string]int32{"a": 4})
+		p.Interval = time.Minute
+		p.Backoff = wait.Backoff{
+			Duration: 10 * time.Second,
+			Factor:   2,
+			Steps:    10,
+		}
+		key := p.Register(target("test-deployment", podsSpec("a")))
+
+		polls := runPoller(t, p, 10*time.Second, func(polls []poll) bool {
+			return len(polls) == 5
+		}, nil)
+
+		scaleErr := "failed to get scale target: failed to get scale subresource: scale error"
+		expected := []poll{
+			{Time: now, Err: scaleErr},
+			{Time: now.Add(10 * time.Second), Err: scaleErr},
+			{Time: now.Add(30 * time.Second), Err: scaleErr},
+			{Key: key, Time: now.Add(70 * time.Second), TargetReplicas: 4},
+			{Key: key, Time: now.Add(130 * time.Second), TargetReplicas: 4},
+		}
+		if !cmp.Equal(expected, polls) {
+			t.Errorf("polls mismatch (-want +got):\n%s", cmp.Diff(expected, polls))
+		}
+	})
+
+	t.Run("Register and unregister while running", func(t *testing.T) {
+		p := newPoller(func(name string) (*autoscalingv1.Scale, error) {
+			return newScale(2), nil
+		}, nil, map[string]int32{"a": 4})
+		p.Interval = time.Minute
+		first := p.Register(target("first", podsSpec("a")))
+
+		second := target("second", podsSpec("a")).Key()
+		polls := runPoller(t, p, time.Minute, func(polls []poll) bool {
+			secondPolls := 0
+			for _, poll := range polls {
+				if poll.Key == second {
+					secondPolls++
+				}
+			}
+			return secondPolls == 2
+		}, func() {
+			p.Unregister(first)
+			p.Register(target("second", podsSpec("a")))
+		})
+
+		for _, poll := range polls {
+			if poll.Key != first && poll.Key != second {
+				t.Errorf("unexpected poll of %s", poll.Key)
+			}
+		}
+		if polls[len(polls)-1].Key != second {
+			t.Errorf("expected second target to be polled last, got %s", polls[len(polls)-1].Key)
+		}
+
+		expectedTargets := []string{second}
+		if !cmp.Equal(expectedTargets, p.Targets()) {
+			t.Errorf("targets mismatch (-want +got):\n%s", cmp.Diff(expectedTargets, p.Targets()))
+		}
+	})
+
+	t.Run("Fail, already running", func(t *testing.T) {
+		p := newPoller(func(name string) (*autoscalingv1.Scale, error) {
+			return newScale(2), nil
+		}, nil, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			_ = p.Run(ctx)
+			close(done)
+		}()
+
+		// Keep trying to run with a cancelled context, which returns immediately if the first run has not started yet
+		cancelled, cancelCancelled := context.WithCancel(context.Background())
+		cancelCancelled()
+		for {
+			err := p.Run(cancelled)
+			if err == nil {
+				time.Sleep(time.Millisecond)
+				continue
+			}
+			if err.Error() != "poller is already running" {
+				t.Errorf("unexpected error: %v", err)
+			}
+			break
+		}
+
+		cancel()
+		<-done
+	})
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictive
+
+import (
+	"time"
+)
+
+// Linear predicts replica counts using a least squares linear regression over the timestamps and replica counts of
+// the last Size samples, extrapolating the trend LookAhead into the future. At least 2 samples are needed to make a
+// prediction.
+type Linear struct {
+	Size      int
+	LookAhead time.Duration
+}
+
+// HistorySize returns the number of samples used for the regression
+func (l *Linear) HistorySize() int {
+	return l.Size
+}
+
+// Predict returns the replica count predicted LookAhead into the future
+func (l *Linear) Predict(now time.Time, history []Sample) (float64, bool) {
+	if len(history) < 2 {
+		return 0, false
+	}
+
+	// Fit replicas = intercept + slope * x, where x is the number of seconds relative to now
+	n := float64(len(history))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, sample := range history {
+		x := sample.Timestamp.Sub(now).Seconds()
+		y := float64(sample.Replicas)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		// All samples at the same time, no trend can be calculated
+		return sumY / n, true
+	}
+
+	slope := (n*sumXY - sumX*sumY) / denominator
+	intercept := (sumY - slope*sumX) / n
+
+	return intercept + slope*l.LookAhead.Seconds(), true
+}
+
+// HoltWintersMethod is the method used to model seasonality with Holt-Winters
+type HoltWintersMethod string
+
+const (
+	// HoltWintersAdditive models seasonality as a fixed amount added to the level, suited to seasonal variation that
+	// is roughly constant
+	HoltWintersAdditive HoltWintersMethod = "additive"
+	// HoltWintersMultiplicative models seasonality as a proportion of the level, suited to seasonal variation that
+	// grows with the level
+	HoltWintersMultiplicative HoltWintersMethod = "multiplicative"
+)
+
+// HoltWinters predicts replica counts using triple exponential smoothing, modelling the level, trend and seasonality
+// of the replica counts. Samples are treated as evenly spaced, so should be recorded at a regular interval such as
+// every evaluation. SeasonLength is the number of samples in a season and StoredSeasons is the number of seasons of
+// history kept, at least 2 full seasons are needed to make a prediction. Alpha, Beta and Gamma are the smoothing
+// factors for the level, trend and seasonality, between 0 and 1. Steps is the number of samples ahead to predict, if
+// 0 the next sample is predicted.
+type HoltWinters struct {
+	Alpha         float64
+	Beta          float64
+	Gamma         float64
+	SeasonLength  int
+	StoredSeasons int
+	Method        HoltWintersMethod
+	Steps         int
+}
+
+// HistorySize returns the number of samples in the stored seasons
+func (h *HoltWinters) HistorySize() int {
+	return h.SeasonLength * h.StoredSeasons
+}
+
+// Predict returns the replica count predicted Steps samples into the future
+func (h *HoltWinters) Predict(now time.Time, history []Sample) (float64, bool) {
+	seasonLength := h.SeasonLength
+	if seasonLength < 1 || len(history) < 2*seasonLength {
+		return 0, false
+	}
+
+	// Only use full seasons
+	seasons := len(history) / seasonLength
+	values := make([]float64, seasons*seasonLength)
+	for i, sample := range history[len(history)-len(values):] {
+		values[i] = float64(sample.Replicas)
+	}
+
+	multiplicative := h.Method == HoltWintersMultiplicative
+
+	// Initialise the level as the average of the first season and the trend as the average change between the first
+	// two seasons
+	var level, trend float64
+	for i := 0; i < seasonLength; i++ {
+		level += values[i]
+		trend += (values[seasonLength+i] - values[i]) / float64(seasonLength)
+	}
+	level /= float64(seasonLength)
+	trend /= float64(seasonLength)
+
+	// Initialise the seasonal components as the average deviation of each point in the season from its season's
+	// average
+	seasonAverages := make([]float64, seasons)
+	for season := 0; season < seasons; season++ {
+		for i := 0; i < seasonLength; i++ {
+			seasonAverages[season] += values[season*seasonLength+i]
+		}
+		seasonAverages[season] /= float64(seasonLength)
+		if multiplicative && seasonAverages[season] == 0 {
+			return 0, false
+		}
+	}
+	seasonals := make([]float64, seasonLength)
+	for i := 0; i < seasonLength; i++ {
+		for season := 0; season < seasons; season++ {
+			if multiplicative {
+				seasonals[i] += values[season*seasonLength+i] / seasonAverages[season]
+			} else {
+				seasonals[i] += values[season*seasonLength+i] - seasonAverages[season]
+			}
+		}
+		seasonals[i] /= float64(seasons)
+	}
+
+	// Smooth the level, trend and seasonal components over the history
+	for i, value := range values {
+		lastLevel := level
+		seasonal := seasonals[i%seasonLength]
+		if multiplicative {
+			if seasonal == 0 {
+				return 0, false
+			}
+			level = h.Alpha*(value/seasonal) + (1-h.Alpha)*(level+trend)
+		} else {
+			level = h.Alpha*(value-seasonal) + (1-h.Alpha)*(level+trend)
+		}
+		trend = h.Beta*(level-lastLevel) + (1-h.Beta)*trend
+		if multiplicative {
+			if level == 0 {
+				return 0, false
+			}
+			seasonals[i%seasonLength] = h.Gamma*(value/level) + (1-h.Gamma)*seasonal
+		} else {
+			seasonals[i%seasonLength] = h.Gamma*(value-level) + (1-h.Gamma)*seasonal
+		}
+	}
+
+	steps := max(h.Steps, 1)
+	seasonal := seasonals[(len(values)+steps-1)%seasonLength]
+	if multiplicative {
+		return (level + float64(steps)*trend) * seasonal, true
+	}
+	return level + float64(steps)*trend + seasonal, true
+}
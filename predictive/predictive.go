@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package predictive provides predictive replica count recommendations, keeping a short history of the replica counts
+// evaluated for each target and forecasting the replica count the target will need using models such as linear
+// regression and Holt-Winters. This allows targets to be scaled ahead of ramping load, rather than purely reacting to
+// it.
+//
+// Predictions never recommend fewer replicas than the current evaluation, predictions only allow scaling up earlier.
+package predictive
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"k8s.io/utils/clock"
+)
+
+// Sample is a replica count evaluated for a target at a point in time
+type Sample struct {
+	Timestamp time.Time `json:"timestamp"`
+	Replicas  int32     `json:"replicas"`
+}
+
+// Model predicts a replica count from the history of a target
+type Model interface {
+	// Predict returns the predicted replica count using the history provided, oldest sample first. If there is not
+	// enough history to make a prediction false is returned.
+	Predict(now time.Time, history []Sample) (float64, bool)
+	// HistorySize returns the number of samples the model uses to make a prediction
+	HistorySize() int
+}
+
+// Predictor keeps the history of evaluated replica counts for each target, keyed by a string identifying the target,
+// and predicts replica counts using the Models provided. The history kept for each target is the largest history size
+// of the Models. If there are multiple models the highest prediction is used.
+type Predictor struct {
+	Models []Model
+	Clock  clock.PassiveClock
+
+	mu      sync.Mutex
+	history map[string][]Sample
+}
+
+// NewPredictor sets up a Predictor using the models provided
+func NewPredictor(models ...Model) *Predictor {
+	return &Predictor{
+		Models: models,
+		Clock:  clock.RealClock{},
+	}
+}
+
+// Record records a replica count evaluated for the target, discarding the oldest samples beyond the history size of
+// the models
+func (p *Predictor) Record(key string, replicas int32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.history == nil {
+		p.history = map[string][]Sample{}
+	}
+
+	history := append(p.history[key], Sample{
+		Timestamp: p.Clock.Now(),
+		Replicas:  replicas,
+	})
+
+	historySize := 0
+	for _, model := range p.Models {
+		historySize = max(historySize, model.HistorySize())
+	}
+	if len(history) > historySize {
+		history = history[len(history)-historySize:]
+	}
+
+	p.history[key] = history
+}
+
+// Predict returns the highest replica count predicted by the models for the target, rounded up. If none of the models
+// have enough history to make a prediction false is returned.
+func (p *Predictor) Predict(key string) (int32, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.Clock.Now()
+	history := p.history[key]
+
+	predicted := false
+	var prediction float64
+	for _, model := range p.Models {
+		modelHistory := history
+		if len(modelHistory) > model.HistorySize() {
+			modelHistory = modelHistory[len(modelHistory)-model.HistorySize():]
+		}
+
+		modelPrediction, ok := model.Predict(now, modelHistory)
+		if !ok {
+			continue
+		}
+		if !predicted || modelPrediction > prediction {
+			prediction = modelPrediction
+			predicted = true
+		}
+	}
+
+	if !predicted {
+		return 0, false
+	}
+
+	return int32(max(math.Ceil(prediction), 0)), true
+}
+
+// History returns the samples recorded for the target, oldest first
+func (p *Predictor) History(key string) []Sample {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	history := make([]Sample, len(p.history[key]))
+	copy(history, p.history[key])
+	return history
+}
+
+// Forget discards the samples recorded for the target, for example when the target is deleted
+func (p *Predictor) Forget(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.history, key)
+}
+
+// Evaluator wraps an Evaluator, recording each evaluation with the Predictor and scaling ahead of the evaluation if
+// the Predictor predicts more replicas will be needed
+type Evaluator struct {
+	Evaluator *k8shorizmetrics.Evaluator
+	Predictor *Predictor
+}
+
+// Evaluate returns the target replica count for the target identified by the key provided, the higher of the replica
+// count evaluated from the metrics provided and the predicted replica count
+func (e *Evaluator) Evaluate(key string, gatheredMetrics []*metrics.Metric, currentReplicas int32) (int32, error) {
+	return e.EvaluateWithContext(context.Background(), key, gatheredMetrics, currentReplicas)
+}
+
+// EvaluateWithContext returns the target replica count for the target identified by the key provided, the higher of
+// the replica count evaluated from the metrics provided and the predicted replica count, passing the context provided
+// to the Evaluator
+func (e *Evaluator) EvaluateWithContext(ctx context.Context, key string, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32) (int32, error) {
+	evaluation, err := e.Evaluator.EvaluateWithContext(ctx, gatheredMetrics, currentReplicas)
+	if err != nil {
+		return 0, err
+	}
+
+	e.Predictor.Record(key, evaluation)
+
+	prediction, ok := e.Predictor.Predict(key)
+	if !ok || prediction < evaluation {
+		return evaluation, nil
+	}
+
+	return prediction, nil
+}
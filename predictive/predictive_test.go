@@ -0,0 +1,310 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictive_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/predictive"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+var now = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func samples(interval time.Duration, replicas ...int32) []predictive.Sample {
+	history := make([]predictive.Sample, len(replicas))
+	for i, replicaCount := range replicas {
+		history[i] = predictive.Sample{
+			Timestamp: now.Add(-time.Duration(len(replicas)-1-i) * interval),
+			Replicas:  replicaCount,
+		}
+	}
+	return history
+}
+
+func TestModels_Predict(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    float64
+		expectedOk  bool
+		model       predictive.Model
+		history     []predictive.Sample
+	}{
+		{
+			description: "Linear, not enough history",
+			expected:    0,
+			expectedOk:  false,
+			model: &predictive.Linear{
+				Size:      5,
+				LookAhead: time.Minute,
+			},
+			history: samples(time.Minute, 3),
+		},
+		{
+			description: "Linear, increasing trend",
+			expected:    8,
+			expectedOk:  true,
+			model: &predictive.Linear{
+				Size:      5,
+				LookAhead: time.Minute,
+			},
+			history: samples(time.Minute, 2, 4, 6),
+		},
+		{
+			description: "Linear, flat",
+			expected:    3,
+			expectedOk:  true,
+			model: &predictive.Linear{
+				Size:      5,
+				LookAhead: 10 * time.Minute,
+			},
+			history: samples(time.Minute, 3, 3, 3, 3),
+		},
+		{
+			description: "Linear, samples at the same time",
+			expected:    4,
+			expectedOk:  true,
+			model: &predictive.Linear{
+				Size:      5,
+				LookAhead: time.Minute,
+			},
+			history: samples(0, 2, 6),
+		},
+		{
+			description: "Holt-Winters, less than 2 seasons of history",
+			expected:    0,
+			expectedOk:  false,
+			model: &predictive.HoltWinters{
+				Alpha:         0.5,
+				Beta:          0.1,
+				Gamma:         0.5,
+				SeasonLength:  2,
+				StoredSeasons: 3,
+				Method:        predictive.HoltWintersAdditive,
+			},
+			history: samples(time.Minute, 1, 5, 1),
+		},
+		{
+			description: "Holt-Winters additive, next in season",
+			expected:    1,
+			expectedOk:  true,
+			model: &predictive.HoltWinters{
+				Alpha:         0.5,
+				Beta:          0.1,
+				Gamma:         0.5,
+				SeasonLength:  2,
+				StoredSeasons: 3,
+				Method:        predictive.HoltWintersAdditive,
+			},
+			history: samples(time.Minute, 1, 5, 1, 5, 1, 5),
+		},
+		{
+			description: "Holt-Winters additive, two steps ahead",
+			expected:    5,
+			expectedOk:  true,
+			model: &predictive.HoltWinters{
+				Alpha:         0.5,
+				Beta:          0.1,
+				Gamma:         0.5,
+				SeasonLength:  2,
+				StoredSeasons: 3,
+				Method:        predictive.HoltWintersAdditive,
+				Steps:         2,
+			},
+			history: samples(time.Minute, 1, 5, 1, 5, 1, 5),
+		},
+		{
+			description: "Holt-Winters multiplicative, next in season",
+			expected:    1,
+			expectedOk:  true,
+			model: &predictive.HoltWinters{
+				Alpha:         0.5,
+				Beta:          0.1,
+				Gamma:         0.5,
+				SeasonLength:  2,
+				StoredSeasons: 3,
+				Method:        predictive.HoltWintersMultiplicative,
+			},
+			history: samples(time.Minute, 1, 5, 1, 5, 1, 5),
+		},
+		{
+			description: "Holt-Winters multiplicative, zero season average",
+			expected:    0,
+			expectedOk:  false,
+			model: &predictive.HoltWinters{
+				Alpha:         0.5,
+				Beta:          0.1,
+				Gamma:         0.5,
+				SeasonLength:  2,
+				StoredSeasons: 2,
+				Method:        predictive.HoltWintersMultiplicative,
+			},
+			history: samples(time.Minute, 0, 0, 1, 5),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, ok := test.model.Predict(now, test.history)
+			if ok != test.expectedOk {
+				t.Errorf("ok mismatch, want %t, got %t", test.expectedOk, ok)
+			}
+			if !cmp.Equal(test.expected, result, cmpopts.EquateApprox(0, 1e-9)) {
+				t.Errorf("prediction mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestPredictor(t *testing.T) {
+	fakeClock := clocktesting.NewFakePassiveClock(now)
+	predictor := &predictive.Predictor{
+		Models: []predictive.Model{
+			&predictive.Linear{
+				Size:      3,
+				LookAhead: time.Minute,
+			},
+			&predictive.Linear{
+				Size:      2,
+				LookAhead: 2 * time.Minute,
+			},
+		},
+		Clock: fakeClock,
+	}
+
+	if _, ok := predictor.Predict("test"); ok {
+		t.Errorf("expected no prediction without history")
+	}
+
+	for i, replicas := range []int32{10, 2, 4, 6} {
+		fakeClock.SetTime(now.Add(time.Duration(i) * time.Minute))
+		predictor.Record("test", replicas)
+	}
+
+	// History is limited to the largest model history size
+	expectedHistory := []predictive.Sample{
+		{Timestamp: now.Add(time.Minute), Replicas: 2},
+		{Timestamp: now.Add(2 * time.Minute), Replicas: 4},
+		{Timestamp: now.Add(3 * time.Minute), Replicas: 6},
+	}
+	if history := predictor.History("test"); !cmp.Equal(expectedHistory, history) {
+		t.Errorf("history mismatch (-want +got):\n%s", cmp.Diff(expectedHistory, history))
+	}
+
+	// First model predicts 8, second predicts 10 using only the last 2 samples, highest is used
+	prediction, ok := predictor.Predict("test")
+	if !ok || prediction != 10 {
+		t.Errorf("prediction mismatch, want 10, got %d (ok %t)", prediction, ok)
+	}
+
+	predictor.Forget("test")
+	if history := predictor.History("test"); len(history) != 0 {
+		t.Errorf("expected history to be forgotten, got %v", history)
+	}
+}
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	gatheredMetrics := []*metrics.Metric{
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+			},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expected    []int32
+		expectedErr error
+		evaluations []int32
+		err         error
+	}{
+		{
+			description: "Fail to evaluate",
+			expected:    []int32{0},
+			expectedErr: errors.New("evaluator multi metric error: 1 errors, first error is fail to evaluate"),
+			evaluations: []int32{0},
+			err:         errors.New("fail to evaluate"),
+		},
+		{
+			description: "Increasing load, scale ahead of evaluation",
+			expected:    []int32{2, 6, 8},
+			expectedErr: nil,
+			evaluations: []int32{2, 4, 6},
+		},
+		{
+			description: "Decreasing load, never below evaluation",
+			expected:    []int32{6, 4, 2},
+			expectedErr: nil,
+			evaluations: []int32{6, 4, 2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakePassiveClock(now)
+			evaluation := 0
+			evaluator := &predictive.Evaluator{
+				Evaluator: &k8shorizmetrics.Evaluator{
+					Resource: &fake.ResourceEvaluater{
+						EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+							replicas := test.evaluations[evaluation]
+							evaluation++
+							return replicas, test.err
+						},
+					},
+				},
+				Predictor: &predictive.Predictor{
+					Models: []predictive.Model{
+						&predictive.Linear{
+							Size:      5,
+							LookAhead: time.Minute,
+						},
+					},
+					Clock: fakeClock,
+				},
+			}
+
+			var err error
+			for i := range test.evaluations {
+				fakeClock.SetTime(now.Add(time.Duration(i) * time.Minute))
+				var result int32
+				result, err = evaluator.Evaluate("test", gatheredMetrics, 2)
+				if err == nil && result != test.expected[i] {
+					t.Errorf("evaluation %d mismatch, want %d, got %d", i, test.expected[i], result)
+				}
+			}
+
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+			}
+		})
+	}
+}
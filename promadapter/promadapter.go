@@ -0,0 +1,252 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package promadapter simulates the Prometheus Adapter's metric discovery, parsing the adapter's rule configuration
+// and applying it to a set of Prometheus series to determine which custom and external metric names would be exposed
+// and which Kubernetes resources they would be associated with. This allows metric specs to be validated against an
+// adapter configuration before deploying changes to the adapter.
+package promadapter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the Prometheus Adapter rule configuration, resource rules are not included as resource metrics are not
+// discovered from series
+type Config struct {
+	Rules         []Rule `json:"rules,omitempty"`
+	ExternalRules []Rule `json:"externalRules,omitempty"`
+}
+
+// Rule describes how to discover metrics from Prometheus series, how to name them and how to associate them with
+// Kubernetes resources
+type Rule struct {
+	SeriesQuery   string          `json:"seriesQuery"`
+	SeriesFilters []RegexFilter   `json:"seriesFilters,omitempty"`
+	Resources     ResourceMapping `json:"resources,omitempty"`
+	Name          NameMapping     `json:"name,omitempty"`
+	MetricsQuery  string          `json:"metricsQuery,omitempty"`
+}
+
+// RegexFilter filters discovered series names, if Is is set the name must match it, if IsNot is set the name must
+// not match it
+type RegexFilter struct {
+	Is    string `json:"is,omitempty"`
+	IsNot string `json:"isNot,omitempty"`
+}
+
+// ResourceMapping maps series labels to Kubernetes resources, either explicitly using overrides keyed by label name
+// or using a template such as "kube_<<.Group>>_<<.Resource>>"
+type ResourceMapping struct {
+	Template  string                   `json:"template,omitempty"`
+	Overrides map[string]GroupResource `json:"overrides,omitempty"`
+}
+
+// GroupResource is a Kubernetes resource that a series label is mapped to
+type GroupResource struct {
+	Group    string `json:"group,omitempty"`
+	Resource string `json:"resource"`
+}
+
+// NameMapping converts a series name into a metric name, Matches is a regular expression applied to the series name
+// and As is the expansion of the match used as the metric name
+type NameMapping struct {
+	Matches string `json:"matches,omitempty"`
+	As      string `json:"as,omitempty"`
+}
+
+// Series is a Prometheus series that exists, identified by its name and labels
+type Series struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ExposedMetric is a metric the adapter would expose, along with the resources it would be associated with and the
+// series it is discovered from
+type ExposedMetric struct {
+	Name      string   `json:"name"`
+	Resources []string `json:"resources,omitempty"`
+	Series    []string `json:"series"`
+}
+
+// Simulation is the result of applying an adapter configuration to a set of series
+type Simulation struct {
+	Custom   []ExposedMetric `json:"custom,omitempty"`
+	External []ExposedMetric `json:"external,omitempty"`
+}
+
+// Parse parses a Prometheus Adapter configuration provided as either YAML or JSON
+func Parse(data []byte) (*Config, error) {
+	config := &Config{}
+	err := yaml.UnmarshalStrict(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid adapter configuration: %w", err)
+	}
+	return config, nil
+}
+
+// Simulate applies the adapter configuration's rules to the series provided, returning the custom and external
+// metrics that the adapter would expose
+func (c *Config) Simulate(series []Series) (*Simulation, error) {
+	custom, err := simulateRules(c.Rules, series)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule: %w", err)
+	}
+
+	external, err := simulateRules(c.ExternalRules, series)
+	if err != nil {
+		return nil, fmt.Errorf("invalid external rule: %w", err)
+	}
+
+	return &Simulation{
+		Custom:   custom,
+		External: external,
+	}, nil
+}
+
+// Validate checks that the metric spec provided would resolve against the simulated adapter, pods and object metrics
+// must be exposed as custom metrics associated with the pod or described object resource and external metrics must
+// be exposed as external metrics. Resource and container resource metrics are not served by the adapter's discovery
+// rules so are always valid.
+func (s *Simulation) Validate(spec autoscalingv2.MetricSpec) error {
+	switch spec.Type {
+	case autoscalingv2.PodsMetricSourceType:
+		if spec.Pods == nil {
+			return fmt.Errorf("invalid pods metric source: pods is nil")
+		}
+		return validateResource(s.Custom, spec.Pods.Metric.Name, "pod")
+	case autoscalingv2.ObjectMetricSourceType:
+		if spec.Object == nil {
+			return fmt.Errorf("invalid object metric source: object is nil")
+		}
+		return validateResource(s.Custom, spec.Object.Metric.Name, spec.Object.DescribedObject.Kind)
+	case autoscalingv2.ExternalMetricSourceType:
+		if spec.External == nil {
+			return fmt.Errorf("invalid external metric source: external is nil")
+		}
+		if findMetric(s.External, spec.External.Metric.Name) == nil {
+			return fmt.Errorf("external metric %q is not exposed by any external rule", spec.External.Metric.Name)
+		}
+		return nil
+	case autoscalingv2.ResourceMetricSourceType, autoscalingv2.ContainerResourceMetricSourceType:
+		return nil
+	default:
+		return fmt.Errorf("unknown metric source type %q", string(spec.Type))
+	}
+}
+
+// ValidateAll checks that each of the metric specs provided would resolve against the simulated adapter, returning
+// all of the validation errors joined together
+func (s *Simulation) ValidateAll(specs []autoscalingv2.MetricSpec) error {
+	var validationErrors []error
+	for _, spec := range specs {
+		err := s.Validate(spec)
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+	return errors.Join(validationErrors...)
+}
+
+func validateResource(exposed []ExposedMetric, metricName string, kind string) error {
+	metric := findMetric(exposed, metricName)
+	if metric == nil {
+		return fmt.Errorf("custom metric %q is not exposed by any rule", metricName)
+	}
+
+	kind = strings.ToLower(kind)
+	for _, resource := range metric.Resources {
+		resource = strings.ToLower(resource)
+		if resource == kind || resource == kind+"s" || resource == kind+"es" {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("custom metric %q is not associated with resource %q", metricName, kind)
+}
+
+func findMetric(exposed []ExposedMetric, metricName string) *ExposedMetric {
+	for i := range exposed {
+		if exposed[i].Name == metricName {
+			return &exposed[i]
+		}
+	}
+	return nil
+}
+
+func simulateRules(rules []Rule, series []Series) ([]ExposedMetric, error) {
+	exposedByName := map[string]*ExposedMetric{}
+
+	for i, rule := range rules {
+		compiled, err := compileRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+
+		for _, singleSeries := range series {
+			metricName, resources, ok := compiled.apply(singleSeries)
+			if !ok {
+				continue
+			}
+
+			exposed, exists := exposedByName[metricName]
+			if !exists {
+				exposed = &ExposedMetric{
+					Name: metricName,
+				}
+				exposedByName[metricName] = exposed
+			}
+
+			exposed.Resources = appendUnique(exposed.Resources, resources...)
+			exposed.Series = appendUnique(exposed.Series, singleSeries.Name)
+		}
+	}
+
+	var exposed []ExposedMetric
+	for _, metric := range exposedByName {
+		sort.Strings(metric.Resources)
+		sort.Strings(metric.Series)
+		exposed = append(exposed, *metric)
+	}
+
+	sort.Slice(exposed, func(i, j int) bool {
+		return exposed[i].Name < exposed[j].Name
+	})
+
+	return exposed, nil
+}
+
+func appendUnique(values []string, additions ...string) []string {
+	for _, addition := range additions {
+		found := false
+		for _, value := range values {
+			if value == addition {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, addition)
+		}
+	}
+	return values
+}
@@ -0,0 +1,392 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promadapter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/promadapter"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+func TestParse(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description string
+		expected    *promadapter.Config
+		expectedErr error
+		data        string
+	}{
+		{
+			description: "Unknown field",
+			expectedErr: errors.New(`invalid adapter configuration: error unmarshaling JSON: while decoding JSON: json: unknown field "unknown"`),
+			data:        `unknown: true`,
+		},
+		{
+			description: "Rules and external rules",
+			expected: &promadapter.Config{
+				Rules: []promadapter.Rule{
+					{
+						SeriesQuery: `http_requests_total{namespace!="",pod!=""}`,
+						Resources: promadapter.ResourceMapping{
+							Overrides: map[string]promadapter.GroupResource{
+								"namespace": {Resource: "namespace"},
+								"pod":       {Resource: "pod"},
+							},
+						},
+						Name: promadapter.NameMapping{
+							Matches: "^(.*)_total$",
+							As:      "${1}_per_second",
+						},
+						MetricsQuery: "sum(rate(<<.Series>>{<<.LabelMatchers>>}[2m])) by (<<.GroupBy>>)",
+					},
+				},
+				ExternalRules: []promadapter.Rule{
+					{
+						SeriesQuery: `queue_messages_ready`,
+						Resources: promadapter.ResourceMapping{
+							Template: "<<.Resource>>",
+						},
+					},
+				},
+			},
+			data: `
+rules:
+- seriesQuery: 'http_requests_total{namespace!="",pod!=""}'
+  resources:
+    overrides:
+      namespace: {resource: "namespace"}
+      pod: {resource: "pod"}
+  name:
+    matches: "^(.*)_total$"
+    as: "${1}_per_second"
+  metricsQuery: 'sum(rate(<<.Series>>{<<.LabelMatchers>>}[2m])) by (<<.GroupBy>>)'
+externalRules:
+- seriesQuery: 'queue_messages_ready'
+  resources:
+    template: "<<.Resource>>"
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := promadapter.Parse([]byte(test.data))
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("config mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	series := []promadapter.Series{
+		{
+			Name: "http_requests_total",
+			Labels: map[string]string{
+				"namespace": "default",
+				"pod":       "web-1",
+			},
+		},
+		{
+			Name: "http_requests_total",
+			Labels: map[string]string{
+				"namespace": "default",
+				"service":   "web",
+			},
+		},
+		{
+			Name: "container_cpu_usage_seconds_total",
+			Labels: map[string]string{
+				"namespace": "default",
+				"pod":       "web-1",
+				"container": "POD",
+			},
+		},
+		{
+			Name: "queue_messages_ready",
+			Labels: map[string]string{
+				"queue": "jobs",
+			},
+		},
+		{
+			Name: "kube_ingress_hits",
+			Labels: map[string]string{
+				"kube_networking.k8s.io_ingress": "web",
+			},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expected    *promadapter.Simulation
+		expectedErr error
+		config      *promadapter.Config
+	}{
+		{
+			description: "Invalid series query",
+			expectedErr: errors.New(`invalid rule: rule 0: invalid series query "{namespace}": invalid label matcher "namespace"`),
+			config: &promadapter.Config{
+				Rules: []promadapter.Rule{
+					{SeriesQuery: "{namespace}"},
+				},
+			},
+		},
+		{
+			description: "Invalid name regex",
+			expectedErr: errors.New("invalid external rule: rule 0: invalid name matches \"(\": error parsing regexp: missing closing ): `(`"),
+			config: &promadapter.Config{
+				ExternalRules: []promadapter.Rule{
+					{
+						SeriesQuery: "queue_messages_ready",
+						Name: promadapter.NameMapping{
+							Matches: "(",
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "Multiple capture groups without as",
+			expectedErr: errors.New(`invalid rule: rule 0: name matches "(a)(b)" must have an as value when it has more than one capture group`),
+			config: &promadapter.Config{
+				Rules: []promadapter.Rule{
+					{
+						SeriesQuery: "queue_messages_ready",
+						Name: promadapter.NameMapping{
+							Matches: "(a)(b)",
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "Overrides, renaming and merged resources",
+			expected: &promadapter.Simulation{
+				Custom: []promadapter.ExposedMetric{
+					{
+						Name:      "http_requests_per_second",
+						Resources: []string{"namespace", "pod", "service"},
+						Series:    []string{"http_requests_total"},
+					},
+				},
+			},
+			config: &promadapter.Config{
+				Rules: []promadapter.Rule{
+					{
+						SeriesQuery: `http_requests_total{namespace!=""}`,
+						Resources: promadapter.ResourceMapping{
+							Overrides: map[string]promadapter.GroupResource{
+								"namespace": {Resource: "namespace"},
+								"pod":       {Resource: "pod"},
+								"service":   {Resource: "service"},
+							},
+						},
+						Name: promadapter.NameMapping{
+							Matches: "^(.*)_total$",
+							As:      "${1}_per_second",
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "Regex series query, series filters and template",
+			expected: &promadapter.Simulation{
+				Custom: []promadapter.ExposedMetric{
+					{
+						Name:      "http_requests_total",
+						Resources: []string{"namespace", "pod"},
+						Series:    []string{"http_requests_total"},
+					},
+				},
+			},
+			config: &promadapter.Config{
+				Rules: []promadapter.Rule{
+					{
+						SeriesQuery: `{__name__=~"^(http|container)_.*",pod!="",container!="POD"}`,
+						SeriesFilters: []promadapter.RegexFilter{
+							{IsNot: "_seconds_total$"},
+						},
+						Resources: promadapter.ResourceMapping{
+							Template: "<<.Resource>>",
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "Grouped template and external rules",
+			expected: &promadapter.Simulation{
+				Custom: []promadapter.ExposedMetric{
+					{
+						Name:      "kube_ingress_hits",
+						Resources: []string{"ingress.networking.k8s.io"},
+						Series:    []string{"kube_ingress_hits"},
+					},
+				},
+				External: []promadapter.ExposedMetric{
+					{
+						Name:   "queue_messages_ready",
+						Series: []string{"queue_messages_ready"},
+					},
+				},
+			},
+			config: &promadapter.Config{
+				Rules: []promadapter.Rule{
+					{
+						SeriesQuery: `kube_ingress_hits`,
+						Resources: promadapter.ResourceMapping{
+							Template: "kube_<<.Group>>_<<.Resource>>",
+						},
+					},
+				},
+				ExternalRules: []promadapter.Rule{
+					{
+						SeriesQuery: `queue_messages_ready{queue="jobs"}`,
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := test.config.Simulate(series)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("simulation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	simulation := &promadapter.Simulation{
+		Custom: []promadapter.ExposedMetric{
+			{
+				Name:      "http_requests_per_second",
+				Resources: []string{"namespace", "pod", "services"},
+			},
+		},
+		External: []promadapter.ExposedMetric{
+			{
+				Name: "queue_messages_ready",
+			},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expectedErr error
+		specs       []autoscalingv2.MetricSpec
+	}{
+		{
+			description: "All specs resolve",
+			specs: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{Name: "http_requests_per_second"},
+					},
+				},
+				{
+					Type: autoscalingv2.ObjectMetricSourceType,
+					Object: &autoscalingv2.ObjectMetricSource{
+						DescribedObject: autoscalingv2.CrossVersionObjectReference{Kind: "Service", Name: "web"},
+						Metric:          autoscalingv2.MetricIdentifier{Name: "http_requests_per_second"},
+					},
+				},
+				{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{Name: "queue_messages_ready"},
+					},
+				},
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+				},
+			},
+		},
+		{
+			description: "Multiple failures",
+			expectedErr: errors.New(`custom metric "unknown" is not exposed by any rule
+custom metric "http_requests_per_second" is not associated with resource "ingress"
+external metric "unknown" is not exposed by any external rule
+unknown metric source type "invalid"`),
+			specs: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{Name: "unknown"},
+					},
+				},
+				{
+					Type: autoscalingv2.ObjectMetricSourceType,
+					Object: &autoscalingv2.ObjectMetricSource{
+						DescribedObject: autoscalingv2.CrossVersionObjectReference{Kind: "Ingress", Name: "web"},
+						Metric:          autoscalingv2.MetricIdentifier{Name: "http_requests_per_second"},
+					},
+				},
+				{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{Name: "unknown"},
+					},
+				},
+				{
+					Type: "invalid",
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := simulation.ValidateAll(test.specs)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+			}
+		})
+	}
+}
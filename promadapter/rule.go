@@ -0,0 +1,294 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promadapter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	nameLabel           = "__name__"
+	groupPlaceholder    = "<<.Group>>"
+	resourcePlaceholder = "<<.Resource>>"
+)
+
+type labelMatcher struct {
+	label    string
+	operator string
+	value    string
+	regex    *regexp.Regexp
+}
+
+func (m *labelMatcher) matches(value string) bool {
+	switch m.operator {
+	case "=":
+		return value == m.value
+	case "!=":
+		return value != m.value
+	case "=~":
+		return m.regex.MatchString(value)
+	default:
+		return !m.regex.MatchString(value)
+	}
+}
+
+type compiledRule struct {
+	matchers         []labelMatcher
+	is               []*regexp.Regexp
+	isNot            []*regexp.Regexp
+	nameMatches      *regexp.Regexp
+	nameAs           string
+	overrides        map[string]GroupResource
+	resourceTemplate *regexp.Regexp
+}
+
+func compileRule(rule Rule) (*compiledRule, error) {
+	matchers, err := parseSeriesQuery(rule.SeriesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid series query %q: %w", rule.SeriesQuery, err)
+	}
+
+	compiled := &compiledRule{
+		matchers:  matchers,
+		overrides: rule.Resources.Overrides,
+	}
+
+	for _, filter := range rule.SeriesFilters {
+		if filter.Is != "" {
+			regex, err := regexp.Compile(filter.Is)
+			if err != nil {
+				return nil, fmt.Errorf("invalid series filter %q: %w", filter.Is, err)
+			}
+			compiled.is = append(compiled.is, regex)
+		}
+		if filter.IsNot != "" {
+			regex, err := regexp.Compile(filter.IsNot)
+			if err != nil {
+				return nil, fmt.Errorf("invalid series filter %q: %w", filter.IsNot, err)
+			}
+			compiled.isNot = append(compiled.isNot, regex)
+		}
+	}
+
+	nameMatches := rule.Name.Matches
+	if nameMatches == "" {
+		nameMatches = "(.*)"
+	}
+
+	compiled.nameMatches, err = regexp.Compile(nameMatches)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name matches %q: %w", nameMatches, err)
+	}
+
+	// Match the adapter's default naming, use the only capture group if there is one, otherwise the whole match
+	compiled.nameAs = rule.Name.As
+	if compiled.nameAs == "" {
+		switch compiled.nameMatches.NumSubexp() {
+		case 0:
+			compiled.nameAs = "$0"
+		case 1:
+			compiled.nameAs = "$1"
+		default:
+			return nil, fmt.Errorf("name matches %q must have an as value when it has more than one capture group", nameMatches)
+		}
+	}
+
+	if rule.Resources.Template != "" {
+		pattern := regexp.QuoteMeta(rule.Resources.Template)
+		pattern = strings.ReplaceAll(pattern, regexp.QuoteMeta(groupPlaceholder), "(?P<group>.*?)")
+		pattern = strings.ReplaceAll(pattern, regexp.QuoteMeta(resourcePlaceholder), "(?P<resource>.+?)")
+		compiled.resourceTemplate, err = regexp.Compile("^" + pattern + "$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource template %q: %w", rule.Resources.Template, err)
+		}
+	}
+
+	return compiled, nil
+}
+
+// apply returns the metric name and the associated resources for the series if the rule discovers it
+func (r *compiledRule) apply(series Series) (string, []string, bool) {
+	for _, matcher := range r.matchers {
+		value := series.Labels[matcher.label]
+		if matcher.label == nameLabel {
+			value = series.Name
+		}
+		if !matcher.matches(value) {
+			return "", nil, false
+		}
+	}
+
+	for _, is := range r.is {
+		if !is.MatchString(series.Name) {
+			return "", nil, false
+		}
+	}
+
+	for _, isNot := range r.isNot {
+		if isNot.MatchString(series.Name) {
+			return "", nil, false
+		}
+	}
+
+	match := r.nameMatches.FindStringSubmatchIndex(series.Name)
+	if match == nil {
+		return "", nil, false
+	}
+
+	metricName := string(r.nameMatches.ExpandString(nil, r.nameAs, series.Name, match))
+
+	var resources []string
+	for label := range series.Labels {
+		resource, ok := r.resourceForLabel(label)
+		if ok {
+			resources = append(resources, resource)
+		}
+	}
+
+	return metricName, resources, true
+}
+
+func (r *compiledRule) resourceForLabel(label string) (string, bool) {
+	if override, ok := r.overrides[label]; ok {
+		if override.Group != "" {
+			return override.Resource + "." + override.Group, true
+		}
+		return override.Resource, true
+	}
+
+	if r.resourceTemplate == nil {
+		return "", false
+	}
+
+	match := r.resourceTemplate.FindStringSubmatch(label)
+	if match == nil {
+		return "", false
+	}
+
+	var group, resource string
+	for i, name := range r.resourceTemplate.SubexpNames() {
+		switch name {
+		case "group":
+			group = match[i]
+		case "resource":
+			resource = match[i]
+		}
+	}
+
+	if group != "" {
+		return resource + "." + group, true
+	}
+
+	return resource, true
+}
+
+// parseSeriesQuery parses a Prometheus series selector, for example 'http_requests_total{namespace!="",pod!=""}',
+// into the label matchers it is made up of
+func parseSeriesQuery(query string) ([]labelMatcher, error) {
+	query = strings.TrimSpace(query)
+
+	var matchers []labelMatcher
+
+	metricName := query
+	labelSelector := ""
+	if start := strings.Index(query, "{"); start != -1 {
+		if !strings.HasSuffix(query, "}") {
+			return nil, fmt.Errorf("unterminated label selector")
+		}
+		metricName = strings.TrimSpace(query[:start])
+		labelSelector = query[start+1 : len(query)-1]
+	}
+
+	if metricName != "" {
+		matchers = append(matchers, labelMatcher{
+			label:    nameLabel,
+			operator: "=",
+			value:    metricName,
+		})
+	}
+
+	for len(strings.TrimSpace(labelSelector)) > 0 {
+		var matcher labelMatcher
+		var err error
+		matcher, labelSelector, err = parseLabelMatcher(labelSelector)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	if len(matchers) == 0 {
+		return nil, fmt.Errorf("series query must select at least one series")
+	}
+
+	return matchers, nil
+}
+
+// parseLabelMatcher parses a single label matcher from the start of the selector, returning the remainder
+func parseLabelMatcher(selector string) (labelMatcher, string, error) {
+	selector = strings.TrimLeft(selector, " ,")
+
+	operatorStart := strings.IndexAny(selector, "=!")
+	if operatorStart <= 0 {
+		return labelMatcher{}, "", fmt.Errorf("invalid label matcher %q", selector)
+	}
+
+	label := strings.TrimSpace(selector[:operatorStart])
+	rest := selector[operatorStart:]
+
+	var operator string
+	for _, candidate := range []string{"=~", "!~", "!=", "="} {
+		if strings.HasPrefix(rest, candidate) {
+			operator = candidate
+			break
+		}
+	}
+
+	if operator == "" {
+		return labelMatcher{}, "", fmt.Errorf("invalid operator in label matcher %q", selector)
+	}
+
+	rest = strings.TrimSpace(rest[len(operator):])
+	quoted, err := strconv.QuotedPrefix(rest)
+	if err != nil {
+		return labelMatcher{}, "", fmt.Errorf("invalid value in label matcher %q: %w", selector, err)
+	}
+
+	value, err := strconv.Unquote(quoted)
+	if err != nil {
+		return labelMatcher{}, "", fmt.Errorf("invalid value in label matcher %q: %w", selector, err)
+	}
+
+	matcher := labelMatcher{
+		label:    label,
+		operator: operator,
+		value:    value,
+	}
+
+	if operator == "=~" || operator == "!~" {
+		// Prometheus regular expressions are fully anchored
+		matcher.regex, err = regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return labelMatcher{}, "", fmt.Errorf("invalid regular expression in label matcher %q: %w", selector, err)
+		}
+	}
+
+	return matcher, rest[len(quoted):], nil
+}
@@ -0,0 +1,381 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package promclient provides a metricsclient.Client that answers custom, object and external metric requests by
+// running PromQL queries directly against the Prometheus HTTP API. This allows metrics to be gathered on clusters that
+// run Prometheus without the Prometheus Adapter or another custom metrics API implementation.
+package promclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+const (
+	// DefaultPodLabel is the series label that identifies the pod a raw metric sample belongs to
+	DefaultPodLabel = "pod"
+	// DefaultWindow is the window reported for raw pod metrics gathered from Prometheus
+	DefaultWindow = time.Minute
+)
+
+// ErrResourceMetricsUnsupported occurs when a resource metric is requested from a Client without a resource metrics
+// client to delegate to
+var ErrResourceMetricsUnsupported = errors.New("resource metrics are not supported by the Prometheus client")
+
+var _ metricsclient.Client = &Client{}
+var _ metricsclient.ClientWithContext = &Client{}
+
+// Client gathers metrics by running PromQL instant queries against a Prometheus server. Raw pod metric queries must
+// return a vector with one sample per pod, labelled with the pod name using the PodLabel; object metric queries must
+// return a single sample; external metric queries may return any number of samples. Sample values are converted to
+// milli-values. Resource metrics are not gathered from Prometheus, instead they are delegated to the Resource client
+// if one is provided.
+// If a PodLister is provided the pods matching the selector of a raw metric are listed, their names are provided to
+// the raw query as PodMatchers and any samples for other pods are dropped, in the same way as the Prometheus Adapter.
+// Without a PodLister raw queries must limit their results to the target's pods themselves, for example by using the
+// Selector or matching on labels the pods are known to have.
+type Client struct {
+	Address    string
+	HTTPClient *http.Client
+	Header     http.Header
+	Queries    Queries
+	PodLabel   string
+	Window     time.Duration
+	Resource   metricsclient.Client
+	PodLister  corelisters.PodLister
+}
+
+// NewClient sets up a Client that runs the queries provided against the Prometheus server at the address provided
+func NewClient(address string, queries Queries) *Client {
+	return &Client{
+		Address:    address,
+		HTTPClient: http.DefaultClient,
+		Queries:    queries,
+		PodLabel:   DefaultPodLabel,
+		Window:     DefaultWindow,
+	}
+}
+
+// GetResourceMetric gets the given resource metric from the Resource client, failing if there is no Resource client
+func (c *Client) GetResourceMetric(resource v1.ResourceName, namespace string, selector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+	return c.GetResourceMetricWithContext(context.Background(), resource, namespace, selector)
+}
+
+// GetResourceMetricWithContext gets the given resource metric from the Resource client, passing the context to it,
+// failing if there is no Resource client
+func (c *Client) GetResourceMetricWithContext(ctx context.Context, resource v1.ResourceName, namespace string, selector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+	if c.Resource == nil {
+		return nil, time.Time{}, fmt.Errorf("unable to fetch %s metrics: %w", resource, ErrResourceMetricsUnsupported)
+	}
+	return metricsclient.WithContext(c.Resource).GetResourceMetricWithContext(ctx, resource, namespace, selector)
+}
+
+// GetRawMetric gets the given metric for all pods matching the specified selector in the given namespace by running
+// the raw query configured for the metric
+func (c *Client) GetRawMetric(metricName string, namespace string, selector labels.Selector, metricSelector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+	return c.GetRawMetricWithContext(context.Background(), metricName, namespace, selector, metricSelector)
+}
+
+// GetRawMetricWithContext gets the given metric for all pods matching the specified selector in the given namespace
+// by running the raw query configured for the metric, abandoning the query if the context is done. If the Client has
+// a PodLister only samples for the pods matching the selector are returned.
+func (c *Client) GetRawMetricWithContext(ctx context.Context, metricName string, namespace string, selector labels.Selector, metricSelector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+	podLabel := c.PodLabel
+	if podLabel == "" {
+		podLabel = DefaultPodLabel
+	}
+	window := c.Window
+	if window == 0 {
+		window = DefaultWindow
+	}
+
+	data := QueryData{
+		Metric:    metricName,
+		Namespace: namespace,
+		Selector:  selectorString(selector),
+	}
+
+	var podNames sets.String
+	if c.PodLister != nil {
+		pods, err := podutil.ListPods(ctx, c.PodLister, namespace, selector)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("unable to fetch metrics from Prometheus for metric %q, failed to list pods: %w", metricName, err)
+		}
+		podNames = sets.NewString()
+		for _, pod := range pods {
+			podNames.Insert(pod.Name)
+		}
+		if podNames.Len() == 0 {
+			return nil, time.Time{}, fmt.Errorf("%w from Prometheus for metric %q, no pods match the selector", metricsclient.ErrNoMetrics, metricName)
+		}
+		data.PodMatchers = fmt.Sprintf("%s=~%s", podLabel, strconv.Quote(valuesRegex(podNames.List())))
+	}
+
+	samples, err := c.query(ctx, c.Queries.Raw, metricName, data, metricSelector)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	res := make(podmetrics.MetricsInfo, len(samples))
+	var timestamp time.Time
+	for _, s := range samples {
+		pod, ok := s.labels[podLabel]
+		if !ok {
+			return nil, time.Time{}, fmt.Errorf("unable to fetch metrics from Prometheus, sample for metric %q is missing the %q label", metricName, podLabel)
+		}
+		if podNames != nil && !podNames.Has(pod) {
+			continue
+		}
+		if _, exists := res[pod]; exists {
+			return nil, time.Time{}, fmt.Errorf("unable to fetch metrics from Prometheus, multiple samples for metric %q returned for pod %q", metricName, pod)
+		}
+		if len(res) == 0 {
+			timestamp = s.timestamp
+		}
+		res[pod] = podmetrics.Metric{
+			Timestamp: s.timestamp,
+			Window:    window,
+			Value:     s.value,
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, time.Time{}, fmt.Errorf("%w from Prometheus for metric %q for the pods matching the selector", metricsclient.ErrNoMetrics, metricName)
+	}
+
+	return res, timestamp, nil
+}
+
+// GetObjectMetric gets the given metric for the given object in the given namespace by running the object query
+// configured for the metric
+func (c *Client) GetObjectMetric(metricName string, namespace string, objectRef *autoscalingv2.CrossVersionObjectReference, metricSelector labels.Selector) (int64, time.Time, error) {
+	return c.GetObjectMetricWithContext(context.Background(), metricName, namespace, objectRef, metricSelector)
+}
+
+// GetObjectMetricWithContext gets the given metric for the given object in the given namespace by running the object
+// query configured for the metric, abandoning the query if the context is done
+func (c *Client) GetObjectMetricWithContext(ctx context.Context, metricName string, namespace string, objectRef *autoscalingv2.CrossVersionObjectReference, metricSelector labels.Selector) (int64, time.Time, error) {
+	samples, err := c.query(ctx, c.Queries.Object, metricName, QueryData{
+		Metric:     metricName,
+		Namespace:  namespace,
+		ObjectKind: objectRef.Kind,
+		ObjectName: objectRef.Name,
+	}, metricSelector)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	if len(samples) > 1 {
+		return 0, time.Time{}, fmt.Errorf("unable to fetch metrics from Prometheus, query for object metric %q returned %d samples, expected 1", metricName, len(samples))
+	}
+
+	return samples[0].value, samples[0].timestamp, nil
+}
+
+// GetExternalMetric gets all the values of a given external metric by running the external query configured for the
+// metric
+func (c *Client) GetExternalMetric(metricName, namespace string, selector labels.Selector) ([]int64, time.Time, error) {
+	return c.GetExternalMetricWithContext(context.Background(), metricName, namespace, selector)
+}
+
+// GetExternalMetricWithContext gets all the values of a given external metric by running the external query
+// configured for the metric, abandoning the query if the context is done
+func (c *Client) GetExternalMetricWithContext(ctx context.Context, metricName, namespace string, selector labels.Selector) ([]int64, time.Time, error) {
+	samples, err := c.query(ctx, c.Queries.External, metricName, QueryData{
+		Metric:    metricName,
+		Namespace: namespace,
+	}, selector)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	res := make([]int64, 0, len(samples))
+	for _, s := range samples {
+		res = append(res, s.value)
+	}
+	return res, samples[0].timestamp, nil
+}
+
+type sample struct {
+	labels    map[string]string
+	timestamp time.Time
+	value     int64
+}
+
+type queryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+type vectorSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  [2]any            `json:"value"`
+}
+
+// query renders the query template for the metric and runs it, returning the samples in the result, if there are no
+// samples ErrNoMetrics is returned
+func (c *Client) query(ctx context.Context, templates QueryTemplates, metricName string, data QueryData, metricSelector labels.Selector) ([]sample, error) {
+	matchers, err := LabelMatchers(metricSelector)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch metrics from Prometheus for metric %q: %w", metricName, err)
+	}
+	data.LabelMatchers = matchers
+
+	query, err := templates.Render(metricName, data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch metrics from Prometheus: %w", err)
+	}
+
+	samples, err := c.instantQuery(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch metrics from Prometheus for metric %q: %w", metricName, err)
+	}
+
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("%w from Prometheus for metric %q", metricsclient.ErrNoMetrics, metricName)
+	}
+
+	return samples, nil
+}
+
+// instantQuery runs the PromQL query provided using the Prometheus instant query API
+func (c *Client) instantQuery(ctx context.Context, query string) ([]sample, error) {
+	endpoint := strings.TrimSuffix(c.Address, "/") + "/api/v1/query"
+	form := url.Values{"query": []string{query}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range c.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed queryResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		if resp.StatusCode/100 != 2 {
+			return nil, fmt.Errorf("query failed with status %d", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("invalid query response: %w", err)
+	}
+
+	if parsed.Status != "success" {
+		return nil, fmt.Errorf("query failed with status %d: %s: %s", resp.StatusCode, parsed.ErrorType, parsed.Error)
+	}
+
+	switch parsed.Data.ResultType {
+	case "vector":
+		var vector []vectorSample
+		if err := json.Unmarshal(parsed.Data.Result, &vector); err != nil {
+			return nil, fmt.Errorf("invalid vector result: %w", err)
+		}
+		samples := make([]sample, 0, len(vector))
+		for _, v := range vector {
+			timestamp, value, err := parseSamplePair(v.Value)
+			if err != nil {
+				return nil, err
+			}
+			samples = append(samples, sample{
+				labels:    v.Metric,
+				timestamp: timestamp,
+				value:     value,
+			})
+		}
+		return samples, nil
+	case "scalar":
+		var scalar [2]any
+		if err := json.Unmarshal(parsed.Data.Result, &scalar); err != nil {
+			return nil, fmt.Errorf("invalid scalar result: %w", err)
+		}
+		timestamp, value, err := parseSamplePair(scalar)
+		if err != nil {
+			return nil, err
+		}
+		return []sample{{timestamp: timestamp, value: value}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported result type %q, queries must return an instant vector or scalar", parsed.Data.ResultType)
+	}
+}
+
+// parseSamplePair parses a Prometheus [<unix time>, "<value>"] pair, converting the value to a milli-value
+func parseSamplePair(pair [2]any) (time.Time, int64, error) {
+	seconds, ok := pair[0].(float64)
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("invalid sample timestamp %v", pair[0])
+	}
+	raw, ok := pair[1].(string)
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("invalid sample value %v", pair[1])
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid sample value %q: %w", raw, err)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return time.Time{}, 0, fmt.Errorf("invalid sample value %q", raw)
+	}
+	timestamp := time.UnixMilli(int64(math.Round(seconds * 1000))).UTC()
+	return timestamp, int64(math.Round(value * 1000)), nil
+}
+
+func selectorString(selector labels.Selector) string {
+	if selector == nil {
+		return ""
+	}
+	return selector.String()
+}
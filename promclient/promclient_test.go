@@ -0,0 +1,511 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promclient_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/promclient"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// prometheus starts a test Prometheus server that records the queries it receives and responds with the status and
+// body provided
+func prometheus(t *testing.T, status int, body string, queries *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if queries != nil {
+			*queries = append(*queries, r.FormValue("query"))
+		}
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// podLister returns a fake pod lister which lists the pods with the names provided, or fails with the error provided
+func podLister(err error, names ...string) corelisters.PodLister {
+	return &fake.PodLister{
+		PodsReactor: func(namespace string) corelisters.PodNamespaceLister {
+			return &fake.PodNamespaceLister{
+				ListReactor: func(selector labels.Selector) ([]*corev1.Pod, error) {
+					if err != nil {
+						return nil, err
+					}
+					pods := []*corev1.Pod{}
+					for _, name := range names {
+						pods = append(pods, &corev1.Pod{
+							ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+						})
+					}
+					return pods, nil
+				},
+			}
+		},
+	}
+}
+
+func TestClient_GetRawMetric(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	timestamp := time.Unix(1700000000, 0).UTC()
+
+	var tests = []struct {
+		description     string
+		expected        podmetrics.MetricsInfo
+		expectedTime    time.Time
+		expectedErr     error
+		expectedQueries []string
+		status          int
+		body            string
+		queries         promclient.Queries
+		metricSelector  labels.Selector
+		podLister       corelisters.PodLister
+	}{
+		{
+			description:  "No query configured",
+			expectedErr:  errors.New(`unable to fetch metrics from Prometheus: no query configured for metric "requests"`),
+			status:       http.StatusOK,
+			body:         `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			queries:      promclient.Queries{},
+			expectedTime: time.Time{},
+		},
+		{
+			description: "Query error",
+			expectedErr: errors.New(`unable to fetch metrics from Prometheus for metric "requests": query failed with status 400: bad_data: parse error`),
+			expectedQueries: []string{
+				`sum(requests{namespace="test-namespace",}) by (pod)`,
+			},
+			status: http.StatusBadRequest,
+			body:   `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+			queries: promclient.Queries{
+				Raw: promclient.QueryTemplates{
+					Default: `sum(<<.Metric>>{namespace="<<.Namespace>>",<<.LabelMatchers>>}) by (pod)`,
+				},
+			},
+		},
+		{
+			description: "No samples",
+			expectedErr: fmt.Errorf(`%w from Prometheus for metric "requests"`, metricsclient.ErrNoMetrics),
+			expectedQueries: []string{
+				`sum(requests{namespace="test-namespace",}) by (pod)`,
+			},
+			status: http.StatusOK,
+			body:   `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			queries: promclient.Queries{
+				Raw: promclient.QueryTemplates{
+					Default: `sum(<<.Metric>>{namespace="<<.Namespace>>",<<.LabelMatchers>>}) by (pod)`,
+				},
+			},
+		},
+		{
+			description: "Sample missing pod label",
+			expectedErr: errors.New(`unable to fetch metrics from Prometheus, sample for metric "requests" is missing the "pod" label`),
+			expectedQueries: []string{
+				`sum(requests{namespace="test-namespace",}) by (pod)`,
+			},
+			status: http.StatusOK,
+			body:   `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"1"]}]}}`,
+			queries: promclient.Queries{
+				Raw: promclient.QueryTemplates{
+					Default: `sum(<<.Metric>>{namespace="<<.Namespace>>",<<.LabelMatchers>>}) by (pod)`,
+				},
+			},
+		},
+		{
+			description: "Success, metric specific query and metric selector",
+			expected: podmetrics.MetricsInfo{
+				"pod-1": podmetrics.Metric{Timestamp: timestamp, Window: promclient.DefaultWindow, Value: 1500},
+				"pod-2": podmetrics.Metric{Timestamp: timestamp, Window: promclient.DefaultWindow, Value: 250},
+			},
+			expectedTime: timestamp,
+			expectedQueries: []string{
+				`sum(rate(http_requests_total{namespace="test-namespace",code="200",method=~"GET|POST"}[2m])) by (pod)`,
+			},
+			status: http.StatusOK,
+			body: `{"status":"success","data":{"resultType":"vector","result":[` +
+				`{"metric":{"pod":"pod-1"},"value":[1700000000,"1.5"]},` +
+				`{"metric":{"pod":"pod-2"},"value":[1700000000,"0.25"]}]}}`,
+			queries: promclient.Queries{
+				Raw: promclient.QueryTemplates{
+					Default: `sum(<<.Metric>>{namespace="<<.Namespace>>",<<.LabelMatchers>>}) by (pod)`,
+					Metrics: map[string]string{
+						"requests": `sum(rate(http_requests_total{namespace="<<.Namespace>>",<<.LabelMatchers>>}[2m])) by (pod)`,
+					},
+				},
+			},
+			metricSelector: labels.SelectorFromSet(labels.Set{"code": "200"}).Add(mustRequirement(t, "method", selection.In, "GET", "POST")),
+		},
+		{
+			description: "Fail to list pods",
+			expectedErr: errors.New(`unable to fetch metrics from Prometheus for metric "requests", failed to list pods: fail to list pods`),
+			status:      http.StatusOK,
+			body:        `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			queries: promclient.Queries{
+				Raw: promclient.QueryTemplates{
+					Default: `sum(<<.Metric>>{namespace="<<.Namespace>>",<<.PodMatchers>>}) by (pod)`,
+				},
+			},
+			podLister: podLister(errors.New("fail to list pods")),
+		},
+		{
+			description: "No pods match the selector",
+			expectedErr: fmt.Errorf(`%w from Prometheus for metric "requests", no pods match the selector`, metricsclient.ErrNoMetrics),
+			status:      http.StatusOK,
+			body:        `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			queries: promclient.Queries{
+				Raw: promclient.QueryTemplates{
+					Default: `sum(<<.Metric>>{namespace="<<.Namespace>>",<<.PodMatchers>>}) by (pod)`,
+				},
+			},
+			podLister: podLister(nil),
+		},
+		{
+			description: "Only samples for other pods",
+			expectedErr: fmt.Errorf(`%w from Prometheus for metric "requests" for the pods matching the selector`, metricsclient.ErrNoMetrics),
+			expectedQueries: []string{
+				`sum(requests{namespace="test-namespace",pod=~"pod-1"}) by (pod)`,
+			},
+			status: http.StatusOK,
+			body: `{"status":"success","data":{"resultType":"vector","result":[` +
+				`{"metric":{"pod":"other-pod"},"value":[1700000000,"3"]}]}}`,
+			queries: promclient.Queries{
+				Raw: promclient.QueryTemplates{
+					Default: `sum(<<.Metric>>{namespace="<<.Namespace>>",<<.PodMatchers>>}) by (pod)`,
+				},
+			},
+			podLister: podLister(nil, "pod-1"),
+		},
+		{
+			description: "Success, pod matchers provided and samples for pods outside the selector dropped",
+			expected: podmetrics.MetricsInfo{
+				"pod-1": podmetrics.Metric{Timestamp: timestamp, Window: promclient.DefaultWindow, Value: 1500},
+				"pod-2": podmetrics.Metric{Timestamp: timestamp, Window: promclient.DefaultWindow, Value: 250},
+			},
+			expectedTime: timestamp,
+			expectedQueries: []string{
+				`sum(requests{namespace="test-namespace",pod=~"pod-1|pod-2"}) by (pod)`,
+			},
+			status: http.StatusOK,
+			body: `{"status":"success","data":{"resultType":"vector","result":[` +
+				`{"metric":{"pod":"other-pod"},"value":[1700000001,"3"]},` +
+				`{"metric":{"pod":"pod-1"},"value":[1700000000,"1.5"]},` +
+				`{"metric":{"pod":"pod-2"},"value":[1700000000,"0.25"]}]}}`,
+			queries: promclient.Queries{
+				Raw: promclient.QueryTemplates{
+					Default: `sum(<<.Metric>>{namespace="<<.Namespace>>",<<.PodMatchers>>}) by (pod)`,
+				},
+			},
+			podLister: podLister(nil, "pod-2", "pod-1"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var queries []string
+			server := prometheus(t, test.status, test.body, &queries)
+			client := promclient.NewClient(server.URL, test.queries)
+			client.PodLister = test.podLister
+
+			metrics, timestamp, err := client.GetRawMetric("requests", "test-namespace", labels.Everything(), test.metricSelector)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, metrics) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metrics))
+			}
+			if !cmp.Equal(test.expectedTime, timestamp) {
+				t.Errorf("timestamp mismatch (-want +got):\n%s", cmp.Diff(test.expectedTime, timestamp))
+			}
+			if !cmp.Equal(test.expectedQueries, queries) {
+				t.Errorf("queries mismatch (-want +got):\n%s", cmp.Diff(test.expectedQueries, queries))
+			}
+		})
+	}
+}
+
+func TestClient_GetObjectMetric(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	timestamp := time.Unix(1700000000, 500*int64(time.Millisecond)).UTC()
+
+	var tests = []struct {
+		description     string
+		expected        int64
+		expectedTime    time.Time
+		expectedErr     error
+		expectedQueries []string
+		body            string
+	}{
+		{
+			description: "Multiple samples",
+			expectedErr: errors.New(`unable to fetch metrics from Prometheus, query for object metric "requests" returned 2 samples, expected 1`),
+			expectedQueries: []string{
+				`sum(requests{namespace="test-namespace",ingress="test-ingress"})`,
+			},
+			body: `{"status":"success","data":{"resultType":"vector","result":[` +
+				`{"metric":{},"value":[1700000000.5,"1"]},{"metric":{},"value":[1700000000.5,"2"]}]}}`,
+		},
+		{
+			description: "Invalid sample value",
+			expectedErr: errors.New(`unable to fetch metrics from Prometheus for metric "requests": invalid sample value "NaN"`),
+			expectedQueries: []string{
+				`sum(requests{namespace="test-namespace",ingress="test-ingress"})`,
+			},
+			body: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000.5,"NaN"]}]}}`,
+		},
+		{
+			description:  "Success, vector",
+			expected:     3000,
+			expectedTime: timestamp,
+			expectedQueries: []string{
+				`sum(requests{namespace="test-namespace",ingress="test-ingress"})`,
+			},
+			body: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000.5,"3"]}]}}`,
+		},
+		{
+			description:  "Success, scalar",
+			expected:     42,
+			expectedTime: timestamp,
+			expectedQueries: []string{
+				`sum(requests{namespace="test-namespace",ingress="test-ingress"})`,
+			},
+			body: `{"status":"success","data":{"resultType":"scalar","result":[1700000000.5,"0.042"]}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var queries []string
+			server := prometheus(t, http.StatusOK, test.body, &queries)
+			client := promclient.NewClient(server.URL, promclient.Queries{
+				Object: promclient.QueryTemplates{
+					Default: `sum(<<.Metric>>{namespace="<<.Namespace>>",<<.ObjectKind>>="<<.ObjectName>>"})`,
+				},
+			})
+
+			value, timestamp, err := client.GetObjectMetric("requests", "test-namespace", &autoscalingv2.CrossVersionObjectReference{
+				Kind: "ingress",
+				Name: "test-ingress",
+			}, nil)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, value) {
+				t.Errorf("value mismatch (-want +got):\n%s", cmp.Diff(test.expected, value))
+			}
+			if !cmp.Equal(test.expectedTime, timestamp) {
+				t.Errorf("timestamp mismatch (-want +got):\n%s", cmp.Diff(test.expectedTime, timestamp))
+			}
+			if !cmp.Equal(test.expectedQueries, queries) {
+				t.Errorf("queries mismatch (-want +got):\n%s", cmp.Diff(test.expectedQueries, queries))
+			}
+		})
+	}
+}
+
+func TestClient_GetExternalMetric(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	timestamp := time.Unix(1700000000, 0).UTC()
+
+	var tests = []struct {
+		description     string
+		expected        []int64
+		expectedTime    time.Time
+		expectedErr     error
+		expectedQueries []string
+		status          int
+		body            string
+		selector        labels.Selector
+	}{
+		{
+			description: "Unsupported selector",
+			expectedErr: errors.New(`unable to fetch metrics from Prometheus for metric "queue_depth": unsupported selector operator "gt" for label "size"`),
+			status:      http.StatusOK,
+			body:        `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			selector:    labels.NewSelector().Add(mustRequirement(t, "size", selection.GreaterThan, "5")),
+		},
+		{
+			description: "Unsupported result type",
+			expectedErr: errors.New(`unable to fetch metrics from Prometheus for metric "queue_depth": unsupported result type "matrix", queries must return an instant vector or scalar`),
+			expectedQueries: []string{
+				`queue_depth{}`,
+			},
+			status: http.StatusOK,
+			body:   `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+		},
+		{
+			description: "Non JSON error response",
+			expectedErr: errors.New(`unable to fetch metrics from Prometheus for metric "queue_depth": query failed with status 502`),
+			expectedQueries: []string{
+				`queue_depth{}`,
+			},
+			status: http.StatusBadGateway,
+			body:   `bad gateway`,
+		},
+		{
+			description:  "Success",
+			expected:     []int64{5000, 7000},
+			expectedTime: timestamp,
+			expectedQueries: []string{
+				`queue_depth{queue="orders",region!="",topology_kubernetes_io_zone!~"a|b\\.c"}`,
+			},
+			status: http.StatusOK,
+			body: `{"status":"success","data":{"resultType":"vector","result":[` +
+				`{"metric":{"queue":"orders"},"value":[1700000000,"5"]},` +
+				`{"metric":{"queue":"orders"},"value":[1700000000,"7"]}]}}`,
+			selector: labels.SelectorFromSet(labels.Set{"queue": "orders"}).
+				Add(mustRequirement(t, "region", selection.Exists)).
+				Add(mustRequirement(t, "topology.kubernetes.io/zone", selection.NotIn, "a", "b.c")),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var queries []string
+			server := prometheus(t, test.status, test.body, &queries)
+			client := promclient.NewClient(server.URL, promclient.Queries{
+				External: promclient.QueryTemplates{
+					Default: `<<.Metric>>{<<.LabelMatchers>>}`,
+				},
+			})
+
+			values, timestamp, err := client.GetExternalMetric("queue_depth", "test-namespace", test.selector)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, values) {
+				t.Errorf("values mismatch (-want +got):\n%s", cmp.Diff(test.expected, values))
+			}
+			if !cmp.Equal(test.expectedTime, timestamp) {
+				t.Errorf("timestamp mismatch (-want +got):\n%s", cmp.Diff(test.expectedTime, timestamp))
+			}
+			if !cmp.Equal(test.expectedQueries, queries) {
+				t.Errorf("queries mismatch (-want +got):\n%s", cmp.Diff(test.expectedQueries, queries))
+			}
+		})
+	}
+}
+
+func TestClient_GetResourceMetric(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description string
+		expected    podmetrics.MetricsInfo
+		expectedErr error
+		resource    metricsclient.Client
+	}{
+		{
+			description: "No resource client",
+			expectedErr: fmt.Errorf("unable to fetch cpu metrics: %w", promclient.ErrResourceMetricsUnsupported),
+		},
+		{
+			description: "Delegate to resource client",
+			expected: podmetrics.MetricsInfo{
+				"pod-1": podmetrics.Metric{Value: 100},
+			},
+			resource: &fake.MetricsClient{
+				GetResourceMetricReactor: func(resource corev1.ResourceName, namespace string, selector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+					return podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 100},
+					}, time.Time{}, nil
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client := promclient.NewClient("http://prometheus.invalid", promclient.Queries{})
+			client.Resource = test.resource
+
+			metrics, _, err := client.GetResourceMetric(corev1.ResourceCPU, "test-namespace", labels.Everything())
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, metrics) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metrics))
+			}
+		})
+	}
+}
+
+func TestClient_GetRawMetricWithContext_Cancelled(t *testing.T) {
+	server := prometheus(t, http.StatusOK, `{"status":"success","data":{"resultType":"vector","result":[]}}`, nil)
+	client := promclient.NewClient(server.URL, promclient.Queries{
+		Raw: promclient.QueryTemplates{Default: `<<.Metric>>`},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := client.GetRawMetricWithContext(ctx, "requests", "test-namespace", labels.Everything(), nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context cancelled error, got %v", err)
+	}
+}
+
+func mustRequirement(t *testing.T, key string, operator selection.Operator, values ...string) labels.Requirement {
+	t.Helper()
+	requirement, err := labels.NewRequirement(key, operator, values)
+	if err != nil {
+		t.Fatalf("invalid requirement: %v", err)
+	}
+	return *requirement
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promclient
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+var invalidLabelNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// Queries are the PromQL query templates used to gather each type of metric
+type Queries struct {
+	Raw      QueryTemplates `json:"raw,omitempty"`
+	Object   QueryTemplates `json:"object,omitempty"`
+	External QueryTemplates `json:"external,omitempty"`
+}
+
+// QueryTemplates are PromQL query templates for a type of metric, Metrics holds templates for specific metric names
+// and Default is used for any metric without its own template. Templates use the Go text/template syntax with "<<"
+// and ">>" delimiters, as Prometheus Adapter metrics queries do, and are rendered with QueryData, for example:
+//
+//	sum(rate(<<.Metric>>{namespace="<<.Namespace>>",<<.LabelMatchers>>}[2m])) by (pod)
+type QueryTemplates struct {
+	Default string            `json:"default,omitempty"`
+	Metrics map[string]string `json:"metrics,omitempty"`
+}
+
+// QueryData is the data a query template is rendered with. LabelMatchers are the PromQL label matchers converted
+// from the metric selector, and are empty if there is no metric selector. Selector is the label selector of the pods
+// a raw metric is gathered for, and PodMatchers is a PromQL label matcher for the names of those pods, for example
+// pod=~"pod-1|pod-2", which is only set if the Client has a PodLister. ObjectKind and ObjectName describe the object
+// an object metric is gathered for.
+type QueryData struct {
+	Metric        string
+	Namespace     string
+	LabelMatchers string
+	Selector      string
+	PodMatchers   string
+	ObjectKind    string
+	ObjectName    string
+}
+
+// Render renders the template for the metric provided using the data provided, failing if there is no template for
+// the metric
+func (q QueryTemplates) Render(metricName string, data QueryData) (string, error) {
+	text, ok := q.Metrics[metricName]
+	if !ok {
+		text = q.Default
+	}
+	if text == "" {
+		return "", fmt.Errorf("no query configured for metric %q", metricName)
+	}
+
+	tmpl, err := template.New(metricName).Delims("<<", ">>").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid query template for metric %q: %w", metricName, err)
+	}
+
+	var query strings.Builder
+	if err := tmpl.Execute(&query, data); err != nil {
+		return "", fmt.Errorf("unable to render query template for metric %q: %w", metricName, err)
+	}
+	return query.String(), nil
+}
+
+// LabelMatchers converts a label selector into comma separated PromQL label matchers. Label names are sanitized by
+// replacing characters that are not valid in Prometheus label names with underscores. Greater than and less than
+// requirements cannot be expressed as PromQL label matchers and are rejected.
+func LabelMatchers(selector labels.Selector) (string, error) {
+	if selector == nil {
+		return "", nil
+	}
+
+	requirements, _ := selector.Requirements()
+	matchers := make([]string, 0, len(requirements))
+	for _, requirement := range requirements {
+		name := invalidLabelNameCharacters.ReplaceAllString(requirement.Key(), "_")
+		values := requirement.Values().List()
+
+		switch requirement.Operator() {
+		case selection.Equals, selection.DoubleEquals:
+			matchers = append(matchers, fmt.Sprintf("%s=%s", name, strconv.Quote(values[0])))
+		case selection.NotEquals:
+			matchers = append(matchers, fmt.Sprintf("%s!=%s", name, strconv.Quote(values[0])))
+		case selection.In:
+			matchers = append(matchers, fmt.Sprintf("%s=~%s", name, strconv.Quote(valuesRegex(values))))
+		case selection.NotIn:
+			matchers = append(matchers, fmt.Sprintf("%s!~%s", name, strconv.Quote(valuesRegex(values))))
+		case selection.Exists:
+			matchers = append(matchers, fmt.Sprintf("%s!=\"\"", name))
+		case selection.DoesNotExist:
+			matchers = append(matchers, fmt.Sprintf("%s=\"\"", name))
+		default:
+			return "", fmt.Errorf("unsupported selector operator %q for label %q", requirement.Operator(), requirement.Key())
+		}
+	}
+	return strings.Join(matchers, ","), nil
+}
+
+func valuesRegex(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, regexp.QuoteMeta(value))
+	}
+	return strings.Join(quoted, "|")
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package quota caps replica count evaluations at the number of replicas that would fit within the remaining
+// ResourceQuota of a namespace, taking into account LimitRange defaults for containers without requests or limits.
+// This allows multi-tenant platforms to avoid scale ups which are guaranteed to fail admission.
+package quota
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// ReasonQuotaLimited is the reason given when a recommendation is capped by a ResourceQuota
+const ReasonQuotaLimited k8shorizmetrics.Reason = "QuotaLimited"
+
+// Recommendation is a replica count evaluation capped by the remaining quota. MetricReplicas is the raw replica count
+// driven by the metrics, QuotaReplicas is the maximum number of replicas that would fit within the remaining quota
+// (nil if no quota applies) and Replicas is the recommendation after the cap is applied. If the recommendation was
+// capped Reason is set to ReasonQuotaLimited.
+type Recommendation struct {
+	Replicas       int32                  `json:"replicas"`
+	MetricReplicas int32                  `json:"metricReplicas"`
+	QuotaReplicas  *int32                 `json:"quotaReplicas,omitempty"`
+	Reason         k8shorizmetrics.Reason `json:"reason,omitempty"`
+}
+
+// Limiter calculates how many replicas fit within the ResourceQuotas of a namespace. Only quotas without scopes are
+// considered, as scoped quotas may not apply to the pods being scaled. If LimitRangeLister is nil LimitRange defaults
+// are not applied.
+type Limiter struct {
+	ResourceQuotaLister corelisters.ResourceQuotaLister
+	LimitRangeLister    corelisters.LimitRangeLister
+}
+
+// Cap caps the metric driven replica count provided at the number of replicas of the pod spec provided that would fit
+// within the namespace's remaining quota. Scale downs are never capped, and the recommendation is never capped below
+// the current replica count as existing replicas are already accounted for in the quota usage.
+func (l *Limiter) Cap(namespace string, podSpec *corev1.PodSpec, currentReplicas int32,
+	metricReplicas int32) (*Recommendation, error) {
+	recommendation := &Recommendation{
+		Replicas:       metricReplicas,
+		MetricReplicas: metricReplicas,
+	}
+
+	additionalReplicas, limited, err := l.AdditionalReplicas(namespace, podSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	if !limited {
+		return recommendation, nil
+	}
+
+	quotaReplicas := currentReplicas + additionalReplicas
+	recommendation.QuotaReplicas = &quotaReplicas
+
+	if metricReplicas > currentReplicas && metricReplicas > quotaReplicas {
+		recommendation.Replicas = quotaReplicas
+		recommendation.Reason = ReasonQuotaLimited
+	}
+
+	return recommendation, nil
+}
+
+// AdditionalReplicas returns how many more replicas of the pod spec provided would fit within the namespace's
+// remaining quota, if no quota limits the pod spec false is returned
+func (l *Limiter) AdditionalReplicas(namespace string, podSpec *corev1.PodSpec) (int32, bool, error) {
+	quotas, err := l.ResourceQuotaLister.ResourceQuotas(namespace).List(labels.Everything())
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to list resource quotas: %w", err)
+	}
+
+	var limitRanges []*corev1.LimitRange
+	if l.LimitRangeLister != nil {
+		limitRanges, err = l.LimitRangeLister.LimitRanges(namespace).List(labels.Everything())
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to list limit ranges: %w", err)
+		}
+	}
+
+	usage := ReplicaUsage(podSpec, limitRanges)
+
+	additional := int64(math.MaxInt32)
+	limited := false
+	for _, quota := range quotas {
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			continue
+		}
+
+		for resourceName, hard := range quota.Spec.Hard {
+			perReplica, ok := usage[resourceName]
+			if !ok || perReplica.IsZero() {
+				continue
+			}
+
+			remaining := hard.DeepCopy()
+			if used, ok := quota.Status.Used[resourceName]; ok {
+				remaining.Sub(used)
+			}
+
+			fit := int64(0)
+			if remaining.Sign() > 0 {
+				fit = remaining.MilliValue() / perReplica.MilliValue()
+			}
+
+			if fit < additional {
+				additional = fit
+			}
+			limited = true
+		}
+	}
+
+	if !limited {
+		return 0, false, nil
+	}
+
+	return int32(additional), true, nil
+}
+
+// ReplicaUsage returns the quota usage of a single replica of the pod spec provided, keyed by the quota resource names
+// it counts against (pods, requests.<resource>, limits.<resource>, and the shorthand cpu and memory). Containers
+// without requests or limits use the defaults of the container LimitRanges provided. As with the API server, init
+// containers run one at a time before the app containers, so the pod's requests and limits are the larger of the sum
+// of the app and sidecar containers and the largest init container plus the sidecars started before it, with any pod
+// overhead added on top.
+func ReplicaUsage(podSpec *corev1.PodSpec, limitRanges []*corev1.LimitRange) corev1.ResourceList {
+	usage := corev1.ResourceList{
+		corev1.ResourcePods: resource.MustParse("1"),
+	}
+
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+	for _, container := range podSpec.Containers {
+		containerRequests, containerLimits := containerResources(container, limitRanges)
+		addResources(requests, containerRequests)
+		addResources(limits, containerLimits)
+	}
+
+	initRequests := corev1.ResourceList{}
+	initLimits := corev1.ResourceList{}
+	sidecarRequests := corev1.ResourceList{}
+	sidecarLimits := corev1.ResourceList{}
+	for _, container := range podSpec.InitContainers {
+		containerRequests, containerLimits := containerResources(container, limitRanges)
+
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			// Sidecar containers keep running alongside the app containers and any init containers started after them
+			addResources(requests, containerRequests)
+			addResources(limits, containerLimits)
+			addResources(sidecarRequests, containerRequests)
+			addResources(sidecarLimits, containerLimits)
+			containerRequests = sidecarRequests.DeepCopy()
+			containerLimits = sidecarLimits.DeepCopy()
+		} else {
+			addResources(containerRequests, sidecarRequests)
+			addResources(containerLimits, sidecarLimits)
+		}
+
+		maxResources(initRequests, containerRequests)
+		maxResources(initLimits, containerLimits)
+	}
+
+	maxResources(requests, initRequests)
+	maxResources(limits, initLimits)
+
+	if podSpec.Overhead != nil {
+		addResources(requests, podSpec.Overhead)
+		for resourceName, overhead := range podSpec.Overhead {
+			if _, ok := limits[resourceName]; ok {
+				addUsage(limits, resourceName, overhead)
+			}
+		}
+	}
+
+	for resourceName, request := range requests {
+		addUsage(usage, corev1.ResourceName("requests."+string(resourceName)), request)
+		if resourceName == corev1.ResourceCPU || resourceName == corev1.ResourceMemory {
+			addUsage(usage, resourceName, request)
+		}
+	}
+
+	for resourceName, limit := range limits {
+		addUsage(usage, corev1.ResourceName("limits."+string(resourceName)), limit)
+	}
+
+	return usage
+}
+
+// containerResources returns the requests and limits of the container provided, with the defaults of the container
+// LimitRanges provided applied
+func containerResources(container corev1.Container,
+	limitRanges []*corev1.LimitRange) (corev1.ResourceList, corev1.ResourceList) {
+	requests := container.Resources.Requests.DeepCopy()
+	if requests == nil {
+		requests = corev1.ResourceList{}
+	}
+	limits := container.Resources.Limits.DeepCopy()
+	if limits == nil {
+		limits = corev1.ResourceList{}
+	}
+
+	for _, limitRange := range limitRanges {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != corev1.LimitTypeContainer {
+				continue
+			}
+			for resourceName, defaultLimit := range item.Default {
+				if _, ok := limits[resourceName]; !ok {
+					limits[resourceName] = defaultLimit
+				}
+			}
+			for resourceName, defaultRequest := range item.DefaultRequest {
+				if _, ok := requests[resourceName]; !ok {
+					requests[resourceName] = defaultRequest
+				}
+			}
+		}
+	}
+
+	// Match the API server, a container with a limit but no request has its request set to its limit
+	for resourceName, limit := range limits {
+		if _, ok := requests[resourceName]; !ok {
+			requests[resourceName] = limit
+		}
+	}
+
+	return requests, limits
+}
+
+func addResources(total corev1.ResourceList, resources corev1.ResourceList) {
+	for resourceName, quantity := range resources {
+		addUsage(total, resourceName, quantity)
+	}
+}
+
+func maxResources(total corev1.ResourceList, resources corev1.ResourceList) {
+	for resourceName, quantity := range resources {
+		if current, ok := total[resourceName]; !ok || quantity.Cmp(current) > 0 {
+			total[resourceName] = quantity.DeepCopy()
+		}
+	}
+}
+
+func addUsage(usage corev1.ResourceList, resourceName corev1.ResourceName, quantity resource.Quantity) {
+	total := usage[resourceName].DeepCopy()
+	total.Add(quantity)
+	usage[resourceName] = total
+}
+
+// Evaluator wraps an Evaluator, capping its evaluations at the number of replicas of the PodSpec provided that fit
+// within the remaining quota of the Namespace
+type Evaluator struct {
+	Evaluator *k8shorizmetrics.Evaluator
+	Limiter   *Limiter
+	Namespace string
+	PodSpec   *corev1.PodSpec
+}
+
+// Evaluate returns the target replica count for the metrics provided capped by the remaining quota, along with the
+// raw metric driven replica count and the quota cap
+func (e *Evaluator) Evaluate(gatheredMetrics []*metrics.Metric, currentReplicas int32) (*Recommendation, error) {
+	metricReplicas, err := e.Evaluator.Evaluate(gatheredMetrics, currentReplicas)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Limiter.Cap(e.Namespace, e.PodSpec, currentReplicas, metricReplicas)
+}
@@ -0,0 +1,472 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/quota"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newIndexer(objects ...interface{}) cache.Indexer {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, object := range objects {
+		_ = indexer.Add(object)
+	}
+	return indexer
+}
+
+func TestReplicaUsage(t *testing.T) {
+	equateQuantity := cmp.Comparer(func(x, y resource.Quantity) bool { return x.Cmp(y) == 0 })
+	restartPolicyAlways := corev1.ContainerRestartPolicyAlways
+
+	var tests = []struct {
+		description string
+		expected    corev1.ResourceList
+		podSpec     *corev1.PodSpec
+		limitRanges []*corev1.LimitRange
+	}{
+		{
+			description: "Requests and limits summed across containers",
+			expected: corev1.ResourceList{
+				corev1.ResourcePods:           resource.MustParse("1"),
+				corev1.ResourceCPU:            resource.MustParse("750m"),
+				corev1.ResourceRequestsCPU:    resource.MustParse("750m"),
+				corev1.ResourceLimitsCPU:      resource.MustParse("500m"),
+				corev1.ResourceMemory:         resource.MustParse("256Mi"),
+				corev1.ResourceRequestsMemory: resource.MustParse("256Mi"),
+			},
+			podSpec: &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("250m"),
+								corev1.ResourceMemory: resource.MustParse("256Mi"),
+							},
+						},
+					},
+					{
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("500m"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "Limit range defaults applied",
+			expected: corev1.ResourceList{
+				corev1.ResourcePods:           resource.MustParse("1"),
+				corev1.ResourceCPU:            resource.MustParse("100m"),
+				corev1.ResourceRequestsCPU:    resource.MustParse("100m"),
+				corev1.ResourceLimitsCPU:      resource.MustParse("200m"),
+				corev1.ResourceMemory:         resource.MustParse("1Gi"),
+				corev1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+				corev1.ResourceLimitsMemory:   resource.MustParse("1Gi"),
+			},
+			podSpec: &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{},
+				},
+			},
+			limitRanges: []*corev1.LimitRange{
+				{
+					Spec: corev1.LimitRangeSpec{
+						Limits: []corev1.LimitRangeItem{
+							{
+								Type: corev1.LimitTypePod,
+								Default: corev1.ResourceList{
+									corev1.ResourceCPU: resource.MustParse("10"),
+								},
+							},
+							{
+								Type: corev1.LimitTypeContainer,
+								Default: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("200m"),
+									corev1.ResourceMemory: resource.MustParse("1Gi"),
+								},
+								DefaultRequest: corev1.ResourceList{
+									corev1.ResourceCPU: resource.MustParse("100m"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "Largest init container used when it requests more than the app containers",
+			expected: corev1.ResourceList{
+				corev1.ResourcePods:        resource.MustParse("1"),
+				corev1.ResourceCPU:         resource.MustParse("1"),
+				corev1.ResourceRequestsCPU: resource.MustParse("1"),
+				corev1.ResourceLimitsCPU:   resource.MustParse("2"),
+			},
+			podSpec: &corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("1"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("2"),
+							},
+						},
+					},
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("500m"),
+							},
+						},
+					},
+				},
+				Containers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("250m"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("500m"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "App containers used when they request more than the init containers",
+			expected: corev1.ResourceList{
+				corev1.ResourcePods:           resource.MustParse("1"),
+				corev1.ResourceCPU:            resource.MustParse("500m"),
+				corev1.ResourceRequestsCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory:         resource.MustParse("512Mi"),
+				corev1.ResourceRequestsMemory: resource.MustParse("512Mi"),
+			},
+			podSpec: &corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("250m"),
+								corev1.ResourceMemory: resource.MustParse("512Mi"),
+							},
+						},
+					},
+				},
+				Containers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("128Mi"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "Sidecar containers counted alongside app containers and later init containers",
+			expected: corev1.ResourceList{
+				corev1.ResourcePods:        resource.MustParse("1"),
+				corev1.ResourceCPU:         resource.MustParse("600m"),
+				corev1.ResourceRequestsCPU: resource.MustParse("600m"),
+			},
+			podSpec: &corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("550m"),
+							},
+						},
+					},
+					{
+						RestartPolicy: &restartPolicyAlways,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("100m"),
+							},
+						},
+					},
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("500m"),
+							},
+						},
+					},
+				},
+				Containers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("250m"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "Sidecar containers summed with app containers",
+			expected: corev1.ResourceList{
+				corev1.ResourcePods:        resource.MustParse("1"),
+				corev1.ResourceCPU:         resource.MustParse("350m"),
+				corev1.ResourceRequestsCPU: resource.MustParse("350m"),
+			},
+			podSpec: &corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("300m"),
+							},
+						},
+					},
+					{
+						RestartPolicy: &restartPolicyAlways,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("100m"),
+							},
+						},
+					},
+				},
+				Containers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("250m"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "Pod overhead added to requests and to limits that are set",
+			expected: corev1.ResourceList{
+				corev1.ResourcePods:           resource.MustParse("1"),
+				corev1.ResourceCPU:            resource.MustParse("350m"),
+				corev1.ResourceRequestsCPU:    resource.MustParse("350m"),
+				corev1.ResourceLimitsCPU:      resource.MustParse("600m"),
+				corev1.ResourceMemory:         resource.MustParse("64Mi"),
+				corev1.ResourceRequestsMemory: resource.MustParse("64Mi"),
+			},
+			podSpec: &corev1.PodSpec{
+				Overhead: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+				Containers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("250m"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("500m"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := quota.ReplicaUsage(test.podSpec, test.limitRanges)
+			if !cmp.Equal(test.expected, result, equateQuantity) {
+				t.Errorf("usage mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, equateQuantity))
+			}
+		})
+	}
+}
+
+func TestCap(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		},
+	}
+
+	cpuQuota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "cpu", Namespace: "test-namespace"},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{
+				corev1.ResourceRequestsCPU: resource.MustParse("4"),
+			},
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Used: corev1.ResourceList{
+				corev1.ResourceRequestsCPU: resource.MustParse("1500m"),
+			},
+		},
+	}
+
+	memoryQuota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "memory", Namespace: "test-namespace"},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("5Gi"),
+				corev1.ResourcePods:   resource.MustParse("100"),
+			},
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Used: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("3Gi"),
+			},
+		},
+	}
+
+	scopedQuota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "scoped", Namespace: "test-namespace"},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{
+				corev1.ResourcePods: resource.MustParse("0"),
+			},
+			Scopes: []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeBestEffort},
+		},
+	}
+
+	exhaustedQuota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "exhausted", Namespace: "test-namespace"},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{
+				corev1.ResourcePods: resource.MustParse("3"),
+			},
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Used: corev1.ResourceList{
+				corev1.ResourcePods: resource.MustParse("4"),
+			},
+		},
+	}
+
+	var tests = []struct {
+		description     string
+		expected        *quota.Recommendation
+		quotas          []interface{}
+		currentReplicas int32
+		metricReplicas  int32
+	}{
+		{
+			description: "No quotas",
+			expected: &quota.Recommendation{
+				Replicas:       10,
+				MetricReplicas: 10,
+			},
+			currentReplicas: 3,
+			metricReplicas:  10,
+		},
+		{
+			description: "Scoped quotas ignored",
+			expected: &quota.Recommendation{
+				Replicas:       10,
+				MetricReplicas: 10,
+			},
+			quotas:          []interface{}{scopedQuota},
+			currentReplicas: 3,
+			metricReplicas:  10,
+		},
+		{
+			description: "Within quota",
+			expected: &quota.Recommendation{
+				Replicas:       4,
+				MetricReplicas: 4,
+				QuotaReplicas:  int32Ptr(5),
+			},
+			quotas:          []interface{}{cpuQuota, memoryQuota},
+			currentReplicas: 3,
+			metricReplicas:  4,
+		},
+		{
+			description: "Capped by most restrictive quota",
+			expected: &quota.Recommendation{
+				Replicas:       5,
+				MetricReplicas: 10,
+				QuotaReplicas:  int32Ptr(5),
+				Reason:         quota.ReasonQuotaLimited,
+			},
+			quotas:          []interface{}{cpuQuota, memoryQuota},
+			currentReplicas: 3,
+			metricReplicas:  10,
+		},
+		{
+			description: "Exhausted quota, stay at current replicas",
+			expected: &quota.Recommendation{
+				Replicas:       4,
+				MetricReplicas: 6,
+				QuotaReplicas:  int32Ptr(4),
+				Reason:         quota.ReasonQuotaLimited,
+			},
+			quotas:          []interface{}{exhaustedQuota},
+			currentReplicas: 4,
+			metricReplicas:  6,
+		},
+		{
+			description: "Scale down never capped",
+			expected: &quota.Recommendation{
+				Replicas:       2,
+				MetricReplicas: 2,
+				QuotaReplicas:  int32Ptr(4),
+			},
+			quotas:          []interface{}{exhaustedQuota},
+			currentReplicas: 4,
+			metricReplicas:  2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			limiter := &quota.Limiter{
+				ResourceQuotaLister: corelisters.NewResourceQuotaLister(newIndexer(test.quotas...)),
+				LimitRangeLister:    corelisters.NewLimitRangeLister(newIndexer()),
+			}
+			result, err := limiter.Cap("test-namespace", podSpec, test.currentReplicas, test.metricReplicas)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("recommendation mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
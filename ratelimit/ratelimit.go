@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit provides a rate limiting decorator around a Gatherer, enforcing a maximum number of metrics
+// gathered per second either globally or per namespace. This stops a misbehaving caller from overloading
+// metrics-server and the custom and external metrics adapters.
+//
+// Each metric spec gathered counts as a single gather, so gathering multiple metric specs at once uses up multiple
+// gathers. Gathers block until they are allowed by the rate limit, or until the context provided is done.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"golang.org/x/time/rate"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Gatherer wraps a Gatherer, limiting the number of metrics gathered per second to Limit with bursts of up to Burst
+// metrics. If PerNamespace is set each namespace is limited separately, otherwise the limit is shared across all
+// namespaces.
+type Gatherer struct {
+	Gatherer     *k8shorizmetrics.Gatherer
+	Limit        rate.Limit
+	Burst        int
+	PerNamespace bool
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewGatherer sets up a rate limited Gatherer allowing the number of gathers per second provided, with bursts of up to
+// burst gathers, shared across all namespaces
+func NewGatherer(gatherer *k8shorizmetrics.Gatherer, gathersPerSecond float64, burst int) *Gatherer {
+	return &Gatherer{
+		Gatherer: gatherer,
+		Limit:    rate.Limit(gathersPerSecond),
+		Burst:    burst,
+	}
+}
+
+// Gather returns all of the metrics gathered based on the metric specs provided, waiting until gathering each metric
+// spec is allowed by the rate limit.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError. If a partial error occurs,
+// meaning some metrics were gathered successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (g *Gatherer) Gather(specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) ([]*metrics.Metric, error) {
+	return g.GatherWithContext(context.Background(), specs, namespace, podSelector)
+}
+
+// GatherWithContext returns all of the metrics gathered based on the metric specs provided, waiting until gathering
+// each metric spec is allowed by the rate limit and passing the context provided to the wrapped Gatherer. If the
+// context is done before gathering is allowed the context's error is returned.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError. If a partial error occurs,
+// meaning some metrics were gathered successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (g *Gatherer) GatherWithContext(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) ([]*metrics.Metric, error) {
+	limiter := g.limiter(namespace)
+	for range specs {
+		err := limiter.Wait(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wait for rate limit: %w", err)
+		}
+	}
+
+	return g.Gatherer.GatherWithContext(ctx, specs, namespace, podSelector)
+}
+
+// GatherSingleMetric returns the metric gathered based on a single metric spec, waiting until gathering is allowed by
+// the rate limit
+func (g *Gatherer) GatherSingleMetric(spec autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) (*metrics.Metric, error) {
+	return g.GatherSingleMetricWithContext(context.Background(), spec, namespace, podSelector)
+}
+
+// GatherSingleMetricWithContext returns the metric gathered based on a single metric spec, waiting until gathering is
+// allowed by the rate limit and passing the context provided to the wrapped Gatherer. If the context is done before
+// gathering is allowed the context's error is returned.
+func (g *Gatherer) GatherSingleMetricWithContext(ctx context.Context, spec autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) (*metrics.Metric, error) {
+	err := g.limiter(namespace).Wait(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for rate limit: %w", err)
+	}
+
+	return g.Gatherer.GatherSingleMetricWithContext(ctx, spec, namespace, podSelector)
+}
+
+func (g *Gatherer) limiter(namespace string) *rate.Limiter {
+	key := ""
+	if g.PerNamespace {
+		key = namespace
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.limiters == nil {
+		g.limiters = map[string]*rate.Limiter{}
+	}
+
+	limiter, exists := g.limiters[key]
+	if !exists {
+		limiter = rate.NewLimiter(g.Limit, g.Burst)
+		g.limiters[key] = limiter
+	}
+
+	return limiter
+}
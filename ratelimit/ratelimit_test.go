@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/ratelimit"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type gather struct {
+	namespace   string
+	specs       int
+	expectedErr error
+}
+
+func TestGather(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+
+	rateLimitErr := errors.New("failed to wait for rate limit: rate: Wait(n=1) would exceed context deadline")
+
+	var tests = []struct {
+		description   string
+		perNamespace  bool
+		gathers       []gather
+		expectedCalls int
+	}{
+		{
+			description: "Global limit, burst used by single gathers",
+			gathers: []gather{
+				{namespace: "a", specs: 1},
+				{namespace: "a", specs: 1},
+				{namespace: "a", specs: 1, expectedErr: rateLimitErr},
+				{namespace: "b", specs: 1, expectedErr: rateLimitErr},
+			},
+			expectedCalls: 2,
+		},
+		{
+			description: "Global limit, burst used by multiple specs",
+			gathers: []gather{
+				{namespace: "a", specs: 2},
+				{namespace: "b", specs: 1, expectedErr: rateLimitErr},
+			},
+			expectedCalls: 2,
+		},
+		{
+			description: "Global limit, more specs than burst",
+			gathers: []gather{
+				{namespace: "a", specs: 3, expectedErr: rateLimitErr},
+			},
+			expectedCalls: 0,
+		},
+		{
+			description:  "Per namespace limit",
+			perNamespace: true,
+			gathers: []gather{
+				{namespace: "a", specs: 2},
+				{namespace: "a", specs: 1, expectedErr: rateLimitErr},
+				{namespace: "b", specs: 1},
+				{namespace: "b", specs: 1},
+				{namespace: "b", specs: 1, expectedErr: rateLimitErr},
+			},
+			expectedCalls: 4,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			calls := 0
+			gatherer := ratelimit.NewGatherer(&k8shorizmetrics.Gatherer{
+				Pods: &fake.PodsGatherer{
+					GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+						calls++
+						return &podsmetrics.Metric{}, nil
+					},
+				},
+			}, 0.001, 2)
+			gatherer.PerNamespace = test.perNamespace
+
+			for i, gather := range test.gathers {
+				ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+
+				var err error
+				if gather.specs == 1 {
+					_, err = gatherer.GatherSingleMetricWithContext(ctx, spec, gather.namespace, labels.Everything())
+				} else {
+					specs := make([]autoscalingv2.MetricSpec, gather.specs)
+					for j := range specs {
+						specs[j] = spec
+					}
+					_, err = gatherer.GatherWithContext(ctx, specs, gather.namespace, labels.Everything())
+				}
+				cancel()
+
+				if !cmp.Equal(&err, &gather.expectedErr, equateErrorMessage) {
+					t.Errorf("gather %d error mismatch (-want +got):\n%s", i, cmp.Diff(gather.expectedErr, err, equateErrorMessage))
+				}
+			}
+
+			if calls != test.expectedCalls {
+				t.Errorf("calls mismatch, want %d, got %d", test.expectedCalls, calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reconciler provides a controller-runtime reconcile.Reconciler which gathers metrics, evaluates them and
+// scales a target resource in the same way the HPA does, allowing operators built with controller-runtime to embed
+// HPA equivalent scaling driven by their own custom resources.
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/scaler"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DefaultSyncPeriod is the period between reconciles of a target if no sync period is set, this matches the HPA
+const DefaultSyncPeriod = 15 * time.Second
+
+// Target describes a resource to scale and the metrics to scale it on, typically built from a custom resource.
+type Target struct {
+	Namespace      string
+	ScaleTargetRef autoscalingv2.CrossVersionObjectReference
+	Metrics        []autoscalingv2.MetricSpec
+	MinReplicas    int32
+	MaxReplicas    int32
+}
+
+// TargetGetter retrieves the target to scale for a reconcile request. If nil is returned with no error, the request is
+// treated as no longer existing and is not requeued.
+type TargetGetter func(ctx context.Context, request reconcile.Request) (*Target, error)
+
+// Reconciler is a controller-runtime reconcile.Reconciler that gathers the target's metrics, evaluates them and
+// updates the replica count of the target through the scale subresource using the Scaler.
+type Reconciler struct {
+	Gatherer   *k8shorizmetrics.Gatherer
+	Evaluator  *k8shorizmetrics.Evaluator
+	Scaler     *scaler.Scaler
+	GetTarget  TargetGetter
+	SyncPeriod time.Duration
+}
+
+var _ reconcile.Reconciler = &Reconciler{}
+
+// Reconcile gathers and evaluates the metrics for the target of the request provided and scales the target to the
+// evaluated replica count, bounded by the target's minimum and maximum replicas. The request is requeued after the
+// sync period. If some metrics fail the target is scaled on the remaining metrics but never scaled down, and the
+// failure is logged using the logger from the context, see k8shorizmetrics.GatherAndEvaluate. As with the HPA, if the
+// target has been scaled to zero replicas scaling is disabled and the target is left unchanged, and the scale target
+// must have a pod selector.
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	target, err := r.GetTarget(ctx, request)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get scale target: %w", err)
+	}
+
+	if target == nil {
+		return reconcile.Result{}, nil
+	}
+
+	scale, err := r.Scaler.GetScale(ctx, target.Namespace, target.ScaleTargetRef)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	syncPeriod := r.SyncPeriod
+	if syncPeriod == 0 {
+		syncPeriod = DefaultSyncPeriod
+	}
+
+	if scale.Spec.Replicas == 0 {
+		return reconcile.Result{RequeueAfter: syncPeriod}, nil
+	}
+
+	if scale.Status.Selector == "" {
+		return reconcile.Result{}, fmt.Errorf("failed to get scale target selector: selector is required for %s %q",
+			target.ScaleTargetRef.Kind, target.ScaleTargetRef.Name)
+	}
+
+	podSelector, err := labels.Parse(scale.Status.Selector)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get scale target selector: %w", err)
+	}
+
+	_, targetReplicas, partialErr := k8shorizmetrics.GatherAndEvaluate(ctx, r.Gatherer, r.Evaluator, target.Metrics,
+		target.Namespace, podSelector, scale.Spec.Replicas, k8shorizmetrics.EvaluateOptions{})
+	if partialErr != nil {
+		if !k8shorizmetrics.IsPartial(partialErr) {
+			return reconcile.Result{}, partialErr
+		}
+		log.FromContext(ctx).Error(partialErr,
+			"Some metrics could not be gathered or evaluated, scaling on the remaining metrics")
+	}
+
+	if targetReplicas < target.MinReplicas {
+		targetReplicas = target.MinReplicas
+	}
+
+	if target.MaxReplicas > 0 && targetReplicas > target.MaxReplicas {
+		targetReplicas = target.MaxReplicas
+	}
+
+	_, err = r.Scaler.Scale(ctx, target.Namespace, target.ScaleTargetRef, targetReplicas)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to scale target: %w", err)
+	}
+
+	return reconcile.Result{RequeueAfter: syncPeriod}, nil
+}
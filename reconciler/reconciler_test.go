@@ -0,0 +1,346 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	resourcemetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/reconciler"
+	"github.com/jthomperoo/k8shorizmetrics/v4/scaler"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakescale "k8s.io/client-go/scale/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcile(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	cpuSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: corev1.ResourceCPU,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: int32Ptr(50),
+			},
+		},
+	}
+
+	deploymentTarget := &reconciler.Target{
+		Namespace: "test-namespace",
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       "test-deployment",
+		},
+		Metrics:     []autoscalingv2.MetricSpec{cpuSpec},
+		MinReplicas: 1,
+		MaxReplicas: 10,
+	}
+
+	partialTarget := &reconciler.Target{
+		Namespace:      deploymentTarget.Namespace,
+		ScaleTargetRef: deploymentTarget.ScaleTargetRef,
+		Metrics: []autoscalingv2.MetricSpec{
+			cpuSpec,
+			{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceMemory,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: int32Ptr(50),
+					},
+				},
+			},
+		},
+		MinReplicas: 1,
+		MaxReplicas: 10,
+	}
+
+	scaleGetReactorWith := func(replicas int32, selector string) k8stesting.ReactionFunc {
+		return func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &autoscalingv1.Scale{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-deployment",
+					Namespace: "test-namespace",
+				},
+				Spec: autoscalingv1.ScaleSpec{
+					Replicas: replicas,
+				},
+				Status: autoscalingv1.ScaleStatus{
+					Replicas: replicas,
+					Selector: selector,
+				},
+			}, nil
+		}
+	}
+	scaleGetReactor := scaleGetReactorWith(3, "app=test")
+
+	resourceGatherer := &fake.ResourceGatherer{
+		GatherReactor: func(resourceName corev1.ResourceName, namespace string, podSelector labels.Selector,
+			cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error) {
+			return &resourcemetrics.Metric{}, nil
+		},
+	}
+
+	partialResourceGatherer := &fake.ResourceGatherer{
+		GatherReactor: func(resourceName corev1.ResourceName, namespace string, podSelector labels.Selector,
+			cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error) {
+			if resourceName == corev1.ResourceMemory {
+				return nil, errors.New("fail to gather")
+			}
+			return &resourcemetrics.Metric{}, nil
+		},
+	}
+
+	resourceEvaluater := func(replicas int32) *fake.ResourceEvaluater {
+		return &fake.ResourceEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+				return replicas, nil
+			},
+		}
+	}
+
+	var tests = []struct {
+		description      string
+		expected         reconcile.Result
+		expectedErr      error
+		expectedReplicas *int32
+		getTarget        reconciler.TargetGetter
+		resourceGatherer k8shorizmetrics.ResourceGatherer
+		resourceEvaluate k8shorizmetrics.ResourceEvaluater
+		scaleGetReactor  k8stesting.ReactionFunc
+		syncPeriod       time.Duration
+	}{
+		{
+			description: "Fail to get target",
+			expectedErr: errors.New("failed to get scale target: fail to get target"),
+			getTarget: func(ctx context.Context, request reconcile.Request) (*reconciler.Target, error) {
+				return nil, errors.New("fail to get target")
+			},
+		},
+		{
+			description: "Target no longer exists, do not requeue",
+			expected:    reconcile.Result{},
+			getTarget: func(ctx context.Context, request reconcile.Request) (*reconciler.Target, error) {
+				return nil, nil
+			},
+		},
+		{
+			description: "Unknown scale target kind",
+			expectedErr: errors.New(`failed to map scale target to resource: no matches for kind "Unknown" in version "apps/v1"`),
+			getTarget: func(ctx context.Context, request reconcile.Request) (*reconciler.Target, error) {
+				return &reconciler.Target{
+					Namespace: "test-namespace",
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+						APIVersion: "apps/v1",
+						Kind:       "Unknown",
+						Name:       "test",
+					},
+				}, nil
+			},
+		},
+		{
+			description: "Fail to get scale subresource",
+			expectedErr: errors.New("failed to get scale subresource: fail to get scale"),
+			getTarget: func(ctx context.Context, request reconcile.Request) (*reconciler.Target, error) {
+				return deploymentTarget, nil
+			},
+			scaleGetReactor: func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("fail to get scale")
+			},
+		},
+		{
+			description: "Scaled to zero, scaling disabled",
+			expected:    reconcile.Result{RequeueAfter: reconciler.DefaultSyncPeriod},
+			getTarget: func(ctx context.Context, request reconcile.Request) (*reconciler.Target, error) {
+				return deploymentTarget, nil
+			},
+			scaleGetReactor:  scaleGetReactorWith(0, "app=test"),
+			resourceGatherer: resourceGatherer,
+			resourceEvaluate: resourceEvaluater(5),
+		},
+		{
+			description: "Empty selector",
+			expectedErr: errors.New(`failed to get scale target selector: selector is required for Deployment "test-deployment"`),
+			getTarget: func(ctx context.Context, request reconcile.Request) (*reconciler.Target, error) {
+				return deploymentTarget, nil
+			},
+			scaleGetReactor:  scaleGetReactorWith(3, ""),
+			resourceGatherer: resourceGatherer,
+			resourceEvaluate: resourceEvaluater(5),
+		},
+		{
+			description: "Invalid selector",
+			expectedErr: errors.New("failed to get scale target selector: unable to parse requirement: found '!', expected: identifier"),
+			getTarget: func(ctx context.Context, request reconcile.Request) (*reconciler.Target, error) {
+				return deploymentTarget, nil
+			},
+			scaleGetReactor:  scaleGetReactorWith(3, "!!"),
+			resourceGatherer: resourceGatherer,
+			resourceEvaluate: resourceEvaluater(5),
+		},
+		{
+			description: "Fail to gather metrics",
+			expectedErr: errors.New("failed to gather metrics: gatherer multi metric error: 1 errors, first error is failed to get resource metric: fail to gather"),
+			getTarget: func(ctx context.Context, request reconcile.Request) (*reconciler.Target, error) {
+				return deploymentTarget, nil
+			},
+			scaleGetReactor: scaleGetReactor,
+			resourceGatherer: &fake.ResourceGatherer{
+				GatherReactor: func(resourceName corev1.ResourceName, namespace string, podSelector labels.Selector,
+					cpuInitializationPeriod, delayOfInitialReadinessStatus time.Duration) (*resourcemetrics.Metric, error) {
+					return nil, errors.New("fail to gather")
+				},
+			},
+		},
+		{
+			description:      "Partial gather failure, scale up on remaining metrics",
+			expected:         reconcile.Result{RequeueAfter: reconciler.DefaultSyncPeriod},
+			expectedReplicas: int32Ptr(6),
+			getTarget: func(ctx context.Context, request reconcile.Request) (*reconciler.Target, error) {
+				return partialTarget, nil
+			},
+			scaleGetReactor:  scaleGetReactor,
+			resourceGatherer: partialResourceGatherer,
+			resourceEvaluate: resourceEvaluater(6),
+		},
+		{
+			description: "Partial gather failure, do not scale down",
+			expected:    reconcile.Result{RequeueAfter: reconciler.DefaultSyncPeriod},
+			getTarget: func(ctx context.Context, request reconcile.Request) (*reconciler.Target, error) {
+				return partialTarget, nil
+			},
+			scaleGetReactor:  scaleGetReactor,
+			resourceGatherer: partialResourceGatherer,
+			resourceEvaluate: resourceEvaluater(1),
+		},
+		{
+			description:      "Scale up to evaluated replicas, default sync period",
+			expected:         reconcile.Result{RequeueAfter: reconciler.DefaultSyncPeriod},
+			expectedReplicas: int32Ptr(5),
+			getTarget: func(ctx context.Context, request reconcile.Request) (*reconciler.Target, error) {
+				return deploymentTarget, nil
+			},
+			scaleGetReactor:  scaleGetReactor,
+			resourceGatherer: resourceGatherer,
+			resourceEvaluate: resourceEvaluater(5),
+		},
+		{
+			description:      "Clamp to max replicas, custom sync period",
+			expected:         reconcile.Result{RequeueAfter: time.Minute},
+			expectedReplicas: int32Ptr(10),
+			getTarget: func(ctx context.Context, request reconcile.Request) (*reconciler.Target, error) {
+				return deploymentTarget, nil
+			},
+			scaleGetReactor:  scaleGetReactor,
+			resourceGatherer: resourceGatherer,
+			resourceEvaluate: resourceEvaluater(20),
+			syncPeriod:       time.Minute,
+		},
+		{
+			description:      "Clamp to min replicas",
+			expected:         reconcile.Result{RequeueAfter: reconciler.DefaultSyncPeriod},
+			expectedReplicas: int32Ptr(1),
+			getTarget: func(ctx context.Context, request reconcile.Request) (*reconciler.Target, error) {
+				return deploymentTarget, nil
+			},
+			scaleGetReactor:  scaleGetReactor,
+			resourceGatherer: resourceGatherer,
+			resourceEvaluate: resourceEvaluater(0),
+		},
+		{
+			description: "No change in replicas, do not update",
+			expected:    reconcile.Result{RequeueAfter: reconciler.DefaultSyncPeriod},
+			getTarget: func(ctx context.Context, request reconcile.Request) (*reconciler.Target, error) {
+				return deploymentTarget, nil
+			},
+			scaleGetReactor:  scaleGetReactor,
+			resourceGatherer: resourceGatherer,
+			resourceEvaluate: resourceEvaluater(3),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var updatedReplicas *int32
+			scaleClient := &fakescale.FakeScaleClient{}
+			if test.scaleGetReactor != nil {
+				scaleClient.AddReactor("get", "deployments", test.scaleGetReactor)
+			}
+			scaleClient.AddReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				scale := action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.Scale)
+				updatedReplicas = &scale.Spec.Replicas
+				return true, scale, nil
+			})
+
+			r := &reconciler.Reconciler{
+				Gatherer: &k8shorizmetrics.Gatherer{
+					Resource: test.resourceGatherer,
+				},
+				Evaluator: &k8shorizmetrics.Evaluator{
+					Resource: test.resourceEvaluate,
+				},
+				Scaler:     scaler.NewScaler(scaleClient, restMapper),
+				GetTarget:  test.getTarget,
+				SyncPeriod: test.syncPeriod,
+			}
+
+			result, err := r.Reconcile(context.Background(), reconcile.Request{})
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("result mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+
+			if !cmp.Equal(test.expectedReplicas, updatedReplicas) {
+				t.Errorf("updated replicas mismatch (-want +got):\n%s", cmp.Diff(test.expectedReplicas, updatedReplicas))
+			}
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
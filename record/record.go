@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package record records gathered metrics to JSON lines and replays them, allowing metrics gathered in production to
+// be captured and served back in tests, for example to reproduce an incident.
+//
+// The Recorder wraps a Gatherer, appending a Record line for every gather made through it, including any metrics that
+// failed to gather. The ReplayGatherer serves the recorded results back, in the order they were recorded, for each
+// metric spec, namespace and pod selector.
+package record
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/clock"
+)
+
+// Record is a single gather, recording the result of gathering each metric spec
+type Record struct {
+	Time        time.Time `json:"time"`
+	Namespace   string    `json:"namespace"`
+	PodSelector string    `json:"podSelector"`
+	Results     []Result  `json:"results"`
+}
+
+// Result is the result of gathering a single metric spec, if the metric failed to gather Error is the error message
+// and Metric is nil
+type Result struct {
+	Spec   autoscalingv2.MetricSpec `json:"spec"`
+	Metric *metrics.Metric          `json:"metric,omitempty"`
+	Error  string                   `json:"error,omitempty"`
+}
+
+// Recorder wraps a Gatherer, writing a Record as a line of JSON to the Writer for every gather. Failing to write a
+// record does not fail the gather, instead the first error is available from Err. Records are written with a single
+// write each, so the Recorder can be used concurrently.
+type Recorder struct {
+	Gatherer *k8shorizmetrics.Gatherer
+	Writer   io.Writer
+	Clock    clock.PassiveClock
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewRecorder sets up a Recorder writing records for the gatherer provided to the writer provided
+func NewRecorder(gatherer *k8shorizmetrics.Gatherer, writer io.Writer) *Recorder {
+	return &Recorder{
+		Gatherer: gatherer,
+		Writer:   writer,
+		Clock:    clock.RealClock{},
+	}
+}
+
+// NewFileRecorder sets up a Recorder appending records for the gatherer provided to the file at the path provided,
+// creating it if it does not exist. The file should be closed using Close once recording is finished.
+func NewFileRecorder(gatherer *k8shorizmetrics.Gatherer, path string) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording file: %w", err)
+	}
+	return NewRecorder(gatherer, file), nil
+}
+
+// Gather returns all of the metrics gathered based on the metric specs provided, recording the result of gathering
+// each metric spec.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError. If a partial error occurs,
+// meaning some metrics were gathered successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (r *Recorder) Gather(specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) ([]*metrics.Metric, error) {
+	return r.GatherWithContext(context.Background(), specs, namespace, podSelector)
+}
+
+// GatherWithContext returns all of the metrics gathered based on the metric specs provided, recording the result of
+// gathering each metric spec and passing the context provided to the wrapped Gatherer.
+// If an error occurs gathering any metric this will return a GatherMultiMetricError. If a partial error occurs,
+// meaning some metrics were gathered successfully and others failed, the 'Partial' property of this error will be
+// set to true.
+func (r *Recorder) GatherWithContext(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) ([]*metrics.Metric, error) {
+	gatherResults := r.Gatherer.GatherResultsWithContext(ctx, specs, namespace, podSelector)
+
+	results := make([]Result, len(gatherResults))
+	gathered := make([]*metrics.Metric, len(gatherResults))
+	errs := make([]error, len(gatherResults))
+	for i, gatherResult := range gatherResults {
+		results[i] = newResult(gatherResult.Spec, gatherResult.Metric, gatherResult.Err)
+		gathered[i], errs[i] = gatherResult.Metric, gatherResult.Err
+	}
+	r.record(namespace, podSelector, results)
+
+	return combineGathered(specs, gathered, errs)
+}
+
+// GatherSingleMetric returns the metric gathered based on a single metric spec, recording the result
+func (r *Recorder) GatherSingleMetric(spec autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) (*metrics.Metric, error) {
+	return r.GatherSingleMetricWithContext(context.Background(), spec, namespace, podSelector)
+}
+
+// GatherSingleMetricWithContext returns the metric gathered based on a single metric spec, recording the result and
+// passing the context provided to the wrapped Gatherer
+func (r *Recorder) GatherSingleMetricWithContext(ctx context.Context, spec autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) (*metrics.Metric, error) {
+	gathered, err := r.Gatherer.GatherSingleMetricWithContext(ctx, spec, namespace, podSelector)
+	if err != nil {
+		gathered = nil
+	}
+	r.record(namespace, podSelector, []Result{newResult(spec, gathered, err)})
+	return gathered, err
+}
+
+// Err returns the first error that occurred writing a record, or nil if every record has been written
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Close closes the Writer if it is an io.Closer, for example the file opened by NewFileRecorder
+func (r *Recorder) Close() error {
+	if closer, ok := r.Writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (r *Recorder) record(namespace string, podSelector labels.Selector, results []Result) {
+	record := &Record{
+		Time:        r.Clock.Now(),
+		Namespace:   namespace,
+		PodSelector: selectorString(podSelector),
+		Results:     results,
+	}
+
+	line, err := json.Marshal(record)
+	if err == nil {
+		line = append(line, '\n')
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err == nil {
+		_, err = r.Writer.Write(line)
+	}
+	if err != nil && r.err == nil {
+		r.err = fmt.Errorf("failed to write record: %w", err)
+	}
+}
+
+func newResult(spec autoscalingv2.MetricSpec, gathered *metrics.Metric, err error) Result {
+	result := Result{
+		Spec:   spec,
+		Metric: gathered,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func selectorString(podSelector labels.Selector) string {
+	if podSelector == nil {
+		return ""
+	}
+	return podSelector.String()
+}
+
+// combineGathered combines the results of gathering multiple metric specs in the order of the specs in the same way
+// as the Gatherer, returning a GathererMultiMetricError if any failed
+func combineGathered(specs []autoscalingv2.MetricSpec, gathered []*metrics.Metric,
+	errs []error) ([]*metrics.Metric, error) {
+	combinedMetrics := []*metrics.Metric{}
+	gatherErrors := []error{}
+	for i := range gathered {
+		if errs[i] != nil {
+			gatherErrors = append(gatherErrors, &k8shorizmetrics.MetricSpecError{
+				Index: i,
+				Spec:  specs[i],
+				Err:   errs[i],
+			})
+			continue
+		}
+		combinedMetrics = append(combinedMetrics, gathered[i])
+	}
+
+	if len(gatherErrors) > 0 {
+		partial := len(gatherErrors) < len(gathered)
+		if partial {
+			return combinedMetrics, &k8shorizmetrics.GathererMultiMetricError{
+				Partial: partial,
+				Errors:  gatherErrors,
+			}
+		}
+
+		return nil, &k8shorizmetrics.GathererMultiMetricError{
+			Partial: partial,
+			Errors:  gatherErrors,
+		}
+	}
+
+	return combinedMetrics, nil
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package record_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/record"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+type failingWriter struct{}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func podsSpec(metricName string) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: metricName,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type: autoscalingv2.AverageValueMetricType,
+			},
+		},
+	}
+}
+
+func newGatherer() *k8shorizmetrics.Gatherer {
+	calls := int64(0)
+	return &k8shorizmetrics.Gatherer{
+		Pods: &fake.PodsGatherer{
+			GatherReactor: func(metricName, namespace string, podSelector, metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+				if metricName == "failing" {
+					return nil, errors.New("fail to gather")
+				}
+				calls++
+				return &podsmetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": {Value: calls},
+					},
+					ReadyPodCount: 1,
+					TotalPods:     1,
+				}, nil
+			},
+		},
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	recordedTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	podSelector := labels.SelectorFromSet(labels.Set{"app": "test"})
+	specs := []autoscalingv2.MetricSpec{podsSpec("requests"), podsSpec("failing")}
+
+	var buffer bytes.Buffer
+	recorder := record.NewRecorder(newGatherer(), &buffer)
+	recorder.Clock = clocktesting.NewFakePassiveClock(recordedTime)
+
+	gathered, gatherErr := recorder.Gather(specs, "test", podSelector)
+	singleGathered, singleErr := recorder.GatherSingleMetric(specs[0], "test", podSelector)
+	_, singleFailedErr := recorder.GatherSingleMetric(specs[1], "test", podSelector)
+
+	expectedErr := errors.New("gatherer multi metric error: 1 errors, first error is failed to get pods metric: fail to gather")
+	if !cmp.Equal(&gatherErr, &expectedErr, equateErrorMessage) {
+		t.Errorf("gather error mismatch (-want +got):\n%s", cmp.Diff(expectedErr, gatherErr, equateErrorMessage))
+	}
+	var multiErr *k8shorizmetrics.GathererMultiMetricError
+	if !errors.As(gatherErr, &multiErr) || !multiErr.Partial {
+		t.Errorf("expected partial gatherer multi metric error, got %v", gatherErr)
+	}
+	if len(gathered) != 1 || singleErr != nil || singleFailedErr == nil {
+		t.Fatalf("unexpected gather results: %v %v %v", gathered, singleErr, singleFailedErr)
+	}
+	if recorder.Err() != nil {
+		t.Fatalf("unexpected recording error: %v", recorder.Err())
+	}
+
+	records, err := record.ReadRecords(bytes.NewReader(buffer.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error reading records: %v", err)
+	}
+	expectedRecords := []*record.Record{
+		{
+			Time:        recordedTime,
+			Namespace:   "test",
+			PodSelector: "app=test",
+			Results: []record.Result{
+				{Spec: specs[0], Metric: gathered[0]},
+				{Spec: specs[1], Error: "failed to get pods metric: fail to gather"},
+			},
+		},
+		{
+			Time:        recordedTime,
+			Namespace:   "test",
+			PodSelector: "app=test",
+			Results: []record.Result{
+				{Spec: specs[0], Metric: singleGathered},
+			},
+		},
+		{
+			Time:        recordedTime,
+			Namespace:   "test",
+			PodSelector: "app=test",
+			Results: []record.Result{
+				{Spec: specs[1], Error: "failed to get pods metric: fail to gather"},
+			},
+		},
+	}
+	if !cmp.Equal(expectedRecords, records) {
+		t.Errorf("records mismatch (-want +got):\n%s", cmp.Diff(expectedRecords, records))
+	}
+
+	replay, err := record.NewReplayGathererFromReader(bytes.NewReader(buffer.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error setting up replay: %v", err)
+	}
+	if replay.Remaining() != 4 {
+		t.Errorf("expected 4 results to replay, got %d", replay.Remaining())
+	}
+
+	replayed, replayedErr := replay.Gather(specs, "test", podSelector)
+	if !cmp.Equal(gathered, replayed) {
+		t.Errorf("replayed metrics mismatch (-want +got):\n%s", cmp.Diff(gathered, replayed))
+	}
+	if !cmp.Equal(&replayedErr, &gatherErr, equateErrorMessage) {
+		t.Errorf("replayed error mismatch (-want +got):\n%s", cmp.Diff(gatherErr, replayedErr, equateErrorMessage))
+	}
+	if !errors.As(replayedErr, &multiErr) || !multiErr.Partial {
+		t.Errorf("expected partial gatherer multi metric error, got %v", replayedErr)
+	}
+
+	replayedSingle, err := replay.GatherSingleMetricWithContext(context.Background(), specs[0], "test", podSelector)
+	if err != nil {
+		t.Fatalf("unexpected error replaying single metric: %v", err)
+	}
+	if !cmp.Equal(singleGathered, replayedSingle) {
+		t.Errorf("replayed single metric mismatch (-want +got):\n%s", cmp.Diff(singleGathered, replayedSingle))
+	}
+
+	// Recorded results are served per metric spec, so the failed single gather can be replayed as part of a gather
+	_, err = replay.Gather(specs[1:], "test", podSelector)
+	expectedErr = errors.New("gatherer multi metric error: 1 errors, first error is failed to get pods metric: fail to gather")
+	if !cmp.Equal(&err, &expectedErr, equateErrorMessage) {
+		t.Errorf("replayed error mismatch (-want +got):\n%s", cmp.Diff(expectedErr, err, equateErrorMessage))
+	}
+
+	if replay.Remaining() != 0 {
+		t.Errorf("expected no results left to replay, got %d", replay.Remaining())
+	}
+
+	_, err = replay.GatherSingleMetric(specs[0], "test", podSelector)
+	if !errors.Is(err, record.ErrNoRecordedResult) {
+		t.Errorf("expected no recorded result error, got %v", err)
+	}
+	expectedErr = errors.New("no recorded result remaining for Pods metric in namespace 'test'")
+	if !cmp.Equal(&err, &expectedErr, equateErrorMessage) {
+		t.Errorf("exhausted error mismatch (-want +got):\n%s", cmp.Diff(expectedErr, err, equateErrorMessage))
+	}
+}
+
+func TestReplayDifferentPodSelector(t *testing.T) {
+	var buffer bytes.Buffer
+	recorder := record.NewRecorder(newGatherer(), &buffer)
+	_, err := recorder.GatherSingleMetric(podsSpec("requests"), "test", labels.SelectorFromSet(labels.Set{"app": "a"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	replay, err := record.NewReplayGathererFromReader(&buffer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = replay.GatherSingleMetric(podsSpec("requests"), "test", labels.SelectorFromSet(labels.Set{"app": "b"}))
+	if !errors.Is(err, record.ErrNoRecordedResult) {
+		t.Errorf("expected no recorded result error, got %v", err)
+	}
+	_, err = replay.GatherSingleMetric(podsSpec("requests"), "other", labels.SelectorFromSet(labels.Set{"app": "a"}))
+	if !errors.Is(err, record.ErrNoRecordedResult) {
+		t.Errorf("expected no recorded result error, got %v", err)
+	}
+}
+
+func TestRecorderWriteError(t *testing.T) {
+	recorder := record.NewRecorder(newGatherer(), &failingWriter{})
+
+	gathered, err := recorder.Gather([]autoscalingv2.MetricSpec{podsSpec("requests")}, "test", labels.Everything())
+	if err != nil {
+		t.Fatalf("unexpected gather error: %v", err)
+	}
+	expected := []*metrics.Metric{
+		{
+			Spec: podsSpec("requests"),
+			Pods: &podsmetrics.Metric{
+				PodMetricsInfo: podmetrics.MetricsInfo{
+					"pod-1": {Value: 1},
+				},
+				ReadyPodCount: 1,
+				TotalPods:     1,
+			},
+		},
+	}
+	if !cmp.Equal(expected, gathered) {
+		t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(expected, gathered))
+	}
+
+	expectedErr := "failed to write record: disk full"
+	if recorder.Err() == nil || recorder.Err().Error() != expectedErr {
+		t.Errorf("expected recording error '%s', got %v", expectedErr, recorder.Err())
+	}
+}
+
+func TestReadRecords(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    []*record.Record
+		expectedErr string
+		data        string
+	}{
+		{
+			description: "Empty",
+			expected:    []*record.Record{},
+		},
+		{
+			description: "Invalid record",
+			expectedErr: "invalid record on line 2: unexpected end of JSON input",
+			data:        "{\"namespace\":\"test\"}\n{\n",
+		},
+		{
+			description: "Empty lines skipped",
+			expected: []*record.Record{
+				{Namespace: "a"},
+				{Namespace: "b"},
+			},
+			data: "{\"namespace\":\"a\"}\n\n  \n{\"namespace\":\"b\"}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			records, err := record.ReadRecords(bytes.NewReader([]byte(test.data)))
+			if test.expectedErr != "" {
+				if err == nil || err.Error() != test.expectedErr {
+					t.Errorf("expected error '%s', got %v", test.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(test.expected, records) {
+				t.Errorf("records mismatch (-want +got):\n%s", cmp.Diff(test.expected, records))
+			}
+		})
+	}
+}
+
+func TestFileRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+
+	// Records are appended, so each recorder adds to the file
+	var gathered []*metrics.Metric
+	for i := 0; i < 2; i++ {
+		recorder, err := record.NewFileRecorder(newGatherer(), path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		gatheredMetric, err := recorder.GatherSingleMetric(podsSpec("requests"), "test", labels.Everything())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		gathered = append(gathered, gatheredMetric)
+		err = recorder.Close()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	replay, err := record.NewReplayGathererFromFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range gathered {
+		replayed, err := replay.GatherSingleMetric(podsSpec("requests"), "test", labels.Everything())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !cmp.Equal(gathered[i], replayed) {
+			t.Errorf("replayed metric %d mismatch (-want +got):\n%s", i, cmp.Diff(gathered[i], replayed))
+		}
+	}
+
+	_, err = record.NewReplayGathererFromFile(filepath.Join(t.TempDir(), "missing.jsonl"))
+	if err == nil {
+		t.Errorf("expected error opening missing file")
+	}
+}
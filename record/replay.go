@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package record
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ErrNoRecordedResult occurs when replaying a metric spec which has no recorded results left to serve
+var ErrNoRecordedResult = errors.New("no recorded result remaining")
+
+// maxRecordSize is the largest record line that can be read, records include the metrics of every pod so can be large
+const maxRecordSize = 64 * 1024 * 1024
+
+// ReadRecords reads the JSON lines records provided, skipping any empty lines
+func ReadRecords(reader io.Reader) ([]*Record, error) {
+	records := []*Record{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, maxRecordSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		record := &Record{}
+		err := json.Unmarshal(data, record)
+		if err != nil {
+			return nil, fmt.Errorf("invalid record on line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+	return records, nil
+}
+
+// ReplayGatherer serves recorded results in place of gathering metrics. Results are served in the order they were
+// recorded for each metric spec, namespace and pod selector, each result is served once and once all of the results
+// for a metric spec have been served gathering it fails with ErrNoRecordedResult. Recorded errors are replayed with the
+// same error message.
+type ReplayGatherer struct {
+	mu      sync.Mutex
+	results map[string][]Result
+}
+
+// NewReplayGatherer sets up a ReplayGatherer serving the results of the records provided
+func NewReplayGatherer(records []*Record) *ReplayGatherer {
+	results := map[string][]Result{}
+	for _, record := range records {
+		for _, result := range record.Results {
+			key := replayKey(result.Spec, record.Namespace, record.PodSelector)
+			results[key] = append(results[key], result)
+		}
+	}
+	return &ReplayGatherer{
+		results: results,
+	}
+}
+
+// NewReplayGathererFromReader sets up a ReplayGatherer serving the results of the JSON lines records provided
+func NewReplayGathererFromReader(reader io.Reader) (*ReplayGatherer, error) {
+	records, err := ReadRecords(reader)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayGatherer(records), nil
+}
+
+// NewReplayGathererFromFile sets up a ReplayGatherer serving the results of the JSON lines records in the file at the
+// path provided, for example a file written by a Recorder set up with NewFileRecorder
+func NewReplayGathererFromFile(path string) (*ReplayGatherer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording file: %w", err)
+	}
+	defer file.Close()
+	return NewReplayGathererFromReader(file)
+}
+
+// Gather returns the next recorded result for each of the metric specs provided.
+// If an error was recorded, or there is no recorded result remaining, for any metric this will return a
+// GatherMultiMetricError. If a partial error occurs, meaning some metrics were replayed successfully and others
+// failed, the 'Partial' property of this error will be set to true.
+func (g *ReplayGatherer) Gather(specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) ([]*metrics.Metric, error) {
+	return g.GatherWithContext(context.Background(), specs, namespace, podSelector)
+}
+
+// GatherWithContext returns the next recorded result for each of the metric specs provided, returning the context's
+// error without replaying if the context is done. See Gather.
+func (g *ReplayGatherer) GatherWithContext(ctx context.Context, specs []autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) ([]*metrics.Metric, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	gathered := make([]*metrics.Metric, len(specs))
+	errs := make([]error, len(specs))
+	for i, spec := range specs {
+		gathered[i], errs[i] = g.next(spec, namespace, podSelector)
+	}
+	return combineGathered(specs, gathered, errs)
+}
+
+// GatherSingleMetric returns the next recorded result for the metric spec provided
+func (g *ReplayGatherer) GatherSingleMetric(spec autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) (*metrics.Metric, error) {
+	return g.GatherSingleMetricWithContext(context.Background(), spec, namespace, podSelector)
+}
+
+// GatherSingleMetricWithContext returns the next recorded result for the metric spec provided, returning the
+// context's error without replaying if the context is done
+func (g *ReplayGatherer) GatherSingleMetricWithContext(ctx context.Context, spec autoscalingv2.MetricSpec,
+	namespace string, podSelector labels.Selector) (*metrics.Metric, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return g.next(spec, namespace, podSelector)
+}
+
+// Remaining returns the number of recorded results which have not yet been served
+func (g *ReplayGatherer) Remaining() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	remaining := 0
+	for _, results := range g.results {
+		remaining += len(results)
+	}
+	return remaining
+}
+
+func (g *ReplayGatherer) next(spec autoscalingv2.MetricSpec, namespace string,
+	podSelector labels.Selector) (*metrics.Metric, error) {
+	key := replayKey(spec, namespace, selectorString(podSelector))
+
+	g.mu.Lock()
+	results := g.results[key]
+	if len(results) == 0 {
+		g.mu.Unlock()
+		return nil, fmt.Errorf("%w for %s metric in namespace '%s'", ErrNoRecordedResult,
+			string(spec.Type), namespace)
+	}
+	result := results[0]
+	g.results[key] = results[1:]
+	g.mu.Unlock()
+
+	if result.Error != "" {
+		return nil, errors.New(result.Error)
+	}
+	return result.Metric, nil
+}
+
+func replayKey(spec autoscalingv2.MetricSpec, namespace string, podSelector string) string {
+	// Marshalling a metric spec cannot fail
+	specJSON, _ := json.Marshal(spec)
+	return namespace + "\n" + podSelector + "\n" + string(specJSON)
+}
@@ -25,7 +25,9 @@ https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscale
 https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscaler/replica_calculator.go
 */
 
-// Package replicas provides utilities for getting replica counts from the K8s APIs.
+// Package replicas provides the replica count calculations used by the HPA, applying a tolerance to usage ratios and
+// handling missing and ignored pods. These can be reused when evaluating bespoke metric sources to keep parity with
+// the HPA.
 package replicas
 
 import (
@@ -47,9 +49,11 @@ type Calculator interface {
 		ignoredPods sets.String) int32
 }
 
-// ReplicaCalculator uses a tolerance provided to calculate replica counts for scaling up/down/remaining the same
+// ReplicaCalculator uses a tolerance provided to calculate replica counts for scaling up/down/remaining the same.
+// Rounding is how fractional replica counts are rounded, if not set they are rounded up in the same way as the HPA.
 type ReplicaCalculator struct {
 	Tolerance float64
+	Rounding  Rounding
 }
 
 // GetUsageRatioReplicaCount calculates the replica count based on the number of replicas, number of ready pods and the
@@ -61,10 +65,10 @@ func (r *ReplicaCalculator) GetUsageRatioReplicaCount(currentReplicas int32, usa
 			// return the current replicas if the change would be too small
 			return currentReplicas
 		}
-		replicaCount = int32(math.Ceil(usageRatio * float64(readyPodCount)))
+		replicaCount = r.Rounding.Round(usageRatio * float64(readyPodCount))
 	} else {
 		// Scale to zero or n pods depending on usageRatio
-		replicaCount = int32(math.Ceil(usageRatio))
+		replicaCount = r.Rounding.Round(usageRatio)
 	}
 
 	return replicaCount
@@ -96,7 +100,7 @@ func (r *ReplicaCalculator) GetPlainMetricReplicaCount(metrics podmetrics.Metric
 		}
 
 		// if we don't have any unready or missing pods, we can calculate the new replica count now
-		return int32(math.Ceil(usageRatio * float64(readyPodCount)))
+		return r.Rounding.Round(usageRatio * float64(readyPodCount))
 	}
 
 	if len(missingPods) > 0 {
@@ -131,5 +135,5 @@ func (r *ReplicaCalculator) GetPlainMetricReplicaCount(metrics podmetrics.Metric
 
 	// return the result, where the number of replicas considered is
 	// however many replicas factored into our calculation
-	return int32(math.Ceil(newUsageRatio * float64(len(metrics))))
+	return r.Rounding.Round(newUsageRatio * float64(len(metrics)))
 }
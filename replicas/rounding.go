@@ -0,0 +1,44 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicas
+
+import "math"
+
+// Rounding is the strategy used to round fractional replica counts to whole replica counts
+type Rounding string
+
+const (
+	// RoundingCeil rounds fractional replica counts up, matching the HPA. This is the default.
+	RoundingCeil Rounding = "ceil"
+	// RoundingRound rounds fractional replica counts to the nearest whole replica count, rounding half away from zero
+	RoundingRound Rounding = "round"
+	// RoundingFloor rounds fractional replica counts down. This can leave usage above the target, for example a usage
+	// ratio of 1.5 with a single replica keeps a single replica.
+	RoundingFloor Rounding = "floor"
+)
+
+// Round rounds the fractional replica count provided to a whole replica count using the rounding strategy, if the
+// rounding strategy is not set RoundingCeil is used
+func (r Rounding) Round(replicaCount float64) int32 {
+	switch r {
+	case RoundingRound:
+		return int32(math.Round(replicaCount))
+	case RoundingFloor:
+		return int32(math.Floor(replicaCount))
+	}
+	return int32(math.Ceil(replicaCount))
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicas_test
+
+import (
+	"testing"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/replicas"
+)
+
+func TestRounding_Round(t *testing.T) {
+	var tests = []struct {
+		description  string
+		expected     int32
+		rounding     replicas.Rounding
+		replicaCount float64
+	}{
+		{
+			description:  "Default, rounds up",
+			expected:     3,
+			rounding:     "",
+			replicaCount: 2.1,
+		},
+		{
+			description:  "Ceil, rounds up",
+			expected:     3,
+			rounding:     replicas.RoundingCeil,
+			replicaCount: 2.1,
+		},
+		{
+			description:  "Ceil, whole replica count",
+			expected:     2,
+			rounding:     replicas.RoundingCeil,
+			replicaCount: 2,
+		},
+		{
+			description:  "Round, rounds down",
+			expected:     2,
+			rounding:     replicas.RoundingRound,
+			replicaCount: 2.4,
+		},
+		{
+			description:  "Round, rounds half up",
+			expected:     3,
+			rounding:     replicas.RoundingRound,
+			replicaCount: 2.5,
+		},
+		{
+			description:  "Floor, rounds down",
+			expected:     2,
+			rounding:     replicas.RoundingFloor,
+			replicaCount: 2.9,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := test.rounding.Round(test.replicaCount)
+			if result != test.expected {
+				t.Errorf("rounding mismatch, want %d, got %d", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestReplicaCalculate_Rounding(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    int32
+		rounding    replicas.Rounding
+	}{
+		{
+			description: "Ceil",
+			expected:    4,
+			rounding:    replicas.RoundingCeil,
+		},
+		{
+			description: "Round",
+			expected:    4,
+			rounding:    replicas.RoundingRound,
+		},
+		{
+			description: "Floor",
+			expected:    3,
+			rounding:    replicas.RoundingFloor,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			calc := replicas.ReplicaCalculator{
+				Tolerance: 0.1,
+				Rounding:  test.rounding,
+			}
+
+			// Usage ratio of 1.2 across 3 pods, 3.6 replicas
+			usageRatioResult := calc.GetUsageRatioReplicaCount(3, 1.2, 3)
+			if usageRatioResult != test.expected {
+				t.Errorf("usage ratio replica count mismatch, want %d, got %d", test.expected, usageRatioResult)
+			}
+
+			plainMetricResult := calc.GetPlainMetricReplicaCount(podmetrics.MetricsInfo{
+				"pod-1": podmetrics.Metric{Value: 120},
+				"pod-2": podmetrics.Metric{Value: 120},
+				"pod-3": podmetrics.Metric{Value: 120},
+			}, 3, 100, 3, nil, nil)
+			if plainMetricResult != test.expected {
+				t.Errorf("plain metric replica count mismatch, want %d, got %d", test.expected, plainMetricResult)
+			}
+		})
+	}
+}
@@ -28,22 +28,49 @@ https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscale
 package resource
 
 import (
+	"context"
 	"fmt"
 	"math"
 
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/replicas"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/replicas"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 )
 
-// Evaluate (resource) calculates a replica count evaluation, using the tolerance and calculater provided
+// Evaluate (resource) calculates a replica count evaluation, using the tolerance and calculater provided. Rounding is
+// how fractional replica counts are rounded for utilization targets, if not set they are rounded up in the same way as
+// the HPA.
 type Evaluate struct {
 	Calculater replicas.Calculator
+	Rounding   replicas.Rounding
 }
 
-// Evaluate calculates an evaluation based on the metric provided and the current number of replicas
+// EvaluateWithContext calculates an evaluation based on the metric provided and the current number of replicas,
+// returning the context's error without evaluating if the context is done
+func (e *Evaluate) EvaluateWithContext(ctx context.Context, currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return e.Evaluate(currentReplicas, gatheredMetric, tolerance)
+}
+
+// Evaluate calculates an evaluation based on the metric provided and the current number of replicas. Value targets
+// are compared against the total usage across all pods, average value targets against the average usage per pod and
+// utilization targets against the average usage as a percentage of the pod requests.
 func (e *Evaluate) Evaluate(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+	if gatheredMetric.Spec.Resource.Target.Value != nil {
+		if len(gatheredMetric.Resource.PodMetricsInfo) == 0 {
+			return 0, fmt.Errorf("%w for resource metric", metricsclient.ErrNoMetrics)
+		}
+		usageRatio := float64(totalValue(gatheredMetric.Resource.PodMetricsInfo)) /
+			float64(gatheredMetric.Spec.Resource.Target.Value.MilliValue())
+		replicaCount := e.Calculater.GetUsageRatioReplicaCount(currentReplicas, usageRatio,
+			gatheredMetric.Resource.ReadyPodCount)
+		return replicaCount, nil
+	}
+
 	if gatheredMetric.Spec.Resource.Target.AverageValue != nil {
 		replicaCount := e.Calculater.GetPlainMetricReplicaCount(
 			gatheredMetric.Resource.PodMetricsInfo,
@@ -58,7 +85,8 @@ func (e *Evaluate) Evaluate(currentReplicas int32, gatheredMetric *metrics.Metri
 
 	if gatheredMetric.Spec.Resource.Target.AverageUtilization != nil {
 		metrics := gatheredMetric.Resource.PodMetricsInfo
-		requests := gatheredMetric.Resource.Requests
+		// Requests are the pod limits instead if the utilization basis is limits
+		requests := gatheredMetric.Resource.UtilizationBase()
 		targetUtilization := *gatheredMetric.Spec.Resource.Target.AverageUtilization
 		ignoredPods := gatheredMetric.Resource.IgnoredPods
 		missingPods := gatheredMetric.Resource.MissingPods
@@ -81,7 +109,7 @@ func (e *Evaluate) Evaluate(currentReplicas int32, gatheredMetric *metrics.Metri
 				// return the current replicas if the change would be too small
 				return currentReplicas, nil
 			}
-			targetReplicas := int32(math.Ceil(usageRatio * float64(readyPodCount)))
+			targetReplicas := e.Rounding.Round(usageRatio * float64(readyPodCount))
 			// if we don't have any unready or missing pods, we can calculate the new replica count now
 			return targetReplicas, nil
 		}
@@ -122,9 +150,56 @@ func (e *Evaluate) Evaluate(currentReplicas int32, gatheredMetric *metrics.Metri
 
 		// return the result, where the number of replicas considered is
 		// however many replicas factored into our calculation
-		targetReplicas := int32(math.Ceil(newUsageRatio * float64(len(metrics))))
+		targetReplicas := e.Rounding.Round(newUsageRatio * float64(len(metrics)))
 		return targetReplicas, nil
 	}
 
-	return 0, fmt.Errorf("invalid resource metric source: neither a utilization target nor a value target was set")
+	return 0, &metrics.InvalidMetricSourceError{
+		SourceType: autoscalingv2.ResourceMetricSourceType,
+		Reason:     "neither a utilization target nor a value target was set",
+	}
+}
+
+// UsageRatio returns the ratio of the current usage of the metric provided to its target, before any adjustment for
+// missing or ignored pods. A ratio above 1 means usage is above the target.
+func (e *Evaluate) UsageRatio(currentReplicas int32, gatheredMetric *metrics.Metric) (float64, error) {
+	if gatheredMetric.Spec.Resource.Target.Value != nil {
+		if len(gatheredMetric.Resource.PodMetricsInfo) == 0 {
+			return 0, fmt.Errorf("%w for resource metric", metricsclient.ErrNoMetrics)
+		}
+		return float64(totalValue(gatheredMetric.Resource.PodMetricsInfo)) /
+			float64(gatheredMetric.Spec.Resource.Target.Value.MilliValue()), nil
+	}
+
+	if gatheredMetric.Spec.Resource.Target.AverageValue != nil {
+		if len(gatheredMetric.Resource.PodMetricsInfo) == 0 {
+			return 0, fmt.Errorf("%w for resource metric", metricsclient.ErrNoMetrics)
+		}
+		usageRatio, _ := metricsclient.GetMetricUtilizationRatio(gatheredMetric.Resource.PodMetricsInfo,
+			gatheredMetric.Spec.Resource.Target.AverageValue.MilliValue())
+		return usageRatio, nil
+	}
+
+	if gatheredMetric.Spec.Resource.Target.AverageUtilization != nil {
+		usageRatio, _, _, err := metricsclient.GetResourceUtilizationRatio(gatheredMetric.Resource.PodMetricsInfo,
+			gatheredMetric.Resource.UtilizationBase(), *gatheredMetric.Spec.Resource.Target.AverageUtilization)
+		if err != nil {
+			return 0, err
+		}
+		return usageRatio, nil
+	}
+
+	return 0, &metrics.InvalidMetricSourceError{
+		SourceType: autoscalingv2.ResourceMetricSourceType,
+		Reason:     "neither a utilization target nor a value target was set",
+	}
+}
+
+// totalValue returns the total of the pod metrics provided
+func totalValue(podMetrics podmetrics.MetricsInfo) int64 {
+	total := int64(0)
+	for _, podMetric := range podMetrics {
+		total += podMetric.Value
+	}
+	return total
 }
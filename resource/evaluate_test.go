@@ -17,17 +17,18 @@ limitations under the License.
 package resource_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/fake"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/replicas"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
 	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
 	resourcemetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/replicas"
+	"github.com/jthomperoo/k8shorizmetrics/v4/resource"
 	v2 "k8s.io/api/autoscaling/v2"
 	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -90,6 +91,64 @@ func TestEvaluate(t *testing.T) {
 				},
 			},
 		},
+		{
+			"Fail, value, no metrics for pods",
+			0,
+			errors.New("no metrics returned for resource metric"),
+			nil,
+			0,
+			3,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Resource: &v2.ResourceMetricSource{
+						Target: v2.MetricTarget{
+							Value: k8sresource.NewMilliQuantity(50, k8sresource.DecimalSI),
+						},
+					},
+				},
+				Resource: &resourcemetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{},
+					ReadyPodCount:  3,
+				},
+			},
+		},
+		{
+			"Success, value, total usage compared to target",
+			4,
+			nil,
+			&fake.Calculate{
+				GetUsageRatioReplicaCountReactor: func(currentReplicas int32, usageRatio float64, readyPodCount int64) int32 {
+					if usageRatio != 2 {
+						return 0
+					}
+					return 4
+				},
+			},
+			0,
+			2,
+			&metrics.Metric{
+				Spec: v2.MetricSpec{
+					Resource: &v2.ResourceMetricSource{
+						Target: v2.MetricTarget{
+							Value: k8sresource.NewMilliQuantity(50, k8sresource.DecimalSI),
+						},
+					},
+				},
+				Resource: &resourcemetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{
+							Value: 40,
+						},
+						"pod-2": podmetrics.Metric{
+							Value: 60,
+						},
+					},
+					ReadyPodCount: 2,
+					IgnoredPods:   sets.String{},
+					MissingPods:   sets.String{},
+				},
+			},
+		},
 		{
 			"Fail, average utilization, no metrics for pods",
 			0,
@@ -399,3 +458,283 @@ func TestEvaluate(t *testing.T) {
 		})
 	}
 }
+
+func TestEvaluateWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	eval := resource.Evaluate{
+		Calculater: &fake.Calculate{},
+	}
+
+	_, err := eval.EvaluateWithContext(ctx, 3, &metrics.Metric{}, 0.1)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled error, got %v", err)
+	}
+}
+
+func TestEvaluateRounding(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    int32
+		rounding    replicas.Rounding
+	}{
+		{
+			description: "Default, rounds up",
+			expected:    6,
+			rounding:    "",
+		},
+		{
+			description: "Round",
+			expected:    5,
+			rounding:    replicas.RoundingRound,
+		},
+		{
+			description: "Floor",
+			expected:    5,
+			rounding:    replicas.RoundingFloor,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluater := resource.Evaluate{
+				Rounding: test.rounding,
+			}
+			// 130% utilization against a 50% target across 2 pods, 5.2 replicas
+			evaluation, err := evaluater.Evaluate(2, &metrics.Metric{
+				Spec: v2.MetricSpec{
+					Resource: &v2.ResourceMetricSource{
+						Target: v2.MetricTarget{
+							AverageUtilization: testutil.Int32Ptr(50),
+						},
+					},
+				},
+				Resource: &resourcemetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 13},
+						"pod-2": podmetrics.Metric{Value: 13},
+					},
+					Requests: map[string]int64{
+						"pod-1": 10,
+						"pod-2": 10,
+					},
+					ReadyPodCount: 2,
+					IgnoredPods:   sets.String{},
+					MissingPods:   sets.String{},
+				},
+			}, 0.1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if evaluation != test.expected {
+				t.Errorf("evaluation mismatch, want %d, got %d", test.expected, evaluation)
+			}
+		})
+	}
+}
+
+func TestEvaluateUtilizationBasis(t *testing.T) {
+	var tests = []struct {
+		description      string
+		expected         int32
+		utilizationBasis resourcemetrics.UtilizationBasis
+	}{
+		{
+			description:      "Default, utilization of requests",
+			expected:         4,
+			utilizationBasis: "",
+		},
+		{
+			description:      "Utilization of requests",
+			expected:         4,
+			utilizationBasis: resourcemetrics.UtilizationBasisRequests,
+		},
+		{
+			description:      "Utilization of limits",
+			expected:         2,
+			utilizationBasis: resourcemetrics.UtilizationBasisLimits,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluater := resource.Evaluate{}
+			// 100% utilization of requests or 50% utilization of limits against a 50% target across 2 pods
+			evaluation, err := evaluater.Evaluate(2, &metrics.Metric{
+				Spec: v2.MetricSpec{
+					Resource: &v2.ResourceMetricSource{
+						Target: v2.MetricTarget{
+							AverageUtilization: testutil.Int32Ptr(50),
+						},
+					},
+				},
+				Resource: &resourcemetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 10},
+						"pod-2": podmetrics.Metric{Value: 10},
+					},
+					Requests: map[string]int64{
+						"pod-1": 10,
+						"pod-2": 10,
+					},
+					Limits: map[string]int64{
+						"pod-1": 20,
+						"pod-2": 20,
+					},
+					ReadyPodCount:    2,
+					IgnoredPods:      sets.String{},
+					MissingPods:      sets.String{},
+					UtilizationBasis: test.utilizationBasis,
+				},
+			}, 0.1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if evaluation != test.expected {
+				t.Errorf("evaluation mismatch, want %d, got %d", test.expected, evaluation)
+			}
+		})
+	}
+}
+
+func TestUsageRatio(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	averageValue := k8sresource.MustParse("50m")
+
+	var tests = []struct {
+		description    string
+		expected       float64
+		expectedErr    error
+		gatheredMetric *metrics.Metric
+	}{
+		{
+			description: "Fail, no target set",
+			expected:    0,
+			expectedErr: errors.New("invalid resource metric source: neither a utilization target nor a value target was set"),
+			gatheredMetric: &metrics.Metric{
+				Spec: v2.MetricSpec{
+					Resource: &v2.ResourceMetricSource{
+						Target: v2.MetricTarget{},
+					},
+				},
+				Resource: &resourcemetrics.Metric{},
+			},
+		},
+		{
+			description: "Fail, average value target with no metrics",
+			expected:    0,
+			expectedErr: errors.New("no metrics returned for resource metric"),
+			gatheredMetric: &metrics.Metric{
+				Spec: v2.MetricSpec{
+					Resource: &v2.ResourceMetricSource{
+						Target: v2.MetricTarget{
+							AverageValue: &averageValue,
+						},
+					},
+				},
+				Resource: &resourcemetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{},
+				},
+			},
+		},
+		{
+			description: "Average value target",
+			expected:    2,
+			expectedErr: nil,
+			gatheredMetric: &metrics.Metric{
+				Spec: v2.MetricSpec{
+					Resource: &v2.ResourceMetricSource{
+						Target: v2.MetricTarget{
+							AverageValue: &averageValue,
+						},
+					},
+				},
+				Resource: &resourcemetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 50},
+						"pod-2": podmetrics.Metric{Value: 150},
+					},
+				},
+			},
+		},
+		{
+			description: "Fail, value target with no metrics",
+			expected:    0,
+			expectedErr: errors.New("no metrics returned for resource metric"),
+			gatheredMetric: &metrics.Metric{
+				Spec: v2.MetricSpec{
+					Resource: &v2.ResourceMetricSource{
+						Target: v2.MetricTarget{
+							Value: &averageValue,
+						},
+					},
+				},
+				Resource: &resourcemetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{},
+				},
+			},
+		},
+		{
+			description: "Value target",
+			expected:    4,
+			expectedErr: nil,
+			gatheredMetric: &metrics.Metric{
+				Spec: v2.MetricSpec{
+					Resource: &v2.ResourceMetricSource{
+						Target: v2.MetricTarget{
+							Value: &averageValue,
+						},
+					},
+				},
+				Resource: &resourcemetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 50},
+						"pod-2": podmetrics.Metric{Value: 150},
+					},
+				},
+			},
+		},
+		{
+			description: "Utilization target, missing pods not adjusted",
+			expected:    0.5,
+			expectedErr: nil,
+			gatheredMetric: &metrics.Metric{
+				Spec: v2.MetricSpec{
+					Resource: &v2.ResourceMetricSource{
+						Target: v2.MetricTarget{
+							AverageUtilization: testutil.Int32Ptr(50),
+						},
+					},
+				},
+				Resource: &resourcemetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 25},
+					},
+					Requests: map[string]int64{
+						"pod-1": 100,
+						"pod-2": 100,
+					},
+					MissingPods: sets.NewString("pod-2"),
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluate := &resource.Evaluate{}
+			result, err := evaluate.UsageRatio(2, test.gatheredMetric)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("usage ratio mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
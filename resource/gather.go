@@ -0,0 +1,201 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Modifications Copyright 2022 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+
+Modified to split up evaluations and metric gathering to work with the
+Custom Pod Autoscaler framework.
+Original source:
+https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscaler/horizontal.go
+https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscaler/replica_calculator.go
+*/
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	metricsclient "github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// Gather (Resource) provides functionality for retrieving metrics for resource metric specs. UtilizationBasis is what
+// resource utilization is calculated against, if not set this is pod requests in the same way as the HPA.
+type Gather struct {
+	MetricsClient    metricsclient.Client
+	PodLister        corelisters.PodLister
+	UtilizationBasis resource.UtilizationBasis
+}
+
+// Gather retrieves a resource metric
+func (c *Gather) Gather(resourceName corev1.ResourceName, namespace string, podSelector labels.Selector,
+	cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration) (*resource.Metric, error) {
+	return c.GatherWithContext(context.Background(), resourceName, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+}
+
+// GatherWithContext retrieves a resource metric, passing the context provided to the metrics client
+func (c *Gather) GatherWithContext(ctx context.Context, resourceName corev1.ResourceName, namespace string, podSelector labels.Selector,
+	cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration) (*resource.Metric, error) {
+	// Get metrics
+	start := time.Now()
+	metrics, timestamp, err := metricsclient.WithContext(c.MetricsClient).GetResourceMetricWithContext(ctx, resourceName, namespace, podSelector)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get metrics for resource %s: %w", resourceName, err)
+	}
+	metricProvenance := &provenance.Provenance{
+		Source:    provenance.SourceResource,
+		Duration:  time.Since(start),
+		ItemCount: len(metrics),
+	}
+
+	// Get pods
+	podList, err := podutil.ListPods(ctx, c.PodLister, namespace, podSelector)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get pods while calculating replica count: %w", err)
+	}
+
+	// Exclude terminating and failed pods, removing any metrics reported for them
+	podList, excludedPods := podutil.FilterActivePods(podList)
+	podutil.RemoveMetricsForPods(metrics, excludedPods)
+
+	totalPods := len(podList)
+	if totalPods == 0 {
+		return nil, fmt.Errorf("%w while calculating replica count", podutil.ErrNoPods)
+	}
+
+	// Remove missing pod metrics
+	readyPodCount, ignoredPods, missingPods, podErrors := podutil.GroupPodsWithReasons(podList, metrics, resourceName, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+	podutil.RemoveMetricsForPods(metrics, ignoredPods)
+	oldestTimestamp, newestTimestamp := metrics.TimestampRange()
+
+	// Calculate requests and limits for pod resources
+	requests, limits, err := c.requestsAndLimits(podList, resourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	metric := &resource.Metric{
+		PodMetricsInfo:   metrics,
+		Requests:         requests,
+		Limits:           limits,
+		ReadyPodCount:    int64(readyPodCount),
+		IgnoredPods:      ignoredPods,
+		MissingPods:      missingPods,
+		Errors:           podErrors,
+		TotalPods:        totalPods,
+		DeletionCosts:    podutil.GetPodDeletionCosts(podList),
+		Timestamp:        timestamp,
+		OldestTimestamp:  oldestTimestamp,
+		NewestTimestamp:  newestTimestamp,
+		Provenance:       metricProvenance,
+		UtilizationBasis: c.UtilizationBasis,
+	}
+
+	// Calculate the current utilization, left unset if no metrics match the pods the utilization is calculated against
+	utilization, averageValue, err := metricsclient.GetResourceUtilization(metrics, metric.UtilizationBase())
+	if err == nil {
+		metric.CurrentUtilization = &utilization
+		metric.CurrentAverageValue = &averageValue
+	}
+
+	return metric, nil
+}
+
+// requestsAndLimits calculates the requests and limits of the pods provided. The resource being calculated against
+// must be set for every pod, requests unless the utilization basis is limits; the other is only included if every pod
+// has it set.
+func (c *Gather) requestsAndLimits(podList []*corev1.Pod, resourceName corev1.ResourceName) (map[string]int64,
+	map[string]int64, error) {
+	requests, requestsErr := podutil.CalculatePodRequests(podList, resourceName)
+	limits, limitsErr := podutil.CalculatePodLimits(podList, resourceName)
+
+	if c.UtilizationBasis == resource.UtilizationBasisLimits {
+		if limitsErr != nil {
+			return nil, nil, limitsErr
+		}
+		return requests, limits, nil
+	}
+
+	if requestsErr != nil {
+		return nil, nil, requestsErr
+	}
+	return requests, limits, nil
+}
+
+// GatherRaw retrieves a a raw resource metric
+func (c *Gather) GatherRaw(resourceName corev1.ResourceName, namespace string, podSelector labels.Selector,
+	cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration) (*resource.Metric, error) {
+	return c.GatherRawWithContext(context.Background(), resourceName, namespace, podSelector, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+}
+
+// GatherRawWithContext retrieves a a raw resource metric, passing the context provided to the metrics client
+func (c *Gather) GatherRawWithContext(ctx context.Context, resourceName corev1.ResourceName, namespace string, podSelector labels.Selector,
+	cpuInitializationPeriod time.Duration, delayOfInitialReadinessStatus time.Duration) (*resource.Metric, error) {
+	// Get metrics
+	start := time.Now()
+	metrics, timestamp, err := metricsclient.WithContext(c.MetricsClient).GetResourceMetricWithContext(ctx, resourceName, namespace, podSelector)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get metrics for resource %s: %w", resourceName, err)
+	}
+	metricProvenance := &provenance.Provenance{
+		Source:    provenance.SourceResource,
+		Duration:  time.Since(start),
+		ItemCount: len(metrics),
+	}
+
+	// Get pods
+	podList, err := podutil.ListPods(ctx, c.PodLister, namespace, podSelector)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get pods while calculating replica count: %w", err)
+	}
+
+	// Exclude terminating and failed pods, removing any metrics reported for them
+	podList, excludedPods := podutil.FilterActivePods(podList)
+	podutil.RemoveMetricsForPods(metrics, excludedPods)
+
+	totalPods := len(podList)
+	if totalPods == 0 {
+		return nil, fmt.Errorf("%w while calculating replica count", podutil.ErrNoPods)
+	}
+
+	// Remove missing pod metrics
+	readyPodCount, ignoredPods, missingPods, podErrors := podutil.GroupPodsWithReasons(podList, metrics, resourceName, cpuInitializationPeriod, delayOfInitialReadinessStatus)
+	podutil.RemoveMetricsForPods(metrics, ignoredPods)
+	oldestTimestamp, newestTimestamp := metrics.TimestampRange()
+
+	return &resource.Metric{
+		PodMetricsInfo:  metrics,
+		ReadyPodCount:   int64(readyPodCount),
+		IgnoredPods:     ignoredPods,
+		MissingPods:     missingPods,
+		Errors:          podErrors,
+		TotalPods:       totalPods,
+		DeletionCosts:   podutil.GetPodDeletionCosts(podList),
+		Timestamp:       timestamp,
+		OldestTimestamp: oldestTimestamp,
+		NewestTimestamp: newestTimestamp,
+		Provenance:      metricProvenance,
+	}, nil
+}
@@ -22,11 +22,15 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/fake"
-	"github.com/jthomperoo/k8shorizmetrics/v4/internal/resource"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
 	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/provenance"
 	resourcemetric "github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
 	metricsclient "github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	"github.com/jthomperoo/k8shorizmetrics/v4/podutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/resource"
 	corev1 "k8s.io/api/core/v1"
 	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,6 +46,7 @@ func TestGather(t *testing.T) {
 		}
 		return x.Error() == y.Error()
 	})
+	ignoreDuration := cmpopts.IgnoreFields(provenance.Provenance{}, "Duration")
 
 	var tests = []struct {
 		description                   string
@@ -183,6 +188,16 @@ func TestGather(t *testing.T) {
 						Value: 3,
 					},
 				},
+				Errors: map[string]string{
+					"missing-pod-1": podutil.ReasonNoMetrics,
+					"missing-pod-2": podutil.ReasonNoMetrics,
+				},
+				CurrentUtilization:  testutil.Int32Ptr(120),
+				CurrentAverageValue: testutil.Int64Ptr(2),
+				Provenance: &provenance.Provenance{
+					Source:    provenance.SourceResource,
+					ItemCount: 3,
+				},
 			},
 			nil,
 			&fake.MetricsClient{
@@ -285,6 +300,18 @@ func TestGather(t *testing.T) {
 						Value: 3,
 					},
 				},
+				Errors: map[string]string{
+					"ignore-pod-1":  podutil.ReasonNoReadyCondition,
+					"ignore-pod-2":  podutil.ReasonNoReadyCondition,
+					"missing-pod-1": podutil.ReasonNoMetrics,
+					"missing-pod-2": podutil.ReasonNoMetrics,
+				},
+				CurrentUtilization:  testutil.Int32Ptr(120),
+				CurrentAverageValue: testutil.Int64Ptr(2),
+				Provenance: &provenance.Provenance{
+					Source:    provenance.SourceResource,
+					ItemCount: 5,
+				},
 			},
 			nil,
 			&fake.MetricsClient{
@@ -394,6 +421,100 @@ func TestGather(t *testing.T) {
 			"test-namespace",
 			nil,
 		},
+		{
+			"1 ready, 1 terminating and 1 evicted pod success",
+			&resourcemetric.Metric{
+				TotalPods:     1,
+				ReadyPodCount: 1,
+				MissingPods:   sets.String{},
+				DeletionCosts: map[string]int32{
+					"ready-pod": 10,
+				},
+				Requests: map[string]int64{
+					"ready-pod": 5,
+				},
+				PodMetricsInfo: podmetrics.MetricsInfo{
+					"ready-pod": podmetrics.Metric{
+						Value: 1,
+					},
+				},
+				Errors:              map[string]string{},
+				CurrentUtilization:  testutil.Int32Ptr(20),
+				CurrentAverageValue: testutil.Int64Ptr(1),
+				Provenance: &provenance.Provenance{
+					Source:    provenance.SourceResource,
+					ItemCount: 3,
+				},
+			},
+			nil,
+			&fake.MetricsClient{
+				GetResourceMetricReactor: func(resource corev1.ResourceName, namespace string, selector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+					return podmetrics.MetricsInfo{
+						"ready-pod": podmetrics.Metric{
+							Value: 1,
+						},
+						"terminating-pod": podmetrics.Metric{
+							Value: 100,
+						},
+						"evicted-pod": podmetrics.Metric{
+							Value: 100,
+						},
+					}, time.Time{}, nil
+				},
+			},
+			&fake.PodLister{
+				PodsReactor: func(namespace string) corelisters.PodNamespaceLister {
+					return &fake.PodNamespaceLister{
+						ListReactor: func(selector labels.Selector) (ret []*corev1.Pod, err error) {
+							return []*corev1.Pod{
+								{
+									ObjectMeta: metav1.ObjectMeta{
+										Name: "ready-pod",
+										Annotations: map[string]string{
+											podutil.PodDeletionCostAnnotation: "10",
+										},
+									},
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Resources: corev1.ResourceRequirements{
+													Requests: corev1.ResourceList{
+														"test-metric": *k8sresource.NewMilliQuantity(5, k8sresource.DecimalSI),
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									ObjectMeta: metav1.ObjectMeta{
+										Name:              "terminating-pod",
+										DeletionTimestamp: &metav1.Time{},
+										Annotations: map[string]string{
+											podutil.PodDeletionCostAnnotation: "-100",
+										},
+									},
+								},
+								{
+									ObjectMeta: metav1.ObjectMeta{
+										Name: "evicted-pod",
+									},
+									Status: corev1.PodStatus{
+										Phase:  corev1.PodFailed,
+										Reason: "Evicted",
+									},
+								},
+							}, nil
+						},
+					}
+				},
+			},
+			0,
+			0,
+			"test-metric",
+			"test-namespace",
+			nil,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
@@ -406,8 +527,125 @@ func TestGather(t *testing.T) {
 				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
 				return
 			}
-			if !cmp.Equal(test.expected, metric) {
-				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric))
+			if !cmp.Equal(test.expected, metric, ignoreDuration) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric, ignoreDuration))
+			}
+		})
+	}
+}
+
+func TestGatherUtilizationBasis(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	podWithResources := func(requests corev1.ResourceList, limits corev1.ResourceList) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-pod",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: requests,
+							Limits:   limits,
+						},
+					},
+				},
+			},
+		}
+	}
+	cpu := func(milliValue int64) corev1.ResourceList {
+		return corev1.ResourceList{
+			corev1.ResourceCPU: *k8sresource.NewMilliQuantity(milliValue, k8sresource.DecimalSI),
+		}
+	}
+
+	var tests = []struct {
+		description      string
+		expectedRequests map[string]int64
+		expectedLimits   map[string]int64
+		expectedErr      error
+		utilizationBasis resourcemetric.UtilizationBasis
+		pod              *corev1.Pod
+	}{
+		{
+			description:      "Requests basis, fail missing requests",
+			expectedErr:      errors.New("missing request for cpu"),
+			utilizationBasis: resourcemetric.UtilizationBasisRequests,
+			pod:              podWithResources(nil, cpu(20)),
+		},
+		{
+			description:      "Requests basis, no limits",
+			expectedRequests: map[string]int64{"test-pod": 10},
+			expectedLimits:   nil,
+			utilizationBasis: resourcemetric.UtilizationBasisRequests,
+			pod:              podWithResources(cpu(10), nil),
+		},
+		{
+			description:      "Requests basis, requests and limits",
+			expectedRequests: map[string]int64{"test-pod": 10},
+			expectedLimits:   map[string]int64{"test-pod": 20},
+			utilizationBasis: "",
+			pod:              podWithResources(cpu(10), cpu(20)),
+		},
+		{
+			description:      "Limits basis, fail missing limits",
+			expectedErr:      errors.New("missing limit for cpu"),
+			utilizationBasis: resourcemetric.UtilizationBasisLimits,
+			pod:              podWithResources(cpu(10), nil),
+		},
+		{
+			description:      "Limits basis, no requests",
+			expectedRequests: nil,
+			expectedLimits:   map[string]int64{"test-pod": 20},
+			utilizationBasis: resourcemetric.UtilizationBasisLimits,
+			pod:              podWithResources(nil, cpu(20)),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			gatherer := &resource.Gather{
+				MetricsClient: &fake.MetricsClient{
+					GetResourceMetricReactor: func(resource corev1.ResourceName, namespace string, selector labels.Selector) (podmetrics.MetricsInfo, time.Time, error) {
+						return podmetrics.MetricsInfo{
+							"test-pod": podmetrics.Metric{
+								Value: 5,
+							},
+						}, time.Time{}, nil
+					},
+				},
+				PodLister: &fake.PodLister{
+					PodsReactor: func(namespace string) corelisters.PodNamespaceLister {
+						return &fake.PodNamespaceLister{
+							ListReactor: func(selector labels.Selector) (ret []*corev1.Pod, err error) {
+								return []*corev1.Pod{test.pod}, nil
+							},
+						}
+					},
+				},
+				UtilizationBasis: test.utilizationBasis,
+			}
+			metric, err := gatherer.Gather(corev1.ResourceCPU, "test-namespace", labels.Everything(), 0, 0)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if err != nil {
+				return
+			}
+			if !cmp.Equal(test.expectedRequests, metric.Requests) {
+				t.Errorf("requests mismatch (-want +got):\n%s", cmp.Diff(test.expectedRequests, metric.Requests))
+			}
+			if !cmp.Equal(test.expectedLimits, metric.Limits) {
+				t.Errorf("limits mismatch (-want +got):\n%s", cmp.Diff(test.expectedLimits, metric.Limits))
+			}
+			if metric.UtilizationBasis != test.utilizationBasis {
+				t.Errorf("utilization basis mismatch, want %s, got %s", test.utilizationBasis, metric.UtilizationBasis)
 			}
 		})
 	}
@@ -420,6 +658,7 @@ func TestGatherRaw(t *testing.T) {
 		}
 		return x.Error() == y.Error()
 	})
+	ignoreDuration := cmpopts.IgnoreFields(provenance.Provenance{}, "Duration")
 
 	var tests = []struct {
 		description                   string
@@ -517,6 +756,14 @@ func TestGatherRaw(t *testing.T) {
 						Value: 3,
 					},
 				},
+				Errors: map[string]string{
+					"missing-pod-1": podutil.ReasonNoMetrics,
+					"missing-pod-2": podutil.ReasonNoMetrics,
+				},
+				Provenance: &provenance.Provenance{
+					Source:    provenance.SourceResource,
+					ItemCount: 3,
+				},
 			},
 			nil,
 			&fake.MetricsClient{
@@ -610,6 +857,16 @@ func TestGatherRaw(t *testing.T) {
 						Value: 3,
 					},
 				},
+				Errors: map[string]string{
+					"ignore-pod-1":  podutil.ReasonNoReadyCondition,
+					"ignore-pod-2":  podutil.ReasonNoReadyCondition,
+					"missing-pod-1": podutil.ReasonNoMetrics,
+					"missing-pod-2": podutil.ReasonNoMetrics,
+				},
+				Provenance: &provenance.Provenance{
+					Source:    provenance.SourceResource,
+					ItemCount: 5,
+				},
 			},
 			nil,
 			&fake.MetricsClient{
@@ -731,8 +988,8 @@ func TestGatherRaw(t *testing.T) {
 				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
 				return
 			}
-			if !cmp.Equal(test.expected, metric) {
-				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric))
+			if !cmp.Equal(test.expected, metric, ignoreDuration) {
+				t.Errorf("metrics mismatch (-want +got):\n%s", cmp.Diff(test.expected, metric, ignoreDuration))
 			}
 		})
 	}
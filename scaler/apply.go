@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+// Recommendation is a replica count recommended for a scale target. CurrentReplicas is the replica count the
+// recommendation was evaluated against, it is reported as the current replica count of the Result if the current
+// replica count is not read before patching. The poller and manager recommendations can be converted to a
+// Recommendation with their ScaleRecommendation methods.
+type Recommendation struct {
+	Namespace       string
+	ScaleTargetRef  autoscalingv2.CrossVersionObjectReference
+	CurrentReplicas int32
+	TargetReplicas  int32
+}
+
+type scalePatch struct {
+	Metadata *scalePatchMetadata `json:"metadata,omitempty"`
+	Spec     scalePatchSpec      `json:"spec"`
+}
+
+type scalePatchMetadata struct {
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+type scalePatchSpec struct {
+	Replicas int32 `json:"replicas"`
+}
+
+// Apply patches the scale subresource of the recommendation's target to the recommended replica count.
+// If SkipUnchanged, DryRun or MaxChange are set the current replica count is read first, the patch then only applies
+// if the target has not been modified since it was read, with the read and patch retried using the Backoff if the
+// target was modified in between. Otherwise the replica count is patched without reading the target first, requiring
+// a single request.
+func (s *Scaler) Apply(ctx context.Context, recommendation *Recommendation) (*Result, error) {
+	resource, err := s.groupVersionResource(recommendation.ScaleTargetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	readCurrent := s.SkipUnchanged || s.DryRun || s.MaxChange > 0
+
+	var result *Result
+	err = retry.RetryOnConflict(s.backoff(), func() error {
+		result = &Result{
+			CurrentReplicas: recommendation.CurrentReplicas,
+			TargetReplicas:  recommendation.TargetReplicas,
+			Scaled:          true,
+			DryRun:          s.DryRun,
+			ServerDryRun:    s.serverDryRun(),
+		}
+
+		patch := scalePatch{
+			Spec: scalePatchSpec{
+				Replicas: recommendation.TargetReplicas,
+			},
+		}
+
+		if readCurrent {
+			scale, err := s.ScaleClient.Scales(recommendation.Namespace).Get(ctx, resource.GroupResource(),
+				recommendation.ScaleTargetRef.Name, metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to get scale subresource: %w", err)
+			}
+
+			result.CurrentReplicas = scale.Spec.Replicas
+
+			change := recommendation.TargetReplicas - scale.Spec.Replicas
+			if change < 0 {
+				change = -change
+			}
+
+			if s.MaxChange > 0 && change > s.MaxChange {
+				return &MaxChangeError{
+					CurrentReplicas: scale.Spec.Replicas,
+					TargetReplicas:  recommendation.TargetReplicas,
+					MaxChange:       s.MaxChange,
+				}
+			}
+
+			if s.SkipUnchanged && change == 0 {
+				result.Scaled = false
+				return nil
+			}
+
+			if s.DryRun {
+				return nil
+			}
+
+			if scale.ResourceVersion != "" {
+				patch.Metadata = &scalePatchMetadata{
+					ResourceVersion: scale.ResourceVersion,
+				}
+			}
+		}
+
+		data, err := json.Marshal(patch)
+		if err != nil {
+			return fmt.Errorf("failed to marshal scale patch: %w", err)
+		}
+
+		patched, err := s.ScaleClient.Scales(recommendation.Namespace).Patch(ctx, resource,
+			recommendation.ScaleTargetRef.Name, types.MergePatchType, data, metav1.PatchOptions{
+				DryRun: s.dryRunOptions(),
+			})
+		if err != nil {
+			return fmt.Errorf("failed to patch scale subresource: %w", err)
+		}
+
+		result.AcceptedReplicas = s.acceptedReplicas(patched)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/scaler"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakescale "k8s.io/client-go/scale/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestApply(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	deploymentRef := autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "test-deployment",
+	}
+
+	recommendation := func(currentReplicas int32, targetReplicas int32) *scaler.Recommendation {
+		return &scaler.Recommendation{
+			Namespace:       "test-namespace",
+			ScaleTargetRef:  deploymentRef,
+			CurrentReplicas: currentReplicas,
+			TargetReplicas:  targetReplicas,
+		}
+	}
+
+	scaleGetReactor := func(replicas int32, resourceVersions ...string) k8stesting.ReactionFunc {
+		calls := 0
+		return func(action k8stesting.Action) (bool, runtime.Object, error) {
+			resourceVersion := ""
+			if calls < len(resourceVersions) {
+				resourceVersion = resourceVersions[calls]
+			}
+			calls++
+			return true, &autoscalingv1.Scale{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test-deployment",
+					Namespace:       "test-namespace",
+					ResourceVersion: resourceVersion,
+				},
+				Spec: autoscalingv1.ScaleSpec{
+					Replicas: replicas,
+				},
+			}, nil
+		}
+	}
+
+	var tests = []struct {
+		description       string
+		expected          *scaler.Result
+		expectedErr       error
+		expectedPatches   []string
+		scaleGetReactor   k8stesting.ReactionFunc
+		scalePatchReactor k8stesting.ReactionFunc
+		recommendation    *scaler.Recommendation
+		dryRun            bool
+		maxChange         int32
+		skipUnchanged     bool
+	}{
+		{
+			description: "Invalid API version",
+			expectedErr: errors.New("invalid scale target API version: unexpected GroupVersion string: a/b/c"),
+			recommendation: &scaler.Recommendation{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+					APIVersion: "a/b/c",
+					Kind:       "Deployment",
+					Name:       "test-deployment",
+				},
+			},
+		},
+		{
+			description:     "Fail to patch scale",
+			expectedErr:     errors.New("failed to patch scale subresource: fail to patch scale"),
+			expectedPatches: []string{`{"spec":{"replicas":5}}`},
+			scalePatchReactor: func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("fail to patch scale")
+			},
+			recommendation: recommendation(3, 5),
+		},
+		{
+			description: "Patch without reading current replicas",
+			expected: &scaler.Result{
+				CurrentReplicas: 3,
+				TargetReplicas:  5,
+				Scaled:          true,
+			},
+			expectedPatches: []string{`{"spec":{"replicas":5}}`},
+			recommendation:  recommendation(3, 5),
+		},
+		{
+			description: "Patch unchanged without no-op detection",
+			expected: &scaler.Result{
+				CurrentReplicas: 3,
+				TargetReplicas:  3,
+				Scaled:          true,
+			},
+			expectedPatches: []string{`{"spec":{"replicas":3}}`},
+			recommendation:  recommendation(3, 3),
+		},
+		{
+			description:    "Skip unchanged, fail to get scale",
+			expectedErr:    errors.New("failed to get scale subresource: fail to get scale"),
+			recommendation: recommendation(3, 5),
+			skipUnchanged:  true,
+			scaleGetReactor: func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("fail to get scale")
+			},
+		},
+		{
+			description: "Skip unchanged, no patch",
+			expected: &scaler.Result{
+				CurrentReplicas: 3,
+				TargetReplicas:  3,
+			},
+			scaleGetReactor: scaleGetReactor(3),
+			recommendation:  recommendation(2, 3),
+			skipUnchanged:   true,
+		},
+		{
+			description: "Skip unchanged, patch with resource version",
+			expected: &scaler.Result{
+				CurrentReplicas: 4,
+				TargetReplicas:  5,
+				Scaled:          true,
+			},
+			expectedPatches: []string{`{"metadata":{"resourceVersion":"1"},"spec":{"replicas":5}}`},
+			scaleGetReactor: scaleGetReactor(4, "1"),
+			recommendation:  recommendation(3, 5),
+			skipUnchanged:   true,
+		},
+		{
+			description: "Conflict on first patch, read again and retry",
+			expected: &scaler.Result{
+				CurrentReplicas: 3,
+				TargetReplicas:  5,
+				Scaled:          true,
+			},
+			expectedPatches: []string{
+				`{"metadata":{"resourceVersion":"1"},"spec":{"replicas":5}}`,
+				`{"metadata":{"resourceVersion":"2"},"spec":{"replicas":5}}`,
+			},
+			scaleGetReactor: scaleGetReactor(3, "1", "2"),
+			scalePatchReactor: func() k8stesting.ReactionFunc {
+				calls := 0
+				return func(action k8stesting.Action) (bool, runtime.Object, error) {
+					calls++
+					if calls == 1 {
+						return true, nil, k8serrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"},
+							"test-deployment", errors.New("object has been modified"))
+					}
+					return true, &autoscalingv1.Scale{}, nil
+				}
+			}(),
+			recommendation: recommendation(3, 5),
+			skipUnchanged:  true,
+		},
+		{
+			description: "Dry run, no patch",
+			expected: &scaler.Result{
+				CurrentReplicas: 4,
+				TargetReplicas:  5,
+				Scaled:          true,
+				DryRun:          true,
+			},
+			scaleGetReactor: scaleGetReactor(4),
+			recommendation:  recommendation(3, 5),
+			dryRun:          true,
+		},
+		{
+			description: "Change exceeds max change",
+			expectedErr: &scaler.MaxChangeError{
+				CurrentReplicas: 3,
+				TargetReplicas:  10,
+				MaxChange:       5,
+			},
+			scaleGetReactor: scaleGetReactor(3),
+			recommendation:  recommendation(3, 10),
+			maxChange:       5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+			restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+			var patches []string
+			scaleClient := &fakescale.FakeScaleClient{}
+			if test.scaleGetReactor != nil {
+				scaleClient.AddReactor("get", "deployments", test.scaleGetReactor)
+			}
+			scaleClient.AddReactor("patch", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				patches = append(patches, string(action.(k8stesting.PatchAction).GetPatch()))
+				if test.scalePatchReactor != nil {
+					return test.scalePatchReactor(action)
+				}
+				return true, &autoscalingv1.Scale{}, nil
+			})
+
+			s := scaler.NewScaler(scaleClient, restMapper)
+			s.DryRun = test.dryRun
+			s.MaxChange = test.maxChange
+			s.SkipUnchanged = test.skipUnchanged
+
+			result, err := s.Apply(context.Background(), test.recommendation)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("result mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+
+			if !cmp.Equal(test.expectedPatches, patches) {
+				t.Errorf("patches mismatch (-want +got):\n%s", cmp.Diff(test.expectedPatches, patches))
+			}
+		})
+	}
+}
+
+func TestApplyWithoutBackoff(t *testing.T) {
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	var patches []string
+	scaleClient := &fakescale.FakeScaleClient{}
+	scaleClient.AddReactor("patch", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patches = append(patches, string(action.(k8stesting.PatchAction).GetPatch()))
+		return true, &autoscalingv1.Scale{}, nil
+	})
+
+	// A Scaler built without NewScaler has no Backoff set, the default retry backoff should be used
+	s := &scaler.Scaler{
+		ScaleClient: scaleClient,
+		RESTMapper:  restMapper,
+	}
+
+	result, err := s.Apply(context.Background(), &scaler.Recommendation{
+		Namespace: "test-namespace",
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       "test-deployment",
+		},
+		CurrentReplicas: 2,
+		TargetReplicas:  5,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &scaler.Result{
+		CurrentReplicas: 2,
+		TargetReplicas:  5,
+		Scaled:          true,
+	}
+	if !cmp.Equal(expected, result) {
+		t.Errorf("result mismatch (-want +got):\n%s", cmp.Diff(expected, result))
+	}
+
+	expectedPatches := []string{`{"spec":{"replicas":5}}`}
+	if !cmp.Equal(expectedPatches, patches) {
+		t.Errorf("patches mismatch (-want +got):\n%s", cmp.Diff(expectedPatches, patches))
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/scaler"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	k8sscale "k8s.io/client-go/scale"
+)
+
+// admittingScales is a scale client which records the dry run options of each write and caps the replica count of
+// written scales at the maximum replicas, as a mutating admission webhook might
+type admittingScales struct {
+	replicas    int32
+	maxReplicas int32
+	dryRuns     [][]string
+}
+
+func (s *admittingScales) Scales(namespace string) k8sscale.ScaleInterface {
+	return s
+}
+
+func (s *admittingScales) Get(ctx context.Context, resource schema.GroupResource, name string,
+	opts metav1.GetOptions) (*autoscalingv1.Scale, error) {
+	return &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{Replicas: s.replicas},
+	}, nil
+}
+
+func (s *admittingScales) Update(ctx context.Context, resource schema.GroupResource, scale *autoscalingv1.Scale,
+	opts metav1.UpdateOptions) (*autoscalingv1.Scale, error) {
+	s.dryRuns = append(s.dryRuns, opts.DryRun)
+	return s.admit(scale.Spec.Replicas), nil
+}
+
+func (s *admittingScales) Patch(ctx context.Context, gvr schema.GroupVersionResource, name string, pt types.PatchType,
+	data []byte, opts metav1.PatchOptions) (*autoscalingv1.Scale, error) {
+	s.dryRuns = append(s.dryRuns, opts.DryRun)
+	return s.admit(s.maxReplicas + 1), nil
+}
+
+func (s *admittingScales) admit(replicas int32) *autoscalingv1.Scale {
+	if replicas > s.maxReplicas {
+		replicas = s.maxReplicas
+	}
+	return &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{Replicas: replicas},
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestServerDryRun(t *testing.T) {
+	deploymentRef := autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "test-deployment",
+	}
+
+	var tests = []struct {
+		description     string
+		expected        *scaler.Result
+		expectedDryRuns [][]string
+		dryRun          bool
+		serverDryRun    bool
+		apply           bool
+	}{
+		{
+			description: "Scale, no dry run",
+			expected: &scaler.Result{
+				CurrentReplicas: 3,
+				TargetReplicas:  10,
+				Scaled:          true,
+			},
+			expectedDryRuns: [][]string{nil},
+		},
+		{
+			description: "Scale, server-side dry run reports accepted replicas",
+			expected: &scaler.Result{
+				CurrentReplicas:  3,
+				TargetReplicas:   10,
+				Scaled:           true,
+				ServerDryRun:     true,
+				AcceptedReplicas: int32Ptr(8),
+			},
+			expectedDryRuns: [][]string{{metav1.DryRunAll}},
+			serverDryRun:    true,
+		},
+		{
+			description: "Scale, client-side dry run takes precedence",
+			expected: &scaler.Result{
+				CurrentReplicas: 3,
+				TargetReplicas:  10,
+				Scaled:          true,
+				DryRun:          true,
+			},
+			dryRun:       true,
+			serverDryRun: true,
+		},
+		{
+			description: "Apply, server-side dry run reports accepted replicas",
+			expected: &scaler.Result{
+				CurrentReplicas:  3,
+				TargetReplicas:   10,
+				Scaled:           true,
+				ServerDryRun:     true,
+				AcceptedReplicas: int32Ptr(8),
+			},
+			expectedDryRuns: [][]string{{metav1.DryRunAll}},
+			serverDryRun:    true,
+			apply:           true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+			restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+			scales := &admittingScales{
+				replicas:    3,
+				maxReplicas: 8,
+			}
+
+			s := scaler.NewScaler(scales, restMapper)
+			s.DryRun = test.dryRun
+			s.ServerDryRun = test.serverDryRun
+
+			var result *scaler.Result
+			var err error
+			if test.apply {
+				result, err = s.Apply(context.Background(), &scaler.Recommendation{
+					Namespace:       "test-namespace",
+					ScaleTargetRef:  deploymentRef,
+					CurrentReplicas: 3,
+					TargetReplicas:  10,
+				})
+			} else {
+				result, err = s.Scale(context.Background(), "test-namespace", deploymentRef, 10)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("result mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+
+			if !cmp.Equal(test.expectedDryRuns, scales.dryRuns) {
+				t.Errorf("dry runs mismatch (-want +got):\n%s", cmp.Diff(test.expectedDryRuns, scales.dryRuns))
+			}
+		})
+	}
+}
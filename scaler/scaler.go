@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scaler applies evaluated replica counts to the scale subresource of a target resource, completing the
+// gather, evaluate and act pipeline. Scaling supports dry runs, retries on update conflicts and a safety check
+// rejecting changes larger than a configured maximum.
+package scaler
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sscale "k8s.io/client-go/scale"
+	"k8s.io/client-go/util/retry"
+)
+
+// MaxChangeError occurs when the difference between the current and target replica counts is larger than the maximum
+// change allowed by the Scaler.
+type MaxChangeError struct {
+	CurrentReplicas int32
+	TargetReplicas  int32
+	MaxChange       int32
+}
+
+func (e *MaxChangeError) Error() string {
+	return fmt.Sprintf("change from %d to %d replicas exceeds maximum change of %d", e.CurrentReplicas, e.TargetReplicas,
+		e.MaxChange)
+}
+
+// Result is the outcome of a scale, recording the replica count before and after and whether the scale subresource
+// was updated. For a server-side dry run AcceptedReplicas is the replica count the API server accepted, which can
+// differ from the target replica count if the request was modified by admission, it is nil if no request was sent.
+type Result struct {
+	CurrentReplicas  int32  `json:"currentReplicas"`
+	TargetReplicas   int32  `json:"targetReplicas"`
+	Scaled           bool   `json:"scaled"`
+	DryRun           bool   `json:"dryRun"`
+	ServerDryRun     bool   `json:"serverDryRun,omitempty"`
+	AcceptedReplicas *int32 `json:"acceptedReplicas,omitempty"`
+}
+
+// Scaler updates the replica count of resources through the scale subresource.
+// If DryRun is set the scale subresource will not be updated, but the result will be reported as if it had been.
+// If ServerDryRun is set updates are sent to the API server as server-side dry runs, validated and admitted but not
+// persisted, reporting the replica count the API server accepted. This allows the whole pipeline to run in shadow
+// mode against a live cluster. DryRun takes precedence, with no request sent if both are set.
+// If MaxChange is greater than 0 any scale changing the replica count by more than MaxChange will be rejected with a
+// MaxChangeError.
+// Updates which fail due to a conflict are retried using the Backoff provided, if the Backoff has no Steps the
+// client-go default retry backoff is used.
+// If SkipUnchanged is set Apply reads the current replica count before patching and skips the patch if the target
+// already has the recommended replica count.
+type Scaler struct {
+	ScaleClient   k8sscale.ScalesGetter
+	RESTMapper    meta.RESTMapper
+	DryRun        bool
+	ServerDryRun  bool
+	MaxChange     int32
+	SkipUnchanged bool
+	Backoff       wait.Backoff
+}
+
+// NewScaler sets up a Scaler which retries on conflicts using the client-go default retry backoff
+func NewScaler(scaleClient k8sscale.ScalesGetter, restMapper meta.RESTMapper) *Scaler {
+	return &Scaler{
+		ScaleClient: scaleClient,
+		RESTMapper:  restMapper,
+		Backoff:     retry.DefaultRetry,
+	}
+}
+
+// GetScale returns the scale subresource of the target resource provided
+func (s *Scaler) GetScale(ctx context.Context, namespace string,
+	scaleTargetRef autoscalingv2.CrossVersionObjectReference) (*autoscalingv1.Scale, error) {
+	groupResource, err := s.groupResource(scaleTargetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	scale, err := s.ScaleClient.Scales(namespace).Get(ctx, groupResource, scaleTargetRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scale subresource: %w", err)
+	}
+
+	return scale, nil
+}
+
+// Scale sets the replica count of the target resource provided to the target replicas. If the resource already has
+// the target replica count no update is made.
+func (s *Scaler) Scale(ctx context.Context, namespace string, scaleTargetRef autoscalingv2.CrossVersionObjectReference,
+	targetReplicas int32) (*Result, error) {
+	groupResource, err := s.groupResource(scaleTargetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *Result
+	err = retry.RetryOnConflict(s.backoff(), func() error {
+		scale, err := s.ScaleClient.Scales(namespace).Get(ctx, groupResource, scaleTargetRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get scale subresource: %w", err)
+		}
+
+		currentReplicas := scale.Spec.Replicas
+
+		change := targetReplicas - currentReplicas
+		if change < 0 {
+			change = -change
+		}
+
+		if s.MaxChange > 0 && change > s.MaxChange {
+			return &MaxChangeError{
+				CurrentReplicas: currentReplicas,
+				TargetReplicas:  targetReplicas,
+				MaxChange:       s.MaxChange,
+			}
+		}
+
+		result = &Result{
+			CurrentReplicas: currentReplicas,
+			TargetReplicas:  targetReplicas,
+			DryRun:          s.DryRun,
+			ServerDryRun:    s.serverDryRun(),
+		}
+
+		if change == 0 {
+			return nil
+		}
+
+		result.Scaled = true
+
+		if s.DryRun {
+			return nil
+		}
+
+		scale.Spec.Replicas = targetReplicas
+		updated, err := s.ScaleClient.Scales(namespace).Update(ctx, groupResource, scale, metav1.UpdateOptions{
+			DryRun: s.dryRunOptions(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update scale subresource: %w", err)
+		}
+
+		result.AcceptedReplicas = s.acceptedReplicas(updated)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *Scaler) serverDryRun() bool {
+	return s.ServerDryRun && !s.DryRun
+}
+
+// dryRunOptions returns the dry run options to send with updates, requesting a server-side dry run if enabled
+func (s *Scaler) dryRunOptions() []string {
+	if !s.serverDryRun() {
+		return nil
+	}
+	return []string{metav1.DryRunAll}
+}
+
+// acceptedReplicas returns the replica count of the scale returned by the API server for server-side dry runs
+func (s *Scaler) acceptedReplicas(scale *autoscalingv1.Scale) *int32 {
+	if !s.serverDryRun() || scale == nil {
+		return nil
+	}
+	accepted := scale.Spec.Replicas
+	return &accepted
+}
+
+func (s *Scaler) backoff() wait.Backoff {
+	if s.Backoff.Steps == 0 {
+		return retry.DefaultRetry
+	}
+	return s.Backoff
+}
+
+func (s *Scaler) groupResource(scaleTargetRef autoscalingv2.CrossVersionObjectReference) (schema.GroupResource, error) {
+	resource, err := s.groupVersionResource(scaleTargetRef)
+	if err != nil {
+		return schema.GroupResource{}, err
+	}
+
+	return resource.GroupResource(), nil
+}
+
+func (s *Scaler) groupVersionResource(
+	scaleTargetRef autoscalingv2.CrossVersionObjectReference) (schema.GroupVersionResource, error) {
+	targetGV, err := schema.ParseGroupVersion(scaleTargetRef.APIVersion)
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid scale target API version: %w", err)
+	}
+
+	mapping, err := s.RESTMapper.RESTMapping(schema.GroupKind{Group: targetGV.Group, Kind: scaleTargetRef.Kind}, targetGV.Version)
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("failed to map scale target to resource: %w", err)
+	}
+
+	return mapping.Resource, nil
+}
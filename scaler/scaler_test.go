@@ -0,0 +1,298 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/scaler"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakescale "k8s.io/client-go/scale/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestScale(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	deploymentRef := autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "test-deployment",
+	}
+
+	scaleGetReactor := func(replicas int32) k8stesting.ReactionFunc {
+		return func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &autoscalingv1.Scale{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-deployment",
+					Namespace: "test-namespace",
+				},
+				Spec: autoscalingv1.ScaleSpec{
+					Replicas: replicas,
+				},
+			}, nil
+		}
+	}
+
+	var tests = []struct {
+		description        string
+		expected           *scaler.Result
+		expectedErr        error
+		expectedUpdates    []int32
+		scaleGetReactor    k8stesting.ReactionFunc
+		scaleUpdateReactor k8stesting.ReactionFunc
+		scaleTargetRef     autoscalingv2.CrossVersionObjectReference
+		targetReplicas     int32
+		dryRun             bool
+		maxChange          int32
+	}{
+		{
+			description: "Invalid API version",
+			expectedErr: errors.New("invalid scale target API version: unexpected GroupVersion string: a/b/c"),
+			scaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "a/b/c",
+				Kind:       "Deployment",
+				Name:       "test-deployment",
+			},
+		},
+		{
+			description: "Unknown kind",
+			expectedErr: errors.New(`failed to map scale target to resource: no matches for kind "Unknown" in version "apps/v1"`),
+			scaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Unknown",
+				Name:       "test",
+			},
+		},
+		{
+			description:    "Fail to get scale",
+			expectedErr:    errors.New("failed to get scale subresource: fail to get scale"),
+			scaleTargetRef: deploymentRef,
+			scaleGetReactor: func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("fail to get scale")
+			},
+		},
+		{
+			description:     "Fail to update scale",
+			expectedErr:     errors.New("failed to update scale subresource: fail to update scale"),
+			expectedUpdates: []int32{5},
+			scaleTargetRef:  deploymentRef,
+			scaleGetReactor: scaleGetReactor(3),
+			scaleUpdateReactor: func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("fail to update scale")
+			},
+			targetReplicas: 5,
+		},
+		{
+			description: "Scale up",
+			expected: &scaler.Result{
+				CurrentReplicas: 3,
+				TargetReplicas:  5,
+				Scaled:          true,
+			},
+			expectedUpdates: []int32{5},
+			scaleTargetRef:  deploymentRef,
+			scaleGetReactor: scaleGetReactor(3),
+			targetReplicas:  5,
+		},
+		{
+			description: "Scale down",
+			expected: &scaler.Result{
+				CurrentReplicas: 3,
+				TargetReplicas:  1,
+				Scaled:          true,
+			},
+			expectedUpdates: []int32{1},
+			scaleTargetRef:  deploymentRef,
+			scaleGetReactor: scaleGetReactor(3),
+			targetReplicas:  1,
+		},
+		{
+			description: "No change, no update",
+			expected: &scaler.Result{
+				CurrentReplicas: 3,
+				TargetReplicas:  3,
+			},
+			scaleTargetRef:  deploymentRef,
+			scaleGetReactor: scaleGetReactor(3),
+			targetReplicas:  3,
+		},
+		{
+			description: "Dry run, no update",
+			expected: &scaler.Result{
+				CurrentReplicas: 3,
+				TargetReplicas:  5,
+				Scaled:          true,
+				DryRun:          true,
+			},
+			scaleTargetRef:  deploymentRef,
+			scaleGetReactor: scaleGetReactor(3),
+			targetReplicas:  5,
+			dryRun:          true,
+		},
+		{
+			description: "Change exceeds max change",
+			expectedErr: &scaler.MaxChangeError{
+				CurrentReplicas: 3,
+				TargetReplicas:  10,
+				MaxChange:       5,
+			},
+			scaleTargetRef:  deploymentRef,
+			scaleGetReactor: scaleGetReactor(3),
+			targetReplicas:  10,
+			maxChange:       5,
+		},
+		{
+			description: "Change within max change",
+			expected: &scaler.Result{
+				CurrentReplicas: 3,
+				TargetReplicas:  8,
+				Scaled:          true,
+			},
+			expectedUpdates: []int32{8},
+			scaleTargetRef:  deploymentRef,
+			scaleGetReactor: scaleGetReactor(3),
+			targetReplicas:  8,
+			maxChange:       5,
+		},
+		{
+			description: "Conflict on first update, retry and succeed",
+			expected: &scaler.Result{
+				CurrentReplicas: 3,
+				TargetReplicas:  5,
+				Scaled:          true,
+			},
+			expectedUpdates: []int32{5, 5},
+			scaleTargetRef:  deploymentRef,
+			scaleGetReactor: scaleGetReactor(3),
+			scaleUpdateReactor: func() k8stesting.ReactionFunc {
+				calls := 0
+				return func(action k8stesting.Action) (bool, runtime.Object, error) {
+					calls++
+					if calls == 1 {
+						return true, nil, k8serrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"},
+							"test-deployment", errors.New("object has been modified"))
+					}
+					return true, action.(k8stesting.UpdateAction).GetObject(), nil
+				}
+			}(),
+			targetReplicas: 5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+			restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+			var updates []int32
+			scaleClient := &fakescale.FakeScaleClient{}
+			if test.scaleGetReactor != nil {
+				scaleClient.AddReactor("get", "deployments", test.scaleGetReactor)
+			}
+			scaleClient.AddReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				updates = append(updates, action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.Scale).Spec.Replicas)
+				if test.scaleUpdateReactor != nil {
+					return test.scaleUpdateReactor(action)
+				}
+				return true, action.(k8stesting.UpdateAction).GetObject(), nil
+			})
+
+			s := scaler.NewScaler(scaleClient, restMapper)
+			s.DryRun = test.dryRun
+			s.MaxChange = test.maxChange
+
+			result, err := s.Scale(context.Background(), "test-namespace", test.scaleTargetRef, test.targetReplicas)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("result mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+
+			if !cmp.Equal(test.expectedUpdates, updates) {
+				t.Errorf("updates mismatch (-want +got):\n%s", cmp.Diff(test.expectedUpdates, updates))
+			}
+		})
+	}
+}
+
+func TestScaleWithoutBackoff(t *testing.T) {
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	var updates []int32
+	scaleClient := &fakescale.FakeScaleClient{}
+	scaleClient.AddReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &autoscalingv1.Scale{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-deployment",
+				Namespace: "test-namespace",
+			},
+			Spec: autoscalingv1.ScaleSpec{
+				Replicas: 2,
+			},
+		}, nil
+	})
+	scaleClient.AddReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		updates = append(updates, action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.Scale).Spec.Replicas)
+		return true, action.(k8stesting.UpdateAction).GetObject(), nil
+	})
+
+	// A Scaler built without NewScaler has no Backoff set, the default retry backoff should be used
+	s := &scaler.Scaler{
+		ScaleClient: scaleClient,
+		RESTMapper:  restMapper,
+	}
+
+	result, err := s.Scale(context.Background(), "test-namespace", autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "test-deployment",
+	}, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &scaler.Result{
+		CurrentReplicas: 2,
+		TargetReplicas:  5,
+		Scaled:          true,
+	}
+	if !cmp.Equal(expected, result) {
+		t.Errorf("result mismatch (-want +got):\n%s", cmp.Diff(expected, result))
+	}
+
+	if !cmp.Equal([]int32{5}, updates) {
+		t.Errorf("updates mismatch (-want +got):\n%s", cmp.Diff([]int32{5}, updates))
+	}
+}
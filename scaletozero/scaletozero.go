@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scaletozero provides opt-in scale to zero, following the semantics of the HPA's HPAScaleToZero feature gate.
+// A target is only scaled to zero once every metric it is scaled on is an object or external metric with a value or
+// average value target, and each of those metrics has reported zero for the whole of the idle window. Otherwise
+// evaluations are never allowed to drop below a single replica, so a target is never scaled to zero because of a
+// transient dip in a metric or because a resource or pods metric has no pods left to measure.
+package scaletozero
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/utils/clock"
+)
+
+// Evaluator wraps an Evaluator, allowing targets to be scaled to zero once the metrics provided have been idle for
+// the IdleWindow, see Idle. Each target is identified by a key, for example from stabilization.TargetKey, and the
+// time each target became idle is kept in memory.
+type Evaluator struct {
+	Evaluator  *k8shorizmetrics.Evaluator
+	IdleWindow time.Duration
+	Clock      clock.PassiveClock
+
+	mu        sync.Mutex
+	idleSince map[string]time.Time
+}
+
+// NewEvaluator sets up an Evaluator that scales targets to zero once their metrics have been idle for the idle window
+// provided
+func NewEvaluator(evaluator *k8shorizmetrics.Evaluator, idleWindow time.Duration) *Evaluator {
+	return &Evaluator{
+		Evaluator:  evaluator,
+		IdleWindow: idleWindow,
+		Clock:      clock.RealClock{},
+	}
+}
+
+// Evaluate returns the target replica count for the target identified by the key provided based on the metrics
+// provided, returning zero if the metrics have been idle for the idle window and at least one replica otherwise
+func (e *Evaluator) Evaluate(key string, gatheredMetrics []*metrics.Metric, currentReplicas int32) (int32, error) {
+	return e.EvaluateWithContext(context.Background(), key, gatheredMetrics, currentReplicas)
+}
+
+// EvaluateWithContext returns the target replica count for the target identified by the key provided based on the
+// metrics provided, returning zero if the metrics have been idle for the idle window and at least one replica
+// otherwise, passing the context provided to the Evaluator. If only some metrics fail to be evaluated the
+// recommendation from the remaining metrics is returned alongside the error, never below one replica, and the target
+// is treated as not idle since the failed metrics may not be idle.
+func (e *Evaluator) EvaluateWithContext(ctx context.Context, key string, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32) (int32, error) {
+	recommendation, err := e.Evaluator.EvaluateWithContext(ctx, gatheredMetrics, currentReplicas)
+	if err != nil {
+		if !k8shorizmetrics.IsPartial(err) {
+			return 0, err
+		}
+		e.idleFor(key, false)
+		return max(recommendation, 1), err
+	}
+
+	if e.idleFor(key, Idle(gatheredMetrics)) {
+		return 0, nil
+	}
+
+	return max(recommendation, 1), nil
+}
+
+// Forget discards the idle state recorded for the target, for example when the target is deleted
+func (e *Evaluator) Forget(key string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.idleSince, key)
+}
+
+// idleFor records whether the target is idle, returning true if it has been idle for the idle window
+func (e *Evaluator) idleFor(key string, idle bool) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !idle {
+		delete(e.idleSince, key)
+		return false
+	}
+
+	if e.idleSince == nil {
+		e.idleSince = map[string]time.Time{}
+	}
+
+	now := e.clock().Now()
+	since, exists := e.idleSince[key]
+	if !exists {
+		since = now
+		e.idleSince[key] = since
+	}
+
+	return !now.Before(since.Add(e.IdleWindow))
+}
+
+func (e *Evaluator) clock() clock.PassiveClock {
+	if e.Clock == nil {
+		return clock.RealClock{}
+	}
+	return e.Clock
+}
+
+// Idle returns true if the metrics provided allow scaling to zero, which requires at least one metric and that every
+// metric is an object or external metric with a value or average value target which has a current value of zero
+func Idle(gatheredMetrics []*metrics.Metric) bool {
+	if len(gatheredMetrics) == 0 {
+		return false
+	}
+	for _, gatheredMetric := range gatheredMetrics {
+		if !idle(gatheredMetric) {
+			return false
+		}
+	}
+	return true
+}
+
+func idle(gatheredMetric *metrics.Metric) bool {
+	switch gatheredMetric.Spec.Type {
+	case autoscalingv2.ObjectMetricSourceType:
+		if gatheredMetric.Spec.Object == nil || gatheredMetric.Object == nil {
+			return false
+		}
+		return zero(gatheredMetric.Spec.Object.Target, gatheredMetric.Object.Current)
+	case autoscalingv2.ExternalMetricSourceType:
+		if gatheredMetric.Spec.External == nil || gatheredMetric.External == nil {
+			return false
+		}
+		return zero(gatheredMetric.Spec.External.Target, gatheredMetric.External.Current)
+	}
+	return false
+}
+
+func zero(target autoscalingv2.MetricTarget, current value.MetricValue) bool {
+	switch target.Type {
+	case autoscalingv2.ValueMetricType:
+		return current.Value != nil && *current.Value == 0
+	case autoscalingv2.AverageValueMetricType:
+		return current.AverageValue != nil && *current.AverageValue == 0
+	}
+	return false
+}
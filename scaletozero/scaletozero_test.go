@@ -0,0 +1,337 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaletozero_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	"github.com/jthomperoo/k8shorizmetrics/v4/scaletozero"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func externalMetric(targetType autoscalingv2.MetricTargetType, current value.MetricValue) *metrics.Metric {
+	return &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ExternalMetricSourceType,
+			External: &autoscalingv2.ExternalMetricSource{
+				Target: autoscalingv2.MetricTarget{
+					Type: targetType,
+				},
+			},
+		},
+		External: &external.Metric{
+			Current: current,
+		},
+	}
+}
+
+func objectMetric(targetType autoscalingv2.MetricTargetType, current value.MetricValue) *metrics.Metric {
+	return &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ObjectMetricSourceType,
+			Object: &autoscalingv2.ObjectMetricSource{
+				Target: autoscalingv2.MetricTarget{
+					Type: targetType,
+				},
+			},
+		},
+		Object: &object.Metric{
+			Current: current,
+		},
+	}
+}
+
+func TestIdle(t *testing.T) {
+	var tests = []struct {
+		description     string
+		expected        bool
+		gatheredMetrics []*metrics.Metric
+	}{
+		{
+			description:     "No metrics",
+			expected:        false,
+			gatheredMetrics: []*metrics.Metric{},
+		},
+		{
+			description: "Resource metric",
+			expected:    false,
+			gatheredMetrics: []*metrics.Metric{
+				{
+					Spec: autoscalingv2.MetricSpec{
+						Type: autoscalingv2.ResourceMetricSourceType,
+					},
+				},
+			},
+		},
+		{
+			description: "External value metric, zero",
+			expected:    true,
+			gatheredMetrics: []*metrics.Metric{
+				externalMetric(autoscalingv2.ValueMetricType, value.MetricValue{Value: testutil.Int64Ptr(0)}),
+			},
+		},
+		{
+			description: "External value metric, non zero",
+			expected:    false,
+			gatheredMetrics: []*metrics.Metric{
+				externalMetric(autoscalingv2.ValueMetricType, value.MetricValue{Value: testutil.Int64Ptr(1)}),
+			},
+		},
+		{
+			description: "External utilization metric",
+			expected:    false,
+			gatheredMetrics: []*metrics.Metric{
+				externalMetric(autoscalingv2.UtilizationMetricType, value.MetricValue{Value: testutil.Int64Ptr(0)}),
+			},
+		},
+		{
+			description: "Object average value metric, zero, and external value metric, zero",
+			expected:    true,
+			gatheredMetrics: []*metrics.Metric{
+				objectMetric(autoscalingv2.AverageValueMetricType, value.MetricValue{AverageValue: testutil.Int64Ptr(0)}),
+				externalMetric(autoscalingv2.ValueMetricType, value.MetricValue{Value: testutil.Int64Ptr(0)}),
+			},
+		},
+		{
+			description: "Object average value metric, missing value",
+			expected:    false,
+			gatheredMetrics: []*metrics.Metric{
+				objectMetric(autoscalingv2.AverageValueMetricType, value.MetricValue{}),
+			},
+		},
+		{
+			description: "Object value metric zero, resource metric",
+			expected:    false,
+			gatheredMetrics: []*metrics.Metric{
+				objectMetric(autoscalingv2.ValueMetricType, value.MetricValue{Value: testutil.Int64Ptr(0)}),
+				{
+					Spec: autoscalingv2.MetricSpec{
+						Type: autoscalingv2.ResourceMetricSourceType,
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := scaletozero.Idle(test.gatheredMetrics)
+			if result != test.expected {
+				t.Errorf("idle mismatch, want %t, got %t", test.expected, result)
+			}
+		})
+	}
+}
+
+type step struct {
+	advance    time.Duration
+	current    int64
+	evaluation int32
+	expected   int32
+}
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description string
+		expectedErr error
+		evaluateErr error
+		idleWindow  time.Duration
+		steps       []step
+	}{
+		{
+			description: "Fail to evaluate",
+			expectedErr: errors.New("evaluator multi metric error: 1 errors, first error is fail to evaluate"),
+			evaluateErr: errors.New("fail to evaluate"),
+			idleWindow:  time.Minute,
+			steps: []step{
+				{current: 0, evaluation: 0, expected: 0},
+			},
+		},
+		{
+			description: "Not idle, never below one replica",
+			idleWindow:  time.Minute,
+			steps: []step{
+				{current: 5, evaluation: 0, expected: 1},
+				{current: 50, evaluation: 3, expected: 3},
+			},
+		},
+		{
+			description: "No idle window, scale to zero immediately",
+			idleWindow:  0,
+			steps: []step{
+				{current: 0, evaluation: 0, expected: 0},
+			},
+		},
+		{
+			description: "Idle for idle window, scale to zero",
+			idleWindow:  5 * time.Minute,
+			steps: []step{
+				{current: 0, evaluation: 0, expected: 1},
+				{advance: 4 * time.Minute, current: 0, evaluation: 0, expected: 1},
+				{advance: time.Minute, current: 0, evaluation: 0, expected: 0},
+			},
+		},
+		{
+			description: "Usage during idle window, idle window restarts",
+			idleWindow:  5 * time.Minute,
+			steps: []step{
+				{current: 0, evaluation: 0, expected: 1},
+				{advance: 4 * time.Minute, current: 10, evaluation: 2, expected: 2},
+				{advance: time.Minute, current: 0, evaluation: 0, expected: 1},
+				{advance: 4 * time.Minute, current: 0, evaluation: 0, expected: 1},
+				{advance: time.Minute, current: 0, evaluation: 0, expected: 0},
+			},
+		},
+		{
+			description: "Scale from zero",
+			idleWindow:  0,
+			steps: []step{
+				{current: 0, evaluation: 0, expected: 0},
+				{current: 10, evaluation: 2, expected: 2},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakePassiveClock(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+			var evaluation int32
+			evaluator := &scaletozero.Evaluator{
+				Evaluator: &k8shorizmetrics.Evaluator{
+					External: &fake.ExternalEvaluater{
+						EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+							return evaluation, test.evaluateErr
+						},
+					},
+				},
+				IdleWindow: test.idleWindow,
+				Clock:      fakeClock,
+			}
+
+			var err error
+			for i, step := range test.steps {
+				fakeClock.SetTime(fakeClock.Now().Add(step.advance))
+				evaluation = step.evaluation
+				gatheredMetrics := []*metrics.Metric{
+					externalMetric(autoscalingv2.ValueMetricType, value.MetricValue{Value: testutil.Int64Ptr(step.current)}),
+				}
+				var result int32
+				result, err = evaluator.Evaluate("test", gatheredMetrics, 1)
+				if result != step.expected {
+					t.Errorf("step %d mismatch, want %d, got %d", i, step.expected, result)
+				}
+			}
+
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+			}
+		})
+	}
+}
+
+func TestEvaluator_EvaluatePartialError(t *testing.T) {
+	fakeClock := clocktesting.NewFakePassiveClock(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+	failing := externalMetric(autoscalingv2.ValueMetricType, value.MetricValue{Value: testutil.Int64Ptr(0)})
+	fail := false
+	evaluator := &scaletozero.Evaluator{
+		Evaluator: &k8shorizmetrics.Evaluator{
+			External: &fake.ExternalEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					if fail && gatheredMetric == failing {
+						return 0, errors.New("fail to evaluate")
+					}
+					return 0, nil
+				},
+			},
+		},
+		IdleWindow: 5 * time.Minute,
+		Clock:      fakeClock,
+	}
+	gatheredMetrics := []*metrics.Metric{
+		externalMetric(autoscalingv2.ValueMetricType, value.MetricValue{Value: testutil.Int64Ptr(0)}),
+		failing,
+	}
+
+	if result, err := evaluator.Evaluate("test", gatheredMetrics, 1); result != 1 || err != nil {
+		t.Errorf("expected 1 replica without error, got %d, %v", result, err)
+	}
+
+	// A partial failure at the end of the idle window is not counted as idle, so never scales to zero
+	fail = true
+	fakeClock.SetTime(fakeClock.Now().Add(5 * time.Minute))
+	result, err := evaluator.Evaluate("test", gatheredMetrics, 1)
+	if result != 1 {
+		t.Errorf("expected 1 replica on partial error, got %d", result)
+	}
+	if !k8shorizmetrics.IsPartial(err) {
+		t.Errorf("expected partial error, got %v", err)
+	}
+
+	// The idle window restarts after the partial failure
+	fail = false
+	fakeClock.SetTime(fakeClock.Now().Add(time.Minute))
+	if result, _ := evaluator.Evaluate("test", gatheredMetrics, 1); result != 1 {
+		t.Errorf("expected idle window to restart after partial error, got %d", result)
+	}
+	fakeClock.SetTime(fakeClock.Now().Add(5 * time.Minute))
+	if result, _ := evaluator.Evaluate("test", gatheredMetrics, 1); result != 0 {
+		t.Errorf("expected scale to zero after idle window, got %d", result)
+	}
+}
+
+func TestEvaluator_Forget(t *testing.T) {
+	fakeClock := clocktesting.NewFakePassiveClock(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+	evaluator := &scaletozero.Evaluator{
+		Evaluator: &k8shorizmetrics.Evaluator{
+			External: &fake.ExternalEvaluater{
+				EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+					return 0, nil
+				},
+			},
+		},
+		IdleWindow: time.Minute,
+		Clock:      fakeClock,
+	}
+	gatheredMetrics := []*metrics.Metric{
+		externalMetric(autoscalingv2.ValueMetricType, value.MetricValue{Value: testutil.Int64Ptr(0)}),
+	}
+
+	evaluator.Evaluate("test", gatheredMetrics, 1)
+	fakeClock.SetTime(fakeClock.Now().Add(time.Minute))
+	evaluator.Forget("test")
+
+	result, _ := evaluator.Evaluate("test", gatheredMetrics, 1)
+	if result != 1 {
+		t.Errorf("expected idle window to restart after forgetting, got %d", result)
+	}
+}
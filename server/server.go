@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package server exposes gathering and evaluating metrics over HTTP as a JSON REST API, so autoscaler components that
+// are not written in Go, such as Custom Pod Autoscaler hooks or scripts, can use k8shorizmetrics without shelling out.
+//
+// POST /gather gathers metrics for the metric specs provided and POST /evaluate evaluates gathered metrics, returning
+// the target replica count. Both endpoints take and return JSON, errors are returned as an ErrorResponse.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// DefaultMaxRequestBytes is the largest request body accepted by default
+const DefaultMaxRequestBytes = 10 << 20
+
+// GatherRequest is the body of a request to gather metrics. PodSelector is a label selector in the same format as
+// kubectl, for example "app=php-apache", if empty all pods in the namespace are selected.
+type GatherRequest struct {
+	Namespace   string                     `json:"namespace"`
+	PodSelector string                     `json:"podSelector"`
+	Specs       []autoscalingv2.MetricSpec `json:"specs"`
+}
+
+// GatherResponse is the body of a response to a gather request. If some metrics failed to be gathered the metrics
+// that were gathered are returned along with the error.
+type GatherResponse struct {
+	Metrics []*metrics.Metric `json:"metrics"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// EvaluateRequest is the body of a request to evaluate metrics. Tolerance overrides the Evaluator's tolerance if it is
+// not zero.
+type EvaluateRequest struct {
+	Metrics         []*metrics.Metric `json:"metrics"`
+	CurrentReplicas int32             `json:"currentReplicas"`
+	Tolerance       float64           `json:"tolerance,omitempty"`
+}
+
+// EvaluateResponse is the body of a response to an evaluate request. If some metrics failed to be evaluated the
+// replica count evaluated from the remaining metrics is returned along with the error.
+type EvaluateResponse struct {
+	Replicas int32  `json:"replicas"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ErrorResponse is the body of a response to a request which failed
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server handles gather and evaluate requests using the Gatherer and Evaluator. MaxRequestBytes limits the size of
+// request bodies, if zero DefaultMaxRequestBytes is used.
+type Server struct {
+	Gatherer        *k8shorizmetrics.Gatherer
+	Evaluator       *k8shorizmetrics.Evaluator
+	MaxRequestBytes int64
+}
+
+// NewServer sets up a server using the gatherer and evaluator provided
+func NewServer(gatherer *k8shorizmetrics.Gatherer, evaluator *k8shorizmetrics.Evaluator) *Server {
+	return &Server{
+		Gatherer:  gatherer,
+		Evaluator: evaluator,
+	}
+}
+
+// Handler returns the HTTP handler serving the gather and evaluate endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /gather", s.handleGather)
+	mux.HandleFunc("POST /evaluate", s.handleEvaluate)
+	return mux
+}
+
+func (s *Server) handleGather(w http.ResponseWriter, r *http.Request) {
+	var request GatherRequest
+	if !s.decode(w, r, &request) {
+		return
+	}
+
+	if len(request.Specs) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("no metric specs provided"))
+		return
+	}
+
+	for i, spec := range request.Specs {
+		if err := k8shorizmetrics.ValidateMetricSpec(spec); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid metric spec at index %d: %w", i, err))
+			return
+		}
+	}
+
+	podSelector, err := labels.Parse(request.PodSelector)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid pod selector: %w", err))
+		return
+	}
+
+	gathered, err := s.Gatherer.GatherWithContext(r.Context(), request.Specs, request.Namespace, podSelector)
+	if err != nil && !k8shorizmetrics.IsPartial(err) {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to gather metrics: %w", err))
+		return
+	}
+
+	response := GatherResponse{
+		Metrics: gathered,
+	}
+	if err != nil {
+		response.Error = err.Error()
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleEvaluate(w http.ResponseWriter, r *http.Request) {
+	var request EvaluateRequest
+	if !s.decode(w, r, &request) {
+		return
+	}
+
+	if len(request.Metrics) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("no metrics provided"))
+		return
+	}
+
+	for i, gatheredMetric := range request.Metrics {
+		if err := s.validateMetric(gatheredMetric); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid metric at index %d: %w", i, err))
+			return
+		}
+	}
+
+	replicas, err := s.Evaluator.EvaluateWithEvaluateOptions(r.Context(), request.Metrics, request.CurrentReplicas,
+		k8shorizmetrics.EvaluateOptions{Tolerance: request.Tolerance})
+	if err != nil && !k8shorizmetrics.IsPartial(err) {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Errorf("failed to evaluate metrics: %w", err))
+		return
+	}
+
+	response := EvaluateResponse{
+		Replicas: replicas,
+	}
+	if err != nil {
+		response.Error = err.Error()
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// validateMetric checks the metric provided can be evaluated, see k8shorizmetrics.ValidateMetric. Metrics for source
+// types registered with the Evaluator are left for their evaluater to validate.
+func (s *Server) validateMetric(gatheredMetric *metrics.Metric) error {
+	if gatheredMetric != nil {
+		if _, ok := s.Evaluator.Sources[gatheredMetric.Spec.Type]; ok {
+			return nil
+		}
+	}
+	return k8shorizmetrics.ValidateMetric(gatheredMetric)
+}
+
+// decode decodes the JSON request body into the value provided, writing an error response and returning false if the
+// body is invalid
+func (s *Server) decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	maxRequestBytes := s.MaxRequestBytes
+	if maxRequestBytes == 0 {
+		maxRequestBytes = DefaultMaxRequestBytes
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("request body exceeds %d bytes", maxBytesErr.Limit))
+			return false
+		}
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return false
+	}
+	return true
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	// The status has already been written, so an encoding error cannot be reported to the client
+	_ = json.NewEncoder(w).Encode(v)
+}
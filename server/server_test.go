@@ -0,0 +1,256 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/server"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	requestsSpec = `{"type":"Pods","pods":{"metric":{"name":"requests"},"target":{"type":"AverageValue","averageValue":"10"}}}`
+	failSpec     = `{"type":"Pods","pods":{"metric":{"name":"fail"},"target":{"type":"AverageValue","averageValue":"10"}}}`
+
+	gatheredMetric = `{"spec":` + requestsSpec + `,"pods":{"podMetricsInfo":null,"readyPodCount":3,"ignoredPods":null,` +
+		`"missingPods":null,"totalPods":0,"timestamp":"0001-01-01T00:00:00Z","oldestTimestamp":"0001-01-01T00:00:00Z",` +
+		`"newestTimestamp":"0001-01-01T00:00:00Z"}}`
+)
+
+func TestServerGather(t *testing.T) {
+	var tests = []struct {
+		description      string
+		expectedStatus   int
+		expectedBody     string
+		method           string
+		body             string
+		maxRequestBytes  int64
+		expectedSelector string
+	}{
+		{
+			description:    "Method not allowed",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedBody:   "Method Not Allowed",
+			method:         http.MethodGet,
+		},
+		{
+			description:    "Invalid JSON",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid request body: unexpected EOF"}`,
+			method:         http.MethodPost,
+			body:           `{`,
+		},
+		{
+			description:    "Unknown field",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid request body: json: unknown field \"unknown\""}`,
+			method:         http.MethodPost,
+			body:           `{"unknown":true}`,
+		},
+		{
+			description:     "Request too large",
+			expectedStatus:  http.StatusRequestEntityTooLarge,
+			expectedBody:    `{"error":"request body exceeds 8 bytes"}`,
+			method:          http.MethodPost,
+			body:            `{"namespace":"default"}`,
+			maxRequestBytes: 8,
+		},
+		{
+			description:    "No specs",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"no metric specs provided"}`,
+			method:         http.MethodPost,
+			body:           `{"namespace":"default"}`,
+		},
+		{
+			description:    "Invalid spec",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid metric spec at index 0: invalid pods metric source: pods must be set"}`,
+			method:         http.MethodPost,
+			body:           `{"namespace":"default","specs":[{"type":"Pods"}]}`,
+		},
+		{
+			description:    "Invalid pod selector",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: `{"error":"invalid pod selector: found '=', expected: !, identifier, or 'end of ` +
+				`string'"}`,
+			method: http.MethodPost,
+			body:   `{"namespace":"default","podSelector":"=","specs":[` + requestsSpec + `]}`,
+		},
+		{
+			description:    "All metrics fail",
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: `{"error":"failed to gather metrics: gatherer multi metric error: 1 errors, first error is ` +
+				`failed to get pods metric: fail"}`,
+			method: http.MethodPost,
+			body:   `{"namespace":"default","specs":[` + failSpec + `]}`,
+		},
+		{
+			description:    "Partial failure",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"metrics":[` + gatheredMetric + `],"error":"gatherer multi metric error: 1 errors, ` +
+				`first error is failed to get pods metric: fail"}`,
+			method:           http.MethodPost,
+			body:             `{"namespace":"default","podSelector":"app=test","specs":[` + requestsSpec + `,` + failSpec + `]}`,
+			expectedSelector: "app=test",
+		},
+		{
+			description:    "Success",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"metrics":[` + gatheredMetric + `]}`,
+			method:         http.MethodPost,
+			body:           `{"namespace":"default","specs":[` + requestsSpec + `]}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			gatherer := &k8shorizmetrics.Gatherer{
+				Pods: &fake.PodsGatherer{
+					GatherReactor: func(metricName string, namespace string, podSelector labels.Selector,
+						metricSelector labels.Selector) (*podsmetrics.Metric, error) {
+						if podSelector.String() != test.expectedSelector {
+							t.Errorf("expected pod selector %q, got %q", test.expectedSelector, podSelector.String())
+						}
+						if metricName == "fail" {
+							return nil, errors.New("fail")
+						}
+						return &podsmetrics.Metric{ReadyPodCount: 3}, nil
+					},
+				},
+			}
+			s := server.NewServer(gatherer, k8shorizmetrics.NewEvaluator(0.1))
+			s.MaxRequestBytes = test.maxRequestBytes
+
+			recorder := httptest.NewRecorder()
+			s.Handler().ServeHTTP(recorder, httptest.NewRequest(test.method, "/gather", strings.NewReader(test.body)))
+
+			if recorder.Code != test.expectedStatus {
+				t.Errorf("expected status %d, got %d", test.expectedStatus, recorder.Code)
+			}
+			body := strings.TrimSpace(recorder.Body.String())
+			if !cmp.Equal(test.expectedBody, body) {
+				t.Errorf("body mismatch (-want +got):\n%s", cmp.Diff(test.expectedBody, body))
+			}
+		})
+	}
+}
+
+func TestServerEvaluate(t *testing.T) {
+	var tests = []struct {
+		description    string
+		expectedStatus int
+		expectedBody   string
+		body           string
+	}{
+		{
+			description:    "No metrics",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"no metrics provided"}`,
+			body:           `{"currentReplicas":2}`,
+		},
+		{
+			description:    "Null metric",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid metric at index 0: metric must not be null"}`,
+			body:           `{"currentReplicas":2,"metrics":[null]}`,
+		},
+		{
+			description:    "Invalid metric spec",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid metric at index 0: unknown metric source type \"invalid\""}`,
+			body:           `{"currentReplicas":2,"metrics":[{"spec":{"type":"invalid"}}]}`,
+		},
+		{
+			description:    "Missing metric values",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid metric at index 0: pods metric values must be set"}`,
+			body:           `{"currentReplicas":2,"metrics":[{"spec":` + requestsSpec + `}]}`,
+		},
+		{
+			description:    "Missing object ready pod count",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: `{"error":"invalid metric at index 0: object metric with a value target must set current ` +
+				`value and ready pod count"}`,
+			body: `{"currentReplicas":2,"metrics":[{"spec":{"type":"Object","object":{"describedObject":{"kind":` +
+				`"Service","name":"test"},"metric":{"name":"requests"},"target":{"type":"Value","value":"10"}}},` +
+				`"object":{"current":{"value":5}}}]}`,
+		},
+		{
+			description:    "All metrics fail",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody: `{"error":"failed to evaluate metrics: evaluator multi metric error: 1 errors, first error ` +
+				`is fail"}`,
+			body: `{"currentReplicas":2,"metrics":[{"spec":{"type":"Resource","resource":{"name":"cpu","target":` +
+				`{"type":"Utilization","averageUtilization":50}}},"resource":{}}]}`,
+		},
+		{
+			description:    "Partial failure",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"replicas":5,"error":"evaluator multi metric error: 1 errors, first error is fail"}`,
+			body: `{"currentReplicas":2,"metrics":[{"spec":{"type":"Resource","resource":{"name":"cpu","target":` +
+				`{"type":"Utilization","averageUtilization":50}}},"resource":{}},{"spec":` + requestsSpec +
+				`,"pods":{}}]}`,
+		},
+		{
+			description:    "Success with tolerance",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"replicas":5}`,
+			body:           `{"currentReplicas":2,"tolerance":0.5,"metrics":[{"spec":` + requestsSpec + `,"pods":{}}]}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluator := &k8shorizmetrics.Evaluator{
+				Tolerance: 0.1,
+				Resource: &fake.ResourceEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric,
+						tolerance float64) (int32, error) {
+						return 0, errors.New("fail")
+					},
+				},
+				Pods: &fake.PodsEvaluater{
+					EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+						return currentReplicas + 3
+					},
+				},
+			}
+			s := server.NewServer(&k8shorizmetrics.Gatherer{}, evaluator)
+
+			recorder := httptest.NewRecorder()
+			s.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/evaluate",
+				strings.NewReader(test.body)))
+
+			if recorder.Code != test.expectedStatus {
+				t.Errorf("expected status %d, got %d", test.expectedStatus, recorder.Code)
+			}
+			body := strings.TrimSpace(recorder.Body.String())
+			if !cmp.Equal(test.expectedBody, body) {
+				t.Errorf("body mismatch (-want +got):\n%s", cmp.Diff(test.expectedBody, body))
+			}
+		})
+	}
+}
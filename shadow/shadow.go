@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shadow provides a dual evaluation mode, running a primary and a shadow Evaluator on the same gathered
+// metrics and reporting both results along with how far they diverge. This allows changes to evaluation behaviour to
+// be canaried in production, with only the primary result acted on.
+package shadow
+
+import (
+	"sync"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+)
+
+// Result is the outcome of evaluating with both the primary and shadow evaluators. Divergence is the shadow replica
+// count minus the primary replica count, and is only calculated if both evaluations succeeded.
+type Result struct {
+	Primary    int32 `json:"primary"`
+	PrimaryErr error `json:"-"`
+	Shadow     int32 `json:"shadow"`
+	ShadowErr  error `json:"-"`
+	Divergence int32 `json:"divergence"`
+	Diverged   bool  `json:"diverged"`
+}
+
+// Stats are the divergence statistics accumulated over all evaluations where both evaluators succeeded
+type Stats struct {
+	Evaluations       int     `json:"evaluations"`
+	Divergences       int     `json:"divergences"`
+	MaxAbsDivergence  int32   `json:"maxAbsDivergence"`
+	MeanAbsDivergence float64 `json:"meanAbsDivergence"`
+	ShadowErrors      int     `json:"shadowErrors"`
+}
+
+// Evaluator runs the Primary and Shadow evaluators on the same metrics
+type Evaluator struct {
+	Primary *k8shorizmetrics.Evaluator
+	Shadow  *k8shorizmetrics.Evaluator
+
+	mu                 sync.Mutex
+	stats              Stats
+	totalAbsDivergence int64
+}
+
+// Evaluate evaluates the metrics provided with both evaluators. The shadow evaluator is given a copy of the metrics
+// so it cannot affect the primary evaluation. The error returned is the primary evaluator's error, a shadow error is
+// only recorded in the result.
+func (e *Evaluator) Evaluate(gatheredMetrics []*metrics.Metric, currentReplicas int32) (*Result, error) {
+	shadowMetrics := copyMetrics(gatheredMetrics)
+
+	result := &Result{}
+	result.Primary, result.PrimaryErr = e.Primary.Evaluate(gatheredMetrics, currentReplicas)
+	result.Shadow, result.ShadowErr = e.Shadow.Evaluate(shadowMetrics, currentReplicas)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if result.ShadowErr != nil {
+		e.stats.ShadowErrors++
+	}
+
+	if result.PrimaryErr == nil && result.ShadowErr == nil {
+		result.Divergence = result.Shadow - result.Primary
+		result.Diverged = result.Divergence != 0
+
+		absDivergence := result.Divergence
+		if absDivergence < 0 {
+			absDivergence = -absDivergence
+		}
+
+		e.stats.Evaluations++
+		if result.Diverged {
+			e.stats.Divergences++
+		}
+		if absDivergence > e.stats.MaxAbsDivergence {
+			e.stats.MaxAbsDivergence = absDivergence
+		}
+		e.totalAbsDivergence += int64(absDivergence)
+		e.stats.MeanAbsDivergence = float64(e.totalAbsDivergence) / float64(e.stats.Evaluations)
+	}
+
+	return result, result.PrimaryErr
+}
+
+// Stats returns the divergence statistics accumulated so far
+func (e *Evaluator) Stats() Stats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.stats
+}
+
+// copyMetrics copies the metrics provided deeply enough that evaluating the copy does not modify the originals,
+// evaluation fills in values for missing and unready pods in the pod metrics so these are copied
+func copyMetrics(gatheredMetrics []*metrics.Metric) []*metrics.Metric {
+	copied := make([]*metrics.Metric, len(gatheredMetrics))
+	for i, gatheredMetric := range gatheredMetrics {
+		copiedMetric := *gatheredMetric
+
+		if gatheredMetric.Resource != nil {
+			resourceMetric := *gatheredMetric.Resource
+			resourceMetric.PodMetricsInfo = copyPodMetrics(resourceMetric.PodMetricsInfo)
+			copiedMetric.Resource = &resourceMetric
+		}
+
+		if gatheredMetric.Pods != nil {
+			podsMetric := *gatheredMetric.Pods
+			podsMetric.PodMetricsInfo = copyPodMetrics(podsMetric.PodMetricsInfo)
+			copiedMetric.Pods = &podsMetric
+		}
+
+		copied[i] = &copiedMetric
+	}
+	return copied
+}
+
+func copyPodMetrics(podMetrics podmetrics.MetricsInfo) podmetrics.MetricsInfo {
+	if podMetrics == nil {
+		return nil
+	}
+	copied := make(podmetrics.MetricsInfo, len(podMetrics))
+	for podName, podMetric := range podMetrics {
+		copied[podName] = podMetric
+	}
+	return copied
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shadow_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/shadow"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+type evaluation struct {
+	primary     int32
+	primaryErr  error
+	shadow      int32
+	shadowErr   error
+	expected    *shadow.Result
+	expectedErr error
+}
+
+func newEvaluator(replicas *int32, err *error, mutate bool) *k8shorizmetrics.Evaluator {
+	return &k8shorizmetrics.Evaluator{
+		Pods: &fake.PodsEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric) int32 {
+				if mutate {
+					gatheredMetric.Pods.PodMetricsInfo["missing-pod"] = podmetrics.Metric{Value: 0}
+				}
+				return *replicas
+			},
+		},
+		Object: &fake.ObjectEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+				return *replicas, *err
+			},
+		},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var primaryReplicas, shadowReplicas int32
+	var primaryErr, shadowErr error
+
+	evaluator := &shadow.Evaluator{
+		Primary: newEvaluator(&primaryReplicas, &primaryErr, false),
+		Shadow:  newEvaluator(&shadowReplicas, &shadowErr, true),
+	}
+
+	evaluations := []evaluation{
+		{
+			primary: 3,
+			shadow:  3,
+			expected: &shadow.Result{
+				Primary: 3,
+				Shadow:  3,
+			},
+		},
+		{
+			primary: 3,
+			shadow:  5,
+			expected: &shadow.Result{
+				Primary:    3,
+				Shadow:     5,
+				Divergence: 2,
+				Diverged:   true,
+			},
+		},
+		{
+			primary: 4,
+			shadow:  2,
+			expected: &shadow.Result{
+				Primary:    4,
+				Shadow:     2,
+				Divergence: -2,
+				Diverged:   true,
+			},
+		},
+		{
+			primary:   4,
+			shadowErr: errors.New("shadow failed"),
+			expected: &shadow.Result{
+				Primary:   4,
+				ShadowErr: errors.New("evaluator multi metric error: 1 errors, first error is shadow failed"),
+			},
+		},
+		{
+			primaryErr:  errors.New("primary failed"),
+			shadow:      3,
+			expectedErr: errors.New("evaluator multi metric error: 1 errors, first error is primary failed"),
+			expected: &shadow.Result{
+				PrimaryErr: errors.New("evaluator multi metric error: 1 errors, first error is primary failed"),
+				Shadow:     3,
+			},
+		},
+	}
+
+	for i, step := range evaluations {
+		primaryReplicas, shadowReplicas = step.primary, step.shadow
+		primaryErr, shadowErr = step.primaryErr, step.shadowErr
+
+		specType := autoscalingv2.PodsMetricSourceType
+		if step.primaryErr != nil || step.shadowErr != nil {
+			specType = autoscalingv2.ObjectMetricSourceType
+		}
+
+		gatheredMetrics := []*metrics.Metric{
+			{
+				Spec: autoscalingv2.MetricSpec{Type: specType},
+				Pods: &podsmetrics.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 100},
+					},
+				},
+			},
+		}
+
+		result, err := evaluator.Evaluate(gatheredMetrics, 3)
+		if !cmp.Equal(&err, &step.expectedErr, equateErrorMessage) {
+			t.Errorf("evaluation %d error mismatch (-want +got):\n%s", i, cmp.Diff(step.expectedErr, err, equateErrorMessage))
+		}
+		if !cmp.Equal(step.expected, result, equateErrorMessage) {
+			t.Errorf("evaluation %d result mismatch (-want +got):\n%s", i, cmp.Diff(step.expected, result, equateErrorMessage))
+		}
+		if len(gatheredMetrics[0].Pods.PodMetricsInfo) != 1 {
+			t.Errorf("evaluation %d shadow evaluator modified primary metrics", i)
+		}
+	}
+
+	expectedStats := shadow.Stats{
+		Evaluations:       3,
+		Divergences:       2,
+		MaxAbsDivergence:  2,
+		MeanAbsDivergence: 4.0 / 3.0,
+		ShadowErrors:      1,
+	}
+	if !cmp.Equal(expectedStats, evaluator.Stats(), cmpopts.EquateApprox(0, 1e-9)) {
+		t.Errorf("stats mismatch (-want +got):\n%s", cmp.Diff(expectedStats, evaluator.Stats()))
+	}
+}
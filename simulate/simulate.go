@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulate replays a time series of gathered metrics through an Evaluator and the HPA scaling behavior,
+// producing the replica count timeline the evaluations would have generated. This allows changes to tolerance and
+// scaling behavior to be validated offline against historical metrics before they are rolled out.
+//
+// Each step of the time series is evaluated at its own timestamp, so stabilization windows and scaling policy periods
+// are applied using the time between the steps rather than the time taken to run the simulation. The simulated scale
+// target is assumed to reach the replica count recommended at each step before the next step.
+package simulate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/behavior"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+// simulationKey is the key the simulated scale target's history is recorded under by the Normalizer
+const simulationKey = "simulation"
+
+// ErrNoMetrics occurs when a step has no metrics to evaluate, the replica count is left unchanged for the step
+var ErrNoMetrics = errors.New("no metrics to evaluate")
+
+// Step is a set of metrics gathered at a point in time
+type Step struct {
+	Time    time.Time         `json:"time"`
+	Metrics []*metrics.Metric `json:"metrics"`
+}
+
+// Point is the result of evaluating a single step. CurrentReplicas is the replica count before the step was
+// evaluated, StabilizedReplicas is the desired replica count after stabilization and Replicas is the replica count
+// after the scaling policies and the minimum and maximum replica counts were applied. If the evaluation failed Err is
+// set and the replica count is left unchanged.
+type Point struct {
+	Time                time.Time              `json:"time"`
+	CurrentReplicas     int32                  `json:"currentReplicas"`
+	StabilizedReplicas  int32                  `json:"stabilizedReplicas"`
+	Replicas            int32                  `json:"replicas"`
+	StabilizationReason k8shorizmetrics.Reason `json:"stabilizationReason,omitempty"`
+	LimitReason         k8shorizmetrics.Reason `json:"limitReason,omitempty"`
+	Err                 error                  `json:"-"`
+}
+
+// Summary describes a simulated replica count timeline
+type Summary struct {
+	Steps       int   `json:"steps"`
+	ScaleUps    int   `json:"scaleUps"`
+	ScaleDowns  int   `json:"scaleDowns"`
+	Errors      int   `json:"errors"`
+	MinReplicas int32 `json:"minReplicas"`
+	MaxReplicas int32 `json:"maxReplicas"`
+}
+
+// Simulator replays gathered metrics through the Evaluator, constraining each recommendation using the Behavior and
+// the MinReplicas and MaxReplicas in the same way the HPA controller does. If Behavior is nil the HPA's default
+// behavior is used, with scale down rules without a stabilization window using the DownscaleStabilizationWindow. If
+// MinReplicas is zero it defaults to 1, the HPA default, and if MaxReplicas is zero the replica count is not limited.
+type Simulator struct {
+	Evaluator                    *k8shorizmetrics.Evaluator
+	Behavior                     *autoscalingv2.HorizontalPodAutoscalerBehavior
+	MinReplicas                  int32
+	MaxReplicas                  int32
+	DownscaleStabilizationWindow time.Duration
+}
+
+// NewSimulator sets up a Simulator evaluating with the tolerance provided, using the HPA's default downscale
+// stabilization window
+func NewSimulator(tolerance float64, scalingBehavior *autoscalingv2.HorizontalPodAutoscalerBehavior,
+	minReplicas int32, maxReplicas int32) *Simulator {
+	return &Simulator{
+		Evaluator:                    k8shorizmetrics.NewEvaluator(tolerance),
+		Behavior:                     scalingBehavior,
+		MinReplicas:                  minReplicas,
+		MaxReplicas:                  maxReplicas,
+		DownscaleStabilizationWindow: behavior.DefaultDownscaleStabilizationWindow,
+	}
+}
+
+// Run replays the steps provided starting from the initial replica count, returning a point for each step in the
+// same order. Steps must be in time order, and the metrics in each step are copied before they are evaluated so the
+// steps are not modified. Run starts each simulation with no recommendation or scale event history, so the same
+// Simulator can be used to run multiple simulations.
+func (s *Simulator) Run(ctx context.Context, initialReplicas int32, steps []Step) ([]Point, error) {
+	clock := &stepClock{}
+	evaluator := &behavior.Evaluator{
+		Evaluator: s.Evaluator,
+		Normalizer: &behavior.Normalizer{
+			DownscaleStabilizationWindow: s.DownscaleStabilizationWindow,
+			Clock:                        clock,
+		},
+		Behavior:    s.Behavior,
+		MinReplicas: s.MinReplicas,
+		MaxReplicas: s.MaxReplicas,
+	}
+	if evaluator.MinReplicas == 0 {
+		evaluator.MinReplicas = 1
+	}
+	if evaluator.MaxReplicas == 0 {
+		evaluator.MaxReplicas = math.MaxInt32
+	}
+
+	points := make([]Point, len(steps))
+	currentReplicas := initialReplicas
+	for i, step := range steps {
+		err := ctx.Err()
+		if err != nil {
+			return nil, err
+		}
+
+		if i > 0 && step.Time.Before(steps[i-1].Time) {
+			return nil, fmt.Errorf("step %d at %s is before the previous step at %s", i,
+				step.Time.Format(time.RFC3339), steps[i-1].Time.Format(time.RFC3339))
+		}
+
+		clock.now = step.Time
+		point := Point{
+			Time:            step.Time,
+			CurrentReplicas: currentReplicas,
+		}
+
+		var normalization *behavior.Normalization
+		if len(step.Metrics) == 0 {
+			err = ErrNoMetrics
+		} else {
+			normalization, err = evaluator.EvaluateWithContextAndReasons(ctx, simulationKey, copyMetrics(step.Metrics),
+				currentReplicas)
+		}
+		if err != nil {
+			point.StabilizedReplicas = currentReplicas
+			point.Replicas = currentReplicas
+			point.Err = err
+			points[i] = point
+			continue
+		}
+
+		point.StabilizedReplicas = normalization.StabilizedReplicas
+		point.Replicas = normalization.Replicas
+		point.StabilizationReason = normalization.StabilizationReason
+		point.LimitReason = normalization.LimitReason
+		points[i] = point
+
+		evaluator.RecordScale(simulationKey, currentReplicas, normalization.Replicas)
+		currentReplicas = normalization.Replicas
+	}
+
+	return points, nil
+}
+
+// Summarize describes the replica count timeline provided, counting the number of times the replica count was scaled
+// up or down and the range of replica counts recommended
+func Summarize(points []Point) Summary {
+	summary := Summary{
+		Steps: len(points),
+	}
+	for i, point := range points {
+		if point.Err != nil {
+			summary.Errors++
+		}
+		if point.Replicas > point.CurrentReplicas {
+			summary.ScaleUps++
+		} else if point.Replicas < point.CurrentReplicas {
+			summary.ScaleDowns++
+		}
+		if i == 0 || point.Replicas < summary.MinReplicas {
+			summary.MinReplicas = point.Replicas
+		}
+		if i == 0 || point.Replicas > summary.MaxReplicas {
+			summary.MaxReplicas = point.Replicas
+		}
+	}
+	return summary
+}
+
+func copyMetrics(gatheredMetrics []*metrics.Metric) []*metrics.Metric {
+	copied := make([]*metrics.Metric, len(gatheredMetrics))
+	for i, gatheredMetric := range gatheredMetrics {
+		copied[i] = gatheredMetric.DeepCopy()
+	}
+	return copied
+}
+
+// stepClock is a clock fixed to the time of the step being evaluated
+type stepClock struct {
+	now time.Time
+}
+
+func (c *stepClock) Now() time.Time {
+	return c.now
+}
+
+func (c *stepClock) Since(t time.Time) time.Duration {
+	return c.now.Sub(t)
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	"github.com/jthomperoo/k8shorizmetrics/v4/simulate"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+var errEvaluation = errors.New("evaluation failed")
+
+// desiredMetric builds an external metric which the test evaluator evaluates to the desired replica count provided,
+// or fails to evaluate if the desired replica count is negative
+func desiredMetric(desiredReplicas int64) *metrics.Metric {
+	return &metrics.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ExternalMetricSourceType,
+		},
+		External: &external.Metric{
+			Current: value.MetricValue{
+				Value: &desiredReplicas,
+			},
+		},
+	}
+}
+
+func newSimulator(minReplicas int32, maxReplicas int32) *simulate.Simulator {
+	simulator := simulate.NewSimulator(0.1, nil, minReplicas, maxReplicas)
+	simulator.Evaluator = &k8shorizmetrics.Evaluator{
+		External: &fake.ExternalEvaluater{
+			EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+				desiredReplicas := *gatheredMetric.External.Current.Value
+				if desiredReplicas < 0 {
+					return 0, errEvaluation
+				}
+				return int32(desiredReplicas), nil
+			},
+		},
+	}
+	return simulator
+}
+
+func TestRun(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		description     string
+		expected        []simulate.Point
+		expectedErr     error
+		simulator       *simulate.Simulator
+		initialReplicas int32
+		steps           []simulate.Step
+	}{
+		{
+			description: "Steps out of order",
+			expectedErr: errors.New("step 1 at 2025-12-31T23:59:00Z is before the previous step at 2026-01-01T00:00:00Z"),
+			simulator:   newSimulator(1, 10),
+			steps: []simulate.Step{
+				{Time: start, Metrics: []*metrics.Metric{desiredMetric(2)}},
+				{Time: start.Add(-time.Minute), Metrics: []*metrics.Metric{desiredMetric(2)}},
+			},
+		},
+		{
+			description:     "No steps",
+			expected:        []simulate.Point{},
+			simulator:       newSimulator(1, 10),
+			initialReplicas: 2,
+		},
+		{
+			description:     "Scale up, stabilized scale down, errors and limited scale up with default behavior",
+			simulator:       newSimulator(1, 10),
+			initialReplicas: 2,
+			steps: []simulate.Step{
+				{Time: start, Metrics: []*metrics.Metric{desiredMetric(4)}},
+				{Time: start.Add(15 * time.Second), Metrics: []*metrics.Metric{desiredMetric(2)}},
+				{Time: start.Add(30 * time.Second), Metrics: []*metrics.Metric{desiredMetric(-1)}},
+				{Time: start.Add(45 * time.Second)},
+				{Time: start.Add(6 * time.Minute), Metrics: []*metrics.Metric{desiredMetric(2)}},
+				{Time: start.Add(7 * time.Minute), Metrics: []*metrics.Metric{desiredMetric(20)}},
+			},
+			expected: []simulate.Point{
+				{
+					Time:                start,
+					CurrentReplicas:     2,
+					StabilizedReplicas:  4,
+					Replicas:            4,
+					StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+					LimitReason:         k8shorizmetrics.ReasonDesiredWithinRange,
+				},
+				{
+					Time:                start.Add(15 * time.Second),
+					CurrentReplicas:     4,
+					StabilizedReplicas:  4,
+					Replicas:            4,
+					StabilizationReason: k8shorizmetrics.ReasonScaleDownStabilized,
+					LimitReason:         k8shorizmetrics.ReasonDesiredWithinRange,
+				},
+				{
+					Time:               start.Add(30 * time.Second),
+					CurrentReplicas:    4,
+					StabilizedReplicas: 4,
+					Replicas:           4,
+					Err: &k8shorizmetrics.EvaluatorMultiMetricError{
+						Errors: []error{errEvaluation},
+					},
+				},
+				{
+					Time:               start.Add(45 * time.Second),
+					CurrentReplicas:    4,
+					StabilizedReplicas: 4,
+					Replicas:           4,
+					Err:                simulate.ErrNoMetrics,
+				},
+				{
+					Time:                start.Add(6 * time.Minute),
+					CurrentReplicas:     4,
+					StabilizedReplicas:  2,
+					Replicas:            2,
+					StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+					LimitReason:         k8shorizmetrics.ReasonDesiredWithinRange,
+				},
+				{
+					Time:                start.Add(7 * time.Minute),
+					CurrentReplicas:     2,
+					StabilizedReplicas:  20,
+					Replicas:            6,
+					StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+					LimitReason:         k8shorizmetrics.ReasonScaleUpLimit,
+				},
+			},
+		},
+		{
+			description:     "Maximum replicas",
+			simulator:       newSimulator(1, 3),
+			initialReplicas: 2,
+			steps: []simulate.Step{
+				{Time: start, Metrics: []*metrics.Metric{desiredMetric(4)}},
+			},
+			expected: []simulate.Point{
+				{
+					Time:                start,
+					CurrentReplicas:     2,
+					StabilizedReplicas:  4,
+					Replicas:            3,
+					StabilizationReason: k8shorizmetrics.ReasonReadyForNewScale,
+					LimitReason:         k8shorizmetrics.ReasonTooManyReplicas,
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := test.simulator.Run(context.Background(), test.initialReplicas, test.steps)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result, equateErrorMessage, cmpopts.EquateEmpty()) {
+				t.Errorf("timeline mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, equateErrorMessage,
+					cmpopts.EquateEmpty()))
+			}
+		})
+	}
+}
+
+func TestRunDoesNotModifySteps(t *testing.T) {
+	simulator := newSimulator(1, 10)
+	simulator.Evaluator.External = &fake.ExternalEvaluater{
+		EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+			*gatheredMetric.External.Current.Value = 0
+			return 1, nil
+		},
+	}
+
+	steps := []simulate.Step{
+		{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Metrics: []*metrics.Metric{desiredMetric(4)}},
+	}
+	_, err := simulator.Run(context.Background(), 1, steps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *steps[0].Metrics[0].External.Current.Value != 4 {
+		t.Errorf("step metrics were modified")
+	}
+}
+
+func TestRunContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := newSimulator(1, 10).Run(ctx, 1, []simulate.Step{
+		{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Metrics: []*metrics.Metric{desiredMetric(4)}},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context cancelled error, got %v", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    simulate.Summary
+		points      []simulate.Point
+	}{
+		{
+			description: "No points",
+			expected:    simulate.Summary{},
+		},
+		{
+			description: "Scale ups, scale downs and errors",
+			expected: simulate.Summary{
+				Steps:       4,
+				ScaleUps:    1,
+				ScaleDowns:  1,
+				Errors:      1,
+				MinReplicas: 2,
+				MaxReplicas: 5,
+			},
+			points: []simulate.Point{
+				{CurrentReplicas: 3, Replicas: 5},
+				{CurrentReplicas: 5, Replicas: 5},
+				{CurrentReplicas: 5, Replicas: 5, Err: errEvaluation},
+				{CurrentReplicas: 5, Replicas: 2},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := simulate.Summarize(test.points)
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("summary mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulate
+
+import (
+	"fmt"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/apis/k8shorizmetrics/v1alpha1"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// utilizationRequest is the request given to each pod of a resource metric rebuilt from a utilization status, allowing
+// the utilization percentage to be represented exactly
+const utilizationRequest = 100000
+
+// StepsFromSnapshots builds steps from recorded MetricSnapshots, in the same order as the snapshots provided.
+// Snapshots only record the current value of each metric as the HPA would report it, so each metric is rebuilt as a
+// metric with the same current value gathered from the snapshot's ready pods, all of which are assumed to have
+// reported the average value. Metrics which failed to be gathered, or which have no current value recorded, are
+// left out of their step.
+func StepsFromSnapshots(snapshots []v1alpha1.MetricSnapshot) []Step {
+	steps := make([]Step, len(snapshots))
+	for i, snapshot := range snapshots {
+		step := Step{
+			Time: snapshot.Spec.GatheredTime.Time,
+		}
+		for _, gatheredMetric := range snapshot.Spec.Metrics {
+			rebuilt := metricFromSnapshot(gatheredMetric, snapshot.Spec.CurrentReplicas)
+			if rebuilt != nil {
+				step.Metrics = append(step.Metrics, rebuilt)
+			}
+		}
+		steps[i] = step
+	}
+	return steps
+}
+
+func metricFromSnapshot(gatheredMetric v1alpha1.GatheredMetric, currentReplicas int32) *metrics.Metric {
+	if gatheredMetric.Error != "" || gatheredMetric.Current == nil {
+		return nil
+	}
+
+	readyPodCount := int64(currentReplicas)
+	if gatheredMetric.ReadyPodCount != nil {
+		readyPodCount = *gatheredMetric.ReadyPodCount
+	}
+
+	rebuilt := &metrics.Metric{
+		Spec:  *gatheredMetric.Spec.DeepCopy(),
+		Stale: gatheredMetric.Stale,
+	}
+
+	current := gatheredMetric.Current
+	switch gatheredMetric.Spec.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if current.Resource == nil || readyPodCount <= 0 {
+			return nil
+		}
+		rebuilt.Resource = resourceFromSnapshot(current.Resource.Current, readyPodCount)
+		if rebuilt.Resource == nil {
+			return nil
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if current.Pods == nil || current.Pods.Current.AverageValue == nil || readyPodCount <= 0 {
+			return nil
+		}
+		podMetricsInfo := podMetricsFromAverage(current.Pods.Current.AverageValue.MilliValue(), readyPodCount)
+		rebuilt.Pods = &pods.Metric{
+			PodMetricsInfo: podMetricsInfo,
+			ReadyPodCount:  readyPodCount,
+			IgnoredPods:    sets.String{},
+			MissingPods:    sets.String{},
+			TotalPods:      len(podMetricsInfo),
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if current.Object == nil {
+			return nil
+		}
+		currentValue, ok := valueFromSnapshot(current.Object.Current, currentReplicas)
+		if !ok {
+			return nil
+		}
+		rebuilt.Object = &object.Metric{
+			Current:       currentValue,
+			ReadyPodCount: &readyPodCount,
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if current.External == nil {
+			return nil
+		}
+		currentValue, ok := valueFromSnapshot(current.External.Current, currentReplicas)
+		if !ok {
+			return nil
+		}
+		rebuilt.External = &external.Metric{
+			Current:       currentValue,
+			ReadyPodCount: &readyPodCount,
+		}
+	default:
+		return nil
+	}
+
+	return rebuilt
+}
+
+// resourceFromSnapshot rebuilds a resource metric with the current value provided, for a utilization status each pod
+// is given the same request so the total utilization matches the status
+func resourceFromSnapshot(current autoscalingv2.MetricValueStatus, readyPodCount int64) *resource.Metric {
+	var podMetricsInfo podmetrics.MetricsInfo
+	var requests map[string]int64
+	switch {
+	case current.AverageUtilization != nil:
+		podMetricsInfo = podMetricsFromAverage(int64(*current.AverageUtilization)*utilizationRequest/100, readyPodCount)
+		requests = map[string]int64{}
+		for podName := range podMetricsInfo {
+			requests[podName] = utilizationRequest
+		}
+	case current.Value != nil:
+		total := current.Value.MilliValue()
+		podMetricsInfo = podMetricsFromAverage(total/readyPodCount, readyPodCount)
+		// Add any remainder to a single pod so the total matches
+		podMetricsInfo[podName(0)] = podmetrics.Metric{
+			Value: podMetricsInfo[podName(0)].Value + total%readyPodCount,
+		}
+	case current.AverageValue != nil:
+		podMetricsInfo = podMetricsFromAverage(current.AverageValue.MilliValue(), readyPodCount)
+	default:
+		return nil
+	}
+
+	return &resource.Metric{
+		PodMetricsInfo: podMetricsInfo,
+		Requests:       requests,
+		ReadyPodCount:  readyPodCount,
+		IgnoredPods:    sets.String{},
+		MissingPods:    sets.String{},
+		TotalPods:      len(podMetricsInfo),
+	}
+}
+
+// valueFromSnapshot rebuilds an object or external metric value, average values are reported as the total value
+// divided between the current replicas so the total is the average multiplied by the current replicas
+func valueFromSnapshot(current autoscalingv2.MetricValueStatus, currentReplicas int32) (value.MetricValue, bool) {
+	if current.Value != nil {
+		currentValue := current.Value.MilliValue()
+		return value.MetricValue{
+			Value: &currentValue,
+		}, true
+	}
+	if current.AverageValue != nil {
+		averageValue := current.AverageValue.MilliValue() * int64(currentReplicas)
+		return value.MetricValue{
+			AverageValue: &averageValue,
+		}, true
+	}
+	return value.MetricValue{}, false
+}
+
+func podMetricsFromAverage(averageValue int64, readyPodCount int64) podmetrics.MetricsInfo {
+	podMetricsInfo := make(podmetrics.MetricsInfo, readyPodCount)
+	for i := int64(0); i < readyPodCount; i++ {
+		podMetricsInfo[podName(i)] = podmetrics.Metric{
+			Value: averageValue,
+		}
+	}
+	return podMetricsInfo
+}
+
+func podName(i int64) string {
+	return fmt.Sprintf("snapshot-pod-%d", i)
+}
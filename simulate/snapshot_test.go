@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/apis/k8shorizmetrics/v1alpha1"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	"github.com/jthomperoo/k8shorizmetrics/v4/simulate"
+	"github.com/jthomperoo/k8shorizmetrics/v4/snapshot"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestStepsFromSnapshots(t *testing.T) {
+	gatheredTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	describedObject := autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "networking.k8s.io/v1",
+		Kind:       "Ingress",
+		Name:       "main-route",
+	}
+
+	podMetrics := func(values ...int64) podmetrics.MetricsInfo {
+		podMetricsInfo := podmetrics.MetricsInfo{}
+		for i, podValue := range values {
+			podMetricsInfo[string(rune('a'+i))] = podmetrics.Metric{Value: podValue}
+		}
+		return podMetricsInfo
+	}
+
+	resourceMetric := func(spec autoscalingv2.MetricSpec, values ...int64) *metrics.Metric {
+		requests := map[string]int64{}
+		for podName := range podMetrics(values...) {
+			requests[podName] = 500
+		}
+		return &metrics.Metric{
+			Spec: spec,
+			Resource: &resource.Metric{
+				PodMetricsInfo: podMetrics(values...),
+				Requests:       requests,
+				ReadyPodCount:  int64(len(values)),
+				IgnoredPods:    sets.String{},
+				MissingPods:    sets.String{},
+				TotalPods:      len(values),
+			},
+		}
+	}
+
+	var tests = []struct {
+		description     string
+		currentReplicas int32
+		metric          *metrics.Metric
+	}{
+		{
+			description:     "Resource utilization",
+			currentReplicas: 3,
+			metric: resourceMetric(k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceCPU, 50),
+				400, 450, 500),
+		},
+		{
+			description:     "Resource average value",
+			currentReplicas: 3,
+			metric: resourceMetric(k8shorizmetrics.NewResourceAverageValueSpec(corev1.ResourceCPU,
+				k8sresource.MustParse("200m")), 400, 450, 500),
+		},
+		{
+			description:     "Resource value",
+			currentReplicas: 3,
+			metric: resourceMetric(k8shorizmetrics.NewResourceValueSpec(corev1.ResourceCPU,
+				k8sresource.MustParse("1")), 400, 451, 500),
+		},
+		{
+			description:     "Pods average value",
+			currentReplicas: 2,
+			metric: &metrics.Metric{
+				Spec: k8shorizmetrics.NewPodsAverageValueSpec("packets_per_second", k8sresource.MustParse("100"), nil),
+				Pods: &pods.Metric{
+					PodMetricsInfo: podMetrics(250000, 350000),
+					ReadyPodCount:  2,
+					IgnoredPods:    sets.String{},
+					MissingPods:    sets.String{},
+					TotalPods:      2,
+				},
+			},
+		},
+		{
+			description:     "Object value",
+			currentReplicas: 4,
+			metric: &metrics.Metric{
+				Spec: k8shorizmetrics.NewObjectValueSpec(describedObject, "requests_per_second",
+					k8sresource.MustParse("10"), nil),
+				Object: &object.Metric{
+					Current:       value.MetricValue{Value: testutil.Int64Ptr(25000)},
+					ReadyPodCount: testutil.Int64Ptr(4),
+				},
+			},
+		},
+		{
+			description:     "Object average value",
+			currentReplicas: 4,
+			metric: &metrics.Metric{
+				Spec: k8shorizmetrics.NewObjectAverageValueSpec(describedObject, "requests_per_second",
+					k8sresource.MustParse("10"), nil),
+				Object: &object.Metric{
+					Current:       value.MetricValue{AverageValue: testutil.Int64Ptr(64000)},
+					ReadyPodCount: testutil.Int64Ptr(4),
+				},
+			},
+		},
+		{
+			description:     "External value",
+			currentReplicas: 2,
+			metric: &metrics.Metric{
+				Spec: k8shorizmetrics.NewExternalValueSpec("queue_length", k8sresource.MustParse("30"), nil),
+				External: &external.Metric{
+					Current:       value.MetricValue{Value: testutil.Int64Ptr(90000)},
+					ReadyPodCount: testutil.Int64Ptr(2),
+				},
+			},
+		},
+		{
+			description:     "External average value",
+			currentReplicas: 2,
+			metric: &metrics.Metric{
+				Spec: k8shorizmetrics.NewExternalAverageValueSpec("queue_length", k8sresource.MustParse("30"), nil),
+				External: &external.Metric{
+					Current:       value.MetricValue{AverageValue: testutil.Int64Ptr(150000)},
+					ReadyPodCount: testutil.Int64Ptr(2),
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluator := k8shorizmetrics.NewEvaluator(0.1)
+			expected, err := evaluator.Evaluate([]*metrics.Metric{test.metric.DeepCopy()}, test.currentReplicas)
+			if err != nil {
+				t.Fatalf("unexpected error evaluating gathered metric: %v", err)
+			}
+
+			metricSnapshot := snapshot.NewMetricSnapshot(metav1.ObjectMeta{}, autoscalingv2.CrossVersionObjectReference{},
+				test.currentReplicas, []*k8shorizmetrics.GatherResult{
+					{
+						Spec:   test.metric.Spec,
+						Metric: test.metric,
+					},
+					{
+						Spec: test.metric.Spec,
+						Err:  errors.New("failed to gather"),
+					},
+				}, gatheredTime)
+
+			steps := simulate.StepsFromSnapshots([]v1alpha1.MetricSnapshot{*metricSnapshot})
+			if len(steps) != 1 {
+				t.Fatalf("expected 1 step, got %d", len(steps))
+			}
+			if !steps[0].Time.Equal(gatheredTime) {
+				t.Errorf("step time mismatch, want %s got %s", gatheredTime, steps[0].Time)
+			}
+			if len(steps[0].Metrics) != 1 {
+				t.Fatalf("expected failed metric to be left out of step, got %d metrics", len(steps[0].Metrics))
+			}
+
+			result, err := evaluator.Evaluate(steps[0].Metrics, test.currentReplicas)
+			if err != nil {
+				t.Fatalf("unexpected error evaluating rebuilt metric: %v", err)
+			}
+			if !cmp.Equal(expected, result) {
+				t.Errorf("replicas mismatch (-want +got):\n%s", cmp.Diff(expected, result))
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package smoothing damps noisy replica count recommendations, applying an exponentially weighted moving average or a
+// simple moving average over the recent recommendations for each target before they are used. Smoothing is configured
+// separately for scaling up and scaling down, for example to smooth scale downs caused by a noisy external metric while
+// still following scale ups immediately.
+//
+// Smoothing only slows changes to the replica count, a smoothed recommendation is always between the current replica
+// count and the recommendation, so smoothing never scales in the opposite direction to the recommendation.
+package smoothing
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+)
+
+// Method smooths the recommendations made for a target
+type Method interface {
+	// Smooth returns the smoothed recommendation, given the previous smoothed recommendation and the history of
+	// recommendations, oldest first, ending with the recommendation to smooth
+	Smooth(previous float64, history []int32) float64
+	// HistorySize returns the number of recommendations the method uses
+	HistorySize() int
+}
+
+// EWMA smooths recommendations using an exponentially weighted moving average, weighting each new recommendation by
+// Alpha and the previous smoothed recommendation by 1 - Alpha. Alpha must be between 0 and 1, lower values smooth
+// more.
+type EWMA struct {
+	Alpha float64
+}
+
+// Smooth returns the exponentially weighted moving average of the previous smoothed recommendation and the latest
+// recommendation
+func (e *EWMA) Smooth(previous float64, history []int32) float64 {
+	return e.Alpha*float64(history[len(history)-1]) + (1-e.Alpha)*previous
+}
+
+// HistorySize returns 1, only the latest recommendation is used
+func (e *EWMA) HistorySize() int {
+	return 1
+}
+
+// SMA smooths recommendations using a simple moving average of the last Size recommendations
+type SMA struct {
+	Size int
+}
+
+// Smooth returns the mean of the last Size recommendations
+func (s *SMA) Smooth(previous float64, history []int32) float64 {
+	if len(history) > s.Size {
+		history = history[len(history)-s.Size:]
+	}
+
+	total := 0.0
+	for _, recommendation := range history {
+		total += float64(recommendation)
+	}
+	return total / float64(len(history))
+}
+
+// HistorySize returns the number of recommendations averaged
+func (s *SMA) HistorySize() int {
+	return s.Size
+}
+
+type state struct {
+	history  []int32
+	smoothed float64
+}
+
+// Smoother keeps the recent recommendations for each target, keyed by a string identifying the target (see
+// stabilization.TargetKey), and smooths new recommendations using ScaleUp when the recommendation is above the current
+// replica count and ScaleDown when it is below. If the method for a direction is nil recommendations in that direction
+// are not smoothed. The first smoothed recommendation for a target starts from its current replica count.
+type Smoother struct {
+	ScaleUp   Method
+	ScaleDown Method
+
+	mu     sync.Mutex
+	states map[string]*state
+}
+
+// NewSmoother sets up a Smoother using the methods provided for each direction, either can be nil to not smooth in
+// that direction
+func NewSmoother(scaleUp Method, scaleDown Method) *Smoother {
+	return &Smoother{
+		ScaleUp:   scaleUp,
+		ScaleDown: scaleDown,
+	}
+}
+
+// Smooth records the recommendation for the target and returns the smoothed recommendation, rounded to the nearest
+// replica and kept between the current replica count and the recommendation
+func (s *Smoother) Smooth(key string, currentReplicas int32, recommendation int32) int32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.states == nil {
+		s.states = map[string]*state{}
+	}
+
+	targetState, ok := s.states[key]
+	if !ok {
+		targetState = &state{
+			smoothed: float64(currentReplicas),
+		}
+		s.states[key] = targetState
+	}
+
+	targetState.history = append(targetState.history, recommendation)
+	if historySize := s.historySize(); len(targetState.history) > historySize {
+		targetState.history = targetState.history[len(targetState.history)-historySize:]
+	}
+
+	var method Method
+	switch {
+	case recommendation > currentReplicas:
+		method = s.ScaleUp
+	case recommendation < currentReplicas:
+		method = s.ScaleDown
+	}
+
+	if method == nil {
+		targetState.smoothed = float64(recommendation)
+		return recommendation
+	}
+
+	targetState.smoothed = method.Smooth(targetState.smoothed, targetState.history)
+
+	smoothed := int32(math.Round(targetState.smoothed))
+	return min(max(smoothed, min(currentReplicas, recommendation)), max(currentReplicas, recommendation))
+}
+
+// Forget discards the recommendations recorded for the target, for example when the target is deleted
+func (s *Smoother) Forget(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, key)
+}
+
+func (s *Smoother) historySize() int {
+	historySize := 1
+	for _, method := range []Method{s.ScaleUp, s.ScaleDown} {
+		if method != nil {
+			historySize = max(historySize, method.HistorySize())
+		}
+	}
+	return historySize
+}
+
+// Evaluator wraps an Evaluator, smoothing its evaluations for each target using the Smoother
+type Evaluator struct {
+	Evaluator *k8shorizmetrics.Evaluator
+	Smoother  *Smoother
+}
+
+// Evaluate returns the smoothed target replica count for the target identified by the key provided based on the
+// metrics provided
+func (e *Evaluator) Evaluate(key string, gatheredMetrics []*metrics.Metric, currentReplicas int32) (int32, error) {
+	return e.EvaluateWithContext(context.Background(), key, gatheredMetrics, currentReplicas)
+}
+
+// EvaluateWithContext returns the smoothed target replica count for the target identified by the key provided based
+// on the metrics provided, passing the context provided to the Evaluator
+func (e *Evaluator) EvaluateWithContext(ctx context.Context, key string, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32) (int32, error) {
+	recommendation, err := e.Evaluator.EvaluateWithContext(ctx, gatheredMetrics, currentReplicas)
+	if err != nil {
+		return 0, err
+	}
+
+	return e.Smoother.Smooth(key, currentReplicas, recommendation), nil
+}
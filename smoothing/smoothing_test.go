@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package smoothing_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/smoothing"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+func TestMethods_Smooth(t *testing.T) {
+	var tests = []struct {
+		description string
+		expected    float64
+		method      smoothing.Method
+		previous    float64
+		history     []int32
+	}{
+		{
+			description: "EWMA, weights latest recommendation by alpha",
+			expected:    4.4,
+			method:      &smoothing.EWMA{Alpha: 0.2},
+			previous:    3,
+			history:     []int32{1, 10},
+		},
+		{
+			description: "EWMA, alpha of 1 follows recommendation",
+			expected:    10,
+			method:      &smoothing.EWMA{Alpha: 1},
+			previous:    3,
+			history:     []int32{10},
+		},
+		{
+			description: "SMA, fewer recommendations than size",
+			expected:    3,
+			method:      &smoothing.SMA{Size: 4},
+			previous:    0,
+			history:     []int32{2, 4},
+		},
+		{
+			description: "SMA, only last size recommendations used",
+			expected:    5,
+			method:      &smoothing.SMA{Size: 3},
+			previous:    0,
+			history:     []int32{20, 2, 5, 8},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := test.method.Smooth(test.previous, test.history)
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("smoothed mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+type step struct {
+	currentReplicas int32
+	recommendation  int32
+	expected        int32
+}
+
+func TestSmoother_Smooth(t *testing.T) {
+	var tests = []struct {
+		description string
+		smoother    *smoothing.Smoother
+		steps       []step
+	}{
+		{
+			description: "No smoothing",
+			smoother:    smoothing.NewSmoother(nil, nil),
+			steps: []step{
+				{currentReplicas: 2, recommendation: 8, expected: 8},
+				{currentReplicas: 8, recommendation: 1, expected: 1},
+			},
+		},
+		{
+			description: "EWMA scale down, scale up not smoothed",
+			smoother:    smoothing.NewSmoother(nil, &smoothing.EWMA{Alpha: 0.5}),
+			steps: []step{
+				{currentReplicas: 10, recommendation: 2, expected: 6},
+				{currentReplicas: 6, recommendation: 2, expected: 4},
+				{currentReplicas: 4, recommendation: 2, expected: 3},
+				{currentReplicas: 3, recommendation: 2, expected: 3},
+				{currentReplicas: 3, recommendation: 2, expected: 2},
+				{currentReplicas: 2, recommendation: 8, expected: 8},
+			},
+		},
+		{
+			description: "EWMA both directions",
+			smoother:    smoothing.NewSmoother(&smoothing.EWMA{Alpha: 0.5}, &smoothing.EWMA{Alpha: 0.5}),
+			steps: []step{
+				{currentReplicas: 2, recommendation: 8, expected: 5},
+				{currentReplicas: 5, recommendation: 8, expected: 7},
+				{currentReplicas: 7, recommendation: 2, expected: 4},
+			},
+		},
+		{
+			description: "SMA, never scales against the recommendation",
+			smoother:    smoothing.NewSmoother(&smoothing.SMA{Size: 3}, &smoothing.SMA{Size: 3}),
+			steps: []step{
+				{currentReplicas: 4, recommendation: 4, expected: 4},
+				{currentReplicas: 4, recommendation: 1, expected: 3},
+				{currentReplicas: 3, recommendation: 5, expected: 3},
+				{currentReplicas: 3, recommendation: 6, expected: 4},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			for i, step := range test.steps {
+				result := test.smoother.Smooth("test", step.currentReplicas, step.recommendation)
+				if result != step.expected {
+					t.Errorf("step %d mismatch, want %d, got %d", i, step.expected, result)
+				}
+			}
+		})
+	}
+}
+
+func TestSmoother_Forget(t *testing.T) {
+	smoother := smoothing.NewSmoother(nil, &smoothing.EWMA{Alpha: 0.5})
+
+	smoother.Smooth("test", 10, 2)
+	smoother.Smooth("other", 10, 2)
+	smoother.Forget("test")
+
+	// A forgotten target starts smoothing from its current replica count again
+	if result := smoother.Smooth("test", 4, 2); result != 3 {
+		t.Errorf("forgotten target mismatch, want 3, got %d", result)
+	}
+
+	if result := smoother.Smooth("other", 6, 2); result != 4 {
+		t.Errorf("other target mismatch, want 4, got %d", result)
+	}
+}
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	gatheredMetrics := []*metrics.Metric{
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+			},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expected    int32
+		expectedErr error
+		evaluation  int32
+		err         error
+	}{
+		{
+			description: "Fail to evaluate",
+			expected:    0,
+			expectedErr: errors.New("evaluator multi metric error: 1 errors, first error is fail to evaluate"),
+			err:         errors.New("fail to evaluate"),
+		},
+		{
+			description: "Smooth evaluation",
+			expected:    6,
+			evaluation:  2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluator := &smoothing.Evaluator{
+				Evaluator: &k8shorizmetrics.Evaluator{
+					Resource: &fake.ResourceEvaluater{
+						EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+							return test.evaluation, test.err
+						},
+					},
+				},
+				Smoother: smoothing.NewSmoother(nil, &smoothing.EWMA{Alpha: 0.5}),
+			}
+
+			result, err := evaluator.Evaluate("test", gatheredMetrics, 10)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+
+			if result != test.expected {
+				t.Errorf("evaluation mismatch, want %d, got %d", test.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package snapshot builds the MetricSnapshot and ScalingRecommendation custom resources from gathered metrics and
+// detailed evaluations, so they can be persisted as cluster objects for auditing using the generated clientset.
+package snapshot
+
+import (
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/apis/k8shorizmetrics/v1alpha1"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/status"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewMetricSnapshot builds a MetricSnapshot recording the results of gathering the metrics of the scale target
+// provided. The current value of each metric is recorded as the HPA would report it in its status, if the status of a
+// metric cannot be determined only its spec is recorded.
+func NewMetricSnapshot(objectMeta metav1.ObjectMeta, scaleTargetRef autoscalingv2.CrossVersionObjectReference,
+	currentReplicas int32, results []*k8shorizmetrics.GatherResult, gatheredTime time.Time) *v1alpha1.MetricSnapshot {
+	gatheredMetrics := make([]v1alpha1.GatheredMetric, len(results))
+	for i, result := range results {
+		gatheredMetric := v1alpha1.GatheredMetric{
+			Spec: *result.Spec.DeepCopy(),
+		}
+
+		if result.Err != nil {
+			gatheredMetric.Error = result.Err.Error()
+		} else if result.Metric != nil {
+			current, err := status.MetricStatus(result.Metric, currentReplicas)
+			if err == nil {
+				gatheredMetric.Current = current
+			}
+			gatheredMetric.ReadyPodCount = readyPodCount(result.Metric)
+			gatheredMetric.Stale = result.Metric.Stale
+		}
+
+		gatheredMetrics[i] = gatheredMetric
+	}
+
+	return &v1alpha1.MetricSnapshot{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "MetricSnapshot",
+		},
+		ObjectMeta: objectMeta,
+		Spec: v1alpha1.MetricSnapshotSpec{
+			ScaleTargetRef:  scaleTargetRef,
+			GatheredTime:    metav1.NewTime(gatheredTime),
+			CurrentReplicas: currentReplicas,
+			Metrics:         gatheredMetrics,
+		},
+	}
+}
+
+// NewScalingRecommendation builds a ScalingRecommendation recording the detailed evaluation of the metrics of the
+// scale target provided.
+func NewScalingRecommendation(objectMeta metav1.ObjectMeta, scaleTargetRef autoscalingv2.CrossVersionObjectReference,
+	currentReplicas int32, evaluation *k8shorizmetrics.DetailedEvaluation,
+	evaluatedTime time.Time) *v1alpha1.ScalingRecommendation {
+	metricRecommendations := make([]v1alpha1.MetricRecommendation, len(evaluation.Metrics))
+	for i, metricEvaluation := range evaluation.Metrics {
+		metricRecommendation := v1alpha1.MetricRecommendation{
+			Replicas: metricEvaluation.Replicas,
+			Winning:  metricEvaluation.Winning,
+		}
+
+		if metricEvaluation.Metric != nil {
+			metricRecommendation.Spec = *metricEvaluation.Metric.Spec.DeepCopy()
+		}
+
+		if metricEvaluation.UsageRatio != nil {
+			metricRecommendation.UsageRatio = resource.NewMilliQuantity(int64(*metricEvaluation.UsageRatio*1000),
+				resource.DecimalSI)
+		}
+
+		if metricEvaluation.Err != nil {
+			metricRecommendation.Error = metricEvaluation.Err.Error()
+		}
+
+		metricRecommendations[i] = metricRecommendation
+	}
+
+	return &v1alpha1.ScalingRecommendation{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "ScalingRecommendation",
+		},
+		ObjectMeta: objectMeta,
+		Spec: v1alpha1.ScalingRecommendationSpec{
+			ScaleTargetRef:  scaleTargetRef,
+			EvaluatedTime:   metav1.NewTime(evaluatedTime),
+			CurrentReplicas: currentReplicas,
+			DesiredReplicas: evaluation.Replicas,
+			Metrics:         metricRecommendations,
+		},
+	}
+}
+
+// readyPodCount returns a copy of the number of ready pods the metric was gathered from, nil if it is not known
+func readyPodCount(gatheredMetric *metrics.Metric) *int64 {
+	var count *int64
+	switch {
+	case gatheredMetric.Resource != nil:
+		count = &gatheredMetric.Resource.ReadyPodCount
+	case gatheredMetric.Pods != nil:
+		count = &gatheredMetric.Pods.ReadyPodCount
+	case gatheredMetric.Object != nil:
+		count = gatheredMetric.Object.ReadyPodCount
+	case gatheredMetric.External != nil:
+		count = gatheredMetric.External.ReadyPodCount
+	}
+
+	if count == nil {
+		return nil
+	}
+	copied := *count
+	return &copied
+}
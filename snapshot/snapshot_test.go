@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/apis/k8shorizmetrics/v1alpha1"
+	"github.com/jthomperoo/k8shorizmetrics/v4/client/clientset/versioned/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	"github.com/jthomperoo/k8shorizmetrics/v4/snapshot"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var scaleTargetRef = autoscalingv2.CrossVersionObjectReference{
+	APIVersion: "apps/v1",
+	Kind:       "Deployment",
+	Name:       "test",
+}
+
+func podsSpec() autoscalingv2.MetricSpec {
+	averageValue := resource.MustParse("100m")
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "requests"},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: &averageValue,
+			},
+		},
+	}
+}
+
+func TestNewMetricSnapshot(t *testing.T) {
+	gatheredTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	objectMeta := metav1.ObjectMeta{Name: "test-snapshot", Namespace: "test"}
+
+	var tests = []struct {
+		description string
+		expected    *v1alpha1.MetricSnapshot
+		results     []*k8shorizmetrics.GatherResult
+	}{
+		{
+			description: "No results",
+			expected: &v1alpha1.MetricSnapshot{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "k8shorizmetrics.com/v1alpha1", Kind: "MetricSnapshot"},
+				ObjectMeta: objectMeta,
+				Spec: v1alpha1.MetricSnapshotSpec{
+					ScaleTargetRef:  scaleTargetRef,
+					GatheredTime:    metav1.NewTime(gatheredTime),
+					CurrentReplicas: 2,
+					Metrics:         []v1alpha1.GatheredMetric{},
+				},
+			},
+			results: []*k8shorizmetrics.GatherResult{},
+		},
+		{
+			description: "Gathered metric, failed metric and metric without a status",
+			expected: &v1alpha1.MetricSnapshot{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "k8shorizmetrics.com/v1alpha1", Kind: "MetricSnapshot"},
+				ObjectMeta: objectMeta,
+				Spec: v1alpha1.MetricSnapshotSpec{
+					ScaleTargetRef:  scaleTargetRef,
+					GatheredTime:    metav1.NewTime(gatheredTime),
+					CurrentReplicas: 2,
+					Metrics: []v1alpha1.GatheredMetric{
+						{
+							Spec: podsSpec(),
+							Current: &autoscalingv2.MetricStatus{
+								Type: autoscalingv2.PodsMetricSourceType,
+								Pods: &autoscalingv2.PodsMetricStatus{
+									Metric: autoscalingv2.MetricIdentifier{Name: "requests"},
+									Current: autoscalingv2.MetricValueStatus{
+										AverageValue: resource.NewMilliQuantity(150, resource.DecimalSI),
+									},
+								},
+							},
+							ReadyPodCount: testutil.Int64Ptr(2),
+							Stale:         true,
+						},
+						{
+							Spec:  podsSpec(),
+							Error: "failed to get pods metric: gather error",
+						},
+						{
+							Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ExternalMetricSourceType},
+						},
+					},
+				},
+			},
+			results: []*k8shorizmetrics.GatherResult{
+				{
+					Spec: podsSpec(),
+					Metric: &metrics.Metric{
+						Spec: podsSpec(),
+						Pods: &pods.Metric{
+							PodMetricsInfo: podmetrics.MetricsInfo{
+								"pod-1": podmetrics.Metric{Value: 100},
+								"pod-2": podmetrics.Metric{Value: 200},
+							},
+							ReadyPodCount: 2,
+						},
+						Stale: true,
+					},
+				},
+				{
+					Spec: podsSpec(),
+					Err:  errors.New("failed to get pods metric: gather error"),
+				},
+				{
+					Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ExternalMetricSourceType},
+					Metric: &metrics.Metric{
+						Spec: autoscalingv2.MetricSpec{Type: autoscalingv2.ExternalMetricSourceType},
+						External: &external.Metric{
+							Current: value.MetricValue{Value: testutil.Int64Ptr(5)},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := snapshot.NewMetricSnapshot(objectMeta, scaleTargetRef, 2, test.results, gatheredTime)
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("snapshot mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestNewScalingRecommendation(t *testing.T) {
+	evaluatedTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	objectMeta := metav1.ObjectMeta{Name: "test-recommendation", Namespace: "test"}
+	usageRatio := 1.5
+
+	expected := &v1alpha1.ScalingRecommendation{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "k8shorizmetrics.com/v1alpha1", Kind: "ScalingRecommendation"},
+		ObjectMeta: objectMeta,
+		Spec: v1alpha1.ScalingRecommendationSpec{
+			ScaleTargetRef:  scaleTargetRef,
+			EvaluatedTime:   metav1.NewTime(evaluatedTime),
+			CurrentReplicas: 2,
+			DesiredReplicas: 3,
+			Metrics: []v1alpha1.MetricRecommendation{
+				{
+					Spec:       podsSpec(),
+					Replicas:   3,
+					UsageRatio: resource.NewMilliQuantity(1500, resource.DecimalSI),
+					Winning:    true,
+				},
+				{
+					Spec:  podsSpec(),
+					Error: "evaluate error",
+				},
+			},
+		},
+	}
+
+	result := snapshot.NewScalingRecommendation(objectMeta, scaleTargetRef, 2, &k8shorizmetrics.DetailedEvaluation{
+		Replicas: 3,
+		Metrics: []*k8shorizmetrics.MetricEvaluation{
+			{
+				Metric:     &metrics.Metric{Spec: podsSpec()},
+				Replicas:   3,
+				UsageRatio: &usageRatio,
+				Winning:    true,
+			},
+			{
+				Metric: &metrics.Metric{Spec: podsSpec()},
+				Err:    errors.New("evaluate error"),
+			},
+		},
+	}, evaluatedTime)
+	if !cmp.Equal(expected, result) {
+		t.Errorf("recommendation mismatch (-want +got):\n%s", cmp.Diff(expected, result))
+	}
+}
+
+func TestPersistWithClientset(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{Name: "test", Namespace: "test"}
+	clientset := fake.NewSimpleClientset()
+
+	metricSnapshot := snapshot.NewMetricSnapshot(objectMeta, scaleTargetRef, 2, []*k8shorizmetrics.GatherResult{},
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	_, err := clientset.K8shorizmetricsV1alpha1().MetricSnapshots("test").Create(context.Background(), metricSnapshot,
+		metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error creating snapshot: %s", err)
+	}
+
+	result, err := clientset.K8shorizmetricsV1alpha1().MetricSnapshots("test").Get(context.Background(), "test",
+		metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error getting snapshot: %s", err)
+	}
+	if !cmp.Equal(metricSnapshot.Spec, result.Spec) {
+		t.Errorf("snapshot mismatch (-want +got):\n%s", cmp.Diff(metricSnapshot.Spec, result.Spec))
+	}
+}
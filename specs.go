@@ -0,0 +1,387 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// NewResourceUtilizationSpec builds a resource metric spec targeting the average utilization percentage of the
+// resource across all pods
+func NewResourceUtilizationSpec(name corev1.ResourceName, averageUtilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &averageUtilization,
+			},
+		},
+	}
+}
+
+// NewResourceAverageValueSpec builds a resource metric spec targeting the average value of the resource across all
+// pods
+func NewResourceAverageValueSpec(name corev1.ResourceName, averageValue resource.Quantity) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: &averageValue,
+			},
+		},
+	}
+}
+
+// NewResourceValueSpec builds a resource metric spec targeting the total value of the resource across all pods
+func NewResourceValueSpec(name corev1.ResourceName, value resource.Quantity) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:  autoscalingv2.ValueMetricType,
+				Value: &value,
+			},
+		},
+	}
+}
+
+// NewPodsAverageValueSpec builds a pods metric spec targeting the average value of the named metric across all pods,
+// the selector is optional and can be nil
+func NewPodsAverageValueSpec(name string, averageValue resource.Quantity,
+	selector *metav1.LabelSelector) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name:     name,
+				Selector: selector,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: &averageValue,
+			},
+		},
+	}
+}
+
+// NewPodsValueSpec builds a pods metric spec targeting the total value of the named metric across all pods, the
+// selector is optional and can be nil
+func NewPodsValueSpec(name string, value resource.Quantity, selector *metav1.LabelSelector) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name:     name,
+				Selector: selector,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:  autoscalingv2.ValueMetricType,
+				Value: &value,
+			},
+		},
+	}
+}
+
+// NewObjectValueSpec builds an object metric spec targeting the value of the named metric describing the object
+// provided, the selector is optional and can be nil
+func NewObjectValueSpec(describedObject autoscalingv2.CrossVersionObjectReference, name string, value resource.Quantity,
+	selector *metav1.LabelSelector) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ObjectMetricSourceType,
+		Object: &autoscalingv2.ObjectMetricSource{
+			DescribedObject: describedObject,
+			Metric: autoscalingv2.MetricIdentifier{
+				Name:     name,
+				Selector: selector,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:  autoscalingv2.ValueMetricType,
+				Value: &value,
+			},
+		},
+	}
+}
+
+// NewObjectAverageValueSpec builds an object metric spec targeting the value of the named metric describing the
+// object provided divided by the number of pods, the selector is optional and can be nil
+func NewObjectAverageValueSpec(describedObject autoscalingv2.CrossVersionObjectReference, name string,
+	averageValue resource.Quantity, selector *metav1.LabelSelector) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ObjectMetricSourceType,
+		Object: &autoscalingv2.ObjectMetricSource{
+			DescribedObject: describedObject,
+			Metric: autoscalingv2.MetricIdentifier{
+				Name:     name,
+				Selector: selector,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: &averageValue,
+			},
+		},
+	}
+}
+
+// NewExternalValueSpec builds an external metric spec targeting the total value of the named metric, the selector is
+// optional and can be nil
+func NewExternalValueSpec(name string, value resource.Quantity, selector *metav1.LabelSelector) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ExternalMetricSourceType,
+		External: &autoscalingv2.ExternalMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name:     name,
+				Selector: selector,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:  autoscalingv2.ValueMetricType,
+				Value: &value,
+			},
+		},
+	}
+}
+
+// NewExternalAverageValueSpec builds an external metric spec targeting the value of the named metric divided by the
+// number of pods, the selector is optional and can be nil
+func NewExternalAverageValueSpec(name string, averageValue resource.Quantity,
+	selector *metav1.LabelSelector) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ExternalMetricSourceType,
+		External: &autoscalingv2.ExternalMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name:     name,
+				Selector: selector,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: &averageValue,
+			},
+		},
+	}
+}
+
+// ParseMetricSpecs parses autoscaling/v2 metric specs from YAML or JSON. The input can either be a list of metric
+// specs, or an object with a metrics field holding the list, matching the metrics section of a HorizontalPodAutoscaler
+// spec. Decoding is strict, unknown and duplicate fields are rejected, and any errors decoding a metric spec include its
+// index in the list. Empty input returns no metric specs.
+func ParseMetricSpecs(data []byte) ([]autoscalingv2.MetricSpec, error) {
+	jsonData, err := yaml.YAMLToJSONStrict(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metric specs: %w", err)
+	}
+
+	jsonData = bytes.TrimSpace(jsonData)
+	if len(jsonData) == 0 || bytes.Equal(jsonData, []byte("null")) {
+		return nil, nil
+	}
+
+	var rawSpecs []json.RawMessage
+	switch jsonData[0] {
+	case '[':
+		err = json.Unmarshal(jsonData, &rawSpecs)
+	case '{':
+		wrapper := struct {
+			Metrics []json.RawMessage `json:"metrics"`
+		}{}
+		err = decodeStrict(jsonData, &wrapper)
+		rawSpecs = wrapper.Metrics
+	default:
+		err = errors.New("must be a list of metric specs or an object with a metrics field")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid metric specs: %w", err)
+	}
+
+	specs := make([]autoscalingv2.MetricSpec, len(rawSpecs))
+	for i, rawSpec := range rawSpecs {
+		err = decodeStrict(rawSpec, &specs[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric spec at index %d: %w", i, err)
+		}
+	}
+
+	return specs, nil
+}
+
+// ValidateMetricSpec checks the metric spec provided can be gathered and evaluated, performing the same checks as
+// gathering and evaluating up front so invalid metric specs can be rejected before they are used. The metric source
+// for the spec's type must be set, its target type must be supported for the source type, the target field matching
+// the target type must be set, metric names and described objects must be set and any metric selectors must be
+// valid. Errors for invalid metric sources match ErrInvalidMetricSource, and errors for source types other than the
+// built in autoscaling/v2 source types match ErrUnknownMetricType; specs for source types registered with a Gatherer
+// using RegisterSource should be validated by the caller.
+func ValidateMetricSpec(spec autoscalingv2.MetricSpec) error {
+	switch spec.Type {
+	case autoscalingv2.ObjectMetricSourceType:
+		if spec.Object == nil {
+			return invalidMetricSource(spec.Type, "object must be set")
+		}
+		if spec.Object.DescribedObject.Kind == "" || spec.Object.DescribedObject.Name == "" {
+			return invalidMetricSource(spec.Type, "described object kind and name must be set")
+		}
+		err := validateMetricIdentifier(spec.Type, spec.Object.Metric)
+		if err != nil {
+			return err
+		}
+		return validateMetricTarget(spec.Type, spec.Object.Target, "must be either value or average value",
+			autoscalingv2.ValueMetricType, autoscalingv2.AverageValueMetricType)
+	case autoscalingv2.PodsMetricSourceType:
+		if spec.Pods == nil {
+			return invalidMetricSource(spec.Type, "pods must be set")
+		}
+		err := validateMetricIdentifier(spec.Type, spec.Pods.Metric)
+		if err != nil {
+			return err
+		}
+		return validateMetricTarget(spec.Type, spec.Pods.Target, "must be either value or average value",
+			autoscalingv2.ValueMetricType, autoscalingv2.AverageValueMetricType)
+	case autoscalingv2.ResourceMetricSourceType:
+		if spec.Resource == nil {
+			return invalidMetricSource(spec.Type, "resource must be set")
+		}
+		if spec.Resource.Name == "" {
+			return invalidMetricSource(spec.Type, "resource name must be set")
+		}
+		return validateMetricTarget(spec.Type, spec.Resource.Target,
+			"must be either value, average value or average utilization",
+			autoscalingv2.ValueMetricType, autoscalingv2.AverageValueMetricType, autoscalingv2.UtilizationMetricType)
+	case autoscalingv2.ExternalMetricSourceType:
+		if spec.External == nil {
+			return invalidMetricSource(spec.Type, "external must be set")
+		}
+		err := validateMetricIdentifier(spec.Type, spec.External.Metric)
+		if err != nil {
+			return err
+		}
+		return validateMetricTarget(spec.Type, spec.External.Target, "must be either value or average value",
+			autoscalingv2.ValueMetricType, autoscalingv2.AverageValueMetricType)
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownMetricType, string(spec.Type))
+	}
+}
+
+// ValidateMetric checks that a gathered metric provided from outside of the Gatherer, for example decoded from JSON,
+// has a valid metric spec and the values its evaluater requires, so that it can be rejected before it is evaluated.
+// Metrics for source types registered with an Evaluator using RegisterSource should be validated by the caller.
+func ValidateMetric(gatheredMetric *metrics.Metric) error {
+	if gatheredMetric == nil {
+		return errors.New("metric must not be null")
+	}
+
+	spec := gatheredMetric.Spec
+	if err := ValidateMetricSpec(spec); err != nil {
+		return err
+	}
+
+	switch spec.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if gatheredMetric.Resource == nil {
+			return errors.New("resource metric values must be set")
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if gatheredMetric.Pods == nil {
+			return errors.New("pods metric values must be set")
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if gatheredMetric.Object == nil {
+			return errors.New("object metric values must be set")
+		}
+		if spec.Object.Target.Type == autoscalingv2.ValueMetricType &&
+			(gatheredMetric.Object.Current.Value == nil || gatheredMetric.Object.ReadyPodCount == nil) {
+			return errors.New("object metric with a value target must set current value and ready pod count")
+		}
+		if spec.Object.Target.Type == autoscalingv2.AverageValueMetricType &&
+			gatheredMetric.Object.Current.AverageValue == nil {
+			return errors.New("object metric with an average value target must set current average value")
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if gatheredMetric.External == nil {
+			return errors.New("external metric values must be set")
+		}
+		if spec.External.Target.Type == autoscalingv2.ValueMetricType &&
+			(gatheredMetric.External.Current.Value == nil || gatheredMetric.External.ReadyPodCount == nil) {
+			return errors.New("external metric with a value target must set current value and ready pod count")
+		}
+		if spec.External.Target.Type == autoscalingv2.AverageValueMetricType &&
+			gatheredMetric.External.Current.AverageValue == nil {
+			return errors.New("external metric with an average value target must set current average value")
+		}
+	}
+
+	return nil
+}
+
+func validateMetricIdentifier(sourceType autoscalingv2.MetricSourceType, metric autoscalingv2.MetricIdentifier) error {
+	if metric.Name == "" {
+		return invalidMetricSource(sourceType, "metric name must be set")
+	}
+	_, err := metav1.LabelSelectorAsSelector(metric.Selector)
+	if err != nil {
+		return invalidMetricSource(sourceType, fmt.Sprintf("invalid metric selector: %s", err))
+	}
+	return nil
+}
+
+func validateMetricTarget(sourceType autoscalingv2.MetricSourceType, target autoscalingv2.MetricTarget,
+	invalidTypeReason string, targetTypes ...autoscalingv2.MetricTargetType) error {
+	if !slices.Contains(targetTypes, target.Type) {
+		return invalidMetricSource(sourceType, invalidTypeReason)
+	}
+	switch target.Type {
+	case autoscalingv2.ValueMetricType:
+		if target.Value == nil {
+			return invalidMetricSource(sourceType, "value target must set value")
+		}
+	case autoscalingv2.AverageValueMetricType:
+		if target.AverageValue == nil {
+			return invalidMetricSource(sourceType, "average value target must set averageValue")
+		}
+	case autoscalingv2.UtilizationMetricType:
+		if target.AverageUtilization == nil {
+			return invalidMetricSource(sourceType, "utilization target must set averageUtilization")
+		}
+	}
+	return nil
+}
+
+func invalidMetricSource(sourceType autoscalingv2.MetricSourceType, reason string) error {
+	return &metrics.InvalidMetricSourceError{
+		SourceType: sourceType,
+		Reason:     reason,
+	}
+}
+
+func decodeStrict(data []byte, target any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(target)
+}
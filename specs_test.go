@@ -0,0 +1,704 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8shorizmetrics_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	externalmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	objectmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/object"
+	podsmetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	resourcemetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseMetricSpecs(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	equateQuantity := cmp.Comparer(func(x, y resource.Quantity) bool {
+		return x.Cmp(y) == 0
+	})
+
+	cpuSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: corev1.ResourceCPU,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: testutil.Int32Ptr(50),
+			},
+		},
+	}
+
+	queueSpec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ExternalMetricSourceType,
+		External: &autoscalingv2.ExternalMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: "queue_length",
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"queue": "orders"},
+				},
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: resource.NewMilliQuantity(30000, resource.DecimalSI),
+			},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expected    []autoscalingv2.MetricSpec
+		expectedErr error
+		data        string
+	}{
+		{
+			description: "Empty input",
+			data:        "",
+		},
+		{
+			description: "Invalid YAML",
+			expectedErr: errors.New("invalid metric specs: yaml: line 1: did not find expected node content"),
+			data:        "[",
+		},
+		{
+			description: "Duplicate field",
+			expectedErr: errors.New(`invalid metric specs: yaml: unmarshal errors:
+  line 3: key "type" already set in map`),
+			data: `
+- type: Resource
+  type: Pods
+`,
+		},
+		{
+			description: "Not a list or object",
+			expectedErr: errors.New("invalid metric specs: must be a list of metric specs or an object with a metrics field"),
+			data:        "cpu",
+		},
+		{
+			description: "Unknown field in wrapping object",
+			expectedErr: errors.New(`invalid metric specs: json: unknown field "behavior"`),
+			data: `
+metrics: []
+behavior: {}
+`,
+		},
+		{
+			description: "Unknown field in metric spec",
+			expectedErr: errors.New(`invalid metric spec at index 1: json: unknown field "targetUtilization"`),
+			data: `
+- type: Resource
+  resource:
+    name: cpu
+    target:
+      type: Utilization
+      averageUtilization: 50
+- type: Resource
+  resource:
+    name: memory
+    targetUtilization: 50
+`,
+		},
+		{
+			description: "Metric spec not an object",
+			expectedErr: errors.New("invalid metric spec at index 0: json: cannot unmarshal string into Go value of type v2.MetricSpec"),
+			data: `
+- cpu
+`,
+		},
+		{
+			description: "YAML list",
+			expected:    []autoscalingv2.MetricSpec{cpuSpec, queueSpec},
+			data: `
+- type: Resource
+  resource:
+    name: cpu
+    target:
+      type: Utilization
+      averageUtilization: 50
+- type: External
+  external:
+    metric:
+      name: queue_length
+      selector:
+        matchLabels:
+          queue: orders
+    target:
+      type: AverageValue
+      averageValue: 30
+`,
+		},
+		{
+			description: "YAML object with metrics field",
+			expected:    []autoscalingv2.MetricSpec{cpuSpec},
+			data: `
+metrics:
+- type: Resource
+  resource:
+    name: cpu
+    target:
+      type: Utilization
+      averageUtilization: 50
+`,
+		},
+		{
+			description: "JSON list",
+			expected:    []autoscalingv2.MetricSpec{cpuSpec},
+			data:        `[{"type":"Resource","resource":{"name":"cpu","target":{"type":"Utilization","averageUtilization":50}}}]`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := k8shorizmetrics.ParseMetricSpecs([]byte(test.data))
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result, equateQuantity) {
+				t.Errorf("metric specs mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, equateQuantity))
+			}
+		})
+	}
+}
+
+func TestSpecBuilders(t *testing.T) {
+	equateQuantity := cmp.Comparer(func(x, y resource.Quantity) bool {
+		return x.Cmp(y) == 0
+	})
+
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"queue": "orders"},
+	}
+
+	describedObject := autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "networking.k8s.io/v1",
+		Kind:       "Ingress",
+		Name:       "main-route",
+	}
+
+	var tests = []struct {
+		description string
+		expected    autoscalingv2.MetricSpec
+		spec        autoscalingv2.MetricSpec
+	}{
+		{
+			description: "Resource utilization",
+			expected: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: testutil.Int32Ptr(50),
+					},
+				},
+			},
+			spec: k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceCPU, 50),
+		},
+		{
+			description: "Resource average value",
+			expected: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceMemory,
+					Target: autoscalingv2.MetricTarget{
+						Type:         autoscalingv2.AverageValueMetricType,
+						AverageValue: resource.NewQuantity(512*1024*1024, resource.BinarySI),
+					},
+				},
+			},
+			spec: k8shorizmetrics.NewResourceAverageValueSpec(corev1.ResourceMemory, resource.MustParse("512Mi")),
+		},
+		{
+			description: "Resource value",
+			expected: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{
+						Type:  autoscalingv2.ValueMetricType,
+						Value: resource.NewMilliQuantity(2000, resource.DecimalSI),
+					},
+				},
+			},
+			spec: k8shorizmetrics.NewResourceValueSpec(corev1.ResourceCPU, resource.MustParse("2")),
+		},
+		{
+			description: "Pods average value",
+			expected: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{
+						Name:     "packets_per_second",
+						Selector: selector,
+					},
+					Target: autoscalingv2.MetricTarget{
+						Type:         autoscalingv2.AverageValueMetricType,
+						AverageValue: resource.NewQuantity(1000, resource.DecimalSI),
+					},
+				},
+			},
+			spec: k8shorizmetrics.NewPodsAverageValueSpec("packets_per_second", resource.MustParse("1k"), selector),
+		},
+		{
+			description: "Pods value",
+			expected: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{
+						Name: "packets_per_second",
+					},
+					Target: autoscalingv2.MetricTarget{
+						Type:  autoscalingv2.ValueMetricType,
+						Value: resource.NewQuantity(5000, resource.DecimalSI),
+					},
+				},
+			},
+			spec: k8shorizmetrics.NewPodsValueSpec("packets_per_second", resource.MustParse("5k"), nil),
+		},
+		{
+			description: "Object value",
+			expected: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ObjectMetricSourceType,
+				Object: &autoscalingv2.ObjectMetricSource{
+					DescribedObject: describedObject,
+					Metric: autoscalingv2.MetricIdentifier{
+						Name: "requests_per_second",
+					},
+					Target: autoscalingv2.MetricTarget{
+						Type:  autoscalingv2.ValueMetricType,
+						Value: resource.NewQuantity(10000, resource.DecimalSI),
+					},
+				},
+			},
+			spec: k8shorizmetrics.NewObjectValueSpec(describedObject, "requests_per_second", resource.MustParse("10k"), nil),
+		},
+		{
+			description: "Object average value",
+			expected: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ObjectMetricSourceType,
+				Object: &autoscalingv2.ObjectMetricSource{
+					DescribedObject: describedObject,
+					Metric: autoscalingv2.MetricIdentifier{
+						Name:     "requests_per_second",
+						Selector: selector,
+					},
+					Target: autoscalingv2.MetricTarget{
+						Type:         autoscalingv2.AverageValueMetricType,
+						AverageValue: resource.NewQuantity(100, resource.DecimalSI),
+					},
+				},
+			},
+			spec: k8shorizmetrics.NewObjectAverageValueSpec(describedObject, "requests_per_second", resource.MustParse("100"),
+				selector),
+		},
+		{
+			description: "External value",
+			expected: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{
+						Name:     "queue_length",
+						Selector: selector,
+					},
+					Target: autoscalingv2.MetricTarget{
+						Type:  autoscalingv2.ValueMetricType,
+						Value: resource.NewQuantity(30, resource.DecimalSI),
+					},
+				},
+			},
+			spec: k8shorizmetrics.NewExternalValueSpec("queue_length", resource.MustParse("30"), selector),
+		},
+		{
+			description: "External average value",
+			expected: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{
+						Name: "queue_length",
+					},
+					Target: autoscalingv2.MetricTarget{
+						Type:         autoscalingv2.AverageValueMetricType,
+						AverageValue: resource.NewMilliQuantity(500, resource.DecimalSI),
+					},
+				},
+			},
+			spec: k8shorizmetrics.NewExternalAverageValueSpec("queue_length", resource.MustParse("500m"), nil),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			if !cmp.Equal(test.expected, test.spec, equateQuantity) {
+				t.Errorf("metric spec mismatch (-want +got):\n%s", cmp.Diff(test.expected, test.spec, equateQuantity))
+			}
+		})
+	}
+}
+
+func TestValidateMetricSpec(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	describedObject := autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "networking.k8s.io/v1",
+		Kind:       "Ingress",
+		Name:       "main-route",
+	}
+
+	invalidSelector := &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      "queue",
+				Operator: "invalid",
+			},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		expectedErr error
+		expectedIs  error
+		spec        autoscalingv2.MetricSpec
+	}{
+		{
+			description: "Unknown metric source type",
+			expectedErr: errors.New(`unknown metric source type "invalid"`),
+			expectedIs:  k8shorizmetrics.ErrUnknownMetricType,
+			spec: autoscalingv2.MetricSpec{
+				Type: "invalid",
+			},
+		},
+		{
+			description: "Object source not set",
+			expectedErr: errors.New("invalid object metric source: object must be set"),
+			expectedIs:  k8shorizmetrics.ErrInvalidMetricSource,
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ObjectMetricSourceType,
+			},
+		},
+		{
+			description: "Object described object not set",
+			expectedErr: errors.New("invalid object metric source: described object kind and name must be set"),
+			expectedIs:  k8shorizmetrics.ErrInvalidMetricSource,
+			spec: k8shorizmetrics.NewObjectValueSpec(autoscalingv2.CrossVersionObjectReference{}, "requests_per_second",
+				resource.MustParse("10"), nil),
+		},
+		{
+			description: "Object invalid target type",
+			expectedErr: errors.New("invalid object metric source: must be either value or average value"),
+			expectedIs:  k8shorizmetrics.ErrInvalidMetricSource,
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ObjectMetricSourceType,
+				Object: &autoscalingv2.ObjectMetricSource{
+					DescribedObject: describedObject,
+					Metric: autoscalingv2.MetricIdentifier{
+						Name: "requests_per_second",
+					},
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: testutil.Int32Ptr(50),
+					},
+				},
+			},
+		},
+		{
+			description: "Object valid",
+			spec: k8shorizmetrics.NewObjectAverageValueSpec(describedObject, "requests_per_second",
+				resource.MustParse("10"), nil),
+		},
+		{
+			description: "Pods source not set",
+			expectedErr: errors.New("invalid pods metric source: pods must be set"),
+			expectedIs:  k8shorizmetrics.ErrInvalidMetricSource,
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+			},
+		},
+		{
+			description: "Pods metric name not set",
+			expectedErr: errors.New("invalid pods metric source: metric name must be set"),
+			expectedIs:  k8shorizmetrics.ErrInvalidMetricSource,
+			spec:        k8shorizmetrics.NewPodsAverageValueSpec("", resource.MustParse("1k"), nil),
+		},
+		{
+			description: "Pods invalid metric selector",
+			expectedErr: errors.New(`invalid pods metric source: invalid metric selector: "invalid" is not a valid label selector operator`),
+			expectedIs:  k8shorizmetrics.ErrInvalidMetricSource,
+			spec:        k8shorizmetrics.NewPodsAverageValueSpec("packets_per_second", resource.MustParse("1k"), invalidSelector),
+		},
+		{
+			description: "Pods average value target without average value",
+			expectedErr: errors.New("invalid pods metric source: average value target must set averageValue"),
+			expectedIs:  k8shorizmetrics.ErrInvalidMetricSource,
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{
+						Name: "packets_per_second",
+					},
+					Target: autoscalingv2.MetricTarget{
+						Type: autoscalingv2.AverageValueMetricType,
+					},
+				},
+			},
+		},
+		{
+			description: "Pods valid",
+			spec:        k8shorizmetrics.NewPodsValueSpec("packets_per_second", resource.MustParse("5k"), nil),
+		},
+		{
+			description: "Resource source not set",
+			expectedErr: errors.New("invalid resource metric source: resource must be set"),
+			expectedIs:  k8shorizmetrics.ErrInvalidMetricSource,
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+			},
+		},
+		{
+			description: "Resource name not set",
+			expectedErr: errors.New("invalid resource metric source: resource name must be set"),
+			expectedIs:  k8shorizmetrics.ErrInvalidMetricSource,
+			spec:        k8shorizmetrics.NewResourceUtilizationSpec("", 50),
+		},
+		{
+			description: "Resource invalid target type",
+			expectedErr: errors.New("invalid resource metric source: must be either value, average value or average utilization"),
+			expectedIs:  k8shorizmetrics.ErrInvalidMetricSource,
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{
+						Type: "invalid",
+					},
+				},
+			},
+		},
+		{
+			description: "Resource utilization target without average utilization",
+			expectedErr: errors.New("invalid resource metric source: utilization target must set averageUtilization"),
+			expectedIs:  k8shorizmetrics.ErrInvalidMetricSource,
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{
+						Type: autoscalingv2.UtilizationMetricType,
+					},
+				},
+			},
+		},
+		{
+			description: "Resource valid",
+			spec:        k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceCPU, 50),
+		},
+		{
+			description: "External source not set",
+			expectedErr: errors.New("invalid external metric source: external must be set"),
+			expectedIs:  k8shorizmetrics.ErrInvalidMetricSource,
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ExternalMetricSourceType,
+			},
+		},
+		{
+			description: "External value target without value",
+			expectedErr: errors.New("invalid external metric source: value target must set value"),
+			expectedIs:  k8shorizmetrics.ErrInvalidMetricSource,
+			spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{
+						Name: "queue_length",
+					},
+					Target: autoscalingv2.MetricTarget{
+						Type: autoscalingv2.ValueMetricType,
+					},
+				},
+			},
+		},
+		{
+			description: "External valid",
+			spec:        k8shorizmetrics.NewExternalValueSpec("queue_length", resource.MustParse("30"), nil),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := k8shorizmetrics.ValidateMetricSpec(test.spec)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if test.expectedIs != nil && !errors.Is(err, test.expectedIs) {
+				t.Errorf("expected error to match %v", test.expectedIs)
+			}
+		})
+	}
+}
+
+func TestValidateMetric(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	describedObject := autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "networking.k8s.io/v1",
+		Kind:       "Ingress",
+		Name:       "main-route",
+	}
+	objectValueSpec := k8shorizmetrics.NewObjectValueSpec(describedObject, "requests", resource.MustParse("10"), nil)
+	objectAverageValueSpec := k8shorizmetrics.NewObjectAverageValueSpec(describedObject, "requests",
+		resource.MustParse("10"), nil)
+	externalValueSpec := k8shorizmetrics.NewExternalValueSpec("queue_length", resource.MustParse("30"), nil)
+	externalAverageValueSpec := k8shorizmetrics.NewExternalAverageValueSpec("queue_length", resource.MustParse("30"),
+		nil)
+
+	var tests = []struct {
+		description string
+		expectedErr error
+		metric      *metrics.Metric
+	}{
+		{
+			description: "Nil metric",
+			expectedErr: errors.New("metric must not be null"),
+		},
+		{
+			description: "Invalid spec",
+			expectedErr: errors.New(`unknown metric source type "invalid"`),
+			metric:      &metrics.Metric{Spec: autoscalingv2.MetricSpec{Type: "invalid"}},
+		},
+		{
+			description: "Resource values missing",
+			expectedErr: errors.New("resource metric values must be set"),
+			metric:      &metrics.Metric{Spec: k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceCPU, 50)},
+		},
+		{
+			description: "Resource valid",
+			metric: &metrics.Metric{
+				Spec:     k8shorizmetrics.NewResourceUtilizationSpec(corev1.ResourceCPU, 50),
+				Resource: &resourcemetrics.Metric{},
+			},
+		},
+		{
+			description: "Pods values missing",
+			expectedErr: errors.New("pods metric values must be set"),
+			metric:      &metrics.Metric{Spec: k8shorizmetrics.NewPodsAverageValueSpec("requests", resource.MustParse("10"), nil)},
+		},
+		{
+			description: "Pods valid",
+			metric: &metrics.Metric{
+				Spec: k8shorizmetrics.NewPodsAverageValueSpec("requests", resource.MustParse("10"), nil),
+				Pods: &podsmetrics.Metric{},
+			},
+		},
+		{
+			description: "Object values missing",
+			expectedErr: errors.New("object metric values must be set"),
+			metric:      &metrics.Metric{Spec: objectValueSpec},
+		},
+		{
+			description: "Object value target missing ready pod count",
+			expectedErr: errors.New("object metric with a value target must set current value and ready pod count"),
+			metric: &metrics.Metric{
+				Spec: objectValueSpec,
+				Object: &objectmetrics.Metric{
+					Current: value.MetricValue{Value: testutil.Int64Ptr(5)},
+				},
+			},
+		},
+		{
+			description: "Object average value target missing current average value",
+			expectedErr: errors.New("object metric with an average value target must set current average value"),
+			metric: &metrics.Metric{
+				Spec: objectAverageValueSpec,
+				Object: &objectmetrics.Metric{
+					Current: value.MetricValue{Value: testutil.Int64Ptr(5)},
+				},
+			},
+		},
+		{
+			description: "Object valid",
+			metric: &metrics.Metric{
+				Spec: objectValueSpec,
+				Object: &objectmetrics.Metric{
+					Current:       value.MetricValue{Value: testutil.Int64Ptr(5)},
+					ReadyPodCount: testutil.Int64Ptr(2),
+				},
+			},
+		},
+		{
+			description: "External values missing",
+			expectedErr: errors.New("external metric values must be set"),
+			metric:      &metrics.Metric{Spec: externalValueSpec},
+		},
+		{
+			description: "External value target missing current value",
+			expectedErr: errors.New("external metric with a value target must set current value and ready pod count"),
+			metric: &metrics.Metric{
+				Spec: externalValueSpec,
+				External: &externalmetrics.Metric{
+					ReadyPodCount: testutil.Int64Ptr(2),
+				},
+			},
+		},
+		{
+			description: "External average value target missing current average value",
+			expectedErr: errors.New("external metric with an average value target must set current average value"),
+			metric: &metrics.Metric{
+				Spec:     externalAverageValueSpec,
+				External: &externalmetrics.Metric{},
+			},
+		},
+		{
+			description: "External valid",
+			metric: &metrics.Metric{
+				Spec: externalAverageValueSpec,
+				External: &externalmetrics.Metric{
+					Current: value.MetricValue{AverageValue: testutil.Int64Ptr(5)},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := k8shorizmetrics.ValidateMetric(test.metric)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+			}
+		})
+	}
+}
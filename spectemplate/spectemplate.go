@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package spectemplate provides parameterized metric spec templates, allowing a list of metric specs to be defined
+// once with variables such as target utilization, metric names and selector values, and instantiated for each
+// workload at runtime.
+//
+// Templates are YAML or JSON lists of autoscaling/v2 metric specs using Go template syntax for variables, for
+// example:
+//
+//	# Target CPU utilization
+//	- type: Resource
+//	  resource:
+//	    name: cpu
+//	    target:
+//	      type: Utilization
+//	      averageUtilization: {{ .targetUtilization }}
+package spectemplate
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// Template is a parsed metric spec template, any variables not provided when instantiating the template are taken
+// from Defaults, if a variable is in neither instantiation fails
+type Template struct {
+	Defaults map[string]any
+	template *template.Template
+}
+
+// Parse parses the metric spec template provided
+func Parse(name string, text string) (*Template, error) {
+	parsed, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metric spec template: %w", err)
+	}
+
+	return &Template{
+		template: parsed,
+	}, nil
+}
+
+// Instantiate renders the template with the variables provided and parses the result into metric specs
+func (t *Template) Instantiate(variables map[string]any) ([]autoscalingv2.MetricSpec, error) {
+	data := map[string]any{}
+	for key, value := range t.Defaults {
+		data[key] = value
+	}
+	for key, value := range variables {
+		data[key] = value
+	}
+
+	var rendered bytes.Buffer
+	err := t.template.Execute(&rendered, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render metric spec template: %w", err)
+	}
+
+	var specs []autoscalingv2.MetricSpec
+	err = yaml.UnmarshalStrict(rendered.Bytes(), &specs)
+	if err != nil {
+		return nil, fmt.Errorf("rendered metric spec template is invalid: %w", err)
+	}
+
+	return specs, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spectemplate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/spectemplate"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const serviceTemplate = `
+- type: Resource
+  resource:
+    name: cpu
+    target:
+      type: Utilization
+      averageUtilization: {{ .targetUtilization }}
+- type: Pods
+  pods:
+    metric:
+      name: {{ .metricName }}
+      selector:
+        matchLabels:
+          service: {{ .service }}
+    target:
+      type: AverageValue
+      averageValue: {{ .targetValue }}
+`
+
+func TestParse(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	_, err := spectemplate.Parse("invalid", "{{ .unterminated")
+	expectedErr := errors.New(`invalid metric spec template: template: invalid:1: unclosed action`)
+	if !cmp.Equal(&err, &expectedErr, equateErrorMessage) {
+		t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(expectedErr, err, equateErrorMessage))
+	}
+}
+
+func TestInstantiate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	equateQuantity := cmp.Comparer(func(x, y resource.Quantity) bool { return x.Cmp(y) == 0 })
+
+	var tests = []struct {
+		description string
+		expected    []autoscalingv2.MetricSpec
+		expectedErr error
+		template    string
+		defaults    map[string]any
+		variables   map[string]any
+	}{
+		{
+			description: "Missing variable",
+			expectedErr: errors.New(`failed to render metric spec template: template: test:7:29: executing "test" at <.targetUtilization>: map has no entry for key "targetUtilization"`),
+			template:    serviceTemplate,
+			variables:   map[string]any{},
+		},
+		{
+			description: "Rendered template invalid",
+			expectedErr: errors.New(`rendered metric spec template is invalid: error unmarshaling JSON: while decoding JSON: json: unknown field "unknown"`),
+			template:    `[{"type": "Resource", "unknown": {{ .value }}}]`,
+			variables: map[string]any{
+				"value": 1,
+			},
+		},
+		{
+			description: "Variables with defaults",
+			expected: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: int32Ptr(70),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{
+							Name: "http_requests",
+							Selector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"service": "checkout",
+								},
+							},
+						},
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: resource.NewQuantity(100, resource.DecimalSI),
+						},
+					},
+				},
+			},
+			template: serviceTemplate,
+			defaults: map[string]any{
+				"targetUtilization": 50,
+				"metricName":        "http_requests",
+				"targetValue":       100,
+			},
+			variables: map[string]any{
+				"targetUtilization": 70,
+				"service":           "checkout",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			template, err := spectemplate.Parse("test", test.template)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			template.Defaults = test.defaults
+
+			result, err := template.Instantiate(test.variables)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result, equateQuantity) {
+				t.Errorf("metric specs mismatch (-want +got):\n%s", cmp.Diff(test.expected, result, equateQuantity))
+			}
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stabilization provides downscale stabilization in the same way the HPA does, keeping a history of replica
+// count recommendations for each target and only scaling down to the highest recommendation made within the
+// stabilization window. This damps scale downs caused by temporary dips in metrics, rather than instantly following
+// every dip.
+package stabilization
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/utils/clock"
+)
+
+// DefaultDownscaleWindow is the default downscale stabilization window, matches the HPA's
+// --horizontal-pod-autoscaler-downscale-stabilization default
+const DefaultDownscaleWindow = 5 * time.Minute
+
+// Recommendation is a replica count recommended at a point in time
+type Recommendation struct {
+	Timestamp time.Time `json:"timestamp"`
+	Replicas  int32     `json:"replicas"`
+}
+
+// Store persists the recommendation history of each target, keyed in the same way as the Stabilizer, so history can
+// be restored after a restart or by another replica
+type Store interface {
+	Load(ctx context.Context, key string) ([]Recommendation, error)
+	Save(ctx context.Context, key string, history []Recommendation) error
+}
+
+// Stabilizer keeps a history of recommendations for each target, keyed by a string identifying the target (see
+// TargetKey), and stabilizes new recommendations against that history. Recommendations older than the
+// DownscaleWindow are discarded. If Clock is nil the real clock is used.
+type Stabilizer struct {
+	DownscaleWindow time.Duration
+	Clock           clock.PassiveClock
+
+	mu      sync.Mutex
+	history map[string][]Recommendation
+}
+
+// NewStabilizer sets up a Stabilizer using the HPA's default downscale stabilization window
+func NewStabilizer() *Stabilizer {
+	return &Stabilizer{
+		DownscaleWindow: DefaultDownscaleWindow,
+		Clock:           clock.RealClock{},
+	}
+}
+
+// Stabilize records the recommendation for the target and returns the stabilized recommendation, which is the highest
+// recommendation for the target within the downscale window, including the recommendation provided. Scale ups are
+// never delayed, while scale downs only happen once every recommendation in the window allows them.
+func (s *Stabilizer) Stabilize(key string, recommendation int32) int32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock().Now()
+	cutoff := now.Add(-s.DownscaleWindow)
+
+	if s.history == nil {
+		s.history = map[string][]Recommendation{}
+	}
+
+	stabilized := recommendation
+	history := []Recommendation{}
+	for _, previous := range s.history[key] {
+		if !previous.Timestamp.After(cutoff) {
+			continue
+		}
+		history = append(history, previous)
+		if previous.Replicas > stabilized {
+			stabilized = previous.Replicas
+		}
+	}
+
+	s.history[key] = append(history, Recommendation{
+		Timestamp: now,
+		Replicas:  recommendation,
+	})
+
+	return stabilized
+}
+
+func (s *Stabilizer) clock() clock.PassiveClock {
+	if s.Clock == nil {
+		return clock.RealClock{}
+	}
+	return s.Clock
+}
+
+// History returns the recommendations recorded for the target, oldest first
+func (s *Stabilizer) History(key string) []Recommendation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history := make([]Recommendation, len(s.history[key]))
+	copy(history, s.history[key])
+	return history
+}
+
+// SetHistory replaces the recommendations recorded for the target, for example to restore history saved to a Store
+func (s *Stabilizer) SetHistory(key string, history []Recommendation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.history == nil {
+		s.history = map[string][]Recommendation{}
+	}
+
+	s.history[key] = make([]Recommendation, len(history))
+	copy(s.history[key], history)
+}
+
+// Forget discards the recommendations recorded for the target, for example when the target is deleted
+func (s *Stabilizer) Forget(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.history, key)
+}
+
+// TargetKey returns a key identifying the scale target in the namespace provided, for use with the Stabilizer
+func TargetKey(namespace string, scaleTargetRef autoscalingv2.CrossVersionObjectReference) string {
+	return fmt.Sprintf("%s/%s/%s/%s", scaleTargetRef.APIVersion, scaleTargetRef.Kind, namespace, scaleTargetRef.Name)
+}
+
+// Evaluator wraps an Evaluator, stabilizing its evaluations for each target using the Stabilizer
+type Evaluator struct {
+	Evaluator  *k8shorizmetrics.Evaluator
+	Stabilizer *Stabilizer
+}
+
+// Evaluate returns the stabilized target replica count for the target identified by the key provided based on the
+// metrics provided
+func (e *Evaluator) Evaluate(key string, gatheredMetrics []*metrics.Metric, currentReplicas int32) (int32, error) {
+	return e.EvaluateWithContext(context.Background(), key, gatheredMetrics, currentReplicas)
+}
+
+// EvaluateWithContext returns the stabilized target replica count for the target identified by the key provided
+// based on the metrics provided, passing the context provided to the Evaluator
+func (e *Evaluator) EvaluateWithContext(ctx context.Context, key string, gatheredMetrics []*metrics.Metric,
+	currentReplicas int32) (int32, error) {
+	recommendation, err := e.Evaluator.EvaluateWithContext(ctx, gatheredMetrics, currentReplicas)
+	if err != nil {
+		return 0, err
+	}
+
+	return e.Stabilizer.Stabilize(key, recommendation), nil
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stabilization_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/fake"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/stabilization"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+type step struct {
+	advance        time.Duration
+	key            string
+	recommendation int32
+	expected       int32
+}
+
+func TestStabilizer_Stabilize(t *testing.T) {
+	var tests = []struct {
+		description     string
+		downscaleWindow time.Duration
+		steps           []step
+	}{
+		{
+			description:     "First recommendation, no history",
+			downscaleWindow: 5 * time.Minute,
+			steps: []step{
+				{key: "test", recommendation: 3, expected: 3},
+			},
+		},
+		{
+			description:     "Scale up immediately",
+			downscaleWindow: 5 * time.Minute,
+			steps: []step{
+				{key: "test", recommendation: 3, expected: 3},
+				{advance: time.Minute, key: "test", recommendation: 6, expected: 6},
+				{advance: time.Minute, key: "test", recommendation: 10, expected: 10},
+			},
+		},
+		{
+			description:     "Scale down held at highest recommendation in window",
+			downscaleWindow: 5 * time.Minute,
+			steps: []step{
+				{key: "test", recommendation: 10, expected: 10},
+				{advance: time.Minute, key: "test", recommendation: 4, expected: 10},
+				{advance: time.Minute, key: "test", recommendation: 2, expected: 10},
+				{advance: 3 * time.Minute, key: "test", recommendation: 2, expected: 4},
+				{advance: time.Minute, key: "test", recommendation: 3, expected: 3},
+			},
+		},
+		{
+			description:     "Temporary dip ignored",
+			downscaleWindow: 5 * time.Minute,
+			steps: []step{
+				{key: "test", recommendation: 5, expected: 5},
+				{advance: time.Minute, key: "test", recommendation: 1, expected: 5},
+				{advance: time.Minute, key: "test", recommendation: 5, expected: 5},
+			},
+		},
+		{
+			description:     "Zero window, follow every recommendation",
+			downscaleWindow: 0,
+			steps: []step{
+				{key: "test", recommendation: 10, expected: 10},
+				{advance: time.Second, key: "test", recommendation: 1, expected: 1},
+			},
+		},
+		{
+			description:     "Separate history per target",
+			downscaleWindow: 5 * time.Minute,
+			steps: []step{
+				{key: "first", recommendation: 10, expected: 10},
+				{advance: time.Minute, key: "second", recommendation: 2, expected: 2},
+				{advance: time.Minute, key: "first", recommendation: 2, expected: 10},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakePassiveClock(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+			stabilizer := &stabilization.Stabilizer{
+				DownscaleWindow: test.downscaleWindow,
+				Clock:           fakeClock,
+			}
+			for i, step := range test.steps {
+				fakeClock.SetTime(fakeClock.Now().Add(step.advance))
+				result := stabilizer.Stabilize(step.key, step.recommendation)
+				if result != step.expected {
+					t.Errorf("step %d stabilized recommendation mismatch, want %d, got %d", i, step.expected, result)
+				}
+			}
+		})
+	}
+}
+
+func TestStabilizer_HistoryAndForget(t *testing.T) {
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clocktesting.NewFakePassiveClock(now)
+	stabilizer := &stabilization.Stabilizer{
+		DownscaleWindow: 5 * time.Minute,
+		Clock:           fakeClock,
+	}
+
+	stabilizer.Stabilize("test", 4)
+	fakeClock.SetTime(now.Add(time.Minute))
+	stabilizer.Stabilize("test", 2)
+
+	expected := []stabilization.Recommendation{
+		{Timestamp: now, Replicas: 4},
+		{Timestamp: now.Add(time.Minute), Replicas: 2},
+	}
+	history := stabilizer.History("test")
+	if !cmp.Equal(expected, history) {
+		t.Errorf("history mismatch (-want +got):\n%s", cmp.Diff(expected, history))
+	}
+
+	// Recommendations outside of the window are discarded
+	fakeClock.SetTime(now.Add(6 * time.Minute))
+	stabilizer.Stabilize("test", 1)
+	expected = []stabilization.Recommendation{
+		{Timestamp: now.Add(6 * time.Minute), Replicas: 1},
+	}
+	history = stabilizer.History("test")
+	if !cmp.Equal(expected, history) {
+		t.Errorf("history mismatch after window (-want +got):\n%s", cmp.Diff(expected, history))
+	}
+
+	stabilizer.Forget("test")
+	if history := stabilizer.History("test"); len(history) != 0 {
+		t.Errorf("expected history to be forgotten, got %v", history)
+	}
+}
+
+func TestStabilizer_ZeroValue(t *testing.T) {
+	stabilizer := &stabilization.Stabilizer{
+		DownscaleWindow: 5 * time.Minute,
+	}
+
+	if result := stabilizer.Stabilize("test", 4); result != 4 {
+		t.Errorf("expected 4, got %d", result)
+	}
+	if result := stabilizer.Stabilize("test", 2); result != 4 {
+		t.Errorf("expected 4, got %d", result)
+	}
+}
+
+func TestStabilizer_SetHistory(t *testing.T) {
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clocktesting.NewFakePassiveClock(now.Add(time.Minute))
+	stabilizer := &stabilization.Stabilizer{
+		DownscaleWindow: 5 * time.Minute,
+		Clock:           fakeClock,
+	}
+
+	stabilizer.Stabilize("test", 1)
+	stabilizer.SetHistory("test", []stabilization.Recommendation{
+		{Timestamp: now, Replicas: 4},
+	})
+
+	// Restored history replaces the existing history and is used to stabilize new recommendations
+	if result := stabilizer.Stabilize("test", 2); result != 4 {
+		t.Errorf("stabilized mismatch, want 4, got %d", result)
+	}
+
+	expected := []stabilization.Recommendation{
+		{Timestamp: now, Replicas: 4},
+		{Timestamp: now.Add(time.Minute), Replicas: 2},
+	}
+	history := stabilizer.History("test")
+	if !cmp.Equal(expected, history) {
+		t.Errorf("history mismatch (-want +got):\n%s", cmp.Diff(expected, history))
+	}
+}
+
+func TestTargetKey(t *testing.T) {
+	result := stabilization.TargetKey("test-namespace", autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "test-deployment",
+	})
+	expected := "apps/v1/Deployment/test-namespace/test-deployment"
+	if result != expected {
+		t.Errorf("key mismatch, want %s, got %s", expected, result)
+	}
+}
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	gatheredMetrics := []*metrics.Metric{
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+			},
+		},
+	}
+
+	var tests = []struct {
+		description     string
+		expected        []int32
+		expectedErr     error
+		recommendations []int32
+		err             error
+	}{
+		{
+			description:     "Fail to evaluate",
+			expected:        []int32{0},
+			expectedErr:     errors.New("evaluator multi metric error: 1 errors, first error is fail to evaluate"),
+			recommendations: []int32{0},
+			err:             errors.New("fail to evaluate"),
+		},
+		{
+			description:     "Scale down stabilized",
+			expected:        []int32{8, 8, 8},
+			expectedErr:     nil,
+			recommendations: []int32{8, 3, 5},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			evaluation := 0
+			evaluator := &stabilization.Evaluator{
+				Evaluator: &k8shorizmetrics.Evaluator{
+					Resource: &fake.ResourceEvaluater{
+						EvaluateReactor: func(currentReplicas int32, gatheredMetric *metrics.Metric, tolerance float64) (int32, error) {
+							recommendation := test.recommendations[evaluation]
+							evaluation++
+							return recommendation, test.err
+						},
+					},
+				},
+				Stabilizer: &stabilization.Stabilizer{
+					DownscaleWindow: 5 * time.Minute,
+					Clock:           clocktesting.NewFakePassiveClock(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			}
+
+			var err error
+			for i := range test.recommendations {
+				var result int32
+				result, err = evaluator.Evaluate("test", gatheredMetrics, 5)
+				if err == nil && result != test.expected[i] {
+					t.Errorf("evaluation %d mismatch, want %d, got %d", i, test.expected[i], result)
+				}
+			}
+
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Modifications Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+
+Modified to produce HPA conditions from k8shorizmetrics gather and evaluation results.
+Original source:
+https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscaler/horizontal.go
+*/
+
+package status
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/events"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition reasons used by the HPA
+const (
+	ReasonSucceededRescale                 = "SucceededRescale"
+	ReasonFailedUpdateScale                = "FailedUpdateScale"
+	ReasonReadyForNewScale                 = string(k8shorizmetrics.ReasonReadyForNewScale)
+	ReasonScalingDisabled                  = "ScalingDisabled"
+	ReasonValidMetricFound                 = "ValidMetricFound"
+	ReasonInvalidMetricSourceType          = "InvalidMetricSourceType"
+	ReasonFailedGetResourceMetric          = "FailedGetResourceMetric"
+	ReasonFailedGetContainerResourceMetric = "FailedGetContainerResourceMetric"
+	ReasonFailedGetPodsMetric              = "FailedGetPodsMetric"
+	ReasonFailedGetObjectMetric            = "FailedGetObjectMetric"
+	ReasonFailedGetExternalMetric          = "FailedGetExternalMetric"
+	ReasonTooFewReplicas                   = string(k8shorizmetrics.ReasonTooFewReplicas)
+	ReasonTooManyReplicas                  = string(k8shorizmetrics.ReasonTooManyReplicas)
+	ReasonDesiredWithinRange               = string(k8shorizmetrics.ReasonDesiredWithinRange)
+)
+
+// ConditionInput is the result of a single autoscaling run used to produce the HPA's conditions. GatherResults are
+// the results of gathering each metric spec, Evaluation is the detailed evaluation of the metrics that were gathered
+// and Limited is the evaluation limited to the minimum and maximum replica counts. ScaleErr is any error that occurred
+// updating the scale target's replica count.
+type ConditionInput struct {
+	GatherResults   []*k8shorizmetrics.GatherResult
+	Evaluation      *k8shorizmetrics.DetailedEvaluation
+	Limited         *k8shorizmetrics.LimitedEvaluation
+	CurrentReplicas int32
+	MinReplicas     int32
+	ScaleErr        error
+}
+
+// Conditions produces the AbleToScale, ScalingActive and ScalingLimited conditions the HPA would report for the
+// autoscaling run provided, with the same reasons and messages, using the time provided as the last transition time.
+// As with the HPA, ScalingLimited is only included if scaling is active and a limited evaluation is provided.
+func Conditions(input *ConditionInput, now metav1.Time) []autoscalingv2.HorizontalPodAutoscalerCondition {
+	scalingActive := scalingActiveCondition(input, now)
+	active := scalingActive.Status == corev1.ConditionTrue
+
+	conditions := []autoscalingv2.HorizontalPodAutoscalerCondition{
+		ableToScaleCondition(input, active, now),
+		scalingActive,
+	}
+
+	if active && input.Limited != nil {
+		conditions = append(conditions, scalingLimitedCondition(input.Limited, now))
+	}
+
+	return conditions
+}
+
+// SetConditions updates the existing conditions provided with the updated conditions, replacing any condition of the
+// same type. As with the HPA, if a condition's status has not changed its existing last transition time is kept.
+func SetConditions(existing []autoscalingv2.HorizontalPodAutoscalerCondition,
+	updated ...autoscalingv2.HorizontalPodAutoscalerCondition) []autoscalingv2.HorizontalPodAutoscalerCondition {
+	conditions := make([]autoscalingv2.HorizontalPodAutoscalerCondition, len(existing))
+	copy(conditions, existing)
+
+	for _, condition := range updated {
+		found := false
+		for i, existingCondition := range conditions {
+			if existingCondition.Type != condition.Type {
+				continue
+			}
+			found = true
+			if existingCondition.Status == condition.Status {
+				condition.LastTransitionTime = existingCondition.LastTransitionTime
+			}
+			conditions[i] = condition
+			break
+		}
+		if !found {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	return conditions
+}
+
+func ableToScaleCondition(input *ConditionInput, active bool,
+	now metav1.Time) autoscalingv2.HorizontalPodAutoscalerCondition {
+	if input.ScaleErr != nil {
+		return condition(autoscalingv2.AbleToScale, corev1.ConditionFalse, ReasonFailedUpdateScale, now,
+			"the HPA controller was unable to update the target scale: %v", input.ScaleErr)
+	}
+
+	// If no replica count could be calculated the HPA does not rescale
+	desiredReplicas := input.CurrentReplicas
+	if active && input.Limited != nil {
+		desiredReplicas = input.Limited.Replicas
+	} else if active && input.Evaluation != nil {
+		desiredReplicas = input.Evaluation.Replicas
+	}
+
+	if desiredReplicas == input.CurrentReplicas {
+		return condition(autoscalingv2.AbleToScale, corev1.ConditionTrue, ReasonReadyForNewScale, now,
+			"%s", k8shorizmetrics.ReasonReadyForNewScale.Message())
+	}
+
+	return condition(autoscalingv2.AbleToScale, corev1.ConditionTrue, ReasonSucceededRescale, now,
+		"the HPA controller was able to update the target scale to %d", desiredReplicas)
+}
+
+func scalingActiveCondition(input *ConditionInput, now metav1.Time) autoscalingv2.HorizontalPodAutoscalerCondition {
+	if input.CurrentReplicas == 0 && input.MinReplicas != 0 {
+		return condition(autoscalingv2.ScalingActive, corev1.ConditionFalse, ReasonScalingDisabled, now,
+			"scaling is disabled since the replica count of the target is zero")
+	}
+
+	var failedReason string
+	var failedErr error
+	fail := func(spec autoscalingv2.MetricSpec, err error) {
+		if failedErr == nil {
+			failedReason = failedMetricReason(spec, err)
+			failedErr = err
+		}
+	}
+
+	for _, result := range input.GatherResults {
+		if result.Err != nil {
+			fail(result.Spec, result.Err)
+		}
+	}
+
+	var validMetric *k8shorizmetrics.MetricEvaluation
+	if input.Evaluation != nil {
+		for _, metricEvaluation := range input.Evaluation.Metrics {
+			if metricEvaluation.Err != nil {
+				fail(metricEvaluation.Metric.Spec, metricEvaluation.Err)
+				continue
+			}
+			if validMetric == nil || (metricEvaluation.Winning && !validMetric.Winning) {
+				validMetric = metricEvaluation
+			}
+		}
+	}
+
+	if validMetric == nil {
+		if failedErr == nil {
+			failedReason = ReasonInvalidMetricSourceType
+			failedErr = errors.New("no metrics to evaluate")
+		}
+		return condition(autoscalingv2.ScalingActive, corev1.ConditionFalse, failedReason, now,
+			"the HPA was unable to compute the replica count: %v", failedErr)
+	}
+
+	return condition(autoscalingv2.ScalingActive, corev1.ConditionTrue, ReasonValidMetricFound, now,
+		"the HPA was able to successfully calculate a replica count from %s", events.MetricName(validMetric.Metric.Spec))
+}
+
+func scalingLimitedCondition(limited *k8shorizmetrics.LimitedEvaluation,
+	now metav1.Time) autoscalingv2.HorizontalPodAutoscalerCondition {
+	reason := limited.Reason()
+	conditionStatus := corev1.ConditionFalse
+	if reason.Limiting() {
+		conditionStatus = corev1.ConditionTrue
+	}
+	return condition(autoscalingv2.ScalingLimited, conditionStatus, string(reason), now, "%s",
+		reason.Message())
+}
+
+func failedMetricReason(spec autoscalingv2.MetricSpec, err error) string {
+	if errors.Is(err, k8shorizmetrics.ErrUnknownMetricType) {
+		return ReasonInvalidMetricSourceType
+	}
+	switch spec.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		return ReasonFailedGetResourceMetric
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		return ReasonFailedGetContainerResourceMetric
+	case autoscalingv2.PodsMetricSourceType:
+		return ReasonFailedGetPodsMetric
+	case autoscalingv2.ObjectMetricSourceType:
+		return ReasonFailedGetObjectMetric
+	case autoscalingv2.ExternalMetricSourceType:
+		return ReasonFailedGetExternalMetric
+	}
+	return ReasonInvalidMetricSourceType
+}
+
+func condition(conditionType autoscalingv2.HorizontalPodAutoscalerConditionType, status corev1.ConditionStatus,
+	reason string, now metav1.Time, message string, args ...any) autoscalingv2.HorizontalPodAutoscalerCondition {
+	return autoscalingv2.HorizontalPodAutoscalerCondition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            fmt.Sprintf(message, args...),
+	}
+}
@@ -0,0 +1,391 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/status"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	cpuSpec = autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: corev1.ResourceCPU,
+		},
+	}
+	podsSpec = autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "requests-per-second"},
+		},
+	}
+)
+
+func TestConditions(t *testing.T) {
+	now := metav1.NewTime(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	var tests = []struct {
+		description string
+		expected    []autoscalingv2.HorizontalPodAutoscalerCondition
+		input       *status.ConditionInput
+	}{
+		{
+			description: "Scaling disabled",
+			expected: []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.AbleToScale,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             status.ReasonReadyForNewScale,
+					Message:            "recommended size matches current size",
+				},
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: now,
+					Reason:             status.ReasonScalingDisabled,
+					Message:            "scaling is disabled since the replica count of the target is zero",
+				},
+			},
+			input: &status.ConditionInput{
+				CurrentReplicas: 0,
+				MinReplicas:     1,
+				Limited:         &k8shorizmetrics.LimitedEvaluation{Replicas: 0},
+			},
+		},
+		{
+			description: "No metrics",
+			expected: []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.AbleToScale,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             status.ReasonReadyForNewScale,
+					Message:            "recommended size matches current size",
+				},
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: now,
+					Reason:             status.ReasonInvalidMetricSourceType,
+					Message:            "the HPA was unable to compute the replica count: no metrics to evaluate",
+				},
+			},
+			input: &status.ConditionInput{
+				CurrentReplicas: 3,
+				MinReplicas:     1,
+			},
+		},
+		{
+			description: "All metrics fail to gather",
+			expected: []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.AbleToScale,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             status.ReasonReadyForNewScale,
+					Message:            "recommended size matches current size",
+				},
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: now,
+					Reason:             status.ReasonFailedGetPodsMetric,
+					Message:            "the HPA was unable to compute the replica count: fail to gather pods",
+				},
+			},
+			input: &status.ConditionInput{
+				GatherResults: []*k8shorizmetrics.GatherResult{
+					{Spec: podsSpec, Err: errors.New("fail to gather pods")},
+					{Spec: cpuSpec, Err: errors.New("fail to gather cpu")},
+				},
+				Evaluation:      &k8shorizmetrics.DetailedEvaluation{},
+				CurrentReplicas: 3,
+				MinReplicas:     1,
+			},
+		},
+		{
+			description: "Unknown metric source type fails",
+			expected: []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.AbleToScale,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             status.ReasonReadyForNewScale,
+					Message:            "recommended size matches current size",
+				},
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: now,
+					Reason:             status.ReasonInvalidMetricSourceType,
+					Message:            `the HPA was unable to compute the replica count: unknown metric source type "invalid"`,
+				},
+			},
+			input: &status.ConditionInput{
+				GatherResults: []*k8shorizmetrics.GatherResult{
+					{
+						Spec: autoscalingv2.MetricSpec{Type: "invalid"},
+						Err:  fmt.Errorf("%w %q", k8shorizmetrics.ErrUnknownMetricType, "invalid"),
+					},
+				},
+				CurrentReplicas: 3,
+				MinReplicas:     1,
+			},
+		},
+		{
+			description: "All metrics fail to evaluate",
+			expected: []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.AbleToScale,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             status.ReasonReadyForNewScale,
+					Message:            "recommended size matches current size",
+				},
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: now,
+					Reason:             status.ReasonFailedGetResourceMetric,
+					Message:            "the HPA was unable to compute the replica count: fail to evaluate",
+				},
+			},
+			input: &status.ConditionInput{
+				Evaluation: &k8shorizmetrics.DetailedEvaluation{
+					Metrics: []*k8shorizmetrics.MetricEvaluation{
+						{Metric: &metrics.Metric{Spec: cpuSpec}, Err: errors.New("fail to evaluate")},
+					},
+				},
+				CurrentReplicas: 3,
+				MinReplicas:     1,
+			},
+		},
+		{
+			description: "Scaled, partial failure, within range",
+			expected: []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.AbleToScale,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             status.ReasonSucceededRescale,
+					Message:            "the HPA controller was able to update the target scale to 5",
+				},
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             status.ReasonValidMetricFound,
+					Message:            "the HPA was able to successfully calculate a replica count from pods metric requests-per-second",
+				},
+				{
+					Type:               autoscalingv2.ScalingLimited,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: now,
+					Reason:             status.ReasonDesiredWithinRange,
+					Message:            "the desired count is within the acceptable range",
+				},
+			},
+			input: &status.ConditionInput{
+				GatherResults: []*k8shorizmetrics.GatherResult{
+					{Spec: cpuSpec, Err: errors.New("fail to gather cpu")},
+				},
+				Evaluation: &k8shorizmetrics.DetailedEvaluation{
+					Replicas: 5,
+					Metrics: []*k8shorizmetrics.MetricEvaluation{
+						{Metric: &metrics.Metric{Spec: cpuSpec}, Replicas: 2},
+						{Metric: &metrics.Metric{Spec: podsSpec}, Replicas: 5, Winning: true},
+					},
+				},
+				Limited:         &k8shorizmetrics.LimitedEvaluation{Replicas: 5, UnlimitedReplicas: 5},
+				CurrentReplicas: 3,
+				MinReplicas:     1,
+			},
+		},
+		{
+			description: "Limited to maximum, failed to scale",
+			expected: []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.AbleToScale,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: now,
+					Reason:             status.ReasonFailedUpdateScale,
+					Message:            "the HPA controller was unable to update the target scale: fail to scale",
+				},
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             status.ReasonValidMetricFound,
+					Message:            "the HPA was able to successfully calculate a replica count from cpu resource utilization (percentage of request)",
+				},
+				{
+					Type:               autoscalingv2.ScalingLimited,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             status.ReasonTooManyReplicas,
+					Message:            "the desired replica count is more than the maximum replica count",
+				},
+			},
+			input: &status.ConditionInput{
+				Evaluation: &k8shorizmetrics.DetailedEvaluation{
+					Replicas: 20,
+					Metrics: []*k8shorizmetrics.MetricEvaluation{
+						{Metric: &metrics.Metric{Spec: cpuSpec}, Replicas: 20, Winning: true},
+					},
+				},
+				Limited: &k8shorizmetrics.LimitedEvaluation{
+					Replicas:          10,
+					UnlimitedReplicas: 20,
+					Direction:         k8shorizmetrics.LimitDirectionLowered,
+				},
+				CurrentReplicas: 3,
+				MinReplicas:     1,
+				ScaleErr:        errors.New("fail to scale"),
+			},
+		},
+		{
+			description: "Limited to minimum, at minimum",
+			expected: []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.AbleToScale,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             status.ReasonReadyForNewScale,
+					Message:            "recommended size matches current size",
+				},
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             status.ReasonValidMetricFound,
+					Message:            "the HPA was able to successfully calculate a replica count from cpu resource utilization (percentage of request)",
+				},
+				{
+					Type:               autoscalingv2.ScalingLimited,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             status.ReasonTooFewReplicas,
+					Message:            "the desired replica count is less than the minimum replica count",
+				},
+			},
+			input: &status.ConditionInput{
+				Evaluation: &k8shorizmetrics.DetailedEvaluation{
+					Replicas: 1,
+					Metrics: []*k8shorizmetrics.MetricEvaluation{
+						{Metric: &metrics.Metric{Spec: cpuSpec}, Replicas: 1, Winning: true},
+					},
+				},
+				Limited: &k8shorizmetrics.LimitedEvaluation{
+					Replicas:          2,
+					UnlimitedReplicas: 1,
+					Direction:         k8shorizmetrics.LimitDirectionRaised,
+				},
+				CurrentReplicas: 2,
+				MinReplicas:     2,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := status.Conditions(test.input, now)
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("conditions mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestSetConditions(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(earlier.Add(time.Minute))
+
+	existing := []autoscalingv2.HorizontalPodAutoscalerCondition{
+		{
+			Type:               autoscalingv2.AbleToScale,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: earlier,
+			Reason:             status.ReasonReadyForNewScale,
+		},
+		{
+			Type:               autoscalingv2.ScalingActive,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: earlier,
+			Reason:             status.ReasonFailedGetResourceMetric,
+		},
+	}
+
+	expected := []autoscalingv2.HorizontalPodAutoscalerCondition{
+		{
+			Type:               autoscalingv2.AbleToScale,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: earlier,
+			Reason:             status.ReasonSucceededRescale,
+		},
+		{
+			Type:               autoscalingv2.ScalingActive,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: now,
+			Reason:             status.ReasonValidMetricFound,
+		},
+		{
+			Type:               autoscalingv2.ScalingLimited,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: now,
+			Reason:             status.ReasonDesiredWithinRange,
+		},
+	}
+
+	result := status.SetConditions(existing,
+		autoscalingv2.HorizontalPodAutoscalerCondition{
+			Type:               autoscalingv2.AbleToScale,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: now,
+			Reason:             status.ReasonSucceededRescale,
+		},
+		autoscalingv2.HorizontalPodAutoscalerCondition{
+			Type:               autoscalingv2.ScalingActive,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: now,
+			Reason:             status.ReasonValidMetricFound,
+		},
+		autoscalingv2.HorizontalPodAutoscalerCondition{
+			Type:               autoscalingv2.ScalingLimited,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: now,
+			Reason:             status.ReasonDesiredWithinRange,
+		},
+	)
+
+	if !cmp.Equal(expected, result) {
+		t.Errorf("conditions mismatch (-want +got):\n%s", cmp.Diff(expected, result))
+	}
+	if existing[0].Reason != status.ReasonReadyForNewScale {
+		t.Errorf("existing conditions were modified")
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Modifications Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+
+Modified to produce HPA metric statuses from gathered k8shorizmetrics metrics.
+Original source:
+https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/podautoscaler/horizontal.go
+*/
+
+// Package status produces the same status fields the Horizontal Pod Autoscaler controller populates, allowing custom
+// autoscalers to report status in a familiar format, for example populating status.currentMetrics on a custom
+// resource with the autoscalingv2.MetricStatus values the HPA would report, and status.conditions with the
+// AbleToScale, ScalingActive and ScalingLimited conditions it would report.
+package status
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/jthomperoo/k8shorizmetrics/v4"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	resourcemetrics "github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metricsclient"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// MetricStatuses converts the gathered metrics provided into the metric statuses the HPA would report for them,
+// returned in the same order. As with the HPA, if the status of a metric cannot be determined an empty metric status
+// is included in its place, and an error is returned describing every metric that failed.
+func MetricStatuses(gatheredMetrics []*metrics.Metric, currentReplicas int32) ([]autoscalingv2.MetricStatus, error) {
+	statuses := make([]autoscalingv2.MetricStatus, len(gatheredMetrics))
+	var statusErrors []error
+	for i, gatheredMetric := range gatheredMetrics {
+		status, err := MetricStatus(gatheredMetric, currentReplicas)
+		if err != nil {
+			statusErrors = append(statusErrors, fmt.Errorf("failed to get status for metric %d: %w", i, err))
+			continue
+		}
+		statuses[i] = *status
+	}
+	return statuses, errors.Join(statusErrors...)
+}
+
+// MetricStatus converts the gathered metric provided into the metric status the HPA would report for it. Gathered
+// values are milli-values, so current quantities are reported as milli-quantities, and average values for object and
+// external metrics are the total value divided between the current replicas.
+func MetricStatus(gatheredMetric *metrics.Metric, currentReplicas int32) (*autoscalingv2.MetricStatus, error) {
+	switch gatheredMetric.Spec.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		return resourceStatus(gatheredMetric)
+	case autoscalingv2.PodsMetricSourceType:
+		return podsStatus(gatheredMetric)
+	case autoscalingv2.ObjectMetricSourceType:
+		return objectStatus(gatheredMetric, currentReplicas)
+	case autoscalingv2.ExternalMetricSourceType:
+		return externalStatus(gatheredMetric, currentReplicas)
+	}
+	return nil, fmt.Errorf("%w %q", k8shorizmetrics.ErrUnknownMetricType, string(gatheredMetric.Spec.Type))
+}
+
+func resourceStatus(gatheredMetric *metrics.Metric) (*autoscalingv2.MetricStatus, error) {
+	if gatheredMetric.Spec.Resource == nil || gatheredMetric.Resource == nil {
+		return nil, invalidSource(autoscalingv2.ResourceMetricSourceType, "no resource metric was gathered")
+	}
+
+	target := gatheredMetric.Spec.Resource.Target
+	current := autoscalingv2.MetricValueStatus{}
+
+	if target.Value != nil {
+		if len(gatheredMetric.Resource.PodMetricsInfo) == 0 {
+			return nil, fmt.Errorf("%w for resource metric", metricsclient.ErrNoMetrics)
+		}
+		total := int64(0)
+		for _, podMetric := range gatheredMetric.Resource.PodMetricsInfo {
+			total += podMetric.Value
+		}
+		current.Value = resource.NewMilliQuantity(total, resource.DecimalSI)
+	} else if target.AverageValue != nil {
+		if len(gatheredMetric.Resource.PodMetricsInfo) == 0 {
+			return nil, fmt.Errorf("%w for resource metric", metricsclient.ErrNoMetrics)
+		}
+		_, averageValue := metricsclient.GetMetricUtilizationRatio(gatheredMetric.Resource.PodMetricsInfo,
+			target.AverageValue.MilliValue())
+		current.AverageValue = resource.NewMilliQuantity(averageValue, resource.DecimalSI)
+	} else if target.AverageUtilization != nil {
+		utilization, averageValue, err := resourceUtilization(gatheredMetric.Resource)
+		if err != nil {
+			return nil, err
+		}
+		current.AverageUtilization = &utilization
+		current.AverageValue = resource.NewMilliQuantity(averageValue, resource.DecimalSI)
+	} else {
+		return nil, invalidSource(autoscalingv2.ResourceMetricSourceType,
+			"neither a utilization target nor a value target was set")
+	}
+
+	return &autoscalingv2.MetricStatus{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricStatus{
+			Name:    gatheredMetric.Spec.Resource.Name,
+			Current: current,
+		},
+	}, nil
+}
+
+// resourceUtilization returns the current utilization and raw average value of a resource metric, using the values
+// computed when gathering if set and otherwise calculating them from the pod metrics
+func resourceUtilization(gatheredMetric *resourcemetrics.Metric) (int32, int64, error) {
+	if gatheredMetric.CurrentUtilization != nil && gatheredMetric.CurrentAverageValue != nil {
+		return *gatheredMetric.CurrentUtilization, *gatheredMetric.CurrentAverageValue, nil
+	}
+	return metricsclient.GetResourceUtilization(gatheredMetric.PodMetricsInfo, gatheredMetric.UtilizationBase())
+}
+
+func podsStatus(gatheredMetric *metrics.Metric) (*autoscalingv2.MetricStatus, error) {
+	if gatheredMetric.Spec.Pods == nil || gatheredMetric.Pods == nil {
+		return nil, invalidSource(autoscalingv2.PodsMetricSourceType, "no pods metric was gathered")
+	}
+
+	if gatheredMetric.Spec.Pods.Target.AverageValue == nil {
+		return nil, invalidSource(autoscalingv2.PodsMetricSourceType, "no average value target was set")
+	}
+
+	if len(gatheredMetric.Pods.PodMetricsInfo) == 0 {
+		return nil, fmt.Errorf("%w for pods metric", metricsclient.ErrNoMetrics)
+	}
+
+	_, averageValue := metricsclient.GetMetricUtilizationRatio(gatheredMetric.Pods.PodMetricsInfo,
+		gatheredMetric.Spec.Pods.Target.AverageValue.MilliValue())
+
+	return &autoscalingv2.MetricStatus{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricStatus{
+			Metric: gatheredMetric.Spec.Pods.Metric,
+			Current: autoscalingv2.MetricValueStatus{
+				AverageValue: resource.NewMilliQuantity(averageValue, resource.DecimalSI),
+			},
+		},
+	}, nil
+}
+
+func objectStatus(gatheredMetric *metrics.Metric, currentReplicas int32) (*autoscalingv2.MetricStatus, error) {
+	if gatheredMetric.Spec.Object == nil || gatheredMetric.Object == nil {
+		return nil, invalidSource(autoscalingv2.ObjectMetricSourceType, "no object metric was gathered")
+	}
+
+	current, err := valueStatus(autoscalingv2.ObjectMetricSourceType, gatheredMetric.Spec.Object.Target,
+		gatheredMetric.Object.Current, currentReplicas)
+	if err != nil {
+		return nil, err
+	}
+
+	return &autoscalingv2.MetricStatus{
+		Type: autoscalingv2.ObjectMetricSourceType,
+		Object: &autoscalingv2.ObjectMetricStatus{
+			DescribedObject: gatheredMetric.Spec.Object.DescribedObject,
+			Metric:          gatheredMetric.Spec.Object.Metric,
+			Current:         *current,
+		},
+	}, nil
+}
+
+func externalStatus(gatheredMetric *metrics.Metric, currentReplicas int32) (*autoscalingv2.MetricStatus, error) {
+	if gatheredMetric.Spec.External == nil || gatheredMetric.External == nil {
+		return nil, invalidSource(autoscalingv2.ExternalMetricSourceType, "no external metric was gathered")
+	}
+
+	current, err := valueStatus(autoscalingv2.ExternalMetricSourceType, gatheredMetric.Spec.External.Target,
+		gatheredMetric.External.Current, currentReplicas)
+	if err != nil {
+		return nil, err
+	}
+
+	return &autoscalingv2.MetricStatus{
+		Type: autoscalingv2.ExternalMetricSourceType,
+		External: &autoscalingv2.ExternalMetricStatus{
+			Metric:  gatheredMetric.Spec.External.Metric,
+			Current: *current,
+		},
+	}, nil
+}
+
+func valueStatus(sourceType autoscalingv2.MetricSourceType, target autoscalingv2.MetricTarget,
+	current value.MetricValue, currentReplicas int32) (*autoscalingv2.MetricValueStatus, error) {
+	switch target.Type {
+	case autoscalingv2.ValueMetricType:
+		if current.Value == nil {
+			return nil, invalidSource(sourceType, "no value was gathered for a value target")
+		}
+		return &autoscalingv2.MetricValueStatus{
+			Value: resource.NewMilliQuantity(*current.Value, resource.DecimalSI),
+		}, nil
+	case autoscalingv2.AverageValueMetricType:
+		if current.AverageValue == nil {
+			return nil, invalidSource(sourceType, "no average value was gathered for an average value target")
+		}
+		if currentReplicas <= 0 {
+			return nil, fmt.Errorf("cannot calculate average value with %d current replicas", currentReplicas)
+		}
+		averageValue := int64(math.Ceil(float64(*current.AverageValue) / float64(currentReplicas)))
+		return &autoscalingv2.MetricValueStatus{
+			AverageValue: resource.NewMilliQuantity(averageValue, resource.DecimalSI),
+		}, nil
+	}
+	return nil, invalidSource(sourceType, "neither a value target nor an average value target was set")
+}
+
+func invalidSource(sourceType autoscalingv2.MetricSourceType, reason string) error {
+	return &metrics.InvalidMetricSourceError{
+		SourceType: sourceType,
+		Reason:     reason,
+	}
+}
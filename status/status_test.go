@@ -0,0 +1,469 @@
+/*
+Copyright 2026 The K8sHorizMetrics Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jthomperoo/k8shorizmetrics/v4/internal/testutil"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/external"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/object"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/podmetrics"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/pods"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/resource"
+	"github.com/jthomperoo/k8shorizmetrics/v4/metrics/value"
+	"github.com/jthomperoo/k8shorizmetrics/v4/status"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestMetricStatus(t *testing.T) {
+	equateErrorMessage := cmp.Comparer(func(x, y error) bool {
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Error() == y.Error()
+	})
+
+	var tests = []struct {
+		description     string
+		expected        *autoscalingv2.MetricStatus
+		expectedErr     error
+		gatheredMetric  *metrics.Metric
+		currentReplicas int32
+	}{
+		{
+			description:    "Unknown metric source type",
+			expected:       nil,
+			expectedErr:    errors.New(`unknown metric source type "invalid"`),
+			gatheredMetric: &metrics.Metric{Spec: autoscalingv2.MetricSpec{Type: "invalid"}},
+		},
+		{
+			description: "Resource, no gathered metric",
+			expected:    nil,
+			expectedErr: errors.New("invalid resource metric source: no resource metric was gathered"),
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type:     autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{},
+				},
+			},
+		},
+		{
+			description: "Resource, no target",
+			expected:    nil,
+			expectedErr: errors.New("invalid resource metric source: neither a utilization target nor a value target was set"),
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type:     autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{},
+				},
+				Resource: &resource.Metric{},
+			},
+		},
+		{
+			description: "Resource, average value target, no metrics",
+			expected:    nil,
+			expectedErr: errors.New("no metrics returned for resource metric"),
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceMemory,
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: k8sresource.NewMilliQuantity(500, k8sresource.DecimalSI),
+						},
+					},
+				},
+				Resource: &resource.Metric{},
+			},
+		},
+		{
+			description: "Resource, average value target",
+			expected: &autoscalingv2.MetricStatus{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricStatus{
+					Name: corev1.ResourceMemory,
+					Current: autoscalingv2.MetricValueStatus{
+						AverageValue: k8sresource.NewMilliQuantity(300, k8sresource.DecimalSI),
+					},
+				},
+			},
+			expectedErr: nil,
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceMemory,
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: k8sresource.NewMilliQuantity(500, k8sresource.DecimalSI),
+						},
+					},
+				},
+				Resource: &resource.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 200},
+						"pod-2": podmetrics.Metric{Value: 400},
+					},
+				},
+			},
+		},
+		{
+			description: "Resource, value target",
+			expected: &autoscalingv2.MetricStatus{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricStatus{
+					Name: corev1.ResourceMemory,
+					Current: autoscalingv2.MetricValueStatus{
+						Value: k8sresource.NewMilliQuantity(600, k8sresource.DecimalSI),
+					},
+				},
+			},
+			expectedErr: nil,
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceMemory,
+						Target: autoscalingv2.MetricTarget{
+							Type:  autoscalingv2.ValueMetricType,
+							Value: k8sresource.NewMilliQuantity(500, k8sresource.DecimalSI),
+						},
+					},
+				},
+				Resource: &resource.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 200},
+						"pod-2": podmetrics.Metric{Value: 400},
+					},
+				},
+			},
+		},
+		{
+			description: "Resource, utilization target, no requests",
+			expected:    nil,
+			expectedErr: errors.New("no metrics returned matched known pods"),
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: testutil.Int32Ptr(50),
+						},
+					},
+				},
+				Resource: &resource.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 200},
+					},
+				},
+			},
+		},
+		{
+			description: "Resource, utilization target",
+			expected: &autoscalingv2.MetricStatus{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricStatus{
+					Name: corev1.ResourceCPU,
+					Current: autoscalingv2.MetricValueStatus{
+						AverageUtilization: testutil.Int32Ptr(60),
+						AverageValue:       k8sresource.NewMilliQuantity(300, k8sresource.DecimalSI),
+					},
+				},
+			},
+			expectedErr: nil,
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: testutil.Int32Ptr(50),
+						},
+					},
+				},
+				Resource: &resource.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 200},
+						"pod-2": podmetrics.Metric{Value: 400},
+					},
+					Requests: map[string]int64{
+						"pod-1": 500,
+						"pod-2": 500,
+					},
+				},
+			},
+		},
+		{
+			description: "Resource, utilization target, computed when gathered",
+			expected: &autoscalingv2.MetricStatus{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricStatus{
+					Name: corev1.ResourceCPU,
+					Current: autoscalingv2.MetricValueStatus{
+						AverageUtilization: testutil.Int32Ptr(70),
+						AverageValue:       k8sresource.NewMilliQuantity(350, k8sresource.DecimalSI),
+					},
+				},
+			},
+			expectedErr: nil,
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: testutil.Int32Ptr(50),
+						},
+					},
+				},
+				Resource: &resource.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 200},
+						"pod-2": podmetrics.Metric{Value: 400},
+					},
+					Requests: map[string]int64{
+						"pod-1": 500,
+						"pod-2": 500,
+					},
+					CurrentUtilization:  testutil.Int32Ptr(70),
+					CurrentAverageValue: testutil.Int64Ptr(350),
+				},
+			},
+		},
+		{
+			description: "Pods, no average value target",
+			expected:    nil,
+			expectedErr: errors.New("invalid pods metric source: no average value target was set"),
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{},
+				},
+				Pods: &pods.Metric{},
+			},
+		},
+		{
+			description: "Pods, average value target",
+			expected: &autoscalingv2.MetricStatus{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricStatus{
+					Metric: autoscalingv2.MetricIdentifier{Name: "requests-per-second"},
+					Current: autoscalingv2.MetricValueStatus{
+						AverageValue: k8sresource.NewMilliQuantity(1500, k8sresource.DecimalSI),
+					},
+				},
+			},
+			expectedErr: nil,
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{Name: "requests-per-second"},
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: k8sresource.NewQuantity(1, k8sresource.DecimalSI),
+						},
+					},
+				},
+				Pods: &pods.Metric{
+					PodMetricsInfo: podmetrics.MetricsInfo{
+						"pod-1": podmetrics.Metric{Value: 1000},
+						"pod-2": podmetrics.Metric{Value: 2000},
+					},
+				},
+			},
+		},
+		{
+			description: "Object, value target",
+			expected: &autoscalingv2.MetricStatus{
+				Type: autoscalingv2.ObjectMetricSourceType,
+				Object: &autoscalingv2.ObjectMetricStatus{
+					DescribedObject: autoscalingv2.CrossVersionObjectReference{Kind: "Ingress", Name: "test"},
+					Metric:          autoscalingv2.MetricIdentifier{Name: "hits"},
+					Current: autoscalingv2.MetricValueStatus{
+						Value: k8sresource.NewMilliQuantity(5000, k8sresource.DecimalSI),
+					},
+				},
+			},
+			expectedErr: nil,
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ObjectMetricSourceType,
+					Object: &autoscalingv2.ObjectMetricSource{
+						DescribedObject: autoscalingv2.CrossVersionObjectReference{Kind: "Ingress", Name: "test"},
+						Metric:          autoscalingv2.MetricIdentifier{Name: "hits"},
+						Target: autoscalingv2.MetricTarget{
+							Type:  autoscalingv2.ValueMetricType,
+							Value: k8sresource.NewQuantity(2, k8sresource.DecimalSI),
+						},
+					},
+				},
+				Object: &object.Metric{
+					Current: value.MetricValue{Value: testutil.Int64Ptr(5000)},
+				},
+			},
+		},
+		{
+			description: "Object, average value target, no current replicas",
+			expected:    nil,
+			expectedErr: errors.New("cannot calculate average value with 0 current replicas"),
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ObjectMetricSourceType,
+					Object: &autoscalingv2.ObjectMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: k8sresource.NewQuantity(2, k8sresource.DecimalSI),
+						},
+					},
+				},
+				Object: &object.Metric{
+					Current: value.MetricValue{AverageValue: testutil.Int64Ptr(5000)},
+				},
+			},
+			currentReplicas: 0,
+		},
+		{
+			description: "Object, average value target, missing value",
+			expected:    nil,
+			expectedErr: errors.New("invalid object metric source: no average value was gathered for an average value target"),
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ObjectMetricSourceType,
+					Object: &autoscalingv2.ObjectMetricSource{
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: k8sresource.NewQuantity(2, k8sresource.DecimalSI),
+						},
+					},
+				},
+				Object: &object.Metric{},
+			},
+			currentReplicas: 3,
+		},
+		{
+			description: "External, average value target",
+			expected: &autoscalingv2.MetricStatus{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricStatus{
+					Metric: autoscalingv2.MetricIdentifier{Name: "queue-length"},
+					Current: autoscalingv2.MetricValueStatus{
+						AverageValue: k8sresource.NewMilliQuantity(1667, k8sresource.DecimalSI),
+					},
+				},
+			},
+			expectedErr: nil,
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{Name: "queue-length"},
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: k8sresource.NewQuantity(2, k8sresource.DecimalSI),
+						},
+					},
+				},
+				External: &external.Metric{
+					Current: value.MetricValue{AverageValue: testutil.Int64Ptr(5000)},
+				},
+			},
+			currentReplicas: 3,
+		},
+		{
+			description: "External, no target",
+			expected:    nil,
+			expectedErr: errors.New("invalid external metric source: neither a value target nor an average value target was set"),
+			gatheredMetric: &metrics.Metric{
+				Spec: autoscalingv2.MetricSpec{
+					Type:     autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{},
+				},
+				External: &external.Metric{},
+			},
+			currentReplicas: 3,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := status.MetricStatus(test.gatheredMetric, test.currentReplicas)
+			if !cmp.Equal(&err, &test.expectedErr, equateErrorMessage) {
+				t.Errorf("error mismatch (-want +got):\n%s", cmp.Diff(test.expectedErr, err, equateErrorMessage))
+				return
+			}
+			if !cmp.Equal(test.expected, result) {
+				t.Errorf("status mismatch (-want +got):\n%s", cmp.Diff(test.expected, result))
+			}
+		})
+	}
+}
+
+func TestMetricStatuses(t *testing.T) {
+	gatheredMetrics := []*metrics.Metric{
+		{
+			Spec: autoscalingv2.MetricSpec{Type: "invalid"},
+		},
+		{
+			Spec: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{Name: "queue-length"},
+					Target: autoscalingv2.MetricTarget{
+						Type:  autoscalingv2.ValueMetricType,
+						Value: k8sresource.NewQuantity(2, k8sresource.DecimalSI),
+					},
+				},
+			},
+			External: &external.Metric{
+				Current: value.MetricValue{Value: testutil.Int64Ptr(5000)},
+			},
+		},
+	}
+
+	expected := []autoscalingv2.MetricStatus{
+		{},
+		{
+			Type: autoscalingv2.ExternalMetricSourceType,
+			External: &autoscalingv2.ExternalMetricStatus{
+				Metric: autoscalingv2.MetricIdentifier{Name: "queue-length"},
+				Current: autoscalingv2.MetricValueStatus{
+					Value: k8sresource.NewMilliQuantity(5000, k8sresource.DecimalSI),
+				},
+			},
+		},
+	}
+
+	result, err := status.MetricStatuses(gatheredMetrics, 3)
+	expectedErr := `failed to get status for metric 0: unknown metric source type "invalid"`
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("error mismatch, want %q, got %v", expectedErr, err)
+	}
+	if !cmp.Equal(expected, result) {
+		t.Errorf("statuses mismatch (-want +got):\n%s", cmp.Diff(expected, result))
+	}
+}